@@ -2,7 +2,9 @@ package alert
 
 import (
 	"crypto/sha512"
+	"database/sql"
 	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
@@ -13,6 +15,10 @@ import (
 const (
 	MaxSummaryLength = 1024     // 1KiB
 	MaxDetailsLength = 6 * 1024 // 6KiB
+
+	MaxDedupWindow = 7 * 24 * time.Hour
+
+	MaxCorrelationKeyLength = 255
 )
 
 // An Alert represents an ongoing situation.
@@ -22,9 +28,33 @@ type Alert struct {
 	Summary   string    `json:"summary"`
 	Details   string    `json:"details"`
 	Source    Source    `json:"source"`
+	Severity  Severity  `json:"severity"`
 	ServiceID string    `json:"service_id"`
 	CreatedAt time.Time `json:"created_at"`
 	Dedup     *DedupID  `json:"dedup"`
+
+	// Meta is optional structured context provided when the alert is created.
+	Meta Meta `json:"meta,omitempty"`
+
+	// IntegrationKeyID is the integration key the alert was created through, if any. It is
+	// set automatically from the request source when the alert is created.
+	IntegrationKeyID string `json:"integration_key_id,omitempty"`
+
+	// CorrelationKey, if set, links the alert to alerts of other services with the same key,
+	// like those caused by a shared outage. Correlated alerts are otherwise independent.
+	CorrelationKey string `json:"correlation_key,omitempty"`
+
+	// Occurrences is the number of events that have been de-duplicated into this alert,
+	// including the one that created it. LastOccurrence is the time of the most recent one.
+	Occurrences    int       `json:"occurrences"`
+	LastOccurrence time.Time `json:"last_occurrence"`
+
+	// DedupWindow, if non-zero, will treat a new alert as a duplicate of one
+	// with the same dedup key closed within the window, instead of creating a new alert.
+	// An open alert that has not occurred within the window is closed and a new one created.
+	//
+	// If zero, the dedup window of the integration key is used, if it has one.
+	DedupWindow time.Duration `json:"-"`
 }
 
 // DedupKey will return the de-duplication key for the alert.
@@ -45,7 +75,21 @@ func (a *Alert) DedupKey() *DedupID {
 }
 
 func (a *Alert) scanFrom(scanFn func(...interface{}) error) error {
-	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup)
+	var ikeyID, corrKey sql.NullString
+	err := scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup, &a.Occurrences, &a.LastOccurrence, &a.Severity, &a.Meta, &ikeyID, &corrKey)
+	a.IntegrationKeyID = ikeyID.String
+	a.CorrelationKey = corrKey.String
+	return err
+}
+
+// OccurrenceSummary returns a short description of how many times the alert has
+// occurred (e.g., "×3 occurrences"), or an empty string if it has only occurred once.
+func (a *Alert) OccurrenceSummary() string {
+	if a.Occurrences <= 1 {
+		return ""
+	}
+
+	return fmt.Sprintf("×%d occurrences", a.Occurrences)
 }
 
 func (a Alert) Normalize() (*Alert, error) {
@@ -55,14 +99,22 @@ func (a Alert) Normalize() (*Alert, error) {
 	if string(a.Status) == "" {
 		a.Status = StatusTriggered
 	}
+	if a.Severity == "" {
+		a.Severity = DefaultSeverity
+	}
 	a.Summary = strings.Replace(a.Summary, "\n", " ", -1)
 	a.Summary = strings.Replace(a.Summary, "  ", " ", -1)
+	a.CorrelationKey = strings.TrimSpace(a.CorrelationKey)
 	err := validate.Many(
 		validate.Text("Summary", a.Summary, 1, MaxSummaryLength),
 		validate.Text("Details", a.Details, 0, MaxDetailsLength),
-		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric),
+		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric, SourceOpsGenie),
 		validate.OneOf("Status", a.Status, StatusTriggered, StatusActive, StatusClosed),
+		validate.OneOf("Severity", a.Severity, SeverityInfo, SeverityWarning, SeverityCritical, SeverityFatal),
 		validate.UUID("ServiceID", a.ServiceID),
+		validate.Duration("DedupWindow", a.DedupWindow, 0, MaxDedupWindow),
+		validateMeta("Meta", a.Meta),
+		validate.Text("CorrelationKey", a.CorrelationKey, 1, MaxCorrelationKeyLength),
 	)
 	if err != nil {
 		return nil, err
@@ -71,6 +123,11 @@ func (a Alert) Normalize() (*Alert, error) {
 	return &a, nil
 }
 
+// correlationKeyArg will return the CorrelationKey for use as a query argument.
+func (a Alert) correlationKeyArg() sql.NullString {
+	return sql.NullString{String: a.CorrelationKey, Valid: a.CorrelationKey != ""}
+}
+
 func (a Alert) Description() string {
 	if a.Details == "" {
 		return a.Summary
@@ -2,6 +2,7 @@ package alert
 
 import (
 	"testing"
+	"time"
 )
 
 func TestAlert_Normalize(t *testing.T) {
@@ -24,9 +25,12 @@ func TestAlert_Normalize(t *testing.T) {
 
 	valid := []Alert{
 		{Summary: "Sample First Alert", Source: SourceManual, Status: StatusTriggered, ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6"},
+		{Summary: "Sample First Alert", Source: SourceGeneric, Status: StatusTriggered, ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", DedupWindow: time.Hour},
 	}
 	invalid := []Alert{
 		{ServiceID: "e93facc0-4764-012d-7bfb"},
+		{Summary: "Sample First Alert", Source: SourceGeneric, Status: StatusTriggered, ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", DedupWindow: 30 * 24 * time.Hour},
+		{Summary: "Sample First Alert", Source: SourceGeneric, Status: StatusTriggered, ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Severity: "high"},
 	}
 	for _, a := range valid {
 		test(true, a)
@@ -35,3 +39,18 @@ func TestAlert_Normalize(t *testing.T) {
 		test(false, a)
 	}
 }
+
+func TestAlert_OccurrenceSummary(t *testing.T) {
+	check := func(n int, exp string) {
+		t.Helper()
+		a := Alert{Occurrences: n}
+		if got := a.OccurrenceSummary(); got != exp {
+			t.Errorf("OccurrenceSummary() with %d occurrences = %q; want %q", n, got, exp)
+		}
+	}
+
+	check(0, "")
+	check(1, "")
+	check(2, "×2 occurrences")
+	check(42, "×42 occurrences")
+}
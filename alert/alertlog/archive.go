@@ -0,0 +1,109 @@
+package alertlog
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivedEntriesJSON is a SQL expression that captures the log entries of an alert
+// (referenced as `a`) as a JSONB array, newest first. Subject names are resolved at
+// archive time, since the subjects may be deleted later.
+//
+// The result can be read with ParseArchivedEntries.
+const ArchivedEntriesJSON = `
+	coalesce((
+		select jsonb_agg(jsonb_build_object(
+			'id', log.id,
+			'alert_id', log.alert_id,
+			'timestamp', log.timestamp,
+			'event', log.event,
+			'message', log.message,
+			'sub_type', log.sub_type,
+			'sub_user_id', log.sub_user_id,
+			'sub_user_name', u.name,
+			'sub_integration_key_id', log.sub_integration_key_id,
+			'sub_integration_key_name', i.name,
+			'sub_hb_monitor_id', log.sub_hb_monitor_id,
+			'sub_hb_monitor_name', hb.name,
+			'sub_channel_id', log.sub_channel_id,
+			'sub_channel_name', nc.name,
+			'sub_classifier', log.sub_classifier,
+			'meta', log.meta
+		) order by log.id desc)
+		from alert_logs log
+		left join users u on u.id = log.sub_user_id
+		left join integration_keys i on i.id = log.sub_integration_key_id
+		left join heartbeat_monitors hb on hb.id = log.sub_hb_monitor_id
+		left join notification_channels nc on nc.id = log.sub_channel_id
+		where log.alert_id = a.id
+	), '[]'::jsonb)
+`
+
+type archivedEntry struct {
+	ID        int       `json:"id"`
+	AlertID   int       `json:"alert_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Event     Type      `json:"event"`
+	Message   string    `json:"message"`
+
+	SubType               *SubjectType  `json:"sub_type"`
+	SubUserID             uuid.NullUUID `json:"sub_user_id"`
+	SubUserName           *string       `json:"sub_user_name"`
+	SubIntegrationKeyID   uuid.NullUUID `json:"sub_integration_key_id"`
+	SubIntegrationKeyName *string       `json:"sub_integration_key_name"`
+	SubHBMonitorID        uuid.NullUUID `json:"sub_hb_monitor_id"`
+	SubHBMonitorName      *string       `json:"sub_hb_monitor_name"`
+	SubChannelID          uuid.NullUUID `json:"sub_channel_id"`
+	SubChannelName        *string       `json:"sub_channel_name"`
+	SubClassifier         string        `json:"sub_classifier"`
+
+	Meta json.RawMessage `json:"meta"`
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *s, Valid: true}
+}
+
+// ParseArchivedEntries will parse log entries captured with ArchivedEntriesJSON.
+func ParseArchivedEntries(data []byte) ([]Entry, error) {
+	var raw []archivedEntry
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse archived log entries: %w", err)
+	}
+
+	entries := make([]Entry, len(raw))
+	for i, r := range raw {
+		e := &entries[i]
+		e.id = r.ID
+		e.alertID = r.AlertID
+		e.timestamp = r.Timestamp
+		e._type = r.Event
+		e.message = r.Message
+		if r.SubType != nil {
+			e.subject._type = *r.SubType
+		}
+		e.subject.userID = r.SubUserID
+		e.subject.userName = nullString(r.SubUserName)
+		e.subject.integrationKeyID = r.SubIntegrationKeyID
+		e.subject.integrationKeyName = nullString(r.SubIntegrationKeyName)
+		e.subject.heartbeatMonitorID = r.SubHBMonitorID
+		e.subject.heartbeatMonitorName = nullString(r.SubHBMonitorName)
+		e.subject.channelID = r.SubChannelID
+		e.subject.channelName = nullString(r.SubChannelName)
+		e.subject.classifier = r.SubClassifier
+		if string(r.Meta) != "null" {
+			e.meta = rawJSON(r.Meta)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,29 @@
+package alertlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArchivedEntries(t *testing.T) {
+	data := []byte(`[
+		{"id": 2, "alert_id": 1, "timestamp": "2023-10-11T10:05:00.123456+00:00", "event": "closed", "message": "", "sub_type": "integration_key", "sub_integration_key_id": "e93facc0-4764-012d-7bfb-002500d5d1a6", "sub_integration_key_name": "Prometheus", "sub_classifier": "", "meta": null},
+		{"id": 1, "alert_id": 1, "timestamp": "2023-10-11T10:00:00+00:00", "event": "created", "message": "", "sub_type": null, "sub_classifier": "", "meta": {"EPNoSteps": true}}
+	]`)
+
+	entries, err := ParseArchivedEntries(data)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, 2, entries[0].ID())
+	assert.Equal(t, TypeClosed, entries[0].Type())
+	assert.Equal(t, &Subject{ID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Name: "Prometheus", Type: SubjectTypeIntegrationKey}, entries[0].Subject())
+	assert.Nil(t, entries[0].Meta(context.Background()))
+
+	assert.Equal(t, TypeCreated, entries[1].Type())
+	assert.Nil(t, entries[1].Subject())
+	assert.Equal(t, &CreatedMetaData{EPNoSteps: true}, entries[1].Meta(context.Background()))
+}
@@ -0,0 +1,82 @@
+package alertlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// EventChannel is the NOTIFY channel used to broadcast alert state changes (created,
+// acknowledged, closed, and escalated log entries).
+const EventChannel = "/goalert/alert-events"
+
+// subBufferSize is the number of events buffered for each subscriber.
+const subBufferSize = 32
+
+// Event is an alert state change.
+type Event struct {
+	AlertID int  `json:"alert_id"`
+	Type    Type `json:"event"`
+}
+
+// ParseEvent parses the payload of an EventChannel notification.
+func ParseEvent(payload string) (*Event, error) {
+	var e Event
+	err := json.Unmarshal([]byte(payload), &e)
+	if err != nil {
+		return nil, fmt.Errorf("parse alert event: %w", err)
+	}
+	if e.AlertID == 0 || e.Type == "" {
+		return nil, fmt.Errorf("parse alert event: missing alert_id or event")
+	}
+
+	return &e, nil
+}
+
+// Broker fans out alert events to subscribers within a single instance.
+type Broker struct {
+	mx   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+// NewBroker returns a new Broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan Event]struct{})}
+}
+
+// Subscribe returns a channel of events published after the call. The channel is
+// closed, and the subscription removed, once ctx is done.
+//
+// Events are dropped for subscribers that are not keeping up, rather than blocking
+// other subscribers.
+func (b *Broker) Subscribe(ctx context.Context) <-chan Event {
+	ch := make(chan Event, subBufferSize)
+
+	b.mx.Lock()
+	b.subs[ch] = struct{}{}
+	b.mx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mx.Lock()
+		delete(b.subs, ch)
+		close(ch)
+		b.mx.Unlock()
+	}()
+
+	return ch
+}
+
+// Publish sends e to all current subscribers without blocking.
+func (b *Broker) Publish(e Event) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
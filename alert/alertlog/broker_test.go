@@ -0,0 +1,49 @@
+package alertlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	e, err := ParseEvent(`{"alert_id": 123, "event": "acknowledged"}`)
+	require.NoError(t, err)
+	assert.Equal(t, Event{AlertID: 123, Type: TypeAcknowledged}, *e)
+
+	_, err = ParseEvent(`{"event": "closed"}`)
+	assert.Error(t, err, "missing alert_id")
+
+	_, err = ParseEvent(`not json`)
+	assert.Error(t, err)
+}
+
+func TestBroker(t *testing.T) {
+	b := NewBroker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := b.Subscribe(ctx)
+
+	b.Publish(Event{AlertID: 1, Type: TypeCreated})
+	assert.Equal(t, Event{AlertID: 1, Type: TypeCreated}, <-ch)
+
+	// slow subscribers must not block publishing
+	for i := 0; i < subBufferSize*2; i++ {
+		b.Publish(Event{AlertID: i, Type: TypeEscalated})
+	}
+	assert.Len(t, ch, subBufferSize)
+
+	cancel()
+	for range ch {
+		// drain until closed
+	}
+
+	b.mx.Lock()
+	assert.Empty(t, b.subs, "subscription removed")
+	b.mx.Unlock()
+
+	// publishing after unsubscribe is a no-op
+	b.Publish(Event{AlertID: 2, Type: TypeClosed})
+}
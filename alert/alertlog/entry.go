@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,10 +39,32 @@ func (e Entry) Meta(ctx context.Context) interface{} {
 	switch e.Type() {
 	case TypeEscalated:
 		dest = &EscalationMetaData{}
+	case TypeEscalationExhausted:
+		dest = &EscalationExhaustedMetaData{}
+	case TypeMaintenanceSuppressed:
+		dest = &MaintenanceSuppressedMetaData{}
 	case TypeNotificationSent:
 		dest = &NotificationMetaData{}
+	case TypeNoNotificationSent:
+		dest = &NoNotificationMetaData{}
+	case TypeSnoozed, TypeUnsnoozed:
+		dest = &SnoozeMetaData{}
+	case TypeAckTimeout:
+		dest = &AckTimeoutMetaData{}
+	case TypeIncidentAcknowledged:
+		dest = &IncidentAckMetaData{}
+	case TypeServiceResumed:
+		dest = &ServiceResumedMetaData{}
+	case TypeNote:
+		dest = &NoteMetaData{}
+	case TypeConferenceBridge:
+		dest = &ConferenceBridgeMetaData{}
+	case TypeAssigned:
+		dest = &AssignedMetaData{}
 	case TypeCreated:
 		dest = &CreatedMetaData{}
+	case TypeDuplicateSupressed:
+		dest = &DuplicateSuppressedMetaData{}
 	case TypeClosed:
 		dest = &AutoClose{}
 	default:
@@ -108,6 +131,42 @@ func (e Entry) Subject() *Subject {
 	return s
 }
 
+func closeReasonString(reason string) string {
+	switch reason {
+	case "falsePositive":
+		return "false positive"
+	case "wontfix":
+		return "won't fix"
+	}
+
+	return reason
+}
+
+func dedupWindowString(secs int) string {
+	if secs%60 != 0 {
+		return (time.Duration(secs) * time.Second).String()
+	}
+
+	return fmt.Sprintf("%d minute", secs/60)
+}
+
+// createdDetails returns the source and integration key of a created alert, if known, e.g.,
+// " (source: grafana, integration key: <id>)".
+func createdDetails(m *CreatedMetaData) string {
+	var details []string
+	if m.Source != "" {
+		details = append(details, "source: "+m.Source)
+	}
+	if m.IntegrationKeyID != "" {
+		details = append(details, "integration key: "+m.IntegrationKeyID)
+	}
+	if len(details) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(details, ", ") + ")"
+}
+
 func escalationMsg(m *EscalationMetaData) string {
 	msg := fmt.Sprintf(" to step #%d", m.NewStepIndex+1)
 	if m.Repeat {
@@ -119,6 +178,11 @@ func escalationMsg(m *EscalationMetaData) string {
 		msg += " due to current step being deleted"
 	} else if m.OldDelayMinutes > 0 {
 		msg += fmt.Sprintf(" automatically after %d minutes", m.OldDelayMinutes)
+	} else if m.NotificationDelayMinutes > 0 {
+		msg += fmt.Sprintf(" after a %d minute notification delay", m.NotificationDelayMinutes)
+	}
+	if m.BelowMinSeverity {
+		msg += " (skipped, alert below minimum severity)"
 	}
 
 	return msg
@@ -135,8 +199,12 @@ func (e Entry) String(ctx context.Context) string {
 	case TypeClosed:
 		msg = "Closed"
 		meta, ok := e.Meta(ctx).(*AutoClose)
-		if ok {
+		if ok && meta.AlertAutoCloseDays > 0 {
 			msg = "Closed due to inactivity (unacknowledged for  " + strconv.Itoa(meta.AlertAutoCloseDays) + " days)"
+		} else if ok && meta.DedupWindowSeconds > 0 {
+			msg = "Closed due to inactivity (no events within the " + dedupWindowString(meta.DedupWindowSeconds) + " dedup window)"
+		} else if ok && meta.Reason != "" {
+			msg += " as " + closeReasonString(meta.Reason)
 		}
 
 	case TypeEscalated:
@@ -155,8 +223,83 @@ func (e Entry) String(ctx context.Context) string {
 		msg = "Policy updated"
 	case TypeDuplicateSupressed:
 		msg = "Suppressed duplicate: created"
+		meta, ok := e.Meta(ctx).(*DuplicateSuppressedMetaData)
+		if ok && meta.AlertClosed {
+			msg = "Suppressed duplicate of closed alert (within dedup window): created"
+		}
 	case TypeEscalationRequest:
 		msg = "Escalation requested"
+	case TypeEscalationExhausted:
+		msg = "Escalation stopped"
+		meta, ok := e.Meta(ctx).(*EscalationExhaustedMetaData)
+		if ok {
+			msg += fmt.Sprintf(" after reaching the limit of %d notifications", meta.MaxNotifications)
+		}
+	case TypeMaintenanceSuppressed:
+		msg = "Acknowledged automatically during maintenance window"
+		meta, ok := e.Meta(ctx).(*MaintenanceSuppressedMetaData)
+		if ok && meta.Description != "" {
+			msg += " (" + meta.Description + ")"
+		}
+	case TypeSnoozed:
+		msg = "Snoozed"
+		meta, ok := e.Meta(ctx).(*SnoozeMetaData)
+		if ok {
+			msg += fmt.Sprintf(" for %d minutes", meta.DurationMinutes)
+		}
+	case TypeUnsnoozed:
+		msg = "Snooze expired"
+		meta, ok := e.Meta(ctx).(*SnoozeMetaData)
+		if ok {
+			msg += fmt.Sprintf(" after %d minutes", meta.DurationMinutes)
+		}
+	case TypeAckTimeout:
+		msg = "Acknowledgement expired"
+		meta, ok := e.Meta(ctx).(*AckTimeoutMetaData)
+		if ok {
+			msg += fmt.Sprintf(" after %d minutes", meta.TimeoutMinutes)
+		}
+	case TypeIncidentAcknowledged:
+		msg = "Acknowledged with incident"
+		meta, ok := e.Meta(ctx).(*IncidentAckMetaData)
+		if ok && meta.Summary != "" {
+			msg += " (" + meta.Summary + ")"
+		}
+	case TypeServicePaused:
+		msg = "Service paused"
+	case TypeServiceResumed:
+		msg = "Service resumed"
+		meta, ok := e.Meta(ctx).(*ServiceResumedMetaData)
+		if ok && meta.Renotify {
+			msg += ", restarting escalation"
+		}
+	case TypeNote:
+		msg = "Note added"
+	case TypeAssigned:
+		msg = "Assigned"
+		meta, ok := e.Meta(ctx).(*AssignedMetaData)
+		if ok && meta.UserName != "" {
+			msg += " to " + meta.UserName
+		}
+	case TypeConferenceBridge:
+		msg = "Conference bridge updated"
+		meta, ok := e.Meta(ctx).(*ConferenceBridgeMetaData)
+		if !ok {
+			break
+		}
+		switch meta.Event {
+		case BridgeStarted:
+			msg = "Conference bridge started"
+			if meta.Number != "" {
+				msg += fmt.Sprintf(" (call %s and enter %d# to join)", meta.Number, e.AlertID())
+			}
+		case BridgeJoined:
+			msg = "Joined conference bridge"
+		case BridgeLeft:
+			msg = "Left conference bridge"
+		case BridgeEnded:
+			msg = "Conference bridge ended"
+		}
 	default:
 		return "Error"
 	}
@@ -164,6 +307,30 @@ func (e Entry) String(ctx context.Context) string {
 	// include subject, if available
 	msg += subjectString(infinitive, e.Subject())
 
+	if e.Type() == TypeCreated {
+		meta, ok := e.Meta(ctx).(*CreatedMetaData)
+		if ok {
+			msg += createdDetails(meta)
+		}
+	}
+
+	if e.Type() == TypeAssigned {
+		meta, ok := e.Meta(ctx).(*AssignedMetaData)
+		if ok && meta.EscalationPausedMinutes > 0 {
+			msg += fmt.Sprintf(", escalation paused for %d minutes", meta.EscalationPausedMinutes)
+		}
+	}
+
+	if e.Type() == TypeNote {
+		meta, ok := e.Meta(ctx).(*NoteMetaData)
+		if ok {
+			msg += ": " + meta.Note
+			if meta.EditedAt != nil {
+				msg += " (edited)"
+			}
+		}
+	}
+
 	return msg
 }
 
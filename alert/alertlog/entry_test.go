@@ -0,0 +1,47 @@
+package alertlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_String_Closed(t *testing.T) {
+	closed := func(meta string) string {
+		var e Entry
+		e._type = TypeClosed
+		e.meta = rawJSON(meta)
+		return e.String(context.Background())
+	}
+
+	assert.Equal(t, "Closed", closed(`{}`))
+	assert.Equal(t, "Closed as false positive", closed(`{"Reason": "falsePositive"}`))
+	assert.Equal(t, "Closed due to inactivity (no events within the 5 minute dedup window)", closed(`{"DedupWindowSeconds": 300}`))
+	assert.Equal(t, "Closed due to inactivity (no events within the 1m30s dedup window)", closed(`{"DedupWindowSeconds": 90}`))
+}
+
+func TestEntry_String_Created(t *testing.T) {
+	created := func(meta string) string {
+		var e Entry
+		e._type = TypeCreated
+		e.meta = rawJSON(meta)
+		return e.String(context.Background())
+	}
+
+	assert.Equal(t, "Created", created(`{}`))
+	assert.Equal(t, "Created (source: manual)", created(`{"Source": "manual"}`))
+	assert.Equal(t, "Created (source: grafana, integration key: 00000000-0000-0000-0000-000000000001)", created(`{"Source": "grafana", "IntegrationKeyID": "00000000-0000-0000-0000-000000000001"}`))
+}
+
+func TestEntry_String_DuplicateSuppressed(t *testing.T) {
+	dup := func(meta string) string {
+		var e Entry
+		e._type = TypeDuplicateSupressed
+		e.meta = rawJSON(meta)
+		return e.String(context.Background())
+	}
+
+	assert.Equal(t, "Suppressed duplicate: created", dup(`{}`))
+	assert.Equal(t, "Suppressed duplicate of closed alert (within dedup window): created", dup(`{"AlertClosed": true}`))
+}
@@ -1,5 +1,7 @@
 package alertlog
 
+import "time"
+
 type EscalationMetaData struct {
 	NewStepIndex    int
 	Repeat          bool
@@ -7,16 +9,113 @@ type EscalationMetaData struct {
 	Deleted         bool
 	OldDelayMinutes int
 	NoOneOnCall     bool
+
+	// BelowMinSeverity indicates no notifications were sent because the alert
+	// severity is below the minimum severity of the step.
+	BelowMinSeverity bool
+
+	// NotificationDelayMinutes is the service notification delay that was waited
+	// out before the first step.
+	NotificationDelayMinutes int
+}
+
+type EscalationExhaustedMetaData struct {
+	MaxNotifications int
+}
+
+type MaintenanceSuppressedMetaData struct {
+	MaintenanceWindowID string
+	Description         string
+}
+
+type SnoozeMetaData struct {
+	DurationMinutes int
+}
+
+type AckTimeoutMetaData struct {
+	TimeoutMinutes int
+}
+
+type IncidentAckMetaData struct {
+	IncidentID string
+	Summary    string
+}
+
+type ServiceResumedMetaData struct {
+	Renotify bool
+}
+
+// NoteMetaData contains the text of a note left by a user.
+type NoteMetaData struct {
+	Note string
+
+	// EditedAt is set if the note was edited after it was added.
+	EditedAt *time.Time `json:",omitempty"`
+}
+
+// Conference bridge events.
+const (
+	BridgeStarted = "started"
+	BridgeJoined  = "joined"
+	BridgeLeft    = "left"
+	BridgeEnded   = "ended"
+)
+
+// ConferenceBridgeMetaData describes a change to the conference bridge of an alert.
+type ConferenceBridgeMetaData struct {
+	// Event is one of BridgeStarted, BridgeJoined, BridgeLeft, or BridgeEnded.
+	Event string
+
+	// Number is the phone number to call to join the bridge, set when it is started.
+	Number string `json:",omitempty"`
 }
 
 type NotificationMetaData struct {
 	MessageID string
 }
 
+type NoNotificationMetaData struct {
+	// DoNotDisturb indicates notifications were skipped because the user has
+	// do-not-disturb enabled.
+	DoNotDisturb bool
+
+	// RateLimited indicates notifications are held because the user reached their
+	// maximum number of notifications per hour.
+	RateLimited bool
+}
+
 type CreatedMetaData struct {
 	EPNoSteps bool
+
+	// Source is the alert source (e.g., "grafana" or "manual") at creation time.
+	Source string `json:",omitempty"`
+
+	// IntegrationKeyID is set if the alert was created through an integration key.
+	IntegrationKeyID string `json:",omitempty"`
+}
+
+type DuplicateSuppressedMetaData struct {
+	// AlertClosed is set if the duplicate matched an alert closed within the dedup window, so
+	// no new alert was created for it.
+	AlertClosed bool `json:",omitempty"`
+}
+
+type AssignedMetaData struct {
+	UserID   string
+	UserName string
+
+	// EscalationPausedMinutes is set if escalation was held back to give the assignee
+	// time to respond.
+	EscalationPausedMinutes int `json:",omitempty"`
 }
 
 type AutoClose struct {
 	AlertAutoCloseDays int
+
+	// Reason is the close reason provided by the user, if any.
+	Reason string `json:",omitempty"`
+
+	// DedupWindowSeconds is set when the alert was closed because a new event arrived after
+	// it had not occurred within the dedup window.
+	DedupWindowSeconds int `json:",omitempty"`
 }
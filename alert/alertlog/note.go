@@ -0,0 +1,99 @@
+package alertlog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// NoteEditWindow is how long after adding a note its author can edit or delete it.
+const NoteEditWindow = 15 * time.Minute
+
+// AddNoteTx will add a note from the current user to the alert's log. Notes do not
+// change the state or escalation of the alert.
+func (s *Store) AddNoteTx(ctx context.Context, tx *sql.Tx, alertID int, note string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Text("Note", note, 1, 1024)
+	if err != nil {
+		return err
+	}
+
+	return s.LogTx(ctx, tx, alertID, TypeNote, &NoteMetaData{Note: note})
+}
+
+// UpdateNoteTx will replace the text of a note, marking it as edited. Only the author
+// can edit a note, and only within the NoteEditWindow.
+func (s *Store) UpdateNoteTx(ctx context.Context, tx *sql.Tx, logID int, note string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Text("Note", note, 1, 1024)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data, err := json.Marshal(NoteMetaData{Note: note, EditedAt: &now})
+	if err != nil {
+		return err
+	}
+
+	res, err := txWrap(ctx, tx, s.updateNote).ExecContext(ctx, logID, permission.UserID(ctx), string(data), int(NoteEditWindow/time.Second))
+	if err != nil {
+		return err
+	}
+
+	return noteChanged(res)
+}
+
+// DeleteNoteTx will remove a note from the alert's log. Only the author can delete a
+// note, and only within the NoteEditWindow.
+func (s *Store) DeleteNoteTx(ctx context.Context, tx *sql.Tx, logID int) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	res, err := txWrap(ctx, tx, s.deleteNote).ExecContext(ctx, logID, permission.UserID(ctx), int(NoteEditWindow/time.Second))
+	if err != nil {
+		return err
+	}
+
+	return noteChanged(res)
+}
+
+func noteChanged(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("ID", "note not found, or can no longer be changed")
+	}
+
+	return nil
+}
+
+// NoteEditable returns true if the entry is a note that the current user can still
+// edit or delete.
+func (e Entry) NoteEditable(ctx context.Context) bool {
+	if e.Type() != TypeNote || !e.subject.userID.Valid {
+		return false
+	}
+	if e.subject.userID.UUID.String() != permission.UserID(ctx) {
+		return false
+	}
+
+	return time.Since(e.timestamp) < NoteEditWindow
+}
@@ -0,0 +1,42 @@
+package alertlog
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/permission"
+)
+
+func TestEntry_Note(t *testing.T) {
+	author := uuid.New()
+	note := func(age time.Duration, meta string) Entry {
+		var e Entry
+		e._type = TypeNote
+		e.timestamp = time.Now().Add(-age)
+		e.subject._type = SubjectTypeUser
+		e.subject.userID = uuid.NullUUID{UUID: author, Valid: true}
+		e.subject.userName = sql.NullString{String: "alice", Valid: true}
+		e.meta = rawJSON(meta)
+		return e
+	}
+	ctx := permission.UserContext(context.Background(), author.String(), permission.RoleUser)
+	other := permission.UserContext(context.Background(), uuid.NewString(), permission.RoleAdmin)
+
+	e := note(time.Minute, `{"Note": "looking into it"}`)
+	assert.Equal(t, &NoteMetaData{Note: "looking into it"}, e.Meta(ctx))
+	assert.Equal(t, "Note added by alice: looking into it", e.String(ctx))
+	assert.True(t, e.NoteEditable(ctx))
+	assert.False(t, e.NoteEditable(other), "other user")
+
+	e = note(NoteEditWindow+time.Minute, `{"Note": "fixed", "EditedAt": "2023-10-30T10:00:00Z"}`)
+	assert.Equal(t, "Note added by alice: fixed (edited)", e.String(ctx))
+	assert.False(t, e.NoteEditable(ctx), "edit window passed")
+
+	e = note(time.Minute, `{}`)
+	e._type = TypeAcknowledged
+	assert.False(t, e.NoteEditable(ctx), "not a note")
+}
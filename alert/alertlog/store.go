@@ -32,6 +32,9 @@ type Store struct {
 	lookupIKeyType     *sql.Stmt
 
 	lookupNCTypeName *sql.Stmt
+
+	updateNote *sql.Stmt
+	deleteNote *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -52,6 +55,25 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		lookupIKeyType: p.P(`select "type" from integration_keys where id = $1`),
+
+		// notes can only be changed by their author, within the edit window
+		updateNote: p.P(`
+			update alert_logs
+			set meta = $3
+			where
+				id = $1 and
+				event = 'note' and
+				sub_user_id = $2 and
+				timestamp > now() - $4::int * '1 second'::interval
+		`),
+		deleteNote: p.P(`
+			delete from alert_logs
+			where
+				id = $1 and
+				event = 'note' and
+				sub_user_id = $2 and
+				timestamp > now() - $3::int * '1 second'::interval
+		`),
 		findOne: p.P(`
 			select
 				log.id,
@@ -301,9 +323,21 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Slack"
 			case notificationchannel.TypeWebhook:
 				r.subject.classifier = "Webhook"
+			case notificationchannel.TypeMSTeams:
+				r.subject.classifier = "Teams"
+			case notificationchannel.TypePagerDuty:
+				r.subject.classifier = "PagerDuty"
 			}
 			r.subject.channelID.UUID = uuid.MustParse(src.ID)
 			r.subject.channelID.Valid = true
+		case permission.SourceTypeSlashCommand:
+			r.subject.classifier = "Slack"
+			r.subject._type = SubjectTypeUser
+
+			if permission.UserID(ctx) != "" {
+				r.subject.userID.UUID = uuid.MustParse(permission.UserID(ctx))
+				r.subject.userID.Valid = true
+			}
 		case permission.SourceTypeAuthProvider:
 			r.subject.classifier = "Web"
 			r.subject._type = SubjectTypeUser
@@ -321,6 +355,11 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 			if _type == TypeNoNotificationSent {
 				// no CMID for no notification sent
 				r.subject.classifier = "no immediate rule"
+				if m, ok := meta.(*NoNotificationMetaData); ok && m.DoNotDisturb {
+					r.subject.classifier = "do not disturb"
+				} else if ok && m.RateLimited {
+					r.subject.classifier = "rate limited"
+				}
 				break
 			}
 			cmType, err := s.queries(tx).AlertLogLookupCMType(ctx, uuid.MustParse(src.ID))
@@ -360,6 +399,10 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Webhook"
 			case notification.DestTypeSlackChannel:
 				r.subject.classifier = "Slack"
+			case notification.DestTypeMSTeams:
+				r.subject.classifier = "Teams"
+			case notification.DestTypeTelegram:
+				r.subject.classifier = "Telegram"
 			}
 			if permission.UserID(ctx) != "" {
 				r.subject.userID.UUID = uuid.MustParse(permission.UserID(ctx))
@@ -400,6 +443,8 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Site24x7"
 			case integrationkey.TypeEmail:
 				r.subject.classifier = "Email"
+			case integrationkey.TypeOpsGenie:
+				r.subject.classifier = "OpsGenie"
 			}
 			r.subject.integrationKeyID.Valid = true
 			r.subject.integrationKeyID.UUID = uuid.MustParse(src.ID)
@@ -7,15 +7,26 @@ type Type string
 
 // Types of Log Entries
 const (
-	TypeCreated            Type = "created"
-	TypeClosed             Type = "closed"
-	TypeNotificationSent   Type = "notification_sent"
-	TypeNoNotificationSent Type = "no_notification_sent"
-	TypeEscalated          Type = "escalated"
-	TypeAcknowledged       Type = "acknowledged"
-	TypePolicyUpdated      Type = "policy_updated"
-	TypeDuplicateSupressed Type = "duplicate_suppressed"
-	TypeEscalationRequest  Type = "escalation_request"
+	TypeCreated               Type = "created"
+	TypeClosed                Type = "closed"
+	TypeNotificationSent      Type = "notification_sent"
+	TypeNoNotificationSent    Type = "no_notification_sent"
+	TypeEscalated             Type = "escalated"
+	TypeAcknowledged          Type = "acknowledged"
+	TypePolicyUpdated         Type = "policy_updated"
+	TypeDuplicateSupressed    Type = "duplicate_suppressed"
+	TypeEscalationRequest     Type = "escalation_request"
+	TypeEscalationExhausted   Type = "escalation_exhausted"
+	TypeMaintenanceSuppressed Type = "maintenance_suppressed"
+	TypeSnoozed               Type = "snoozed"
+	TypeUnsnoozed             Type = "unsnoozed"
+	TypeAckTimeout            Type = "ack_timeout"
+	TypeIncidentAcknowledged  Type = "incident_acknowledged"
+	TypeServicePaused         Type = "service_paused"
+	TypeServiceResumed        Type = "service_resumed"
+	TypeNote                  Type = "note"
+	TypeAssigned              Type = "assignment_changed"
+	TypeConferenceBridge      Type = "conference_bridge"
 
 	// not exported, status_changed will be turned into an acknowledged where appropriate
 	_TypeStatusChanged Type = "status_changed"
@@ -0,0 +1,171 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ArchivedAlert is a read-only copy of a closed alert that has been moved to the
+// archive, along with its log entries.
+type ArchivedAlert struct {
+	ID          int
+	ServiceID   string
+	ServiceName string
+	Summary     string
+	Details     string
+	Source      Source
+	Severity    Severity
+	CreatedAt   time.Time
+	ArchivedAt  time.Time
+
+	logs json.RawMessage
+}
+
+// Logs returns the log entries of the alert at the time it was archived, newest first.
+func (a ArchivedAlert) Logs() ([]alertlog.Entry, error) {
+	return alertlog.ParseArchivedEntries(a.logs)
+}
+
+func (a *ArchivedAlert) scanFrom(scanFn func(...interface{}) error) error {
+	var svcID sql.NullString
+	err := scanFn(&a.ID, &svcID, &a.ServiceName, &a.Summary, &a.Details, &a.Source, &a.Severity, &a.CreatedAt, &a.ArchivedAt, &a.logs)
+	a.ServiceID = svcID.String
+	return err
+}
+
+// ArchiveSearchOptions contains criteria for filtering archived alerts.
+type ArchiveSearchOptions struct {
+	// Search is matched case-insensitive against the alert summary and service name.
+	Search string `json:"s,omitempty"`
+
+	// ServiceIDs, if specified, will restrict alerts to those with a matching ServiceID.
+	ServiceIDs []string `json:"v,omitempty"`
+
+	// AfterID will only include alerts with an ID lower than the provided value.
+	AfterID int `json:"a,omitempty"`
+
+	// Limit restricts the maximum number of rows returned. Default is 50.
+	Limit int `json:"-"`
+}
+
+var archiveSearchTemplate = template.Must(template.New("alert-archive-search").Funcs(search.Helpers()).Parse(`
+	SELECT
+		a.id,
+		a.service_id,
+		a.service_name,
+		a.summary,
+		a.details,
+		a.source,
+		a.severity,
+		a.created_at,
+		a.archived_at,
+		a.logs
+	FROM alert_archives a
+	WHERE true
+	{{ if .Search }}
+		AND {{textSearch "search" "a.summary" "a.service_name"}}
+	{{ end }}
+	{{ if .ServiceIDs }}
+		AND a.service_id = any(:services)
+	{{ end }}
+	{{ if .AfterID }}
+		AND a.id < :afterID
+	{{ end }}
+	ORDER BY a.id DESC
+	LIMIT {{.Limit}}
+`))
+
+type archiveRenderData ArchiveSearchOptions
+
+func (opts archiveRenderData) Normalize() (*archiveRenderData, error) {
+	if opts.Limit == 0 {
+		opts.Limit = search.DefaultMaxResults
+	}
+
+	err := validate.Many(
+		validate.Search("Search", opts.Search),
+		validate.Range("Limit", opts.Limit, 0, 1001),
+		validate.ManyUUID("ServiceIDs", opts.ServiceIDs, 50),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+func (opts archiveRenderData) QueryArgs() []sql.NamedArg {
+	return []sql.NamedArg{
+		sql.Named("search", opts.Search),
+		sql.Named("services", sqlutil.UUIDArray(opts.ServiceIDs)),
+		sql.Named("afterID", opts.AfterID),
+	}
+}
+
+// SearchArchived will return archived alerts matching the provided options, newest first.
+func (s *Store) SearchArchived(ctx context.Context, opts *ArchiveSearchOptions) ([]ArchivedAlert, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = new(ArchiveSearchOptions)
+	}
+
+	data, err := (*archiveRenderData)(opts).Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := search.RenderQuery(ctx, archiveSearchTemplate, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "render query")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	var result []ArchivedAlert
+	for rows.Next() {
+		var a ArchivedAlert
+		err = a.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+// FindOneArchived will return the archived alert with the given ID, or nil if it does not exist.
+func (s *Store) FindOneArchived(ctx context.Context, id int) (*ArchivedAlert, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var a ArchivedAlert
+	err = a.scanFrom(s.findArchived.QueryRowContext(ctx, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,139 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxAssignEscalationPause is the longest escalation can be paused when assigning an alert.
+const MaxAssignEscalationPause = 24 * time.Hour
+
+// Assign will make the given user responsible for an unacknowledged alert. The user is
+// notified through their notification rules until the alert is acknowledged.
+//
+// If pause is non-zero, the next escalation of the alert is delayed until at least pause
+// has elapsed, giving the assignee time to respond before normal escalation resumes.
+func (s *Store) Assign(ctx context.Context, alertID int, userID string, pause time.Duration) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return err
+	}
+
+	uid, err := validate.ParseUUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+	if pause != 0 {
+		err = validate.Duration("PauseEscalationMinutes", pause, time.Minute, MaxAssignEscalationPause)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "assign alert", tx)
+
+	q := gadb.New(tx)
+	lck, err := q.LockOneAlertService(ctx, int64(alertID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewGenericError("alert not found")
+	}
+	if err != nil {
+		return fmt.Errorf("lock alert: %w", err)
+	}
+	if lck.Status == gadb.EnumAlertStatusClosed {
+		return logError{isAlreadyClosed: true, alertID: alertID, _type: alertlog.TypeClosed, logDB: s.logDB}
+	}
+	if lck.Status != gadb.EnumAlertStatusTriggered {
+		// notification cycles are removed once an alert is acknowledged, so the assignee
+		// would never be notified
+		return validation.NewGenericError("only unacknowledged alerts can be assigned")
+	}
+
+	name, err := q.AlertAssign(ctx, gadb.AlertAssignParams{AlertID: int64(alertID), UserID: uid})
+	if e := sqlutil.MapError(err); e != nil && e.Code == "23503" {
+		// 23503 is foreign_key_violation
+		return validation.NewFieldError("UserID", "user not found")
+	}
+	if err != nil {
+		return fmt.Errorf("assign alert: %w", err)
+	}
+
+	err = q.AlertAssignNotify(ctx, gadb.AlertAssignNotifyParams{AlertID: int32(alertID), UserID: uid})
+	if err != nil {
+		return fmt.Errorf("notify assignee: %w", err)
+	}
+
+	mins := int(pause / time.Minute)
+	if mins > 0 {
+		err = q.AlertAssignPauseEscalation(ctx, gadb.AlertAssignPauseEscalationParams{AlertID: int64(alertID), Mins: int32(mins)})
+		if err != nil {
+			return fmt.Errorf("pause escalation: %w", err)
+		}
+	}
+
+	err = s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeAssigned, &alertlog.AssignedMetaData{
+		UserID:                  userID,
+		UserName:                name,
+		EscalationPausedMinutes: mins,
+	})
+	if err != nil {
+		return fmt.Errorf("log assignment: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// Assignment is the user an alert is assigned to.
+type Assignment struct {
+	// ID is the ID of the alert.
+	ID     int
+	UserID string
+}
+
+// Assignments will return the assignments of the given alerts. Alerts that have not been
+// assigned are omitted.
+func (s *Store) Assignments(ctx context.Context, alertIDs []int) ([]Assignment, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("AlertIDs", len(alertIDs), 1, maxBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(alertIDs))
+	for i, id := range alertIDs {
+		ids[i] = int64(id)
+	}
+	rows, err := gadb.New(s.db).AlertAssignees(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Assignment, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, Assignment{ID: int(r.AlertID), UserID: r.UserID.String()})
+	}
+
+	return result, nil
+}
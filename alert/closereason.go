@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/validation"
+)
+
+// CloseReason categorizes why an alert was closed.
+type CloseReason string
+
+// Close reasons
+const (
+	CloseReasonResolved      CloseReason = "resolved"
+	CloseReasonFalsePositive CloseReason = "falsePositive"
+	CloseReasonDuplicate     CloseReason = "duplicate"
+	CloseReasonWontFix       CloseReason = "wontfix"
+)
+
+func (r CloseReason) validate() error {
+	switch r {
+	case "", CloseReasonResolved, CloseReasonFalsePositive, CloseReasonDuplicate, CloseReasonWontFix:
+		return nil
+	}
+
+	return validation.NewFieldError("CloseReason", "unknown close reason "+string(r))
+}
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (r *CloseReason) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+
+	*r = CloseReason(str)
+	return r.validate()
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (r CloseReason) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(r)).MarshalGQL(w)
+}
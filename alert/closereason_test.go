@@ -0,0 +1,15 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseReason_UnmarshalGQL(t *testing.T) {
+	var r CloseReason
+	assert.NoError(t, r.UnmarshalGQL("falsePositive"))
+	assert.Equal(t, CloseReasonFalsePositive, r)
+
+	assert.Error(t, r.UnmarshalGQL("false_positive"))
+}
@@ -0,0 +1,124 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// bridgeUserContext will return a context for logging conference bridge events on behalf
+// of the user of a voice contact method.
+func bridgeUserContext(ctx context.Context, userID, cmID uuid.NullUUID) context.Context {
+	if !userID.Valid || !cmID.Valid {
+		return ctx
+	}
+
+	return permission.UserSourceContext(ctx, userID.UUID.String(), permission.RoleUser, &permission.SourceInfo{
+		Type: permission.SourceTypeContactMethod,
+		ID:   cmID.UUID.String(),
+	})
+}
+
+// JoinConferenceBridge records a call joining the conference bridge of an alert. The number
+// must belong to an enabled voice contact method, and the bridge must still be active.
+func (s *Store) JoinConferenceBridge(ctx context.Context, alertID int, callSID, number string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.Range("AlertID", alertID, 1, math.MaxInt32),
+		validate.Phone("Number", number),
+	)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "alert: join conference bridge", tx)
+
+	var cmID, userID uuid.NullUUID
+	err = tx.StmtContext(ctx, s.bridgeCaller).QueryRowContext(ctx, number).Scan(&cmID, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewGenericError("this number is not registered for voice notifications")
+	}
+	if err != nil {
+		return errors.Wrap(err, "lookup contact method")
+	}
+
+	var active bool
+	err = tx.StmtContext(ctx, s.bridgeActive).QueryRowContext(ctx, alertID).Scan(&active)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !active) {
+		return validation.NewGenericError("there is no active conference bridge for that alert")
+	}
+	if err != nil {
+		return errors.Wrap(err, "lookup conference bridge")
+	}
+
+	res, err := tx.StmtContext(ctx, s.bridgeJoin).ExecContext(ctx, alertID, callSID, userID, cmID)
+	if err != nil {
+		return errors.Wrap(err, "add participant")
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		err = s.logDB.LogTx(bridgeUserContext(ctx, userID, cmID), tx, alertID, alertlog.TypeConferenceBridge, &alertlog.ConferenceBridgeMetaData{Event: alertlog.BridgeJoined})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LeaveConferenceBridge records a call leaving the conference bridge of an alert.
+func (s *Store) LeaveConferenceBridge(ctx context.Context, alertID int, callSID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "alert: leave conference bridge", tx)
+
+	var userID, cmID uuid.NullUUID
+	err = tx.StmtContext(ctx, s.bridgeLeave).QueryRowContext(ctx, alertID, callSID).Scan(&userID, &cmID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// never joined, or already left
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "remove participant")
+	}
+
+	err = s.logDB.LogTx(bridgeUserContext(ctx, userID, cmID), tx, alertID, alertlog.TypeConferenceBridge, &alertlog.ConferenceBridgeMetaData{Event: alertlog.BridgeLeft})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetConferenceBridgeSID sets the Twilio conference SID of an active conference bridge, so it
+// can be ended when the alert is closed. An empty sid clears it once the conference is over.
+func (s *Store) SetConferenceBridgeSID(ctx context.Context, alertID int, sid string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.bridgeSetSID.ExecContext(ctx, alertID, sql.NullString{String: sid, Valid: sid != ""})
+	return err
+}
@@ -18,6 +18,14 @@ const (
 	DedupTypeUser      = DedupType("user")
 	DedupTypeAuto      = DedupType("auto")
 	DedupTypeHeartbeat = DedupType("heartbeat")
+
+	// DedupTypeHeartbeatWarning is used for the warning alert of a heartbeat
+	// monitor, so it is tracked separately from the expired alert.
+	DedupTypeHeartbeatWarning = DedupType("heartbeat-warning")
+
+	// DedupTypeRateLimit is used for the alert raised in place of alerts dropped
+	// by an integration key rate limit, with the key ID as the payload.
+	DedupTypeRateLimit = DedupType("rate-limit")
 )
 
 // DedupID represents a de-duplication ID for alerts.
@@ -1,7 +1,75 @@
 package alert
 
+import (
+	"io"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/validation"
+)
+
+// FeedbackValue indicates whether an alert required action from responders.
+type FeedbackValue string
+
+// Feedback values
+const (
+	FeedbackActionable FeedbackValue = "actionable"
+	FeedbackNoise      FeedbackValue = "noise"
+)
+
 // Feedback represents user provided information about a given alert
 type Feedback struct {
-	ID     int
+	ID          int
+	Value       FeedbackValue
 	NoiseReason string
+
+	// UserID is the user that last provided feedback, empty if it was not set by a user.
+	UserID    string
+	UpdatedAt time.Time
+}
+
+// FeedbackStats contains a summary of the feedback for alerts of a service.
+type FeedbackStats struct {
+	ServiceID string
+
+	// AlertCount is the total number of alerts, including those without feedback.
+	AlertCount      int
+	ActionableCount int
+	NoiseCount      int
+}
+
+// NoiseRatio returns the fraction of alerts with feedback that were reported as noise,
+// or zero if none have feedback.
+func (s FeedbackStats) NoiseRatio() float64 {
+	total := s.ActionableCount + s.NoiseCount
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.NoiseCount) / float64(total)
+}
+
+func (f FeedbackValue) validate() error {
+	switch f {
+	case FeedbackActionable, FeedbackNoise:
+		return nil
+	}
+
+	return validation.NewFieldError("Value", "unknown feedback value "+string(f))
+}
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (f *FeedbackValue) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+
+	*f = FeedbackValue(str)
+	return f.validate()
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (f FeedbackValue) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(f)).MarshalGQL(w)
 }
@@ -0,0 +1,21 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackStats_NoiseRatio(t *testing.T) {
+	assert.Equal(t, 0.0, FeedbackStats{AlertCount: 10}.NoiseRatio())
+	assert.Equal(t, 0.25, FeedbackStats{AlertCount: 10, ActionableCount: 3, NoiseCount: 1}.NoiseRatio())
+	assert.Equal(t, 1.0, FeedbackStats{AlertCount: 2, NoiseCount: 2}.NoiseRatio())
+}
+
+func TestFeedbackValue_UnmarshalGQL(t *testing.T) {
+	var f FeedbackValue
+	assert.NoError(t, f.UnmarshalGQL("noise"))
+	assert.Equal(t, FeedbackNoise, f)
+
+	assert.Error(t, f.UnmarshalGQL("maybe"))
+}
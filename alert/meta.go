@@ -0,0 +1,154 @@
+package alert
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Limits for alert metadata.
+const (
+	MaxMetaKeys        = 32
+	MaxMetaKeyLength   = 128
+	MaxMetaValueLength = 2048
+	MaxMetaSize        = 16 * 1024
+)
+
+// MetaKeyRunbookURL is the metadata key for a per-alert runbook link. When set to a valid
+// URL, it takes precedence over the service's runbook URL.
+const MetaKeyRunbookURL = "runbook_url"
+
+// Meta contains structured context for an alert (e.g., a runbook link or metric value),
+// kept separate from the free-text details.
+type Meta map[string]string
+
+// Keys returns the metadata keys in sorted order.
+func (m Meta) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RunbookURL returns the runbook link from the metadata, or serviceDefault if it is
+// not set or is not a valid URL.
+func (m Meta) RunbookURL(serviceDefault string) string {
+	u := m[MetaKeyRunbookURL]
+	if u == "" || validate.AbsoluteURL(MetaKeyRunbookURL, u) != nil {
+		return serviceDefault
+	}
+
+	return u
+}
+
+// Sanitize returns a copy of the metadata with keys and values trimmed to their maximum
+// lengths, for use with data from external integrations. Empty keys are dropped, and only
+// the first MaxMetaKeys keys (in sorted order) are kept.
+func (m Meta) Sanitize() Meta {
+	if len(m) == 0 {
+		return nil
+	}
+
+	res := make(Meta, len(m))
+	for _, k := range m.Keys() {
+		if len(res) == MaxMetaKeys {
+			break
+		}
+		key := validate.SanitizeText(k, MaxMetaKeyLength)
+		if key == "" {
+			continue
+		}
+		res[key] = validate.SanitizeText(m[k], MaxMetaValueLength)
+	}
+
+	return res
+}
+
+// Merge returns a copy of the metadata with keys from add that are not already set, for use
+// with data from external integrations. Keys from add are sanitized, and are skipped once the
+// result would exceed MaxMetaKeys or MaxMetaSize.
+func (m Meta) Merge(add Meta) Meta {
+	res := make(Meta, len(m)+len(add))
+	for k, v := range m {
+		res[k] = v
+	}
+
+	add = add.Sanitize()
+	for _, k := range add.Keys() {
+		if len(res) == MaxMetaKeys {
+			break
+		}
+		if _, ok := res[k]; ok {
+			continue
+		}
+
+		res[k] = add[k]
+		data, _ := json.Marshal(res)
+		if len(data) > MaxMetaSize {
+			delete(res, k)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+func validateMeta(fname string, m Meta) error {
+	if len(m) > MaxMetaKeys {
+		return validation.NewFieldErrorf(fname, "cannot exceed %d keys", MaxMetaKeys)
+	}
+
+	var err error
+	for _, k := range m.Keys() {
+		if k == "" {
+			return validation.NewFieldError(fname, "keys must not be empty")
+		}
+		err = validate.Many(err,
+			validate.Text(fname+"["+k+"]", k, 1, MaxMetaKeyLength),
+			validate.Text(fname+"["+k+"]", m[k], 0, MaxMetaValueLength),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, _ := json.Marshal(m)
+	if len(data) > MaxMetaSize {
+		return validation.NewFieldErrorf(fname, "cannot exceed %d bytes", MaxMetaSize)
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing empty metadata as NULL.
+func (m Meta) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *Meta) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(t, m)
+	case string:
+		return json.Unmarshal([]byte(t), m)
+	}
+
+	return fmt.Errorf("could not process unknown type for Meta(%T)", value)
+}
@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta_Validate(t *testing.T) {
+	assert.NoError(t, validateMeta("Meta", nil))
+	assert.NoError(t, validateMeta("Meta", Meta{"runbook": "https://example.com", "empty": ""}))
+
+	assert.Error(t, validateMeta("Meta", Meta{"": "value"}))
+	assert.Error(t, validateMeta("Meta", Meta{" key": "value"}))
+	assert.Error(t, validateMeta("Meta", Meta{"key": strings.Repeat("a", MaxMetaValueLength+1)}))
+
+	tooMany := make(Meta)
+	for i := 0; i <= MaxMetaKeys; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	assert.Error(t, validateMeta("Meta", tooMany))
+
+	tooBig := make(Meta)
+	for i := 0; i < 10; i++ {
+		tooBig[strings.Repeat("k", i+1)] = strings.Repeat("v", MaxMetaValueLength)
+	}
+	assert.Error(t, validateMeta("Meta", tooBig))
+}
+
+func TestMeta_Sanitize(t *testing.T) {
+	assert.Nil(t, Meta{}.Sanitize())
+
+	m := Meta{
+		" graph ": "https://example.com/graph",
+		"":        "dropped",
+		"long":    strings.Repeat("a", MaxMetaValueLength+10),
+	}.Sanitize()
+	assert.Equal(t, []string{"graph", "long"}, m.Keys())
+	assert.Len(t, []rune(m["long"]), MaxMetaValueLength)
+}
+
+func TestMeta_Merge(t *testing.T) {
+	assert.Nil(t, Meta(nil).Merge(nil))
+
+	m := Meta{"team": "payments"}.Merge(Meta{"team": "ignored", " deploy ": "v1.2.3"})
+	assert.Equal(t, Meta{"team": "payments", "deploy": "v1.2.3"}, m)
+
+	big := make(Meta)
+	for i := 0; i < 10; i++ {
+		big[strings.Repeat("k", i+1)] = strings.Repeat("v", MaxMetaValueLength)
+	}
+	m = Meta{"a": "b"}.Merge(big)
+	assert.NoError(t, validateMeta("Meta", m))
+	assert.Equal(t, "b", m["a"])
+}
+
+func TestMeta_Scan(t *testing.T) {
+	var m Meta
+	require.NoError(t, m.Scan([]byte(`{"a":"b"}`)))
+	assert.Equal(t, Meta{"a": "b"}, m)
+
+	require.NoError(t, m.Scan(nil))
+	assert.Nil(t, m)
+
+	v, err := Meta{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestMeta_RunbookURL(t *testing.T) {
+	const svcURL = "https://example.com/runbooks/service"
+
+	assert.Equal(t, svcURL, Meta(nil).RunbookURL(svcURL))
+	assert.Equal(t, "", Meta(nil).RunbookURL(""))
+	assert.Equal(t, "https://example.com/runbooks/alert", Meta{MetaKeyRunbookURL: "https://example.com/runbooks/alert"}.RunbookURL(svcURL), "alert takes precedence")
+	assert.Equal(t, svcURL, Meta{MetaKeyRunbookURL: "not a url"}.RunbookURL(svcURL), "invalid alert URL")
+}
@@ -1,6 +1,7 @@
 -- name: LockOneAlertService :one
 SELECT
     maintenance_expires_at NOTNULL::bool AS is_maint_mode,
+    paused_at NOTNULL::bool AS is_paused,
     alerts.status
 FROM
     services svc
@@ -31,20 +32,249 @@ SELECT
         WHERE
             alert_id = $1) AS has_ep_state;
 
+-- name: AlertHasOpenDedup :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            alerts
+        WHERE
+            service_id = $1
+            AND dedup_key = $2) AS has_open;
+
+-- name: AlertIntKeyDedupWindow :one
+-- AlertIntKeyDedupWindow returns the dedup window of an integration key. No rows are returned if the key
+-- does not have one.
+SELECT
+    dedup_window_minutes::int
+FROM
+    integration_keys
+WHERE
+    id = $1
+    AND dedup_window_minutes NOTNULL;
+
+-- name: AlertIntKeyDefaultLabels :one
+-- AlertIntKeyDefaultLabels returns the default labels of an integration key. No rows are returned if the key
+-- does not have any.
+SELECT
+    default_labels
+FROM
+    integration_keys
+WHERE
+    id = $1
+    AND default_labels NOTNULL;
+
+-- name: AlertCloseStaleDedup :one
+-- AlertCloseStaleDedup closes the open alert with the given dedup key if its last occurrence is older than
+-- the dedup window, so a new alert is created instead of de-duplicating against it.
+UPDATE
+    alerts
+SET
+    status = 'closed'
+WHERE
+    service_id = $1
+    AND dedup_key = $2
+    AND coalesce(last_occurrence, created_at) <= now() - make_interval(secs => @window_seconds::float8)
+RETURNING
+    id;
+
+-- name: AlertIntKeyRateLimit :one
+-- AlertIntKeyRateLimit returns the rate limit and the number of alerts created in the last minute for an
+-- integration key, locking it so concurrent requests are counted correctly. No rows are returned if the
+-- key is not rate limited.
+SELECT
+    k.alert_rate_limit::int AS alert_rate_limit,
+(
+        SELECT
+            count(*)
+        FROM
+            alerts a
+        WHERE
+            a.integration_key_id = k.id
+            AND a.created_at > now() - '1 minute'::interval)::int AS recent_count
+FROM
+    integration_keys k
+WHERE
+    k.id = $1
+    AND k.alert_rate_limit NOTNULL
+FOR UPDATE;
+
+-- name: AlertIntKeyDropAlert :one
+UPDATE
+    integration_keys
+SET
+    dropped_alert_count = dropped_alert_count + 1
+WHERE
+    id = $1
+RETURNING
+    name,
+    dropped_alert_count;
+
 -- name: AlertFeedback :many
 SELECT
     alert_id,
-    noise_reason
+    feedback,
+    noise_reason,
+    user_id,
+    updated_at
 FROM
     alert_feedback
 WHERE
     alert_id = ANY($1::int[]);
 
 -- name: SetAlertFeedback :exec
-INSERT INTO alert_feedback(alert_id, noise_reason)
-    VALUES ($1, $2)
+INSERT INTO alert_feedback(alert_id, feedback, noise_reason, user_id)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT (alert_id)
     DO UPDATE SET
-        noise_reason = $2
+        feedback = $2,
+        noise_reason = $3,
+        user_id = $4,
+        updated_at = now()
     WHERE
         alert_feedback.alert_id = $1;
+
+-- name: AlertFeedbackStats :many
+SELECT
+    a.service_id,
+    count(*) AS alert_count,
+    count(*) FILTER (WHERE f.feedback = 'actionable') AS actionable_count,
+    count(*) FILTER (WHERE f.feedback = 'noise') AS noise_count
+FROM
+    alerts a
+    LEFT JOIN alert_feedback f ON f.alert_id = a.id
+WHERE
+    a.service_id = ANY (@service_ids::uuid[])
+    AND a.created_at >= @start_time::timestamptz
+    AND a.created_at < @end_time::timestamptz
+GROUP BY
+    a.service_id;
+
+-- name: AlertActiveMaintWindow :one
+SELECT
+    id,
+    description
+FROM
+    service_maintenance_windows
+WHERE
+    service_id = $1
+    AND start_time <= now()
+    AND end_time > now()
+ORDER BY
+    end_time DESC
+LIMIT 1;
+
+-- name: AlertSnoozeMany :many
+UPDATE
+    escalation_policy_state state
+SET
+    snooze_until = now() + make_interval(mins => $2)
+FROM
+    alerts a
+WHERE
+    state.alert_id = ANY ($1::bigint[])
+    AND a.id = state.alert_id
+    AND a.status = 'active'
+RETURNING
+    state.alert_id;
+
+-- name: AlertLockSvcByLabel :exec
+SELECT
+    1
+FROM
+    services svc
+    JOIN labels l ON l.tgt_service_id = svc.id
+        AND l.key = @label_key
+        AND (@label_value::text = '*'
+            OR l.value = @label_value)
+WHERE
+    sqlc.narg(service_id)::uuid IS NULL
+    OR svc.id = sqlc.narg(service_id)
+FOR UPDATE
+    OF svc;
+
+-- name: AlertRequiresCloseReasonByLabel :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            alerts a
+            JOIN services svc ON svc.id = a.service_id
+                AND svc.require_close_reason
+            JOIN labels l ON l.tgt_service_id = svc.id
+                AND l.key = @label_key
+                AND (@label_value::text = '*'
+                    OR l.value = @label_value)
+        WHERE (sqlc.narg(service_id)::uuid IS NULL
+            OR svc.id = sqlc.narg(service_id))
+        AND a.status != 'closed');
+
+-- name: AlertUpdateStatusByLabel :many
+UPDATE
+    alerts a
+SET
+    status = @new_status::enum_alert_status,
+    close_reason = coalesce(sqlc.narg(close_reason)::enum_alert_close_reason, a.close_reason)
+FROM
+    labels l
+WHERE
+    l.tgt_service_id = a.service_id
+    AND l.key = @label_key
+    AND (@label_value::text = '*'
+        OR l.value = @label_value)
+    AND (sqlc.narg(service_id)::uuid IS NULL
+        OR a.service_id = sqlc.narg(service_id))
+    AND a.status < @new_status::enum_alert_status
+RETURNING
+    a.id;
+
+-- name: AlertAssign :one
+INSERT INTO alert_assignments(alert_id, user_id)
+    VALUES ($1, $2)
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        user_id = excluded.user_id, assigned_at = now()
+    RETURNING
+        (
+            SELECT
+                name
+            FROM
+                users
+            WHERE
+                id = alert_assignments.user_id) AS user_name;
+
+-- name: AlertAssignNotify :exec
+INSERT INTO notification_policy_cycles(alert_id, user_id)
+SELECT
+    @alert_id::int,
+    @user_id::uuid
+WHERE
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            notification_policy_cycles
+        WHERE
+            alert_id = @alert_id::int
+            AND user_id = @user_id::uuid
+            AND NOT shadow);
+
+-- name: AlertAssignPauseEscalation :exec
+UPDATE
+    escalation_policy_state
+SET
+    next_escalation = greatest(next_escalation, now() + make_interval(mins => $2))
+WHERE
+    alert_id = $1
+    AND last_escalation NOTNULL;
+
+-- name: AlertAssignees :many
+SELECT
+    alert_id,
+    user_id
+FROM
+    alert_assignments
+WHERE
+    alert_id = ANY ($1::bigint[]);
@@ -71,6 +71,16 @@ type SearchOptions struct {
 
 	// NotClosedBefore will omit any alerts closed any time before the provided time.
 	NotClosedBefore time.Time `json:"nc,omitempty"`
+
+	// EscalationExhausted will only include alerts where escalation stopped after
+	// reaching the max notifications of the escalation policy.
+	EscalationExhausted bool `json:"x,omitempty"`
+
+	// Severity, if specified, will restrict alerts to those with a matching severity.
+	Severity []Severity `json:"sv,omitempty"`
+
+	// CorrelationKey, if specified, will restrict alerts to those with a matching correlation key.
+	CorrelationKey string `json:"ck,omitempty"`
 }
 
 type IDFilter struct {
@@ -99,7 +109,13 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 		a.source,
 		a.status,
 		created_at,
-		a.dedup_key
+		a.dedup_key,
+		a.occurrence_count,
+		coalesce(a.last_occurrence, a.created_at),
+		a.severity,
+		a.meta,
+		a.integration_key_id,
+		a.correlation_key
 	FROM alerts a
 	WHERE true
 	{{ if .Omit }}
@@ -113,6 +129,12 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 	{{ if .Status }}
 		AND a.status = any(:status::enum_alert_status[])
 	{{ end }}
+	{{ if .Severity }}
+		AND a.severity = any(:severity::enum_alert_severity[])
+	{{ end }}
+	{{ if .CorrelationKey }}
+		AND a.correlation_key = :correlationKey
+	{{ end }}
 	{{ if .ServiceFilter.Valid }}
 		AND (a.service_id = any(:services)
 			{{ if .NotifiedUserID }}
@@ -146,6 +168,9 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 	{{ if not .NotClosedBefore.IsZero }}
 		AND EXISTS (select 1 from alert_metrics where alert_id = a.id AND closed_at > :notClosedBeforeTime) 
 	{{ end }}
+	{{ if .EscalationExhausted }}
+		AND EXISTS (select 1 from escalation_policy_state where alert_id = a.id AND escalation_exhausted_at notnull)
+	{{ end }}
 	ORDER BY {{.SortStr}}
 	LIMIT {{.Limit}}
 `))
@@ -173,8 +198,10 @@ func (opts renderData) Normalize() (*renderData, error) {
 		validate.Search("Search", opts.Search),
 		validate.Range("Limit", opts.Limit, 0, 1001),
 		validate.Range("Status", len(opts.Status), 0, 3),
-		validate.ManyUUID("Services", opts.ServiceFilter.IDs, 50),
+		validate.Range("Severity", len(opts.Severity), 0, 4),
+		validate.ManyUUID("Services", opts.ServiceFilter.IDs, 100),
 		validate.Range("Omit", len(opts.Omit), 0, 50),
+		validate.Text("CorrelationKey", opts.CorrelationKey, 0, MaxCorrelationKeyLength),
 		validate.OneOf("Sort", opts.Sort, SortModeStatusID, SortModeDateID, SortModeDateIDReverse),
 	)
 	if opts.After.Status != "" {
@@ -190,6 +217,12 @@ func (opts renderData) Normalize() (*renderData, error) {
 			return nil, err
 		}
 	}
+	for i, sev := range opts.Severity {
+		err = validate.OneOf("Severity["+strconv.Itoa(i)+"]", sev, SeverityInfo, SeverityWarning, SeverityCritical, SeverityFatal)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &opts, err
 }
@@ -205,17 +238,23 @@ func (opts renderData) QueryArgs() []sql.NamedArg {
 	for i := range opts.Status {
 		stat[i] = string(opts.Status[i])
 	}
+	sev := make(sqlutil.StringArray, len(opts.Severity))
+	for i := range opts.Severity {
+		sev[i] = string(opts.Severity[i])
+	}
 
 	return []sql.NamedArg{
 		sql.Named("search", opts.Search),
 		sql.Named("searchID", searchID),
 		sql.Named("status", stat),
+		sql.Named("severity", sev),
 		sql.Named("services", sqlutil.UUIDArray(opts.ServiceFilter.IDs)),
 		sql.Named("svcNameMatchIDs", sqlutil.UUIDArray(opts.serviceNameIDs)),
 		sql.Named("afterID", opts.After.ID),
 		sql.Named("afterStatus", opts.After.Status),
 		sql.Named("afterCreated", opts.After.Created),
 		sql.Named("omit", sqlutil.IntArray(opts.Omit)),
+		sql.Named("correlationKey", opts.CorrelationKey),
 		sql.Named("notifiedUserID", opts.NotifiedUserID),
 		sql.Named("beforeTime", opts.Before),
 		sql.Named("notBeforeTime", opts.NotBefore),
@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/validation"
+)
+
+// Severity indicates the impact of an Alert.
+type Severity string
+
+// Severity levels, from least to most severe.
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+	SeverityFatal    Severity = "fatal"
+)
+
+// DefaultSeverity is used for alerts that do not specify a severity.
+const DefaultSeverity = SeverityCritical
+
+// Severities returns all severity levels, from least to most severe.
+func Severities() []Severity {
+	return []Severity{SeverityInfo, SeverityWarning, SeverityCritical, SeverityFatal}
+}
+
+// ParseSeverity parses a severity level, case-insensitive. An empty string returns
+// DefaultSeverity.
+func ParseSeverity(s string) (Severity, error) {
+	if s == "" {
+		return DefaultSeverity, nil
+	}
+
+	sev := Severity(strings.ToLower(s))
+	for _, v := range Severities() {
+		if v == sev {
+			return sev, nil
+		}
+	}
+
+	return "", validation.NewFieldError("Severity", "unknown severity "+s)
+}
+
+// AtLeast returns true if s is as severe or more severe than min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.level() >= min.level()
+}
+
+func (s Severity) level() int {
+	if s == "" {
+		s = DefaultSeverity
+	}
+	for i, v := range Severities() {
+		if v == s {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s Severity) Value() (driver.Value, error) {
+	if s == "" {
+		return string(DefaultSeverity), nil
+	}
+
+	return string(s), nil
+}
+
+func (s *Severity) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*s = Severity(t)
+	case string:
+		*s = Severity(t)
+	case nil:
+		*s = DefaultSeverity
+	default:
+		return fmt.Errorf("could not process unknown type for Severity(%T)", t)
+	}
+	return nil
+}
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (s *Severity) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+
+	*s, err = ParseSeverity(str)
+	return err
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (s Severity) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(s)).MarshalGQL(w)
+}
@@ -0,0 +1,27 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverity(t *testing.T) {
+	sev, err := ParseSeverity("")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityCritical, sev)
+
+	sev, err = ParseSeverity("Warning")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityWarning, sev)
+
+	_, err = ParseSeverity("high")
+	assert.Error(t, err)
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	assert.True(t, SeverityFatal.AtLeast(SeverityCritical))
+	assert.True(t, SeverityInfo.AtLeast(SeverityInfo))
+	assert.False(t, SeverityWarning.AtLeast(SeverityCritical))
+	assert.True(t, Severity("").AtLeast(SeverityCritical))
+}
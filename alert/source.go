@@ -1,8 +1,14 @@
 package alert
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/permission"
 )
 
 // Source is the entity that triggered an alert.
@@ -16,6 +22,7 @@ const (
 	SourcePrometheusAlertmanager Source = "prometheusAlertmanager" // prometheus alertmanager alert
 	SourceManual                 Source = "manual"                 // manually triggered
 	SourceGeneric                Source = "generic"                // generic API
+	SourceOpsGenie               Source = "opsgenie"               // opsgenie-compatible API
 )
 
 func (s Source) Value() (driver.Value, error) {
@@ -37,3 +44,32 @@ func (s *Source) Scan(value interface{}) error {
 	}
 	return nil
 }
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (s *Source) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+
+	*s = Source(str)
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (s Source) MarshalGQL(w io.Writer) {
+	if s == "" {
+		s = SourceManual
+	}
+	graphql.MarshalString(string(s)).MarshalGQL(w)
+}
+
+// integrationKeyID returns the ID of the integration key the request was made with, if any.
+func integrationKeyID(ctx context.Context) sql.NullString {
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeIntegrationKey {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: src.ID, Valid: true}
+}
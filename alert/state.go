@@ -11,4 +11,12 @@ type State struct {
 	StepNumber     int
 	RepeatCount    int
 	LastEscalation time.Time
+
+	// EscalationExhausted indicates escalation stopped after reaching the
+	// max notifications of the escalation policy.
+	EscalationExhausted bool
+
+	// SnoozeUntil, if set, is the time a snoozed alert will return to
+	// triggered if it is still acknowledged.
+	SnoozeUntil *time.Time
 }
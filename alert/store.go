@@ -3,6 +3,7 @@ package alert
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -42,6 +44,11 @@ type Store struct {
 	updateByStatusAndService *sql.Stmt
 	updateByIDAndStatus      *sql.Stmt
 
+	svcRequiresReason   *sql.Stmt
+	svcNameAndReason    *sql.Stmt
+	alertRequiresReason *sql.Stmt
+	closeReason         *sql.Stmt
+
 	noStepsBySvc *sql.Stmt
 
 	epID *sql.Stmt
@@ -49,6 +56,21 @@ type Store struct {
 	escalate *sql.Stmt
 	epState  *sql.Stmt
 	svcInfo  *sql.Stmt
+
+	findArchived *sql.Stmt
+
+	openBySvc         *sql.Stmt
+	closeAllBySvc     *sql.Stmt
+	clearSvcCycles    *sql.Stmt
+	failSvcMessages   *sql.Stmt
+	restartSvcEsc     *sql.Stmt
+	resumeSvcEscTimer *sql.Stmt
+
+	bridgeCaller *sql.Stmt
+	bridgeActive *sql.Stmt
+	bridgeJoin   *sql.Stmt
+	bridgeLeave  *sql.Stmt
+	bridgeSetSID *sql.Stmt
 }
 
 // A Trigger signals that an alert needs to be processed
@@ -76,6 +98,102 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 			, false)
 		`),
 
+		openBySvc: p(`SELECT id FROM alerts WHERE service_id = $1 AND status != 'closed'`),
+		closeAllBySvc: p(`
+			UPDATE alerts
+			SET
+				status = 'closed',
+				close_reason = coalesce($2::enum_alert_close_reason, close_reason)
+			WHERE service_id = $1 AND status != 'closed'
+			RETURNING id
+		`),
+		// alert messages waiting to be sent (or retried) for a paused service are never sent
+		failSvcMessages: p(`
+			UPDATE outgoing_messages
+			SET
+				last_status = 'failed',
+				last_status_at = now(),
+				status_details = 'service paused',
+				cycle_id = null,
+				next_retry_at = null
+			WHERE
+				service_id = $1 AND
+				message_type IN ('alert_notification', 'alert_notification_bundle', 'alert_bridge_invite') AND
+				(
+					last_status = 'pending' OR
+					(last_status = 'failed' AND next_retry_at NOTNULL)
+				)
+		`),
+		clearSvcCycles: p(`
+			DELETE FROM notification_policy_cycles cycle
+			USING alerts a
+			WHERE a.id = cycle.alert_id AND a.service_id = $1
+		`),
+		// start escalation over from the first step for unacknowledged alerts
+		restartSvcEsc: p(`
+			UPDATE escalation_policy_state state
+			SET
+				escalation_policy_step_id = null,
+				escalation_policy_step_number = 0,
+				last_escalation = null,
+				next_escalation = null,
+				loop_count = 0,
+				notification_count = 0,
+				escalation_exhausted_at = null,
+				force_escalation = false
+			FROM alerts a
+			WHERE
+				state.service_id = $1 AND
+				a.id = state.alert_id AND
+				a.status = 'triggered'
+		`),
+		// restart the delay of the current step for alerts that were due to escalate while paused
+		resumeSvcEscTimer: p(`
+			UPDATE escalation_policy_state state
+			SET next_escalation = now() + (cast(step.delay_minutes as text)||' minutes')::interval
+			FROM escalation_policy_steps step
+			WHERE
+				state.service_id = $1 AND
+				step.id = state.escalation_policy_step_id AND
+				state.next_escalation < now()
+		`),
+
+		bridgeCaller: p(`
+			select id, user_id
+			from user_contact_methods
+			where type = 'VOICE' and value = $1 and not disabled
+		`),
+		bridgeActive: p(`
+			select b.ended_at isnull and a.status != 'closed'
+			from alert_conference_bridges b
+			join alerts a on a.id = b.alert_id
+			where b.alert_id = $1
+			for update of b
+		`),
+		bridgeJoin: p(`
+			insert into alert_conference_participants (alert_id, call_sid, user_id, contact_method_id)
+			values ($1, $2, $3, $4)
+			on conflict do nothing
+		`),
+		bridgeLeave: p(`
+			update alert_conference_participants
+			set left_at = now()
+			where alert_id = $1 and call_sid = $2 and left_at isnull
+			returning user_id, contact_method_id
+		`),
+		bridgeSetSID: p(`update alert_conference_bridges set conference_sid = $2 where alert_id = $1 and ended_at isnull`),
+
+		svcRequiresReason: p(`select require_close_reason from services where id = $1`),
+		svcNameAndReason:  p(`select name, require_close_reason from services where id = $1`),
+		closeReason:       p(`select close_reason from alerts where id = $1`),
+		alertRequiresReason: p(`
+			select exists (
+				select 1
+				from alerts a
+				join services svc on svc.id = a.service_id and svc.require_close_reason
+				where a.id = any($1) and a.status != 'closed'
+			)
+		`),
 		lockSvc:      p(`select 1 from services where id = $1 for update`),
 		lockAlertSvc: p(`SELECT 1 FROM services s JOIN alerts a ON a.id = ANY ($1) AND s.id = a.service_id FOR UPDATE`),
 		getStatusAndLockSvc: p(`
@@ -94,7 +212,7 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		`),
 
 		insert: p(`
-			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at
+			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key, severity, meta, integration_key_id, correlation_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at
 		`),
 		update: p("UPDATE alerts SET status = $2 WHERE id = $1"),
 		logs:   p("SELECT timestamp, event, message FROM alert_logs WHERE alert_id = $1"),
@@ -108,30 +226,56 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				a.source,
 				a.status,
 				created_at,
-				a.dedup_key
+				a.dedup_key,
+				a.occurrence_count,
+				coalesce(a.last_occurrence, a.created_at),
+				a.severity,
+				a.meta,
+				a.integration_key_id,
+				a.correlation_key
 			FROM alerts a
 			WHERE a.id = ANY ($1)
 		`),
 		createUpdNew: p(`
 			WITH existing as (
-				SELECT id, summary, details, status, source, created_at, false
-				FROM alerts
+				UPDATE alerts
+				SET
+					occurrence_count = occurrence_count + 1,
+					last_occurrence = now(),
+					severity = greatest(severity, $7),
+					correlation_key = coalesce(correlation_key, $10)
 				WHERE service_id = $3 AND dedup_key = $5
+				RETURNING id, summary, details, status, source, created_at, occurrence_count, last_occurrence, severity, meta, integration_key_id, correlation_key, false
+			), recently_closed as (
+				-- a re-fire of an alert closed within the dedup window is suppressed, not re-created
+				SELECT a.id, a.summary, a.details, a.status, a.source, a.created_at, a.occurrence_count, coalesce(a.last_occurrence, a.created_at), a.severity, a.meta, a.integration_key_id, a.correlation_key, false
+				FROM alert_closed_dedup d
+				JOIN alerts a ON a.id = d.alert_id
+				WHERE
+					d.service_id = $3 AND
+					d.dedup_key = $5 AND
+					d.closed_at > now() - make_interval(secs => $6) AND
+					NOT EXISTS (SELECT 1 FROM existing)
 			), to_insert as (
 				SELECT 1
 				EXCEPT
 				SELECT 1
 				FROM existing
+				EXCEPT
+				SELECT 1
+				FROM recently_closed
 			), inserted as (
 				INSERT INTO alerts (
-					summary, details, service_id, source, dedup_key
+					summary, details, service_id, source, dedup_key, severity, meta, integration_key_id, correlation_key
 				)
-				SELECT $1, $2, $3, $4, $5
+				SELECT $1, $2, $3, $4, $5, $7, $8, $9, $10
 				FROM to_insert
-				RETURNING id, summary, details, status, source, created_at, true
+				RETURNING id, summary, details, status, source, created_at, occurrence_count, created_at, severity, meta, integration_key_id, correlation_key, true
 			)
 			SELECT * FROM existing
 			UNION
+			SELECT * FROM recently_closed
+			UNION
 			SELECT * FROM inserted
 		`),
 		createUpdAck: p(`
@@ -160,7 +304,8 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 			UPDATE
 				alerts
 			SET
-				status = $2
+				status = $2,
+				close_reason = coalesce($3::enum_alert_close_reason, close_reason)
 			WHERE
 				service_id = $1
 			AND (
@@ -169,7 +314,9 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		`),
 		updateByIDAndStatus: p(`			
 			UPDATE alerts
-			SET	status = $1
+			SET
+				status = $1,
+				close_reason = coalesce($3::enum_alert_close_reason, close_reason)
 			WHERE
 				id = ANY ($2) AND 
 				($1 > status)
@@ -185,12 +332,13 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				state.force_escalation = false AND
 				a.id = state.alert_id AND
 				svc.id = a.service_id AND
-				svc.maintenance_expires_at ISNULL
+				svc.maintenance_expires_at ISNULL AND
+				svc.paused_at ISNULL
 			RETURNING state.alert_id
 		`),
 
 		epState: p(`
-			SELECT alert_id, last_escalation, loop_count, escalation_policy_step_number 
+			SELECT alert_id, last_escalation, loop_count, escalation_policy_step_number, escalation_exhausted_at notnull, snooze_until
 			FROM escalation_policy_state
 			WHERE alert_id = ANY ($1)
 		`),
@@ -202,6 +350,12 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 			FROM services
 			WHERE id = $1
 		`),
+
+		findArchived: p(`
+			SELECT id, service_id, service_name, summary, details, source, severity, created_at, archived_at, logs
+			FROM alert_archives
+			WHERE id = $1
+		`),
 	}, prep.Err
 }
 
@@ -264,7 +418,7 @@ func (s *Store) canTouchAlert(ctx context.Context, alertID int) error {
 // EscalateAsOf will request escalation for the given alert ID as-of the given time.
 //
 // An error will be returned if the alert is already closed, if the service is
-// in maintenance mode, there are no steps on the escalation policy, or if the
+// in maintenance mode or paused, there are no steps on the escalation policy, or if the
 // alert has already been escalated since the given time.
 func (s *Store) EscalateAsOf(ctx context.Context, id int, t time.Time) error {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
@@ -291,6 +445,9 @@ func (s *Store) EscalateAsOf(ctx context.Context, id int, t time.Time) error {
 	if lck.IsMaintMode {
 		return validation.NewGenericError("service is in maintenance mode")
 	}
+	if lck.IsPaused {
+		return validation.NewGenericError("service is paused")
+	}
 
 	if t.IsZero() {
 		t, err = gadb.New(tx).Now(ctx)
@@ -360,6 +517,94 @@ func (s *Store) EscalateMany(ctx context.Context, alertIDs []int) ([]int, error)
 }
 
 func (s *Store) UpdateStatusByService(ctx context.Context, serviceID string, status Status) error {
+	return s.updateStatusByService(ctx, serviceID, status, "", false)
+}
+
+// CloseByService will close all open alerts for a service, recording the reason. An
+// error is returned if no reason is provided and the service requires one.
+func (s *Store) CloseByService(ctx context.Context, serviceID string, reason CloseReason) error {
+	return s.updateStatusByService(ctx, serviceID, StatusClosed, reason, true)
+}
+
+// CloseAllByServiceTx will close every open alert for a service, logging a close entry for
+// each, and return the number of alerts closed. It is restricted to admins.
+//
+// To guard against accidents, confirm must match the name of the service. An error is
+// returned if no reason is provided and the service requires one.
+func (s *Store) CloseAllByServiceTx(ctx context.Context, tx *sql.Tx, serviceID, confirm string, reason CloseReason) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+
+	err = validate.Many(
+		validate.UUID("ServiceID", serviceID),
+		reason.validate(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, serviceID)
+	if err != nil {
+		return 0, err
+	}
+
+	var name string
+	var required bool
+	err = tx.StmtContext(ctx, s.svcNameAndReason).QueryRowContext(ctx, serviceID).Scan(&name, &required)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, validation.NewFieldError("ServiceID", "service not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if confirm != name {
+		return 0, validation.NewFieldError("Confirm", "must match the service name")
+	}
+	if required && reason == "" {
+		return 0, errReasonRequired
+	}
+
+	rows, err := tx.StmtContext(ctx, s.closeAllBySvc).QueryContext(ctx, serviceID, closeReasonArg(reason))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var meta interface{}
+	if reason != "" {
+		meta = &alertlog.AutoClose{Reason: string(reason)}
+	}
+	err = s.logDB.LogManyTx(ctx, tx, ids, alertlog.TypeClosed, meta)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// errReasonRequired is returned when closing alerts without a reason for a service that requires one.
+var errReasonRequired = validation.NewFieldError("CloseReason", "a close reason is required for this service")
+
+func closeReasonArg(reason CloseReason) sql.NullString {
+	return sql.NullString{String: string(reason), Valid: reason != ""}
+}
+
+func (s *Store) updateStatusByService(ctx context.Context, serviceID string, status Status, reason CloseReason, checkReason bool) error {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
 	if err != nil {
 		return err
@@ -370,7 +615,10 @@ func (s *Store) UpdateStatusByService(ctx context.Context, serviceID string, sta
 		return err
 	}
 
-	err = validate.OneOf("Status", status, StatusActive, StatusClosed)
+	err = validate.Many(
+		validate.OneOf("Status", status, StatusActive, StatusClosed),
+		reason.validate(),
+	)
 	if err != nil {
 		return err
 	}
@@ -381,30 +629,259 @@ func (s *Store) UpdateStatusByService(ctx context.Context, serviceID string, sta
 	}
 	defer sqlutil.Rollback(ctx, "alert: update status by service", tx)
 
+	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+
+	if checkReason && reason == "" {
+		var required bool
+		err = tx.StmtContext(ctx, s.svcRequiresReason).QueryRowContext(ctx, serviceID).Scan(&required)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if required {
+			return errReasonRequired
+		}
+	}
+
 	t := alertlog.TypeAcknowledged
+	var meta interface{}
 	if status == StatusClosed {
 		t = alertlog.TypeClosed
+		if reason != "" {
+			meta = &alertlog.AutoClose{Reason: string(reason)}
+		}
 	}
 
-	err = s.logDB.LogServiceTx(ctx, tx, serviceID, t, nil)
+	err = s.logDB.LogServiceTx(ctx, tx, serviceID, t, meta)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, serviceID)
+	_, err = tx.StmtContext(ctx, s.updateByStatusAndService).ExecContext(ctx, serviceID, status, closeReasonArg(reason))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Store) openAlertIDsBySvcTx(ctx context.Context, tx *sql.Tx, serviceID string) ([]int, error) {
+	rows, err := tx.StmtContext(ctx, s.openBySvc).QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// PauseServiceTx will stop notifications for the open alerts of a service that was just paused,
+// including any already waiting to be sent, logging an entry for each. Escalation of the service's alerts is suspended by the engine
+// while the service is paused.
+func (s *Store) PauseServiceTx(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.StmtContext(ctx, s.updateByStatusAndService).ExecContext(ctx, serviceID, status)
+	err = validate.UUID("ServiceID", serviceID)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	_, err = tx.StmtContext(ctx, s.clearSvcCycles).ExecContext(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("end notification cycles: %w", err)
+	}
+
+	_, err = tx.StmtContext(ctx, s.failSvcMessages).ExecContext(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("fail pending messages: %w", err)
+	}
+
+	ids, err := s.openAlertIDsBySvcTx(ctx, tx, serviceID)
+	if err != nil {
+		return fmt.Errorf("find open alerts: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.logDB.LogManyTx(ctx, tx, ids, alertlog.TypeServicePaused, nil)
+}
+
+// ResumeServiceTx will resume escalation for the open alerts of a service that was just
+// resumed, logging an entry for each.
+//
+// If renotify is true, unacknowledged alerts start escalating again from the first step.
+// Otherwise, alerts continue from their current step once its delay has passed again. Alerts
+// created while the service was paused start escalating either way.
+func (s *Store) ResumeServiceTx(ctx context.Context, tx *sql.Tx, serviceID string, renotify bool) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.resumeSvcEscTimer
+	if renotify {
+		stmt = s.restartSvcEsc
+	}
+	_, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("resume escalation: %w", err)
+	}
+
+	ids, err := s.openAlertIDsBySvcTx(ctx, tx, serviceID)
+	if err != nil {
+		return fmt.Errorf("find open alerts: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.logDB.LogManyTx(ctx, tx, ids, alertlog.TypeServiceResumed, alertlog.ServiceResumedMetaData{Renotify: renotify})
+}
+
+// UpdateStatusByLabel will update the status of all open alerts for services with
+// the given label key and value. A value of "*" matches any value.
+//
+// When closing, the reason is recorded for each alert, and an error is returned if no
+// reason is provided and any of the open alerts belong to a service that requires one.
+//
+// If the context is limited to a single service, only alerts from that
+// service are updated. The number of alerts updated is returned.
+func (s *Store) UpdateStatusByLabel(ctx context.Context, key, value string, status Status, reason CloseReason) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return 0, err
+	}
+
+	if value == "" {
+		value = "*"
+	}
+	err = validate.Many(
+		validate.LabelKey("LabelKey", key),
+		validate.OneOf("Status", status, StatusActive, StatusClosed),
+		reason.validate(),
+	)
+	if status != StatusClosed {
+		// a reason only applies when closing
+		reason = ""
+	}
+	if value != "*" {
+		err = validate.Many(err, validate.LabelValue("LabelValue", value))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var svcID uuid.NullUUID
+	if permission.Service(ctx) {
+		svcID.UUID, svcID.Valid = uuid.MustParse(permission.ServiceID(ctx)), true
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer sqlutil.Rollback(ctx, "alert: update status by label", tx)
+
+	q := gadb.New(tx)
+	err = q.AlertLockSvcByLabel(ctx, gadb.AlertLockSvcByLabelParams{
+		LabelKey:   key,
+		LabelValue: value,
+		ServiceID:  svcID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "lock services")
+	}
+
+	if status == StatusClosed && reason == "" {
+		required, err := q.AlertRequiresCloseReasonByLabel(ctx, gadb.AlertRequiresCloseReasonByLabelParams{
+			LabelKey:   key,
+			LabelValue: value,
+			ServiceID:  svcID,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "check close reason")
+		}
+		if required {
+			return 0, errReasonRequired
+		}
+	}
+
+	updated, err := q.AlertUpdateStatusByLabel(ctx, gadb.AlertUpdateStatusByLabelParams{
+		NewStatus:   gadb.EnumAlertStatus(status),
+		CloseReason: gadb.NullEnumAlertCloseReason{EnumAlertCloseReason: gadb.EnumAlertCloseReason(reason), Valid: reason != ""},
+		LabelKey:    key,
+		LabelValue:  value,
+		ServiceID:   svcID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "update alert status")
+	}
+
+	ids := make([]int, 0, len(updated))
+	for _, id := range updated {
+		ids = append(ids, int(id))
+	}
+
+	t := alertlog.TypeAcknowledged
+	var meta interface{}
+	if status == StatusClosed {
+		t = alertlog.TypeClosed
+		if reason != "" {
+			meta = &alertlog.AutoClose{Reason: string(reason)}
+		}
+	}
+	err = s.logDB.LogManyTx(ctx, tx, ids, t, meta)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
 }
 
 func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertIDs []int, logMeta interface{}) ([]int, error) {
+	return s.updateManyAlertStatus(ctx, status, alertIDs, "", logMeta, false)
+}
+
+// CloseMany will close the given alerts, recording the reason. An error is returned if no
+// reason is provided and any of the open alerts belong to a service that requires one.
+//
+// The IDs of all alerts that were closed are returned.
+func (s *Store) CloseMany(ctx context.Context, alertIDs []int, reason CloseReason) ([]int, error) {
+	var meta interface{}
+	if reason != "" {
+		meta = &alertlog.AutoClose{Reason: string(reason)}
+	}
+
+	return s.updateManyAlertStatus(ctx, StatusClosed, alertIDs, reason, meta, true)
+}
+
+func (s *Store) updateManyAlertStatus(ctx context.Context, status Status, alertIDs []int, reason CloseReason, logMeta interface{}, checkReason bool) ([]int, error) {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
 	if err != nil {
 		return nil, err
@@ -417,6 +894,7 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 	err = validate.Many(
 		validate.Range("AlertIDs", len(alertIDs), 1, maxBatch),
 		validate.OneOf("Status", status, StatusActive, StatusClosed),
+		reason.validate(),
 	)
 	if err != nil {
 		return nil, err
@@ -442,7 +920,18 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 		return nil, err
 	}
 
-	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, status, ids)
+	if checkReason && reason == "" {
+		var required bool
+		err = tx.StmtContext(ctx, s.alertRequiresReason).QueryRowContext(ctx, ids).Scan(&required)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			return nil, errReasonRequired
+		}
+	}
+
+	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, status, ids, closeReasonArg(reason))
 	if err != nil {
 		return nil, err
 	}
@@ -470,6 +959,97 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 	return updatedIDs, nil
 }
 
+// MaxSnoozeDuration is the longest an alert may be snoozed for.
+const MaxSnoozeDuration = 24 * time.Hour
+
+// SnoozeMany will acknowledge the given alerts and suppress escalation until dur has elapsed,
+// after which the engine will return them to triggered if they are still acknowledged.
+//
+// The IDs of all alerts that were snoozed are returned.
+func (s *Store) SnoozeMany(ctx context.Context, alertIDs []int, dur time.Duration) ([]int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(alertIDs) == 0 {
+		return nil, nil
+	}
+
+	err = validate.Many(
+		validate.Range("AlertIDs", len(alertIDs), 1, maxBatch),
+		validate.Duration("Duration", dur, time.Minute, MaxSnoozeDuration),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := sqlutil.IntArray(alertIDs)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "alert: snooze", tx)
+
+	_, err = tx.StmtContext(ctx, s.lockAlertSvc).ExecContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, StatusActive, ids, closeReasonArg(""))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ackedIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ackedIDs = append(ackedIDs, id)
+	}
+
+	err = s.logDB.LogManyTx(ctx, tx, ackedIDs, alertlog.TypeAcknowledged, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snoozeIDs := make([]int64, 0, len(alertIDs))
+	for _, id := range alertIDs {
+		snoozeIDs = append(snoozeIDs, int64(id))
+	}
+
+	mins := int(dur / time.Minute)
+	snoozed, err := gadb.New(tx).AlertSnoozeMany(ctx, gadb.AlertSnoozeManyParams{
+		Column1: snoozeIDs,
+		Mins:    int32(mins),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "set snooze time")
+	}
+
+	snoozedIDs := make([]int, 0, len(snoozed))
+	for _, id := range snoozed {
+		snoozedIDs = append(snoozedIDs, int(id))
+	}
+
+	err = s.logDB.LogManyTx(ctx, tx, snoozedIDs, alertlog.TypeSnoozed, &alertlog.SnoozeMetaData{DurationMinutes: mins})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return snoozedIDs, nil
+}
+
 func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 	n, err := a.Normalize() // validation
 	if err != nil {
@@ -500,6 +1080,11 @@ func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 		return nil, err
 	}
 
+	err = s.defaultLabelsTx(ctx, tx, n)
+	if err != nil {
+		return nil, err
+	}
+
 	n, meta, err := s._create(ctx, tx, *n)
 	if err != nil {
 		return nil, err
@@ -507,6 +1092,13 @@ func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 
 	s.logDB.MustLogTx(ctx, tx, n.ID, alertlog.TypeCreated, meta)
 
+	if n.Status == StatusTriggered {
+		err = s.maintenanceAckTx(ctx, tx, n)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -521,11 +1113,17 @@ func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 
 func (s *Store) _create(ctx context.Context, tx *sql.Tx, a Alert) (*Alert, *alertlog.CreatedMetaData, error) {
 	var meta alertlog.CreatedMetaData
-	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey())
+	ikeyID := integrationKeyID(ctx)
+	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey(), a.Severity, a.Meta, ikeyID, a.correlationKeyArg())
 	err := row.Scan(&a.ID, &a.CreatedAt)
 	if err != nil {
 		return nil, nil, err
 	}
+	a.IntegrationKeyID = ikeyID.String
+	meta.Source = string(a.Source)
+	meta.IntegrationKeyID = a.IntegrationKeyID
+	a.Occurrences = 1
+	a.LastOccurrence = a.CreatedAt
 
 	err = tx.StmtContext(ctx, s.noStepsBySvc).QueryRowContext(ctx, a.ServiceID).Scan(&meta.EPNoSteps)
 	if err != nil {
@@ -573,20 +1171,41 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 	var meta interface{}
 	switch n.Status {
 	case StatusTriggered:
+		n, err = s.rateLimitTx(ctx, tx, n)
+		if err != nil {
+			return nil, false, err
+		}
+		err = s.dedupWindowTx(ctx, tx, n)
+		if err != nil {
+			return nil, false, err
+		}
+		err = s.defaultLabelsTx(ctx, tx, n)
+		if err != nil {
+			return nil, false, err
+		}
+
 		var m alertlog.CreatedMetaData
+		var ikeyID, corrKey sql.NullString
 		err = tx.Stmt(s.createUpdNew).
-			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &inserted)
+			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey(), n.DedupWindow.Seconds(), n.Severity, n.Meta, integrationKeyID(ctx), n.correlationKeyArg()).
+			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &n.Occurrences, &n.LastOccurrence, &n.Severity, &n.Meta, &ikeyID, &corrKey, &inserted)
+		n.IntegrationKeyID = ikeyID.String
+		n.CorrelationKey = corrKey.String
 		if !inserted {
+			// matched an open alert, or one closed within the dedup window (a re-fire is
+			// not alerted on again until the window has passed)
 			logType = alertlog.TypeDuplicateSupressed
+			meta = &alertlog.DuplicateSuppressedMetaData{AlertClosed: n.Status == StatusClosed}
 		} else {
 			logType = alertlog.TypeCreated
+			m.Source = string(n.Source)
+			m.IntegrationKeyID = n.IntegrationKeyID
 			stepErr := tx.StmtContext(ctx, s.noStepsBySvc).QueryRowContext(ctx, n.ServiceID).Scan(&m.EPNoSteps)
 			if stepErr != nil {
 				return nil, false, err
 			}
+			meta = &m
 		}
-		meta = &m
 	case StatusActive:
 		var oldStatus Status
 		err = tx.Stmt(s.createUpdAck).
@@ -611,10 +1230,191 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 	if logType != "" {
 		s.logDB.MustLogTx(ctx, tx, n.ID, logType, meta)
 	}
+	if inserted {
+		err = s.maintenanceAckTx(ctx, tx, n)
+		if err != nil {
+			return nil, false, err
+		}
+	}
 
 	return n, inserted, nil
 }
 
+// rateLimitTx will return the alert to create or update in place of a. If the integration key
+// that is creating it has exceeded its alert rate limit, a is dropped and a single "rate limited"
+// alert for the key is returned instead.
+//
+// Alerts that would be de-duplicated into an existing open alert are never dropped.
+func (s *Store) rateLimitTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, error) {
+	keyID := integrationKeyID(ctx)
+	if !keyID.Valid {
+		return a, nil
+	}
+	keyUUID, err := uuid.Parse(keyID.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse integration key ID")
+	}
+
+	q := gadb.New(tx)
+	rl, err := q.AlertIntKeyRateLimit(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no limit configured
+		return a, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup integration key rate limit")
+	}
+	if rl.RecentCount < rl.AlertRateLimit {
+		return a, nil
+	}
+
+	dedup, err := a.DedupKey().Value()
+	if err != nil {
+		return nil, err
+	}
+	hasOpen, err := q.AlertHasOpenDedup(ctx, gadb.AlertHasOpenDedupParams{
+		ServiceID: uuid.NullUUID{UUID: uuid.MustParse(a.ServiceID), Valid: true},
+		DedupKey:  sql.NullString{String: dedup.(string), Valid: true},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "check for open alert")
+	}
+	if hasOpen {
+		return a, nil
+	}
+
+	dropped, err := q.AlertIntKeyDropAlert(ctx, keyUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "record dropped alert")
+	}
+	log.Logf(log.WithFields(ctx, log.Fields{
+		"IntegrationKeyID":  keyID.String,
+		"AlertRateLimit":    rl.AlertRateLimit,
+		"DroppedAlertCount": dropped.DroppedAlertCount,
+	}), "alert dropped, integration key rate limit exceeded")
+
+	return Alert{
+		Summary: validate.SanitizeText(fmt.Sprintf("Integration key '%s' is rate limited, new alerts are being dropped", dropped.Name), MaxSummaryLength),
+		Details: fmt.Sprintf("Integration key '%s' created more than %d alerts in the last minute, so new alerts from it are being dropped.\n\n"+
+			"The number of dropped alerts is shown on the integration key.", dropped.Name, rl.AlertRateLimit),
+		ServiceID: a.ServiceID,
+		Source:    a.Source,
+		Status:    StatusTriggered,
+		Dedup:     &DedupID{Type: DedupTypeRateLimit, Version: 1, Payload: keyID.String},
+	}.Normalize()
+}
+
+// dedupWindowTx will apply the DedupWindow of a, falling back to the dedup window of the
+// integration key creating a if none was provided.
+//
+// An open alert with the same dedup key that last occurred outside the window is closed, so that a
+// new alert is created instead. Otherwise, the window is left in place for createUpdNew to match
+// recently closed alerts.
+func (s *Store) dedupWindowTx(ctx context.Context, tx *sql.Tx, a *Alert) error {
+	q := gadb.New(tx)
+	if a.DedupWindow == 0 {
+		keyID := integrationKeyID(ctx)
+		if !keyID.Valid {
+			return nil
+		}
+		keyUUID, err := uuid.Parse(keyID.String)
+		if err != nil {
+			return errors.Wrap(err, "parse integration key ID")
+		}
+
+		mins, err := q.AlertIntKeyDedupWindow(ctx, keyUUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// no window configured
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "lookup integration key dedup window")
+		}
+		a.DedupWindow = time.Duration(mins) * time.Minute
+	}
+
+	dedup, err := a.DedupKey().Value()
+	if err != nil {
+		return err
+	}
+	staleID, err := q.AlertCloseStaleDedup(ctx, gadb.AlertCloseStaleDedupParams{
+		ServiceID:     uuid.NullUUID{UUID: uuid.MustParse(a.ServiceID), Valid: true},
+		DedupKey:      sql.NullString{String: dedup.(string), Valid: true},
+		WindowSeconds: a.DedupWindow.Seconds(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "close stale alert")
+	}
+
+	s.logDB.MustLogTx(ctx, tx, int(staleID), alertlog.TypeClosed, &alertlog.AutoClose{
+		DedupWindowSeconds: int(a.DedupWindow / time.Second),
+	})
+
+	// the stale alert was just closed, so it must not be treated as recently closed
+	a.DedupWindow = 0
+	return nil
+}
+
+// defaultLabelsTx will add the default labels of the integration key creating a to its
+// metadata. Metadata provided with the alert takes precedence, and the merged result must
+// be within the metadata limits.
+func (s *Store) defaultLabelsTx(ctx context.Context, tx *sql.Tx, a *Alert) error {
+	keyID := integrationKeyID(ctx)
+	if !keyID.Valid {
+		return nil
+	}
+	keyUUID, err := uuid.Parse(keyID.String)
+	if err != nil {
+		return errors.Wrap(err, "parse integration key ID")
+	}
+
+	data, err := gadb.New(tx).AlertIntKeyDefaultLabels(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no default labels
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "lookup integration key default labels")
+	}
+
+	var labels Meta
+	err = json.Unmarshal(data.RawMessage, &labels)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal default labels")
+	}
+
+	a.Meta = a.Meta.Merge(labels)
+	return validateMeta("Meta", a.Meta)
+}
+
+// maintenanceAckTx will acknowledge a newly created alert if its service has an
+// active maintenance window, so that no notifications are sent for it.
+func (s *Store) maintenanceAckTx(ctx context.Context, tx *sql.Tx, a *Alert) error {
+	win, err := gadb.New(tx).AlertActiveMaintWindow(ctx, uuid.MustParse(a.ServiceID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "lookup active maintenance window")
+	}
+
+	_, err = tx.StmtContext(ctx, s.update).ExecContext(ctx, a.ID, StatusActive)
+	if err != nil {
+		return errors.Wrap(err, "acknowledge alert for maintenance window")
+	}
+	a.Status = StatusActive
+
+	s.logDB.MustLogTx(ctx, tx, a.ID, alertlog.TypeMaintenanceSuppressed, &alertlog.MaintenanceSuppressedMetaData{
+		MaintenanceWindowID: win.ID.String(),
+		Description:         win.Description,
+	})
+
+	return nil
+}
+
 // CreateOrUpdate will create an alert or log a "duplicate suppressed message" if
 // Status is Triggered. If Status is Closed, it will close and return the result.
 //
@@ -709,6 +1509,22 @@ func (s *Store) UpdateStatus(ctx context.Context, id int, stat Status) error {
 	return tx.Commit()
 }
 
+// CloseReason returns the reason provided when the alert was closed, if any.
+func (s *Store) CloseReason(ctx context.Context, id int) (CloseReason, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return "", err
+	}
+
+	var reason sql.NullString
+	err = s.closeReason.QueryRowContext(ctx, id).Scan(&reason)
+	if err != nil {
+		return "", err
+	}
+
+	return CloseReason(reason.String), nil
+}
+
 func (s *Store) FindOne(ctx context.Context, id int) (*Alert, error) {
 	alerts, err := s.FindMany(ctx, []int{id})
 	if err != nil {
@@ -766,7 +1582,7 @@ func (s *Store) State(ctx context.Context, alertIDs []int) ([]State, error) {
 		return nil, err
 	}
 
-	var t sqlutil.NullTime
+	var t, snooze sqlutil.NullTime
 	rows, err := s.epState.QueryContext(ctx, sqlutil.IntArray(alertIDs))
 	if errors.Is(err, sql.ErrNoRows) {
 		err = nil
@@ -779,10 +1595,14 @@ func (s *Store) State(ctx context.Context, alertIDs []int) ([]State, error) {
 	list := make([]State, 0, len(alertIDs))
 	for rows.Next() {
 		var s State
-		err = rows.Scan(&s.ID, &t, &s.RepeatCount, &s.StepNumber)
+		err = rows.Scan(&s.ID, &t, &s.RepeatCount, &s.StepNumber, &s.EscalationExhausted, &snooze)
 		if t.Valid {
 			s.LastEscalation = t.Time
 		}
+		if snooze.Valid {
+			until := snooze.Time
+			s.SnoozeUntil = &until
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -802,7 +1622,7 @@ func (s *Store) Feedback(ctx context.Context, alertIDs []int) ([]Feedback, error
 		return nil, err
 	}
 
-	ids := make([]int32, len(alertIDs))
+	ids := make([]int32, 0, len(alertIDs))
 	for _, id := range alertIDs {
 		ids = append(ids, int32(id))
 	}
@@ -817,25 +1637,63 @@ func (s *Store) Feedback(ctx context.Context, alertIDs []int) ([]Feedback, error
 	var result []Feedback
 
 	for _, r := range rows {
-		result = append(result, Feedback{ID: int(r.AlertID), NoiseReason: r.NoiseReason})
+		f := Feedback{
+			ID:          int(r.AlertID),
+			Value:       FeedbackValue(r.Feedback),
+			NoiseReason: r.NoiseReason,
+			UpdatedAt:   r.UpdatedAt,
+		}
+		if r.UserID.Valid {
+			f.UserID = r.UserID.UUID.String()
+		}
+		result = append(result, f)
 	}
 	return result, nil
 }
 
+// UpdateFeedback sets the feedback for an alert, recording the current user (if any) as
+// the one that provided it. Setting only a NoiseReason implies FeedbackNoise.
+//
+// Feedback is informational and does not change the status of the alert.
 func (s Store) UpdateFeedback(ctx context.Context, feedback *Feedback) error {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
 	if err != nil {
 		return err
 	}
 
-	err = validate.Text("NoiseReason", feedback.NoiseReason, 1, 255)
+	val := feedback.Value
+	if val == "" && feedback.NoiseReason != "" {
+		val = FeedbackNoise
+	}
+	err = val.validate()
+	if err != nil {
+		return err
+	}
+	switch {
+	case feedback.NoiseReason == "":
+	case val == FeedbackActionable:
+		err = validation.NewFieldError("NoiseReason", "only allowed for noise feedback")
+	default:
+		err = validate.Text("NoiseReason", feedback.NoiseReason, 1, 255)
+	}
 	if err != nil {
 		return err
 	}
 
+	var userID uuid.NullUUID
+	if permission.User(ctx) {
+		userID.UUID, err = uuid.Parse(permission.UserID(ctx))
+		if err != nil {
+			return err
+		}
+		userID.Valid = true
+	}
+
 	err = gadb.New(s.db).SetAlertFeedback(ctx, gadb.SetAlertFeedbackParams{
 		AlertID:     int64(feedback.ID),
+		Feedback:    gadb.EnumAlertFeedback(val),
 		NoiseReason: feedback.NoiseReason,
+		UserID:      userID,
 	})
 	if err != nil {
 		return err
@@ -843,3 +1701,48 @@ func (s Store) UpdateFeedback(ctx context.Context, feedback *Feedback) error {
 
 	return nil
 }
+
+// FeedbackStats returns a summary of feedback for alerts created within [start, end) for
+// each of the given services. Services without any alerts in the range are omitted.
+func (s *Store) FeedbackStats(ctx context.Context, serviceIDs []string, start, end time.Time) ([]FeedbackStats, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Many(
+		validate.Range("ServiceIDs", len(serviceIDs), 1, maxBatch),
+		validate.ManyUUID("ServiceIDs", serviceIDs, maxBatch),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, validation.NewFieldError("End", "must be after start")
+	}
+
+	ids := make([]uuid.UUID, 0, len(serviceIDs))
+	for _, id := range serviceIDs {
+		ids = append(ids, uuid.MustParse(id))
+	}
+
+	rows, err := gadb.New(s.db).AlertFeedbackStats(ctx, gadb.AlertFeedbackStatsParams{
+		ServiceIds: ids,
+		StartTime:  start,
+		EndTime:    end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FeedbackStats, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, FeedbackStats{
+			ServiceID:       r.ServiceID.UUID.String(),
+			AlertCount:      int(r.AlertCount),
+			ActionableCount: int(r.ActionableCount),
+			NoiseCount:      int(r.NoiseCount),
+		})
+	}
+
+	return result, nil
+}
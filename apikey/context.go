@@ -1,11 +1,19 @@
 package apikey
 
-import "context"
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 type contextKey int
 
 const (
 	contextKeyPolicy contextKey = iota
+	contextKeyServiceFilter
+	contextKeyReadOnly
 )
 
 // PolicyFromContext returns the Policy associated with the given context.
@@ -18,3 +26,42 @@ func PolicyFromContext(ctx context.Context) *GQLPolicy {
 func ContextWithPolicy(ctx context.Context, p *GQLPolicy) context.Context {
 	return context.WithValue(ctx, contextKeyPolicy, p)
 }
+
+// ContextWithServiceFilter returns a new context that is restricted to the given service IDs.
+func ContextWithServiceFilter(ctx context.Context, ids []uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKeyServiceFilter, ids)
+}
+
+// ServiceFilterFromContext returns the service IDs the context is restricted to, if any.
+//
+// If the context is not restricted, ok will be false.
+func ServiceFilterFromContext(ctx context.Context) (ids []uuid.UUID, ok bool) {
+	ids, ok = ctx.Value(contextKeyServiceFilter).([]uuid.UUID)
+	return ids, ok
+}
+
+// ServiceAllowed returns true if the context is allowed to access the given service ID.
+func ServiceAllowed(ctx context.Context, serviceID string) bool {
+	ids, ok := ServiceFilterFromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	id, err := uuid.Parse(strings.TrimSpace(serviceID))
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(ids, id)
+}
+
+// ContextWithReadOnly returns a new context that is marked as read-only.
+func ContextWithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyReadOnly, true)
+}
+
+// IsReadOnly returns true if the context was marked as read-only (e.g., by a read-only API key).
+func IsReadOnly(ctx context.Context) bool {
+	ro, _ := ctx.Value(contextKeyReadOnly).(bool)
+	return ro
+}
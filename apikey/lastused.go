@@ -5,15 +5,18 @@ import (
 	"net"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/validation/validate"
 )
 
 // _updateLastUsed will record usage for the given API key ID, user agent, and IP address.
+//
+// If usage history is enabled, an entry will also be appended to the key's history. Older
+// entries beyond the configured limit are removed by the cleanup manager.
 func (s *Store) _updateLastUsed(ctx context.Context, id uuid.UUID, ua, ip string) error {
 	ua = validate.SanitizeText(ua, 1024)
-	ip, _, _ = net.SplitHostPort(ip)
-	ip = validate.SanitizeText(ip, 255)
+	ip = validate.SanitizeText(remoteHost(ip), 255)
 	params := gadb.APIKeyRecordUsageParams{
 		KeyID:     id,
 		UserAgent: ua,
@@ -23,5 +26,30 @@ func (s *Store) _updateLastUsed(ctx context.Context, id uuid.UUID, ua, ip string
 	if params.IpAddress.IPNet.IP != nil {
 		params.IpAddress.Valid = true
 	}
-	return gadb.New(s.db).APIKeyRecordUsage(ctx, params)
+	err := gadb.New(s.db).APIKeyRecordUsage(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	keep := config.FromContext(ctx).Maintenance.GQLAPIKeyUsageHistoryRows
+	if keep <= 0 {
+		return nil
+	}
+
+	return gadb.New(s.db).APIKeyInsertUsageHistory(ctx, gadb.APIKeyInsertUsageHistoryParams(params))
+}
+
+// remoteHost returns the host portion of a remote address, which may or may not include a port.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// parseRemoteIP will parse the IP address from a remote address, returning nil if it is invalid.
+func parseRemoteIP(addr string) net.IP {
+	return net.ParseIP(remoteHost(addr))
 }
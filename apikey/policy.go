@@ -1,10 +1,81 @@
 package apikey
 
-import "github.com/target/goalert/permission"
+import (
+	"net"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+)
 
 // GQLPolicy is a GraphQL API key policy.
+//
+// Version 1 policies only restrict allowed fields and role. Version 2 policies
+// may additionally restrict access to a set of services.
 type GQLPolicy struct {
 	Version       int
 	AllowedFields []string
 	Role          permission.Role
+
+	// ServiceIDs, if set, limits the key to only access the given services (version 2+).
+	ServiceIDs []uuid.UUID `json:",omitempty"`
+
+	// AllowedCIDRs, if set, limits the key to only be used from the given networks.
+	AllowedCIDRs []string `json:",omitempty"`
+
+	// ReadOnly, if set, prevents the key from being used to execute mutations.
+	ReadOnly bool `json:",omitempty"`
+
+	// RateLimit, if positive, is the maximum number of requests per minute allowed for the key.
+	RateLimit int `json:",omitempty"`
+
+	// MaxComplexity, if positive, is used in place of the configured GraphQL complexity limit for the key.
+	MaxComplexity int `json:",omitempty"`
+
+	// TokenVersion is incremented each time the key is rotated, changing the policy hash
+	// so that previously issued tokens are no longer valid.
+	TokenVersion int `json:",omitempty"`
+}
+
+// IsSupportedVersion returns true if the policy version is understood by this version of GoAlert.
+func (p GQLPolicy) IsSupportedVersion() bool {
+	return p.Version == 1 || p.Version == 2
+}
+
+// AllowsIP returns true if the given IP address is permitted by the policy's AllowedCIDRs.
+//
+// If no CIDRs are configured, all addresses are allowed.
+func (p GQLPolicy) AllowsIP(ip net.IP) bool {
+	if len(p.AllowedCIDRs) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+
+	for _, c := range p.AllowedCIDRs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			continue
+		}
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StaleFields returns any AllowedFields that no longer exist in the current GraphQL schema.
+func (p GQLPolicy) StaleFields() []string {
+	var stale []string
+	for _, f := range p.AllowedFields {
+		if _, ok := slices.BinarySearch(graphql2.SchemaFields(), f); ok {
+			continue
+		}
+		stale = append(stale, f)
+	}
+
+	return stale
 }
@@ -0,0 +1,34 @@
+package apikey
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGQLPolicy_AllowsIP(t *testing.T) {
+	var p GQLPolicy
+	assert.True(t, p.AllowsIP(net.ParseIP("10.1.2.3")), "no CIDRs should allow all")
+
+	p.AllowedCIDRs = []string{"10.0.0.0/8", "2001:db8::/32"}
+	assert.True(t, p.AllowsIP(net.ParseIP("10.1.2.3")))
+	assert.True(t, p.AllowsIP(net.ParseIP("2001:db8::1")))
+	assert.False(t, p.AllowsIP(net.ParseIP("192.168.1.1")))
+	assert.False(t, p.AllowsIP(nil))
+}
+
+func TestParseRemoteIP(t *testing.T) {
+	assert.Equal(t, "10.1.2.3", parseRemoteIP("10.1.2.3:1234").String())
+	assert.Equal(t, "10.1.2.3", parseRemoteIP("10.1.2.3").String())
+	assert.Equal(t, "::1", parseRemoteIP("[::1]:80").String())
+	assert.Nil(t, parseRemoteIP("not-an-ip"))
+}
+
+func TestGQLPolicy_StaleFields(t *testing.T) {
+	p := GQLPolicy{AllowedFields: []string{"Query.user", "Query.doesNotExist"}}
+	assert.Equal(t, []string{"Query.doesNotExist"}, p.StaleFields())
+
+	p.AllowedFields = []string{"Query.user"}
+	assert.Empty(t, p.StaleFields())
+}
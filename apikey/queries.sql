@@ -75,3 +75,58 @@ FROM
 WHERE
     gql_api_keys.deleted_at IS NULL;
 
+
+-- name: APIKeyPolicyForUpdate :one
+-- APIKeyPolicyForUpdate returns the policy and expiration of an active API key, locking the row.
+SELECT
+    policy,
+    expires_at
+FROM
+    gql_api_keys
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+    AND expires_at > now()
+FOR UPDATE;
+
+-- name: APIKeyUpdatePolicy :exec
+UPDATE
+    gql_api_keys
+SET
+    policy = $2,
+    updated_at = now(),
+    updated_by = $3
+WHERE
+    id = $1;
+
+-- name: APIKeyInsertUsageHistory :exec
+-- APIKeyInsertUsageHistory appends an entry to the usage history of an API key.
+INSERT INTO gql_api_key_usage_history(api_key_id, user_agent, ip_address)
+    VALUES (@key_id::uuid, @user_agent::text, @ip_address::inet);
+
+-- name: APIKeyUsageHistory :many
+-- APIKeyUsageHistory returns the most recent usage history entries of an API key.
+SELECT
+    used_at,
+    user_agent,
+    ip_address
+FROM
+    gql_api_key_usage_history
+WHERE
+    api_key_id = @key_id::uuid
+ORDER BY
+    id DESC
+LIMIT @max_rows::int;
+
+-- name: APIKeyDeleteByCreator :many
+-- APIKeyDeleteByCreator marks all active API keys created by the given user as deleted, returning their IDs.
+UPDATE
+    gql_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE
+    created_by = $1
+    AND deleted_at IS NULL
+RETURNING
+    id;
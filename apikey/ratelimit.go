@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/util/errutil"
+)
+
+// ErrRateLimited is returned by AuthorizeGraphQL when a key has exceeded its configured rate limit.
+var ErrRateLimited = fmt.Errorf("apikey: %w", errutil.ErrRateLimited)
+
+// rateLimiter is an in-memory, per-key token bucket.
+type rateLimiter struct {
+	mx      sync.Mutex
+	buckets map[uuid.UUID]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: make(map[uuid.UUID]*tokenBucket)}
+}
+
+// Allow will return true if a request for the given key is allowed with a limit of perMinute
+// requests per minute. A perMinute value of zero or less is unlimited.
+func (r *rateLimiter) Allow(id uuid.UUID, perMinute int, now time.Time) bool {
+	if perMinute <= 0 {
+		return true
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	max := float64(perMinute)
+	b, ok := r.buckets[id]
+	if !ok {
+		b = &tokenBucket{tokens: max, last: now}
+		r.buckets[id] = b
+	}
+
+	elapsed := now.Sub(b.last)
+	if elapsed > 0 {
+		b.tokens += elapsed.Minutes() * max
+		b.last = now
+	}
+	if b.tokens > max {
+		b.tokens = max
+	}
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
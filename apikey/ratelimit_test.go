@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := newRateLimiter()
+	id := uuid.New()
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, rl.Allow(id, 3, now), "request %d", i)
+	}
+	assert.False(t, rl.Allow(id, 3, now), "bucket should be empty")
+	assert.True(t, rl.Allow(uuid.New(), 3, now), "other keys should have their own bucket")
+
+	// one token is refilled every 20 seconds at 3/min
+	assert.True(t, rl.Allow(id, 3, now.Add(20*time.Second)))
+	assert.False(t, rl.Allow(id, 3, now.Add(20*time.Second)))
+
+	assert.True(t, rl.Allow(id, 0, now), "zero should be unlimited")
+}
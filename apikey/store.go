@@ -6,12 +6,17 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/keyring"
@@ -26,6 +31,14 @@ import (
 type Store struct {
 	db  *sql.DB
 	key keyring.Keyring
+
+	rl *rateLimiter
+
+	// staleWarned tracks the policy hashes that have already been logged for having stale fields.
+	staleWarned sync.Map
+
+	// oldKeyWarned tracks the API keys that have already been logged for using a previous signing key.
+	oldKeyWarned sync.Map
 }
 
 // NewStore will create a new Store.
@@ -33,6 +46,7 @@ func NewStore(ctx context.Context, db *sql.DB, key keyring.Keyring) (*Store, err
 	s := &Store{
 		db:  db,
 		key: key,
+		rl:  newRateLimiter(),
 	}
 
 	return s, nil
@@ -49,6 +63,17 @@ type APIKeyInfo struct {
 	CreatedBy     *uuid.UUID
 	UpdatedBy     *uuid.UUID
 	AllowedFields []string
+	ServiceIDs    []uuid.UUID
+	AllowedCIDRs  []string
+	ReadOnly      bool
+	RateLimit     int
+	MaxComplexity int
+
+	// StaleFields contains any AllowedFields that no longer exist in the schema.
+	StaleFields []string
+
+	// ExpiringSoon indicates the key has not yet expired, but will within the configured warning window.
+	ExpiringSoon bool
 }
 
 func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, error) {
@@ -57,6 +82,38 @@ func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, erro
 		return nil, err
 	}
 
+	return s.findAllKeys(ctx, time.Duration(config.FromContext(ctx).Maintenance.GQLAPIKeyExpireWarningDays)*24*time.Hour)
+}
+
+// FindExpiringGraphQLKeys will return all keys that are not yet expired, but will expire within the given duration.
+func (s *Store) FindExpiringGraphQLKeys(ctx context.Context, within time.Duration) ([]APIKeyInfo, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if within <= 0 {
+		return nil, validation.NewFieldError("Within", "must be positive")
+	}
+
+	keys, err := s.findAllKeys(ctx, within)
+	if err != nil {
+		return nil, err
+	}
+
+	res := keys[:0]
+	for _, k := range keys {
+		if !k.ExpiringSoon {
+			continue
+		}
+		res = append(res, k)
+	}
+
+	return res, nil
+}
+
+// findAllKeys returns all non-deleted keys, flagging those that will expire within warnWindow.
+func (s *Store) findAllKeys(ctx context.Context, warnWindow time.Duration) ([]APIKeyInfo, error) {
+	now := time.Now()
 	keys, err := gadb.New(s.db).APIKeyList(ctx)
 	if err != nil {
 		return nil, err
@@ -72,7 +129,7 @@ func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, erro
 			log.Log(ctx, fmt.Errorf("invalid policy for key %s: %w", k.ID, err))
 			continue
 		}
-		if p.Version != 1 {
+		if !p.IsSupportedVersion() {
 			log.Log(ctx, fmt.Errorf("unknown policy version for key %s: %d", k.ID, p.Version))
 			continue
 		}
@@ -101,6 +158,13 @@ func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, erro
 			CreatedBy:     &k.CreatedBy.UUID,
 			UpdatedBy:     &k.UpdatedBy.UUID,
 			AllowedFields: p.AllowedFields,
+			ServiceIDs:    p.ServiceIDs,
+			AllowedCIDRs:  p.AllowedCIDRs,
+			ReadOnly:      p.ReadOnly,
+			RateLimit:     p.RateLimit,
+			MaxComplexity: p.MaxComplexity,
+			StaleFields:   p.StaleFields(),
+			ExpiringSoon:  k.ExpiresAt.After(now) && k.ExpiresAt.Before(now.Add(warnWindow)),
 		})
 	}
 
@@ -113,67 +177,156 @@ type APIKeyUsage struct {
 	Time      time.Time
 }
 
+// APIKeyUsageLog will return up to limit of the most recent usage history entries for the given key, newest first.
+func (s *Store) APIKeyUsageLog(ctx context.Context, id uuid.UUID, limit int) ([]APIKeyUsage, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("Limit", limit, 1, 10000)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).APIKeyUsageHistory(ctx, gadb.APIKeyUsageHistoryParams{
+		KeyID:   id,
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]APIKeyUsage, 0, len(rows))
+	for _, r := range rows {
+		var ip string
+		if r.IpAddress.Valid {
+			ip = r.IpAddress.IPNet.IP.String()
+		}
+		res = append(res, APIKeyUsage{
+			UserAgent: r.UserAgent,
+			IP:        ip,
+			Time:      r.UsedAt,
+		})
+	}
+
+	return res, nil
+}
+
 type UpdateKey struct {
 	ID          uuid.UUID
 	Name        string
 	Description string
 }
 
-func (s *Store) UpdateAdminGraphQLKey(ctx context.Context, id uuid.UUID, name, desc *string) error {
-	err := permission.LimitCheckAny(ctx, permission.Admin)
+// withTx will run fn in tx, or in a new transaction if tx is nil.
+func (s *Store) withTx(ctx context.Context, tx *sql.Tx, name string, fn func(tx *sql.Tx) error) error {
+	if tx != nil {
+		return fn(tx)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer sqlutil.Rollback(ctx, name, tx)
 
-	if name != nil {
-		err = validate.IDName("Name", *name)
-	}
-	if desc != nil {
-		err = validate.Many(err, validate.Text("Description", *desc, 0, 255))
-	}
+	err = fn(tx)
 	if err != nil {
 		return err
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	return tx.Commit()
+}
+
+// FindOneForUpdateTx will return the name and description of the given key, locking it until tx ends.
+func (s *Store) FindOneForUpdateTx(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*UpdateKey, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer sqlutil.Rollback(ctx, "UpdateAdminGraphQLKey", tx)
 
 	key, err := gadb.New(tx).APIKeyForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateKey{ID: id, Name: key.Name, Description: key.Description}, nil
+}
+
+// UpdateAdminGraphQLKey will update the name and/or description of a key. If tx is nil, a new transaction is used.
+func (s *Store) UpdateAdminGraphQLKey(ctx context.Context, tx *sql.Tx, id uuid.UUID, name, desc *string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
 	if err != nil {
 		return err
 	}
+
 	if name != nil {
-		key.Name = *name
+		err = validate.IDName("Name", *name)
 	}
 	if desc != nil {
-		key.Description = *desc
+		err = validate.Many(err, validate.Text("Description", *desc, 0, 255))
 	}
-
-	var user uuid.NullUUID
-	if u, err := uuid.Parse(permission.UserID(ctx)); err == nil {
-		user = uuid.NullUUID{UUID: u, Valid: true}
+	if err != nil {
+		return err
 	}
 
-	err = gadb.New(tx).APIKeyUpdate(ctx, gadb.APIKeyUpdateParams{
-		ID:          id,
-		Name:        key.Name,
-		Description: key.Description,
-		UpdatedBy:   user,
+	return s.withTx(ctx, tx, "UpdateAdminGraphQLKey", func(tx *sql.Tx) error {
+		key, err := gadb.New(tx).APIKeyForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if name != nil {
+			key.Name = *name
+		}
+		if desc != nil {
+			key.Description = *desc
+		}
+
+		var user uuid.NullUUID
+		if u, err := uuid.Parse(permission.UserID(ctx)); err == nil {
+			user = uuid.NullUUID{UUID: u, Valid: true}
+		}
+
+		return gadb.New(tx).APIKeyUpdate(ctx, gadb.APIKeyUpdateParams{
+			ID:          id,
+			Name:        key.Name,
+			Description: key.Description,
+			UpdatedBy:   user,
+		})
 	})
+}
+
+// DeleteAdminGraphQLKey will delete the given key. If tx is nil, the key is deleted immediately.
+func (s *Store) DeleteAdminGraphQLKey(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	var byID uuid.NullUUID
+	if id, err := uuid.Parse(permission.UserID(ctx)); err == nil {
+		byID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	var db gadb.DBTX = s.db
+	if tx != nil {
+		db = tx
+	}
+	return gadb.New(db).APIKeyDelete(ctx, gadb.APIKeyDeleteParams{
+		DeletedBy: byID,
+		ID:        id,
+	})
 }
 
-func (s *Store) DeleteAdminGraphQLKey(ctx context.Context, id uuid.UUID) error {
+// DeleteGraphQLKeysByCreator will delete all GraphQL API keys created by the given user, returning the IDs of the keys removed.
+// If tx is nil, the keys are deleted immediately.
+func (s *Store) DeleteGraphQLKeysByCreator(ctx context.Context, tx *sql.Tx, createdBy uuid.UUID) ([]uuid.UUID, error) {
 	err := permission.LimitCheckAny(ctx, permission.Admin)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var byID uuid.NullUUID
@@ -181,16 +334,81 @@ func (s *Store) DeleteAdminGraphQLKey(ctx context.Context, id uuid.UUID) error {
 		byID = uuid.NullUUID{UUID: id, Valid: true}
 	}
 
-	return gadb.New(s.db).APIKeyDelete(ctx, gadb.APIKeyDeleteParams{
+	var db gadb.DBTX = s.db
+	if tx != nil {
+		db = tx
+	}
+	return gadb.New(db).APIKeyDeleteByCreator(ctx, gadb.APIKeyDeleteByCreatorParams{
+		CreatedBy: uuid.NullUUID{UUID: createdBy, Valid: true},
 		DeletedBy: byID,
-		ID:        id,
 	})
 }
 
+// RotateAdminGraphQLKey will issue a new token for the given key, invalidating all previously issued tokens.
+// If tx is nil, a new transaction is used.
+//
+// The key ID, policy, and expiration are preserved.
+func (s *Store) RotateAdminGraphQLKey(ctx context.Context, tx *sql.Tx, id uuid.UUID) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return "", err
+	}
+
+	var tok string
+	err = s.withTx(ctx, tx, "RotateAdminGraphQLKey", func(tx *sql.Tx) error {
+		row, err := gadb.New(tx).APIKeyPolicyForUpdate(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return validation.NewFieldError("ID", "not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		var p GQLPolicy
+		err = json.Unmarshal(row.Policy, &p)
+		if err != nil {
+			return err
+		}
+		if !p.IsSupportedVersion() {
+			return fmt.Errorf("unknown policy version for key %s: %d", id, p.Version)
+		}
+
+		p.TokenVersion++
+		policyData, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+
+		var user uuid.NullUUID
+		if u, err := uuid.Parse(permission.UserID(ctx)); err == nil {
+			user = uuid.NullUUID{UUID: u, Valid: true}
+		}
+
+		err = gadb.New(tx).APIKeyUpdatePolicy(ctx, gadb.APIKeyUpdatePolicyParams{
+			ID:        id,
+			Policy:    policyData,
+			UpdatedBy: user,
+		})
+		if err != nil {
+			return err
+		}
+
+		hash := sha256.Sum256(policyData)
+		tok, err = s.key.SignJWT(NewGraphQLClaims(id, hash[:], row.ExpiresAt))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tok, nil
+}
+
 func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (context.Context, error) {
 	var claims Claims
-	_, err := s.key.VerifyJWT(tok, &claims, Issuer, Audience)
+	currentKey, err := s.key.VerifyJWT(tok, &claims, Issuer, Audience)
 	if err != nil {
+		log.Debugf(ctx, "apikey: token verification failed: %v", err)
 		return ctx, permission.Unauthorized()
 	}
 	id, err := uuid.Parse(claims.Subject)
@@ -212,6 +430,18 @@ func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (conte
 		log.Log(ctx, fmt.Errorf("apikey: policy hash mismatch for key %s", id))
 		return ctx, permission.Unauthorized()
 	}
+	if remoteIP := parseRemoteIP(ip); !info.Policy.AllowsIP(remoteIP) {
+		// The token itself is valid, so make it clear this is a network restriction and not a signature failure.
+		log.Logf(ctx, "apikey: request from %s rejected by allowed CIDRs for key %s", ip, id)
+		return ctx, permission.Unauthorized()
+	}
+	s.warnStaleFields(ctx, id, info)
+	if !currentKey {
+		s.warnOldSigningKey(ctx, id)
+	}
+	if !s.rl.Allow(id, info.Policy.RateLimit, time.Now()) {
+		return ctx, ErrRateLimited
+	}
 
 	err = s._updateLastUsed(ctx, id, ua, ip)
 	if err != nil {
@@ -224,11 +454,62 @@ func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (conte
 		Type: permission.SourceTypeGQLAPIKey,
 	})
 	ctx = permission.UserContext(ctx, "", info.Policy.Role)
+	if len(info.Policy.ServiceIDs) > 0 {
+		ctx = ContextWithServiceFilter(ctx, info.Policy.ServiceIDs)
+	}
+	if info.Policy.ReadOnly {
+		ctx = ContextWithReadOnly(ctx)
+	}
 
 	ctx = ContextWithPolicy(ctx, &info.Policy)
 	return ctx, nil
 }
 
+// warnStaleFields will log a warning (once per policy) if the policy references fields no longer in the schema.
+//
+// Stale fields do not affect authorization, since they can't be queried anyway.
+func (s *Store) warnStaleFields(ctx context.Context, id uuid.UUID, info *policyInfo) {
+	if _, loaded := s.staleWarned.LoadOrStore(string(info.Hash), struct{}{}); loaded {
+		return
+	}
+
+	stale := info.Policy.StaleFields()
+	if len(stale) == 0 {
+		return
+	}
+
+	log.Log(ctx, fmt.Errorf("apikey: key %s policy references fields no longer in schema: %s", id, strings.Join(stale, ", ")))
+}
+
+// warnOldSigningKey will log a warning (once per key) if the token was signed by a previous signing key.
+//
+// Such tokens remain valid until the key is retired, so they should be rotated before then.
+func (s *Store) warnOldSigningKey(ctx context.Context, id uuid.UUID) {
+	if _, loaded := s.oldKeyWarned.LoadOrStore(id, struct{}{}); loaded {
+		return
+	}
+
+	log.Log(ctx, fmt.Errorf("apikey: key %s token was signed by a previous signing key and should be rotated", id))
+}
+
+// MaxSigningKeyGracePeriod is the longest tokens signed by previous signing keys may remain valid after promoting a new one.
+const MaxSigningKeyGracePeriod = 365 * 24 * time.Hour
+
+// PromoteSigningKey will make a new key the signing key for all new tokens. Tokens signed by previous keys
+// will continue to be accepted until gracePeriod has elapsed.
+func (s *Store) PromoteSigningKey(ctx context.Context, gracePeriod time.Duration) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	// other instances only refresh keys every 12 hours, so they need at least that long to start using the new key
+	if gracePeriod < 24*time.Hour || gracePeriod > MaxSigningKeyGracePeriod {
+		return validation.NewFieldError("GracePeriod", "must be between 1 and 365 days")
+	}
+
+	return s.key.PromoteNextKey(ctx, gracePeriod)
+}
+
 // NewAdminGQLKeyOpts is used to create a new GraphQL API key.
 type NewAdminGQLKeyOpts struct {
 	Name    string
@@ -236,10 +517,26 @@ type NewAdminGQLKeyOpts struct {
 	Fields  []string
 	Expires time.Time
 	Role    permission.Role
+
+	// ServiceIDs, if set, will restrict the key to only the given services.
+	ServiceIDs []uuid.UUID
+
+	// AllowedCIDRs, if set, will restrict the key to only be used from the given networks.
+	AllowedCIDRs []string
+
+	// ReadOnly, if set, will prevent the key from being used for mutations.
+	ReadOnly bool
+
+	// RateLimit, if positive, is the maximum number of requests per minute allowed for the key.
+	RateLimit int
+
+	// MaxComplexity, if positive, is used in place of the configured GraphQL complexity limit for the key.
+	MaxComplexity int
 }
 
-// CreateAdminGraphQLKey will create a new GraphQL API key returning the ID and token.
-func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpts) (uuid.UUID, string, error) {
+// CreateAdminGraphQLKey will create a new GraphQL API key returning the ID and token. If tx is nil, the key is
+// created immediately.
+func (s *Store) CreateAdminGraphQLKey(ctx context.Context, tx *sql.Tx, opt NewAdminGQLKeyOpts) (uuid.UUID, string, error) {
 	err := permission.LimitCheckAny(ctx, permission.Admin)
 	if err != nil {
 		return uuid.Nil, "", err
@@ -250,6 +547,10 @@ func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpt
 		validate.Text("Description", opt.Desc, 0, 255),
 		validate.Range("Fields", len(opt.Fields), 1, len(graphql2.SchemaFields())),
 		validate.OneOf("Role", opt.Role, permission.RoleAdmin, permission.RoleUser),
+		validate.Range("ServiceIDs", len(opt.ServiceIDs), 0, 100),
+		validate.Range("AllowedCIDRs", len(opt.AllowedCIDRs), 0, 100),
+		validate.Range("RateLimit", opt.RateLimit, 0, 100000),
+		validate.Range("MaxComplexity", opt.MaxComplexity, 0, 1000000),
 	)
 	if time.Until(opt.Expires) <= 0 {
 		err = validate.Many(err, validation.NewFieldError("Expires", "must be in the future"))
@@ -261,15 +562,30 @@ func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpt
 
 		err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("Fields[%d]", i), "is not a valid field"))
 	}
+	for i, c := range opt.AllowedCIDRs {
+		_, n, cidrErr := net.ParseCIDR(c)
+		if cidrErr != nil {
+			err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("AllowedCIDRs[%d]", i), "is not a valid CIDR"))
+			continue
+		}
+		opt.AllowedCIDRs[i] = n.String()
+	}
 	if err != nil {
 		return uuid.Nil, "", err
 	}
 
 	sort.Strings(opt.Fields)
+	slices.SortFunc(opt.ServiceIDs, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	opt.ServiceIDs = slices.Compact(opt.ServiceIDs)
 	policyData, err := json.Marshal(GQLPolicy{
-		Version:       1,
+		Version:       2,
 		AllowedFields: opt.Fields,
 		Role:          opt.Role,
+		ServiceIDs:    opt.ServiceIDs,
+		AllowedCIDRs:  opt.AllowedCIDRs,
+		ReadOnly:      opt.ReadOnly,
+		RateLimit:     opt.RateLimit,
+		MaxComplexity: opt.MaxComplexity,
 	})
 	if err != nil {
 		return uuid.Nil, "", err
@@ -281,8 +597,12 @@ func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpt
 		user = uuid.NullUUID{UUID: userID, Valid: true}
 	}
 
+	var db gadb.DBTX = s.db
+	if tx != nil {
+		db = tx
+	}
 	id := uuid.New()
-	err = gadb.New(s.db).APIKeyInsert(ctx, gadb.APIKeyInsertParams{
+	err = gadb.New(db).APIKeyInsert(ctx, gadb.APIKeyInsertParams{
 		ID:          id,
 		Name:        opt.Name,
 		Description: opt.Desc,
@@ -14,23 +14,30 @@ import (
 	"github.com/target/goalert/alert/alertmetrics"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/app/lifecycle"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/businesshours"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/configexport"
 	"github.com/target/goalert/engine"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/telegram"
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
@@ -83,12 +90,15 @@ type App struct {
 	twilioConfig *twilio.Config
 
 	slackChan *slack.ChannelSender
+	telegram  *telegram.Sender
+	email     *email.Sender
 
 	ConfigStore *config.Store
 
 	AlertStore        *alert.Store
 	AlertLogStore     *alertlog.Store
 	AlertMetricsStore *alertmetrics.Store
+	AlertEvents       *alertlog.Broker
 
 	AuthBasicStore        *basic.Store
 	UserStore             *user.Store
@@ -122,6 +132,12 @@ type App struct {
 	NoticeStore   *notice.Store
 	AuthLinkStore *authlink.Store
 	APIKeyStore   *apikey.Store
+
+	MaintenanceStore   *maintenance.Store
+	IncidentStore      *incident.Store
+	BusinessHoursStore *businesshours.Store
+	ConfigExportStore  *configexport.Store
+	AuditLogStore      *auditlog.Store
 }
 
 // NewApp constructs a new App and binds the listening socket.
@@ -37,8 +37,10 @@ func (app *App) initEngine(ctx context.Context) error {
 		NCStore:             app.NCStore,
 		OnCallStore:         app.OnCallStore,
 		ScheduleStore:       app.ScheduleStore,
+		ServiceStore:        app.ServiceStore,
 		AuthLinkStore:       app.AuthLinkStore,
 		SlackStore:          app.slackChan,
+		TwilioConfig:        app.twilioConfig,
 
 		ConfigSource: app.ConfigStore,
 
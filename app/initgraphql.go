@@ -17,6 +17,7 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		AlertStore:          app.AlertStore,
 		AlertLogStore:       app.AlertLogStore,
 		AlertMetricsStore:   app.AlertMetricsStore,
+		AlertEventBroker:    app.AlertEvents,
 		ServiceStore:        app.ServiceStore,
 		FavoriteStore:       app.FavoriteStore,
 		PolicyStore:         app.EscalationStore,
@@ -27,6 +28,9 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		TimeZoneStore:       app.TimeZoneStore,
 		IntKeyStore:         app.IntegrationKeyStore,
 		LabelStore:          app.LabelStore,
+		MaintenanceStore:    app.MaintenanceStore,
+		IncidentStore:       app.IncidentStore,
+		BusinessHoursStore:  app.BusinessHoursStore,
 		RuleStore:           app.ScheduleRuleStore,
 		OverrideStore:       app.OverrideStore,
 		ConfigStore:         app.ConfigStore,
@@ -34,6 +38,8 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		NotificationStore:   app.NotificationStore,
 		SlackStore:          app.slackChan,
 		HeartbeatStore:      app.HeartbeatStore,
+		ConfigExportStore:   app.ConfigExportStore,
+		AuditLogStore:       app.AuditLogStore,
 		NoticeStore:         app.NoticeStore,
 		Twilio:              app.twilioConfig,
 		AuthHandler:         app.AuthHandler,
@@ -13,8 +13,10 @@ import (
 	"github.com/target/goalert/grafana"
 	"github.com/target/goalert/mailgun"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/opsgenie"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
 	"github.com/target/goalert/site24x7"
+	"github.com/target/goalert/user/userimport"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/web"
@@ -81,7 +83,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 		logRequest(app.cfg.LogRequests),
 
 		// max request time
-		timeout(2 * time.Minute),
+		timeout(app.cfg.HTTPPrefix, 2*time.Minute),
 
 		func(next http.Handler) http.Handler {
 			return http.StripPrefix(app.cfg.HTTPPrefix, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -124,6 +126,13 @@ func (app *App) initHTTP(ctx context.Context) error {
 		UserStore:           app.UserStore,
 	})
 
+	userImport := userimport.NewHandler(userimport.Config{
+		DB:                 app.db,
+		UserStore:          app.UserStore,
+		ContactMethodStore: app.ContactMethodStore,
+		AuditLogStore:      app.AuditLogStore,
+	})
+
 	mux.Handle("/api/graphql", app.graphql2.Handler())
 
 	mux.HandleFunc("/api/v2/config", app.ConfigStore.ServeConfig)
@@ -142,22 +151,30 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/identity/providers/oidc", oidcAuth)
 	mux.HandleFunc("/api/v2/identity/providers/oidc/callback", oidcAuth)
 
-	mux.HandleFunc("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore, app.email))
 	mux.HandleFunc("/api/v2/grafana/incoming", grafana.GrafanaToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/site24x7/incoming", site24x7.Site24x7ToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/prometheusalertmanager/incoming", prometheus.PrometheusAlertmanagerEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	opsGenie := opsgenie.OpsGenieToEventsAPI(app.AlertStore, app.IntegrationKeyStore)
+	mux.HandleFunc(opsgenie.BasePath, opsGenie)
+	mux.HandleFunc(opsgenie.BasePath+"/", opsGenie)
 
 	mux.HandleFunc("/api/v2/generic/incoming", generic.ServeCreateAlert)
 	mux.HandleFunc("/api/v2/heartbeat/", generic.ServeHeartbeatCheck)
 	mux.HandleFunc("/api/v2/user-avatar/", generic.ServeUserAvatar)
+	mux.Handle("/api/v2/users/import", userImport)
 	mux.HandleFunc("/api/v2/calendar", app.CalSubStore.ServeICalData)
 
 	mux.HandleFunc("/api/v2/twilio/message", app.twilioSMS.ServeMessage)
 	mux.HandleFunc("/api/v2/twilio/message/status", app.twilioSMS.ServeStatusCallback)
 	mux.HandleFunc("/api/v2/twilio/call", app.twilioVoice.ServeCall)
 	mux.HandleFunc("/api/v2/twilio/call/status", app.twilioVoice.ServeStatusCallback)
+	mux.HandleFunc("/api/v2/twilio/call/conference", app.twilioVoice.ServeConferenceStatus)
 
 	mux.HandleFunc("/api/v2/slack/message-action", app.slackChan.ServeMessageAction)
+	mux.HandleFunc("/api/v2/slack/command", app.slackChan.ServeSlashCommand)
+
+	mux.HandleFunc("/api/v2/telegram/webhook", app.telegram.ServeWebhook)
 
 	middleware = append(middleware,
 		httpRewrite(app.cfg.HTTPPrefix, "/v1/graphql2", "/api/graphql"),
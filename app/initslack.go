@@ -10,8 +10,9 @@ import (
 func (app *App) initSlack(ctx context.Context) error {
 	var err error
 	app.slackChan, err = slack.NewChannelSender(ctx, slack.Config{
-		BaseURL:   app.cfg.SlackBaseURL,
-		UserStore: app.UserStore,
+		BaseURL:    app.cfg.SlackBaseURL,
+		UserStore:  app.UserStore,
+		AlertStore: app.AlertStore,
 	})
 	if err != nil {
 		return err
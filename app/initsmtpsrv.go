@@ -10,6 +10,7 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/smtpsrv"
 )
 
@@ -41,6 +42,9 @@ func (app *App) initSMTPServer(ctx context.Context) error {
 			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
 			return err
 		},
+		ReplyFunc: func(ctx context.Context, r email.Reply) error {
+			return app.email.ReceiveReply(ctx, r)
+		},
 	}
 
 	app.smtpsrv = smtpsrv.NewServer(cfg)
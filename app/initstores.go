@@ -8,17 +8,22 @@ import (
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
 	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/businesshours"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/configexport"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
@@ -142,6 +147,9 @@ func (app *App) initStores(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "init alertlog store")
 	}
+	if app.AlertEvents == nil {
+		app.AlertEvents = alertlog.NewBroker()
+	}
 
 	if app.AlertStore == nil {
 		app.AlertStore, err = alert.NewStore(ctx, app.db, app.AlertLogStore)
@@ -200,7 +208,7 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.NCStore == nil {
-		app.NCStore, err = notificationchannel.NewStore(ctx, app.db)
+		app.NCStore, err = notificationchannel.NewStore(ctx, app.db, app.cfg.EncryptionKeys)
 	}
 	if err != nil {
 		return errors.Wrap(err, "init notification channel store")
@@ -223,6 +231,18 @@ func (app *App) initStores(ctx context.Context) error {
 		app.IntegrationKeyStore = integrationkey.NewStore(ctx, app.db)
 	}
 
+	if app.MaintenanceStore == nil {
+		app.MaintenanceStore = maintenance.NewStore(ctx, app.db)
+	}
+
+	if app.IncidentStore == nil {
+		app.IncidentStore = incident.NewStore(ctx, app.db)
+	}
+
+	if app.BusinessHoursStore == nil {
+		app.BusinessHoursStore = businesshours.NewStore(ctx, app.db)
+	}
+
 	if app.ScheduleRuleStore == nil {
 		app.ScheduleRuleStore, err = rule.NewStore(ctx, app.db)
 	}
@@ -281,6 +301,20 @@ func (app *App) initStores(ctx context.Context) error {
 		app.TimeZoneStore = timezone.NewStore(ctx, app.db)
 	}
 
+	if app.ConfigExportStore == nil {
+		app.ConfigExportStore, err = configexport.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init config export store")
+	}
+
+	if app.AuditLogStore == nil {
+		app.AuditLogStore, err = auditlog.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init audit log store")
+	}
+
 	if app.CalSubStore == nil {
 		app.CalSubStore, err = calsub.NewStore(ctx, app.db, app.APIKeyring, app.OnCallStore)
 	}
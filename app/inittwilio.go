@@ -13,6 +13,8 @@ func (app *App) initTwilio(ctx context.Context) error {
 	app.twilioConfig = &twilio.Config{
 		BaseURL: app.cfg.TwilioBaseURL,
 		CMStore: app.ContactMethodStore,
+
+		AlertStore: app.AlertStore,
 	}
 
 	var err error
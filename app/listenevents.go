@@ -5,13 +5,14 @@ import (
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
 )
 
 func (app *App) listenEvents(ctx context.Context) (<-chan struct{}, error) {
-	l, err := sqlutil.NewListener(ctx, app.cfg.Logger, app.db, "/goalert/config-refresh")
+	l, err := sqlutil.NewListener(ctx, app.cfg.Logger, app.db, "/goalert/config-refresh", alertlog.EventChannel)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +53,13 @@ func (app *App) listenEvents(ctx context.Context) (<-chan struct{}, error) {
 				permission.SudoContext(ctx, func(ctx context.Context) {
 					log.Log(ctx, app.ConfigStore.Reload(ctx))
 				})
+			case alertlog.EventChannel:
+				e, err := alertlog.ParseEvent(n.Payload)
+				if err != nil {
+					log.Log(ctx, err)
+					continue
+				}
+				app.AlertEvents.Publish(*e)
 			}
 		}
 	}()
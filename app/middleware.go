@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/felixge/httpsnoop"
@@ -126,9 +127,17 @@ func authCheckLimit(max int) func(http.Handler) http.Handler {
 	}
 }
 
-func timeout(timeout time.Duration) func(http.Handler) http.Handler {
+// timeout limits the duration of requests, except for websocket upgrades of the GraphQL
+// endpoint (under prefix) used for subscriptions.
+func timeout(prefix string, timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == prefix+"/api/graphql" && strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+				// long-lived GraphQL subscriptions, ends when the client disconnects
+				next.ServeHTTP(w, req)
+				return
+			}
+
 			ctx, cancel := context.WithTimeout(req.Context(), timeout)
 			defer cancel()
 			next.ServeHTTP(w, req.WithContext(ctx))
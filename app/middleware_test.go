@@ -0,0 +1,32 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	check := func(path string, upgrade bool, expDeadline bool) {
+		t.Helper()
+		var hasDeadline bool
+		h := timeout("/prefix", time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, hasDeadline = req.Context().Deadline()
+		}))
+
+		req := httptest.NewRequest("GET", path, nil)
+		if upgrade {
+			req.Header.Set("Upgrade", "websocket")
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, expDeadline, hasDeadline, "deadline for %s (upgrade=%t)", path, upgrade)
+	}
+
+	check("/prefix/api/graphql", true, false)
+	check("/prefix/api/graphql", false, true)
+	check("/prefix/api/v2/generic/incoming", true, true)
+	check("/api/graphql", true, true)
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"crypto/subtle"
 	"net"
 	"net/http"
 
@@ -36,10 +37,29 @@ func initPromServer() error {
 		Help:      "Number of outgoing HTTP requests currently active.",
 	}), http.DefaultTransport)
 
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", requireBearerToken(viper.GetString("prometheus-auth-token"), promhttp.Handler()))
 	srv := http.Server{
 		Handler: mux,
 	}
 	go func() { _ = srv.Serve(l) }()
 	return nil
 }
+
+// requireBearerToken wraps next to require the given token in the Authorization header. If
+// token is empty, next is returned unchanged.
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
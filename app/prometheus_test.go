@@ -0,0 +1,29 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+
+	check := func(token, header string, code int) {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		requireBearerToken(token, ok).ServeHTTP(rec, req)
+		assert.Equal(t, code, rec.Code)
+	}
+
+	check("", "", http.StatusOK)
+	check("secret", "Bearer secret", http.StatusOK)
+	check("secret", "", http.StatusUnauthorized)
+	check("secret", "Bearer wrong", http.StatusUnauthorized)
+}
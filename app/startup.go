@@ -9,6 +9,9 @@ import (
 	"github.com/target/goalert/expflag"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/msteams"
+	"github.com/target/goalert/notification/pagerduty"
+	"github.com/target/goalert/notification/telegram"
 	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
@@ -69,9 +72,15 @@ func (app *App) startup(ctx context.Context) error {
 		ctx, "Startup.Twilio", app.initTwilio)
 
 	app.initStartup(ctx, "Startup.Slack", app.initSlack)
-	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", email.NewSender(ctx))
-	app.notificationManager.RegisterSender(notification.DestTypeUserWebhook, "webhook-user", webhook.NewSender(ctx))
-	app.notificationManager.RegisterSender(notification.DestTypeChanWebhook, "webhook-channel", webhook.NewSender(ctx))
+	app.email = email.NewSender(ctx)
+	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", app.email)
+	app.notificationManager.RegisterSender(notification.DestTypeUserWebhook, "webhook-user", webhook.NewSender(ctx, nil))
+	app.notificationManager.RegisterSender(notification.DestTypeChanWebhook, "webhook-channel", webhook.NewSender(ctx, app.NCStore))
+	app.notificationManager.RegisterSender(notification.DestTypeMSTeams, "msteams-channel", msteams.NewSender(ctx))
+	app.notificationManager.RegisterSender(notification.DestTypePagerDuty, "pagerduty-channel", pagerduty.NewSender(ctx, app.NCStore))
+
+	app.telegram = telegram.NewSender(ctx)
+	app.notificationManager.RegisterSender(notification.DestTypeTelegram, "Telegram", app.telegram)
 
 	app.initStartup(ctx, "Startup.Engine", app.initEngine)
 	app.initStartup(ctx, "Startup.Auth", app.initAuth)
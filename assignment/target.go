@@ -61,6 +61,8 @@ type (
 	CalendarSubscriptionTarget string
 	// UserSessionTarget implements the Target interface by wrapping a UserSession ID.
 	UserSessionTarget string
+	// EscalationPolicyStepTarget implements the Target interface by wrapping an EscalationPolicyStep ID.
+	EscalationPolicyStepTarget string
 )
 
 // TargetType implements the Target interface.
@@ -146,3 +148,9 @@ func (UserSessionTarget) TargetType() TargetType { return TargetTypeUserSession
 
 // TargetID implements the Target interface.
 func (s UserSessionTarget) TargetID() string { return string(s) }
+
+// TargetType implements the Target interface.
+func (EscalationPolicyStepTarget) TargetType() TargetType { return TargetTypeEscalationPolicyStep }
+
+// TargetID implements the Target interface.
+func (s EscalationPolicyStepTarget) TargetID() string { return string(s) }
@@ -33,6 +33,9 @@ const (
 	TargetTypeContactMethod
 	TargetTypeHeartbeatMonitor
 	TargetTypeUserSession
+	TargetTypeMSTeamsChannel
+	TargetTypePagerDuty
+	TargetTypeEscalationPolicyStep
 )
 
 var (
@@ -79,6 +82,12 @@ func (tt *TargetType) UnmarshalText(data []byte) error {
 		*tt = TargetTypeHeartbeatMonitor
 	case "userSession":
 		*tt = TargetTypeUserSession
+	case "msTeamsChannel":
+		*tt = TargetTypeMSTeamsChannel
+	case "pagerDuty":
+		*tt = TargetTypePagerDuty
+	case "escalationPolicyStep":
+		*tt = TargetTypeEscalationPolicyStep
 	default:
 		return validation.NewFieldError("TargetType", "unknown target type "+str)
 	}
@@ -131,6 +140,12 @@ func (tt TargetType) MarshalText() ([]byte, error) {
 		return []byte("heartbeatMonitor"), nil
 	case TargetTypeUserSession:
 		return []byte("userSession"), nil
+	case TargetTypeMSTeamsChannel:
+		return []byte("msTeamsChannel"), nil
+	case TargetTypePagerDuty:
+		return []byte("pagerDuty"), nil
+	case TargetTypeEscalationPolicyStep:
+		return []byte("escalationPolicyStep"), nil
 	}
 
 	return nil, validation.NewFieldError("TargetType", "unknown target type "+tt.String())
@@ -26,11 +26,14 @@ func _() {
 	_ = x[TargetTypeContactMethod-15]
 	_ = x[TargetTypeHeartbeatMonitor-16]
 	_ = x[TargetTypeUserSession-17]
+	_ = x[TargetTypeMSTeamsChannel-18]
+	_ = x[TargetTypePagerDuty-19]
+	_ = x[TargetTypeEscalationPolicyStep-20]
 }
 
-const _TargetType_name = "TargetTypeUnspecifiedTargetTypeEscalationPolicyTargetTypeNotificationPolicyTargetTypeRotationTargetTypeServiceTargetTypeScheduleTargetTypeCalendarSubscriptionTargetTypeUserTargetTypeNotificationChannelTargetTypeSlackChannelTargetTypeSlackUserGroupTargetTypeChanWebhookTargetTypeIntegrationKeyTargetTypeUserOverrideTargetTypeNotificationRuleTargetTypeContactMethodTargetTypeHeartbeatMonitorTargetTypeUserSession"
+const _TargetType_name = "TargetTypeUnspecifiedTargetTypeEscalationPolicyTargetTypeNotificationPolicyTargetTypeRotationTargetTypeServiceTargetTypeScheduleTargetTypeCalendarSubscriptionTargetTypeUserTargetTypeNotificationChannelTargetTypeSlackChannelTargetTypeSlackUserGroupTargetTypeChanWebhookTargetTypeIntegrationKeyTargetTypeUserOverrideTargetTypeNotificationRuleTargetTypeContactMethodTargetTypeHeartbeatMonitorTargetTypeUserSessionTargetTypeMSTeamsChannelTargetTypePagerDutyTargetTypeEscalationPolicyStep"
 
-var _TargetType_index = [...]uint16{0, 21, 47, 75, 93, 110, 128, 158, 172, 201, 223, 247, 268, 292, 314, 340, 363, 389, 410}
+var _TargetType_index = [...]uint16{0, 21, 47, 75, 93, 110, 128, 158, 172, 201, 223, 247, 268, 292, 314, 340, 363, 389, 410, 434, 453, 483}
 
 func (i TargetType) String() string {
 	if i < 0 || i >= TargetType(len(_TargetType_index)-1) {
@@ -0,0 +1,130 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// EntityType is the kind of entity changed by an audited mutation.
+type EntityType string
+
+// Audited entity types.
+const (
+	EntityTypeIntegrationKey       EntityType = "integrationKey"
+	EntityTypeEscalationPolicy     EntityType = "escalationPolicy"
+	EntityTypeEscalationPolicyStep EntityType = "escalationPolicyStep"
+	EntityTypeUser                 EntityType = "user"
+	EntityTypeUserOverride         EntityType = "userOverride"
+	EntityTypeOutgoingMessage      EntityType = "outgoingMessage"
+	EntityTypeGQLAPIKey            EntityType = "gqlAPIKey"
+	EntityTypeCorrelationRule      EntityType = "incidentCorrelationRule"
+	EntityTypeService              EntityType = "service"
+	EntityTypeConfig               EntityType = "config"
+	EntityTypeSystemLimit          EntityType = "systemLimit"
+)
+
+// An Entry records a single admin-level mutation. Entries are never modified or
+// removed once written.
+type Entry struct {
+	ID        int
+	Timestamp time.Time
+
+	// ActorUserID is the user that made the change, if any.
+	ActorUserID string
+
+	// ActorSource describes how the actor was authenticated, like an API key or the UI.
+	ActorSource string
+
+	// Action is the name of the mutation, like `updateEscalationPolicy`.
+	Action string
+
+	EntityType EntityType
+	EntityID   string
+
+	// Before and After are JSON snapshots of the entity. Before is empty for created
+	// entities, and After is empty for deleted ones.
+	Before json.RawMessage
+	After  json.RawMessage
+
+	// Error is set if the mutation failed, in which case nothing was changed.
+	Error string
+}
+
+// A Change is a single top-level field that differs between the Before and After
+// snapshots of an Entry.
+type Change struct {
+	Field  string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// SetBefore will set the Before snapshot to v encoded as JSON.
+func (e *Entry) SetBefore(v interface{}) (err error) {
+	e.Before, err = json.Marshal(v)
+	return err
+}
+
+// SetAfter will set the After snapshot to v encoded as JSON.
+func (e *Entry) SetAfter(v interface{}) (err error) {
+	e.After, err = json.Marshal(v)
+	return err
+}
+
+// Changes will return the fields that differ between the Before and After snapshots,
+// sorted by name. Fields missing from one side have an empty value.
+func (e Entry) Changes() ([]Change, error) {
+	before, err := fields(e.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := fields(e.After)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(before)+len(after))
+	for name := range before {
+		names[name] = struct{}{}
+	}
+	for name := range after {
+		names[name] = struct{}{}
+	}
+
+	var result []Change
+	for name := range names {
+		b, a := before[name], after[name]
+		if bytes.Equal(b, a) {
+			continue
+		}
+		result = append(result, Change{Field: name, Before: b, After: a})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Field < result[j].Field })
+
+	return result, nil
+}
+
+// fields will decode a JSON object snapshot into its compacted top-level fields.
+func fields(data json.RawMessage) (map[string]json.RawMessage, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, val := range m {
+		var buf bytes.Buffer
+		err = json.Compact(&buf, val)
+		if err != nil {
+			return nil, err
+		}
+		m[name] = buf.Bytes()
+	}
+
+	return m, nil
+}
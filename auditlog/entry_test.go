@@ -0,0 +1,34 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_Changes(t *testing.T) {
+	e := Entry{
+		Before: json.RawMessage(`{"name":"foo","repeat":1,"targets":[{"id":"a"}],"removed":true}`),
+		After:  json.RawMessage(`{"name":"bar", "repeat":1, "targets":[ {"id":"a"} ],"added":2}`),
+	}
+
+	changes, err := e.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Field: "added", After: json.RawMessage(`2`)},
+		{Field: "name", Before: json.RawMessage(`"foo"`), After: json.RawMessage(`"bar"`)},
+		{Field: "removed", Before: json.RawMessage(`true`)},
+	}, changes)
+
+	// created entities have no Before snapshot
+	e = Entry{After: json.RawMessage(`{"name":"foo"}`)}
+	changes, err = e.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, []Change{{Field: "name", After: json.RawMessage(`"foo"`)}}, changes)
+
+	e = Entry{Before: json.RawMessage(`[1]`)}
+	_, err = e.Changes()
+	assert.Error(t, err)
+}
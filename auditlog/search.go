@@ -0,0 +1,151 @@
+package auditlog
+
+import (
+	"context"
+	"database/sql"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SearchOptions contains criteria for filtering audit log entries.
+type SearchOptions struct {
+	// ActorUserID, if specified, will restrict entries to changes made by the given user.
+	ActorUserID string `json:"u,omitempty"`
+
+	// EntityType, if specified, will restrict entries to changes of the given type.
+	EntityType EntityType `json:"t,omitempty"`
+
+	// EntityID, if specified, will restrict entries to changes of the given entity.
+	EntityID string `json:"e,omitempty"`
+
+	// Before will restrict entries to those created before the given time.
+	Before time.Time `json:"b,omitempty"`
+
+	// NotBefore will restrict entries to those created at or after the given time.
+	NotBefore time.Time `json:"n,omitempty"`
+
+	// AfterID will only include entries with an ID lower than the provided value.
+	AfterID int `json:"a,omitempty"`
+
+	// Limit restricts the maximum number of rows returned. Default is 15.
+	Limit int `json:"-"`
+}
+
+var searchTemplate = template.Must(template.New("audit-log-search").Funcs(search.Helpers()).Parse(`
+	SELECT
+		id,
+		created_at,
+		actor_user_id,
+		actor_source,
+		action,
+		entity_type,
+		entity_id,
+		before_data,
+		after_data,
+		error
+	FROM audit_logs
+	WHERE true
+	{{ if .ActorUserID }}
+		AND actor_user_id = :actorID
+	{{ end }}
+	{{ if .EntityType }}
+		AND entity_type = :entityType
+	{{ end }}
+	{{ if .EntityID }}
+		AND entity_id = :entityID
+	{{ end }}
+	{{ if not .Before.IsZero }}
+		AND created_at < :before
+	{{ end }}
+	{{ if not .NotBefore.IsZero }}
+		AND created_at >= :notBefore
+	{{ end }}
+	{{ if .AfterID }}
+		AND id < :afterID
+	{{ end }}
+	ORDER BY id DESC
+	LIMIT {{.Limit}}
+`))
+
+type renderData SearchOptions
+
+func (opts renderData) Normalize() (*renderData, error) {
+	if opts.Limit == 0 {
+		opts.Limit = search.DefaultMaxResults
+	}
+
+	err := validate.Many(
+		validate.Range("Limit", opts.Limit, 0, 1001),
+		validate.OneOf("EntityType", opts.EntityType, "", EntityTypeIntegrationKey, EntityTypeEscalationPolicy, EntityTypeEscalationPolicyStep, EntityTypeUser, EntityTypeUserOverride, EntityTypeOutgoingMessage, EntityTypeGQLAPIKey, EntityTypeCorrelationRule, EntityTypeService, EntityTypeConfig, EntityTypeSystemLimit),
+		validate.Text("EntityID", opts.EntityID, 0, 255),
+	)
+	if opts.ActorUserID != "" {
+		err = validate.Many(err, validate.UUID("ActorUserID", opts.ActorUserID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+func (opts renderData) QueryArgs() []sql.NamedArg {
+	return []sql.NamedArg{
+		sql.Named("actorID", opts.ActorUserID),
+		sql.Named("entityType", string(opts.EntityType)),
+		sql.Named("entityID", opts.EntityID),
+		sql.Named("before", opts.Before),
+		sql.Named("notBefore", opts.NotBefore),
+		sql.Named("afterID", opts.AfterID),
+	}
+}
+
+// Search will return audit log entries matching the provided options, newest first.
+func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Entry, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = new(SearchOptions)
+	}
+
+	data, err := (*renderData)(opts).Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := search.RenderQuery(ctx, searchTemplate, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "render query")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	var result []Entry
+	for rows.Next() {
+		var e Entry
+		var actorID sql.NullString
+		var before, after pqtype.NullRawMessage
+		err = rows.Scan(&e.ID, &e.Timestamp, &actorID, &e.ActorSource, &e.Action, &e.EntityType, &e.EntityID, &before, &after, &e.Error)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+		e.ActorUserID = actorID.String
+		e.Before = before.RawMessage
+		e.After = after.RawMessage
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
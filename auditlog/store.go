@@ -0,0 +1,66 @@
+package auditlog
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sqlc-dev/pqtype"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+)
+
+// Store records and searches the audit log.
+type Store struct {
+	db *sql.DB
+
+	insert *sql.Stmt
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		insert: p.P(`
+			insert into audit_logs (actor_user_id, actor_source, action, entity_type, entity_id, before_data, after_data, error)
+			values ($1, $2, $3, $4, $5, $6, $7, $8)
+		`),
+	}, p.Err
+}
+
+// LogTx will record entries in the audit log, with the actor taken from the context. If tx
+// is nil, the entries are written immediately, independent of any transaction.
+func (s *Store) LogTx(ctx context.Context, tx *sql.Tx, entries ...Entry) error {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return err
+	}
+
+	var actorID sql.NullString
+	if id := permission.UserID(ctx); id != "" {
+		actorID = sql.NullString{Valid: true, String: id}
+	}
+	var src string
+	if info := permission.Source(ctx); info != nil {
+		src = info.String()
+	}
+
+	stmt := s.insert
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	for _, e := range entries {
+		_, err = stmt.ExecContext(ctx, actorID, src, e.Action, string(e.EntityType), e.EntityID, nullJSON(e.Before), nullJSON(e.After), e.Error)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nullJSON(data []byte) pqtype.NullRawMessage {
+	return pqtype.NullRawMessage{Valid: len(data) > 0, RawMessage: data}
+}
@@ -35,6 +35,8 @@ func GetToken(req *http.Request) string {
 	}
 
 	tok = strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	// compat: OpsGenie clients use the GenieKey scheme
+	tok = strings.TrimPrefix(tok, "GenieKey ")
 	if tok != "" {
 		return tok
 	}
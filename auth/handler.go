@@ -577,7 +577,13 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		return true
 	}
 
-	switch req.URL.Path {
+	path := req.URL.Path
+	if strings.HasPrefix(path, "/api/v2/opsgenie/incoming/") {
+		// close and acknowledge requests include the alert alias in the path
+		path = "/api/v2/opsgenie/incoming"
+	}
+
+	switch path {
 	case "/v1/api/alerts", "/api/v2/generic/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGeneric)
 	case "/v1/webhooks/grafana", "/api/v2/grafana/incoming":
@@ -586,6 +592,8 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSite24x7)
 	case "/api/v2/prometheusalertmanager/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypePrometheusAlertmanager)
+	case "/api/v2/opsgenie/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeOpsGenie)
 	case "/api/v2/calendar":
 		ctx, err = h.cfg.CalSubStore.Authorize(ctx, *tok)
 	default:
@@ -647,7 +655,7 @@ func (h *Handler) tryAuthUser(ctx context.Context, w http.ResponseWriter, req *h
 // Updating and clearing the session cookie is automatically handled.
 func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if strings.HasPrefix(req.URL.Path, "/api/v2/slack") {
+		if strings.HasPrefix(req.URL.Path, "/api/v2/slack") || req.URL.Path == "/api/v2/telegram/webhook" {
 			wrapped.ServeHTTP(w, req)
 			return
 		}
@@ -0,0 +1,66 @@
+package businesshours
+
+import (
+	"time"
+
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// BusinessHours is a reusable definition of working hours, used to route escalation
+// policy step notifications differently during and outside of business hours.
+type BusinessHours struct {
+	ID          string
+	Name        string
+	Description string
+
+	// TimeZone is used to evaluate the daily window.
+	TimeZone *time.Location
+
+	// WeekdayFilter indicates the days the window starts on.
+	WeekdayFilter timeutil.WeekdayFilter
+
+	// Start and End define the daily window. Windows where End is before Start span
+	// midnight, ending on the following day.
+	Start timeutil.Clock
+	End   timeutil.Clock
+}
+
+// Contains returns true if t falls within business hours.
+func (bh BusinessHours) Contains(t time.Time) bool {
+	if bh.TimeZone != nil {
+		t = t.In(bh.TimeZone)
+	}
+
+	c := timeutil.NewClock(t.Hour(), t.Minute())
+	if bh.Start < bh.End {
+		return bh.WeekdayFilter.Day(t.Weekday()) && c >= bh.Start && c < bh.End
+	}
+
+	// overnight windows belong to the day they start on
+	return (bh.WeekdayFilter.Day(t.Weekday()) && c >= bh.Start) ||
+		(bh.WeekdayFilter.Day(t.Weekday()-1) && c < bh.End)
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (bh BusinessHours) Normalize() (*BusinessHours, error) {
+	err := validate.Many(
+		validate.IDName("Name", bh.Name),
+		validate.Text("Description", bh.Description, 0, 255),
+	)
+	if bh.TimeZone == nil {
+		err = validate.Many(err, validation.NewFieldError("TimeZone", "must be specified"))
+	}
+	if bh.WeekdayFilter.IsNever() {
+		err = validate.Many(err, validation.NewFieldError("WeekdayFilter", "must include at least one day"))
+	}
+	if bh.Start == bh.End {
+		err = validate.Many(err, validation.NewFieldError("End", "must differ from start"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &bh, nil
+}
@@ -0,0 +1,85 @@
+package businesshours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func weekdays() timeutil.WeekdayFilter {
+	var f timeutil.WeekdayFilter
+	for d := time.Monday; d <= time.Friday; d++ {
+		f.SetDay(d, true)
+	}
+	return f
+}
+
+func TestBusinessHours_Contains(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("time zone data not available:", err)
+	}
+
+	bh := BusinessHours{
+		TimeZone:      chicago,
+		WeekdayFilter: weekdays(),
+		Start:         timeutil.NewClock(9, 0),
+		End:           timeutil.NewClock(17, 0),
+	}
+
+	// Monday, October 23, 2023
+	at := func(day, hour, min int) time.Time { return time.Date(2023, 10, 23+day, hour, min, 0, 0, chicago) }
+
+	assert.True(t, bh.Contains(at(0, 9, 0)), "start of window")
+	assert.True(t, bh.Contains(at(0, 16, 59)), "end of window")
+	assert.False(t, bh.Contains(at(0, 17, 0)), "after window")
+	assert.False(t, bh.Contains(at(0, 8, 59)), "before window")
+	assert.False(t, bh.Contains(at(5, 12, 0)), "saturday")
+
+	// 14:00 UTC is 09:00 in Chicago (CDT)
+	assert.True(t, bh.Contains(time.Date(2023, 10, 23, 14, 0, 0, 0, time.UTC)), "other time zone")
+	assert.False(t, bh.Contains(time.Date(2023, 10, 23, 13, 59, 0, 0, time.UTC)), "other time zone before window")
+
+	// overnight, starting Monday through Friday at 22:00
+	bh.Start = timeutil.NewClock(22, 0)
+	bh.End = timeutil.NewClock(6, 0)
+	assert.True(t, bh.Contains(at(0, 23, 0)), "overnight start day")
+	assert.True(t, bh.Contains(at(1, 5, 59)), "overnight next day")
+	assert.False(t, bh.Contains(at(0, 5, 0)), "overnight, started sunday")
+	assert.True(t, bh.Contains(at(5, 5, 0)), "overnight, started friday")
+	assert.False(t, bh.Contains(at(5, 23, 0)), "overnight, saturday")
+}
+
+func TestBusinessHours_Normalize(t *testing.T) {
+	valid := BusinessHours{
+		Name:          "Support Hours",
+		TimeZone:      time.UTC,
+		WeekdayFilter: weekdays(),
+		Start:         timeutil.NewClock(9, 0),
+		End:           timeutil.NewClock(17, 0),
+	}
+	_, err := valid.Normalize()
+	assert.NoError(t, err)
+
+	bh := valid
+	bh.Name = ""
+	_, err = bh.Normalize()
+	assert.Error(t, err, "missing name")
+
+	bh = valid
+	bh.TimeZone = nil
+	_, err = bh.Normalize()
+	assert.Error(t, err, "missing time zone")
+
+	bh = valid
+	bh.WeekdayFilter = timeutil.WeekdayFilter{}
+	_, err = bh.Normalize()
+	assert.Error(t, err, "no days")
+
+	bh = valid
+	bh.End = bh.Start
+	_, err = bh.Normalize()
+	assert.Error(t, err, "empty window")
+}
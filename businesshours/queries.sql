@@ -0,0 +1,48 @@
+-- name: BusinessHoursCreate :exec
+INSERT INTO business_hours(id, name, description, time_zone, weekday_filter, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: BusinessHoursUpdate :exec
+UPDATE
+    business_hours
+SET
+    name = $2,
+    description = $3,
+    time_zone = $4,
+    weekday_filter = $5,
+    start_time = $6,
+    end_time = $7
+WHERE
+    id = $1;
+
+-- name: BusinessHoursDelete :exec
+DELETE FROM business_hours
+WHERE id = $1;
+
+-- name: BusinessHoursFindOne :one
+SELECT
+    id,
+    name,
+    description,
+    time_zone,
+    weekday_filter,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM
+    business_hours
+WHERE
+    id = $1;
+
+-- name: BusinessHoursFindAll :many
+SELECT
+    id,
+    name,
+    description,
+    time_zone,
+    weekday_filter,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM
+    business_hours
+ORDER BY
+    name;
@@ -0,0 +1,187 @@
+package businesshours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages business hours definitions.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// clockTime returns c as a time.Time for use as a DB time value.
+func clockTime(c timeutil.Clock) time.Time {
+	return time.Date(2000, 1, 1, c.Hour(), c.Minute(), 0, 0, time.UTC)
+}
+
+func weekdayArray(f timeutil.WeekdayFilter) []bool {
+	res := make([]bool, len(f))
+	for i := range f {
+		res[i] = f.Day(time.Weekday(i))
+	}
+	return res
+}
+
+func parse(id uuid.UUID, name, desc, tz string, days []bool, start, end string) (*BusinessHours, error) {
+	bh := BusinessHours{
+		ID:          id.String(),
+		Name:        name,
+		Description: desc,
+	}
+	var err error
+	bh.TimeZone, err = util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range days {
+		if i < len(bh.WeekdayFilter) {
+			bh.WeekdayFilter.SetDay(time.Weekday(i), d)
+		}
+	}
+	err = bh.Start.Scan(start)
+	if err != nil {
+		return nil, err
+	}
+	err = bh.End.Scan(end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bh, nil
+}
+
+// CreateTx will create a new business hours definition.
+func (s *Store) CreateTx(ctx context.Context, dbtx gadb.DBTX, bh *BusinessHours) (*BusinessHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := bh.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	err = gadb.New(dbtx).BusinessHoursCreate(ctx, gadb.BusinessHoursCreateParams{
+		ID:            id,
+		Name:          n.Name,
+		Description:   n.Description,
+		TimeZone:      n.TimeZone.String(),
+		WeekdayFilter: weekdayArray(n.WeekdayFilter),
+		StartTime:     clockTime(n.Start),
+		EndTime:       clockTime(n.End),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = id.String()
+	return n, nil
+}
+
+// UpdateTx will update an existing business hours definition.
+func (s *Store) UpdateTx(ctx context.Context, dbtx gadb.DBTX, bh *BusinessHours) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	id, err := validate.ParseUUID("BusinessHoursID", bh.ID)
+	if err != nil {
+		return err
+	}
+
+	n, err := bh.Normalize()
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).BusinessHoursUpdate(ctx, gadb.BusinessHoursUpdateParams{
+		ID:            id,
+		Name:          n.Name,
+		Description:   n.Description,
+		TimeZone:      n.TimeZone.String(),
+		WeekdayFilter: weekdayArray(n.WeekdayFilter),
+		StartTime:     clockTime(n.Start),
+		EndTime:       clockTime(n.End),
+	})
+}
+
+// DeleteTx will delete a business hours definition. Definitions in use by an
+// escalation policy step cannot be deleted.
+func (s *Store) DeleteTx(ctx context.Context, dbtx gadb.DBTX, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	bhID, err := validate.ParseUUID("BusinessHoursID", id)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).BusinessHoursDelete(ctx, bhID)
+}
+
+// FindOne will return a single business hours definition, or nil if it does not exist.
+func (s *Store) FindOne(ctx context.Context, id string) (*BusinessHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	bhID, err := validate.ParseUUID("BusinessHoursID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gadb.New(s.db).BusinessHoursFindOne(ctx, bhID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(r.ID, r.Name, r.Description, r.TimeZone, r.WeekdayFilter, r.StartTime, r.EndTime)
+}
+
+// FindAll will return all business hours definitions, ordered by name.
+func (s *Store) FindAll(ctx context.Context) ([]BusinessHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).BusinessHoursFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]BusinessHours, 0, len(rows))
+	for _, r := range rows {
+		bh, err := parse(r.ID, r.Name, r.Description, r.TimeZone, r.WeekdayFilter, r.StartTime, r.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *bh)
+	}
+
+	return result, nil
+}
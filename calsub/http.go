@@ -27,31 +27,51 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shifts, err := s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(1, 0, 0))
+	var subCfg SubscriptionConfig
+	err = json.Unmarshal(info.Config, &subCfg)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	// filter out other users
-	filtered := shifts[:0]
-	for _, s := range shifts {
-		if s.UserID != info.UserID.String() {
-			continue
-		}
-		filtered = append(filtered, s)
-	}
-
-	var subCfg SubscriptionConfig
-	err = json.Unmarshal(info.Config, &subCfg)
+	shifts, err := s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(0, 0, subCfg.Horizon()))
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
+	var userNames map[string]string
+	if subCfg.FullSchedule {
+		var ids []uuid.UUID
+		for _, s := range shifts {
+			ids = append(ids, uuid.MustParse(s.UserID))
+		}
+		rows, err := gadb.New(s.db).CalSubUserNames(ctx, ids)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		userNames = make(map[string]string, len(rows))
+		for _, r := range rows {
+			userNames[r.ID.String()] = r.Name
+		}
+	} else {
+		// filter out other users
+		filtered := shifts[:0]
+		for _, s := range shifts {
+			if s.UserID != info.UserID.String() {
+				continue
+			}
+			filtered = append(filtered, s)
+		}
+		shifts = filtered
+	}
+
 	data := renderData{
 		ApplicationName: cfg.ApplicationName(),
 		ScheduleID:      info.ScheduleID,
 		ScheduleName:    info.ScheduleName,
-		Shifts:          filtered,
+		TimeZone:        info.ScheduleTimeZone,
+		ServiceNames:    info.ServiceNames,
+		Shifts:          shifts,
+		UserNames:       userNames,
 		ReminderMinutes: subCfg.ReminderMinutes,
 		Version:         version.GitVersion(),
 		GeneratedAt:     info.Now,
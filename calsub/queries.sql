@@ -14,6 +14,18 @@ SELECT
     now()::timestamptz AS now,
     sub.schedule_id,
     sched.name AS schedule_name,
+    sched.time_zone AS schedule_time_zone,
+    array(
+        SELECT DISTINCT
+            svc.name
+        FROM
+            escalation_policy_actions act
+            JOIN escalation_policy_steps step ON step.id = act.escalation_policy_step_id
+            JOIN services svc ON svc.escalation_policy_id = step.escalation_policy_id
+        WHERE
+            act.schedule_id = sub.schedule_id
+        ORDER BY
+            svc.name)::text[] AS service_names,
     sub.config,
     sub.user_id
 FROM
@@ -22,6 +34,15 @@ FROM
 WHERE
     sub.id = $1;
 
+-- name: CalSubUserNames :many
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[]);
+
 -- name: FindOneCalSubForUpdate :one
 SELECT id,
     NAME,
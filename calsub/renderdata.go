@@ -11,7 +11,13 @@ type renderData struct {
 	ApplicationName string
 	ScheduleID      uuid.UUID
 	ScheduleName    string
+	TimeZone        string
+	ServiceNames    []string
 	Shifts          []oncall.Shift
+
+	// UserNames, if set, will include the name of the on-call user in each event.
+	UserNames map[string]string
+
 	ReminderMinutes []int
 	Version         string
 	GeneratedAt     time.Time
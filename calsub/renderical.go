@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
-	"html/template"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,14 +17,19 @@ PRODID:-//{{.ApplicationName}}//{{.Version}}//EN
 VERSION:2.0
 CALSCALE:GREGORIAN
 METHOD:PUBLISH
+{{- if .TimeZone}}
+X-WR-TIMEZONE:{{.TimeZone}}
+{{- end}}
 {{- $mins := .ReminderMinutes }}
 {{- $genTime := .GeneratedAt }}
-{{- $eventUIDs := .EventUIDs}}
+{{- $events := .Events}}
 {{- range $i, $s := .Shifts}}
+{{- $e := index $events $i}}
 BEGIN:VEVENT
-UID:{{index $eventUIDs $i}}
-SUMMARY:On-Call ({{$.ApplicationName}}: {{$.ScheduleName}}){{if $s.Truncated}} Begins*
-DESCRIPTION:The end time of this shift is unknown and will continue beyond what is displayed.
+UID:{{$e.UID}}
+SUMMARY:{{$e.Summary}}
+{{- if $e.Description}}
+DESCRIPTION:{{$e.Description}}
 {{- end }}
 DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
 DTSTART:{{.Start.UTC.Format "20060102T150405Z"}}
@@ -41,11 +46,22 @@ END:VEVENT
 END:VCALENDAR
 `, "\n", "\r\n")))
 
+type icalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+// icalText will escape s for use as an iCal TEXT value.
+func icalText(s string) string { return icalTextEscaper.Replace(s) }
+
 // renderICal will generate an iCal file from the renderData.
 func (r renderData) renderICal() ([]byte, error) {
 	var icalRender struct {
 		renderData
-		EventUIDs []string
+		Events []icalEvent
 	}
 	icalRender.renderData = r
 	for _, s := range r.Shifts {
@@ -54,7 +70,29 @@ func (r renderData) renderICal() ([]byte, error) {
 			t = s.Start
 		}
 		sum := sha256.Sum256([]byte(s.UserID + r.ScheduleID.String() + t.Format(time.RFC3339)))
-		icalRender.EventUIDs = append(icalRender.EventUIDs, hex.EncodeToString(sum[:]))
+
+		var e icalEvent
+		e.UID = hex.EncodeToString(sum[:])
+		e.Summary = "On-Call"
+		if name, ok := r.UserNames[s.UserID]; ok {
+			e.Summary += ": " + name
+		}
+		e.Summary += " (" + r.ApplicationName + ": " + r.ScheduleName + ")"
+		if s.Truncated {
+			e.Summary += " Begins*"
+		}
+		e.Summary = icalText(e.Summary)
+
+		var desc []string
+		if s.Truncated {
+			desc = append(desc, icalText("The end time of this shift is unknown and will continue beyond what is displayed."))
+		}
+		if len(r.ServiceNames) > 0 {
+			desc = append(desc, icalText("Services: "+strings.Join(r.ServiceNames, ", ")))
+		}
+		e.Description = strings.Join(desc, `\n`)
+
+		icalRender.Events = append(icalRender.Events, e)
 	}
 
 	buf := bytes.NewBuffer(nil)
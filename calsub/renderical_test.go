@@ -80,3 +80,45 @@ func TestRenderData_RenderICal(t *testing.T) {
 	}, "\r\n")
 	assert.Equal(t, expected, string(iCal))
 }
+
+func TestRenderData_RenderICal_FullSchedule(t *testing.T) {
+	generatedAt := time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC)
+	r := renderData{
+		ApplicationName: "GoAlert",
+		ScheduleID:      uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+		ScheduleName:    "Sched",
+		TimeZone:        "America/Chicago",
+		ServiceNames:    []string{"API", "Web, Mobile"},
+		Shifts: []oncall.Shift{{
+			UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+			Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+			End:    time.Date(2020, 1, 15, 8, 0, 0, 0, time.UTC),
+		}},
+		UserNames: map[string]string{
+			"01020304-0506-0708-090a-0b0c0d0e0f10": "Jane; Doe",
+		},
+		Version:     "dev",
+		GeneratedAt: generatedAt,
+	}
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//GoAlert//dev//EN",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-TIMEZONE:America/Chicago",
+		"BEGIN:VEVENT",
+		"UID:4c7d37bf28d64eccc1e74a3889cfc97f6839a00fa781c91721058df915de27ce",
+		`SUMMARY:On-Call: Jane\; Doe (GoAlert: Sched)`,
+		`DESCRIPTION:Services: API\, Web\, Mobile`,
+		"DTSTAMP:20200101T050000Z",
+		"DTSTART:20200101T080000Z",
+		"DTEND:20200115T080000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, string(iCal))
+}
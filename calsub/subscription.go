@@ -35,6 +35,7 @@ func (cs Subscription) Normalize() (*Subscription, error) {
 
 	err := validate.Many(
 		validate.Range("ReminderMinutes", len(cs.Config.ReminderMinutes), 0, 15),
+		validate.Range("HorizonDays", cs.Config.HorizonDays, 0, MaxHorizonDays),
 		validate.IDName("Name", cs.Name),
 		validate.UUID("ID", cs.ID),
 		validate.UUID("UserID", cs.UserID),
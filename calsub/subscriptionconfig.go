@@ -10,6 +10,30 @@ import (
 // SubscriptionConfig is the configuration for a calendar subscription.
 type SubscriptionConfig struct {
 	ReminderMinutes []int
+
+	// FullSchedule, if set, will include shifts for all users on the schedule
+	// instead of only the subscriber's own shifts.
+	FullSchedule bool `json:",omitempty"`
+
+	// HorizonDays is the number of days of upcoming shifts to include. If zero,
+	// DefaultHorizonDays is used.
+	HorizonDays int `json:",omitempty"`
+}
+
+const (
+	// DefaultHorizonDays is the look-ahead used when a subscription does not specify one.
+	DefaultHorizonDays = 365
+
+	// MaxHorizonDays is the maximum look-ahead a subscription may specify.
+	MaxHorizonDays = 366
+)
+
+// Horizon returns the configured look-ahead duration, in days.
+func (scfg SubscriptionConfig) Horizon() int {
+	if scfg.HorizonDays == 0 {
+		return DefaultHorizonDays
+	}
+	return scfg.HorizonDays
 }
 
 var (
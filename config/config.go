@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
@@ -32,13 +34,22 @@ type Config struct {
 		DisableSMSLinks              bool   `public:"true" info:"If set, SMS messages will not contain a URL pointing to GoAlert."`
 		DisableLabelCreation         bool   `public:"true" info:"Disables the ability to create new labels for services."`
 		DisableCalendarSubscriptions bool   `public:"true" info:"If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions."`
+		RestrictServiceManagement    bool   `public:"true" info:"If set, non-admin users may only manage services (and their escalation policies, integration keys, heartbeat monitors, and maintenance windows) matching one of their label grants."`
+		GraphQLMaxComplexity         int    `public:"true" info:"Maximum estimated cost of a GraphQL operation, where list and connection fields are weighted by their expected size. Operations exceeding it are rejected before execution (0 means no limit)."`
+		ContactMethodFailureLimit    int    `public:"true" info:"Contact methods are disabled after this many consecutive failed deliveries, and the user is notified using another contact method (0 means never disable)."`
+		ContactMethodDisableMinutes  int    `public:"true" info:"Contact methods disabled after repeated failed deliveries are re-enabled after this many minutes (0 means they stay disabled until the user re-enables them)."`
+		DefaultTimeZone              string `public:"true" info:"IANA time zone (e.g. America/Chicago) used for timestamps in notifications to users without a time zone set, unless the alert's service has one. Defaults to UTC."`
+		MaxUserNotificationsPerHour  int    `public:"true" info:"Maximum number of alert notifications sent to each user per hour, unless the user has their own limit set. Notifications for critical and fatal alerts are always sent. Additional notifications are held until the limit allows, and bundled by service (0 means no limit)."`
 	}
 
 	Maintenance struct {
-		AlertCleanupDays    int `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
-		AlertAutoCloseDays  int `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
-		APIKeyExpireDays    int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
-		ScheduleCleanupDays int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		AlertCleanupDays           int `public:"true" info:"Closed and archived alerts will be deleted after this many days (0 means disable cleanup)."`
+		AlertArchiveDays           int `public:"true" info:"Closed alerts will be moved to the archive after this many days, and remain available read-only (0 means disable archival). Should be less than AlertCleanupDays."`
+		AlertAutoCloseDays         int `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
+		APIKeyExpireDays           int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
+		ScheduleCleanupDays        int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		GQLAPIKeyExpireWarningDays int `public:"true" info:"GraphQL API keys will be flagged as expiring soon this many days before they expire (0 means disable)."`
+		GQLAPIKeyUsageHistoryRows  int `public:"true" info:"Number of usage history entries to keep for each GraphQL API key (0 means disable usage history)."`
 	}
 
 	Auth struct {
@@ -95,6 +106,7 @@ type Config struct {
 
 		SigningSecret       string `password:"true" info:"Signing secret to verify requests from slack."`
 		InteractiveMessages bool   `info:"Enable interactive messages (e.g. buttons)."`
+		SlashCommands       bool   `info:"Enable the slash command for listing, acknowledging, and closing alerts from Slack."`
 	}
 
 	Twilio struct {
@@ -114,6 +126,10 @@ type Config struct {
 		DisableTwoWaySMS      bool     `info:"Disables SMS reply codes for alert messages."`
 		SMSCarrierLookup      bool     `info:"Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply."`
 		SMSFromNumberOverride []string `info:"List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number."`
+		SMSFromNumberRegion   []string `info:"List of 'prefix=number' pairs, SMS messages to numbers starting with the provided country calling code (e.g. +44) will use the alternate From Number. The longest matching prefix is used, and carrier overrides take precedence."`
+
+		SMSMaxLength      int  `info:"Maximum length of alert SMS messages, in characters. Summaries are shortened at a word boundary to fit, and the link to the full alert is kept. Defaults to 160 (a single segment) if unset."`
+		SMSAllowMultipart bool `info:"Allows SMS messages longer than a single segment, up to SMS Max Length. Otherwise messages are limited to a single segment, unless the link and reply instructions alone do not fit."`
 	}
 
 	SMTP struct {
@@ -132,6 +148,27 @@ type Config struct {
 	Webhook struct {
 		Enable      bool     `public:"true" info:"Enables webhook as a contact method."`
 		AllowedURLs []string `public:"true" info:"If set, allows webhooks for these domains only."`
+
+		RetryMaxAttempts      int `info:"Maximum number of attempts to send a webhook notification before it is dead-lettered. Defaults to 4 if unset."`
+		RetryBaseDelaySeconds int `info:"Delay before retrying a failed webhook notification, doubled after each attempt. Defaults to 15 if unset."`
+		RetryMaxDelaySeconds  int `info:"Maximum delay between webhook notification attempts. Defaults to 600 if unset."`
+	}
+
+	MSTeams struct {
+		Enable bool `public:"true" info:"Enables Microsoft Teams channels (via incoming webhook) as notification targets."`
+	}
+
+	PagerDuty struct {
+		Enable         bool `public:"true" info:"Enables PagerDuty (via Events API v2 routing key) as an escalation target."`
+		ResolveOnClose bool `info:"Resolve the PagerDuty incident when the alert is closed in GoAlert."`
+	}
+
+	Telegram struct {
+		Enable bool `public:"true" info:"Enables Telegram as a contact method."`
+
+		BotUsername   string `public:"true" info:"Username of the Telegram bot (without the @), shown to users when linking a chat."`
+		BotToken      string `password:"true" info:"Bot API token provided by @BotFather."`
+		WebhookSecret string `password:"true" info:"Secret token set when registering the bot webhook, used to verify updates from Telegram."`
 	}
 
 	Feedback struct {
@@ -161,7 +198,8 @@ func (cfg Config) EmailIngressDomain() string {
 }
 
 // TwilioSMSFromNumber will determine the appropriate FROM number to use for SMS messages to the given number
-func (cfg Config) TwilioSMSFromNumber(carrier string) string {
+// and carrier (if known).
+func (cfg Config) TwilioSMSFromNumber(to, carrier string) string {
 	if carrier != "" {
 		for _, s := range cfg.Twilio.SMSFromNumberOverride {
 			parts := strings.SplitN(s, "=", 2)
@@ -175,6 +213,21 @@ func (cfg Config) TwilioSMSFromNumber(carrier string) string {
 		}
 	}
 
+	var prefix, from string
+	for _, s := range cfg.Twilio.SMSFromNumberRegion {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if !strings.HasPrefix(to, parts[0]) || len(parts[0]) <= len(prefix) {
+			continue
+		}
+		prefix, from = parts[0], parts[1]
+	}
+	if from != "" {
+		return from
+	}
+
 	if cfg.Twilio.MessagingServiceSID != "" {
 		return cfg.Twilio.MessagingServiceSID
 	}
@@ -182,6 +235,19 @@ func (cfg Config) TwilioSMSFromNumber(carrier string) string {
 	return cfg.Twilio.FromNumber
 }
 
+// validRegionPrefix returns true if prefix is a '+' followed by 1-6 digits, not starting with zero.
+func validRegionPrefix(prefix string) bool {
+	if len(prefix) < 2 || len(prefix) > 7 || prefix[0] != '+' || prefix[1] == '0' {
+		return false
+	}
+	for _, c := range prefix[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // RequestURL returns the full URL for the given request based on the current public url.
 func RequestURL(req *http.Request) string {
 	cfg := FromContext(req.Context())
@@ -388,6 +454,18 @@ func (cfg Config) ApplicationName() string {
 	return cfg.General.ApplicationName
 }
 
+// DefaultLocation will return the General.DefaultTimeZone, or UTC if unset or invalid.
+func (cfg Config) DefaultLocation() *time.Location {
+	if cfg.General.DefaultTimeZone == "" {
+		return time.UTC
+	}
+	loc, err := util.LoadLocation(cfg.General.DefaultTimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // PublicURL will return the General.PublicURL or a fallback address (i.e. the app listening port).
 func (cfg Config) PublicURL() string {
 	switch {
@@ -462,10 +540,21 @@ func (cfg Config) Validate() error {
 		validateKey("GitHub.ClientID", cfg.GitHub.ClientID),
 		validateKey("GitHub.ClientSecret", cfg.GitHub.ClientSecret),
 		validateKey("Slack.AccessToken", cfg.Slack.AccessToken),
+		validate.Range("General.GraphQLMaxComplexity", cfg.General.GraphQLMaxComplexity, 0, 1000000),
+		validate.Range("General.ContactMethodFailureLimit", cfg.General.ContactMethodFailureLimit, 0, 100),
+		validate.Range("General.ContactMethodDisableMinutes", cfg.General.ContactMethodDisableMinutes, 0, 43200),
+		validate.Range("General.MaxUserNotificationsPerHour", cfg.General.MaxUserNotificationsPerHour, 0, 1000),
 		validate.Range("Maintenance.AlertCleanupDays", cfg.Maintenance.AlertCleanupDays, 0, 9000),
+		validate.Range("Maintenance.AlertArchiveDays", cfg.Maintenance.AlertArchiveDays, 0, 9000),
 		validate.Range("Maintenance.AlertAutoCloseDays", cfg.Maintenance.AlertAutoCloseDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
 		validate.Range("Maintenance.ScheduleCleanupDays", cfg.Maintenance.ScheduleCleanupDays, 0, 9000),
+		validate.Range("Maintenance.GQLAPIKeyExpireWarningDays", cfg.Maintenance.GQLAPIKeyExpireWarningDays, 0, 9000),
+		validate.Range("Maintenance.GQLAPIKeyUsageHistoryRows", cfg.Maintenance.GQLAPIKeyUsageHistoryRows, 0, 10000),
+		validate.Range("Twilio.SMSMaxLength", cfg.Twilio.SMSMaxLength, 0, 1600),
+		validate.Range("Webhook.RetryMaxAttempts", cfg.Webhook.RetryMaxAttempts, 0, 20),
+		validate.Range("Webhook.RetryBaseDelaySeconds", cfg.Webhook.RetryBaseDelaySeconds, 0, 3600),
+		validate.Range("Webhook.RetryMaxDelaySeconds", cfg.Webhook.RetryMaxDelaySeconds, 0, 86400),
 		validateScopes("OIDC.Scopes", cfg.OIDC.Scopes),
 		validatePath("OIDC.UserInfoEmailPath", cfg.OIDC.UserInfoEmailPath),
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
@@ -473,6 +562,12 @@ func (cfg Config) Validate() error {
 		validateKey("Slack.SigningSecret", cfg.Slack.SigningSecret),
 	)
 
+	if cfg.General.DefaultTimeZone != "" {
+		if _, tzErr := util.LoadLocation(cfg.General.DefaultTimeZone); tzErr != nil {
+			err = validate.Many(err, validation.NewFieldError("General.DefaultTimeZone", "unknown time zone"))
+		}
+	}
+
 	if cfg.General.GoogleAnalyticsID != "" {
 		err = validate.Many(err, validate.MeasurementID("General.GoogleAnalyticsID", cfg.General.GoogleAnalyticsID))
 	}
@@ -505,6 +600,9 @@ func (cfg Config) Validate() error {
 	if cfg.Slack.InteractiveMessages && cfg.Slack.SigningSecret == "" {
 		err = validate.Many(err, validation.NewFieldError("Slack.SigningSecret", "required to enable Slack interactive messages"))
 	}
+	if cfg.Slack.SlashCommands && cfg.Slack.SigningSecret == "" {
+		err = validate.Many(err, validation.NewFieldError("Slack.SigningSecret", "required to enable Slack slash commands"))
+	}
 
 	err = validate.Many(
 		err,
@@ -539,6 +637,10 @@ func (cfg Config) Validate() error {
 			"From", cfg.SMTP.From,
 			"Address", cfg.SMTP.Address,
 		),
+		validateEnable("Telegram", cfg.Telegram.Enable,
+			"BotToken", cfg.Telegram.BotToken,
+			"WebhookSecret", cfg.Telegram.WebhookSecret,
+		),
 	)
 
 	if cfg.Feedback.OverrideURL != "" {
@@ -589,5 +691,26 @@ func (cfg Config) Validate() error {
 		m[parts[0]] = true
 	}
 
+	m = make(map[string]bool)
+	for i, str := range cfg.Twilio.SMSFromNumberRegion {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("Twilio.SMSFromNumberRegion[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'prefix=number'",
+			))
+			continue
+		}
+		if !validRegionPrefix(parts[0]) {
+			err = validate.Many(err, validation.NewFieldError(fname+".Prefix", "must be a '+' followed by a country calling code (e.g. +44)"))
+		}
+		err = validate.Many(err, validate.Phone(fname+".Phone", parts[1]))
+		if m[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("region prefix '%s' already set", parts[0])))
+		}
+		m[parts[0]] = true
+	}
+
 	return err
 }
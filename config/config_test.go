@@ -119,4 +119,35 @@ func TestConfig_Validate(t *testing.T) {
 		cfg.Twilio.VoiceLanguage = "\x00" // non-ASCII value
 		assert.Error(t, cfg.Validate(), "language must be a valid string")
 	})
+
+	t.Run("Twilio.SMSFromNumberRegion", func(t *testing.T) {
+		var cfg Config
+		cfg.Twilio.SMSFromNumberRegion = []string{"+44=+447911123456", "+1604=+16045550100"}
+		assert.NoError(t, cfg.Validate())
+
+		cfg.Twilio.SMSFromNumberRegion = []string{"+44"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSFromNumberRegion[0]", "number is required")
+
+		cfg.Twilio.SMSFromNumberRegion = []string{"44=+447911123456"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSFromNumberRegion[0].Prefix", "prefix must start with +")
+
+		cfg.Twilio.SMSFromNumberRegion = []string{"+44=foo"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSFromNumberRegion[0].Phone", "number must be valid")
+
+		cfg.Twilio.SMSFromNumberRegion = []string{"+44=+447911123456", "+44=+447911123457"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSFromNumberRegion[1]", "prefix must be unique")
+	})
+}
+
+func TestConfig_TwilioSMSFromNumber(t *testing.T) {
+	var cfg Config
+	cfg.Twilio.FromNumber = "+17633456789"
+	cfg.Twilio.SMSFromNumberOverride = []string{"carrier=+17633450000"}
+	cfg.Twilio.SMSFromNumberRegion = []string{"+1=+17633451111", "+1604=+16045550100", "+44=+447911123456"}
+
+	assert.Equal(t, "+17633450000", cfg.TwilioSMSFromNumber("+447911654321", "carrier"), "carrier override first")
+	assert.Equal(t, "+447911123456", cfg.TwilioSMSFromNumber("+447911654321", ""))
+	assert.Equal(t, "+16045550100", cfg.TwilioSMSFromNumber("+16045550199", ""), "longest prefix")
+	assert.Equal(t, "+17633451111", cfg.TwilioSMSFromNumber("+17635550199", ""))
+	assert.Equal(t, "+17633456789", cfg.TwilioSMSFromNumber("+33612345678", ""), "default")
 }
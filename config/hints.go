@@ -17,6 +17,10 @@ type Hints struct {
 	}
 	Slack struct {
 		InteractivityResponseURL string
+		SlashCommandURL          string
+	}
+	Telegram struct {
+		WebhookURL string
 	}
 }
 
@@ -30,6 +34,8 @@ func (cfg Config) Hints() Hints {
 	h.Twilio.MessageWebhookURL = cfg.CallbackURL("/api/v2/twilio/message")
 	h.Twilio.VoiceWebhookURL = cfg.CallbackURL("/api/v2/twilio/call")
 	h.Slack.InteractivityResponseURL = cfg.CallbackURL("/api/v2/slack/message-action")
+	h.Slack.SlashCommandURL = cfg.CallbackURL("/api/v2/slack/command")
+	h.Telegram.WebhookURL = cfg.CallbackURL("/api/v2/telegram/webhook")
 
 	return h
 }
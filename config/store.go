@@ -297,6 +297,17 @@ func (s *Store) UpdateConfig(ctx context.Context, fn func(Config) (Config, error
 	return s.Reload(ctx)
 }
 
+// UpdateConfigTx will update the configuration in the DB as part of tx, returning the new
+// config ID. The caller must call Reload once tx is committed.
+func (s *Store) UpdateConfigTx(ctx context.Context, tx *sql.Tx, fn func(Config) (Config, error)) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.updateConfigTx(ctx, tx, fn)
+}
+
 // SetConfig will replace the configuration in the DB and perform an immediate reload.
 func (s *Store) SetConfig(ctx context.Context, cfg Config) error {
 	return s.UpdateConfig(ctx, func(Config) (Config, error) { return cfg, nil })
@@ -0,0 +1,196 @@
+package configexport
+
+import (
+	"strings"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/integrationkey"
+)
+
+// Version is the version of the Document format. It is incremented whenever a change
+// would break tools reading an older Document.
+//
+// Version 2 added step, target, and schedule fields that change when and who is
+// notified, as well as business hours.
+const Version = 2
+
+// A Document is a point-in-time snapshot of the configuration of services, escalation
+// policies, schedules, rotations, and business hours.
+//
+// Users and notification channels are referenced by ID and are not included. Secrets,
+// such as routing webhook secrets and integration key IDs, are never exported.
+type Document struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	Services           []Service          `json:"services"`
+	EscalationPolicies []EscalationPolicy `json:"escalation_policies"`
+	Schedules          []Schedule         `json:"schedules"`
+	Rotations          []Rotation         `json:"rotations"`
+	BusinessHours      []BusinessHours    `json:"business_hours"`
+}
+
+// Service is the exported configuration of a service.
+type Service struct {
+	ID                       string           `json:"id"`
+	Name                     string           `json:"name"`
+	Description              string           `json:"description"`
+	EscalationPolicyID       string           `json:"escalation_policy_id"`
+	NotificationTemplate     string           `json:"notification_template,omitempty"`
+	RunbookURL               string           `json:"runbook_url,omitempty"`
+	NotificationDelayMinutes int              `json:"notification_delay_minutes,omitempty"`
+	RequireCloseReason       bool             `json:"require_close_reason,omitempty"`
+	EnrichmentURL            string           `json:"enrichment_url,omitempty"`
+	TimeZone                 string           `json:"time_zone,omitempty"`
+	Labels                   []Label          `json:"labels"`
+	IntegrationKeys          []IntegrationKey `json:"integration_keys"`
+}
+
+// Label is a key/value label of a service.
+type Label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// IntegrationKey is the exported configuration of an integration key.
+//
+// The ID of an integration key is the secret used to create alerts, so only its last
+// few characters are included.
+type IntegrationKey struct {
+	MaskedID string `json:"masked_id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+
+	AlertRateLimit     int                          `json:"alert_rate_limit,omitempty"`
+	DedupWindowMinutes int                          `json:"dedup_window_minutes,omitempty"`
+	DefaultLabels      integrationkey.DefaultLabels `json:"default_labels,omitempty"`
+	FieldMapping       *integrationkey.FieldMapping `json:"field_mapping,omitempty"`
+	Routing            *integrationkey.Routing      `json:"routing,omitempty"`
+}
+
+// EscalationPolicy is the exported configuration of an escalation policy.
+type EscalationPolicy struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Repeat      int    `json:"repeat"`
+	Steps       []Step `json:"steps"`
+}
+
+// Step is a step of an escalation policy, in order.
+type Step struct {
+	ID           string       `json:"id"`
+	DelayMinutes int          `json:"delay_minutes"`
+	Targets      []StepTarget `json:"targets"`
+
+	// OffHoursDelayMinutes replaces DelayMinutes outside of DelayBusinessHoursID.
+	DelayBusinessHoursID string `json:"delay_business_hours_id,omitempty"`
+	OffHoursDelayMinutes *int   `json:"off_hours_delay_minutes,omitempty"`
+
+	MinSeverity        string `json:"min_severity,omitempty"`
+	LowUrgency         bool   `json:"low_urgency,omitempty"`
+	AssignmentStrategy string `json:"assignment_strategy"`
+	ConferenceBridge   bool   `json:"conference_bridge,omitempty"`
+
+	RoutingWebhookURL      string `json:"routing_webhook_url,omitempty"`
+	RoutingBusinessHoursID string `json:"routing_business_hours_id,omitempty"`
+}
+
+// StepTarget is a target of an escalation policy step.
+type StepTarget struct {
+	Target
+
+	// BusinessHoursCondition, if set, limits the target to during (`in_hours`) or outside
+	// of (`after_hours`) the routing business hours of the step.
+	BusinessHoursCondition string `json:"business_hours_condition,omitempty"`
+}
+
+// Target is the type and ID of something being notified or scheduled.
+type Target struct {
+	Type assignment.TargetType `json:"type"`
+	ID   string                `json:"id"`
+}
+
+// Schedule is the exported configuration of a schedule.
+type Schedule struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	TimeZone    string         `json:"time_zone"`
+	Rules       []ScheduleRule `json:"rules"`
+
+	FixedShifts        []Shift             `json:"fixed_shifts"`
+	TemporarySchedules []TemporarySchedule `json:"temporary_schedules"`
+}
+
+// ScheduleRule is a rule of a schedule. Start and End are clock times (e.g. `09:00`) in
+// the time zone of the schedule.
+type ScheduleRule struct {
+	Target   Target   `json:"target"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Weekdays []string `json:"weekdays"`
+	Priority int      `json:"priority,omitempty"`
+}
+
+// Shift is a planned on-call shift of a user.
+type Shift struct {
+	UserID string    `json:"user_id"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+// TemporarySchedule replaces all rule-based shifts of a schedule between Start and End.
+type TemporarySchedule struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Shifts []Shift   `json:"shifts"`
+}
+
+// Rotation is the exported configuration of a rotation.
+type Rotation struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"`
+	ShiftLength int       `json:"shift_length"`
+	Start       time.Time `json:"start"`
+	TimeZone    string    `json:"time_zone"`
+
+	// ParticipantUserIDs are the IDs of the users in the rotation, in order.
+	ParticipantUserIDs []string `json:"participant_user_ids"`
+
+	// ParticipantWeights are the number of consecutive shifts of each participant, in
+	// the same order as ParticipantUserIDs.
+	ParticipantWeights []int `json:"participant_weights"`
+
+	Shadows []Shadow `json:"shadows"`
+}
+
+// Shadow is a user receiving copies of the notifications of a rotation.
+type Shadow struct {
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BusinessHours is the exported configuration of a set of business hours. Start and End
+// are clock times in TimeZone.
+type BusinessHours struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	TimeZone    string   `json:"time_zone"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	Weekdays    []string `json:"weekdays"`
+}
+
+// maskKey will replace all but the last 4 characters of an integration key with `*`.
+func maskKey(id string) string {
+	if len(id) <= 4 {
+		return strings.Repeat("*", len(id))
+	}
+
+	return strings.Repeat("*", len(id)-4) + id[len(id)-4:]
+}
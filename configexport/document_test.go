@@ -0,0 +1,37 @@
+package configexport
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/assignment"
+)
+
+func TestMaskKey(t *testing.T) {
+	assert.Equal(t, strings.Repeat("*", 32)+"abcd", maskKey("00000000-0000-0000-0000-00000000abcd"))
+	assert.Equal(t, "***", maskKey("abc"))
+}
+
+func TestTarget_JSON(t *testing.T) {
+	data, err := json.Marshal(Target{Type: assignment.TargetTypeNotificationChannel, ID: "foo"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"notificationChannel","id":"foo"}`, string(data))
+}
+
+func TestStepTarget_JSON(t *testing.T) {
+	data, err := json.Marshal(StepTarget{Target: Target{Type: assignment.TargetTypeUser, ID: "foo"}, BusinessHoursCondition: "in_hours"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"user","id":"foo","business_hours_condition":"in_hours"}`, string(data))
+
+	data, err = json.Marshal(StepTarget{Target: Target{Type: assignment.TargetTypeUser, ID: "foo"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"user","id":"foo"}`, string(data))
+}
+
+func TestWeekdayNames(t *testing.T) {
+	assert.Equal(t, []string{"sunday", "saturday"}, weekdayNames([]bool{true, false, false, false, false, false, true}))
+	assert.Equal(t, []string{}, weekdayNames(make([]bool, 7)))
+}
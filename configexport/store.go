@@ -0,0 +1,463 @@
+package configexport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// Store exports the configuration of services, escalation policies, schedules, and rotations.
+type Store struct {
+	db *sql.DB
+
+	services   *sql.Stmt
+	labels     *sql.Stmt
+	intKeys    *sql.Stmt
+	policies   *sql.Stmt
+	steps      *sql.Stmt
+	stepTgts   *sql.Stmt
+	schedules  *sql.Stmt
+	rules      *sql.Stmt
+	rotations  *sql.Stmt
+	rotateUsrs *sql.Stmt
+	shadows    *sql.Stmt
+	schedData  *sql.Stmt
+	bizHours   *sql.Stmt
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	s := &Store{
+		db: db,
+
+		services: p.P(`
+			select
+				id, name, description, escalation_policy_id, notification_template,
+				runbook_url, notification_delay_minutes, require_close_reason, enrichment_url, time_zone
+			from services
+			order by lower(name), id
+		`),
+		labels: p.P(`select tgt_service_id, key, value from labels order by key`),
+		intKeys: p.P(`
+			select
+				service_id, id, name, type, coalesce(alert_rate_limit, 0), coalesce(dedup_window_minutes, 0),
+				default_labels, field_mapping, route_label_key, route_field
+			from integration_keys
+			order by lower(name), id
+		`),
+		policies: p.P(`
+			select id, name, description, repeat
+			from escalation_policies
+			order by lower(name), id
+		`),
+		steps: p.P(`
+			select
+				id, escalation_policy_id, delay, delay_business_hours_id, off_hours_delay,
+				min_severity, low_urgency, assignment_strategy, conference_bridge,
+				routing_webhook_url, routing_business_hours_id
+			from escalation_policy_steps
+			order by step_number
+		`),
+		stepTgts: p.P(`
+			select
+				escalation_policy_step_id,
+				coalesce(user_id, schedule_id, rotation_id, channel_id),
+				user_id notnull, schedule_id notnull, rotation_id notnull,
+				business_hours_condition
+			from escalation_policy_actions
+			order by id
+		`),
+		schedules: p.P(`
+			select id, name, description, time_zone
+			from schedules
+			order by lower(name), id
+		`),
+		rules: p.P(`
+			select
+				schedule_id,
+				coalesce(tgt_user_id, tgt_rotation_id),
+				tgt_user_id notnull,
+				to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'),
+				sunday, monday, tuesday, wednesday, thursday, friday, saturday,
+				priority
+			from schedule_rules
+			order by created_at, id
+		`),
+		rotations: p.P(`
+			select id, name, description, type, shift_length, start_time, time_zone
+			from rotations
+			order by lower(name), id
+		`),
+		rotateUsrs: p.P(`select rotation_id, user_id, weight from rotation_participants order by position`),
+		shadows:    p.P(`select rotation_id, user_id, expires_at from rotation_shadows where expires_at > now() order by expires_at, id`),
+		schedData:  p.P(`select schedule_id, data from schedule_data`),
+		bizHours: p.P(`
+			select
+				id, name, description, time_zone,
+				to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'),
+				weekday_filter[1], weekday_filter[2], weekday_filter[3], weekday_filter[4],
+				weekday_filter[5], weekday_filter[6], weekday_filter[7]
+			from business_hours
+			order by lower(name), id
+		`),
+	}
+
+	return s, p.Err
+}
+
+var weekdays = [...]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// Export will return a Document of the current configuration. All data is read from a
+// single snapshot of the database.
+func (s *Store) Export(ctx context.Context) (*Document, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "config export", tx)
+
+	doc := &Document{
+		Version:    Version,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+
+		Services:           []Service{},
+		EscalationPolicies: []EscalationPolicy{},
+		Schedules:          []Schedule{},
+		Rotations:          []Rotation{},
+		BusinessHours:      []BusinessHours{},
+	}
+
+	svcIdx := make(map[string]int)
+	err = eachRow(ctx, tx, s.services, func(rows *sql.Rows) error {
+		var svc Service
+		err := rows.Scan(&svc.ID, &svc.Name, &svc.Description, &svc.EscalationPolicyID, &svc.NotificationTemplate, &svc.RunbookURL, &svc.NotificationDelayMinutes, &svc.RequireCloseReason, &svc.EnrichmentURL, &svc.TimeZone)
+		if err != nil {
+			return err
+		}
+		svc.Labels = []Label{}
+		svc.IntegrationKeys = []IntegrationKey{}
+		svcIdx[svc.ID] = len(doc.Services)
+		doc.Services = append(doc.Services, svc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.labels, func(rows *sql.Rows) error {
+		var svcID string
+		var l Label
+		err := rows.Scan(&svcID, &l.Key, &l.Value)
+		if err != nil {
+			return err
+		}
+		if i, ok := svcIdx[svcID]; ok {
+			doc.Services[i].Labels = append(doc.Services[i].Labels, l)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.intKeys, func(rows *sql.Rows) error {
+		var svcID, id string
+		var key IntegrationKey
+		var labels, mapping []byte
+		var routeKey, routeField sql.NullString
+		err := rows.Scan(&svcID, &id, &key.Name, &key.Type, &key.AlertRateLimit, &key.DedupWindowMinutes, &labels, &mapping, &routeKey, &routeField)
+		if err != nil {
+			return err
+		}
+		key.MaskedID = maskKey(id)
+		if len(labels) > 0 {
+			err = json.Unmarshal(labels, &key.DefaultLabels)
+			if err != nil {
+				return fmt.Errorf("decode default labels: %w", err)
+			}
+		}
+		if len(mapping) > 0 {
+			key.FieldMapping = new(integrationkey.FieldMapping)
+			err = json.Unmarshal(mapping, key.FieldMapping)
+			if err != nil {
+				return fmt.Errorf("decode field mapping: %w", err)
+			}
+		}
+		if routeKey.Valid {
+			key.Routing = &integrationkey.Routing{LabelKey: routeKey.String, Field: routeField.String}
+		}
+		if i, ok := svcIdx[svcID]; ok {
+			doc.Services[i].IntegrationKeys = append(doc.Services[i].IntegrationKeys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	epIdx := make(map[string]int)
+	err = eachRow(ctx, tx, s.policies, func(rows *sql.Rows) error {
+		var ep EscalationPolicy
+		err := rows.Scan(&ep.ID, &ep.Name, &ep.Description, &ep.Repeat)
+		if err != nil {
+			return err
+		}
+		ep.Steps = []Step{}
+		epIdx[ep.ID] = len(doc.EscalationPolicies)
+		doc.EscalationPolicies = append(doc.EscalationPolicies, ep)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	type stepRef struct{ ep, step int }
+	stepIdx := make(map[string]stepRef)
+	err = eachRow(ctx, tx, s.steps, func(rows *sql.Rows) error {
+		var epID string
+		var step Step
+		var delayBizHrs, minSev, webhookURL, routeBizHrs sql.NullString
+		var offHoursDelay sql.NullInt64
+		err := rows.Scan(&step.ID, &epID, &step.DelayMinutes, &delayBizHrs, &offHoursDelay, &minSev, &step.LowUrgency, &step.AssignmentStrategy, &step.ConferenceBridge, &webhookURL, &routeBizHrs)
+		if err != nil {
+			return err
+		}
+		i, ok := epIdx[epID]
+		if !ok {
+			return nil
+		}
+		step.DelayBusinessHoursID = delayBizHrs.String
+		if offHoursDelay.Valid {
+			delay := int(offHoursDelay.Int64)
+			step.OffHoursDelayMinutes = &delay
+		}
+		step.MinSeverity = minSev.String
+		step.RoutingWebhookURL = webhookURL.String
+		step.RoutingBusinessHoursID = routeBizHrs.String
+		step.Targets = []StepTarget{}
+		stepIdx[step.ID] = stepRef{ep: i, step: len(doc.EscalationPolicies[i].Steps)}
+		doc.EscalationPolicies[i].Steps = append(doc.EscalationPolicies[i].Steps, step)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.stepTgts, func(rows *sql.Rows) error {
+		var stepID string
+		var tgt StepTarget
+		var isUser, isSched, isRot bool
+		var cond sql.NullString
+		err := rows.Scan(&stepID, &tgt.ID, &isUser, &isSched, &isRot, &cond)
+		if err != nil {
+			return err
+		}
+		tgt.BusinessHoursCondition = cond.String
+		switch {
+		case isUser:
+			tgt.Type = assignment.TargetTypeUser
+		case isSched:
+			tgt.Type = assignment.TargetTypeSchedule
+		case isRot:
+			tgt.Type = assignment.TargetTypeRotation
+		default:
+			tgt.Type = assignment.TargetTypeNotificationChannel
+		}
+		if ref, ok := stepIdx[stepID]; ok {
+			step := &doc.EscalationPolicies[ref.ep].Steps[ref.step]
+			step.Targets = append(step.Targets, tgt)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedIdx := make(map[string]int)
+	err = eachRow(ctx, tx, s.schedules, func(rows *sql.Rows) error {
+		var sched Schedule
+		err := rows.Scan(&sched.ID, &sched.Name, &sched.Description, &sched.TimeZone)
+		if err != nil {
+			return err
+		}
+		sched.Rules = []ScheduleRule{}
+		sched.FixedShifts = []Shift{}
+		sched.TemporarySchedules = []TemporarySchedule{}
+		schedIdx[sched.ID] = len(doc.Schedules)
+		doc.Schedules = append(doc.Schedules, sched)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.rules, func(rows *sql.Rows) error {
+		var schedID string
+		var r ScheduleRule
+		var isUser bool
+		var days [len(weekdays)]bool
+		err := rows.Scan(&schedID, &r.Target.ID, &isUser, &r.Start, &r.End, &days[0], &days[1], &days[2], &days[3], &days[4], &days[5], &days[6], &r.Priority)
+		if err != nil {
+			return err
+		}
+		r.Target.Type = assignment.TargetTypeRotation
+		if isUser {
+			r.Target.Type = assignment.TargetTypeUser
+		}
+		r.Weekdays = weekdayNames(days[:])
+		if i, ok := schedIdx[schedID]; ok {
+			doc.Schedules[i].Rules = append(doc.Schedules[i].Rules, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rotIdx := make(map[string]int)
+	err = eachRow(ctx, tx, s.rotations, func(rows *sql.Rows) error {
+		var rot Rotation
+		err := rows.Scan(&rot.ID, &rot.Name, &rot.Description, &rot.Type, &rot.ShiftLength, &rot.Start, &rot.TimeZone)
+		if err != nil {
+			return err
+		}
+		rot.Start = rot.Start.UTC()
+		rot.ParticipantUserIDs = []string{}
+		rot.ParticipantWeights = []int{}
+		rot.Shadows = []Shadow{}
+		rotIdx[rot.ID] = len(doc.Rotations)
+		doc.Rotations = append(doc.Rotations, rot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.rotateUsrs, func(rows *sql.Rows) error {
+		var rotID, userID string
+		var weight int
+		err := rows.Scan(&rotID, &userID, &weight)
+		if err != nil {
+			return err
+		}
+		if i, ok := rotIdx[rotID]; ok {
+			doc.Rotations[i].ParticipantUserIDs = append(doc.Rotations[i].ParticipantUserIDs, userID)
+			doc.Rotations[i].ParticipantWeights = append(doc.Rotations[i].ParticipantWeights, weight)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachRow(ctx, tx, s.shadows, func(rows *sql.Rows) error {
+		var rotID string
+		var sh Shadow
+		err := rows.Scan(&rotID, &sh.UserID, &sh.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		sh.ExpiresAt = sh.ExpiresAt.UTC()
+		if i, ok := rotIdx[rotID]; ok {
+			doc.Rotations[i].Shadows = append(doc.Rotations[i].Shadows, sh)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachRow(ctx, tx, s.schedData, func(rows *sql.Rows) error {
+		var schedID string
+		var raw []byte
+		err := rows.Scan(&schedID, &raw)
+		if err != nil {
+			return err
+		}
+		i, ok := schedIdx[schedID]
+		if !ok || len(raw) == 0 {
+			return nil
+		}
+		var data schedule.Data
+		err = json.Unmarshal(raw, &data)
+		if err != nil {
+			return fmt.Errorf("decode schedule data: %w", err)
+		}
+		doc.Schedules[i].FixedShifts = exportShifts(data.V1.FixedShifts)
+		for _, tmp := range data.V1.TemporarySchedules {
+			doc.Schedules[i].TemporarySchedules = append(doc.Schedules[i].TemporarySchedules, TemporarySchedule{
+				Start:  tmp.Start.UTC(),
+				End:    tmp.End.UTC(),
+				Shifts: exportShifts(tmp.Shifts),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachRow(ctx, tx, s.bizHours, func(rows *sql.Rows) error {
+		var bh BusinessHours
+		var days [len(weekdays)]bool
+		err := rows.Scan(&bh.ID, &bh.Name, &bh.Description, &bh.TimeZone, &bh.Start, &bh.End, &days[0], &days[1], &days[2], &days[3], &days[4], &days[5], &days[6])
+		if err != nil {
+			return err
+		}
+		bh.Weekdays = weekdayNames(days[:])
+		doc.BusinessHours = append(doc.BusinessHours, bh)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// weekdayNames will return the names of the enabled days, starting with Sunday.
+func weekdayNames(days []bool) []string {
+	names := []string{}
+	for i, enabled := range days {
+		if enabled {
+			names = append(names, weekdays[i])
+		}
+	}
+	return names
+}
+
+// exportShifts will convert shifts to their exported form.
+func exportShifts(shifts []schedule.FixedShift) []Shift {
+	res := make([]Shift, 0, len(shifts))
+	for _, s := range shifts {
+		res = append(res, Shift{UserID: s.UserID, Start: s.Start.UTC(), End: s.End.UTC()})
+	}
+	return res
+}
+
+// eachRow will call scan for each row returned by stmt.
+func eachRow(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt, scan func(*sql.Rows) error) error {
+	rows, err := tx.StmtContext(ctx, stmt).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -36,7 +36,9 @@ func newBackend(db *sql.DB) (*backend, error) {
 				alert_id,
 				service_id,
 				contact_method_id,
-				created_at
+				created_at,
+				shadow,
+				watcher
 			FROM outgoing_messages
 			WHERE id = $1
 		`),
@@ -61,7 +63,7 @@ func (b *backend) FindOne(ctx context.Context, id string) (*callback, error) {
 	var alertID sql.NullInt64
 	var serviceID sql.NullString
 	var cmID sql.NullString
-	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &c.CreatedAt)
+	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &c.CreatedAt, &c.Shadow, &c.Watcher)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,80 @@
+package bridgemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util"
+)
+
+// DB handles announcing new alert conference bridges, and ending them once their alert
+// is closed.
+type DB struct {
+	lock   *processinglock.Lock
+	log    *alertlog.Store
+	twilio *twilio.Config
+
+	announce *sql.Stmt
+	closed   *sql.Stmt
+	end      *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.BridgeManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store, tw *twilio.Config) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Version: 1,
+		Type:    processinglock.TypeBridge,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		log:    log,
+		twilio: tw,
+
+		announce: p.P(`
+			update alert_conference_bridges
+			set announced_at = now()
+			where announced_at isnull
+			returning alert_id
+		`),
+
+		closed: p.P(`
+			select b.alert_id, b.conference_sid
+			from alert_conference_bridges b
+			join alerts a on a.id = b.alert_id and a.status = 'closed'
+			where b.ended_at isnull
+			for update of b skip locked
+			limit 100
+		`),
+
+		end: p.P(`
+			with ended as (
+				update alert_conference_bridges
+				set
+					ended_at = now(),
+					conference_sid = null
+				where alert_id = any($1)
+				returning alert_id
+			), _left as (
+				update alert_conference_participants part
+				set left_at = now()
+				from ended
+				where
+					part.alert_id = ended.alert_id and
+					part.left_at isnull
+			)
+			select alert_id from ended
+		`),
+	}, p.Err
+}
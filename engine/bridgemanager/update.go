@@ -0,0 +1,152 @@
+package bridgemanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// UpdateAll will post the dial-in details of new conference bridges to their alerts, and
+// end the bridges of closed alerts.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Processing conference bridges.")
+
+	err = db.announceBridges(ctx)
+	if err != nil {
+		return fmt.Errorf("announce bridges: %w", err)
+	}
+
+	err = db.endBridges(ctx)
+	if err != nil {
+		return fmt.Errorf("end bridges: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) announceBridges(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "bridge manager: announce", tx)
+
+	alertIDs, err := scanIDs(tx.StmtContext(ctx, db.announce).QueryContext(ctx))
+	if err != nil {
+		return fmt.Errorf("mark announced: %w", err)
+	}
+	if len(alertIDs) == 0 {
+		return tx.Commit()
+	}
+
+	meta := &alertlog.ConferenceBridgeMetaData{Event: alertlog.BridgeStarted}
+	if cfg := config.FromContext(ctx); cfg.Twilio.Enable {
+		meta.Number = cfg.Twilio.FromNumber
+	}
+	err = db.log.LogManyTx(ctx, tx, alertIDs, alertlog.TypeConferenceBridge, meta)
+	if err != nil {
+		return fmt.Errorf("log bridge started: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// endBridges will mark the bridges of closed alerts as ended, then hang up their conferences.
+// Conferences are ended after the transaction is committed, so no locks are held waiting on
+// Twilio.
+func (db *DB) endBridges(ctx context.Context) error {
+	sids, err := db.markEnded(ctx)
+	if err != nil {
+		return err
+	}
+	if db.twilio == nil {
+		return nil
+	}
+
+	for _, sid := range sids {
+		// the bridge is ended regardless, a failure just means participants have to hang up themselves
+		err = db.twilio.EndConference(ctx, sid)
+		if err != nil {
+			log.Log(log.WithField(ctx, "ConferenceSID", sid), fmt.Errorf("end conference: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// markEnded will end the bridges of closed alerts, returning the SIDs of their conferences.
+func (db *DB) markEnded(ctx context.Context) ([]string, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "bridge manager: end", tx)
+
+	rows, err := tx.StmtContext(ctx, db.closed).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find bridges of closed alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alertIDs []int
+	var sids []string
+	for rows.Next() {
+		var id int
+		var sid sql.NullString
+		err = rows.Scan(&id, &sid)
+		if err != nil {
+			return nil, fmt.Errorf("scan bridge: %w", err)
+		}
+		alertIDs = append(alertIDs, id)
+		if sid.Valid {
+			sids = append(sids, sid.String)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("find bridges of closed alerts: %w", err)
+	}
+	if len(alertIDs) == 0 {
+		return nil, tx.Commit()
+	}
+
+	ended, err := scanIDs(tx.StmtContext(ctx, db.end).QueryContext(ctx, sqlutil.IntArray(alertIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("mark ended: %w", err)
+	}
+
+	err = db.log.LogManyTx(ctx, tx, ended, alertlog.TypeConferenceBridge, &alertlog.ConferenceBridgeMetaData{Event: alertlog.BridgeEnded})
+	if err != nil {
+		return nil, fmt.Errorf("log bridge ended: %w", err)
+	}
+
+	return sids, tx.Commit()
+}
+
+func scanIDs(rows *sql.Rows, err error) ([]int, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
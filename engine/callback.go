@@ -14,6 +14,12 @@ type callback struct {
 	ServiceID       string
 	ContactMethodID string
 	CreatedAt       time.Time
+
+	// Shadow is set for training notifications sent to a rotation shadow.
+	Shadow bool
+
+	// Watcher is set for informational notifications sent to a service watcher.
+	Watcher bool
 }
 
 func (c callback) Normalize() (*callback, error) {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/util"
 )
@@ -18,19 +19,26 @@ type DB struct {
 
 	userIDs        *sql.Stmt
 	cleanupAlerts  *sql.Stmt
+	archiveAlerts  *sql.Stmt
+	cleanupArchive *sql.Stmt
 	cleanupAPIKeys *sql.Stmt
 	setTimeout     *sql.Stmt
 
+	cleanupAPIKeyUsage *sql.Stmt
+
 	schedData    *sql.Stmt
 	setSchedData *sql.Stmt
 
 	cleanupSessions *sql.Stmt
+	cleanupShadows  *sql.Stmt
 
 	cleanupAlertLogs *sql.Stmt
 
 	cleanupOverrides   *sql.Stmt
+	cleanupRecurrences *sql.Stmt
 	cleanupSchedOnCall *sql.Stmt
 	cleanupEPOnCall    *sql.Stmt
+	cleanupHandoffs    *sql.Stmt
 	unackAlerts        *sql.Stmt
 	alertStore         *alert.Store
 
@@ -43,7 +51,7 @@ func (db *DB) Name() string { return "Engine.CleanupManager" }
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 1,
+		Version: 3,
 		Type:    processinglock.TypeCleanup,
 	})
 	if err != nil {
@@ -63,8 +71,54 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 		// error will be logged.
 		setTimeout:     p.P(`SET LOCAL statement_timeout = 3000`),
 		cleanupAlerts:  p.P(`delete from alerts where id = any(select id from alerts where status = 'closed' AND created_at < (now() - $1::interval) order by id limit 100 for update skip locked)`),
+		cleanupArchive: p.P(`delete from alert_archives where id = any(select id from alert_archives where created_at < (now() - $1::interval) order by id limit 100 for update skip locked)`),
+
+		// Alerts are archived in batches, each in a single statement, so an interrupted
+		// run leaves nothing partially archived and is picked up by the next one.
+		archiveAlerts: p.P(`
+			with
+				batch as (
+					select id from alerts
+					where status = 'closed' and created_at < (now() - $1::interval)
+					order by id
+					limit 100
+					for update skip locked
+				),
+				_archive as (
+					insert into alert_archives (id, service_id, service_name, summary, details, source, severity, created_at, logs)
+					select
+						a.id,
+						a.service_id,
+						coalesce(svc.name, ''),
+						a.summary,
+						a.details,
+						a.source,
+						a.severity,
+						a.created_at,
+						` + alertlog.ArchivedEntriesJSON + `
+					from alerts a
+					left join services svc on svc.id = a.service_id
+					where a.id = any(select id from batch)
+					on conflict (id) do nothing
+				)
+			delete from alerts where id = any(select id from batch)
+		`),
 		cleanupAPIKeys: p.P(`update user_calendar_subscriptions set disabled = true where id = any(select id from user_calendar_subscriptions where greatest(last_access, last_update) < (now() - $1::interval) order by id limit 100 for update skip locked)`),
 
+		// Only the most recent $1 usage history entries of each GraphQL API key are kept.
+		cleanupAPIKeyUsage: p.P(`
+			delete from gql_api_key_usage_history where id = any(
+				select h.id from gql_api_keys k,
+				lateral (
+					select id from gql_api_key_usage_history
+					where api_key_id = k.id
+					order by id desc
+					offset $1
+				) h
+				limit 100
+			)
+		`),
+
 		schedData: p.P(`
 			select schedule_id, data from schedule_data
 			where data notnull and (last_cleanup_at isnull or last_cleanup_at <= now() - '1 month'::interval)
@@ -74,6 +128,7 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 		`),
 		setSchedData:    p.P(`update schedule_data set last_cleanup_at = now(), data = $2 where schedule_id = $1`),
 		cleanupSessions: p.P(`DELETE FROM auth_user_sessions WHERE id = any(select id from auth_user_sessions where last_access_at < (now() - '30 days'::interval) LIMIT 100 for update skip locked)`),
+		cleanupShadows:  p.P(`DELETE FROM rotation_shadows WHERE id = any(select id from rotation_shadows where expires_at <= now() LIMIT 100 for update skip locked)`),
 
 		cleanupAlertLogs: p.P(`
 			with
@@ -92,8 +147,10 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 		`),
 
 		cleanupOverrides:   p.P(`DELETE FROM user_overrides WHERE id = ANY(SELECT id FROM user_overrides WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+		cleanupRecurrences: p.P(`DELETE FROM user_override_recurrences WHERE id = ANY(SELECT id FROM user_override_recurrences WHERE until < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupSchedOnCall: p.P(`DELETE FROM schedule_on_call_users WHERE id = ANY(SELECT id FROM schedule_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupEPOnCall:    p.P(`DELETE FROM ep_step_on_call_users WHERE id = ANY(SELECT id FROM ep_step_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+		cleanupHandoffs:    p.P(`DELETE FROM schedule_handoff_notices WHERE id = ANY(SELECT id FROM schedule_handoff_notices WHERE resolved AND created_at < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		unackAlerts: p.P(`
 			select id from alerts a
 	     		where
@@ -102,7 +159,8 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 				not exists (
 					select 1 from alert_logs log
 					where timestamp > now() - '1 day'::interval * $1 and
-					log.alert_id = a.id
+					log.alert_id = a.id and
+					log.event != 'note'
 				)
 			limit 100`),
 		alertStore: alertstore,
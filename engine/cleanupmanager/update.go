@@ -54,7 +54,21 @@ func (db *DB) update(ctx context.Context) error {
 		return fmt.Errorf("cleanup sessions: %w", err)
 	}
 
+	_, err = tx.StmtContext(ctx, db.cleanupShadows).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("cleanup rotation shadows: %w", err)
+	}
+
 	cfg := config.FromContext(ctx)
+	if cfg.Maintenance.AlertArchiveDays > 0 {
+		var dur pgtype.Interval
+		dur.Days = int32(cfg.Maintenance.AlertArchiveDays)
+		dur.Status = pgtype.Present
+		_, err = tx.StmtContext(ctx, db.archiveAlerts).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("archive alerts: %w", err)
+		}
+	}
 	if cfg.Maintenance.AlertCleanupDays > 0 {
 		var dur pgtype.Interval
 		dur.Days = int32(cfg.Maintenance.AlertCleanupDays)
@@ -63,6 +77,11 @@ func (db *DB) update(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("cleanup alerts: %w", err)
 		}
+
+		_, err = tx.StmtContext(ctx, db.cleanupArchive).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("cleanup archived alerts: %w", err)
+		}
 	}
 
 	if cfg.Maintenance.AlertAutoCloseDays > 0 {
@@ -97,6 +116,12 @@ func (db *DB) update(ctx context.Context) error {
 			return err
 		}
 	}
+	if cfg.Maintenance.GQLAPIKeyUsageHistoryRows > 0 {
+		_, err = tx.StmtContext(ctx, db.cleanupAPIKeyUsage).ExecContext(ctx, cfg.Maintenance.GQLAPIKeyUsageHistoryRows)
+		if err != nil {
+			return fmt.Errorf("cleanup api key usage history: %w", err)
+		}
+	}
 	if cfg.Maintenance.ScheduleCleanupDays > 0 {
 		var dur pgtype.Interval
 		dur.Days = int32(cfg.Maintenance.ScheduleCleanupDays)
@@ -106,6 +131,11 @@ func (db *DB) update(ctx context.Context) error {
 			return fmt.Errorf("cleanup overrides: %w", err)
 		}
 
+		_, err = tx.StmtContext(ctx, db.cleanupRecurrences).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("cleanup override recurrences: %w", err)
+		}
+
 		_, err = tx.StmtContext(ctx, db.cleanupSchedOnCall).ExecContext(ctx, &dur)
 		if err != nil {
 			return fmt.Errorf("cleanup schedule on-call: %w", err)
@@ -115,6 +145,11 @@ func (db *DB) update(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("cleanup escalation policy on-call: %w", err)
 		}
+
+		_, err = tx.StmtContext(ctx, db.cleanupHandoffs).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("cleanup schedule handoff notices: %w", err)
+		}
 	}
 
 	rows, err := tx.StmtContext(ctx, db.schedData).QueryContext(ctx)
@@ -192,6 +227,15 @@ func cleanupScheduleData(data *schedule.Data, userMap map[string]struct{}, cutof
 		filtered = append(filtered, temp)
 	}
 	data.V1.TemporarySchedules = filtered
+
+	shifts := data.V1.FixedShifts[:0]
+	for _, s := range data.V1.FixedShifts {
+		if s.End.Before(cutoff) {
+			continue
+		}
+		shifts = append(shifts, s)
+	}
+	data.V1.FixedShifts = shifts
 }
 
 // getUsers retrieves the current set of user IDs
@@ -10,9 +10,11 @@ import (
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 )
@@ -28,8 +30,10 @@ type Config struct {
 	NCStore             *notificationchannel.Store
 	OnCallStore         *oncall.Store
 	ScheduleStore       *schedule.Store
+	ServiceStore        *service.Store
 	AuthLinkStore       *authlink.Store
 	SlackStore          *slack.ChannelSender
+	TwilioConfig        *twilio.Config
 
 	ConfigSource config.Source
 
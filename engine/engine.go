@@ -11,10 +11,13 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/engine/bridgemanager"
 	"github.com/target/goalert/engine/cleanupmanager"
 	"github.com/target/goalert/engine/compatmanager"
+	"github.com/target/goalert/engine/enrichmentmanager"
 	"github.com/target/goalert/engine/escalationmanager"
 	"github.com/target/goalert/engine/heartbeatmanager"
+	"github.com/target/goalert/engine/incidentmanager"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/engine/metricsmanager"
 	"github.com/target/goalert/engine/npcyclemanager"
@@ -26,8 +29,10 @@ import (
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 )
 
 type updater interface {
@@ -134,11 +139,27 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 		return nil, errors.Wrap(err, "compatibility backend")
 	}
 
+	incMgr, err := incidentmanager.NewDB(ctx, db, c.AlertLogStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "incident backend")
+	}
+	enrichMgr, err := enrichmentmanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "alert enrichment backend")
+	}
+	bridgeMgr, err := bridgemanager.NewDB(ctx, db, c.AlertLogStore, c.TwilioConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "conference bridge backend")
+	}
+
 	p.modules = []updater{
 		compatMgr,
 		rotMgr,
 		schedMgr,
 		epMgr,
+		bridgeMgr,
+		enrichMgr,
+		incMgr,
 		ncMgr,
 		statMgr,
 		verifyMgr,
@@ -355,6 +376,14 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	if cb.AlertID != 0 {
 		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
 	}
+	if cb.Shadow {
+		// shadows are only along for training, they can't affect the alert
+		return fmt.Errorf("shadow notification: %w", validation.NewGenericError("training notification, responses are ignored"))
+	}
+	if cb.Watcher {
+		// watchers are informed of alerts, but aren't part of the escalation path
+		return fmt.Errorf("watcher notification: %w", validation.NewGenericError("informational notification, responses are ignored"))
+	}
 
 	var usr *user.User
 	permission.SudoContext(ctx, func(ctx context.Context) {
@@ -402,6 +431,30 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	return errors.New("unknown callback type")
 }
 
+// ReceiveFrom will process a notification result, after checking that from is the
+// destination the notification was sent to.
+func (p *Engine) ReceiveFrom(ctx context.Context, callbackID string, from notification.Dest, result notification.Result) error {
+	cb, err := p.b.FindOne(ctx, callbackID)
+	if err != nil {
+		return err
+	}
+
+	var cm *contactmethod.ContactMethod
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		cm, err = p.cfg.ContactMethodStore.FindOne(ctx, cb.ContactMethodID)
+	})
+	if err != nil {
+		return errors.Wrap(err, "lookup contact method")
+	}
+
+	dest := notification.DestFromPair(cm, nil)
+	if dest.Type != from.Type || !strings.EqualFold(dest.Value, from.Value) {
+		return validation.NewGenericError("response must come from the address the notification was sent to")
+	}
+
+	return p.Receive(ctx, callbackID, result)
+}
+
 // Start will enable all associated contact methods of `value` with type `t`. This should
 // be invoked if a user, for example, responds with `START` via sms.
 func (p *Engine) Start(ctx context.Context, d notification.Dest) error {
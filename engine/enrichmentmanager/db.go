@@ -0,0 +1,76 @@
+package enrichmentmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB handles calling the enrichment URL of a service for each new alert, and adding the
+// returned key-value pairs to the alert's metadata.
+type DB struct {
+	lock *processinglock.Lock
+
+	pending  *sql.Stmt
+	claim    *sql.Stmt
+	findMeta *sql.Stmt
+	setMeta  *sql.Stmt
+	setError *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.EnrichmentManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Version: 1,
+		Type:    processinglock.TypeEnrichment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock: lock,
+
+		// Only recent alerts are enriched, so setting a URL doesn't call it for every
+		// open alert of the service, and a stopped engine doesn't catch up on stale ones.
+		pending: p.P(`
+			select
+				a.id,
+				a.summary,
+				a.details,
+				a.source,
+				a.severity,
+				a.meta,
+				s.id,
+				s.name,
+				s.enrichment_url
+			from services s
+			join alerts a on
+				a.service_id = s.id and
+				a.status != 'closed' and
+				a.created_at > now() - '5 minutes'::interval
+			where
+				s.enrichment_url != '' and
+				not exists (select 1 from alert_enrichments e where e.alert_id = a.id)
+			order by a.id
+			limit 100
+		`),
+
+		claim: p.P(`
+			insert into alert_enrichments (alert_id)
+			select unnest($1::bigint[])
+			on conflict do nothing
+		`),
+
+		findMeta: p.P(`select meta from alerts where id = $1 for update`),
+		setMeta:  p.P(`update alerts set meta = $2 where id = $1`),
+		setError: p.P(`update alert_enrichments set error = $2 where alert_id = $1`),
+	}, p.Err
+}
@@ -0,0 +1,202 @@
+package enrichmentmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// enrichTimeout is how long an enrichment URL has to respond before the alert is left
+	// unchanged.
+	enrichTimeout = 3 * time.Second
+
+	// maxResponseSize is the largest response body read from an enrichment URL.
+	maxResponseSize = 1 << 20
+)
+
+// EnrichmentRequest is the body POSTed to the enrichment URL of a service when an alert
+// is created.
+type EnrichmentRequest struct {
+	AppName     string
+	Type        string
+	AlertID     int
+	Summary     string
+	Details     string
+	Source      string
+	Severity    string
+	ServiceID   string
+	ServiceName string
+	Meta        map[string]string
+}
+
+type enrichItem struct {
+	url  string
+	req  EnrichmentRequest
+	meta alert.Meta
+	err  error
+}
+
+// UpdateAll will enrich new alerts for services with an enrichment URL.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Enriching alerts.")
+
+	items, err := db.claimPending(ctx)
+	if err != nil {
+		return fmt.Errorf("claim pending alerts: %w", err)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	cfg := config.FromContext(ctx)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func(item *enrichItem) {
+			defer wg.Done()
+			item.meta, item.err = callEnrichmentURL(ctx, cfg, item)
+			if item.err != nil {
+				log.Log(log.WithField(ctx, "AlertID", item.req.AlertID), fmt.Errorf("call enrichment URL: %w", item.err))
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	return db.applyResults(ctx, items)
+}
+
+// claimPending will return the alerts waiting to be enriched, marking them so that each is
+// only attempted once.
+func (db *DB) claimPending(ctx context.Context) ([]*enrichItem, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "enrichment manager: claim", tx)
+
+	rows, err := tx.StmtContext(ctx, db.pending).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cfg := config.FromContext(ctx)
+	var items []*enrichItem
+	var ids sqlutil.IntArray
+	for rows.Next() {
+		item := &enrichItem{req: EnrichmentRequest{AppName: cfg.ApplicationName(), Type: "EnrichmentRequest"}}
+		var meta alert.Meta
+		err = rows.Scan(&item.req.AlertID, &item.req.Summary, &item.req.Details, &item.req.Source, &item.req.Severity, &meta, &item.req.ServiceID, &item.req.ServiceName, &item.url)
+		if err != nil {
+			return nil, err
+		}
+		item.req.Meta = meta
+		items = append(items, item)
+		ids = append(ids, item.req.AlertID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, tx.Commit()
+	}
+
+	_, err = tx.StmtContext(ctx, db.claim).ExecContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, tx.Commit()
+}
+
+// applyResults will merge the returned metadata into each alert, or record the failure.
+func (db *DB) applyResults(ctx context.Context, items []*enrichItem) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "enrichment manager: apply", tx)
+
+	for _, item := range items {
+		if item.err != nil {
+			_, err = tx.StmtContext(ctx, db.setError).ExecContext(ctx, item.req.AlertID, item.err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		var meta alert.Meta
+		err = tx.StmtContext(ctx, db.findMeta).QueryRowContext(ctx, item.req.AlertID).Scan(&meta)
+		if err != nil {
+			return fmt.Errorf("lookup alert metadata: %w", err)
+		}
+
+		merged := meta.Merge(item.meta)
+		if len(merged) == len(meta) {
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setMeta).ExecContext(ctx, item.req.AlertID, merged)
+		if err != nil {
+			return fmt.Errorf("update alert metadata: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func callEnrichmentURL(ctx context.Context, cfg config.Config, item *enrichItem) (alert.Meta, error) {
+	if !cfg.ValidWebhookURL(item.url) {
+		return nil, errors.New("URL not allowed by administrator")
+	}
+
+	data, err := json.Marshal(item.req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", item.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var meta alert.Meta
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&meta)
+	if err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,43 @@
+package enrichmentmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+)
+
+func TestCallEnrichmentURL(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EnrichmentRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+		assert.Equal(t, 123, req.AlertID)
+		assert.Equal(t, "api", req.Meta["component"])
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	item := &enrichItem{url: srv.URL, req: EnrichmentRequest{AlertID: 123, Meta: map[string]string{"component": "api"}}}
+
+	body = `{"team":"payments"}`
+	meta, err := callEnrichmentURL(context.Background(), config.Config{}, item)
+	require.NoError(t, err)
+	assert.Equal(t, alert.Meta{"team": "payments"}, meta)
+
+	body = `{"team":["payments"]}`
+	_, err = callEnrichmentURL(context.Background(), config.Config{}, item)
+	assert.Error(t, err, "non-string value")
+
+	var cfg config.Config
+	cfg.Webhook.AllowedURLs = []string{"http://example.com"}
+	_, err = callEnrichmentURL(context.Background(), cfg, item)
+	assert.Error(t, err, "URL not allowed")
+}
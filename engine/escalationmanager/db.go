@@ -3,6 +3,8 @@ package escalationmanager
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"sync"
 
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/engine/processinglock"
@@ -16,23 +18,287 @@ type DB struct {
 	clearMaintExpiredSvc *sql.Stmt
 	cleanupNoSteps       *sql.Stmt
 
+	unsnooze      *sql.Stmt
+	rearmUnsnooze *sql.Stmt
+
+	trackAck   *sql.Stmt
+	ackTimeout *sql.Stmt
+
 	lockStmt     *sql.Stmt
 	updateOnCall *sql.Stmt
 
-	newPolicies      *sql.Stmt
-	deletedSteps     *sql.Stmt
-	normalEscalation *sql.Stmt
+	newPolicies       *sql.Stmt
+	deletedSteps      *sql.Stmt
+	exhaustEscalation *sql.Stmt
+	normalEscalation  *sql.Stmt
+
+	clearRouting    *sql.Stmt
+	routingRequests *sql.Stmt
+	routeAlert      *sql.Stmt
+
+	routeMx    sync.Mutex
+	routeCache map[string]routingCacheEntry
 
 	log *alertlog.Store
 }
 
+// businessHoursExpr returns a SQL expression that is true if the timestamp expression at
+// falls within the business hours referenced by id, evaluated in their time zone. Overnight
+// windows belong to the weekday they start on. It is NULL if id does not reference business hours.
+//
+// It must match businesshours.BusinessHours.Contains.
+func businessHoursExpr(id, at string) string {
+	const wallClock = `({at} at time zone bh.time_zone)`
+	expr := strings.ReplaceAll(`(
+			select CASE
+				WHEN bh.start_time < bh.end_time THEN
+					bh.weekday_filter[extract(dow from {now})::int + 1] AND
+					{now}::time >= bh.start_time AND {now}::time < bh.end_time
+				ELSE
+					(bh.weekday_filter[extract(dow from {now})::int + 1] AND {now}::time >= bh.start_time) OR
+					(bh.weekday_filter[(extract(dow from {now})::int + 6) % 7 + 1] AND {now}::time < bh.end_time)
+			END
+			from business_hours bh
+			where bh.id = {id}
+		)`, "{now}", wallClock)
+	return strings.NewReplacer("{id}", id, "{at}", at).Replace(expr)
+}
+
+// stepDelayExpr returns a SQL expression for the delay (in minutes) of the step
+// referenced by alias, as of the timestamp expression at. Steps with an off-hours
+// delay use it when at falls outside of their delay business hours; otherwise the
+// fixed delay is used.
+//
+// It must match escalation.Step.DelayAt.
+func stepDelayExpr(alias, at string) string {
+	return strings.ReplaceAll(`
+		CASE
+			WHEN {step}.off_hours_delay isnull OR {step}.delay_business_hours_id isnull THEN {step}.delay
+			WHEN coalesce(`+businessHoursExpr("{step}.delay_business_hours_id", at)+`, true) THEN {step}.delay
+			ELSE {step}.off_hours_delay
+		END`, "{step}", alias)
+}
+
+// stepNotifyExpr returns a SQL expression that is true if the step referenced by alias
+// should send notifications for the alert (aliased as a), based on the step's minimum severity.
+func stepNotifyExpr(alias string) string {
+	return "coalesce(a.severity >= " + alias + ".min_severity, true)"
+}
+
+// actionActiveExpr returns a SQL expression that is true if the escalation policy action
+// referenced by act should currently be notified for the step referenced by step. Actions
+// with a business hours condition only apply when the step has routing business hours, and
+// the current time (in the time zone of the business hours) matches the condition.
+func actionActiveExpr(step, act string) string {
+	return strings.NewReplacer("{step}", step, "{act}", act).Replace(`(
+		{act}.business_hours_condition isnull OR
+		{step}.routing_business_hours_id isnull OR
+		({act}.business_hours_condition = 'in_hours') = coalesce(` + businessHoursExpr("{step}.routing_business_hours_id", "now()") + `, true)
+	)`)
+}
+
+// schedulePriorityExpr returns a SQL expression for the priority of the users on call for
+// the schedule that should be notified. It is the highest priority with a user that can be
+// reached (has a notification rule for an enabled contact method), so a primary target with
+// nobody reachable falls through to the backup. If no one can be reached, the highest
+// priority is used.
+func schedulePriorityExpr(scheduleID string) string {
+	return strings.ReplaceAll(`coalesce(
+		(
+			select min(p.priority)
+			from schedule_on_call_users p
+			where
+				p.schedule_id = {sched} and
+				p.end_time isnull and
+				exists (
+					select null
+					from user_notification_rules r
+					join user_contact_methods cm on cm.id = r.contact_method_id and not cm.disabled
+					where r.user_id = p.user_id
+				)
+		),
+		(
+			select min(p.priority)
+			from schedule_on_call_users p
+			where p.schedule_id = {sched} and p.end_time isnull
+		)
+	)`, "{sched}", scheduleID)
+}
+
+// startStepExpr returns a SQL expression for the step number a new alert (aliased as a) begins
+// escalation at, for the policy state referenced by alias. The first step with a minimum severity
+// the alert meets is used, otherwise the first step.
+//
+// It must match escalation.StartStepIndex.
+func startStepExpr(alias string) string {
+	return strings.ReplaceAll(`coalesce(
+						(
+							select min(start.step_number)
+							from escalation_policy_steps start
+							where
+								start.escalation_policy_id = {state}.escalation_policy_id and
+								` + stepNotifyExpr("start") + `
+						),
+						0
+					)`, "{state}", alias)
+}
+
+// roundRobinCTEs are CTEs (expecting a preceding to_escalate) that pick which participant
+// of each rotation targeted by a round-robin step should be notified, and advance the
+// persisted pointer for that step and rotation.
+//
+// Every alert entering a round-robin step advances the pointer, including an alert
+// re-entering the step after the policy repeats, so a repeat pages the next participant
+// rather than the one notified previously. The first alert to reach a step starts with
+// the rotation's active participant.
+var roundRobinCTEs = `
+			_rr_targets as (
+				select
+					esc.alert_id,
+					esc.ep_step_id,
+					act.rotation_id,
+					coalesce(rr.position, rState.position, 0) start_pos,
+					rot.participant_count,
+					row_number() over (partition by esc.ep_step_id, act.rotation_id order by esc.alert_id) - 1 n
+				from to_escalate esc
+				join escalation_policy_steps step on
+					esc.notify and
+					step.id = esc.ep_step_id and
+					step.assignment_strategy = 'round_robin'
+				join escalation_policy_actions act on
+					act.escalation_policy_step_id = esc.ep_step_id and
+					act.rotation_id notnull and
+					` + actionActiveExpr("step", "act") + `
+				join rotations rot on rot.id = act.rotation_id and rot.participant_count > 0
+				left join rotation_state rState on rState.rotation_id = act.rotation_id
+				left join ep_step_round_robin_state rr on
+					rr.ep_step_id = esc.ep_step_id and
+					rr.rotation_id = act.rotation_id
+			), _rr_picks as (
+				select t.alert_id, part.user_id, t.ep_step_id, t.rotation_id
+				from _rr_targets t
+				join rotation_participants part on
+					part.rotation_id = t.rotation_id and
+					part.position = (t.start_pos + t.n) % t.participant_count
+			), _rr_advance as (
+				insert into ep_step_round_robin_state (ep_step_id, rotation_id, position)
+				select ep_step_id, rotation_id, (max(start_pos) + count(*)) % max(participant_count)
+				from _rr_targets
+				group by ep_step_id, rotation_id
+				on conflict (ep_step_id, rotation_id) do update
+				set position = excluded.position
+			),`
+
+// routingCTEs are CTEs (expecting a preceding to_escalate) that queue a routing request for
+// alerts entering a step with a routing webhook. Users are notified for those steps once the
+// request is processed, see processRoutingRequests.
+var routingCTEs = `
+			_routed as (
+				select esc.alert_id, esc.ep_step_id
+				from to_escalate esc
+				join escalation_policy_steps step on
+					esc.notify and
+					step.id = esc.ep_step_id and
+					step.routing_webhook_url notnull
+			), _routing as (
+				insert into ep_step_routing_requests (alert_id, ep_step_id)
+				select alert_id, ep_step_id
+				from _routed
+				on conflict do nothing
+			),`
+
+// shadowCTEs are CTEs (expecting a preceding _step_cycles) that pick the unexpired shadows
+// of rotations whose on-call user is being notified by the step. A rotation counts when the
+// step targets it directly, or through a schedule with a rule for it. Shadows that are
+// already being notified normally are skipped.
+var shadowCTEs = `
+			_shadow_cycles as (
+				select distinct sc.alert_id, sh.user_id, sc.ep_step_id
+				from _step_cycles sc
+				join escalation_policy_steps step on step.id = sc.ep_step_id
+				join escalation_policy_actions act on
+					act.escalation_policy_step_id = sc.ep_step_id and
+					` + actionActiveExpr("step", "act") + `
+				left join schedule_rules rule on
+					rule.schedule_id = act.schedule_id and
+					rule.tgt_rotation_id notnull
+				join rotation_state rState on rState.rotation_id = coalesce(act.rotation_id, rule.tgt_rotation_id)
+				join rotation_participants part on
+					part.id = rState.rotation_participant_id and
+					part.user_id = sc.user_id
+				join rotation_shadows sh on
+					sh.rotation_id = rState.rotation_id and
+					sh.expires_at > now()
+				union
+				select pick.alert_id, sh.user_id, pick.ep_step_id
+				from _rr_picks pick
+				join rotation_shadows sh on
+					sh.rotation_id = pick.rotation_id and
+					sh.expires_at > now()
+			), _shadows as (
+				select alert_id, user_id, ep_step_id
+				from _shadow_cycles shadow
+				where not exists (
+					select null
+					from _step_cycles sc
+					where sc.alert_id = shadow.alert_id and sc.user_id = shadow.user_id
+				)
+			),`
+
+// bridgeCTEs are CTEs (expecting a preceding _step_cycles) that start a conference bridge
+// for alerts reaching a step with one, and invite each user notified by the step to join it
+// with a call to one of their voice contact methods, preferring those used by their
+// notification rules. Users are only invited once per alert.
+var bridgeCTEs = `
+			_bridges as (
+				insert into alert_conference_bridges (alert_id)
+				select distinct esc.alert_id
+				from to_escalate esc
+				join escalation_policy_steps step on
+					step.id = esc.ep_step_id and
+					step.conference_bridge
+				where esc.notify
+				on conflict do nothing
+			), _bridge_invites as (
+				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, contact_method_id, user_id)
+				select distinct on (sc.alert_id, sc.user_id)
+					cast('alert_bridge_invite' as enum_outgoing_messages_type),
+					sc.alert_id,
+					esc.service_id,
+					esc.escalation_policy_id,
+					cm.id,
+					sc.user_id
+				from _step_cycles sc
+				join to_escalate esc on esc.alert_id = sc.alert_id
+				join escalation_policy_steps step on
+					step.id = sc.ep_step_id and
+					step.conference_bridge
+				join user_contact_methods cm on
+					cm.user_id = sc.user_id and
+					cm.type = 'VOICE' and
+					not cm.disabled
+				where not exists (
+					select null
+					from outgoing_messages om
+					where
+						om.message_type = 'alert_bridge_invite' and
+						om.alert_id = sc.alert_id and
+						om.user_id = sc.user_id
+				)
+				order by
+					sc.alert_id,
+					sc.user_id,
+					exists (select null from user_notification_rules r where r.contact_method_id = cm.id) desc,
+					cm.id
+			),`
+
 // Name returns the name of the module.
 func (db *DB) Name() string { return "Engine.EscalationManager" }
 
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 4,
+		Version: 22,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -45,6 +311,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 		log:  log,
 		lock: lock,
 
+		routeCache: make(map[string]routingCacheEntry),
+
 		lockStmt: p.P(`lock escalation_policy_steps in share mode`),
 
 		updateOnCall: p.P(`
@@ -53,10 +321,17 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					step.id step_id,
 					coalesce(act.user_id, part.user_id, sched.user_id) user_id
 				from escalation_policy_steps step
-				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
-				left join rotation_state rState on rState.rotation_id = act.rotation_id
+				join escalation_policy_actions act on
+					act.escalation_policy_step_id = step.id and
+					` + actionActiveExpr("step", "act") + `
+				left join rotation_state rState on
+					rState.rotation_id = act.rotation_id and
+					step.assignment_strategy = 'sequential'
 				left join rotation_participants part on part.id = rState.rotation_participant_id
-				left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
+				left join schedule_on_call_users sched on
+					sched.schedule_id = act.schedule_id and
+					sched.end_time isnull and
+					sched.priority = ` + schedulePriorityExpr("act.schedule_id") + `
 				where coalesce(act.user_id, part.user_id, sched.user_id) notnull
 			), ended as (
 				select
@@ -83,6 +358,77 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			returning ep_step_id, user_id
 		`),
 
+		clearRouting: p.P(`
+			delete from ep_step_routing_requests req
+			using alerts a
+			where a.id = req.alert_id and a.status != 'triggered'
+		`),
+
+		// claim requests waiting on a webhook call, or whose claim expired without being processed
+		routingRequests: p.P(`
+			with claimed as (
+				update ep_step_routing_requests req
+				set claimed_at = now()
+				from (
+					select req.alert_id, req.ep_step_id
+					from ep_step_routing_requests req
+					join escalation_policy_steps step on step.id = req.ep_step_id and step.routing_webhook_url notnull
+					where req.claimed_at isnull or req.claimed_at < now() - '1 minute'::interval
+					order by req.created_at
+					for update of req skip locked
+					limit 50
+				) next
+				where req.alert_id = next.alert_id and req.ep_step_id = next.ep_step_id
+				returning req.alert_id, req.ep_step_id, req.created_at
+			)
+			select req.alert_id, req.ep_step_id, step.routing_webhook_url, a.summary, a.details, a.severity, a.service_id, svc.name, step.escalation_policy_id, step.step_number
+			from claimed req
+			join escalation_policy_steps step on step.id = req.ep_step_id
+			join alerts a on a.id = req.alert_id
+			join services svc on svc.id = a.service_id
+			order by req.created_at
+		`),
+
+		// notify the routed target, or the users of the step if the target has no one on call
+		routeAlert: p.P(`
+			with req as (
+				delete from ep_step_routing_requests
+				where alert_id = $1 and ep_step_id = $2
+				returning alert_id, ep_step_id
+			), routed as (
+				select id user_id
+				from users
+				where id = $3
+				union
+				select part.user_id
+				from rotation_state rState
+				join rotation_participants part on part.id = rState.rotation_participant_id
+				where rState.rotation_id = $5
+				union
+				select sched.user_id
+				from schedule_on_call_users sched
+				where
+					sched.schedule_id = $4 and
+					sched.end_time isnull and
+					sched.priority = ` + schedulePriorityExpr("$4") + `
+			), notify as (
+				select user_id from routed
+				union
+				select on_call.user_id
+				from ep_step_on_call_users on_call
+				where
+					on_call.ep_step_id = $2 and
+					on_call.end_time isnull and
+					not exists (select null from routed)
+			)
+			insert into notification_policy_cycles (alert_id, user_id, low_urgency)
+			select req.alert_id, notify.user_id, step.low_urgency
+			from req
+			join alerts a on a.id = req.alert_id and a.status = 'triggered'
+			join escalation_policy_steps step on step.id = req.ep_step_id
+			cross join notify
+		`),
+
 		clearMaintExpiredSvc: p.P(`
 				update services s
 				set maintenance_expires_at = null
@@ -98,27 +444,124 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				pol.step_count = 0
 		`),
 
+		unsnooze: p.P(`
+			with expired as (
+				select alert_id
+				from escalation_policy_state
+				where snooze_until <= now()
+				for update skip locked
+				limit 1000
+			), _clear as (
+				update escalation_policy_state state
+				set snooze_until = null
+				from expired
+				where state.alert_id = expired.alert_id
+			)
+			update alerts a
+			set status = 'triggered'
+			from expired
+			where
+				a.id = expired.alert_id and
+				a.status = 'active'
+			returning a.id, coalesce((
+				select (log.meta->>'DurationMinutes')::int
+				from alert_logs log
+				where log.alert_id = a.id and log.event = 'snoozed'
+				order by log.id desc
+				limit 1
+			), 0)
+		`),
+
+		// un-acknowledging clears next_escalation, so resume escalation immediately
+		rearmUnsnooze: p.P(`
+			update escalation_policy_state
+			set next_escalation = now()
+			where alert_id = any($1) and escalation_policy_step_id notnull
+		`),
+
+		// record when alerts on policies with an ack timeout were acknowledged, and
+		// clear it once they are no longer acknowledged
+		trackAck: p.P(`
+			update escalation_policy_state state
+			set acknowledged_at = CASE WHEN a.status = 'active' THEN now() ELSE null END
+			from alerts a, escalation_policies ep
+			where
+				a.id = state.alert_id and
+				ep.id = state.escalation_policy_id and (
+					(a.status = 'active' and state.acknowledged_at isnull and ep.ack_timeout_minutes > 0) or
+					(a.status != 'active' and state.acknowledged_at notnull)
+				)
+		`),
+
+		// snoozed alerts are left to expire their snooze instead
+		ackTimeout: p.P(`
+			with expired as (
+				select state.alert_id, ep.ack_timeout_minutes
+				from escalation_policy_state state
+				join escalation_policies ep on ep.id = state.escalation_policy_id
+				where
+					ep.ack_timeout_minutes > 0 and
+					state.snooze_until isnull and
+					state.acknowledged_at + (cast(ep.ack_timeout_minutes as text)||' minutes')::interval <= now()
+				for update of state skip locked
+				limit 1000
+			), _clear as (
+				update escalation_policy_state state
+				set acknowledged_at = null
+				from expired
+				where state.alert_id = expired.alert_id
+			)
+			update alerts a
+			set status = 'triggered'
+			from expired
+			where
+				a.id = expired.alert_id and
+				a.status = 'active'
+			returning a.id, expired.ack_timeout_minutes
+		`),
+
 		newPolicies: p.P(`
 			with to_escalate as (
-				select alert_id, step.id ep_step_id, step.delay, step.escalation_policy_id, a.service_id
+				select
+					alert_id,
+					step.id ep_step_id,
+					step.step_number,
+					` + stepDelayExpr("step", "now()") + ` delay,
+					step.escalation_policy_id,
+					a.service_id,
+					` + stepNotifyExpr("step") + ` notify,
+					CASE WHEN state.force_escalation THEN 0 ELSE s.notification_delay_minutes END notification_delay
 				from escalation_policy_state state
+				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
 				join escalation_policy_steps step on
 					step.escalation_policy_id = state.escalation_policy_id and
-					step.step_number = 0
-				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
-				where state.last_escalation isnull
+					step.step_number = ` + startStepExpr("state") + `
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and s.paused_at isnull
+				where
+					state.last_escalation isnull and
+					-- wait out the service notification delay, unless escalated manually
+					(state.force_escalation or a.created_at + (cast(s.notification_delay_minutes as text)||' minutes')::interval <= now())
 				for update skip locked
 				limit 1000
-			), _step_cycles as (
+			), ` + roundRobinCTEs + routingCTEs + ` _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
 				join ep_step_on_call_users on_call on
+					esc.notify and
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
-			), _cycles as (
-				insert into notification_policy_cycles (alert_id, user_id)
-				select alert_id, user_id from _step_cycles
+				where not exists (select null from _routed routed where routed.alert_id = esc.alert_id)
+				union
+				select alert_id, user_id, ep_step_id from _rr_picks
+			), ` + shadowCTEs + bridgeCTEs + ` _cycles as (
+				insert into notification_policy_cycles (alert_id, user_id, shadow, low_urgency)
+				select c.alert_id, c.user_id, c.shadow, step.low_urgency
+				from (
+					select alert_id, user_id, ep_step_id, false shadow from _step_cycles
+					union all
+					select alert_id, user_id, ep_step_id, true from _shadows
+				) c
+				join escalation_policy_steps step on step.id = c.ep_step_id
 			), _step_channels as (
 				select
 					cast('alert_notification' as enum_outgoing_messages_type),
@@ -128,9 +571,12 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					act.channel_id,
 					esc.ep_step_id
 				from to_escalate esc
+				join escalation_policy_steps step on step.id = esc.ep_step_id
 				join escalation_policy_actions act on
+					esc.notify and
 					act.channel_id notnull and
-					act.escalation_policy_step_id = esc.ep_step_id
+					act.escalation_policy_step_id = esc.ep_step_id and
+					` + actionActiveExpr("step", "act") + `
 			), _channels as (
 				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
 				select
@@ -145,17 +591,20 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				set
 					last_escalation = now(),
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
+					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
+					notification_count = state.notification_count + CASE WHEN esc.notify THEN 1 ELSE 0 END,
 					force_escalation = false
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, step isnull and chan isnull
+			select distinct esc.alert_id, esc.step_number, esc.notify and step isnull and chan isnull and routed isnull, not esc.notify, esc.notification_delay
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
+			left join _routed routed on routed.alert_id = esc.alert_id
 		`),
 
 		deletedSteps: p.P(`
@@ -164,10 +613,11 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					alert_id,
 					step.id ep_step_id,
 					step.step_number,
-					step.delay,
+					` + stepDelayExpr("step", "now()") + ` delay,
 					state.escalation_policy_step_number >= ep.step_count repeated,
 					a.service_id,
-					step.escalation_policy_id
+					step.escalation_policy_id,
+					` + stepNotifyExpr("step") + ` notify
 				from escalation_policy_state state
 				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
 				join escalation_policies ep on ep.id = state.escalation_policy_id
@@ -177,22 +627,32 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 						WHEN state.escalation_policy_step_number >= ep.step_count THEN 0
 						ELSE state.escalation_policy_step_number
 						END
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and s.paused_at isnull
 				where
 					state.last_escalation notnull and
+					state.escalation_exhausted_at isnull and
 					escalation_policy_step_id isnull
 				for update skip locked
 				limit 100
-			), _step_cycles as (
+			), ` + roundRobinCTEs + routingCTEs + ` _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
 				join ep_step_on_call_users on_call on
+					esc.notify and
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
-			), _cycles as (
-				insert into notification_policy_cycles (alert_id, user_id)
-				select alert_id, user_id
-				from _step_cycles
+				where not exists (select null from _routed routed where routed.alert_id = esc.alert_id)
+				union
+				select alert_id, user_id, ep_step_id from _rr_picks
+			), ` + shadowCTEs + bridgeCTEs + ` _cycles as (
+				insert into notification_policy_cycles (alert_id, user_id, shadow, low_urgency)
+				select c.alert_id, c.user_id, c.shadow, step.low_urgency
+				from (
+					select alert_id, user_id, ep_step_id, false shadow from _step_cycles
+					union all
+					select alert_id, user_id, ep_step_id, true from _shadows
+				) c
+				join escalation_policy_steps step on step.id = c.ep_step_id
 			), _step_channels as (
 				select
 					cast('alert_notification' as enum_outgoing_messages_type),
@@ -202,9 +662,12 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					act.channel_id,
 					esc.ep_step_id
 				from to_escalate esc
+				join escalation_policy_steps step on step.id = esc.ep_step_id
 				join escalation_policy_actions act on
+					esc.notify and
 					act.channel_id notnull and
-					act.escalation_policy_step_id = esc.ep_step_id
+					act.escalation_policy_step_id = esc.ep_step_id and
+					` + actionActiveExpr("step", "act") + `
 			), _channels as (
 				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
 				select
@@ -221,29 +684,50 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
+					notification_count = state.notification_count + CASE WHEN esc.notify THEN 1 ELSE 0 END,
 					force_escalation = false
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, esc.repeated, esc.step_number, step isnull and chan isnull
+			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.notify and step isnull and chan isnull and routed isnull, not esc.notify
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
+			left join _routed routed on routed.alert_id = esc.alert_id
+		`),
+		exhaustEscalation: p.P(`
+			update escalation_policy_state state
+			set
+				escalation_exhausted_at = now(),
+				next_escalation = null
+			from escalation_policies ep, alerts a
+			where
+				ep.id = state.escalation_policy_id and
+				ep.max_notifications > 0 and
+				a.id = state.alert_id and
+				a.status = 'triggered' and
+				state.notification_count >= ep.max_notifications and
+				state.escalation_exhausted_at isnull and
+				not state.force_escalation and
+				state.next_escalation < now()
+			returning state.alert_id, ep.max_notifications
 		`),
 		normalEscalation: p.P(`
 			with to_escalate as (
 				select
 					alert_id,
 					nextStep.id ep_step_id,
-					nextStep.delay,
+					` + stepDelayExpr("nextStep", "now()") + ` delay,
 					nextStep.step_number,
 					force_escalation forced,
-					oldStep.delay old_delay,
+					` + stepDelayExpr("oldStep", "state.last_escalation") + ` old_delay,
 					oldStep.step_number + 1 >= ep.step_count repeated,
 					nextStep.escalation_policy_id,
-					a.service_id
+					a.service_id,
+					` + stepNotifyExpr("nextStep") + ` notify,
+					state.next_escalation
 				from escalation_policy_state state
 				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
 				join escalation_policies ep on ep.id = state.escalation_policy_id
@@ -257,7 +741,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 						WHEN state.loop_count < ep.repeat THEN 0
 						ELSE -1
 					END
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and s.paused_at isnull
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id notnull and
@@ -265,16 +749,25 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				order by next_escalation - now()
 				for update skip locked
 				limit 500
-			), _step_cycles as (
+			), ` + roundRobinCTEs + routingCTEs + ` _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
 				join ep_step_on_call_users on_call on
+					esc.notify and
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
-			), _cycles as (
-				insert into notification_policy_cycles (alert_id, user_id)
-				select alert_id, user_id
-				from _step_cycles
+				where not exists (select null from _routed routed where routed.alert_id = esc.alert_id)
+				union
+				select alert_id, user_id, ep_step_id from _rr_picks
+			), ` + shadowCTEs + bridgeCTEs + ` _cycles as (
+				insert into notification_policy_cycles (alert_id, user_id, shadow, low_urgency)
+				select c.alert_id, c.user_id, c.shadow, step.low_urgency
+				from (
+					select alert_id, user_id, ep_step_id, false shadow from _step_cycles
+					union all
+					select alert_id, user_id, ep_step_id, true from _shadows
+				) c
+				join escalation_policy_steps step on step.id = c.ep_step_id
 			), _step_channels as (
 				select
 					cast('alert_notification' as enum_outgoing_messages_type),
@@ -284,9 +777,12 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					act.channel_id,
 					esc.ep_step_id
 				from to_escalate esc
+				join escalation_policy_steps step on step.id = esc.ep_step_id
 				join escalation_policy_actions act on
+					esc.notify and
 					act.channel_id notnull and
-					act.escalation_policy_step_id = esc.ep_step_id
+					act.escalation_policy_step_id = esc.ep_step_id and
+					` + actionActiveExpr("step", "act") + `
 			), _channels as (
 				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
 				select
@@ -303,17 +799,21 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
+					notification_count = state.notification_count + CASE WHEN esc.notify THEN 1 ELSE 0 END,
 					loop_count = CASE WHEN esc.repeated THEN loop_count + 1 ELSE loop_count END,
+					escalation_exhausted_at = null,
 					force_escalation = false
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.old_delay, esc.forced, step isnull and chan isnull
+			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.old_delay, esc.forced, esc.notify and step isnull and chan isnull and routed isnull, not esc.notify,
+				coalesce(extract(epoch from now() - esc.next_escalation), 0)
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
+			left join _routed routed on routed.alert_id = esc.alert_id
 		`),
 	}, p.Err
 }
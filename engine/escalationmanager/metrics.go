@@ -0,0 +1,14 @@
+package escalationmanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricEscalationLag = promauto.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "goalert",
+	Subsystem: "engine",
+	Name:      "escalation_lag_seconds",
+	Help:      "Time between when an alert was due to escalate and when it was escalated, in seconds.",
+	Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+})
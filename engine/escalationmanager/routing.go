@@ -0,0 +1,250 @@
+package escalationmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// routingTimeout is how long a routing webhook has to respond before the
+	// static targets of the step are notified instead.
+	routingTimeout = 3 * time.Second
+
+	// routingCacheTTL is how long a routing decision is reused for other alerts of the
+	// same service and summary, so an alert storm doesn't flood the webhook.
+	routingCacheTTL = time.Minute
+)
+
+// RoutingRequest is the body POSTed to the routing webhook of an escalation policy step.
+type RoutingRequest struct {
+	AppName            string
+	Type               string
+	AlertID            int
+	Summary            string
+	Details            string
+	Severity           string
+	ServiceID          string
+	ServiceName        string
+	EscalationPolicyID string
+	StepNumber         int
+}
+
+// RoutingResponse is the response expected from a routing webhook, naming the target to notify.
+type RoutingResponse struct {
+	// Type is one of `user`, `schedule`, or `rotation`.
+	Type string
+	ID   string
+}
+
+type routingItem struct {
+	url  string
+	req  RoutingRequest
+	step string
+	resp *RoutingResponse
+}
+
+type routingCacheEntry struct {
+	resp    RoutingResponse
+	expires time.Time
+}
+
+func (c *RoutingResponse) validate() error {
+	c.Type = strings.ToLower(c.Type)
+	return validate.Many(
+		validate.OneOf("Type", c.Type, "user", "schedule", "rotation"),
+		validate.UUID("ID", c.ID),
+	)
+}
+
+func routingCacheKey(item *routingItem) string {
+	return item.url + "\x00" + item.req.ServiceID + "\x00" + item.req.Summary
+}
+
+// processRoutingRequests calls the routing webhook for each alert waiting on one, and
+// notifies the returned target. If a call fails, or the target has no one on call, the
+// users targeted by the step are notified instead.
+//
+// Requests are claimed in one transaction and the results applied in another, so no
+// transaction is held open while waiting on webhooks.
+func (db *DB) processRoutingRequests(ctx context.Context) error {
+	_, err := db.lock.Exec(ctx, db.clearRouting)
+	if err != nil {
+		return errors.Wrap(err, "clear routing requests for inactive alerts")
+	}
+
+	items, err := db.claimRoutingRequests(ctx)
+	if err != nil {
+		return errors.Wrap(err, "claim routing requests")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	cfg := config.FromContext(ctx)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		if resp, ok := db.cachedRoute(item); ok {
+			item.resp = &resp
+			continue
+		}
+
+		wg.Add(1)
+		go func(item *routingItem) {
+			defer wg.Done()
+			resp, err := callRoutingWebhook(ctx, cfg, item)
+			if err != nil {
+				log.Log(log.WithFields(ctx, log.Fields{"AlertID": item.req.AlertID, "StepID": item.step}), errors.Wrap(err, "call routing webhook"))
+				return
+			}
+			item.resp = resp
+			db.cacheRoute(item)
+		}(item)
+	}
+	wg.Wait()
+
+	return db.applyRoutes(ctx, items)
+}
+
+// claimRoutingRequests will return the requests waiting on a routing webhook, marking them so
+// they are not picked up again unless they go unprocessed for a minute.
+func (db *DB) claimRoutingRequests(ctx context.Context) ([]*routingItem, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "escalation manager: claim routing", tx)
+
+	rows, err := tx.StmtContext(ctx, db.routingRequests).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cfg := config.FromContext(ctx)
+	var items []*routingItem
+	for rows.Next() {
+		item := &routingItem{req: RoutingRequest{AppName: cfg.ApplicationName(), Type: "RoutingRequest"}}
+		err = rows.Scan(&item.req.AlertID, &item.step, &item.url, &item.req.Summary, &item.req.Details, &item.req.Severity, &item.req.ServiceID, &item.req.ServiceName, &item.req.EscalationPolicyID, &item.req.StepNumber)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, tx.Commit()
+}
+
+// applyRoutes will notify the routed target of each request, or the users of the step.
+func (db *DB) applyRoutes(ctx context.Context, items []*routingItem) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "escalation manager: apply routing", tx)
+
+	for _, item := range items {
+		var userID, scheduleID, rotationID sql.NullString
+		if item.resp != nil {
+			switch item.resp.Type {
+			case "user":
+				userID = sql.NullString{Valid: true, String: item.resp.ID}
+			case "schedule":
+				scheduleID = sql.NullString{Valid: true, String: item.resp.ID}
+			case "rotation":
+				rotationID = sql.NullString{Valid: true, String: item.resp.ID}
+			}
+		}
+
+		_, err = tx.StmtContext(ctx, db.routeAlert).ExecContext(ctx, item.req.AlertID, item.step, userID, scheduleID, rotationID)
+		if err != nil {
+			return errors.Wrap(err, "notify routed target")
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) cachedRoute(item *routingItem) (RoutingResponse, bool) {
+	db.routeMx.Lock()
+	defer db.routeMx.Unlock()
+
+	key := routingCacheKey(item)
+	e, ok := db.routeCache[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(db.routeCache, key)
+		return RoutingResponse{}, false
+	}
+
+	return e.resp, true
+}
+
+func (db *DB) cacheRoute(item *routingItem) {
+	db.routeMx.Lock()
+	defer db.routeMx.Unlock()
+
+	now := time.Now()
+	for key, e := range db.routeCache {
+		if now.After(e.expires) {
+			delete(db.routeCache, key)
+		}
+	}
+	db.routeCache[routingCacheKey(item)] = routingCacheEntry{resp: *item.resp, expires: now.Add(routingCacheTTL)}
+}
+
+func callRoutingWebhook(ctx context.Context, cfg config.Config, item *routingItem) (*RoutingResponse, error) {
+	if !cfg.ValidWebhookURL(item.url) {
+		return nil, errors.New("URL not allowed by administrator")
+	}
+
+	data, err := json.Marshal(item.req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, routingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", item.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var r RoutingResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+	err = r.validate()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+
+	return &r, nil
+}
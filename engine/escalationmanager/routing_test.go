@@ -0,0 +1,49 @@
+package escalationmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+func TestCallRoutingWebhook(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RoutingRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+		assert.Equal(t, 123, req.AlertID)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	call := func() (*RoutingResponse, error) {
+		return callRoutingWebhook(context.Background(), config.Config{}, &routingItem{url: srv.URL, req: RoutingRequest{AlertID: 123}})
+	}
+
+	body = `{"type":"Schedule","id":"` + id + `"}`
+	resp, err := call()
+	require.NoError(t, err)
+	assert.Equal(t, &RoutingResponse{Type: "schedule", ID: id}, resp)
+
+	body = `{"type":"channel","id":"` + id + `"}`
+	_, err = call()
+	assert.Error(t, err, "unknown type")
+
+	body = `{"type":"user","id":"bob"}`
+	_, err = call()
+	assert.Error(t, err, "invalid ID")
+
+	var cfg config.Config
+	cfg.Webhook.AllowedURLs = []string{"http://example.com"}
+	_, err = callRoutingWebhook(context.Background(), cfg, &routingItem{url: srv.URL})
+	assert.Error(t, err, "URL not allowed")
+}
@@ -54,10 +54,25 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "end policies with no steps")
 	}
 
+	err = db.unsnoozeAlerts(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unsnooze expired alerts")
+	}
+
+	_, err = db.lock.Exec(ctx, db.trackAck)
+	if err != nil {
+		return errors.Wrap(err, "track acknowledged alerts")
+	}
+
+	err = db.timeoutAcks(ctx)
+	if err != nil {
+		return errors.Wrap(err, "re-trigger alerts with expired acks")
+	}
+
 	err = db.processEscalations(ctx, db.newPolicies, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.NoOneOnCall)
+		err := rows.Scan(&id, &meta.NewStepIndex, &meta.NoOneOnCall, &meta.BelowMinSeverity, &meta.NotificationDelayMinutes)
 		return id, &meta, err
 	})
 	if err != nil {
@@ -67,23 +82,37 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	err = db.processEscalations(ctx, db.deletedSteps, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.NoOneOnCall)
+		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.NoOneOnCall, &meta.BelowMinSeverity)
 		return id, &meta, err
 	})
 	if err != nil {
 		return errors.Wrap(err, "escalate policies with deleted steps")
 	}
 
+	err = db.exhaustEscalations(ctx)
+	if err != nil {
+		return errors.Wrap(err, "stop exhausted escalations")
+	}
+
 	err = db.processEscalations(ctx, db.normalEscalation, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.OldDelayMinutes, &meta.Forced, &meta.NoOneOnCall)
+		var lag float64
+		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.OldDelayMinutes, &meta.Forced, &meta.NoOneOnCall, &meta.BelowMinSeverity, &lag)
+		if err == nil && !meta.Forced {
+			metricEscalationLag.Observe(lag)
+		}
 		return id, &meta, err
 	})
 	if err != nil {
 		return errors.Wrap(err, "escalate forced or expired")
 	}
 
+	err = db.processRoutingRequests(ctx)
+	if err != nil {
+		return errors.Wrap(err, "process routing webhooks")
+	}
+
 	return nil
 }
 
@@ -119,3 +148,132 @@ func (db *DB) processEscalations(ctx context.Context, stmt *sql.Stmt, scan func(
 
 	return tx.Commit()
 }
+
+// exhaustEscalations stops escalation for alerts that have reached the max notifications
+// of their policy, logging an entry for each.
+func (db *DB) exhaustEscalations(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "escalation manager: exhaust", tx)
+
+	rows, err := tx.StmtContext(ctx, db.exhaustEscalation).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make(map[alertlog.EscalationExhaustedMetaData][]int)
+	for rows.Next() {
+		var id int
+		var meta alertlog.EscalationExhaustedMetaData
+		err = rows.Scan(&id, &meta.MaxNotifications)
+		if err != nil {
+			return err
+		}
+		batch[meta] = append(batch[meta], id)
+	}
+
+	for meta, ids := range batch {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeEscalationExhausted, meta)
+		if err != nil {
+			return errors.Wrap(err, "log escalation exhausted")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// unsnoozeAlerts returns snoozed alerts that are still acknowledged to triggered once their
+// snooze has expired, logging an entry for each, and resumes their escalation.
+func (db *DB) unsnoozeAlerts(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "escalation manager: unsnooze", tx)
+
+	rows, err := tx.StmtContext(ctx, db.unsnooze).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int
+	batch := make(map[alertlog.SnoozeMetaData][]int)
+	for rows.Next() {
+		var id int
+		var meta alertlog.SnoozeMetaData
+		err = rows.Scan(&id, &meta.DurationMinutes)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		batch[meta] = append(batch[meta], id)
+	}
+	if len(ids) == 0 {
+		return tx.Commit()
+	}
+
+	_, err = tx.StmtContext(ctx, db.rearmUnsnooze).ExecContext(ctx, sqlutil.IntArray(ids))
+	if err != nil {
+		return errors.Wrap(err, "resume escalation")
+	}
+
+	for meta, ids := range batch {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeUnsnoozed, meta)
+		if err != nil {
+			return errors.Wrap(err, "log unsnooze")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// timeoutAcks returns acknowledged alerts to triggered once they have been acknowledged
+// for longer than the ack timeout of their policy, logging an entry for each, and resumes
+// their escalation.
+func (db *DB) timeoutAcks(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "escalation manager: ack timeout", tx)
+
+	rows, err := tx.StmtContext(ctx, db.ackTimeout).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int
+	batch := make(map[alertlog.AckTimeoutMetaData][]int)
+	for rows.Next() {
+		var id int
+		var meta alertlog.AckTimeoutMetaData
+		err = rows.Scan(&id, &meta.TimeoutMinutes)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		batch[meta] = append(batch[meta], id)
+	}
+	if len(ids) == 0 {
+		return tx.Commit()
+	}
+
+	_, err = tx.StmtContext(ctx, db.rearmUnsnooze).ExecContext(ctx, sqlutil.IntArray(ids))
+	if err != nil {
+		return errors.Wrap(err, "resume escalation")
+	}
+
+	for meta, ids := range batch {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeAckTimeout, meta)
+		if err != nil {
+			return errors.Wrap(err, "log ack timeout")
+		}
+	}
+
+	return tx.Commit()
+}
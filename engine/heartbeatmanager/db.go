@@ -15,6 +15,7 @@ type DB struct {
 
 	alertStore *alert.Store
 
+	fetchWarning *sql.Stmt
 	fetchFailed  *sql.Stmt
 	fetchHealthy *sql.Stmt
 }
@@ -26,7 +27,7 @@ func (db *DB) Name() string { return "Engine.HeartbeatManager" }
 func NewDB(ctx context.Context, db *sql.DB, a *alert.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeHeartbeat,
-		Version: 1,
+		Version: 2,
 	})
 	if err != nil {
 		return nil, err
@@ -38,6 +39,24 @@ func NewDB(ctx context.Context, db *sql.DB, a *alert.Store) (*DB, error) {
 		lock:       lock,
 		alertStore: a,
 
+		fetchWarning: p.P(`
+			with rows as (
+				select id
+				from heartbeat_monitors
+				where
+					last_state not in ('warning', 'unhealthy') and
+					warning_interval notnull and
+					now() - last_heartbeat >= warning_interval and
+					now() - last_heartbeat < heartbeat_interval
+				limit 250
+				for update skip locked
+			)
+			update heartbeat_monitors mon
+			set last_state = 'warning'
+			from rows
+			where mon.id = rows.id
+			returning mon.id, name, service_id, last_heartbeat
+		`),
 		// if checked is still false after processing, we can delete it
 		fetchFailed: p.P(`
 			with rows as (
@@ -61,7 +80,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alert.Store) (*DB, error) {
 				from heartbeat_monitors
 				where
 					last_state != 'healthy' and
-					now() - last_heartbeat < heartbeat_interval
+					now() - last_heartbeat < coalesce(warning_interval, heartbeat_interval)
 				limit 250
 				for update skip locked
 			)
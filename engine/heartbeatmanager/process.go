@@ -33,6 +33,35 @@ func (db *DB) processAll(ctx context.Context) error {
 	defer sqlutil.Rollback(ctx, "heartbeat manager", tx)
 
 	var newAlertCtx []context.Context
+	logNew := func(ctx context.Context, a *alert.Alert, isNew bool) {
+		if !isNew {
+			return
+		}
+		// Store contexts with alert info for each alert that was newly-created.
+		newAlertCtx = append(newAlertCtx, log.WithFields(ctx, log.Fields{
+			"AlertID":   a.ID,
+			"ServiceID": a.ServiceID,
+		}))
+	}
+
+	warn, err := db.warning(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "fetch warning heartbeats")
+	}
+	for _, row := range warn {
+		a, isNew, err := db.alertStore.CreateOrUpdateTx(row.Context(ctx), tx, &alert.Alert{
+			Summary:   fmt.Sprintf("Heartbeat monitor '%s' is late.", row.Name),
+			Details:   "Last heartbeat: " + row.LastHeartbeat.Format(time.UnixDate),
+			Status:    alert.StatusTriggered,
+			ServiceID: row.ServiceID,
+			Dedup:     row.dedup(alert.DedupTypeHeartbeatWarning),
+		})
+		if err != nil {
+			return errors.Wrap(err, "create warning alert")
+		}
+		logNew(row.Context(ctx), a, isNew)
+	}
+
 	bad, err := db.unhealthy(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "fetch unhealthy heartbeats")
@@ -43,21 +72,17 @@ func (db *DB) processAll(ctx context.Context) error {
 			Details:   "Last heartbeat: " + row.LastHeartbeat.Format(time.UnixDate),
 			Status:    alert.StatusTriggered,
 			ServiceID: row.ServiceID,
-			Dedup: &alert.DedupID{
-				Type:    alert.DedupTypeHeartbeat,
-				Version: 1,
-				Payload: row.ID,
-			},
+			Dedup:     row.dedup(alert.DedupTypeHeartbeat),
 		})
 		if err != nil {
 			return errors.Wrap(err, "create alert")
 		}
-		if isNew {
-			// Store contexts with alert info for each alert that was newly-created.
-			newAlertCtx = append(newAlertCtx, log.WithFields(row.Context(ctx), log.Fields{
-				"AlertID":   a.ID,
-				"ServiceID": a.ServiceID,
-			}))
+		logNew(row.Context(ctx), a, isNew)
+
+		// the expired alert replaces the warning
+		err = db.closeAlert(ctx, tx, row, alert.DedupTypeHeartbeatWarning)
+		if err != nil {
+			return errors.Wrap(err, "close warning alert")
 		}
 	}
 	good, err := db.healthy(ctx, tx)
@@ -65,18 +90,14 @@ func (db *DB) processAll(ctx context.Context) error {
 		return errors.Wrap(err, "fetch healthy heartbeats")
 	}
 	for _, row := range good {
-		_, _, err = db.alertStore.CreateOrUpdateTx(row.Context(ctx), tx, &alert.Alert{
-			Status:    alert.StatusClosed,
-			ServiceID: row.ServiceID,
-			Dedup: &alert.DedupID{
-				Type:    alert.DedupTypeHeartbeat,
-				Version: 1,
-				Payload: row.ID,
-			},
-		})
+		err = db.closeAlert(ctx, tx, row, alert.DedupTypeHeartbeat)
 		if err != nil {
 			return errors.Wrap(err, "close alert")
 		}
+		err = db.closeAlert(ctx, tx, row, alert.DedupTypeHeartbeatWarning)
+		if err != nil {
+			return errors.Wrap(err, "close warning alert")
+		}
 	}
 
 	err = tx.Commit()
@@ -106,6 +127,41 @@ func (r row) Context(ctx context.Context) context.Context {
 	})
 }
 
+func (r row) dedup(t alert.DedupType) *alert.DedupID {
+	return &alert.DedupID{
+		Type:    t,
+		Version: 1,
+		Payload: r.ID,
+	}
+}
+
+func (db *DB) closeAlert(ctx context.Context, tx *sql.Tx, r row, t alert.DedupType) error {
+	_, _, err := db.alertStore.CreateOrUpdateTx(r.Context(ctx), tx, &alert.Alert{
+		Status:    alert.StatusClosed,
+		ServiceID: r.ServiceID,
+		Dedup:     r.dedup(t),
+	})
+	return err
+}
+
+func (db *DB) warning(ctx context.Context, tx *sql.Tx) ([]row, error) {
+	rows, err := tx.Stmt(db.fetchWarning).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []row
+	for rows.Next() {
+		var r row
+		err = rows.Scan(&r.ID, &r.Name, &r.ServiceID, &r.LastHeartbeat)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
 func (db *DB) unhealthy(ctx context.Context, tx *sql.Tx) ([]row, error) {
 	rows, err := tx.Stmt(db.fetchFailed).QueryContext(ctx)
 	if err != nil {
@@ -0,0 +1,131 @@
+package incidentmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB handles grouping alerts into incidents via correlation rules, and propagating
+// acknowledgements for grouped-ack incidents.
+type DB struct {
+	db   *sql.DB
+	lock *processinglock.Lock
+	log  *alertlog.Store
+
+	rules        *sql.Stmt
+	newAlerts    *sql.Stmt
+	findIncident *sql.Stmt
+	findPeers    *sql.Stmt
+	insertInc    *sql.Stmt
+	addAlerts    *sql.Stmt
+	setProcessed *sql.Stmt
+	groupedAck   *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.IncidentManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Version: 1,
+		Type:    processinglock.TypeIncident,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		db:   db,
+		lock: lock,
+		log:  log,
+
+		rules: p.P(`
+			select id, key, window_minutes, grouped_ack, processed_alert_id
+			from incident_correlation_rules
+			for update
+		`),
+
+		// closed alerts, and alerts already in an incident, are skipped but still
+		// counted as processed
+		newAlerts: p.P(`
+			select
+				a.id,
+				a.created_at,
+				coalesce(nullif(a.meta->>$2, ''), l.value),
+				a.status = 'closed' or exists (select 1 from incident_alerts ia where ia.alert_id = a.id)
+			from alerts a
+			left join labels l on l.tgt_service_id = a.service_id and l.key = $2
+			where a.id > $1
+			order by a.id
+			limit 500
+		`),
+
+		// the most recent incident for the rule and value whose latest alert is within the window
+		findIncident: p.P(`
+			select i.id
+			from incidents i
+			join incident_alerts ia on ia.incident_id = i.id
+			join alerts a on a.id = ia.alert_id
+			where
+				i.correlation_rule_id = $1 and
+				i.correlation_value = $2
+			group by i.id
+			having max(a.created_at) >= $3::timestamptz - $4::int * interval '1 minute'
+			order by max(a.created_at) desc
+			limit 1
+		`),
+
+		findPeers: p.P(`
+			select a.id
+			from alerts a
+			left join labels l on l.tgt_service_id = a.service_id and l.key = $2
+			where
+				a.id < $1 and
+				a.status != 'closed' and
+				a.created_at >= $4::timestamptz - $5::int * interval '1 minute' and
+				coalesce(nullif(a.meta->>$2, ''), l.value) = $3 and
+				not exists (select 1 from incident_alerts ia where ia.alert_id = a.id)
+			order by a.id
+			limit 99
+		`),
+
+		insertInc: p.P(`
+			insert into incidents (id, summary, grouped_ack, correlation_rule_id, correlation_value)
+			values ($1, $2, $3, $4, $5)
+		`),
+
+		addAlerts: p.P(`
+			insert into incident_alerts (incident_id, alert_id)
+			select $1::uuid, unnest($2::bigint[])
+			on conflict (alert_id) do nothing
+		`),
+
+		setProcessed: p.P(`update incident_correlation_rules set processed_alert_id = $2 where id = $1`),
+
+		// acknowledge triggered alerts in grouped-ack incidents that have an acknowledged alert
+		groupedAck: p.P(`
+			with acked as (
+				select distinct ia.incident_id
+				from incident_alerts ia
+				join incidents i on i.id = ia.incident_id and i.grouped_ack
+				join alerts a on a.id = ia.alert_id and a.status = 'active'
+			)
+			update alerts a
+			set status = 'active'
+			from incident_alerts ia, acked, incidents i
+			where
+				ia.alert_id = a.id and
+				ia.incident_id = acked.incident_id and
+				i.id = acked.incident_id and
+				a.status = 'triggered'
+			returning a.id, i.id, i.summary
+		`),
+	}, p.Err
+}
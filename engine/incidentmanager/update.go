@@ -0,0 +1,211 @@
+package incidentmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// UpdateAll will correlate new alerts into incidents and propagate grouped acknowledgements.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Processing incidents.")
+
+	err = db.correlate(ctx)
+	if err != nil {
+		return fmt.Errorf("correlate alerts: %w", err)
+	}
+
+	err = db.ackGrouped(ctx)
+	if err != nil {
+		return fmt.Errorf("grouped ack: %w", err)
+	}
+
+	return nil
+}
+
+type rule struct {
+	ID            uuid.UUID
+	Key           string
+	WindowMinutes int
+	GroupedAck    bool
+	ProcessedID   int64
+}
+
+func (db *DB) correlate(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "incident manager: correlate", tx)
+
+	rows, err := tx.StmtContext(ctx, db.rules).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []rule
+	for rows.Next() {
+		var r rule
+		err = rows.Scan(&r.ID, &r.Key, &r.WindowMinutes, &r.GroupedAck, &r.ProcessedID)
+		if err != nil {
+			return fmt.Errorf("scan rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	rows.Close()
+
+	for _, r := range rules {
+		err = db.correlateRule(ctx, tx, r)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", r.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+type newAlert struct {
+	ID        int64
+	CreatedAt time.Time
+	Value     sql.NullString
+	Skip      bool
+}
+
+func (db *DB) correlateRule(ctx context.Context, tx *sql.Tx, r rule) error {
+	rows, err := tx.StmtContext(ctx, db.newAlerts).QueryContext(ctx, r.ProcessedID, r.Key)
+	if err != nil {
+		return fmt.Errorf("fetch new alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []newAlert
+	for rows.Next() {
+		var a newAlert
+		err = rows.Scan(&a.ID, &a.CreatedAt, &a.Value, &a.Skip)
+		if err != nil {
+			return fmt.Errorf("scan alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	rows.Close()
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	for _, a := range alerts {
+		if a.Skip || !a.Value.Valid {
+			continue
+		}
+
+		err = db.correlateAlert(ctx, tx, r, a)
+		if err != nil {
+			return fmt.Errorf("alert %d: %w", a.ID, err)
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, db.setProcessed).ExecContext(ctx, r.ID, alerts[len(alerts)-1].ID)
+	if err != nil {
+		return fmt.Errorf("update processed alert ID: %w", err)
+	}
+
+	return nil
+}
+
+// correlateAlert will add the alert to an existing incident for the rule and value, or
+// create a new one if there are other un-grouped open alerts with the same value.
+func (db *DB) correlateAlert(ctx context.Context, tx *sql.Tx, r rule, a newAlert) error {
+	var incID uuid.UUID
+	err := tx.StmtContext(ctx, db.findIncident).QueryRowContext(ctx, r.ID, a.Value.String, a.CreatedAt, r.WindowMinutes).Scan(&incID)
+	if err == nil {
+		_, err = tx.StmtContext(ctx, db.addAlerts).ExecContext(ctx, incID, sqlutil.IntArray{int(a.ID)})
+		return err
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("find incident: %w", err)
+	}
+
+	rows, err := tx.StmtContext(ctx, db.findPeers).QueryContext(ctx, a.ID, r.Key, a.Value.String, a.CreatedAt, r.WindowMinutes)
+	if err != nil {
+		return fmt.Errorf("find related alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var ids sqlutil.IntArray
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return fmt.Errorf("scan related alert: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return nil
+	}
+	ids = append(ids, int(a.ID))
+
+	incID = uuid.New()
+	summary := validate.SanitizeText(fmt.Sprintf("Correlated alerts for %s: %s", r.Key, a.Value.String), 255)
+	_, err = tx.StmtContext(ctx, db.insertInc).ExecContext(ctx, incID, summary, r.GroupedAck, r.ID, a.Value.String)
+	if err != nil {
+		return fmt.Errorf("create incident: %w", err)
+	}
+
+	_, err = tx.StmtContext(ctx, db.addAlerts).ExecContext(ctx, incID, ids)
+	if err != nil {
+		return fmt.Errorf("add alerts: %w", err)
+	}
+
+	return nil
+}
+
+// ackGrouped will acknowledge the triggered alerts of grouped-ack incidents that have
+// an acknowledged alert, logging an entry for each.
+func (db *DB) ackGrouped(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "incident manager: grouped ack", tx)
+
+	rows, err := tx.StmtContext(ctx, db.groupedAck).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("ack alerts: %w", err)
+	}
+	defer rows.Close()
+
+	batch := make(map[alertlog.IncidentAckMetaData][]int)
+	for rows.Next() {
+		var id int
+		var meta alertlog.IncidentAckMetaData
+		err = rows.Scan(&id, &meta.IncidentID, &meta.Summary)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		batch[meta] = append(batch[meta], id)
+	}
+	rows.Close()
+
+	for meta, ids := range batch {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeIncidentAcknowledged, meta)
+		if err != nil {
+			return fmt.Errorf("log ack: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -16,6 +16,7 @@ func typeOrder(msg Message) int {
 
 // bundleAlertMessages will bundle status updates for the same Dest value. It will add any new messages to an existing bundle.
 // A single contact-method will only ever have a single alert notification per-service in the result.
+// Shadow (training), watcher (informational), and replayed notifications are never bundled.
 //
 // It also handles updating the outgoing_messages table by marking bundled messages with the `bundled`
 // status and creating a new bundled message placeholder.
@@ -36,6 +37,11 @@ func bundleAlertMessages(messages []Message, newBundleFunc func(Message) (string
 
 	groups := make(map[key][]Message)
 	for _, msg := range toProcess {
+		if msg.Shadow || msg.Watcher || msg.Replay {
+			// training, informational, and replayed notifications are always sent individually
+			result = append(result, msg)
+			continue
+		}
 		key := key{
 			Dest:      msg.Dest,
 			ServiceID: msg.ServiceID,
@@ -51,8 +57,10 @@ func bundleAlertMessages(messages []Message, newBundleFunc func(Message) (string
 		}
 
 		ids := make([]string, len(msgs))
+		var critical bool
 		for i, msg := range msgs {
 			ids[i] = msg.ID
+			critical = critical || msg.Critical
 		}
 
 		bundleID := msgs[0].ID
@@ -73,6 +81,7 @@ func bundleAlertMessages(messages []Message, newBundleFunc func(Message) (string
 		if err != nil {
 			return nil, err
 		}
+		msgs[0].Critical = critical
 		result = append(result, msgs[0])
 	}
 
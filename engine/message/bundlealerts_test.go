@@ -114,4 +114,37 @@ func TestBundleAlertMessages(t *testing.T) {
 		}, out[0])
 	})
 
+	t.Run("shadow", func(t *testing.T) {
+		msg := []Message{
+			{ID: "a", AlertID: 1, Type: notification.MessageTypeAlert},
+			{ID: "b", AlertID: 2, Type: notification.MessageTypeAlert, Shadow: true},
+		}
+
+		out, err := bundleAlertMessages(msg, func(b Message) (string, error) {
+			t.Fatal("should not create a bundle")
+			return "", nil
+		}, func(parentID string, ids []string) error {
+			t.Fatal("should not bundle messages")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, msg, out)
+	})
+
+	t.Run("watcher", func(t *testing.T) {
+		msg := []Message{
+			{ID: "a", AlertID: 1, Type: notification.MessageTypeAlert},
+			{ID: "b", AlertID: 2, Type: notification.MessageTypeAlert, Watcher: true},
+		}
+
+		out, err := bundleAlertMessages(msg, func(b Message) (string, error) {
+			t.Fatal("should not create a bundle")
+			return "", nil
+		}, func(parentID string, ids []string) error {
+			t.Fatal("should not bundle messages")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, msg, out)
+	})
 }
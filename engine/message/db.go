@@ -41,11 +41,15 @@ type DB struct {
 
 	sendDeadlineExpired *sql.Stmt
 
-	failDisabledCM *sql.Stmt
-	alertlogstore  *alertlog.Store
+	failDisabledCM   *sql.Stmt
+	disableFailingCM *sql.Stmt
+	enableCooledCM   *sql.Stmt
+	alertlogstore    *alertlog.Store
 
 	failSMSVoice *sql.Stmt
 
+	failInactiveAlerts *sql.Stmt
+
 	sentByCMType *sql.Stmt
 
 	cleanupStatusUpdateOptOut *sql.Stmt
@@ -54,14 +58,22 @@ type DB struct {
 	permFail     *sql.Stmt
 	updateStatus *sql.Stmt
 
+	cmFailed    *sql.Stmt
+	cmDelivered *sql.Stmt
+
 	advLock        *sql.Stmt
 	advLockCleanup *sql.Stmt
 
 	createAlertBundle *sql.Stmt
 	bundleMessages    *sql.Stmt
 
+	userLimits  *sql.Stmt
+	markLimited *sql.Stmt
+
 	deleteAny *sql.Stmt
 
+	insertAttempt *sql.Stmt
+
 	lastSent     time.Time
 	sentMessages map[string]Message
 }
@@ -70,7 +82,7 @@ type DB struct {
 func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycle.Pausable) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeMessage,
-		Version: 9,
+		Version: 17,
 	})
 	if err != nil {
 		return nil, err
@@ -84,7 +96,8 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			last_status_at = now(),
 			status_details = $3,
 			provider_msg_id = coalesce($2, provider_msg_id),
-			next_retry_at = CASE WHEN retry_count < 3 THEN now() + '15 seconds'::interval ELSE null END
+			next_retry_at = CASE WHEN retry_count < $4 THEN now() + cast($5 as float8) * '1 second'::interval ELSE null END,
+			dead_lettered_at = CASE WHEN retry_count >= $4 and cast($6 as boolean) THEN now() ELSE null END
 		where id = $1 or provider_msg_id = $2
 	`)
 	permFail := p.P(`
@@ -129,6 +142,30 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 		tempFail:     tempFail,
 		permFail:     permFail,
 
+		// Only count a failure once per message, on its last attempt (see
+		// retryPolicy.cmFailedArgs), as providers may report the same failure
+		// more than once and earlier attempts are retried.
+		cmFailed: p.P(`
+			update user_contact_methods cm
+			set consecutive_failures = cm.consecutive_failures + 1
+			from outgoing_messages msg
+			where
+				(msg.id = $1 or msg.provider_msg_id = $2) and
+				msg.last_status != 'failed' and
+				msg.retry_count >= $3 and
+				msg.message_type != 'verification_message' and
+				cm.id = msg.contact_method_id
+		`),
+		cmDelivered: p.P(`
+			update user_contact_methods cm
+			set consecutive_failures = 0
+			from outgoing_messages msg
+			where
+				(msg.id = $1 or msg.provider_msg_id = $2) and
+				cm.id = msg.contact_method_id and
+				cm.consecutive_failures > 0
+		`),
+
 		sentMessages: make(map[string]Message),
 
 		advLock: p.P(`select pg_advisory_lock($1)`),
@@ -223,18 +260,15 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				provider_seq = 0
 			where
 				last_status = 'failed' and
-				now() > next_retry_at and
-				retry_count < 3
+				now() > next_retry_at
 		`),
 		retryClear: p.P(`
 			update outgoing_messages
-			set
-				next_retry_at = null,
-				cycle_id = null
+			set cycle_id = null
 			where
 				last_status = 'failed' and
-				retry_count >= 3 and
-				(cycle_id notnull or next_retry_at notnull)
+				next_retry_at isnull and
+				cycle_id notnull
 		`),
 
 		lockStmt:    p.P(`lock outgoing_messages in exclusive mode`),
@@ -259,6 +293,41 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			) select distinct msg_id, alert_id, user_id, cm_id from disabled where alert_id notnull
 		`),
 
+		disableFailingCM: p.P(`
+			with disabled as (
+				update user_contact_methods
+				set
+					disabled = true,
+					auto_disabled_at = now()
+				where
+					not disabled and
+					consecutive_failures >= $1
+				returning id, user_id
+			), notify as (
+				select distinct on (d.id) d.id as disabled_id, d.user_id, cm.id as cm_id
+				from disabled d
+				join user_contact_methods cm on
+					cm.user_id = d.user_id and
+					not cm.disabled and
+					cm.id not in (select id from disabled)
+				order by d.id, cm.consecutive_failures, cm.type = 'EMAIL' desc, cm.name
+			)
+			insert into outgoing_messages (message_type, contact_method_id, user_id, disabled_contact_method_id)
+			select 'contact_method_disabled_notification'::enum_outgoing_messages_type, cm_id, user_id, disabled_id
+			from notify
+		`),
+
+		enableCooledCM: p.P(`
+			update user_contact_methods
+			set
+				disabled = false,
+				consecutive_failures = 0,
+				auto_disabled_at = null
+			where
+				disabled and
+				auto_disabled_at < now() - cast($1 as int) * '1 minute'::interval
+		`),
+
 		failSMSVoice: p.P(`
 			update outgoing_messages msg
 			set
@@ -275,6 +344,26 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
 		`),
 
+		failInactiveAlerts: p.P(`
+			update outgoing_messages msg
+			set
+				last_status = 'failed',
+				last_status_at = now(),
+				status_details = 'alert acked/closed before message sent',
+				cycle_id = null,
+				next_retry_at = null
+			from alerts a
+			where
+				msg.last_status = 'pending' and
+				msg.replay_of_id isnull and
+				a.id = msg.alert_id and
+				(
+					(msg.message_type = 'alert_notification' and a.status != 'triggered') or
+					-- bridge invites are still useful once acknowledged
+					(msg.message_type = 'alert_bridge_invite' and a.status = 'closed')
+				)
+		`),
+
 		createAlertBundle: p.P(`
 			insert into outgoing_messages (
 				id,
@@ -303,7 +392,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			select
 				msg.id,
 				msg.message_type,
-				cm.type,
+				case when code.voice then 'VOICE' else cm.type end,
 				chan.type,
 				coalesce(msg.contact_method_id, msg.channel_id),
 				coalesce(cm.value, chan.value),
@@ -315,22 +404,66 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				msg.created_at,
 				msg.sent_at,
 				msg.status_alert_ids,
-				msg.schedule_id
+				msg.schedule_id,
+				msg.schedule_handoff_notice_id,
+				msg.retry_count,
+				msg.disabled_contact_method_id,
+				msg.shadow,
+				msg.watcher,
+				msg.replay_of_id notnull,
+				coalesce(a.severity >= 'critical', false) or (
+					msg.message_type = 'alert_notification_bundle' and
+					msg.last_status = 'pending' and
+					exists (
+						select 1
+						from outgoing_messages b
+						join alerts ba on ba.id = b.alert_id
+						where
+							b.last_status = 'bundled' and
+							b.status_details = msg.id::text and
+							ba.severity >= 'critical'
+					)
+				)
 			from outgoing_messages msg
+			left join alerts a on a.id = msg.alert_id
 			left join user_contact_methods cm on cm.id = msg.contact_method_id
 			left join notification_channels chan on chan.id = msg.channel_id
+			left join user_verification_codes code on code.id = msg.user_verification_code_id
 			where
 				sent_at >= $1 or
 				last_status = 'pending' and
 				(msg.contact_method_id isnull or msg.message_type = 'verification_message' or not cm.disabled)
 		`),
 
+		userLimits: p.P(`
+			select id, max_notifications_per_hour
+			from users
+			where id = any($1) and max_notifications_per_hour notnull
+		`),
+
+		markLimited: p.P(`
+			update outgoing_messages
+			set status_details = 'held: notification rate limit reached'
+			where
+				id = any($1) and
+				last_status = 'pending' and
+				status_details = ''
+			returning id, alert_id, service_id
+		`),
+
 		deleteAny: p.P(`delete from outgoing_messages where id = any($1)`),
+
+		insertAttempt: p.P(`
+			insert into outgoing_message_attempts (message_id, attempt, status, status_details)
+			values ($1, $2, $3, $4)
+			on conflict (message_id, attempt) do nothing
+		`),
 	}, p.Err
 }
 
 func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*queue, error) {
-	cutoff := now.Add(-maxThrottleDuration(PerCMThrottle, GlobalCMThrottle))
+	// sent messages are kept long enough for all throttles and per-user limits
+	cutoff := now.Add(-maxThrottleDuration(PerCMThrottle, GlobalCMThrottle, ThrottleRules{{Per: userLimitPer}}))
 	sentSince := db.lastSent
 	if sentSince.IsZero() {
 		sentSince = cutoff
@@ -345,7 +478,7 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 	result := make([]Message, 0, len(db.sentMessages))
 	for rows.Next() {
 		var msg Message
-		var destID, destValue, verifyID, userID, serviceID, scheduleID sql.NullString
+		var destID, destValue, verifyID, userID, serviceID, scheduleID, noticeID, disabledCMID sql.NullString
 		var dstType notification.ScannableDestType
 		var alertID, logID sql.NullInt64
 		var statusAlertIDs sqlutil.IntArray
@@ -366,6 +499,13 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 			&sentAt,
 			&statusAlertIDs,
 			&scheduleID,
+			&noticeID,
+			&msg.RetryCount,
+			&disabledCMID,
+			&msg.Shadow,
+			&msg.Watcher,
+			&msg.Replay,
+			&msg.Critical,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row")
@@ -381,6 +521,8 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		msg.Dest.Value = destValue.String
 		msg.StatusAlertIDs = statusAlertIDs
 		msg.ScheduleID = scheduleID.String
+		msg.HandoffNoticeID = noticeID.String
+		msg.DisabledCMID = disabledCMID.String
 
 		msg.Dest.Type = dstType.DestType()
 		if msg.Dest.Type == notification.DestTypeUnknown {
@@ -435,11 +577,23 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		return nil, fmt.Errorf("dedup alerts: %w", err)
 	}
 
-	if cfg.General.DisableMessageBundles {
-		return newQueue(result, now), nil
+	if !cfg.General.DisableMessageBundles {
+		result, err = db.bundleAlerts(ctx, tx, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err = db.limitUserAlerts(ctx, tx, result, now)
+	if err != nil {
+		return nil, fmt.Errorf("limit user notifications: %w", err)
 	}
 
-	result, err = bundleAlertMessages(result, func(msg Message) (string, error) {
+	return newQueue(result, now), nil
+}
+
+func (db *DB) bundleAlerts(ctx context.Context, tx *sql.Tx, result []Message) ([]Message, error) {
+	return bundleAlertMessages(result, func(msg Message) (string, error) {
 		var cmID, chanID, userID sql.NullString
 		if msg.UserID != "" {
 			userID.Valid = true
@@ -461,14 +615,105 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 
 		return newID, nil
 	}, func(parentID string, ids []string) error {
-		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(ids))
+		_, err := tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(ids))
 		return err
 	})
+}
+
+// limitUserAlerts will hold back alert notifications to users over their notification rate
+// limit, and log them to the alert log the first time they are held.
+func (db *DB) limitUserAlerts(ctx context.Context, tx *sql.Tx, result []Message, now time.Time) ([]Message, error) {
+	var userIDs sqlutil.UUIDArray
+	seen := make(map[string]bool)
+	for _, msg := range result {
+		if msg.UserID == "" || seen[msg.UserID] || !msg.SentAt.IsZero() {
+			continue
+		}
+		seen[msg.UserID] = true
+		userIDs = append(userIDs, msg.UserID)
+	}
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := tx.StmtContext(ctx, db.userLimits).QueryContext(ctx, userIDs)
 	if err != nil {
+		return nil, fmt.Errorf("fetch user limits: %w", err)
+	}
+	defer rows.Close()
+
+	limits := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var limit int
+		err = rows.Scan(&userID, &limit)
+		if err != nil {
+			return nil, fmt.Errorf("scan user limit: %w", err)
+		}
+		limits[userID] = limit
+	}
+	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return newQueue(result, now), nil
+	defaultLimit := config.FromContext(ctx).General.MaxUserNotificationsPerHour
+	result, held := limitUserAlertMessages(result, now, func(userID string) int {
+		if limit, ok := limits[userID]; ok {
+			return limit
+		}
+		return defaultLimit
+	})
+	if len(held) == 0 {
+		return result, nil
+	}
+
+	ids := make(sqlutil.UUIDArray, len(held))
+	users := make(map[string]string, len(held))
+	for i, msg := range held {
+		ids[i] = msg.ID
+		users[msg.ID] = msg.UserID
+	}
+	rows, err = tx.StmtContext(ctx, db.markLimited).QueryContext(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("mark held messages: %w", err)
+	}
+	defer rows.Close()
+
+	type heldMsg struct {
+		id        string
+		alertID   sql.NullInt64
+		serviceID sql.NullString
+	}
+	var toLog []heldMsg
+	for rows.Next() {
+		var m heldMsg
+		err = rows.Scan(&m.id, &m.alertID, &m.serviceID)
+		if err != nil {
+			return nil, fmt.Errorf("scan held message: %w", err)
+		}
+		toLog = append(toLog, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	meta := &alertlog.NoNotificationMetaData{RateLimited: true}
+	for _, m := range toLog {
+		logCtx := permission.UserSourceContext(ctx, users[m.id], permission.RoleUser, &permission.SourceInfo{
+			Type: permission.SourceTypeContactMethod,
+		})
+		switch {
+		case m.alertID.Valid:
+			err = db.alertlogstore.LogTx(logCtx, tx, int(m.alertID.Int64), alertlog.TypeNoNotificationSent, meta)
+		case m.serviceID.Valid:
+			err = db.alertlogstore.LogServiceTx(logCtx, tx, m.serviceID.String, alertlog.TypeNoNotificationSent, meta)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("log held notification: %w", err)
+		}
+	}
+
+	return result, nil
 }
 
 // UpdateMessageStatus will update the state of a message.
@@ -497,8 +742,17 @@ func (db *DB) _UpdateMessageStatus(ctx context.Context, status *notification.Sen
 		cbID.String = status.ID
 	}
 
+	// provider status updates don't include the destination, so use the default policy
+	if isCMFailure(status) {
+		_, err = db.cmFailed.ExecContext(ctx, append([]interface{}{cbID, status.ProviderMessageID}, defaultRetryPolicy.cmFailedArgs(status.State)...)...)
+		if err != nil {
+			return err
+		}
+	}
+
 	if status.State == notification.StateFailedTemp {
-		_, err = db.tempFail.ExecContext(ctx, cbID, status.ProviderMessageID, status.Details)
+		args := append([]interface{}{cbID, status.ProviderMessageID, status.Details}, defaultRetryPolicy.tempFailArgs(0)...)
+		_, err = db.tempFail.ExecContext(ctx, args...)
 		return err
 	}
 	if status.State == notification.StateFailedPerm {
@@ -523,6 +777,13 @@ func (db *DB) _UpdateMessageStatus(ctx context.Context, status *notification.Sen
 	}
 
 	_, err = db.updateStatus.ExecContext(ctx, cbID, status.ProviderMessageID, status.Sequence, s, status.Details, srcValue)
+	if err != nil {
+		return err
+	}
+
+	if s == StatusSent || s == StatusDelivered {
+		_, err = db.cmDelivered.ExecContext(ctx, cbID, status.ProviderMessageID)
+	}
 	return err
 }
 
@@ -640,6 +901,23 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		}
 	}
 
+	// re-enable CMs that were disabled for failing once the cooldown has passed
+	if cfg.General.ContactMethodDisableMinutes > 0 {
+		_, err = tx.Stmt(db.enableCooledCM).ExecContext(execCtx, cfg.General.ContactMethodDisableMinutes)
+		if err != nil {
+			return errors.Wrap(err, "re-enable disabled CMs")
+		}
+	}
+
+	// disable CMs that keep failing, so pending messages to them fail below
+	// instead of waiting on more attempts
+	if cfg.General.ContactMethodFailureLimit > 0 {
+		_, err = tx.Stmt(db.disableFailingCM).ExecContext(execCtx, cfg.General.ContactMethodFailureLimit)
+		if err != nil {
+			return errors.Wrap(err, "disable failing CMs")
+		}
+	}
+
 	// processes disabled CMs and writes to alert log if disabled
 	rows, err := tx.Stmt(db.failDisabledCM).QueryContext(execCtx)
 	if err != nil {
@@ -683,10 +961,18 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		return errors.Wrap(err, "reset retry messages")
 	}
 
+	// cancel queued notifications for alerts acknowledged or closed since they were
+	// created, regardless of which step they were sent for
+	_, err = tx.Stmt(db.failInactiveAlerts).ExecContext(execCtx)
+	if err != nil {
+		return errors.Wrap(err, "fail messages for inactive alerts")
+	}
+
 	q, err := db.currentQueue(ctx, tx, t)
 	if err != nil {
 		return errors.Wrap(err, "get pending messages")
 	}
+	q.recordMetrics()
 
 	err = tx.Commit()
 	if err != nil {
@@ -842,6 +1128,7 @@ func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send
 		retry.FibBackoff(65*time.Millisecond),
 	)
 	cancel()
+	recordSendResult(m, status, err)
 
 	var pID notification.ProviderMessageID
 	if status != nil {
@@ -857,15 +1144,30 @@ func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send
 			retry.FibBackoff(time.Millisecond*50),
 		)
 	}
+	policy := retryPolicyFor(config.FromContext(ctx), m.Dest.Type)
+	if policy.DeadLetter {
+		details, state := attemptStatus(status, err)
+		aErr := retryExec(db.insertAttempt, m.ID, m.RetryCount+1, state, details)
+		if aErr != nil {
+			log.Log(ctx, errors.Wrap(aErr, "record message attempt"))
+		}
+	}
+
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "send message"))
 
-		err = retryExec(db.tempFail, m.ID, pID, err.Error())
+		err = retryExec(db.tempFail, append([]interface{}{m.ID, pID, err.Error()}, policy.tempFailArgs(m.RetryCount)...)...)
 		return false, errors.Wrap(err, "mark failed message")
 	}
 
+	if isCMFailure(status) {
+		err = retryExec(db.cmFailed, append([]interface{}{m.ID, pID}, policy.cmFailedArgs(status.State)...)...)
+		if err != nil {
+			return false, errors.Wrap(err, "record contact method failure")
+		}
+	}
 	if status.State == notification.StateFailedTemp {
-		err = retryExec(db.tempFail, m.ID, pID, status.Details)
+		err = retryExec(db.tempFail, append([]interface{}{m.ID, pID, status.Details}, policy.tempFailArgs(m.RetryCount)...)...)
 		return false, errors.Wrap(err, "mark failed message (temp)")
 	}
 	if status.State == notification.StateFailedPerm {
@@ -20,13 +20,22 @@ func dedupAlerts(msgs []Message, bundleFunc func(parentID string, duplicateIDs [
 	type msgKey struct {
 		notification.Dest
 		AlertID int
+		Watcher bool
+		Shadow  bool
 	}
 	alerts := make(map[msgKey]string, len(msgs))
 	duplicates := make(map[string][]string)
 
 	for _, msg := range toProcess {
+		if msg.Replay {
+			// replays were explicitly requested, so they are always sent
+			result = append(result, msg)
+			continue
+		}
+
 		// check if we have seen this alert before
-		key := msgKey{msg.Dest, msg.AlertID}
+		// a real page to a shadow is never folded into their training notification, or the reverse
+		key := msgKey{msg.Dest, msg.AlertID, msg.Watcher, msg.Shadow}
 
 		if parentID, ok := alerts[key]; ok {
 			duplicates[parentID] = append(duplicates[parentID], msg.ID)
@@ -43,3 +43,18 @@ func TestDedupAlerts(t *testing.T) {
 		},
 		res)
 }
+
+func TestDedupAlertsShadow(t *testing.T) {
+	// a real page queued after a shadow (training) notification for the same alert and destination
+	messages := []Message{
+		{ID: "1", Type: notification.MessageTypeAlert, CreatedAt: time.Date(2021, 7, 15, 10, 0, 0, 0, time.UTC), AlertID: 1, Dest: notification.Dest{ID: "foo"}, Shadow: true},
+		{ID: "2", Type: notification.MessageTypeAlert, CreatedAt: time.Date(2021, 7, 15, 11, 0, 0, 0, time.UTC), AlertID: 1, Dest: notification.Dest{ID: "foo"}},
+	}
+
+	res, err := dedupAlerts(messages, func(parentID string, duplicates []string) error {
+		t.Errorf("unexpected bundle of %v into %s", duplicates, parentID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, messages, res)
+}
@@ -0,0 +1,70 @@
+package message
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/notification"
+)
+
+// userLimitPer is the period over which per-user notification limits apply.
+const userLimitPer = time.Hour
+
+// limitUserAlertMessages will hold back pending alert notifications to users that have
+// reached their maximum number of notifications per hour. The limit func returns the maximum
+// for a user, or zero if there is no limit.
+//
+// Notifications for critical and fatal alerts are never held, but count toward the limit.
+// Replayed, watcher, and shadow notifications are never held, and do not count toward the limit.
+// Held messages are returned separately; they remain pending, so they are bundled and sent
+// once the limit allows.
+func limitUserAlertMessages(messages []Message, now time.Time, limit func(userID string) int) (result, held []Message) {
+	toProcess, result := splitPendingByType(messages, notification.MessageTypeAlert, notification.MessageTypeAlertBundle)
+
+	sent := make(map[string]int)
+	for _, msg := range result {
+		if msg.SentAt.IsZero() || msg.UserID == "" || now.Sub(msg.SentAt) >= userLimitPer {
+			continue
+		}
+		if msg.Type != notification.MessageTypeAlert && msg.Type != notification.MessageTypeAlertBundle || msg.Replay || msg.Watcher || msg.Shadow {
+			continue
+		}
+		sent[msg.UserID]++
+	}
+
+	sort.Slice(toProcess, func(i, j int) bool { return toProcess[i].CreatedAt.Before(toProcess[j].CreatedAt) })
+
+	byUser := make(map[string][]Message)
+	for _, msg := range toProcess {
+		if msg.UserID == "" || msg.Replay || msg.Watcher || msg.Shadow {
+			result = append(result, msg)
+			continue
+		}
+		if msg.Critical {
+			sent[msg.UserID]++
+			result = append(result, msg)
+			continue
+		}
+		byUser[msg.UserID] = append(byUser[msg.UserID], msg)
+	}
+
+	for userID, msgs := range byUser {
+		max := limit(userID)
+		if max == 0 {
+			result = append(result, msgs...)
+			continue
+		}
+
+		n := max - sent[userID]
+		if n < 0 {
+			n = 0
+		}
+		if n > len(msgs) {
+			n = len(msgs)
+		}
+		result = append(result, msgs[:n]...)
+		held = append(held, msgs[n:]...)
+	}
+
+	return result, held
+}
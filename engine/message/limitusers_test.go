@@ -0,0 +1,51 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestLimitUserAlertMessages(t *testing.T) {
+	n := time.Date(2021, 7, 15, 12, 0, 0, 0, time.UTC)
+	messages := []Message{
+		{ID: "1", Type: notification.MessageTypeAlert, UserID: "a", SentAt: n.Add(-2 * time.Hour)}, // outside the window
+		{ID: "2", Type: notification.MessageTypeAlert, UserID: "a", SentAt: n.Add(-time.Minute)},
+		{ID: "3", Type: notification.MessageTypeAlertStatus, UserID: "a", SentAt: n.Add(-time.Minute)},           // not counted
+		{ID: "10", Type: notification.MessageTypeAlert, UserID: "a", SentAt: n.Add(-time.Minute), Replay: true},  // not counted
+		{ID: "12", Type: notification.MessageTypeAlert, UserID: "a", SentAt: n.Add(-time.Minute), Watcher: true}, // not counted
+		{ID: "13", Type: notification.MessageTypeAlert, UserID: "a", SentAt: n.Add(-time.Minute), Shadow: true},  // not counted
+
+		{ID: "4", Type: notification.MessageTypeAlert, UserID: "a", CreatedAt: n.Add(-3 * time.Minute)},
+		{ID: "5", Type: notification.MessageTypeAlertBundle, UserID: "a", CreatedAt: n.Add(-2 * time.Minute)},
+		{ID: "6", Type: notification.MessageTypeAlert, UserID: "a", CreatedAt: n.Add(-time.Minute), Critical: true},
+		{ID: "7", Type: notification.MessageTypeAlertStatus, UserID: "a", CreatedAt: n},
+
+		{ID: "8", Type: notification.MessageTypeAlert, UserID: "b", CreatedAt: n},
+		{ID: "11", Type: notification.MessageTypeAlert, UserID: "a", CreatedAt: n.Add(-4 * time.Minute), Replay: true},  // never held
+		{ID: "14", Type: notification.MessageTypeAlert, UserID: "a", CreatedAt: n.Add(-4 * time.Minute), Watcher: true}, // never held
+		{ID: "15", Type: notification.MessageTypeAlert, UserID: "a", CreatedAt: n.Add(-4 * time.Minute), Shadow: true},  // never held
+		{ID: "9", Type: notification.MessageTypeAlert, CreatedAt: n, Dest: notification.Dest{Type: notification.DestTypeSlackChannel}},
+	}
+
+	res, held := limitUserAlertMessages(messages, n, func(userID string) int {
+		if userID == "a" {
+			return 3
+		}
+		return 0
+	})
+
+	ids := func(msgs []Message) []string {
+		var ids []string
+		for _, m := range msgs {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	// 1 sent and 1 critical leaves room for the oldest of the rest
+	assert.ElementsMatch(t, []string{"1", "2", "3", "4", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15"}, ids(res))
+	assert.ElementsMatch(t, []string{"5"}, ids(held))
+}
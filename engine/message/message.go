@@ -22,4 +22,26 @@ type Message struct {
 	SentAt     time.Time
 
 	StatusAlertIDs []int
+
+	// HandoffNoticeID is set for schedule handoff messages.
+	HandoffNoticeID string
+
+	// DisabledCMID is set for contact method disabled messages.
+	DisabledCMID string
+
+	// RetryCount is the number of times sending the message has been retried.
+	RetryCount int
+
+	// Shadow is set for alert notifications sent to a rotation shadow for training.
+	Shadow bool
+
+	// Watcher is set for informational alert notifications sent to a service watcher.
+	Watcher bool
+
+	// Critical is set for alert notifications of critical or fatal alerts, and bundles
+	// containing one.
+	Critical bool
+
+	// Replay is set for messages that are an administrator-requested copy of a previous message.
+	Replay bool
 }
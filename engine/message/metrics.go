@@ -0,0 +1,49 @@
+package message
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/target/goalert/notification"
+)
+
+var (
+	metricPending = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "messages_pending",
+		Help:      "Number of outgoing messages waiting to be sent, as of the last engine cycle.",
+	}, []string{"dest_type"})
+
+	metricOldestPendingAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "oldest_pending_message_age_seconds",
+		Help:      "Age of the oldest outgoing message waiting to be sent, in seconds, as of the last engine cycle.",
+	})
+
+	metricSendTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "messages_total",
+		Help:      "Total number of outgoing message send attempts by result.",
+	}, []string{"dest_type", "message_type", "result"})
+)
+
+// recordSendResult records the outcome of a send attempt.
+func recordSendResult(m *Message, status *notification.SendResult, err error) {
+	var result string
+	switch {
+	case err != nil:
+		result = "error"
+	case status.Skipped:
+		result = "skipped"
+	case status.State == notification.StateFailedTemp:
+		result = "failed_temp"
+	case status.State == notification.StateFailedPerm:
+		result = "failed_perm"
+	default:
+		result = "sent"
+	}
+
+	metricSendTotal.WithLabelValues(m.Dest.Type.String(), m.Type.String(), result).Inc()
+}
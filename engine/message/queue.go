@@ -13,13 +13,18 @@ var typePriority = map[notification.MessageType]int{
 	notification.MessageTypeVerification: 1,
 	notification.MessageTypeTest:         2,
 
+	notification.MessageTypeContactMethodDisabled: 2,
+
 	notification.MessageTypeScheduleOnCallUsers: 3,
+	notification.MessageTypeScheduleHandoff:     3,
 
 	// First alert will jump the list with priority 0, so this only
 	// represents additional alerts to the service after the first.
 	notification.MessageTypeAlert:       4,
 	notification.MessageTypeAlertBundle: 4,
 
+	notification.MessageTypeAlertBridgeInvite: 4,
+
 	notification.MessageTypeAlertStatus: 5,
 }
 
@@ -69,6 +74,30 @@ func newQueue(msgs []Message, now time.Time) *queue {
 
 	return q
 }
+
+// recordMetrics updates the pending message metrics from the current queue.
+func (q *queue) recordMetrics() {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	metricPending.Reset()
+	var oldest time.Time
+	for typ, msgs := range q.pending {
+		metricPending.WithLabelValues(typ.String()).Set(float64(len(msgs)))
+		for _, m := range msgs {
+			if oldest.IsZero() || m.CreatedAt.Before(oldest) {
+				oldest = m.CreatedAt
+			}
+		}
+	}
+
+	if oldest.IsZero() {
+		metricOldestPendingAge.Set(0)
+		return
+	}
+	metricOldestPendingAge.Set(q.now.Sub(oldest).Seconds())
+}
+
 func (q *queue) addSent(m Message) {
 	if m.SentAt.IsZero() {
 		m.SentAt = q.now
@@ -0,0 +1,130 @@
+package message
+
+import (
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+// retryPolicy controls how a temporarily failed message is retried.
+type retryPolicy struct {
+	// MaxRetries is the number of times a message is retried after the first attempt.
+	MaxRetries int
+
+	// BaseDelay is the delay before the first retry, doubled for each subsequent retry
+	// up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// DeadLetter indicates each attempt is recorded, and the message is dead-lettered
+	// once retries are exhausted.
+	DeadLetter bool
+}
+
+// defaultRetryPolicy is used for all destinations without a configurable policy.
+var defaultRetryPolicy = retryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  15 * time.Second,
+	MaxDelay:   15 * time.Second,
+}
+
+// pagerDutyRetryPolicy backs off for longer than the default, as PagerDuty is usually
+// the last step of an escalation and rate limits are common during large incidents.
+var pagerDutyRetryPolicy = retryPolicy{
+	MaxRetries: 5,
+	BaseDelay:  15 * time.Second,
+	MaxDelay:   5 * time.Minute,
+	DeadLetter: true,
+}
+
+// retryPolicyFor returns the retry policy for messages to the given destination type.
+func retryPolicyFor(cfg config.Config, t notification.DestType) retryPolicy {
+	switch t {
+	case notification.DestTypeUserWebhook, notification.DestTypeChanWebhook:
+	case notification.DestTypePagerDuty:
+		return pagerDutyRetryPolicy
+	default:
+		return defaultRetryPolicy
+	}
+
+	p := retryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  15 * time.Second,
+		MaxDelay:   10 * time.Minute,
+		DeadLetter: true,
+	}
+	if cfg.Webhook.RetryMaxAttempts > 0 {
+		p.MaxRetries = cfg.Webhook.RetryMaxAttempts - 1
+	}
+	if cfg.Webhook.RetryBaseDelaySeconds > 0 {
+		p.BaseDelay = time.Duration(cfg.Webhook.RetryBaseDelaySeconds) * time.Second
+	}
+	if cfg.Webhook.RetryMaxDelaySeconds > 0 {
+		p.MaxDelay = time.Duration(cfg.Webhook.RetryMaxDelaySeconds) * time.Second
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+
+	return p
+}
+
+// Delay returns the delay before retrying a message that has already been retried
+// retryCount times.
+func (p retryPolicy) Delay(retryCount int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < retryCount && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return d
+}
+
+// tempFailArgs returns the retry arguments for the tempFail statement, for a message
+// that has already been retried retryCount times.
+func (p retryPolicy) tempFailArgs(retryCount int) []interface{} {
+	return []interface{}{p.MaxRetries, p.Delay(retryCount).Seconds(), p.DeadLetter}
+}
+
+// cmFailedArgs returns the query arguments for counting a failed attempt against the contact
+// method of a message: the retry count at which a failure in state is final. Permanent
+// failures always count, temporary ones only once retries are exhausted.
+func (p retryPolicy) cmFailedArgs(state notification.State) []interface{} {
+	if state == notification.StateFailedPerm {
+		return []interface{}{0}
+	}
+
+	return []interface{}{p.MaxRetries}
+}
+
+// isCMFailure returns true if the result of sending a message should be counted as a failure of
+// its contact method. Messages skipped before reaching a provider never count.
+func isCMFailure(res *notification.SendResult) bool {
+	if res.Skipped {
+		return false
+	}
+
+	return res.State == notification.StateFailedTemp || res.State == notification.StateFailedPerm
+}
+
+// attemptStatus returns the details and status to record for a single send attempt.
+func attemptStatus(res *notification.SendResult, err error) (string, Status) {
+	if err != nil {
+		return err.Error(), StatusFailed
+	}
+
+	switch res.State {
+	case notification.StateSending:
+		return res.Details, StatusQueuedRemotely
+	case notification.StateSent:
+		return res.Details, StatusSent
+	case notification.StateDelivered:
+		return res.Details, StatusDelivered
+	}
+
+	return res.Details, StatusFailed
+}
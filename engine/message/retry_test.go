@@ -0,0 +1,57 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := retryPolicy{MaxRetries: 5, BaseDelay: 10 * time.Second, MaxDelay: time.Minute}
+
+	assert.Equal(t, 10*time.Second, p.Delay(0))
+	assert.Equal(t, 20*time.Second, p.Delay(1))
+	assert.Equal(t, 40*time.Second, p.Delay(2))
+	assert.Equal(t, time.Minute, p.Delay(3))
+	assert.Equal(t, time.Minute, p.Delay(100))
+
+	assert.Equal(t, 15*time.Second, defaultRetryPolicy.Delay(2), "default policy should not back off")
+}
+
+func TestRetryPolicyFor(t *testing.T) {
+	var cfg config.Config
+
+	assert.Equal(t, defaultRetryPolicy, retryPolicyFor(cfg, notification.DestTypeSMS))
+
+	p := retryPolicyFor(cfg, notification.DestTypeChanWebhook)
+	assert.True(t, p.DeadLetter)
+	assert.Equal(t, 3, p.MaxRetries)
+
+	cfg.Webhook.RetryMaxAttempts = 1
+	cfg.Webhook.RetryBaseDelaySeconds = 30
+	cfg.Webhook.RetryMaxDelaySeconds = 10
+	p = retryPolicyFor(cfg, notification.DestTypeUserWebhook)
+	assert.Equal(t, 0, p.MaxRetries, "single attempt should never retry")
+	assert.Equal(t, 30*time.Second, p.MaxDelay, "max delay should be at least the base delay")
+}
+
+func TestRetryPolicy_CMFailedArgs(t *testing.T) {
+	p := retryPolicy{MaxRetries: 3}
+
+	assert.Equal(t, []interface{}{3}, p.cmFailedArgs(notification.StateFailedTemp), "temporary failures count on the last attempt")
+	assert.Equal(t, []interface{}{0}, p.cmFailedArgs(notification.StateFailedPerm), "permanent failures always count")
+}
+
+func TestIsCMFailure(t *testing.T) {
+	res := func(state notification.State, skipped bool) *notification.SendResult {
+		return &notification.SendResult{Status: notification.Status{State: state}, Skipped: skipped}
+	}
+
+	assert.True(t, isCMFailure(res(notification.StateFailedPerm, false)))
+	assert.True(t, isCMFailure(res(notification.StateFailedTemp, false)))
+	assert.False(t, isCMFailure(res(notification.StateSent, false)))
+	assert.False(t, isCMFailure(res(notification.StateFailedPerm, true)), "alert acked before send")
+}
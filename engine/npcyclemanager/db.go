@@ -9,6 +9,21 @@ import (
 	"github.com/target/goalert/util"
 )
 
+// ruleDelayExpr is the delay, in minutes, of the notification rule aliased as rule. Rules later
+// in the user's order than a stop-on-success rule are held until at least a minute after it, so
+// that its notification has a chance to be sent first, even if they have the same delay.
+const ruleDelayExpr = `greatest(
+	rule.delay_minutes,
+	(
+		select max(stop.delay_minutes) + 1
+		from user_notification_rules stop
+		where
+			stop.user_id = rule.user_id and
+			stop.stop_on_success and
+			stop.position < rule.position
+	)
+)`
+
 // DB manages user notification cycles in Postgres.
 //
 // It handles queueing of notifications.
@@ -26,7 +41,7 @@ func (db *DB) Name() string { return "Engine.NotificationCycleManager" }
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeNPCycle,
-		Version: 2,
+		Version: 11,
 	})
 	if err != nil {
 		return nil, err
@@ -44,6 +59,23 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 		// - notifications were sent for 0-minute at 1:00:15 (last tick = 1:00:15)
 		// - at 1:01:15 only notification rules with delays between 15 and 75 seconds would be processed/sent
 		// Note: since delays are in minutes, the above example would just send the 1 minute rules (60 seconds)
+		//
+		// Rules within their quiet hours are skipped; they are not sent later, but other rules for
+		// the same user are unaffected. Quiet hours are in the user's time zone, falling back to
+		// that of the service and then the default time zone ($1). Rules that allow high urgency
+		// are still sent for high-urgency alerts.
+		//
+		// Rules with an urgency are only used for alerts of that urgency. Critical and fatal alerts
+		// are high urgency, unless the user has an urgency window (in the same time zone as quiet
+		// hours) that did not contain the start of the cycle. Cycles started by a low-urgency
+		// escalation step are always low urgency.
+		// Rule delays, and escalation, are unaffected.
+		//
+		// Once a notification from a stop-on-success rule is sent or delivered for the cycle, rules
+		// later in the user's order are skipped. Rules earlier in the order are unaffected. Later
+		// rules are held until at least a minute after the stop-on-success rule (see ruleDelayExpr).
+		//
+		// Shadow cycles send training notifications; they are never logged as muted or missed.
 		queueMessages: p.P(`
 			with lock_cycles as (
 				select
@@ -51,7 +83,9 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					alert_id,
 					user_id,
 					started_at,
-					last_tick
+					last_tick,
+					shadow,
+					low_urgency
 				from notification_policy_cycles
 				where
 					last_tick isnull or
@@ -76,6 +110,24 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					from deleted del
 					where lock.id = del.id
 				)
+			), muted as (
+				select cycle.id, cycle.user_id, cycle.alert_id
+				from process_cycles cycle
+				join user_do_not_disturb dnd on
+					dnd.user_id = cycle.user_id and
+					(dnd.expires_at isnull or dnd.expires_at > now())
+				where
+					not cycle.shadow and (
+						cycle.last_tick isnull or
+						exists (
+							select null
+							from user_notification_rules rule
+							where
+								rule.user_id = cycle.user_id and
+								concat(` + ruleDelayExpr + `,' minutes')::interval > (cycle.last_tick - cycle.started_at) and
+								concat(` + ruleDelayExpr + `,' minutes')::interval <= (now() - cycle.started_at)
+						)
+					)
 			), inserted as (
 				insert into outgoing_messages (
 					message_type,
@@ -84,7 +136,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					cycle_id,
 					user_id,
 					service_id,
-					escalation_policy_id
+					escalation_policy_id,
+					shadow
 				)
 				select distinct
 					cast('alert_notification' as enum_outgoing_messages_type),
@@ -93,28 +146,90 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					cycle.id,
 					rule.user_id,
 					a.service_id,
-					svc.escalation_policy_id
+					svc.escalation_policy_id,
+					cycle.shadow
 				from process_cycles cycle
 				join alerts a on a.id = cycle.alert_id
 				join services svc on svc.id = a.service_id
+				join users u on u.id = cycle.user_id
+				left join user_urgency_windows win on win.user_id = cycle.user_id
+				cross join lateral (
+					select coalesce(u.time_zone, nullif(svc.time_zone, ''), nullif($1::text, ''), 'UTC') as time_zone
+				) loc
+				cross join lateral (
+					select (CASE
+						WHEN not cycle.low_urgency and a.severity >= 'critical' and coalesce(
+							CASE
+								WHEN win.start_time < win.end_time THEN
+									(cycle.started_at at time zone loc.time_zone)::time >= win.start_time and
+									(cycle.started_at at time zone loc.time_zone)::time < win.end_time
+								ELSE
+									(cycle.started_at at time zone loc.time_zone)::time >= win.start_time or
+									(cycle.started_at at time zone loc.time_zone)::time < win.end_time
+							END,
+							true
+						) THEN 'high'
+						ELSE 'low'
+					END)::enum_notification_rule_urgency as urgency
+				) urg
 				join user_notification_rules rule on
 					rule.user_id = cycle.user_id and
 					(
 						cycle.last_tick isnull or
-						concat(rule.delay_minutes,' minutes')::interval > (cycle.last_tick - cycle.started_at)
+						concat(` + ruleDelayExpr + `,' minutes')::interval > (cycle.last_tick - cycle.started_at)
+					) and
+					concat(` + ruleDelayExpr + `,' minutes')::interval <= (now() - cycle.started_at) and
+					(
+						(rule.quiet_hours_allow_high_urgency and urg.urgency = 'high') or
+						not coalesce(
+							CASE
+								WHEN rule.quiet_hours_start < rule.quiet_hours_end THEN
+									(now() at time zone loc.time_zone)::time >= rule.quiet_hours_start and
+									(now() at time zone loc.time_zone)::time < rule.quiet_hours_end
+								ELSE
+									(now() at time zone loc.time_zone)::time >= rule.quiet_hours_start or
+									(now() at time zone loc.time_zone)::time < rule.quiet_hours_end
+							END,
+							false
+						)
 					) and
-					concat(rule.delay_minutes,' minutes')::interval <= (now() - cycle.started_at)
+					(rule.urgency isnull or rule.urgency = urg.urgency) and
+					not exists (
+						select null
+						from user_notification_rules stop
+						join outgoing_messages msg on
+							msg.cycle_id = cycle.id and
+							msg.contact_method_id = stop.contact_method_id and
+							msg.last_status in ('sent', 'delivered')
+						where
+							stop.user_id = cycle.user_id and
+							stop.stop_on_success and
+							stop.position < rule.position
+					)
+				where not exists (
+					select null
+					from user_do_not_disturb dnd
+					where
+						dnd.user_id = cycle.user_id and
+						(dnd.expires_at isnull or dnd.expires_at > now())
+				)
 				returning cycle_id
 			), no_first_notif_sent as (
 				select user_id, alert_id
 				from process_cycles
-				where last_tick isnull and id not in (select cycle_id from inserted)
+				where
+					last_tick isnull and
+					not shadow and
+					id not in (select cycle_id from inserted) and
+					id not in (select id from muted)
 			), update as (
 				update notification_policy_cycles
 				set last_tick = greatest(last_tick, now())
 				where id in (select id from process_cycles)
 			)
-			select user_id, alert_id from no_first_notif_sent
+			select user_id, alert_id, false from no_first_notif_sent
+			union all
+			select user_id, alert_id, true from muted
 		`),
 	}, p.Err
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
@@ -35,7 +36,7 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	}
 	defer sqlutil.Rollback(ctx, "np cycle manager", tx)
 
-	rows, err := tx.StmtContext(ctx, db.queueMessages).QueryContext(ctx)
+	rows, err := tx.StmtContext(ctx, db.queueMessages).QueryContext(ctx, config.FromContext(ctx).General.DefaultTimeZone)
 	if err != nil {
 		return errors.Wrap(err, "queue outgoing messages")
 	}
@@ -44,12 +45,13 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	type record struct {
 		alertID int
 		userID  string
+		muted   bool
 	}
 
 	var data []record
 	for rows.Next() {
 		var rec record
-		err = rows.Scan(&rec.userID, &rec.alertID)
+		err = rows.Scan(&rec.userID, &rec.alertID, &rec.muted)
 		if err != nil {
 			return errors.Wrap(err, "scan userID and alertID")
 		}
@@ -61,7 +63,12 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 			Type: permission.SourceTypeContactMethod,
 			// no ID available, since notification couldn't be sent
 		})
-		err = db.log.LogTx(logCtx, tx, rec.alertID, alertlog.TypeNoNotificationSent, nil)
+		var meta interface{}
+		if rec.muted {
+			// skipped notifications are logged so the alert history shows why the user wasn't paged
+			meta = &alertlog.NoNotificationMetaData{DoNotDisturb: true}
+		}
+		err = db.log.LogTx(logCtx, tx, rec.alertID, alertlog.TypeNoNotificationSent, meta)
 		if err != nil {
 			return errors.Wrap(err, "log no notifications sent")
 		}
@@ -16,4 +16,7 @@ const (
 	TypeCleanup      Type = "cleanup"
 	TypeMetrics      Type = "metrics"
 	TypeCompat       Type = "compat"
+	TypeIncident     Type = "incident"
+	TypeEnrichment   Type = "enrichment"
+	TypeBridge       Type = "conference_bridge"
 )
@@ -21,13 +21,25 @@ type rotState struct {
 	Version int
 }
 
+// weightAt returns the weight of the participant at pos, or 1 if there is none.
+func weightAt(weights []int, pos int) int {
+	if pos < 0 || pos >= len(weights) {
+		return 1
+	}
+	return weights[pos]
+}
+
 // calcAdvance will calculate rotation advancement if it is required. If not, nil is returned
-func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, partCount int) *advance {
+//
+// The weights are those of each participant, in position order. A participant remains active
+// for as many consecutive shifts as their weight before the rotation advances.
+func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, weights []int) *advance {
 	var mustUpdate bool
 	origPos := state.Position
+	partCount := len(weights)
 
 	// get next shift start time
-	newStart := rot.EndTime(state.ShiftStart)
+	newStart := rot.TurnEndTime(state.ShiftStart, weightAt(weights, state.Position))
 	if state.Version == 1 {
 		newStart = calcVersion1EndTime(rot, state.ShiftStart)
 		mustUpdate = true
@@ -68,7 +80,7 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 		}
 
 		state.Position = (state.Position + 1) % partCount
-		end := rot.EndTime(state.ShiftStart)
+		end := rot.TurnEndTime(state.ShiftStart, weightAt(weights, state.Position))
 		if end.After(t) {
 			break
 		}
@@ -0,0 +1,66 @@
+package rotationmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/schedule/rotation"
+)
+
+func TestCalcAdvance_Weighted(t *testing.T) {
+	rot := &rotation.Rotation{
+		ID:          "rot",
+		Type:        rotation.TypeDaily,
+		ShiftLength: 1,
+		Start:       time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+	weights := []int{1, 2, 3}
+
+	state := rotState{Version: 2}
+	state.ShiftStart = rot.Start
+
+	// simulate the engine running every hour for 600 days
+	hours := make([]int, len(weights))
+	now := rot.Start
+	for i := 0; i < 600*24; i++ {
+		adv := calcAdvance(context.Background(), now, rot, state, weights)
+		if adv != nil {
+			state.Position = adv.newPosition
+			state.ShiftStart = now
+		}
+		hours[state.Position]++
+		now = now.Add(time.Hour)
+	}
+
+	// a full cycle is 6 days, so each participant gets weight/6 of the time
+	assert.Equal(t, []int{100 * 24, 200 * 24, 300 * 24}, hours)
+}
+
+func TestCalcAdvance_WeightedCatchUp(t *testing.T) {
+	rot := &rotation.Rotation{
+		ID:          "rot",
+		Type:        rotation.TypeDaily,
+		ShiftLength: 1,
+		Start:       time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	state := rotState{Version: 2}
+	state.ShiftStart = rot.Start
+
+	// still within the first participant's 2-day turn
+	adv := calcAdvance(context.Background(), rot.Start.Add(36*time.Hour), rot, state, []int{2, 1})
+	assert.Nil(t, adv)
+
+	// day 2 is the second participant, day 3 starts the first participant's next turn
+	adv = calcAdvance(context.Background(), rot.Start.Add(72*time.Hour+time.Minute), rot, state, []int{2, 1})
+	if assert.NotNil(t, adv) {
+		assert.Equal(t, 0, adv.newPosition)
+	}
+
+	adv = calcAdvance(context.Background(), rot.Start.Add(48*time.Hour+time.Minute), rot, state, []int{2, 1})
+	if assert.NotNil(t, adv) {
+		assert.Equal(t, 1, adv.newPosition)
+	}
+}
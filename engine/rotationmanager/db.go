@@ -26,7 +26,7 @@ func (db *DB) Name() string { return "Engine.RotationManager" }
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeRotation,
-		Version: 2,
+		Version: 3,
 	})
 	if err != nil {
 		return nil, err
@@ -55,7 +55,12 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				rot.time_zone,
 				state.shift_start,
 				state."position",
-				rot.participant_count,
+				array(
+					select part.weight
+					from rotation_participants part
+					where part.rotation_id = rot.id
+					order by part.position
+				),
 				state.version
 			from rotations rot
 			join rotation_state state on state.rotation_id = rot.id
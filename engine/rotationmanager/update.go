@@ -96,7 +96,7 @@ func (db *DB) calcAdvances(ctx context.Context, tx *sql.Tx, all bool, rotID *str
 
 	var rot rotation.Rotation
 	var state rotState
-	var partCount int
+	var weights sqlutil.IntArray
 	var tzName string
 	var adv *advance
 	var loc *time.Location
@@ -111,7 +111,7 @@ func (db *DB) calcAdvances(ctx context.Context, tx *sql.Tx, all bool, rotID *str
 			&tzName,
 			&state.ShiftStart,
 			&state.Position,
-			&partCount,
+			&weights,
 			&state.Version,
 		)
 		if err != nil {
@@ -122,7 +122,7 @@ func (db *DB) calcAdvances(ctx context.Context, tx *sql.Tx, all bool, rotID *str
 			return nil, errors.Wrap(err, "load timezone")
 		}
 		rot.Start = rot.Start.In(loc)
-		adv = calcAdvance(ctx, t, &rot, state, partCount)
+		adv = calcAdvance(ctx, t, &rot, state, weights)
 		if adv != nil {
 			needsAdvance = append(needsAdvance, *adv)
 			if len(needsAdvance) == 150 {
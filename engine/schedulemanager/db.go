@@ -21,9 +21,16 @@ type DB struct {
 	data        *sql.Stmt
 	updateData  *sql.Stmt
 
+	setOnCallPriority *sql.Stmt
+
 	schedTZ *sql.Stmt
 
 	scheduleOnCallNotification *sql.Stmt
+
+	handoffRotations    *sql.Stmt
+	handoffParticipants *sql.Stmt
+	handoffNotice       *sql.Stmt
+	resolveHandoff      *sql.Stmt
 }
 
 // Name returns the name of the module.
@@ -33,7 +40,7 @@ func (db *DB) Name() string { return "Engine.ScheduleManager" }
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeSchedule,
-		Version: 3,
+		Version: 6,
 	})
 	if err != nil {
 		return nil, err
@@ -47,9 +54,11 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			select
 				add_user_id,
 				remove_user_id,
-				tgt_schedule_id
+				tgt_schedule_id,
+				start_time,
+				end_time
 			from user_overrides
-			where now() between start_time and end_time
+			where end_time >= now() and start_time <= $1
 		`),
 		data:       p.P(`select schedule_id, data from schedule_data where data notnull for update`),
 		updateData: p.P(`update schedule_data set data = $2 where schedule_id = $1`),
@@ -68,7 +77,9 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
-				coalesce(rule.tgt_user_id, part.user_id)
+				coalesce(rule.tgt_user_id, part.user_id),
+				rule.tgt_rotation_id,
+				rule.priority
 			from schedule_rules rule
 			left join rotation_state rState on rState.rotation_id = rule.tgt_rotation_id
 			left join rotation_participants part on part.id = rState.rotation_participant_id
@@ -76,14 +87,22 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				coalesce(rule.tgt_user_id, part.user_id) notnull
 		`),
 		getOnCall: p.P(`
-			select schedule_id, user_id
+			select schedule_id, user_id, priority
 			from schedule_on_call_users
 			where
 				end_time isnull
 		`),
 		startOnCall: p.P(`
-			insert into schedule_on_call_users (schedule_id, start_time, user_id)
-			select $1, now(), $2 from users where id = $2
+			insert into schedule_on_call_users (schedule_id, start_time, user_id, priority)
+			select $1, now(), $2, $3 from users where id = $2
+		`),
+		setOnCallPriority: p.P(`
+			update schedule_on_call_users
+			set priority = $3
+			where
+				schedule_id = $1 and
+				user_id = $2 and
+				end_time isnull
 		`),
 		endOnCall: p.P(`
 			update schedule_on_call_users
@@ -96,6 +115,49 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		scheduleOnCallNotification: p.P(`
 			insert into outgoing_messages (id, message_type, channel_id, schedule_id) values ($1, 'schedule_on_call_notification', $2, $3)
 		`),
+		handoffRotations: p.P(`
+			select distinct
+				rot.id,
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.time_zone,
+				state.position,
+				state.shift_start
+			from schedule_rules rule
+			join rotations rot on rot.id = rule.tgt_rotation_id
+			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
+			where rule.schedule_id = any($1)
+		`),
+		handoffParticipants: p.P(`
+			select rotation_id, user_id
+			from rotation_participants
+			where rotation_id = any($1)
+			order by rotation_id, position
+		`),
+		handoffNotice: p.P(`
+			with notice as (
+				insert into schedule_handoff_notices (id, schedule_id, user_id, on_call, shift_time, resolved)
+				select $1, $2, $3, $4, $5, not $4
+				from users where id = $3
+				on conflict do nothing
+				returning id, schedule_id, user_id
+			)
+			insert into outgoing_messages (message_type, contact_method_id, user_id, schedule_id, schedule_handoff_notice_id)
+			select distinct 'schedule_handoff_notification'::enum_outgoing_messages_type, cm.id, notice.user_id, notice.schedule_id, notice.id
+			from notice
+			join user_notification_rules nr on nr.user_id = notice.user_id and nr.delay_minutes = 0
+			join user_contact_methods cm on cm.id = nr.contact_method_id and not cm.disabled
+		`),
+		resolveHandoff: p.P(`
+			update schedule_handoff_notices
+			set resolved = true
+			where
+				schedule_id = $1 and
+				on_call and
+				not resolved and
+				not user_id = any($2)
+		`),
 		currentTime: p.P(`select now()`),
 	}, p.Err
 }
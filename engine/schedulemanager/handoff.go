@@ -0,0 +1,125 @@
+package schedulemanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// updateHandoffs records handoff notices, and queues messages for them, for schedules with
+// handoff notifications enabled.
+//
+// Users going off call are notified once, when their shift ends. Users about to go on call are
+// notified once the lead time reaches the start of their shift; the notice stays pending until
+// they are no longer expected to go on call, so later cycles within the window will not repeat it.
+func (db *DB) updateHandoffs(ctx context.Context, tx *sql.Tx, calc *onCallCalc, oldOnCall, newOnCall map[onCall]bool) error {
+	var schedIDs []string
+	leadTimes := make(map[time.Duration][]string)
+	for id, data := range calc.data {
+		if data.V1.HandoffNotification == nil {
+			continue
+		}
+		schedIDs = append(schedIDs, id)
+		lead := data.V1.HandoffNotification.LeadTime()
+		leadTimes[lead] = append(leadTimes[lead], id)
+	}
+	if len(schedIDs) == 0 {
+		return nil
+	}
+	sort.Strings(schedIDs)
+
+	notice := tx.StmtContext(ctx, db.handoffNotice)
+	for oc := range oldOnCall {
+		if newOnCall[oc] {
+			continue
+		}
+		data := calc.data[oc.ScheduleID]
+		if data == nil || data.V1.HandoffNotification == nil {
+			continue
+		}
+
+		_, err := notice.ExecContext(ctx, uuid.New(), oc.ScheduleID, oc.UserID, false, calc.now)
+		if err != nil {
+			return fmt.Errorf("record off-call notice: %w", err)
+		}
+	}
+
+	err := db.loadHandoffRotations(ctx, tx, calc, schedIDs)
+	if err != nil {
+		return err
+	}
+
+	resolve := tx.StmtContext(ctx, db.resolveHandoff)
+	for lead, ids := range leadTimes {
+		shiftTime := calc.now.Add(lead)
+		future := calc.OnCallAt(shiftTime)
+		for _, schedID := range ids {
+			userIDs := upcomingHandoffs(schedID, newOnCall, future)
+
+			_, err = resolve.ExecContext(ctx, schedID, sqlutil.UUIDArray(userIDs))
+			if err != nil {
+				return fmt.Errorf("resolve handoff notices: %w", err)
+			}
+
+			for _, userID := range userIDs {
+				_, err = notice.ExecContext(ctx, uuid.New(), schedID, userID, true, shiftTime)
+				if err != nil {
+					return fmt.Errorf("record on-call notice: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadHandoffRotations loads rotation information for the provided schedules, so that future
+// participants of rotation rules can be calculated.
+func (db *DB) loadHandoffRotations(ctx context.Context, tx *sql.Tx, calc *onCallCalc, schedIDs []string) error {
+	rows, err := tx.StmtContext(ctx, db.handoffRotations).QueryContext(ctx, sqlutil.UUIDArray(schedIDs))
+	if err != nil {
+		return fmt.Errorf("lookup schedule rotations: %w", err)
+	}
+	defer rows.Close()
+
+	calc.rots = make(map[string]*oncall.ResolvedRotation)
+	var rotIDs []string
+	for rows.Next() {
+		var rot oncall.ResolvedRotation
+		var rotTZ string
+		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
+		if err != nil {
+			return fmt.Errorf("scan rotation info: %w", err)
+		}
+		loc, err := util.LoadLocation(rotTZ)
+		if err != nil {
+			return fmt.Errorf("load time zone info '%s' for rotation '%s': %w", rotTZ, rot.ID, err)
+		}
+		rot.Start = rot.Start.In(loc)
+		calc.rots[rot.ID] = &rot
+		rotIDs = append(rotIDs, rot.ID)
+	}
+
+	rows, err = tx.StmtContext(ctx, db.handoffParticipants).QueryContext(ctx, sqlutil.UUIDArray(rotIDs))
+	if err != nil {
+		return fmt.Errorf("lookup rotation participants: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rotID, userID string
+		err = rows.Scan(&rotID, &userID)
+		if err != nil {
+			return fmt.Errorf("scan rotation participant info: %w", err)
+		}
+		calc.rots[rotID].Users = append(calc.rots[rotID].Users, userID)
+	}
+
+	return nil
+}
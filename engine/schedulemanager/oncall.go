@@ -0,0 +1,147 @@
+package schedulemanager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rule"
+)
+
+type onCall struct {
+	UserID     string
+	ScheduleID string
+}
+
+type userRule struct {
+	rule.Rule
+	UserID string
+
+	// RotationID is set if the rule targets a rotation, UserID will be the active participant.
+	RotationID string
+}
+
+// onCallCalc calculates on-call users for all schedules.
+type onCallCalc struct {
+	now       time.Time
+	data      map[string]*schedule.Data
+	rules     []userRule
+	overrides []override.UserOverride
+	tz        map[string]*time.Location
+
+	// rots is used to find the participant of a rotation rule at times other than now.
+	rots map[string]*oncall.ResolvedRotation
+}
+
+func (c *onCallCalc) ruleUser(r userRule, t time.Time) string {
+	if r.RotationID == "" || t.Equal(c.now) {
+		return r.UserID
+	}
+	rot, ok := c.rots[r.RotationID]
+	if !ok {
+		return r.UserID
+	}
+
+	return rot.UserID(t)
+}
+
+// OnCallAt returns the set of on-call users for every schedule at the given time.
+func (c *onCallCalc) OnCallAt(t time.Time) map[onCall]bool {
+	prio := c.PrioritiesAt(t)
+	result := make(map[onCall]bool, len(prio))
+	for oc := range prio {
+		result[oc] = true
+	}
+
+	return result
+}
+
+// PrioritiesAt returns the on-call users for every schedule at the given time, mapped to
+// their priority. Users on call via multiple rules have the highest priority (lowest value)
+// of those rules, while users added by a temporary schedule, fixed shift, or override have priority 0.
+func (c *onCallCalc) PrioritiesAt(t time.Time) map[onCall]int {
+	result := make(map[onCall]int, len(c.rules))
+	set := func(oc onCall, priority int) {
+		if p, ok := result[oc]; ok && p <= priority {
+			return
+		}
+		result[oc] = priority
+	}
+
+	tempSched := make(map[string]struct{})
+	for id, data := range c.data {
+		ok, users := data.TempOnCall(t)
+		if !ok {
+			// fixed shifts are on call alongside rules, unless a temp schedule is active
+			for _, uid := range data.FixedOnCall(t) {
+				set(onCall{ScheduleID: id, UserID: uid}, 0)
+			}
+			continue
+		}
+
+		for _, uid := range users {
+			set(onCall{ScheduleID: id, UserID: uid}, 0)
+		}
+		tempSched[id] = struct{}{}
+	}
+
+	for _, r := range c.rules {
+		if _, ok := tempSched[r.ScheduleID]; ok {
+			// temp schedule active for this ID, skip
+			continue
+		}
+		if !r.IsActive(t.In(c.tz[r.ScheduleID])) {
+			continue
+		}
+		userID := c.ruleUser(r, t)
+		if userID == "" {
+			continue
+		}
+		set(onCall{ScheduleID: r.ScheduleID, UserID: userID}, r.Priority)
+	}
+
+	for _, o := range c.overrides {
+		if t.Before(o.Start) || t.After(o.End) {
+			continue
+		}
+		if _, ok := tempSched[o.Target.TargetID()]; ok {
+			// temp schedule active for this ID, skip
+			continue
+		}
+		if o.AddUserID != "" && o.RemoveUserID == "" {
+			// ADD override
+			set(onCall{ScheduleID: o.Target.TargetID(), UserID: o.AddUserID}, 0)
+			continue
+		}
+		if o.AddUserID == "" && o.RemoveUserID != "" {
+			// REMOVE override
+			delete(result, onCall{ScheduleID: o.Target.TargetID(), UserID: o.RemoveUserID})
+			continue
+		}
+
+		removed := onCall{ScheduleID: o.Target.TargetID(), UserID: o.RemoveUserID}
+		if p, ok := result[removed]; ok {
+			// REPLACE override, the new user takes the place (and priority) of the old one
+			delete(result, removed)
+			set(onCall{ScheduleID: o.Target.TargetID(), UserID: o.AddUserID}, p)
+		}
+	}
+
+	return result
+}
+
+// upcomingHandoffs returns the users of the schedule that are on call in future, but not in current.
+func upcomingHandoffs(scheduleID string, current, future map[onCall]bool) []string {
+	var userIDs []string
+	for oc := range future {
+		if oc.ScheduleID != scheduleID || current[oc] {
+			continue
+		}
+		userIDs = append(userIDs, oc.UserID)
+	}
+	sort.Strings(userIDs)
+
+	return userIDs
+}
@@ -0,0 +1,116 @@
+package schedulemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestOnCallCalc_Handoff(t *testing.T) {
+	now := time.Date(2023, 10, 9, 8, 40, 0, 0, time.UTC)
+	start := time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC)
+
+	calc := &onCallCalc{
+		now: now,
+		rules: []userRule{{
+			Rule:       rule.Rule{ScheduleID: "sched", WeekdayFilter: timeutil.EveryDay()},
+			UserID:     "alice",
+			RotationID: "rot",
+		}},
+		overrides: []override.UserOverride{{
+			AddUserID: "carol",
+			Start:     now.Add(10 * time.Minute),
+			End:       now.Add(2 * time.Hour),
+			Target:    assignment.ScheduleTarget("sched"),
+		}},
+		tz: map[string]*time.Location{"sched": time.UTC},
+		rots: map[string]*oncall.ResolvedRotation{
+			"rot": {
+				Rotation:     rotation.Rotation{ID: "rot", Type: rotation.TypeHourly, ShiftLength: 1, Start: start},
+				CurrentIndex: 0,
+				CurrentStart: now.Truncate(time.Hour),
+				Users:        []string{"alice", "bob"},
+			},
+		},
+	}
+
+	current := calc.OnCallAt(now)
+	assert.Equal(t, map[onCall]bool{{ScheduleID: "sched", UserID: "alice"}: true}, current)
+
+	// rotation hands off at 9:00, and the override starts at 8:50
+	future := calc.OnCallAt(now.Add(30 * time.Minute))
+	assert.Equal(t, []string{"bob", "carol"}, upcomingHandoffs("sched", current, future))
+
+	future = calc.OnCallAt(now.Add(5 * time.Minute))
+	assert.Empty(t, upcomingHandoffs("sched", current, future))
+}
+
+func TestOnCallCalc_PrioritiesAt(t *testing.T) {
+	now := time.Date(2023, 10, 9, 8, 40, 0, 0, time.UTC)
+
+	calc := &onCallCalc{
+		now: now,
+		rules: []userRule{
+			{Rule: rule.Rule{ScheduleID: "sched", WeekdayFilter: timeutil.EveryDay()}, UserID: "alice"},
+			{Rule: rule.Rule{ScheduleID: "sched", WeekdayFilter: timeutil.EveryDay(), Priority: 1}, UserID: "bob"},
+			{Rule: rule.Rule{ScheduleID: "sched", WeekdayFilter: timeutil.EveryDay(), Priority: 2}, UserID: "alice"},
+		},
+		overrides: []override.UserOverride{{
+			AddUserID:    "carol",
+			RemoveUserID: "bob",
+			Start:        now.Add(-time.Hour),
+			End:          now.Add(time.Hour),
+			Target:       assignment.ScheduleTarget("sched"),
+		}},
+		tz: map[string]*time.Location{"sched": time.UTC},
+	}
+
+	// alice keeps her highest priority, and carol replaces bob as the backup
+	assert.Equal(t, map[onCall]int{
+		{ScheduleID: "sched", UserID: "alice"}: 0,
+		{ScheduleID: "sched", UserID: "carol"}: 1,
+	}, calc.PrioritiesAt(now))
+}
+
+func TestOnCallCalc_FixedShifts(t *testing.T) {
+	now := time.Date(2023, 10, 9, 8, 40, 0, 0, time.UTC)
+
+	var data schedule.Data
+	data.V1.FixedShifts = []schedule.FixedShift{
+		{Start: now.Add(-time.Hour), End: now.Add(time.Hour), UserID: "bob"},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), UserID: "carol"},
+	}
+
+	calc := &onCallCalc{
+		now:  now,
+		data: map[string]*schedule.Data{"sched": &data},
+		rules: []userRule{
+			{Rule: rule.Rule{ScheduleID: "sched", WeekdayFilter: timeutil.EveryDay(), Priority: 1}, UserID: "alice"},
+		},
+		tz: map[string]*time.Location{"sched": time.UTC},
+	}
+
+	// fixed shifts are on call alongside rules
+	assert.Equal(t, map[onCall]int{
+		{ScheduleID: "sched", UserID: "alice"}: 1,
+		{ScheduleID: "sched", UserID: "bob"}:   0,
+	}, calc.PrioritiesAt(now))
+
+	// an active temporary schedule replaces both
+	data.V1.TemporarySchedules = []schedule.TemporarySchedule{{
+		Start:  now.Add(-time.Hour),
+		End:    now.Add(time.Hour),
+		Shifts: []schedule.FixedShift{{Start: now.Add(-time.Hour), End: now.Add(time.Hour), UserID: "dave"}},
+	}}
+	assert.Equal(t, map[onCall]int{
+		{ScheduleID: "sched", UserID: "dave"}: 0,
+	}, calc.PrioritiesAt(now))
+}
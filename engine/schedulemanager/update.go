@@ -14,7 +14,6 @@ import (
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
-	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/jsonutil"
 	"github.com/target/goalert/util/log"
@@ -71,7 +70,8 @@ func (db *DB) update(ctx context.Context) error {
 		scheduleData[id] = &sData
 	}
 
-	rows, err = tx.Stmt(db.overrides).QueryContext(ctx)
+	// include overrides that start within the max lead time for handoff notifications
+	rows, err = tx.Stmt(db.overrides).QueryContext(ctx, now.Add(schedule.MaxHandoffLeadTimeMinutes*time.Minute))
 	if err != nil {
 		return errors.Wrap(err, "get active overrides")
 	}
@@ -82,7 +82,7 @@ func (db *DB) update(ctx context.Context) error {
 		var o override.UserOverride
 		var schedTgt sql.NullString
 		var add, rem sql.NullString
-		err = rows.Scan(&add, &rem, &schedTgt)
+		err = rows.Scan(&add, &rem, &schedTgt, &o.Start, &o.End)
 		if err != nil {
 			return errors.Wrap(err, "scan override")
 		}
@@ -101,24 +101,23 @@ func (db *DB) update(ctx context.Context) error {
 	}
 	defer rows.Close()
 
-	type userRule struct {
-		rule.Rule
-		UserID string
-	}
-
 	var rules []userRule
 	for rows.Next() {
 		var r userRule
+		var rotID sql.NullString
 		err = rows.Scan(
 			&r.ScheduleID,
 			&r.WeekdayFilter,
 			&r.Start,
 			&r.End,
 			&r.UserID,
+			&rotID,
+			&r.Priority,
 		)
 		if err != nil {
 			return errors.Wrap(err, "scan rule")
 		}
+		r.RotationID = rotID.String
 
 		rules = append(rules, r)
 	}
@@ -147,80 +146,52 @@ func (db *DB) update(ctx context.Context) error {
 	}
 	defer rows.Close()
 
-	type onCall struct {
-		UserID     string
-		ScheduleID string
-	}
 	oldOnCall := make(map[onCall]bool)
+	oldPriority := make(map[onCall]int)
 	var oc onCall
 	for rows.Next() {
-		err = rows.Scan(&oc.ScheduleID, &oc.UserID)
+		var priority int
+		err = rows.Scan(&oc.ScheduleID, &oc.UserID, &priority)
 		if err != nil {
 			return errors.Wrap(err, "scan on call user")
 		}
 		oldOnCall[oc] = true
+		oldPriority[oc] = priority
 	}
 
 	// Calculate new state
-	newOnCall := make(map[onCall]bool, len(rules))
-
-	tempSched := make(map[string]struct{})
-	for id, data := range scheduleData {
-		ok, users := data.TempOnCall(now)
-		if !ok {
-			continue
-		}
-
-		for _, uid := range users {
-			newOnCall[onCall{ScheduleID: id, UserID: uid}] = true
-		}
-		tempSched[id] = struct{}{}
+	calc := &onCallCalc{
+		now:       now,
+		data:      scheduleData,
+		rules:     rules,
+		overrides: overrides,
+		tz:        tz,
 	}
-
-	for _, r := range rules {
-		if _, ok := tempSched[r.ScheduleID]; ok {
-			// temp schedule active for this ID, skip
-			continue
-		}
-		if r.IsActive(now.In(tz[r.ScheduleID])) {
-			newOnCall[onCall{ScheduleID: r.ScheduleID, UserID: r.UserID}] = true
-		}
-	}
-
-	for _, o := range overrides {
-		if _, ok := tempSched[o.Target.TargetID()]; ok {
-			// temp schedule active for this ID, skip
-			continue
-		}
-		if o.AddUserID != "" && o.RemoveUserID == "" {
-			// ADD override
-			newOnCall[onCall{ScheduleID: o.Target.TargetID(), UserID: o.AddUserID}] = true
-			continue
-		}
-		if o.AddUserID == "" && o.RemoveUserID != "" {
-			// REMOVE override
-			delete(newOnCall, onCall{ScheduleID: o.Target.TargetID(), UserID: o.RemoveUserID})
-			continue
-		}
-
-		if newOnCall[onCall{ScheduleID: o.Target.TargetID(), UserID: o.RemoveUserID}] {
-			// REPLACE override
-			delete(newOnCall, onCall{ScheduleID: o.Target.TargetID(), UserID: o.RemoveUserID})
-			newOnCall[onCall{ScheduleID: o.Target.TargetID(), UserID: o.AddUserID}] = true
-		}
+	newPriority := calc.PrioritiesAt(now)
+	newOnCall := make(map[onCall]bool, len(newPriority))
+	for oc := range newPriority {
+		newOnCall[oc] = true
 	}
 
 	start := tx.Stmt(db.startOnCall)
+	setPriority := tx.Stmt(db.setOnCallPriority)
 
 	changedSchedules := make(map[string]struct{})
-	for oc := range newOnCall {
+	for oc, priority := range newPriority {
 		// not on call in DB, but are now
 		if !oldOnCall[oc] {
 			changedSchedules[oc.ScheduleID] = struct{}{}
-			_, err = start.ExecContext(ctx, oc.ScheduleID, oc.UserID)
+			_, err = start.ExecContext(ctx, oc.ScheduleID, oc.UserID, priority)
 			if err != nil && !isScheduleDeleted(err) {
 				return errors.Wrap(err, "record shift start")
 			}
+			continue
+		}
+		if oldPriority[oc] != priority {
+			_, err = setPriority.ExecContext(ctx, oc.ScheduleID, oc.UserID, priority)
+			if err != nil {
+				return errors.Wrap(err, "update on-call priority")
+			}
 		}
 	}
 	end := tx.Stmt(db.endOnCall)
@@ -235,6 +206,11 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 
+	err = db.updateHandoffs(ctx, tx, calc, oldOnCall, newOnCall)
+	if err != nil {
+		return errors.Wrap(err, "update handoff notifications")
+	}
+
 	// Notify changed schedules
 	needsOnCallNotification := make(map[string][]uuid.UUID)
 	for schedID := range changedSchedules {
@@ -4,15 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
 )
 
+// ShadowPrefix is prepended to the summary of alert notifications sent to rotation shadows.
+const ShadowPrefix = "[Training] "
+
+// WatcherPrefix is prepended to the summary of alert notifications sent to service watchers.
+const WatcherPrefix = "[Watching] "
+
+// skipMessage returns the result for a message that won't be passed to a provider, so that
+// it isn't counted as a contact method failure.
+func skipMessage(msg *message.Message, details string) *notification.SendResult {
+	return &notification.SendResult{
+		ID:      msg.ID,
+		Status:  notification.Status{Details: details, State: notification.StateFailedPerm},
+		Skipped: true,
+	}
+}
+
 func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notification.SendResult, error) {
 	ctx = log.WithField(ctx, "CallbackID", msg.ID)
 
@@ -29,6 +52,18 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		})
 	}
 
+	if msg.Dest.Type.IsUserCM() && !msg.Replay && msg.Type != notification.MessageTypeTest && msg.Type != notification.MessageTypeVerification {
+		// Notifications may already be queued when a user enables do-not-disturb, so
+		// check again before sending. Tests, verification codes, and replays are always sent.
+		dnd, err := p.cfg.UserStore.FindDoNotDisturb(ctx, msg.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup do-not-disturb")
+		}
+		if dnd != nil {
+			return skipMessage(msg, "user has do-not-disturb enabled"), nil
+		}
+	}
+
 	var notifMsg notification.Message
 	var isFirstAlertMessage bool
 	switch msg.Type {
@@ -39,13 +74,7 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		}
 		if count == 0 {
 			// already acked/closed, don't send bundled notification
-			return &notification.SendResult{
-				ID: msg.ID,
-				Status: notification.Status{
-					Details: "alerts acked/closed before message sent",
-					State:   notification.StateFailedPerm,
-				},
-			}, nil
+			return skipMessage(msg, "alerts acked/closed before message sent"), nil
 		}
 		notifMsg = notification.AlertBundle{
 			Dest:        msg.Dest,
@@ -55,13 +84,31 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			Count:       count,
 		}
 	case notification.MessageTypeAlert:
-		name, _, err := p.a.ServiceInfo(ctx, msg.ServiceID)
+		a, err := p.a.FindOne(ctx, msg.AlertID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup alert")
+		}
+		if !msg.Replay && a.Status != alert.StatusTriggered {
+			// The alert may be acknowledged after a message is queued but before it is
+			// sent, so don't notify anyone else about it; the first responder wins. Replays
+			// are sent regardless, as they are usually requested after the fact.
+			return skipMessage(msg, "alert acked/closed before message sent"), nil
+		}
+		svc, err := p.cfg.ServiceStore.FindOne(ctx, msg.ServiceID)
 		if err != nil {
 			return nil, errors.Wrap(err, "lookup service info")
 		}
-		a, err := p.a.FindOne(ctx, msg.AlertID)
+		summary, err := service.RenderNotificationTemplate(svc.NotificationTemplate, service.TemplateData{
+			AlertID:     a.ID,
+			Summary:     a.Summary,
+			Details:     a.Details,
+			ServiceID:   a.ServiceID,
+			ServiceName: svc.Name,
+		})
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup alert")
+			// templates are validated when saved, but never drop a notification over one
+			log.Log(ctx, errors.Wrap(err, "render notification template"))
+			summary = a.Summary
 		}
 		stat, err := p.cfg.NotificationStore.OriginalMessageStatus(ctx, msg.AlertID, msg.Dest)
 		if err != nil {
@@ -71,18 +118,32 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			// set to nil if it's the current message
 			stat = nil
 		}
+		if msg.Shadow {
+			summary = ShadowPrefix + summary
+		}
+		if msg.Watcher {
+			summary = WatcherPrefix + summary
+		}
+		loc, err := p.recipientLocation(ctx, msg, svc)
+		if err != nil {
+			return nil, err
+		}
 		notifMsg = notification.Alert{
 			Dest:        msg.Dest,
 			AlertID:     msg.AlertID,
-			Summary:     a.Summary,
+			Summary:     validate.SanitizeText(summary, alert.MaxSummaryLength),
 			Details:     a.Details,
 			CallbackID:  msg.ID,
 			ServiceID:   a.ServiceID,
-			ServiceName: name,
+			ServiceName: svc.Name,
+			Meta:        a.Meta,
+			RunbookURL:  a.Meta.RunbookURL(svc.RunbookURL),
+			CreatedAt:   a.CreatedAt.In(loc),
 
 			OriginalStatus: stat,
 		}
-		isFirstAlertMessage = stat == nil
+		// shadows and watchers aren't subscribed to status updates, since those can be responded to
+		isFirstAlertMessage = stat == nil && !msg.Shadow && !msg.Watcher
 	case notification.MessageTypeAlertStatus:
 		e, err := p.cfg.AlertLogStore.FindOne(ctx, msg.AlertLogID)
 		if err != nil {
@@ -92,6 +153,10 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		if err != nil {
 			return nil, fmt.Errorf("lookup original alert: %w", err)
 		}
+		svc, err := p.cfg.ServiceStore.FindOne(ctx, a.ServiceID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup service info")
+		}
 		stat, err := p.cfg.NotificationStore.OriginalMessageStatus(ctx, msg.AlertID, msg.Dest)
 		if err != nil {
 			return nil, fmt.Errorf("lookup original message: %w", err)
@@ -117,6 +182,7 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			LogEntry:       e.String(ctx),
 			Summary:        a.Summary,
 			Details:        a.Details,
+			RunbookURL:     a.Meta.RunbookURL(svc.RunbookURL),
 			NewAlertState:  status,
 			OriginalStatus: *stat,
 		}
@@ -162,9 +228,68 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ScheduleID:   msg.ScheduleID,
 			Users:        onCallUsers,
 		}
+	case notification.MessageTypeScheduleHandoff:
+		noticeID, err := uuid.Parse(msg.HandoffNoticeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse handoff notice id")
+		}
+		notice, err := p.cfg.ScheduleStore.FindOneHandoffNotice(ctx, noticeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup handoff notice")
+		}
+		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule by id")
+		}
+
+		notifMsg = notification.ScheduleHandoff{
+			Dest:         msg.Dest,
+			CallbackID:   msg.ID,
+			ScheduleID:   msg.ScheduleID,
+			ScheduleName: sched.Name,
+			ScheduleURL:  p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + msg.ScheduleID),
+			OnCall:       notice.OnCall,
+			ShiftTime:    notice.ShiftTime.In(sched.TimeZone),
+		}
+	case notification.MessageTypeAlertBridgeInvite:
+		a, err := p.a.FindOne(ctx, msg.AlertID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup alert")
+		}
+		if a.Status == alert.StatusClosed {
+			return skipMessage(msg, "alert closed before message sent"), nil
+		}
+		svc, err := p.cfg.ServiceStore.FindOne(ctx, msg.ServiceID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup service info")
+		}
+
+		notifMsg = notification.AlertBridgeInvite{
+			Dest:        msg.Dest,
+			CallbackID:  msg.ID,
+			AlertID:     a.ID,
+			Summary:     a.Summary,
+			ServiceName: svc.Name,
+		}
+	case notification.MessageTypeContactMethodDisabled:
+		cm, err := p.cfg.ContactMethodStore.FindOne(ctx, msg.DisabledCMID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup disabled contact method")
+		}
+
+		notifMsg = notification.ContactMethodDisabled{
+			Dest:              msg.Dest,
+			CallbackID:        msg.ID,
+			ContactMethodID:   cm.ID,
+			ContactMethodName: cm.Name,
+			ContactMethodType: string(cm.Type),
+			Failures:          cm.ConsecutiveFailures,
+			ReenableMinutes:   p.cfg.ConfigSource.Config().General.ContactMethodDisableMinutes,
+			ProfileURL:        p.cfg.ConfigSource.Config().CallbackURL("/profile"),
+		}
 	default:
 		log.Log(ctx, errors.New("SEND NOT IMPLEMENTED FOR MESSAGE TYPE"))
-		return &notification.SendResult{ID: msg.ID, Status: notification.Status{State: notification.StateFailedPerm}}, nil
+		return skipMessage(msg, ""), nil
 	}
 
 	meta := alertlog.NotificationMetaData{
@@ -179,6 +304,12 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 	switch msg.Type {
 	case notification.MessageTypeAlert:
 		p.cfg.AlertLogStore.MustLog(ctx, msg.AlertID, alertlog.TypeNotificationSent, meta)
+	case notification.MessageTypeAlertStatus:
+		status, _ := notifMsg.(notification.AlertStatus)
+		if msg.Dest.Type == notification.DestTypePagerDuty && status.NewAlertState == notification.AlertStateClosed && p.cfg.ConfigSource.Config().PagerDuty.ResolveOnClose {
+			// record attempts to resolve the PagerDuty incident, so failures show in the alert activity
+			p.cfg.AlertLogStore.MustLog(ctx, msg.AlertID, alertlog.TypeNotificationSent, meta)
+		}
 	case notification.MessageTypeAlertBundle:
 		err = p.cfg.AlertLogStore.LogServiceTx(ctx, nil, msg.ServiceID, alertlog.TypeNotificationSent, meta)
 		if err != nil {
@@ -204,3 +335,27 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 
 	return res, nil
 }
+
+// recipientLocation returns the time zone used for timestamps in a notification about an alert
+// of svc: that of the user being notified, if set, otherwise that of the service, falling back
+// to General.DefaultTimeZone.
+func (p *Engine) recipientLocation(ctx context.Context, msg *message.Message, svc *service.Service) (*time.Location, error) {
+	if msg.Dest.Type.IsUserCM() {
+		tz, err := p.cfg.UserStore.FindTimeZone(ctx, msg.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup user time zone")
+		}
+		if tz != nil {
+			return tz, nil
+		}
+	}
+	if svc.TimeZone != "" {
+		loc, err := util.LoadLocation(svc.TimeZone)
+		if err == nil {
+			return loc, nil
+		}
+		log.Log(ctx, errors.Wrap(err, "load service time zone"))
+	}
+
+	return config.FromContext(ctx).DefaultLocation(), nil
+}
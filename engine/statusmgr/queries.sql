@@ -54,6 +54,39 @@ ORDER BY
     id DESC
 LIMIT 1;
 
+-- name: StatusMgrNotifyWatchers :exec
+INSERT INTO outgoing_messages (message_type, contact_method_id, user_id, alert_id, service_id, escalation_policy_id, watcher)
+SELECT
+    'alert_notification',
+    w.contact_method_id,
+    w.user_id,
+    a.id,
+    a.service_id,
+    svc.escalation_policy_id,
+    TRUE
+FROM
+    service_watchers w
+    JOIN services svc ON svc.id = w.service_id
+        AND svc.maintenance_expires_at ISNULL
+        AND svc.paused_at ISNULL
+    JOIN alerts a ON a.service_id = w.service_id
+        AND a.status = 'triggered'
+        AND a.created_at > w.created_at
+        AND a.created_at > now() - '1 hour'::interval
+        AND a.created_at + make_interval(mins => svc.notification_delay_minutes) <= now()
+    JOIN user_contact_methods cm ON cm.id = w.contact_method_id
+        AND NOT cm.disabled
+WHERE
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            outgoing_messages om
+        WHERE
+            om.alert_id = a.id
+            AND om.contact_method_id = w.contact_method_id
+            AND om.watcher);
+
 -- name: StatusMgrCMInfo :one
 SELECT
     user_id,
@@ -0,0 +1,75 @@
+package escalation
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/validation"
+)
+
+// AssignmentStrategy determines which participants of a rotation are notified
+// when an alert reaches a step.
+type AssignmentStrategy string
+
+const (
+	// AssignmentStrategySequential notifies the active participant of each rotation.
+	AssignmentStrategySequential AssignmentStrategy = "sequential"
+
+	// AssignmentStrategyRoundRobin notifies the next participant of each rotation,
+	// advancing for every alert that enters the step.
+	AssignmentStrategyRoundRobin AssignmentStrategy = "round_robin"
+)
+
+// Scan handles reading an AssignmentStrategy from the DB format
+func (a *AssignmentStrategy) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*a = AssignmentStrategy(t)
+	case string:
+		*a = AssignmentStrategy(t)
+	default:
+		return fmt.Errorf("could not process unknown type for assignment strategy: %T", t)
+	}
+
+	return nil
+}
+
+// Value converts the AssignmentStrategy to the DB representation
+func (a AssignmentStrategy) Value() (driver.Value, error) {
+	switch a {
+	case AssignmentStrategySequential, AssignmentStrategyRoundRobin:
+		return string(a), nil
+	default:
+		return nil, fmt.Errorf("unknown assignment strategy specified '%s'", a)
+	}
+}
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (a *AssignmentStrategy) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	switch str {
+	case "sequential":
+		*a = AssignmentStrategySequential
+	case "round_robin":
+		*a = AssignmentStrategyRoundRobin
+	default:
+		return validation.NewFieldError("AssignmentStrategy", "unknown assignment strategy "+str)
+	}
+
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (a AssignmentStrategy) MarshalGQL(w io.Writer) {
+	switch a {
+	case AssignmentStrategySequential:
+		graphql.MarshalString("sequential").MarshalGQL(w)
+	case AssignmentStrategyRoundRobin:
+		graphql.MarshalString("round_robin").MarshalGQL(w)
+	}
+}
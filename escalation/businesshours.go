@@ -0,0 +1,36 @@
+package escalation
+
+import (
+	"time"
+
+	"github.com/target/goalert/businesshours"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// DelayAt returns the delay used when the step is entered at t, given the business hours
+// referenced by DelayBusinessHoursID. The fixed delay is used if bh is nil.
+func (s Step) DelayAt(bh *businesshours.BusinessHours, t time.Time) time.Duration {
+	if bh == nil || s.OffHoursDelayMinutes == 0 || bh.Contains(t) {
+		return s.Delay()
+	}
+
+	return time.Duration(s.OffHoursDelayMinutes) * time.Minute
+}
+
+func (s Step) validateOverride() error {
+	if s.OffHoursDelayMinutes == 0 && s.DelayBusinessHoursID == "" {
+		return nil
+	}
+	if s.DelayBusinessHoursID == "" {
+		return validation.NewFieldError("DelayBusinessHoursID", "required when OffHoursDelayMinutes is set")
+	}
+	if s.OffHoursDelayMinutes == 0 {
+		return validation.NewFieldError("OffHoursDelayMinutes", "required when DelayBusinessHoursID is set")
+	}
+
+	return validate.Many(
+		validate.Range("OffHoursDelayMinutes", s.OffHoursDelayMinutes, 1, 9000),
+		validate.UUID("DelayBusinessHoursID", s.DelayBusinessHoursID),
+	)
+}
@@ -5,10 +5,19 @@ import (
 )
 
 type Policy struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	Repeat         int    `json:"repeat"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Repeat      int    `json:"repeat"`
+
+	// MaxNotifications, if non-zero, is the total number of step escalations
+	// after which escalation stops, regardless of Repeat.
+	MaxNotifications int `json:"max_notifications,omitempty"`
+
+	// AckTimeoutMinutes, if non-zero, is the number of minutes an alert may remain
+	// acknowledged before escalation resumes.
+	AckTimeoutMinutes int `json:"ack_timeout_minutes,omitempty"`
+
 	isUserFavorite bool
 }
 
@@ -17,6 +26,8 @@ func (p Policy) Normalize() (*Policy, error) {
 		validate.IDName("Name", p.Name),
 		validate.Text("Description", p.Description, 1, 255),
 		validate.Range("Repeat", p.Repeat, 0, 5),
+		validate.Range("MaxNotifications", p.MaxNotifications, 0, 100),
+		validate.Range("AckTimeoutMinutes", p.AckTimeoutMinutes, 0, 10080),
 	)
 	if err != nil {
 		return nil, err
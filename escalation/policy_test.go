@@ -23,9 +23,14 @@ func TestPolicy_Normalize(t *testing.T) {
 
 	valid := []Policy{
 		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1, MaxNotifications: 3},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", AckTimeoutMinutes: 30},
 	}
 	invalid := []Policy{
 		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: -5},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", MaxNotifications: -1},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", AckTimeoutMinutes: -1},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", AckTimeoutMinutes: 10081},
 	}
 	for _, p := range valid {
 		test(true, p)
@@ -45,6 +45,8 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		pol.name,
 		pol.description,
 		pol.repeat,
+		pol.max_notifications,
+		pol.ack_timeout_minutes,
 		fav IS DISTINCT FROM NULL
 	FROM escalation_policies pol
 	{{if not .FavoritesOnly }}
@@ -149,7 +151,7 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Policy, erro
 	var result []Policy
 	var p Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.isUserFavorite)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.MaxNotifications, &p.AckTimeoutMinutes, &p.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
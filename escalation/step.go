@@ -1,9 +1,13 @@
 package escalation
 
 import (
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
-	"time"
 )
 
 type ActiveStep struct {
@@ -22,6 +26,36 @@ type Step struct {
 	DelayMinutes int    `json:"delay_minutes"`
 	StepNumber   int    `json:"step_number"`
 
+	// OffHoursDelayMinutes, if non-zero, is used instead of DelayMinutes when the
+	// step is entered outside of the business hours referenced by DelayBusinessHoursID.
+	OffHoursDelayMinutes int    `json:"off_hours_delay_minutes,omitempty"`
+	DelayBusinessHoursID string `json:"delay_business_hours_id,omitempty"`
+
+	AssignmentStrategy AssignmentStrategy `json:"assignment_strategy"`
+
+	// MinSeverity is the lowest alert severity that will be notified by the step. New alerts
+	// begin escalation at the first step they meet the minimum of, skipping earlier steps
+	// (see StartStepIndex). Alerts below it that reach the step by escalating still wait out
+	// the step delay, but no notifications are sent.
+	MinSeverity alert.Severity `json:"min_severity"`
+
+	// LowUrgency, if set, sends all user notifications for the step using low-urgency
+	// notification rules, regardless of alert severity. Later steps are unaffected.
+	LowUrgency bool `json:"low_urgency,omitempty"`
+
+	// RoutingBusinessHoursID, if set, references the business hours used to decide
+	// which conditional targets (in-hours or after-hours) of the step are notified.
+	RoutingBusinessHoursID string `json:"routing_business_hours_id,omitempty"`
+
+	// RoutingWebhookURL, if set, is called when an alert reaches the step to decide which
+	// user, schedule, or rotation is notified. The user targets of the step are notified
+	// instead if the call fails.
+	RoutingWebhookURL string `json:"routing_webhook_url,omitempty"`
+
+	// ConferenceBridge, if set, starts a conference bridge for alerts reaching the step and
+	// calls the users it notifies to invite them to join.
+	ConferenceBridge bool `json:"conference_bridge,omitempty"`
+
 	Targets []assignment.Target
 }
 
@@ -29,13 +63,77 @@ func (s Step) Delay() time.Duration {
 	return time.Duration(s.DelayMinutes) * time.Minute
 }
 func (s Step) Normalize() (*Step, error) {
+	if s.AssignmentStrategy == "" {
+		s.AssignmentStrategy = AssignmentStrategySequential
+	}
+	if s.MinSeverity == "" {
+		s.MinSeverity = alert.SeverityInfo
+	}
 	err := validate.Many(
 		validate.UUID("PolicyID", s.PolicyID),
 		validate.Range("DelayMinutes", s.DelayMinutes, 1, 9000),
+		validate.OneOf("AssignmentStrategy", s.AssignmentStrategy, AssignmentStrategySequential, AssignmentStrategyRoundRobin),
+		validate.OneOf("MinSeverity", s.MinSeverity, alert.SeverityInfo, alert.SeverityWarning, alert.SeverityCritical, alert.SeverityFatal),
 	)
+	if s.RoutingBusinessHoursID != "" {
+		err = validate.Many(err, validate.UUID("RoutingBusinessHoursID", s.RoutingBusinessHoursID))
+	}
+	if s.RoutingWebhookURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("RoutingWebhookURL", s.RoutingWebhookURL))
+		if s.AssignmentStrategy == AssignmentStrategyRoundRobin {
+			err = validate.Many(err, validation.NewFieldError("RoutingWebhookURL", "cannot be used with round-robin assignment"))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateOverride()
 	if err != nil {
 		return nil, err
 	}
 
 	return &s, nil
 }
+
+// minSeverityArg returns the DB value for the step's minimum severity, NULL if all alerts are notified.
+func minSeverityArg(s *Step) sql.NullString {
+	if s.MinSeverity == "" || s.MinSeverity == alert.SeverityInfo {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: string(s.MinSeverity)}
+}
+
+// StartStepIndex returns the index of the step a new alert of the given severity begins
+// escalation at: the first step with a MinSeverity the alert meets, or the first step if
+// there is none.
+//
+// The engine applies the same logic when escalating new alerts.
+func StartStepIndex(steps []Step, sev alert.Severity) int {
+	for i, s := range steps {
+		if s.MinSeverity == "" || sev.AtLeast(s.MinSeverity) {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// routingArg returns the DB value for the step's routing business hours, NULL if unset.
+func routingArg(s *Step) sql.NullString {
+	if s.RoutingBusinessHoursID == "" {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: s.RoutingBusinessHoursID}
+}
+
+// routingWebhookArg returns the DB value for the step's routing webhook URL, NULL if unset.
+func routingWebhookArg(s *Step) sql.NullString {
+	if s.RoutingWebhookURL == "" {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: s.RoutingWebhookURL}
+}
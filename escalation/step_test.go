@@ -2,6 +2,11 @@ package escalation
 
 import (
 	"testing"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/businesshours"
+	"github.com/target/goalert/util/timeutil"
 )
 
 func TestStep_Normalize(t *testing.T) {
@@ -21,12 +26,17 @@ func TestStep_Normalize(t *testing.T) {
 		})
 	}
 
+	const bhID = "a81facc0-4764-012d-7bfb-002500d5d678"
 	valid := []Step{
 		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, OffHoursDelayMinutes: 30, DelayBusinessHoursID: bhID},
 	}
 
 	invalid := []Step{
 		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 9001},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, OffHoursDelayMinutes: 30},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, DelayBusinessHoursID: bhID},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, OffHoursDelayMinutes: 30, DelayBusinessHoursID: "not-a-uuid"},
 	}
 	for _, s := range valid {
 		test(true, s)
@@ -35,3 +45,63 @@ func TestStep_Normalize(t *testing.T) {
 		test(false, s)
 	}
 }
+
+func TestStartStepIndex(t *testing.T) {
+	steps := []Step{
+		{MinSeverity: alert.SeverityCritical},
+		{MinSeverity: alert.SeverityWarning},
+		{MinSeverity: alert.SeverityInfo},
+	}
+
+	if i := StartStepIndex(steps, alert.SeverityFatal); i != 0 {
+		t.Errorf("fatal: got %d; want 0", i)
+	}
+	if i := StartStepIndex(steps, alert.SeverityWarning); i != 1 {
+		t.Errorf("warning: got %d; want 1", i)
+	}
+	if i := StartStepIndex(steps, alert.SeverityInfo); i != 2 {
+		t.Errorf("info: got %d; want 2", i)
+	}
+	if i := StartStepIndex(steps[:1], alert.SeverityInfo); i != 0 {
+		t.Errorf("no unconditional step: got %d; want 0", i)
+	}
+}
+
+func TestStep_DelayAt(t *testing.T) {
+	// 2023-09-19 is a Tuesday
+	at := func(day, h, m int) time.Time { return time.Date(2023, 9, day, h, m, 0, 0, time.UTC) }
+
+	var weekdays timeutil.WeekdayFilter
+	for d := time.Monday; d <= time.Friday; d++ {
+		weekdays.SetDay(d, true)
+	}
+	bh := &businesshours.BusinessHours{TimeZone: time.UTC, WeekdayFilter: weekdays, Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0)}
+
+	s := Step{DelayMinutes: 5}
+	if d := s.DelayAt(bh, at(19, 3, 0)); d != 5*time.Minute {
+		t.Errorf("got %s; want 5m without an off-hours delay", d)
+	}
+
+	s.OffHoursDelayMinutes = 30
+	if d := s.DelayAt(nil, at(19, 3, 0)); d != 5*time.Minute {
+		t.Errorf("got %s; want 5m without business hours", d)
+	}
+	if d := s.DelayAt(bh, at(19, 10, 0)); d != 5*time.Minute {
+		t.Errorf("got %s; want 5m during business hours", d)
+	}
+	if d := s.DelayAt(bh, at(19, 18, 0)); d != 30*time.Minute {
+		t.Errorf("got %s; want 30m outside business hours", d)
+	}
+	if d := s.DelayAt(bh, at(23, 10, 0)); d != 30*time.Minute {
+		t.Errorf("got %s; want 30m on a Saturday", d)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bh.TimeZone = ny
+	if d := s.DelayAt(bh, at(19, 10, 0)); d != 30*time.Minute {
+		t.Errorf("got %s; want 30m at 06:00 New York time", d)
+	}
+}
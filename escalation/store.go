@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"net/url"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/notification/slack"
@@ -13,6 +14,7 @@ import (
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
@@ -48,12 +50,21 @@ type Store struct {
 	findAllOnCallSteps   *sql.Stmt
 	createStep           *sql.Stmt
 	updateStepDelay      *sql.Stmt
+	updateStepOverride   *sql.Stmt
+	updateStepStrategy   *sql.Stmt
+	updateStepSeverity   *sql.Stmt
+	updateStepUrgency    *sql.Stmt
+	updateStepBridge     *sql.Stmt
+	updateStepWebhook    *sql.Stmt
+	countStartSteps      *sql.Stmt
+	updateStepRouting    *sql.Stmt
 	updateStepNumber     *sql.Stmt
 	deleteStep           *sql.Stmt
 
-	addStepTarget      *sql.Stmt
-	deleteStepTarget   *sql.Stmt
-	findAllStepTargets *sql.Stmt
+	addStepTarget          *sql.Stmt
+	deleteStepTarget       *sql.Stmt
+	setStepTargetCondition *sql.Stmt
+	findAllStepTargets     *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
@@ -80,6 +91,8 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				e.name,
 				e.description,
 				e.repeat,
+				e.max_notifications,
+				e.ack_timeout_minutes,
 				fav is distinct from null
 			FROM
 				escalation_policies e
@@ -87,13 +100,15 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				fav.tgt_escalation_policy_id = e.id AND fav.user_id = $2
 			WHERE e.id = $1
 		`),
-		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat FROM escalation_policies WHERE id = $1 FOR UPDATE`),
+		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat, max_notifications, ack_timeout_minutes FROM escalation_policies WHERE id = $1 FOR UPDATE`),
 		findManyPolicies: p.P(`
             SELECT
                 e.id,
                 e.name,
                 e.description,
                 e.repeat,
+                e.max_notifications,
+                e.ack_timeout_minutes,
                 fav is distinct from null
             FROM
                 escalation_policies e
@@ -106,7 +121,9 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				step.escalation_policy_id,
 				pol.name,
 				pol.description,
-				pol.repeat
+				pol.repeat,
+				pol.max_notifications,
+				pol.ack_timeout_minutes
 			FROM
 				escalation_policy_actions as act
 			JOIN
@@ -116,8 +133,8 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 			WHERE
 				act.schedule_id = $1
 		`),
-		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat) VALUES ($1, $2, $3, $4)`),
-		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4 WHERE id = $1`),
+		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat, max_notifications, ack_timeout_minutes) VALUES ($1, $2, $3, $4, $5, $6)`),
+		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4, max_notifications = $5, ack_timeout_minutes = $6 WHERE id = $1`),
 		deletePolicy: p.P(`DELETE FROM escalation_policies WHERE id = any($1)`),
 
 		addStepTarget: p.P(`
@@ -135,6 +152,18 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 					channel_id = $5
 				)
 		`),
+		setStepTargetCondition: p.P(`
+			UPDATE escalation_policy_actions
+			SET business_hours_condition = $6
+			WHERE
+				escalation_policy_step_id = $1 AND
+				(
+					user_id = $2 OR
+					schedule_id = $3 OR
+					rotation_id = $4 OR
+					channel_id = $5
+				)
+		`),
 		findAllStepTargets: p.P(`
 			SELECT
 				user_id,
@@ -143,7 +172,8 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				channel_id,
 				chan.type,
 				chan.value,
-				COALESCE(users.name, rot.name, sched.name, chan.name)
+				COALESCE(users.name, rot.name, sched.name, chan.name),
+				act.business_hours_condition
 			FROM
 				escalation_policy_actions act
 			LEFT JOIN users
@@ -158,10 +188,10 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				escalation_policy_step_id = $1
 		`),
 
-		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
-		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
+		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number, off_hours_delay, delay_business_hours_id, assignment_strategy, min_severity, routing_business_hours_id, low_urgency, routing_webhook_url, conference_bridge FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
+		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number, off_hours_delay, delay_business_hours_id, assignment_strategy, min_severity, routing_business_hours_id, low_urgency, routing_webhook_url, conference_bridge FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
 		findAllOnCallSteps: p.P(`
-			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number
+			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number, step.off_hours_delay, step.delay_business_hours_id, step.assignment_strategy, step.min_severity, step.routing_business_hours_id, step.low_urgency, step.routing_webhook_url, step.conference_bridge
 			FROM ep_step_on_call_users oc
 			JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
 			WHERE oc.user_id = $1 AND oc.end_time isnull
@@ -170,13 +200,29 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 
 		createStep: p.P(`
 			INSERT INTO escalation_policy_steps
-				(id, escalation_policy_id, delay, step_number)
-			VALUES ($1, $2, $3, DEFAULT)
+				(id, escalation_policy_id, delay, step_number, off_hours_delay, delay_business_hours_id, assignment_strategy, min_severity, routing_business_hours_id, low_urgency, routing_webhook_url, conference_bridge)
+			VALUES ($1, $2, $3, DEFAULT, $4, $5, $6, $7, $8, $9, $10, $11)
 			RETURNING step_number
 		`),
-		updateStepDelay:  p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
+		updateStepDelay:    p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
+		updateStepStrategy: p.P(`UPDATE escalation_policy_steps SET assignment_strategy = $2 WHERE id = $1`),
+		updateStepSeverity: p.P(`UPDATE escalation_policy_steps SET min_severity = $2 WHERE id = $1`),
+		updateStepUrgency:  p.P(`UPDATE escalation_policy_steps SET low_urgency = $2 WHERE id = $1`),
+		updateStepBridge:   p.P(`UPDATE escalation_policy_steps SET conference_bridge = $2 WHERE id = $1`),
+		updateStepRouting:  p.P(`UPDATE escalation_policy_steps SET routing_business_hours_id = $2 WHERE id = $1`),
+		updateStepWebhook:  p.P(`UPDATE escalation_policy_steps SET routing_webhook_url = $2 WHERE id = $1`),
+		updateStepOverride: p.P(`
+			UPDATE escalation_policy_steps
+			SET off_hours_delay = $2, delay_business_hours_id = $3
+			WHERE id = $1
+		`),
 		updateStepNumber: p.P(`UPDATE escalation_policy_steps SET step_number = $2 WHERE id = $1`),
 		deleteStep:       p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
+		countStartSteps: p.P(`
+			SELECT count(*), count(*) FILTER (WHERE min_severity ISNULL)
+			FROM escalation_policy_steps
+			WHERE escalation_policy_id = $1
+		`),
 	}, p.Err
 }
 
@@ -187,6 +233,41 @@ func (s *Store) logChange(ctx context.Context, tx *sql.Tx, policyID string) {
 	}
 }
 
+type scanner interface {
+	Scan(...interface{}) error
+}
+
+func scanStep(row scanner) (*Step, error) {
+	var st Step
+	var offHours sql.NullInt32
+	var delayID, minSev, routingID, webhookURL sql.NullString
+	err := row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber, &offHours, &delayID, &st.AssignmentStrategy, &minSev, &routingID, &st.LowUrgency, &webhookURL, &st.ConferenceBridge)
+	if err != nil {
+		return nil, err
+	}
+	st.OffHoursDelayMinutes = int(offHours.Int32)
+	st.DelayBusinessHoursID = delayID.String
+	st.RoutingBusinessHoursID = routingID.String
+	st.RoutingWebhookURL = webhookURL.String
+	st.MinSeverity = alert.SeverityInfo
+	if minSev.Valid {
+		st.MinSeverity = alert.Severity(minSev.String)
+	}
+
+	return &st, nil
+}
+
+// overrideArgs returns the query arguments for the delay override columns of a step.
+func overrideArgs(st *Step) (offHours sql.NullInt32, delayID sql.NullString) {
+	if st.OffHoursDelayMinutes == 0 || st.DelayBusinessHoursID == "" {
+		return offHours, delayID
+	}
+
+	offHours = sql.NullInt32{Int32: int32(st.OffHoursDelayMinutes), Valid: true}
+	delayID = sql.NullString{String: st.DelayBusinessHoursID, Valid: true}
+	return offHours, delayID
+}
+
 func validStepTarget(tgt assignment.Target) error {
 	return validate.Many(
 		validate.UUID("TargetID", tgt.TargetID()),
@@ -258,7 +339,7 @@ func (s *Store) FindManyPolicies(ctx context.Context, ids []string) ([]Policy, e
 	var result []Policy
 	var p Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.isUserFavorite)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.MaxNotifications, &p.AckTimeoutMinutes, &p.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -303,6 +384,30 @@ func (s *Store) chanWebhook(ctx context.Context, tx *sql.Tx, webhookTarget assig
 	return assignment.NotificationChannelTarget(notifID.String()), nil
 }
 
+func (s *Store) msTeamsChannel(ctx context.Context, tx *sql.Tx, webhookURL string) (assignment.Target, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+	notifID, err := s.ncStore.MapToID(ctx, tx, &notificationchannel.Channel{
+		Type:  notificationchannel.TypeMSTeams,
+		Name:  "Teams (" + u.Hostname() + ")",
+		Value: webhookURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment.NotificationChannelTarget(notifID.String()), nil
+}
+
+func (s *Store) pagerDuty(ctx context.Context, tx *sql.Tx, routingKey string) (assignment.Target, error) {
+	notifID, err := s.ncStore.MapPagerDutyToID(ctx, tx, routingKey)
+	if err != nil {
+		return nil, err
+	}
+	return assignment.NotificationChannelTarget(notifID.String()), nil
+}
+
 func (s *Store) newSlackChannel(ctx context.Context, tx *sql.Tx, slackChanID string) (assignment.Target, error) {
 	ch, err := s.slackFn(ctx, slackChanID)
 	if err != nil {
@@ -347,30 +452,108 @@ func (s *Store) AddStepTargetTx(ctx context.Context, tx *sql.Tx, stepID string,
 			return err
 		}
 	}
+	if tgt.TargetType() == assignment.TargetTypeMSTeamsChannel {
+		var err error
+		tgt, err = s.msTeamsChannel(ctx, tx, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
+	if tgt.TargetType() == assignment.TargetTypePagerDuty {
+		var err error
+		tgt, err = s.pagerDuty(ctx, tx, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
 	return s._updateStepTarget(ctx, stepID, tgt, tx.StmtContext(ctx, s.addStepTarget), true)
 }
 
-// DeleteStepTargetTx removes the target from the step.
-func (s *Store) DeleteStepTargetTx(ctx context.Context, tx *sql.Tx, stepID string, tgt assignment.Target) error {
+// resolveStepTarget returns the stored target for one that is already assigned to the step,
+// mapping channel targets to their notification channel.
+func (s *Store) resolveStepTarget(ctx context.Context, tx *sql.Tx, stepID string, tgt assignment.Target) (assignment.Target, error) {
 	if tgt.TargetType() == assignment.TargetTypeSlackChannel {
 		var err error
 		tgt, err = s.lookupNotifChannel(ctx, tx, stepID, tgt.TargetID(), "SLACK")
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if tgt.TargetType() == assignment.TargetTypeChanWebhook {
 		var err error
 		tgt, err = s.lookupNotifChannel(ctx, tx, stepID, tgt.TargetID(), "WEBHOOK")
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+	if tgt.TargetType() == assignment.TargetTypeMSTeamsChannel {
+		var err error
+		tgt, err = s.lookupNotifChannel(ctx, tx, stepID, tgt.TargetID(), "MS_TEAMS")
+		if err != nil {
+			return nil, err
 		}
 	}
+	if tgt.TargetType() == assignment.TargetTypePagerDuty {
+		ch, err := notificationchannel.PagerDutyChannel(tgt.TargetID())
+		if err != nil {
+			return nil, err
+		}
+		tgt, err = s.lookupNotifChannel(ctx, tx, stepID, ch.Value, "PAGERDUTY")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tgt, nil
+}
+
+// DeleteStepTargetTx removes the target from the step.
+func (s *Store) DeleteStepTargetTx(ctx context.Context, tx *sql.Tx, stepID string, tgt assignment.Target) error {
+	tgt, err := s.resolveStepTarget(ctx, tx, stepID, tgt)
+	if err != nil {
+		return err
+	}
 	return s._updateStepTarget(ctx, stepID, tgt, tx.StmtContext(ctx, s.deleteStepTarget), false)
 }
 
-// FindAllStepTargetsTx returns the targets for a step.
+// SetStepTargetConditionTx sets when an existing target of the step is notified. Conditional
+// targets only take effect for steps with routing business hours.
+func (s *Store) SetStepTargetConditionTx(ctx context.Context, tx *sql.Tx, stepID string, tgt assignment.Target, cond TargetCondition) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.UUID("StepID", stepID),
+		validate.OneOf("Condition", cond, TargetConditionAlways, TargetConditionInHours, TargetConditionAfterHours),
+	)
+	if err != nil {
+		return err
+	}
+
+	tgt, err = s.resolveStepTarget(ctx, tx, stepID, tgt)
+	if err != nil {
+		return err
+	}
+	err = validStepTarget(tgt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setStepTargetCondition).ExecContext(ctx, append(tgtFields(stepID, tgt, false), cond.nullString())...)
+	return err
+}
+
+// FindAllStepTargetsTx returns the targets for a step, including conditional targets.
 func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID string) ([]assignment.Target, error) {
+	return s.findStepTargets(ctx, tx, stepID, func(TargetCondition) bool { return true })
+}
+
+// FindStepTargetsByConditionTx returns the targets for a step with the given condition.
+func (s *Store) FindStepTargetsByConditionTx(ctx context.Context, tx *sql.Tx, stepID string, cond TargetCondition) ([]assignment.Target, error) {
+	return s.findStepTargets(ctx, tx, stepID, func(c TargetCondition) bool { return c == cond })
+}
+
+func (s *Store) findStepTargets(ctx context.Context, tx *sql.Tx, stepID string, match func(TargetCondition) bool) ([]assignment.Target, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
 	if err != nil {
 		return nil, err
@@ -400,10 +583,14 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 		var usr, sched, rot, ch, chValue sql.NullString
 		var chType *notificationchannel.Type
 		var tgt assignment.RawTarget
-		err = rows.Scan(&usr, &sched, &rot, &ch, &chType, &chValue, &tgt.Name)
+		var cond TargetCondition
+		err = rows.Scan(&usr, &sched, &rot, &ch, &chType, &chValue, &tgt.Name, &cond)
 		if err != nil {
 			return nil, err
 		}
+		if !match(cond) {
+			continue
+		}
 
 		switch {
 		case usr.Valid:
@@ -423,6 +610,9 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 			case notificationchannel.TypeWebhook:
 				tgt.ID = chValue.String
 				tgt.Type = assignment.TargetTypeChanWebhook
+			case notificationchannel.TypeMSTeams:
+				tgt.ID = chValue.String
+				tgt.Type = assignment.TargetTypeMSTeamsChannel
 			default:
 				tgt.ID = ch.String
 				tgt.Type = assignment.TargetTypeNotificationChannel
@@ -455,13 +645,93 @@ func (s *Store) CreatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) (*Pol
 
 	n.ID = uuid.New().String()
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.MaxNotifications, n.AckTimeoutMinutes)
 	if err != nil {
 		return nil, err
 	}
 	return n, nil
 }
 
+// ClonePolicyTx creates a new escalation policy with the given name, copying the settings, steps,
+// and step targets of an existing policy. Schedules, rotations, and other targets are referenced
+// by the new policy, not duplicated.
+func (s *Store) ClonePolicyTx(ctx context.Context, tx *sql.Tx, id, name string) (*Policy, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := s.FindOnePolicyTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := s.FindAllStepsTx(ctx, tx, src.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pol, err := s.CreatePolicyTx(ctx, tx, &Policy{
+		Name:              name,
+		Description:       src.Description,
+		Repeat:            src.Repeat,
+		MaxNotifications:  src.MaxNotifications,
+		AckTimeoutMinutes: src.AckTimeoutMinutes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// minimum severities are set once all steps exist, as the source policy is only
+	// guaranteed to have a step without one as a whole
+	minSteps := make(map[string]alert.Severity)
+	for _, step := range steps {
+		tgts, err := s.FindAllStepTargetsTx(ctx, tx, step.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		step.PolicyID = pol.ID
+		minSev := step.MinSeverity
+		step.MinSeverity = alert.SeverityInfo
+		newStep, err := s.CreateStepTx(ctx, tx, &step)
+		if err != nil {
+			return nil, err
+		}
+		if minSev != alert.SeverityInfo {
+			minSteps[newStep.ID] = minSev
+		}
+
+		for _, tgt := range tgts {
+			err = s.AddStepTargetTx(ctx, tx, newStep.ID, tgt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, cond := range []TargetCondition{TargetConditionInHours, TargetConditionAfterHours} {
+			tgts, err := s.FindStepTargetsByConditionTx(ctx, tx, step.ID, cond)
+			if err != nil {
+				return nil, err
+			}
+			for _, tgt := range tgts {
+				err = s.SetStepTargetConditionTx(ctx, tx, newStep.ID, tgt, cond)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for stepID, sev := range minSteps {
+		err = s.UpdateStepMinSeverityTx(ctx, tx, &Step{ID: stepID, PolicyID: pol.ID, MinSeverity: sev})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pol, nil
+}
+
 // UpdatePolicyTx will update a single escalation policy.
 func (s *Store) UpdatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) error {
 	err := validate.UUID("EscalationPolicyID", p.ID)
@@ -483,7 +753,7 @@ func (s *Store) UpdatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) error
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.MaxNotifications, n.AckTimeoutMinutes)
 	if err != nil {
 		return err
 	}
@@ -531,7 +801,7 @@ func (s *Store) FindOnePolicyTx(ctx context.Context, tx *sql.Tx, id string) (*Po
 
 	row := stmt.QueryRowContext(ctx, id)
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.MaxNotifications, &p.AckTimeoutMinutes)
 	return &p, err
 }
 
@@ -554,7 +824,7 @@ func (s *Store) FindOnePolicyForUpdateTx(ctx context.Context, tx *sql.Tx, id str
 
 	row := stmt.QueryRowContext(ctx, id)
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.MaxNotifications, &p.AckTimeoutMinutes)
 	return &p, err
 }
 
@@ -577,7 +847,7 @@ func (s *Store) FindAllPoliciesBySchedule(ctx context.Context, scheduleID string
 	var p Policy
 	var policies []Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.MaxNotifications, &p.AckTimeoutMinutes)
 		if err != nil {
 			return nil, err
 		}
@@ -604,14 +874,12 @@ func (s *Store) FindOneStepForUpdateTx(ctx context.Context, tx *sql.Tx, id strin
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	row := stmt.QueryRowContext(ctx, id)
-	var st Step
-	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber)
+	st, err := scanStep(stmt.QueryRowContext(ctx, id))
 	if err != nil {
 		return nil, err
 	}
 
-	return &st, nil
+	return st, nil
 }
 
 func (s *Store) FindAllSteps(ctx context.Context, policyID string) ([]Step, error) {
@@ -642,12 +910,11 @@ func (s *Store) FindAllOnCallStepsForUserTx(ctx context.Context, tx *sql.Tx, use
 
 	var result []Step
 	for rows.Next() {
-		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		s, err := scanStep(rows)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, s)
+		result = append(result, *s)
 	}
 	return result, nil
 }
@@ -677,12 +944,11 @@ func (s *Store) FindAllStepsTx(ctx context.Context, tx *sql.Tx, policyID string)
 
 	var result []Step
 	for rows.Next() {
-		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		s, err := scanStep(rows)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, s)
+		result = append(result, *s)
 	}
 	return result, nil
 }
@@ -706,7 +972,13 @@ func (s *Store) CreateStepTx(ctx context.Context, tx *sql.Tx, st *Step) (*Step,
 
 	n.ID = uuid.New().String()
 
-	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes).Scan(&n.StepNumber)
+	offHours, delayID := overrideArgs(n)
+	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes, offHours, delayID, n.AssignmentStrategy, minSeverityArg(n), routingArg(n), n.LowUrgency, routingWebhookArg(n), n.ConferenceBridge).Scan(&n.StepNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateStartStepsTx(ctx, tx, n.PolicyID)
 	if err != nil {
 		return nil, err
 	}
@@ -770,6 +1042,241 @@ func (s *Store) UpdateStepDelayTx(ctx context.Context, tx *sql.Tx, stepID string
 	return nil
 }
 
+// UpdateStepOverrideTx updates the off-hours delay and delay business hours for a step.
+// Clearing both (zero OffHoursDelayMinutes and empty DelayBusinessHoursID) reverts the
+// step to a fixed delay.
+func (s *Store) UpdateStepOverrideTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", st.ID)
+	if err != nil {
+		return err
+	}
+
+	err = st.validateOverride()
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepOverride
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	offHours, delayID := overrideArgs(st)
+	_, err = stmt.ExecContext(ctx, st.ID, offHours, delayID)
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// UpdateStepRoutingTx updates the routing business hours for a step. An empty
+// RoutingBusinessHoursID notifies all targets regardless of their condition.
+func (s *Store) UpdateStepRoutingTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", st.ID)
+	if err != nil {
+		return err
+	}
+	if st.RoutingBusinessHoursID != "" {
+		err = validate.UUID("RoutingBusinessHoursID", st.RoutingBusinessHoursID)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := s.updateStepRouting
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, st.ID, routingArg(st))
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// UpdateStepAssignmentStrategyTx updates the assignment strategy for a step.
+func (s *Store) UpdateStepAssignmentStrategyTx(ctx context.Context, tx *sql.Tx, stepID string, strategy AssignmentStrategy) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("EscalationPolicyStepID", stepID),
+		validate.OneOf("AssignmentStrategy", strategy, AssignmentStrategySequential, AssignmentStrategyRoundRobin),
+	)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepStrategy
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, stepID, strategy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStepMinSeverityTx updates the minimum alert severity notified by a step, which is also
+// the lowest severity of new alerts that may begin escalation at it.
+func (s *Store) UpdateStepMinSeverityTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("EscalationPolicyStepID", st.ID),
+		validate.OneOf("MinSeverity", st.MinSeverity, alert.SeverityInfo, alert.SeverityWarning, alert.SeverityCritical, alert.SeverityFatal),
+	)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepSeverity
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, st.ID, minSeverityArg(st))
+	if err != nil {
+		return err
+	}
+
+	err = s.validateStartStepsTx(ctx, tx, st.PolicyID)
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// UpdateStepLowUrgencyTx updates whether all notifications sent by a step are low urgency.
+func (s *Store) UpdateStepLowUrgencyTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", st.ID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepUrgency
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, st.ID, st.LowUrgency)
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// UpdateStepConferenceBridgeTx updates whether a step starts a conference bridge for alerts reaching it.
+func (s *Store) UpdateStepConferenceBridgeTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", st.ID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepBridge
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, st.ID, st.ConferenceBridge)
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// UpdateStepRoutingWebhookTx updates the URL called to decide who a step notifies. An empty
+// RoutingWebhookURL removes it.
+func (s *Store) UpdateStepRoutingWebhookTx(ctx context.Context, tx *sql.Tx, st *Step) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", st.ID)
+	if err != nil {
+		return err
+	}
+	if st.RoutingWebhookURL != "" {
+		err = validate.AbsoluteURL("RoutingWebhookURL", st.RoutingWebhookURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := s.updateStepWebhook
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, st.ID, routingWebhookArg(st))
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, st.PolicyID)
+	return nil
+}
+
+// validateStartStepsTx ensures a policy with steps has at least one step without a minimum
+// severity, so that every alert has a step to begin escalation at.
+func (s *Store) validateStartStepsTx(ctx context.Context, tx *sql.Tx, policyID string) error {
+	stmt := s.countStartSteps
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	var total, unconditional int
+	err := stmt.QueryRowContext(ctx, policyID).Scan(&total, &unconditional)
+	if err != nil {
+		return err
+	}
+	if total > 0 && unconditional == 0 {
+		return validation.NewFieldError("MinSeverity", "at least one step must apply to all alerts")
+	}
+
+	return nil
+}
+
 // DeleteStepTx deletes a step from an escalation policy.
 func (s *Store) DeleteStepTx(ctx context.Context, tx *sql.Tx, id string) (string, error) {
 	err := validate.UUID("EscalationPolicyStepID", id)
@@ -792,6 +1299,11 @@ func (s *Store) DeleteStepTx(ctx context.Context, tx *sql.Tx, id string) (string
 		return "", err
 	}
 
+	err = s.validateStartStepsTx(ctx, tx, polID)
+	if err != nil {
+		return "", err
+	}
+
 	s.logChange(ctx, tx, polID)
 
 	return polID, nil
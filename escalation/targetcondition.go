@@ -0,0 +1,46 @@
+package escalation
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TargetCondition restricts when a step target is notified, based on the routing
+// business hours of the step.
+type TargetCondition string
+
+const (
+	// TargetConditionAlways notifies the target regardless of business hours.
+	TargetConditionAlways TargetCondition = ""
+
+	// TargetConditionInHours notifies the target only during business hours.
+	TargetConditionInHours TargetCondition = "in_hours"
+
+	// TargetConditionAfterHours notifies the target only outside of business hours.
+	TargetConditionAfterHours TargetCondition = "after_hours"
+)
+
+// Scan handles reading a TargetCondition from the DB format, where NULL
+// indicates TargetConditionAlways.
+func (c *TargetCondition) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case nil:
+		*c = TargetConditionAlways
+	case []byte:
+		*c = TargetCondition(t)
+	case string:
+		*c = TargetCondition(t)
+	default:
+		return fmt.Errorf("could not process unknown type for target condition: %T", t)
+	}
+
+	return nil
+}
+
+func (c TargetCondition) nullString() sql.NullString {
+	if c == TargetConditionAlways {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: string(c)}
+}
@@ -18,17 +18,20 @@ import (
 type EngineProcessingType string
 
 const (
-	EngineProcessingTypeCleanup      EngineProcessingType = "cleanup"
-	EngineProcessingTypeCompat       EngineProcessingType = "compat"
-	EngineProcessingTypeEscalation   EngineProcessingType = "escalation"
-	EngineProcessingTypeHeartbeat    EngineProcessingType = "heartbeat"
-	EngineProcessingTypeMessage      EngineProcessingType = "message"
-	EngineProcessingTypeMetrics      EngineProcessingType = "metrics"
-	EngineProcessingTypeNpCycle      EngineProcessingType = "np_cycle"
-	EngineProcessingTypeRotation     EngineProcessingType = "rotation"
-	EngineProcessingTypeSchedule     EngineProcessingType = "schedule"
-	EngineProcessingTypeStatusUpdate EngineProcessingType = "status_update"
-	EngineProcessingTypeVerify       EngineProcessingType = "verify"
+	EngineProcessingTypeCleanup          EngineProcessingType = "cleanup"
+	EngineProcessingTypeCompat           EngineProcessingType = "compat"
+	EngineProcessingTypeConferenceBridge EngineProcessingType = "conference_bridge"
+	EngineProcessingTypeEnrichment       EngineProcessingType = "enrichment"
+	EngineProcessingTypeEscalation       EngineProcessingType = "escalation"
+	EngineProcessingTypeHeartbeat        EngineProcessingType = "heartbeat"
+	EngineProcessingTypeIncident         EngineProcessingType = "incident"
+	EngineProcessingTypeMessage          EngineProcessingType = "message"
+	EngineProcessingTypeMetrics          EngineProcessingType = "metrics"
+	EngineProcessingTypeNpCycle          EngineProcessingType = "np_cycle"
+	EngineProcessingTypeRotation         EngineProcessingType = "rotation"
+	EngineProcessingTypeSchedule         EngineProcessingType = "schedule"
+	EngineProcessingTypeStatusUpdate     EngineProcessingType = "status_update"
+	EngineProcessingTypeVerify           EngineProcessingType = "verify"
 )
 
 func (e *EngineProcessingType) Scan(src interface{}) error {
@@ -66,22 +69,118 @@ func (ns NullEngineProcessingType) Value() (driver.Value, error) {
 	return string(ns.EngineProcessingType), nil
 }
 
+type EnumAlertCloseReason string
+
+const (
+	EnumAlertCloseReasonDuplicate     EnumAlertCloseReason = "duplicate"
+	EnumAlertCloseReasonFalsePositive EnumAlertCloseReason = "falsePositive"
+	EnumAlertCloseReasonResolved      EnumAlertCloseReason = "resolved"
+	EnumAlertCloseReasonWontfix       EnumAlertCloseReason = "wontfix"
+)
+
+func (e *EnumAlertCloseReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumAlertCloseReason(s)
+	case string:
+		*e = EnumAlertCloseReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumAlertCloseReason: %T", src)
+	}
+	return nil
+}
+
+type NullEnumAlertCloseReason struct {
+	EnumAlertCloseReason EnumAlertCloseReason
+	Valid                bool // Valid is true if EnumAlertCloseReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumAlertCloseReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumAlertCloseReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumAlertCloseReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumAlertCloseReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumAlertCloseReason), nil
+}
+
+type EnumAlertFeedback string
+
+const (
+	EnumAlertFeedbackActionable EnumAlertFeedback = "actionable"
+	EnumAlertFeedbackNoise      EnumAlertFeedback = "noise"
+)
+
+func (e *EnumAlertFeedback) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumAlertFeedback(s)
+	case string:
+		*e = EnumAlertFeedback(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumAlertFeedback: %T", src)
+	}
+	return nil
+}
+
+type NullEnumAlertFeedback struct {
+	EnumAlertFeedback EnumAlertFeedback
+	Valid             bool // Valid is true if EnumAlertFeedback is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumAlertFeedback) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumAlertFeedback, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumAlertFeedback.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumAlertFeedback) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumAlertFeedback), nil
+}
+
 type EnumAlertLogEvent string
 
 const (
-	EnumAlertLogEventAcknowledged        EnumAlertLogEvent = "acknowledged"
-	EnumAlertLogEventAssignmentChanged   EnumAlertLogEvent = "assignment_changed"
-	EnumAlertLogEventClosed              EnumAlertLogEvent = "closed"
-	EnumAlertLogEventCreated             EnumAlertLogEvent = "created"
-	EnumAlertLogEventDuplicateSuppressed EnumAlertLogEvent = "duplicate_suppressed"
-	EnumAlertLogEventEscalated           EnumAlertLogEvent = "escalated"
-	EnumAlertLogEventEscalationRequest   EnumAlertLogEvent = "escalation_request"
-	EnumAlertLogEventNoNotificationSent  EnumAlertLogEvent = "no_notification_sent"
-	EnumAlertLogEventNotificationSent    EnumAlertLogEvent = "notification_sent"
-	EnumAlertLogEventPolicyUpdated       EnumAlertLogEvent = "policy_updated"
-	EnumAlertLogEventReopened            EnumAlertLogEvent = "reopened"
-	EnumAlertLogEventResponseReceived    EnumAlertLogEvent = "response_received"
-	EnumAlertLogEventStatusChanged       EnumAlertLogEvent = "status_changed"
+	EnumAlertLogEventAckTimeout            EnumAlertLogEvent = "ack_timeout"
+	EnumAlertLogEventAcknowledged          EnumAlertLogEvent = "acknowledged"
+	EnumAlertLogEventAssignmentChanged     EnumAlertLogEvent = "assignment_changed"
+	EnumAlertLogEventClosed                EnumAlertLogEvent = "closed"
+	EnumAlertLogEventConferenceBridge      EnumAlertLogEvent = "conference_bridge"
+	EnumAlertLogEventCreated               EnumAlertLogEvent = "created"
+	EnumAlertLogEventDuplicateSuppressed   EnumAlertLogEvent = "duplicate_suppressed"
+	EnumAlertLogEventEscalated             EnumAlertLogEvent = "escalated"
+	EnumAlertLogEventEscalationExhausted   EnumAlertLogEvent = "escalation_exhausted"
+	EnumAlertLogEventEscalationRequest     EnumAlertLogEvent = "escalation_request"
+	EnumAlertLogEventIncidentAcknowledged  EnumAlertLogEvent = "incident_acknowledged"
+	EnumAlertLogEventMaintenanceSuppressed EnumAlertLogEvent = "maintenance_suppressed"
+	EnumAlertLogEventNoNotificationSent    EnumAlertLogEvent = "no_notification_sent"
+	EnumAlertLogEventNote                  EnumAlertLogEvent = "note"
+	EnumAlertLogEventNotificationSent      EnumAlertLogEvent = "notification_sent"
+	EnumAlertLogEventPolicyUpdated         EnumAlertLogEvent = "policy_updated"
+	EnumAlertLogEventReopened              EnumAlertLogEvent = "reopened"
+	EnumAlertLogEventResponseReceived      EnumAlertLogEvent = "response_received"
+	EnumAlertLogEventServicePaused         EnumAlertLogEvent = "service_paused"
+	EnumAlertLogEventServiceResumed        EnumAlertLogEvent = "service_resumed"
+	EnumAlertLogEventSnoozed               EnumAlertLogEvent = "snoozed"
+	EnumAlertLogEventStatusChanged         EnumAlertLogEvent = "status_changed"
+	EnumAlertLogEventUnsnoozed             EnumAlertLogEvent = "unsnoozed"
 )
 
 func (e *EnumAlertLogEvent) Scan(src interface{}) error {
@@ -163,6 +262,50 @@ func (ns NullEnumAlertLogSubjectType) Value() (driver.Value, error) {
 	return string(ns.EnumAlertLogSubjectType), nil
 }
 
+type EnumAlertSeverity string
+
+const (
+	EnumAlertSeverityCritical EnumAlertSeverity = "critical"
+	EnumAlertSeverityFatal    EnumAlertSeverity = "fatal"
+	EnumAlertSeverityInfo     EnumAlertSeverity = "info"
+	EnumAlertSeverityWarning  EnumAlertSeverity = "warning"
+)
+
+func (e *EnumAlertSeverity) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumAlertSeverity(s)
+	case string:
+		*e = EnumAlertSeverity(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumAlertSeverity: %T", src)
+	}
+	return nil
+}
+
+type NullEnumAlertSeverity struct {
+	EnumAlertSeverity EnumAlertSeverity
+	Valid             bool // Valid is true if EnumAlertSeverity is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumAlertSeverity) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumAlertSeverity, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumAlertSeverity.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumAlertSeverity) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumAlertSeverity), nil
+}
+
 type EnumAlertSource string
 
 const (
@@ -170,6 +313,7 @@ const (
 	EnumAlertSourceGeneric                EnumAlertSource = "generic"
 	EnumAlertSourceGrafana                EnumAlertSource = "grafana"
 	EnumAlertSourceManual                 EnumAlertSource = "manual"
+	EnumAlertSourceOpsgenie               EnumAlertSource = "opsgenie"
 	EnumAlertSourcePrometheusAlertmanager EnumAlertSource = "prometheusAlertmanager"
 	EnumAlertSourceSite24x7               EnumAlertSource = "site24x7"
 )
@@ -252,12 +396,97 @@ func (ns NullEnumAlertStatus) Value() (driver.Value, error) {
 	return string(ns.EnumAlertStatus), nil
 }
 
+type EnumBusinessHoursCondition string
+
+const (
+	EnumBusinessHoursConditionAfterHours EnumBusinessHoursCondition = "after_hours"
+	EnumBusinessHoursConditionInHours    EnumBusinessHoursCondition = "in_hours"
+)
+
+func (e *EnumBusinessHoursCondition) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumBusinessHoursCondition(s)
+	case string:
+		*e = EnumBusinessHoursCondition(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumBusinessHoursCondition: %T", src)
+	}
+	return nil
+}
+
+type NullEnumBusinessHoursCondition struct {
+	EnumBusinessHoursCondition EnumBusinessHoursCondition
+	Valid                      bool // Valid is true if EnumBusinessHoursCondition is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumBusinessHoursCondition) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumBusinessHoursCondition, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumBusinessHoursCondition.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumBusinessHoursCondition) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumBusinessHoursCondition), nil
+}
+
+type EnumEpStepAssignmentStrategy string
+
+const (
+	EnumEpStepAssignmentStrategyRoundRobin EnumEpStepAssignmentStrategy = "round_robin"
+	EnumEpStepAssignmentStrategySequential EnumEpStepAssignmentStrategy = "sequential"
+)
+
+func (e *EnumEpStepAssignmentStrategy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumEpStepAssignmentStrategy(s)
+	case string:
+		*e = EnumEpStepAssignmentStrategy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumEpStepAssignmentStrategy: %T", src)
+	}
+	return nil
+}
+
+type NullEnumEpStepAssignmentStrategy struct {
+	EnumEpStepAssignmentStrategy EnumEpStepAssignmentStrategy
+	Valid                        bool // Valid is true if EnumEpStepAssignmentStrategy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumEpStepAssignmentStrategy) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumEpStepAssignmentStrategy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumEpStepAssignmentStrategy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumEpStepAssignmentStrategy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumEpStepAssignmentStrategy), nil
+}
+
 type EnumHeartbeatState string
 
 const (
 	EnumHeartbeatStateHealthy   EnumHeartbeatState = "healthy"
 	EnumHeartbeatStateInactive  EnumHeartbeatState = "inactive"
 	EnumHeartbeatStateUnhealthy EnumHeartbeatState = "unhealthy"
+	EnumHeartbeatStateWarning   EnumHeartbeatState = "warning"
 )
 
 func (e *EnumHeartbeatState) Scan(src interface{}) error {
@@ -301,6 +530,7 @@ const (
 	EnumIntegrationKeysTypeEmail                  EnumIntegrationKeysType = "email"
 	EnumIntegrationKeysTypeGeneric                EnumIntegrationKeysType = "generic"
 	EnumIntegrationKeysTypeGrafana                EnumIntegrationKeysType = "grafana"
+	EnumIntegrationKeysTypeOpsgenie               EnumIntegrationKeysType = "opsgenie"
 	EnumIntegrationKeysTypePrometheusAlertmanager EnumIntegrationKeysType = "prometheusAlertmanager"
 	EnumIntegrationKeysTypeSite24x7               EnumIntegrationKeysType = "site24x7"
 )
@@ -395,6 +625,8 @@ func (ns NullEnumLimitType) Value() (driver.Value, error) {
 type EnumNotifChannelType string
 
 const (
+	EnumNotifChannelTypeMSTEAMS        EnumNotifChannelType = "MS_TEAMS"
+	EnumNotifChannelTypePAGERDUTY      EnumNotifChannelType = "PAGERDUTY"
 	EnumNotifChannelTypeSLACK          EnumNotifChannelType = "SLACK"
 	EnumNotifChannelTypeSLACKUSERGROUP EnumNotifChannelType = "SLACK_USER_GROUP"
 	EnumNotifChannelTypeWEBHOOK        EnumNotifChannelType = "WEBHOOK"
@@ -435,6 +667,48 @@ func (ns NullEnumNotifChannelType) Value() (driver.Value, error) {
 	return string(ns.EnumNotifChannelType), nil
 }
 
+type EnumNotificationRuleUrgency string
+
+const (
+	EnumNotificationRuleUrgencyHigh EnumNotificationRuleUrgency = "high"
+	EnumNotificationRuleUrgencyLow  EnumNotificationRuleUrgency = "low"
+)
+
+func (e *EnumNotificationRuleUrgency) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumNotificationRuleUrgency(s)
+	case string:
+		*e = EnumNotificationRuleUrgency(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumNotificationRuleUrgency: %T", src)
+	}
+	return nil
+}
+
+type NullEnumNotificationRuleUrgency struct {
+	EnumNotificationRuleUrgency EnumNotificationRuleUrgency
+	Valid                       bool // Valid is true if EnumNotificationRuleUrgency is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumNotificationRuleUrgency) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumNotificationRuleUrgency, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumNotificationRuleUrgency.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumNotificationRuleUrgency) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumNotificationRuleUrgency), nil
+}
+
 type EnumOutgoingMessagesStatus string
 
 const (
@@ -485,13 +759,16 @@ func (ns NullEnumOutgoingMessagesStatus) Value() (driver.Value, error) {
 type EnumOutgoingMessagesType string
 
 const (
-	EnumOutgoingMessagesTypeAlertNotification          EnumOutgoingMessagesType = "alert_notification"
-	EnumOutgoingMessagesTypeAlertNotificationBundle    EnumOutgoingMessagesType = "alert_notification_bundle"
-	EnumOutgoingMessagesTypeAlertStatusUpdate          EnumOutgoingMessagesType = "alert_status_update"
-	EnumOutgoingMessagesTypeAlertStatusUpdateBundle    EnumOutgoingMessagesType = "alert_status_update_bundle"
-	EnumOutgoingMessagesTypeScheduleOnCallNotification EnumOutgoingMessagesType = "schedule_on_call_notification"
-	EnumOutgoingMessagesTypeTestNotification           EnumOutgoingMessagesType = "test_notification"
-	EnumOutgoingMessagesTypeVerificationMessage        EnumOutgoingMessagesType = "verification_message"
+	EnumOutgoingMessagesTypeAlertBridgeInvite                 EnumOutgoingMessagesType = "alert_bridge_invite"
+	EnumOutgoingMessagesTypeAlertNotification                 EnumOutgoingMessagesType = "alert_notification"
+	EnumOutgoingMessagesTypeAlertNotificationBundle           EnumOutgoingMessagesType = "alert_notification_bundle"
+	EnumOutgoingMessagesTypeAlertStatusUpdate                 EnumOutgoingMessagesType = "alert_status_update"
+	EnumOutgoingMessagesTypeAlertStatusUpdateBundle           EnumOutgoingMessagesType = "alert_status_update_bundle"
+	EnumOutgoingMessagesTypeContactMethodDisabledNotification EnumOutgoingMessagesType = "contact_method_disabled_notification"
+	EnumOutgoingMessagesTypeScheduleHandoffNotification       EnumOutgoingMessagesType = "schedule_handoff_notification"
+	EnumOutgoingMessagesTypeScheduleOnCallNotification        EnumOutgoingMessagesType = "schedule_on_call_notification"
+	EnumOutgoingMessagesTypeTestNotification                  EnumOutgoingMessagesType = "test_notification"
+	EnumOutgoingMessagesTypeVerificationMessage               EnumOutgoingMessagesType = "verification_message"
 )
 
 func (e *EnumOutgoingMessagesType) Scan(src interface{}) error {
@@ -532,10 +809,11 @@ func (ns NullEnumOutgoingMessagesType) Value() (driver.Value, error) {
 type EnumRotationType string
 
 const (
-	EnumRotationTypeDaily   EnumRotationType = "daily"
-	EnumRotationTypeHourly  EnumRotationType = "hourly"
-	EnumRotationTypeMonthly EnumRotationType = "monthly"
-	EnumRotationTypeWeekly  EnumRotationType = "weekly"
+	EnumRotationTypeDaily      EnumRotationType = "daily"
+	EnumRotationTypeFixedHours EnumRotationType = "fixed_hours"
+	EnumRotationTypeHourly     EnumRotationType = "hourly"
+	EnumRotationTypeMonthly    EnumRotationType = "monthly"
+	EnumRotationTypeWeekly     EnumRotationType = "weekly"
 )
 
 func (e *EnumRotationType) Scan(src interface{}) error {
@@ -661,12 +939,13 @@ func (ns NullEnumThrottleType) Value() (driver.Value, error) {
 type EnumUserContactMethodType string
 
 const (
-	EnumUserContactMethodTypeEMAIL   EnumUserContactMethodType = "EMAIL"
-	EnumUserContactMethodTypePUSH    EnumUserContactMethodType = "PUSH"
-	EnumUserContactMethodTypeSLACKDM EnumUserContactMethodType = "SLACK_DM"
-	EnumUserContactMethodTypeSMS     EnumUserContactMethodType = "SMS"
-	EnumUserContactMethodTypeVOICE   EnumUserContactMethodType = "VOICE"
-	EnumUserContactMethodTypeWEBHOOK EnumUserContactMethodType = "WEBHOOK"
+	EnumUserContactMethodTypeEMAIL    EnumUserContactMethodType = "EMAIL"
+	EnumUserContactMethodTypePUSH     EnumUserContactMethodType = "PUSH"
+	EnumUserContactMethodTypeSLACKDM  EnumUserContactMethodType = "SLACK_DM"
+	EnumUserContactMethodTypeSMS      EnumUserContactMethodType = "SMS"
+	EnumUserContactMethodTypeTELEGRAM EnumUserContactMethodType = "TELEGRAM"
+	EnumUserContactMethodTypeVOICE    EnumUserContactMethodType = "VOICE"
+	EnumUserContactMethodTypeWEBHOOK  EnumUserContactMethodType = "WEBHOOK"
 )
 
 func (e *EnumUserContactMethodType) Scan(src interface{}) error {
@@ -748,23 +1027,82 @@ func (ns NullEnumUserRole) Value() (driver.Value, error) {
 }
 
 type Alert struct {
-	CreatedAt       time.Time
-	DedupKey        sql.NullString
-	Details         string
-	EscalationLevel int32
-	ID              int64
-	LastEscalation  sql.NullTime
-	LastProcessed   sql.NullTime
-	ServiceID       uuid.NullUUID
-	Source          EnumAlertSource
-	Status          EnumAlertStatus
-	Summary         string
+	CloseReason      NullEnumAlertCloseReason
+	CorrelationKey   sql.NullString
+	CreatedAt        time.Time
+	DedupKey         sql.NullString
+	Details          string
+	EscalationLevel  int32
+	ID               int64
+	IntegrationKeyID uuid.NullUUID
+	LastEscalation   sql.NullTime
+	LastOccurrence   sql.NullTime
+	LastProcessed    sql.NullTime
+	Meta             pqtype.NullRawMessage
+	OccurrenceCount  int32
+	ServiceID        uuid.NullUUID
+	Severity         EnumAlertSeverity
+	Source           EnumAlertSource
+	Status           EnumAlertStatus
+	Summary          string
+}
+
+type AlertArchive struct {
+	ArchivedAt  time.Time
+	CreatedAt   time.Time
+	Details     string
+	ID          int64
+	Logs        json.RawMessage
+	ServiceID   uuid.NullUUID
+	ServiceName string
+	Severity    EnumAlertSeverity
+	Source      EnumAlertSource
+	Summary     string
+}
+
+type AlertAssignment struct {
+	AlertID    int64
+	AssignedAt time.Time
+	UserID     uuid.UUID
+}
+
+type AlertClosedDedup struct {
+	AlertID   int64
+	ClosedAt  time.Time
+	DedupKey  string
+	ServiceID uuid.UUID
+}
+
+type AlertConferenceBridge struct {
+	AlertID       int64
+	AnnouncedAt   sql.NullTime
+	ConferenceSid sql.NullString
+	CreatedAt     time.Time
+	EndedAt       sql.NullTime
+}
+
+type AlertConferenceParticipant struct {
+	AlertID         int64
+	CallSid         string
+	ContactMethodID uuid.NullUUID
+	JoinedAt        time.Time
+	LeftAt          sql.NullTime
+	UserID          uuid.NullUUID
+}
+
+type AlertEnrichment struct {
+	AlertID   int64
+	CreatedAt time.Time
+	Error     string
 }
 
 type AlertFeedback struct {
 	AlertID     int64
+	Feedback    EnumAlertFeedback
 	ID          int64
 	NoiseReason string
+	UpdatedAt   time.Time
+	UserID      uuid.NullUUID
 }
 
 type AlertLog struct {
@@ -800,6 +1138,19 @@ type AlertStatusSubscription struct {
 	LastAlertStatus EnumAlertStatus
 }
 
+type AuditLog struct {
+	Action      string
+	ActorSource string
+	ActorUserID uuid.NullUUID
+	AfterData   pqtype.NullRawMessage
+	BeforeData  pqtype.NullRawMessage
+	CreatedAt   time.Time
+	EntityID    string
+	EntityType  string
+	Error       string
+	ID          int64
+}
+
 type AuthBasicUser struct {
 	ID           int64
 	PasswordHash string
@@ -837,6 +1188,16 @@ type AuthUserSession struct {
 	UserID       uuid.NullUUID
 }
 
+type BusinessHour struct {
+	Description   string
+	EndTime       time.Time
+	ID            uuid.UUID
+	Name          string
+	StartTime     time.Time
+	TimeZone      string
+	WeekdayFilter []bool
+}
+
 type Config struct {
 	CreatedAt time.Time
 	Data      []byte
@@ -863,15 +1224,31 @@ type EpStepOnCallUser struct {
 	UserID    uuid.UUID
 }
 
+type EpStepRoundRobinState struct {
+	EpStepID   uuid.UUID
+	Position   int32
+	RotationID uuid.UUID
+}
+
+type EpStepRoutingRequest struct {
+	AlertID   int64
+	ClaimedAt sql.NullTime
+	CreatedAt time.Time
+	EpStepID  uuid.UUID
+}
+
 type EscalationPolicy struct {
-	Description string
-	ID          uuid.UUID
-	Name        string
-	Repeat      int32
-	StepCount   int32
+	AckTimeoutMinutes int32
+	Description       string
+	ID                uuid.UUID
+	MaxNotifications  int32
+	Name              string
+	Repeat            int32
+	StepCount         int32
 }
 
 type EscalationPolicyAction struct {
+	BusinessHoursCondition NullEnumBusinessHoursCondition
 	ChannelID              uuid.NullUUID
 	EscalationPolicyStepID uuid.UUID
 	ID                     uuid.UUID
@@ -881,7 +1258,9 @@ type EscalationPolicyAction struct {
 }
 
 type EscalationPolicyState struct {
+	AcknowledgedAt             sql.NullTime
 	AlertID                    int64
+	EscalationExhaustedAt      sql.NullTime
 	EscalationPolicyID         uuid.UUID
 	EscalationPolicyStepID     uuid.NullUUID
 	EscalationPolicyStepNumber int32
@@ -890,14 +1269,24 @@ type EscalationPolicyState struct {
 	LastEscalation             sql.NullTime
 	LoopCount                  int32
 	NextEscalation             sql.NullTime
+	NotificationCount          int32
 	ServiceID                  uuid.UUID
+	SnoozeUntil                sql.NullTime
 }
 
 type EscalationPolicyStep struct {
-	Delay              int32
-	EscalationPolicyID uuid.UUID
-	ID                 uuid.UUID
-	StepNumber         int32
+	AssignmentStrategy     EnumEpStepAssignmentStrategy
+	ConferenceBridge       bool
+	Delay                  int32
+	DelayBusinessHoursID   uuid.NullUUID
+	EscalationPolicyID     uuid.UUID
+	ID                     uuid.UUID
+	LowUrgency             bool
+	MinSeverity            NullEnumAlertSeverity
+	OffHoursDelay          sql.NullInt32
+	RoutingBusinessHoursID uuid.NullUUID
+	RoutingWebhookUrl      sql.NullString
+	StepNumber             int32
 }
 
 type GorpMigration struct {
@@ -927,6 +1316,14 @@ type GqlApiKeyUsage struct {
 	UserAgent sql.NullString
 }
 
+type GqlApiKeyUsageHistory struct {
+	ApiKeyID  uuid.UUID
+	ID        int64
+	IpAddress pqtype.Inet
+	UsedAt    time.Time
+	UserAgent string
+}
+
 type HeartbeatMonitor struct {
 	HeartbeatInterval int64
 	ID                uuid.UUID
@@ -934,19 +1331,53 @@ type HeartbeatMonitor struct {
 	LastState         EnumHeartbeatState
 	Name              string
 	ServiceID         uuid.UUID
+	WarningInterval   sql.NullInt64
+}
+
+type Incident struct {
+	CorrelationRuleID uuid.NullUUID
+	CorrelationValue  sql.NullString
+	CreatedAt         time.Time
+	GroupedAck        bool
+	ID                uuid.UUID
+	Summary           string
+}
+
+type IncidentAlert struct {
+	AddedAt    time.Time
+	AlertID    int64
+	IncidentID uuid.UUID
+}
+
+type IncidentCorrelationRule struct {
+	CreatedAt        time.Time
+	GroupedAck       bool
+	ID               uuid.UUID
+	Key              string
+	Name             string
+	ProcessedAlertID int64
+	WindowMinutes    int32
 }
 
 type IntegrationKey struct {
-	ID        uuid.UUID
-	Name      string
-	ServiceID uuid.UUID
-	Type      EnumIntegrationKeysType
+	AlertRateLimit     sql.NullInt32
+	DedupWindowMinutes sql.NullInt32
+	DefaultLabels      pqtype.NullRawMessage
+	DroppedAlertCount  int64
+	FieldMapping       pqtype.NullRawMessage
+	ID                 uuid.UUID
+	Name               string
+	RouteField         sql.NullString
+	RouteLabelKey      sql.NullString
+	ServiceID          uuid.UUID
+	Type               EnumIntegrationKeysType
 }
 
 type Keyring struct {
 	ID               string
 	NextKey          []byte
 	NextRotation     sql.NullTime
+	RetireTimes      []byte
 	RotationCount    int64
 	SigningKey       []byte
 	VerificationKeys []byte
@@ -960,12 +1391,16 @@ type Label struct {
 }
 
 type NotificationChannel struct {
-	CreatedAt time.Time
-	ID        uuid.UUID
-	Meta      json.RawMessage
-	Name      string
-	Type      EnumNotifChannelType
-	Value     string
+	CreatedAt                  time.Time
+	ID                         uuid.UUID
+	Meta                       json.RawMessage
+	Name                       string
+	PagerdutyRoutingKey        []byte
+	Type                       EnumNotifChannelType
+	Value                      string
+	WebhookSecret              []byte
+	WebhookSecretPrev          []byte
+	WebhookSecretPrevExpiresAt sql.NullTime
 }
 
 type NotificationPolicyCycle struct {
@@ -973,37 +1408,54 @@ type NotificationPolicyCycle struct {
 	Checked     bool
 	ID          uuid.UUID
 	LastTick    sql.NullTime
+	LowUrgency  bool
 	RepeatCount int32
+	Shadow      bool
 	StartedAt   time.Time
 	UserID      uuid.UUID
 }
 
+type OutgoingMessageAttempt struct {
+	Attempt       int32
+	CreatedAt     time.Time
+	ID            int64
+	MessageID     uuid.UUID
+	Status        EnumOutgoingMessagesStatus
+	StatusDetails string
+}
+
 type OutgoingMessage struct {
-	AlertID                sql.NullInt64
-	AlertLogID             sql.NullInt64
-	ChannelID              uuid.NullUUID
-	ContactMethodID        uuid.NullUUID
-	CreatedAt              time.Time
-	CycleID                uuid.NullUUID
-	EscalationPolicyID     uuid.NullUUID
-	FiredAt                sql.NullTime
-	ID                     uuid.UUID
-	LastStatus             EnumOutgoingMessagesStatus
-	LastStatusAt           sql.NullTime
-	MessageType            EnumOutgoingMessagesType
-	NextRetryAt            sql.NullTime
-	ProviderMsgID          sql.NullString
-	ProviderSeq            int32
-	RetryCount             int32
-	ScheduleID             uuid.NullUUID
-	SendingDeadline        sql.NullTime
-	SentAt                 sql.NullTime
-	ServiceID              uuid.NullUUID
-	SrcValue               sql.NullString
-	StatusAlertIds         []int64
-	StatusDetails          string
-	UserID                 uuid.NullUUID
-	UserVerificationCodeID uuid.NullUUID
+	AlertID                 sql.NullInt64
+	AlertLogID              sql.NullInt64
+	ChannelID               uuid.NullUUID
+	ContactMethodID         uuid.NullUUID
+	CreatedAt               time.Time
+	CycleID                 uuid.NullUUID
+	DeadLetteredAt          sql.NullTime
+	DisabledContactMethodID uuid.NullUUID
+	EscalationPolicyID      uuid.NullUUID
+	FiredAt                 sql.NullTime
+	ID                      uuid.UUID
+	LastStatus              EnumOutgoingMessagesStatus
+	LastStatusAt            sql.NullTime
+	MessageType             EnumOutgoingMessagesType
+	NextRetryAt             sql.NullTime
+	ProviderMsgID           sql.NullString
+	ProviderSeq             int32
+	ReplayOfID              uuid.NullUUID
+	RetryCount              int32
+	ScheduleHandoffNoticeID uuid.NullUUID
+	ScheduleID              uuid.NullUUID
+	SendingDeadline         sql.NullTime
+	SentAt                  sql.NullTime
+	ServiceID               uuid.NullUUID
+	Shadow                  bool
+	SrcValue                sql.NullString
+	StatusAlertIds          []int64
+	StatusDetails           string
+	UserID                  uuid.NullUUID
+	UserVerificationCodeID  uuid.NullUUID
+	Watcher                 bool
 }
 
 type RegionID struct {
@@ -1028,6 +1480,15 @@ type RotationParticipant struct {
 	Position   int32
 	RotationID uuid.UUID
 	UserID     uuid.UUID
+	Weight     int32
+}
+
+type RotationShadow struct {
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	ID         uuid.UUID
+	RotationID uuid.UUID
+	UserID     uuid.UUID
 }
 
 type RotationState struct {
@@ -1054,9 +1515,20 @@ type ScheduleDatum struct {
 	ScheduleID    uuid.UUID
 }
 
+type ScheduleHandoffNotice struct {
+	CreatedAt  time.Time
+	ID         uuid.UUID
+	OnCall     bool
+	Resolved   bool
+	ScheduleID uuid.UUID
+	ShiftTime  time.Time
+	UserID     uuid.UUID
+}
+
 type ScheduleOnCallUser struct {
 	EndTime    sql.NullTime
 	ID         int64
+	Priority   int32
 	ScheduleID uuid.UUID
 	StartTime  time.Time
 	UserID     uuid.UUID
@@ -1069,6 +1541,7 @@ type ScheduleRule struct {
 	ID            uuid.UUID
 	IsActive      bool
 	Monday        bool
+	Priority      int32
 	Saturday      bool
 	ScheduleID    uuid.UUID
 	StartTime     time.Time
@@ -1080,12 +1553,36 @@ type ScheduleRule struct {
 	Wednesday     bool
 }
 
+type ServiceMaintenanceWindow struct {
+	Description string
+	EndTime     time.Time
+	ID          uuid.UUID
+	ServiceID   uuid.UUID
+	StartTime   time.Time
+}
+
+type ServiceWatcher struct {
+	ContactMethodID uuid.UUID
+	CreatedAt       time.Time
+	ID              uuid.UUID
+	ServiceID       uuid.UUID
+	UserID          uuid.UUID
+}
+
 type Service struct {
-	Description          string
-	EscalationPolicyID   uuid.UUID
-	ID                   uuid.UUID
-	MaintenanceExpiresAt sql.NullTime
-	Name                 string
+	Description              string
+	EnrichmentURL            string
+	EscalationPolicyID       uuid.UUID
+	ID                       uuid.UUID
+	MaintenanceExpiresAt     sql.NullTime
+	Name                     string
+	NotificationDelayMinutes int32
+	NotificationTemplate     string
+	PausedAt                 sql.NullTime
+	PausedByUserID           uuid.NullUUID
+	RequireCloseReason       bool
+	RunbookURL               string
+	TimeZone                 string
 }
 
 type SwitchoverLog struct {
@@ -1132,8 +1629,10 @@ type User struct {
 	Bio                           string
 	Email                         string
 	ID                            uuid.UUID
+	MaxNotificationsPerHour       sql.NullInt32
 	Name                          string
 	Role                          EnumUserRole
+	TimeZone                      sql.NullString
 }
 
 type UserCalendarSubscription struct {
@@ -1149,6 +1648,8 @@ type UserCalendarSubscription struct {
 }
 
 type UserContactMethod struct {
+	AutoDisabledAt      sql.NullTime
+	ConsecutiveFailures int32
 	Disabled            bool
 	EnableStatusUpdates bool
 	ID                  uuid.UUID
@@ -1161,6 +1662,12 @@ type UserContactMethod struct {
 	Value               string
 }
 
+type UserDoNotDisturb struct {
+	CreatedAt time.Time
+	ExpiresAt sql.NullTime
+	UserID    uuid.UUID
+}
+
 type UserFavorite struct {
 	ID                    int64
 	TgtEscalationPolicyID uuid.NullUUID
@@ -1171,32 +1678,63 @@ type UserFavorite struct {
 	UserID                uuid.UUID
 }
 
+type UserLabelGrant struct {
+	Key    string
+	UserID uuid.UUID
+	Value  string
+}
+
 type UserNotificationRule struct {
-	ContactMethodID uuid.UUID
-	CreatedAt       sql.NullTime
-	DelayMinutes    int32
-	ID              uuid.UUID
-	UserID          uuid.UUID
+	ContactMethodID            uuid.UUID
+	CreatedAt                  sql.NullTime
+	DelayMinutes               int32
+	ID                         uuid.UUID
+	Position                   int32
+	QuietHoursAllowHighUrgency bool
+	QuietHoursEnd              sql.NullTime
+	QuietHoursStart            sql.NullTime
+	StopOnSuccess              bool
+	Urgency                    NullEnumNotificationRuleUrgency
+	UserID                     uuid.UUID
 }
 
 type UserOverride struct {
 	AddUserID     uuid.NullUUID
 	EndTime       time.Time
 	ID            uuid.UUID
+	RecurrenceID  uuid.NullUUID
 	RemoveUserID  uuid.NullUUID
 	StartTime     time.Time
 	TgtScheduleID uuid.UUID
 }
 
+type UserOverrideRecurrence struct {
+	AddUserID     uuid.NullUUID
+	EndTime       time.Time
+	ID            uuid.UUID
+	RemoveUserID  uuid.NullUUID
+	StartTime     time.Time
+	TgtScheduleID uuid.UUID
+	Until         time.Time
+	Weekdays      []bool
+}
+
 type UserSlackDatum struct {
 	AccessToken string
 	ID          uuid.UUID
 }
 
+type UserUrgencyWindow struct {
+	EndTime   time.Time
+	StartTime time.Time
+	UserID    uuid.UUID
+}
+
 type UserVerificationCode struct {
 	Code            int32
 	ContactMethodID uuid.UUID
 	ExpiresAt       time.Time
 	ID              uuid.UUID
 	Sent            bool
+	Voice           bool
 }
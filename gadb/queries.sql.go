@@ -73,6 +73,48 @@ func (q *Queries) APIKeyDelete(ctx context.Context, arg APIKeyDeleteParams) erro
 	return err
 }
 
+const aPIKeyDeleteByCreator = `-- name: APIKeyDeleteByCreator :many
+UPDATE
+    gql_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE
+    created_by = $1
+    AND deleted_at IS NULL
+RETURNING
+    id
+`
+
+type APIKeyDeleteByCreatorParams struct {
+	CreatedBy uuid.NullUUID
+	DeletedBy uuid.NullUUID
+}
+
+// APIKeyDeleteByCreator marks all active API keys created by the given user as deleted, returning their IDs.
+func (q *Queries) APIKeyDeleteByCreator(ctx context.Context, arg APIKeyDeleteByCreatorParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyDeleteByCreator, arg.CreatedBy, arg.DeletedBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aPIKeyForUpdate = `-- name: APIKeyForUpdate :one
 SELECT
     name,
@@ -125,6 +167,23 @@ func (q *Queries) APIKeyInsert(ctx context.Context, arg APIKeyInsertParams) erro
 	return err
 }
 
+const aPIKeyInsertUsageHistory = `-- name: APIKeyInsertUsageHistory :exec
+INSERT INTO gql_api_key_usage_history(api_key_id, user_agent, ip_address)
+    VALUES ($1::uuid, $2::text, $3::inet)
+`
+
+type APIKeyInsertUsageHistoryParams struct {
+	KeyID     uuid.UUID
+	UserAgent string
+	IpAddress pqtype.Inet
+}
+
+// APIKeyInsertUsageHistory appends an entry to the usage history of an API key.
+func (q *Queries) APIKeyInsertUsageHistory(ctx context.Context, arg APIKeyInsertUsageHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, aPIKeyInsertUsageHistory, arg.KeyID, arg.UserAgent, arg.IpAddress)
+	return err
+}
+
 const aPIKeyList = `-- name: APIKeyList :many
 SELECT
     gql_api_keys.created_at, gql_api_keys.created_by, gql_api_keys.deleted_at, gql_api_keys.deleted_by, gql_api_keys.description, gql_api_keys.expires_at, gql_api_keys.id, gql_api_keys.name, gql_api_keys.policy, gql_api_keys.updated_at, gql_api_keys.updated_by,
@@ -194,6 +253,32 @@ func (q *Queries) APIKeyList(ctx context.Context) ([]APIKeyListRow, error) {
 	return items, nil
 }
 
+const aPIKeyPolicyForUpdate = `-- name: APIKeyPolicyForUpdate :one
+SELECT
+    policy,
+    expires_at
+FROM
+    gql_api_keys
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+    AND expires_at > now()
+FOR UPDATE
+`
+
+type APIKeyPolicyForUpdateRow struct {
+	Policy    json.RawMessage
+	ExpiresAt time.Time
+}
+
+// APIKeyPolicyForUpdate returns the policy and expiration of an active API key, locking the row.
+func (q *Queries) APIKeyPolicyForUpdate(ctx context.Context, id uuid.UUID) (APIKeyPolicyForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, aPIKeyPolicyForUpdate, id)
+	var i APIKeyPolicyForUpdateRow
+	err := row.Scan(&i.Policy, &i.ExpiresAt)
+	return i, err
+}
+
 const aPIKeyRecordUsage = `-- name: APIKeyRecordUsage :exec
 INSERT INTO gql_api_key_usage(api_key_id, user_agent, ip_address)
     VALUES ($1::uuid, $2::text, $3::inet)
@@ -214,6 +299,76 @@ func (q *Queries) APIKeyRecordUsage(ctx context.Context, arg APIKeyRecordUsagePa
 	return err
 }
 
+const aPIKeyScopeAlertServiceIDs = `-- name: APIKeyScopeAlertServiceIDs :many
+SELECT DISTINCT
+    service_id::uuid
+FROM
+    alerts
+WHERE
+    id = ANY ($1::bigint[])
+    AND service_id IS NOT NULL
+`
+
+// APIKeyScopeAlertServiceIDs returns the services of the given alerts.
+func (q *Queries) APIKeyScopeAlertServiceIDs(ctx context.Context, alertIds []int64) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyScopeAlertServiceIDs, pq.Array(alertIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var service_id uuid.UUID
+		if err := rows.Scan(&service_id); err != nil {
+			return nil, err
+		}
+		items = append(items, service_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aPIKeyScopeScheduleIDs = `-- name: APIKeyScopeScheduleIDs :many
+SELECT DISTINCT
+    act.schedule_id::uuid
+FROM
+    services svc
+    JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+    JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+WHERE
+    svc.id = ANY ($1::uuid[])
+    AND act.schedule_id IS NOT NULL
+`
+
+// APIKeyScopeScheduleIDs returns the schedules targeted by the escalation policies of the given services.
+func (q *Queries) APIKeyScopeScheduleIDs(ctx context.Context, serviceIds []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyScopeScheduleIDs, pq.Array(serviceIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var act_schedule_id uuid.UUID
+		if err := rows.Scan(&act_schedule_id); err != nil {
+			return nil, err
+		}
+		items = append(items, act_schedule_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aPIKeyUpdate = `-- name: APIKeyUpdate :exec
 UPDATE
     gql_api_keys
@@ -243,31 +398,64 @@ func (q *Queries) APIKeyUpdate(ctx context.Context, arg APIKeyUpdateParams) erro
 	return err
 }
 
-const alertFeedback = `-- name: AlertFeedback :many
+const aPIKeyUpdatePolicy = `-- name: APIKeyUpdatePolicy :exec
+UPDATE
+    gql_api_keys
+SET
+    policy = $2,
+    updated_at = now(),
+    updated_by = $3
+WHERE
+    id = $1
+`
+
+type APIKeyUpdatePolicyParams struct {
+	ID        uuid.UUID
+	Policy    json.RawMessage
+	UpdatedBy uuid.NullUUID
+}
+
+func (q *Queries) APIKeyUpdatePolicy(ctx context.Context, arg APIKeyUpdatePolicyParams) error {
+	_, err := q.db.ExecContext(ctx, aPIKeyUpdatePolicy, arg.ID, arg.Policy, arg.UpdatedBy)
+	return err
+}
+
+const aPIKeyUsageHistory = `-- name: APIKeyUsageHistory :many
 SELECT
-    alert_id,
-    noise_reason
+    used_at,
+    user_agent,
+    ip_address
 FROM
-    alert_feedback
+    gql_api_key_usage_history
 WHERE
-    alert_id = ANY($1::int[])
+    api_key_id = $1::uuid
+ORDER BY
+    id DESC
+LIMIT $2::int
 `
 
-type AlertFeedbackRow struct {
-	AlertID     int64
-	NoiseReason string
+type APIKeyUsageHistoryParams struct {
+	KeyID   uuid.UUID
+	MaxRows int32
 }
 
-func (q *Queries) AlertFeedback(ctx context.Context, dollar_1 []int32) ([]AlertFeedbackRow, error) {
-	rows, err := q.db.QueryContext(ctx, alertFeedback, pq.Array(dollar_1))
+type APIKeyUsageHistoryRow struct {
+	UsedAt    time.Time
+	UserAgent string
+	IpAddress pqtype.Inet
+}
+
+// APIKeyUsageHistory returns the most recent usage history entries of an API key.
+func (q *Queries) APIKeyUsageHistory(ctx context.Context, arg APIKeyUsageHistoryParams) ([]APIKeyUsageHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyUsageHistory, arg.KeyID, arg.MaxRows)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []AlertFeedbackRow
+	var items []APIKeyUsageHistoryRow
 	for rows.Next() {
-		var i AlertFeedbackRow
-		if err := rows.Scan(&i.AlertID, &i.NoiseReason); err != nil {
+		var i APIKeyUsageHistoryRow
+		if err := rows.Scan(&i.UsedAt, &i.UserAgent, &i.IpAddress); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -281,114 +469,537 @@ func (q *Queries) AlertFeedback(ctx context.Context, dollar_1 []int32) ([]AlertF
 	return items, nil
 }
 
-const alertHasEPState = `-- name: AlertHasEPState :one
+const alertActiveMaintWindow = `-- name: AlertActiveMaintWindow :one
 SELECT
-    EXISTS (
-        SELECT
-            1
-        FROM
-            escalation_policy_state
-        WHERE
-            alert_id = $1) AS has_ep_state
+    id,
+    description
+FROM
+    service_maintenance_windows
+WHERE
+    service_id = $1
+    AND start_time <= now()
+    AND end_time > now()
+ORDER BY
+    end_time DESC
+LIMIT 1
 `
 
-func (q *Queries) AlertHasEPState(ctx context.Context, alertID int64) (bool, error) {
-	row := q.db.QueryRowContext(ctx, alertHasEPState, alertID)
-	var has_ep_state bool
-	err := row.Scan(&has_ep_state)
-	return has_ep_state, err
+type AlertActiveMaintWindowRow struct {
+	ID          uuid.UUID
+	Description string
 }
 
-const alertLogHBIntervalMinutes = `-- name: AlertLogHBIntervalMinutes :one
+func (q *Queries) AlertActiveMaintWindow(ctx context.Context, serviceID uuid.UUID) (AlertActiveMaintWindowRow, error) {
+	row := q.db.QueryRowContext(ctx, alertActiveMaintWindow, serviceID)
+	var i AlertActiveMaintWindowRow
+	err := row.Scan(&i.ID, &i.Description)
+	return i, err
+}
+
+const alertAssign = `-- name: AlertAssign :one
+INSERT INTO alert_assignments(alert_id, user_id)
+    VALUES ($1, $2)
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        user_id = excluded.user_id, assigned_at = now()
+    RETURNING
+        (
+            SELECT
+                name
+            FROM
+                users
+            WHERE
+                id = alert_assignments.user_id) AS user_name
+`
+
+type AlertAssignParams struct {
+	AlertID int64
+	UserID  uuid.UUID
+}
+
+func (q *Queries) AlertAssign(ctx context.Context, arg AlertAssignParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, alertAssign, arg.AlertID, arg.UserID)
+	var user_name string
+	err := row.Scan(&user_name)
+	return user_name, err
+}
+
+const alertAssignNotify = `-- name: AlertAssignNotify :exec
+INSERT INTO notification_policy_cycles(alert_id, user_id)
 SELECT
-    (EXTRACT(EPOCH FROM heartbeat_interval) / 60)::int
-FROM
-    heartbeat_monitors
+    $1::int,
+    $2::uuid
 WHERE
-    id = $1
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            notification_policy_cycles
+        WHERE
+            alert_id = $1::int
+            AND user_id = $2::uuid
+            AND NOT shadow)
 `
 
-func (q *Queries) AlertLogHBIntervalMinutes(ctx context.Context, id uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, alertLogHBIntervalMinutes, id)
-	var column_1 int32
-	err := row.Scan(&column_1)
-	return column_1, err
+type AlertAssignNotifyParams struct {
+	AlertID int32
+	UserID  uuid.UUID
 }
 
-const alertLogInsertEP = `-- name: AlertLogInsertEP :exec
-INSERT INTO alert_logs(alert_id, event, sub_type, sub_user_id, sub_integration_key_id, sub_hb_monitor_id, sub_channel_id, sub_classifier, meta, message)
-SELECT
-    a.id,
-    $2,
-    $3,
-    $4,
-    $5,
-    $6,
-    $7,
-    $8,
-    $9,
-    $10
-FROM
-    alerts a
-    JOIN services svc ON svc.id = a.service_id
-        AND svc.escalation_policy_id = $1
+func (q *Queries) AlertAssignNotify(ctx context.Context, arg AlertAssignNotifyParams) error {
+	_, err := q.db.ExecContext(ctx, alertAssignNotify, arg.AlertID, arg.UserID)
+	return err
+}
+
+const alertAssignPauseEscalation = `-- name: AlertAssignPauseEscalation :exec
+UPDATE
+    escalation_policy_state
+SET
+    next_escalation = greatest(next_escalation, now() + make_interval(mins => $2))
 WHERE
-    a.status != 'closed'
+    alert_id = $1
+    AND last_escalation NOTNULL
 `
 
-type AlertLogInsertEPParams struct {
-	EscalationPolicyID  uuid.UUID
-	Event               EnumAlertLogEvent
-	SubType             NullEnumAlertLogSubjectType
-	SubUserID           uuid.NullUUID
-	SubIntegrationKeyID uuid.NullUUID
-	SubHbMonitorID      uuid.NullUUID
-	SubChannelID        uuid.NullUUID
-	SubClassifier       string
-	Meta                pqtype.NullRawMessage
-	Message             string
+type AlertAssignPauseEscalationParams struct {
+	AlertID int64
+	Mins    int32
 }
 
-func (q *Queries) AlertLogInsertEP(ctx context.Context, arg AlertLogInsertEPParams) error {
-	_, err := q.db.ExecContext(ctx, alertLogInsertEP,
-		arg.EscalationPolicyID,
-		arg.Event,
-		arg.SubType,
-		arg.SubUserID,
-		arg.SubIntegrationKeyID,
-		arg.SubHbMonitorID,
-		arg.SubChannelID,
-		arg.SubClassifier,
-		arg.Meta,
-		arg.Message,
-	)
+func (q *Queries) AlertAssignPauseEscalation(ctx context.Context, arg AlertAssignPauseEscalationParams) error {
+	_, err := q.db.ExecContext(ctx, alertAssignPauseEscalation, arg.AlertID, arg.Mins)
 	return err
 }
 
-const alertLogInsertMany = `-- name: AlertLogInsertMany :exec
-INSERT INTO alert_logs(alert_id, event, sub_type, sub_user_id, sub_integration_key_id, sub_hb_monitor_id, sub_channel_id, sub_classifier, meta, message)
+const alertAssignees = `-- name: AlertAssignees :many
 SELECT
-    unnest,
-    $2,
-    $3,
-    $4,
-    $5,
-    $6,
-    $7,
-    $8,
-    $9,
-    $10
+    alert_id,
+    user_id
 FROM
-    unnest($1::bigint[])
+    alert_assignments
+WHERE
+    alert_id = ANY ($1::bigint[])
 `
 
-type AlertLogInsertManyParams struct {
-	Column1             []int64
-	Event               EnumAlertLogEvent
-	SubType             NullEnumAlertLogSubjectType
-	SubUserID           uuid.NullUUID
-	SubIntegrationKeyID uuid.NullUUID
-	SubHbMonitorID      uuid.NullUUID
+type AlertAssigneesRow struct {
+	AlertID int64
+	UserID  uuid.UUID
+}
+
+func (q *Queries) AlertAssignees(ctx context.Context, dollar_1 []int64) ([]AlertAssigneesRow, error) {
+	rows, err := q.db.QueryContext(ctx, alertAssignees, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AlertAssigneesRow
+	for rows.Next() {
+		var i AlertAssigneesRow
+		if err := rows.Scan(&i.AlertID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alertCloseStaleDedup = `-- name: AlertCloseStaleDedup :one
+UPDATE
+    alerts
+SET
+    status = 'closed'
+WHERE
+    service_id = $1
+    AND dedup_key = $2
+    AND coalesce(last_occurrence, created_at) <= now() - make_interval(secs => $3::float8)
+RETURNING
+    id
+`
+
+type AlertCloseStaleDedupParams struct {
+	ServiceID     uuid.NullUUID
+	DedupKey      sql.NullString
+	WindowSeconds float64
+}
+
+// AlertCloseStaleDedup closes the open alert with the given dedup key if its last occurrence is older than
+// the dedup window, so a new alert is created instead of de-duplicating against it.
+func (q *Queries) AlertCloseStaleDedup(ctx context.Context, arg AlertCloseStaleDedupParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, alertCloseStaleDedup, arg.ServiceID, arg.DedupKey, arg.WindowSeconds)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const alertFeedback = `-- name: AlertFeedback :many
+SELECT
+    alert_id,
+    feedback,
+    noise_reason,
+    user_id,
+    updated_at
+FROM
+    alert_feedback
+WHERE
+    alert_id = ANY($1::int[])
+`
+
+type AlertFeedbackRow struct {
+	AlertID     int64
+	Feedback    EnumAlertFeedback
+	NoiseReason string
+	UserID      uuid.NullUUID
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) AlertFeedback(ctx context.Context, dollar_1 []int32) ([]AlertFeedbackRow, error) {
+	rows, err := q.db.QueryContext(ctx, alertFeedback, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AlertFeedbackRow
+	for rows.Next() {
+		var i AlertFeedbackRow
+		if err := rows.Scan(
+			&i.AlertID,
+			&i.Feedback,
+			&i.NoiseReason,
+			&i.UserID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alertFeedbackStats = `-- name: AlertFeedbackStats :many
+SELECT
+    a.service_id,
+    count(*) AS alert_count,
+    count(*) FILTER (WHERE f.feedback = 'actionable') AS actionable_count,
+    count(*) FILTER (WHERE f.feedback = 'noise') AS noise_count
+FROM
+    alerts a
+    LEFT JOIN alert_feedback f ON f.alert_id = a.id
+WHERE
+    a.service_id = ANY ($1::uuid[])
+    AND a.created_at >= $2::timestamptz
+    AND a.created_at < $3::timestamptz
+GROUP BY
+    a.service_id
+`
+
+type AlertFeedbackStatsParams struct {
+	ServiceIds []uuid.UUID
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+type AlertFeedbackStatsRow struct {
+	ServiceID       uuid.NullUUID
+	AlertCount      int64
+	ActionableCount int64
+	NoiseCount      int64
+}
+
+func (q *Queries) AlertFeedbackStats(ctx context.Context, arg AlertFeedbackStatsParams) ([]AlertFeedbackStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, alertFeedbackStats, pq.Array(arg.ServiceIds), arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AlertFeedbackStatsRow
+	for rows.Next() {
+		var i AlertFeedbackStatsRow
+		if err := rows.Scan(
+			&i.ServiceID,
+			&i.AlertCount,
+			&i.ActionableCount,
+			&i.NoiseCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alertHasEPState = `-- name: AlertHasEPState :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            escalation_policy_state
+        WHERE
+            alert_id = $1) AS has_ep_state
+`
+
+func (q *Queries) AlertHasEPState(ctx context.Context, alertID int64) (bool, error) {
+	row := q.db.QueryRowContext(ctx, alertHasEPState, alertID)
+	var has_ep_state bool
+	err := row.Scan(&has_ep_state)
+	return has_ep_state, err
+}
+
+const alertHasOpenDedup = `-- name: AlertHasOpenDedup :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            alerts
+        WHERE
+            service_id = $1
+            AND dedup_key = $2) AS has_open
+`
+
+type AlertHasOpenDedupParams struct {
+	ServiceID uuid.NullUUID
+	DedupKey  sql.NullString
+}
+
+func (q *Queries) AlertHasOpenDedup(ctx context.Context, arg AlertHasOpenDedupParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, alertHasOpenDedup, arg.ServiceID, arg.DedupKey)
+	var has_open bool
+	err := row.Scan(&has_open)
+	return has_open, err
+}
+
+const alertIntKeyDedupWindow = `-- name: AlertIntKeyDedupWindow :one
+SELECT
+    dedup_window_minutes::int
+FROM
+    integration_keys
+WHERE
+    id = $1
+    AND dedup_window_minutes NOTNULL
+`
+
+// AlertIntKeyDedupWindow returns the dedup window of an integration key. No rows are returned if the key
+// does not have one.
+func (q *Queries) AlertIntKeyDedupWindow(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, alertIntKeyDedupWindow, id)
+	var dedup_window_minutes int32
+	err := row.Scan(&dedup_window_minutes)
+	return dedup_window_minutes, err
+}
+
+const alertIntKeyDefaultLabels = `-- name: AlertIntKeyDefaultLabels :one
+SELECT
+    default_labels
+FROM
+    integration_keys
+WHERE
+    id = $1
+    AND default_labels NOTNULL
+`
+
+// AlertIntKeyDefaultLabels returns the default labels of an integration key. No rows are returned if the key
+// does not have any.
+func (q *Queries) AlertIntKeyDefaultLabels(ctx context.Context, id uuid.UUID) (pqtype.NullRawMessage, error) {
+	row := q.db.QueryRowContext(ctx, alertIntKeyDefaultLabels, id)
+	var default_labels pqtype.NullRawMessage
+	err := row.Scan(&default_labels)
+	return default_labels, err
+}
+
+const alertIntKeyDropAlert = `-- name: AlertIntKeyDropAlert :one
+UPDATE
+    integration_keys
+SET
+    dropped_alert_count = dropped_alert_count + 1
+WHERE
+    id = $1
+RETURNING
+    name,
+    dropped_alert_count
+`
+
+type AlertIntKeyDropAlertRow struct {
+	Name              string
+	DroppedAlertCount int64
+}
+
+func (q *Queries) AlertIntKeyDropAlert(ctx context.Context, id uuid.UUID) (AlertIntKeyDropAlertRow, error) {
+	row := q.db.QueryRowContext(ctx, alertIntKeyDropAlert, id)
+	var i AlertIntKeyDropAlertRow
+	err := row.Scan(&i.Name, &i.DroppedAlertCount)
+	return i, err
+}
+
+const alertIntKeyRateLimit = `-- name: AlertIntKeyRateLimit :one
+SELECT
+    k.alert_rate_limit::int AS alert_rate_limit,
+(
+        SELECT
+            count(*)
+        FROM
+            alerts a
+        WHERE
+            a.integration_key_id = k.id
+            AND a.created_at > now() - '1 minute'::interval)::int AS recent_count
+FROM
+    integration_keys k
+WHERE
+    k.id = $1
+    AND k.alert_rate_limit NOTNULL
+FOR UPDATE
+`
+
+type AlertIntKeyRateLimitRow struct {
+	AlertRateLimit int32
+	RecentCount    int32
+}
+
+// AlertIntKeyRateLimit returns the rate limit and the number of alerts created in the last minute for an
+// integration key, locking it so concurrent requests are counted correctly. No rows are returned if the
+// key is not rate limited.
+func (q *Queries) AlertIntKeyRateLimit(ctx context.Context, id uuid.UUID) (AlertIntKeyRateLimitRow, error) {
+	row := q.db.QueryRowContext(ctx, alertIntKeyRateLimit, id)
+	var i AlertIntKeyRateLimitRow
+	err := row.Scan(&i.AlertRateLimit, &i.RecentCount)
+	return i, err
+}
+
+const alertLockSvcByLabel = `-- name: AlertLockSvcByLabel :exec
+SELECT
+    1
+FROM
+    services svc
+    JOIN labels l ON l.tgt_service_id = svc.id
+        AND l.key = $1
+        AND ($2::text = '*'
+            OR l.value = $2)
+WHERE
+    $3::uuid IS NULL
+    OR svc.id = $3
+FOR UPDATE
+    OF svc
+`
+
+type AlertLockSvcByLabelParams struct {
+	LabelKey   string
+	LabelValue string
+	ServiceID  uuid.NullUUID
+}
+
+func (q *Queries) AlertLockSvcByLabel(ctx context.Context, arg AlertLockSvcByLabelParams) error {
+	_, err := q.db.ExecContext(ctx, alertLockSvcByLabel, arg.LabelKey, arg.LabelValue, arg.ServiceID)
+	return err
+}
+
+const alertLogHBIntervalMinutes = `-- name: AlertLogHBIntervalMinutes :one
+SELECT
+    (EXTRACT(EPOCH FROM heartbeat_interval) / 60)::int
+FROM
+    heartbeat_monitors
+WHERE
+    id = $1
+`
+
+func (q *Queries) AlertLogHBIntervalMinutes(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, alertLogHBIntervalMinutes, id)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const alertLogInsertEP = `-- name: AlertLogInsertEP :exec
+INSERT INTO alert_logs(alert_id, event, sub_type, sub_user_id, sub_integration_key_id, sub_hb_monitor_id, sub_channel_id, sub_classifier, meta, message)
+SELECT
+    a.id,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+        AND svc.escalation_policy_id = $1
+WHERE
+    a.status != 'closed'
+`
+
+type AlertLogInsertEPParams struct {
+	EscalationPolicyID  uuid.UUID
+	Event               EnumAlertLogEvent
+	SubType             NullEnumAlertLogSubjectType
+	SubUserID           uuid.NullUUID
+	SubIntegrationKeyID uuid.NullUUID
+	SubHbMonitorID      uuid.NullUUID
+	SubChannelID        uuid.NullUUID
+	SubClassifier       string
+	Meta                pqtype.NullRawMessage
+	Message             string
+}
+
+func (q *Queries) AlertLogInsertEP(ctx context.Context, arg AlertLogInsertEPParams) error {
+	_, err := q.db.ExecContext(ctx, alertLogInsertEP,
+		arg.EscalationPolicyID,
+		arg.Event,
+		arg.SubType,
+		arg.SubUserID,
+		arg.SubIntegrationKeyID,
+		arg.SubHbMonitorID,
+		arg.SubChannelID,
+		arg.SubClassifier,
+		arg.Meta,
+		arg.Message,
+	)
+	return err
+}
+
+const alertLogInsertMany = `-- name: AlertLogInsertMany :exec
+INSERT INTO alert_logs(alert_id, event, sub_type, sub_user_id, sub_integration_key_id, sub_hb_monitor_id, sub_channel_id, sub_classifier, meta, message)
+SELECT
+    unnest,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10
+FROM
+    unnest($1::bigint[])
+`
+
+type AlertLogInsertManyParams struct {
+	Column1             []int64
+	Event               EnumAlertLogEvent
+	SubType             NullEnumAlertLogSubjectType
+	SubUserID           uuid.NullUUID
+	SubIntegrationKeyID uuid.NullUUID
+	SubHbMonitorID      uuid.NullUUID
 	SubChannelID        uuid.NullUUID
 	SubClassifier       string
 	Meta                pqtype.NullRawMessage
@@ -479,6 +1090,137 @@ func (q *Queries) AlertLogLookupCMType(ctx context.Context, id uuid.UUID) (EnumU
 	return cm_type, err
 }
 
+const alertRequiresCloseReasonByLabel = `-- name: AlertRequiresCloseReasonByLabel :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            alerts a
+            JOIN services svc ON svc.id = a.service_id
+                AND svc.require_close_reason
+            JOIN labels l ON l.tgt_service_id = svc.id
+                AND l.key = $1
+                AND ($2::text = '*'
+                    OR l.value = $2)
+        WHERE ($3::uuid IS NULL
+            OR svc.id = $3)
+        AND a.status != 'closed')
+`
+
+type AlertRequiresCloseReasonByLabelParams struct {
+	LabelKey   string
+	LabelValue string
+	ServiceID  uuid.NullUUID
+}
+
+func (q *Queries) AlertRequiresCloseReasonByLabel(ctx context.Context, arg AlertRequiresCloseReasonByLabelParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, alertRequiresCloseReasonByLabel, arg.LabelKey, arg.LabelValue, arg.ServiceID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const alertSnoozeMany = `-- name: AlertSnoozeMany :many
+UPDATE
+    escalation_policy_state state
+SET
+    snooze_until = now() + make_interval(mins => $2)
+FROM
+    alerts a
+WHERE
+    state.alert_id = ANY ($1::bigint[])
+    AND a.id = state.alert_id
+    AND a.status = 'active'
+RETURNING
+    state.alert_id
+`
+
+type AlertSnoozeManyParams struct {
+	Column1 []int64
+	Mins    int32
+}
+
+func (q *Queries) AlertSnoozeMany(ctx context.Context, arg AlertSnoozeManyParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, alertSnoozeMany, pq.Array(arg.Column1), arg.Mins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var alert_id int64
+		if err := rows.Scan(&alert_id); err != nil {
+			return nil, err
+		}
+		items = append(items, alert_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alertUpdateStatusByLabel = `-- name: AlertUpdateStatusByLabel :many
+UPDATE
+    alerts a
+SET
+    status = $1::enum_alert_status,
+    close_reason = coalesce($2::enum_alert_close_reason, a.close_reason)
+FROM
+    labels l
+WHERE
+    l.tgt_service_id = a.service_id
+    AND l.key = $3
+    AND ($4::text = '*'
+        OR l.value = $4)
+    AND ($5::uuid IS NULL
+        OR a.service_id = $5)
+    AND a.status < $1::enum_alert_status
+RETURNING
+    a.id
+`
+
+type AlertUpdateStatusByLabelParams struct {
+	NewStatus   EnumAlertStatus
+	CloseReason NullEnumAlertCloseReason
+	LabelKey    string
+	LabelValue  string
+	ServiceID   uuid.NullUUID
+}
+
+func (q *Queries) AlertUpdateStatusByLabel(ctx context.Context, arg AlertUpdateStatusByLabelParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, alertUpdateStatusByLabel,
+		arg.NewStatus,
+		arg.CloseReason,
+		arg.LabelKey,
+		arg.LabelValue,
+		arg.ServiceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const allPendingMsgDests = `-- name: AllPendingMsgDests :many
 SELECT DISTINCT
     usr.name AS user_name,
@@ -604,16 +1346,187 @@ RETURNING
     subject_id
 `
 
-type AuthLinkUseReqRow struct {
-	ProviderID string
-	SubjectID  string
+type AuthLinkUseReqRow struct {
+	ProviderID string
+	SubjectID  string
+}
+
+func (q *Queries) AuthLinkUseReq(ctx context.Context, id uuid.UUID) (AuthLinkUseReqRow, error) {
+	row := q.db.QueryRowContext(ctx, authLinkUseReq, id)
+	var i AuthLinkUseReqRow
+	err := row.Scan(&i.ProviderID, &i.SubjectID)
+	return i, err
+}
+
+const businessHoursCreate = `-- name: BusinessHoursCreate :exec
+INSERT INTO business_hours(id, name, description, time_zone, weekday_filter, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type BusinessHoursCreateParams struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	TimeZone      string
+	WeekdayFilter []bool
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+func (q *Queries) BusinessHoursCreate(ctx context.Context, arg BusinessHoursCreateParams) error {
+	_, err := q.db.ExecContext(ctx, businessHoursCreate,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.TimeZone,
+		pq.Array(arg.WeekdayFilter),
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const businessHoursDelete = `-- name: BusinessHoursDelete :exec
+DELETE FROM business_hours
+WHERE id = $1
+`
+
+func (q *Queries) BusinessHoursDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, businessHoursDelete, id)
+	return err
+}
+
+const businessHoursFindAll = `-- name: BusinessHoursFindAll :many
+SELECT
+    id,
+    name,
+    description,
+    time_zone,
+    weekday_filter,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM
+    business_hours
+ORDER BY
+    name
+`
+
+type BusinessHoursFindAllRow struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	TimeZone      string
+	WeekdayFilter []bool
+	StartTime     string
+	EndTime       string
+}
+
+func (q *Queries) BusinessHoursFindAll(ctx context.Context) ([]BusinessHoursFindAllRow, error) {
+	rows, err := q.db.QueryContext(ctx, businessHoursFindAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BusinessHoursFindAllRow
+	for rows.Next() {
+		var i BusinessHoursFindAllRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.TimeZone,
+			pq.Array(&i.WeekdayFilter),
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const businessHoursFindOne = `-- name: BusinessHoursFindOne :one
+SELECT
+    id,
+    name,
+    description,
+    time_zone,
+    weekday_filter,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM
+    business_hours
+WHERE
+    id = $1
+`
+
+type BusinessHoursFindOneRow struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	TimeZone      string
+	WeekdayFilter []bool
+	StartTime     string
+	EndTime       string
+}
+
+func (q *Queries) BusinessHoursFindOne(ctx context.Context, id uuid.UUID) (BusinessHoursFindOneRow, error) {
+	row := q.db.QueryRowContext(ctx, businessHoursFindOne, id)
+	var i BusinessHoursFindOneRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.TimeZone,
+		pq.Array(&i.WeekdayFilter),
+		&i.StartTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const businessHoursUpdate = `-- name: BusinessHoursUpdate :exec
+UPDATE
+    business_hours
+SET
+    name = $2,
+    description = $3,
+    time_zone = $4,
+    weekday_filter = $5,
+    start_time = $6,
+    end_time = $7
+WHERE
+    id = $1
+`
+
+type BusinessHoursUpdateParams struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	TimeZone      string
+	WeekdayFilter []bool
+	StartTime     time.Time
+	EndTime       time.Time
 }
 
-func (q *Queries) AuthLinkUseReq(ctx context.Context, id uuid.UUID) (AuthLinkUseReqRow, error) {
-	row := q.db.QueryRowContext(ctx, authLinkUseReq, id)
-	var i AuthLinkUseReqRow
-	err := row.Scan(&i.ProviderID, &i.SubjectID)
-	return i, err
+func (q *Queries) BusinessHoursUpdate(ctx context.Context, arg BusinessHoursUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, businessHoursUpdate,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.TimeZone,
+		pq.Array(arg.WeekdayFilter),
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
 }
 
 const calSubAuthUser = `-- name: CalSubAuthUser :one
@@ -641,6 +1554,18 @@ SELECT
     now()::timestamptz AS now,
     sub.schedule_id,
     sched.name AS schedule_name,
+    sched.time_zone AS schedule_time_zone,
+    array(
+        SELECT DISTINCT
+            svc.name
+        FROM
+            escalation_policy_actions act
+            JOIN escalation_policy_steps step ON step.id = act.escalation_policy_step_id
+            JOIN services svc ON svc.escalation_policy_id = step.escalation_policy_id
+        WHERE
+            act.schedule_id = sub.schedule_id
+        ORDER BY
+            svc.name)::text[] AS service_names,
     sub.config,
     sub.user_id
 FROM
@@ -651,11 +1576,13 @@ WHERE
 `
 
 type CalSubRenderInfoRow struct {
-	Now          time.Time
-	ScheduleID   uuid.UUID
-	ScheduleName string
-	Config       json.RawMessage
-	UserID       uuid.UUID
+	Now              time.Time
+	ScheduleID       uuid.UUID
+	ScheduleName     string
+	ScheduleTimeZone string
+	ServiceNames     []string
+	Config           json.RawMessage
+	UserID           uuid.UUID
 }
 
 func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRenderInfoRow, error) {
@@ -665,12 +1592,52 @@ func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRen
 		&i.Now,
 		&i.ScheduleID,
 		&i.ScheduleName,
+		&i.ScheduleTimeZone,
+		pq.Array(&i.ServiceNames),
 		&i.Config,
 		&i.UserID,
 	)
 	return i, err
 }
 
+const calSubUserNames = `-- name: CalSubUserNames :many
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type CalSubUserNamesRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]CalSubUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubUserNames, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubUserNamesRow
+	for rows.Next() {
+		var i CalSubUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createCalSub = `-- name: CreateCalSub :one
 INSERT INTO user_calendar_subscriptions (
         id,
@@ -787,79 +1754,400 @@ FROM user_calendar_subscriptions
 WHERE id = $1
 `
 
-type FindOneCalSubRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
+type FindOneCalSubRow struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.UUID
+	Config     json.RawMessage
+	LastAccess sql.NullTime
+}
+
+func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSubRow, error) {
+	row := q.db.QueryRowContext(ctx, findOneCalSub, id)
+	var i FindOneCalSubRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Disabled,
+		&i.ScheduleID,
+		&i.Config,
+		&i.LastAccess,
+	)
+	return i, err
+}
+
+const findOneCalSubForUpdate = `-- name: FindOneCalSubForUpdate :one
+SELECT id,
+    NAME,
+    user_id,
+    disabled,
+    schedule_id,
+    config,
+    last_access
+FROM user_calendar_subscriptions
+WHERE id = $1 FOR
+UPDATE
+`
+
+type FindOneCalSubForUpdateRow struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.UUID
+	Config     json.RawMessage
+	LastAccess sql.NullTime
+}
+
+func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (FindOneCalSubForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, findOneCalSubForUpdate, id)
+	var i FindOneCalSubForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Disabled,
+		&i.ScheduleID,
+		&i.Config,
+		&i.LastAccess,
+	)
+	return i, err
+}
+
+const incidentAddAlerts = `-- name: IncidentAddAlerts :exec
+INSERT INTO incident_alerts(incident_id, alert_id)
+SELECT
+    $1::uuid,
+    unnest($2::bigint[])
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        incident_id = excluded.incident_id, added_at = now()
+`
+
+type IncidentAddAlertsParams struct {
+	IncidentID uuid.UUID
+	AlertIds   []int64
+}
+
+func (q *Queries) IncidentAddAlerts(ctx context.Context, arg IncidentAddAlertsParams) error {
+	_, err := q.db.ExecContext(ctx, incidentAddAlerts, arg.IncidentID, pq.Array(arg.AlertIds))
+	return err
+}
+
+const incidentAlertIDs = `-- name: IncidentAlertIDs :many
+SELECT
+    alert_id
+FROM
+    incident_alerts
+WHERE
+    incident_id = $1
+ORDER BY
+    alert_id DESC
+LIMIT 500
+`
+
+func (q *Queries) IncidentAlertIDs(ctx context.Context, incidentID uuid.UUID) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, incidentAlertIDs, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var alert_id int64
+		if err := rows.Scan(&alert_id); err != nil {
+			return nil, err
+		}
+		items = append(items, alert_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incidentCreate = `-- name: IncidentCreate :exec
+INSERT INTO incidents(id, summary, grouped_ack)
+    VALUES ($1, $2, $3)
+`
+
+type IncidentCreateParams struct {
+	ID         uuid.UUID
+	Summary    string
+	GroupedAck bool
+}
+
+func (q *Queries) IncidentCreate(ctx context.Context, arg IncidentCreateParams) error {
+	_, err := q.db.ExecContext(ctx, incidentCreate, arg.ID, arg.Summary, arg.GroupedAck)
+	return err
+}
+
+const incidentDeleteIfEmpty = `-- name: IncidentDeleteIfEmpty :exec
+DELETE FROM incidents i
+WHERE i.id = $1
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            incident_alerts ia
+        WHERE
+            ia.incident_id = i.id)
+`
+
+func (q *Queries) IncidentDeleteIfEmpty(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, incidentDeleteIfEmpty, id)
+	return err
+}
+
+const incidentFindByAlert = `-- name: IncidentFindByAlert :one
+SELECT
+    i.correlation_rule_id,
+    i.created_at,
+    i.grouped_ack,
+    i.id,
+    i.summary
+FROM
+    incidents i
+    JOIN incident_alerts ia ON ia.incident_id = i.id
+WHERE
+    ia.alert_id = $1
+`
+
+type IncidentFindByAlertRow struct {
+	CorrelationRuleID uuid.NullUUID
+	CreatedAt         time.Time
+	GroupedAck        bool
+	ID                uuid.UUID
+	Summary           string
+}
+
+func (q *Queries) IncidentFindByAlert(ctx context.Context, alertID int64) (IncidentFindByAlertRow, error) {
+	row := q.db.QueryRowContext(ctx, incidentFindByAlert, alertID)
+	var i IncidentFindByAlertRow
+	err := row.Scan(
+		&i.CorrelationRuleID,
+		&i.CreatedAt,
+		&i.GroupedAck,
+		&i.ID,
+		&i.Summary,
+	)
+	return i, err
+}
+
+const incidentFindOne = `-- name: IncidentFindOne :one
+SELECT
+    correlation_rule_id,
+    created_at,
+    grouped_ack,
+    id,
+    summary
+FROM
+    incidents
+WHERE
+    id = $1
+`
+
+type IncidentFindOneRow struct {
+	CorrelationRuleID uuid.NullUUID
+	CreatedAt         time.Time
+	GroupedAck        bool
+	ID                uuid.UUID
+	Summary           string
+}
+
+func (q *Queries) IncidentFindOne(ctx context.Context, id uuid.UUID) (IncidentFindOneRow, error) {
+	row := q.db.QueryRowContext(ctx, incidentFindOne, id)
+	var i IncidentFindOneRow
+	err := row.Scan(
+		&i.CorrelationRuleID,
+		&i.CreatedAt,
+		&i.GroupedAck,
+		&i.ID,
+		&i.Summary,
+	)
+	return i, err
+}
+
+const incidentRemoveAlerts = `-- name: IncidentRemoveAlerts :exec
+DELETE FROM incident_alerts
+WHERE incident_id = $1
+    AND alert_id = ANY ($2::bigint[])
+`
+
+type IncidentRemoveAlertsParams struct {
+	IncidentID uuid.UUID
+	AlertIds   []int64
+}
+
+func (q *Queries) IncidentRemoveAlerts(ctx context.Context, arg IncidentRemoveAlertsParams) error {
+	_, err := q.db.ExecContext(ctx, incidentRemoveAlerts, arg.IncidentID, pq.Array(arg.AlertIds))
+	return err
+}
+
+const incidentRuleCreate = `-- name: IncidentRuleCreate :exec
+INSERT INTO incident_correlation_rules(id, name, key, window_minutes, grouped_ack, processed_alert_id)
+    VALUES ($1, $2, $3, $4, $5, coalesce((
+            SELECT
+                max(id)
+            FROM alerts), 0))
+`
+
+type IncidentRuleCreateParams struct {
+	ID            uuid.UUID
+	Name          string
+	Key           string
+	WindowMinutes int32
+	GroupedAck    bool
+}
+
+func (q *Queries) IncidentRuleCreate(ctx context.Context, arg IncidentRuleCreateParams) error {
+	_, err := q.db.ExecContext(ctx, incidentRuleCreate,
+		arg.ID,
+		arg.Name,
+		arg.Key,
+		arg.WindowMinutes,
+		arg.GroupedAck,
+	)
+	return err
+}
+
+const incidentRuleDelete = `-- name: IncidentRuleDelete :exec
+DELETE FROM incident_correlation_rules
+WHERE id = $1
+`
+
+func (q *Queries) IncidentRuleDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, incidentRuleDelete, id)
+	return err
+}
+
+const incidentRuleFindAll = `-- name: IncidentRuleFindAll :many
+SELECT
+    grouped_ack,
+    id,
+    key,
+    name,
+    window_minutes
+FROM
+    incident_correlation_rules
+ORDER BY
+    name
+`
+
+type IncidentRuleFindAllRow struct {
+	GroupedAck    bool
+	ID            uuid.UUID
+	Key           string
+	Name          string
+	WindowMinutes int32
+}
+
+func (q *Queries) IncidentRuleFindAll(ctx context.Context) ([]IncidentRuleFindAllRow, error) {
+	rows, err := q.db.QueryContext(ctx, incidentRuleFindAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IncidentRuleFindAllRow
+	for rows.Next() {
+		var i IncidentRuleFindAllRow
+		if err := rows.Scan(
+			&i.GroupedAck,
+			&i.ID,
+			&i.Key,
+			&i.Name,
+			&i.WindowMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incidentRuleFindOne = `-- name: IncidentRuleFindOne :one
+SELECT
+    grouped_ack,
+    id,
+    key,
+    name,
+    window_minutes
+FROM
+    incident_correlation_rules
+WHERE
+    id = $1
+`
+
+type IncidentRuleFindOneRow struct {
+	GroupedAck    bool
+	ID            uuid.UUID
+	Key           string
+	Name          string
+	WindowMinutes int32
 }
 
-func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSubRow, error) {
-	row := q.db.QueryRowContext(ctx, findOneCalSub, id)
-	var i FindOneCalSubRow
+func (q *Queries) IncidentRuleFindOne(ctx context.Context, id uuid.UUID) (IncidentRuleFindOneRow, error) {
+	row := q.db.QueryRowContext(ctx, incidentRuleFindOne, id)
+	var i IncidentRuleFindOneRow
 	err := row.Scan(
+		&i.GroupedAck,
 		&i.ID,
+		&i.Key,
 		&i.Name,
-		&i.UserID,
-		&i.Disabled,
-		&i.ScheduleID,
-		&i.Config,
-		&i.LastAccess,
+		&i.WindowMinutes,
 	)
 	return i, err
 }
 
-const findOneCalSubForUpdate = `-- name: FindOneCalSubForUpdate :one
-SELECT id,
-    NAME,
-    user_id,
-    disabled,
-    schedule_id,
-    config,
-    last_access
-FROM user_calendar_subscriptions
-WHERE id = $1 FOR
+const incidentUpdate = `-- name: IncidentUpdate :exec
 UPDATE
+    incidents
+SET
+    summary = $2,
+    grouped_ack = $3
+WHERE
+    id = $1
 `
 
-type FindOneCalSubForUpdateRow struct {
+type IncidentUpdateParams struct {
 	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
+	Summary    string
+	GroupedAck bool
 }
 
-func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (FindOneCalSubForUpdateRow, error) {
-	row := q.db.QueryRowContext(ctx, findOneCalSubForUpdate, id)
-	var i FindOneCalSubForUpdateRow
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.UserID,
-		&i.Disabled,
-		&i.ScheduleID,
-		&i.Config,
-		&i.LastAccess,
-	)
-	return i, err
+func (q *Queries) IncidentUpdate(ctx context.Context, arg IncidentUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, incidentUpdate, arg.ID, arg.Summary, arg.GroupedAck)
+	return err
 }
 
 const intKeyCreate = `-- name: IntKeyCreate :exec
-INSERT INTO integration_keys(id, name, type, service_id)
-    VALUES ($1, $2, $3, $4)
+INSERT INTO integration_keys(id, name, type, service_id, route_label_key, route_field, alert_rate_limit, field_mapping, dedup_window_minutes, default_labels)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 `
 
 type IntKeyCreateParams struct {
-	ID        uuid.UUID
-	Name      string
-	Type      EnumIntegrationKeysType
-	ServiceID uuid.UUID
+	ID                 uuid.UUID
+	Name               string
+	Type               EnumIntegrationKeysType
+	ServiceID          uuid.UUID
+	RouteLabelKey      sql.NullString
+	RouteField         sql.NullString
+	AlertRateLimit     sql.NullInt32
+	FieldMapping       pqtype.NullRawMessage
+	DedupWindowMinutes sql.NullInt32
+	DefaultLabels      pqtype.NullRawMessage
 }
 
 func (q *Queries) IntKeyCreate(ctx context.Context, arg IntKeyCreateParams) error {
@@ -868,6 +2156,12 @@ func (q *Queries) IntKeyCreate(ctx context.Context, arg IntKeyCreateParams) erro
 		arg.Name,
 		arg.Type,
 		arg.ServiceID,
+		arg.RouteLabelKey,
+		arg.RouteField,
+		arg.AlertRateLimit,
+		arg.FieldMapping,
+		arg.DedupWindowMinutes,
+		arg.DefaultLabels,
 	)
 	return err
 }
@@ -887,7 +2181,14 @@ SELECT
     id,
     name,
     type,
-    service_id
+    service_id,
+    route_label_key,
+    route_field,
+    alert_rate_limit,
+    dedup_window_minutes,
+    dropped_alert_count,
+    field_mapping,
+    default_labels
 FROM
     integration_keys
 WHERE
@@ -895,10 +2196,17 @@ WHERE
 `
 
 type IntKeyFindByServiceRow struct {
-	ID        uuid.UUID
-	Name      string
-	Type      EnumIntegrationKeysType
-	ServiceID uuid.UUID
+	ID                 uuid.UUID
+	Name               string
+	Type               EnumIntegrationKeysType
+	ServiceID          uuid.UUID
+	RouteLabelKey      sql.NullString
+	RouteField         sql.NullString
+	AlertRateLimit     sql.NullInt32
+	DedupWindowMinutes sql.NullInt32
+	DroppedAlertCount  int64
+	FieldMapping       pqtype.NullRawMessage
+	DefaultLabels      pqtype.NullRawMessage
 }
 
 func (q *Queries) IntKeyFindByService(ctx context.Context, serviceID uuid.UUID) ([]IntKeyFindByServiceRow, error) {
@@ -915,6 +2223,13 @@ func (q *Queries) IntKeyFindByService(ctx context.Context, serviceID uuid.UUID)
 			&i.Name,
 			&i.Type,
 			&i.ServiceID,
+			&i.RouteLabelKey,
+			&i.RouteField,
+			&i.AlertRateLimit,
+			&i.DedupWindowMinutes,
+			&i.DroppedAlertCount,
+			&i.FieldMapping,
+			&i.DefaultLabels,
 		); err != nil {
 			return nil, err
 		}
@@ -934,7 +2249,14 @@ SELECT
     id,
     name,
     type,
-    service_id
+    service_id,
+    route_label_key,
+    route_field,
+    alert_rate_limit,
+    dedup_window_minutes,
+    dropped_alert_count,
+    field_mapping,
+    default_labels
 FROM
     integration_keys
 WHERE
@@ -942,10 +2264,17 @@ WHERE
 `
 
 type IntKeyFindOneRow struct {
-	ID        uuid.UUID
-	Name      string
-	Type      EnumIntegrationKeysType
-	ServiceID uuid.UUID
+	ID                 uuid.UUID
+	Name               string
+	Type               EnumIntegrationKeysType
+	ServiceID          uuid.UUID
+	RouteLabelKey      sql.NullString
+	RouteField         sql.NullString
+	AlertRateLimit     sql.NullInt32
+	DedupWindowMinutes sql.NullInt32
+	DroppedAlertCount  int64
+	FieldMapping       pqtype.NullRawMessage
+	DefaultLabels      pqtype.NullRawMessage
 }
 
 func (q *Queries) IntKeyFindOne(ctx context.Context, id uuid.UUID) (IntKeyFindOneRow, error) {
@@ -956,10 +2285,55 @@ func (q *Queries) IntKeyFindOne(ctx context.Context, id uuid.UUID) (IntKeyFindOn
 		&i.Name,
 		&i.Type,
 		&i.ServiceID,
+		&i.RouteLabelKey,
+		&i.RouteField,
+		&i.AlertRateLimit,
+		&i.DedupWindowMinutes,
+		&i.DroppedAlertCount,
+		&i.FieldMapping,
+		&i.DefaultLabels,
 	)
 	return i, err
 }
 
+const intKeyGetFieldMapping = `-- name: IntKeyGetFieldMapping :one
+SELECT
+    field_mapping
+FROM
+    integration_keys
+WHERE
+    id = $1
+`
+
+func (q *Queries) IntKeyGetFieldMapping(ctx context.Context, id uuid.UUID) (pqtype.NullRawMessage, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetFieldMapping, id)
+	var field_mapping pqtype.NullRawMessage
+	err := row.Scan(&field_mapping)
+	return field_mapping, err
+}
+
+const intKeyGetRoute = `-- name: IntKeyGetRoute :one
+SELECT
+    route_label_key,
+    route_field
+FROM
+    integration_keys
+WHERE
+    id = $1
+`
+
+type IntKeyGetRouteRow struct {
+	RouteLabelKey sql.NullString
+	RouteField    sql.NullString
+}
+
+func (q *Queries) IntKeyGetRoute(ctx context.Context, id uuid.UUID) (IntKeyGetRouteRow, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetRoute, id)
+	var i IntKeyGetRouteRow
+	err := row.Scan(&i.RouteLabelKey, &i.RouteField)
+	return i, err
+}
+
 const intKeyGetServiceID = `-- name: IntKeyGetServiceID :one
 SELECT
     service_id
@@ -982,9 +2356,127 @@ func (q *Queries) IntKeyGetServiceID(ctx context.Context, arg IntKeyGetServiceID
 	return service_id, err
 }
 
+const intKeyRouteServiceIDs = `-- name: IntKeyRouteServiceIDs :many
+SELECT
+    l.tgt_service_id
+FROM
+    integration_keys k
+    JOIN labels l ON l.key = k.route_label_key
+WHERE
+    k.id = $1
+    AND l.value = $2
+ORDER BY
+    l.tgt_service_id
+`
+
+type IntKeyRouteServiceIDsParams struct {
+	ID    uuid.UUID
+	Value string
+}
+
+func (q *Queries) IntKeyRouteServiceIDs(ctx context.Context, arg IntKeyRouteServiceIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, intKeyRouteServiceIDs, arg.ID, arg.Value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var tgt_service_id uuid.UUID
+		if err := rows.Scan(&tgt_service_id); err != nil {
+			return nil, err
+		}
+		items = append(items, tgt_service_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const intKeySetAlertRateLimit = `-- name: IntKeySetAlertRateLimit :exec
+UPDATE
+    integration_keys
+SET
+    alert_rate_limit = $2
+WHERE
+    id = $1
+`
+
+type IntKeySetAlertRateLimitParams struct {
+	ID             uuid.UUID
+	AlertRateLimit sql.NullInt32
+}
+
+func (q *Queries) IntKeySetAlertRateLimit(ctx context.Context, arg IntKeySetAlertRateLimitParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetAlertRateLimit, arg.ID, arg.AlertRateLimit)
+	return err
+}
+
+const intKeySetDedupWindow = `-- name: IntKeySetDedupWindow :exec
+UPDATE
+    integration_keys
+SET
+    dedup_window_minutes = $2
+WHERE
+    id = $1
+`
+
+type IntKeySetDedupWindowParams struct {
+	ID                 uuid.UUID
+	DedupWindowMinutes sql.NullInt32
+}
+
+func (q *Queries) IntKeySetDedupWindow(ctx context.Context, arg IntKeySetDedupWindowParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetDedupWindow, arg.ID, arg.DedupWindowMinutes)
+	return err
+}
+
+const intKeySetDefaultLabels = `-- name: IntKeySetDefaultLabels :exec
+UPDATE
+    integration_keys
+SET
+    default_labels = $2
+WHERE
+    id = $1
+`
+
+type IntKeySetDefaultLabelsParams struct {
+	ID            uuid.UUID
+	DefaultLabels pqtype.NullRawMessage
+}
+
+func (q *Queries) IntKeySetDefaultLabels(ctx context.Context, arg IntKeySetDefaultLabelsParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetDefaultLabels, arg.ID, arg.DefaultLabels)
+	return err
+}
+
+const intKeySetFieldMapping = `-- name: IntKeySetFieldMapping :exec
+UPDATE
+    integration_keys
+SET
+    field_mapping = $2
+WHERE
+    id = $1
+`
+
+type IntKeySetFieldMappingParams struct {
+	ID           uuid.UUID
+	FieldMapping pqtype.NullRawMessage
+}
+
+func (q *Queries) IntKeySetFieldMapping(ctx context.Context, arg IntKeySetFieldMappingParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetFieldMapping, arg.ID, arg.FieldMapping)
+	return err
+}
+
 const lockOneAlertService = `-- name: LockOneAlertService :one
 SELECT
     maintenance_expires_at NOTNULL::bool AS is_maint_mode,
+    paused_at NOTNULL::bool AS is_paused,
     alerts.status
 FROM
     services svc
@@ -996,16 +2488,128 @@ FOR UPDATE
 
 type LockOneAlertServiceRow struct {
 	IsMaintMode bool
+	IsPaused    bool
 	Status      EnumAlertStatus
 }
 
 func (q *Queries) LockOneAlertService(ctx context.Context, id int64) (LockOneAlertServiceRow, error) {
 	row := q.db.QueryRowContext(ctx, lockOneAlertService, id)
 	var i LockOneAlertServiceRow
-	err := row.Scan(&i.IsMaintMode, &i.Status)
+	err := row.Scan(&i.IsMaintMode, &i.IsPaused, &i.Status)
 	return i, err
 }
 
+const maintWindowCreate = `-- name: MaintWindowCreate :exec
+INSERT INTO service_maintenance_windows(id, service_id, start_time, end_time, description)
+    VALUES ($1, $2, $3, $4, $5)
+`
+
+type MaintWindowCreateParams struct {
+	ID          uuid.UUID
+	ServiceID   uuid.UUID
+	StartTime   time.Time
+	EndTime     time.Time
+	Description string
+}
+
+func (q *Queries) MaintWindowCreate(ctx context.Context, arg MaintWindowCreateParams) error {
+	_, err := q.db.ExecContext(ctx, maintWindowCreate,
+		arg.ID,
+		arg.ServiceID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Description,
+	)
+	return err
+}
+
+const maintWindowDelete = `-- name: MaintWindowDelete :exec
+DELETE FROM service_maintenance_windows
+WHERE id = ANY ($1::uuid[])
+`
+
+func (q *Queries) MaintWindowDelete(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, maintWindowDelete, pq.Array(ids))
+	return err
+}
+
+const maintWindowFindByService = `-- name: MaintWindowFindByService :many
+SELECT
+    description,
+    end_time,
+    id,
+    service_id,
+    start_time
+FROM
+    service_maintenance_windows
+WHERE
+    service_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time
+`
+
+func (q *Queries) MaintWindowFindByService(ctx context.Context, serviceID uuid.UUID) ([]ServiceMaintenanceWindow, error) {
+	rows, err := q.db.QueryContext(ctx, maintWindowFindByService, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ServiceMaintenanceWindow
+	for rows.Next() {
+		var i ServiceMaintenanceWindow
+		if err := rows.Scan(
+			&i.Description,
+			&i.EndTime,
+			&i.ID,
+			&i.ServiceID,
+			&i.StartTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const maintWindowServiceIDs = `-- name: MaintWindowServiceIDs :many
+SELECT DISTINCT
+    service_id
+FROM
+    service_maintenance_windows
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+func (q *Queries) MaintWindowServiceIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, maintWindowServiceIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var service_id uuid.UUID
+		if err := rows.Scan(&service_id); err != nil {
+			return nil, err
+		}
+		items = append(items, service_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const noticeUnackedAlertsByService = `-- name: NoticeUnackedAlertsByService :one
 SELECT
     count(*),
@@ -1073,22 +2677,32 @@ func (q *Queries) RequestAlertEscalationByTime(ctx context.Context, arg RequestA
 }
 
 const setAlertFeedback = `-- name: SetAlertFeedback :exec
-INSERT INTO alert_feedback(alert_id, noise_reason)
-    VALUES ($1, $2)
+INSERT INTO alert_feedback(alert_id, feedback, noise_reason, user_id)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT (alert_id)
     DO UPDATE SET
-        noise_reason = $2
+        feedback = $2,
+        noise_reason = $3,
+        user_id = $4,
+        updated_at = now()
     WHERE
         alert_feedback.alert_id = $1
 `
 
 type SetAlertFeedbackParams struct {
 	AlertID     int64
+	Feedback    EnumAlertFeedback
 	NoiseReason string
+	UserID      uuid.NullUUID
 }
 
 func (q *Queries) SetAlertFeedback(ctx context.Context, arg SetAlertFeedbackParams) error {
-	_, err := q.db.ExecContext(ctx, setAlertFeedback, arg.AlertID, arg.NoiseReason)
+	_, err := q.db.ExecContext(ctx, setAlertFeedback,
+		arg.AlertID,
+		arg.Feedback,
+		arg.NoiseReason,
+		arg.UserID,
+	)
 	return err
 }
 
@@ -1219,6 +2833,45 @@ func (q *Queries) StatusMgrNextUpdate(ctx context.Context) (StatusMgrNextUpdateR
 	return i, err
 }
 
+const statusMgrNotifyWatchers = `-- name: StatusMgrNotifyWatchers :exec
+INSERT INTO outgoing_messages (message_type, contact_method_id, user_id, alert_id, service_id, escalation_policy_id, watcher)
+SELECT
+    'alert_notification',
+    w.contact_method_id,
+    w.user_id,
+    a.id,
+    a.service_id,
+    svc.escalation_policy_id,
+    TRUE
+FROM
+    service_watchers w
+    JOIN services svc ON svc.id = w.service_id
+        AND svc.maintenance_expires_at ISNULL
+        AND svc.paused_at ISNULL
+    JOIN alerts a ON a.service_id = w.service_id
+        AND a.status = 'triggered'
+        AND a.created_at > w.created_at
+        AND a.created_at > now() - '1 hour'::interval
+        AND a.created_at + make_interval(mins => svc.notification_delay_minutes) <= now()
+    JOIN user_contact_methods cm ON cm.id = w.contact_method_id
+        AND NOT cm.disabled
+WHERE
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            outgoing_messages om
+        WHERE
+            om.alert_id = a.id
+            AND om.contact_method_id = w.contact_method_id
+            AND om.watcher)
+`
+
+func (q *Queries) StatusMgrNotifyWatchers(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, statusMgrNotifyWatchers)
+	return err
+}
+
 const statusMgrSendChannelMsg = `-- name: StatusMgrSendChannelMsg :exec
 INSERT INTO outgoing_messages (id, message_type, channel_id, alert_id, alert_log_id)
     VALUES ($1::uuid, 'alert_status_update', $2::uuid, $3::bigint, $4)
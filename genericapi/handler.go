@@ -15,6 +15,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -75,6 +76,23 @@ func (h *Handler) ServeHeartbeatCheck(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// eventAlertStatus will return the status of the alert an event results in. Either the
+// `close` action or the `resolved` status closes the matching alert.
+func eventAlertStatus(action, status string) (alert.Status, error) {
+	switch status {
+	case "", "firing":
+	case "resolved":
+		return alert.StatusClosed, nil
+	default:
+		return "", errors.New("invalid status: must be firing or resolved")
+	}
+	if action == "close" {
+		return alert.StatusClosed, nil
+	}
+
+	return alert.StatusTriggered, nil
+}
+
 // ServeCreateAlert allows creating or closing an alert.
 func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -83,12 +101,27 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
-	serviceID := permission.ServiceID(ctx)
 
 	summary := r.FormValue("summary")
 	details := r.FormValue("details")
 	action := r.FormValue("action")
+	eventStatus := r.FormValue("status")
 	dedup := r.FormValue("dedup")
+	dedupWindow := r.FormValue("dedupWindow")
+	severity := r.FormValue("severity")
+	correlationKey := r.FormValue("correlationKey")
+	fieldValue := r.FormValue
+
+	var meta alert.Meta
+	for k, v := range r.Form {
+		if !strings.HasPrefix(k, "meta.") || len(v) == 0 {
+			continue
+		}
+		if meta == nil {
+			meta = make(alert.Meta)
+		}
+		meta[strings.TrimPrefix(k, "meta.")] = v[0]
+	}
 
 	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
 	if ct == "application/json" {
@@ -98,10 +131,23 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		mapping, err := h.c.IntegrationKeyStore.FieldMapping(ctx)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+
 		var b struct {
-			Summary, Details, Action, Dedup *string
+			Summary, Details, Action, Status, Dedup, DedupWindow, Severity, CorrelationKey *string
+
+			Meta map[string]interface{}
 		}
 		err = json.Unmarshal(data, &b)
+		var typeErr *json.UnmarshalTypeError
+		if mapping != nil && errors.As(err, &typeErr) {
+			// mapped payloads are in the shape of the source, so fields that
+			// don't match ours are ignored
+			err = nil
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -119,23 +165,107 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		if b.Action != nil {
 			action = *b.Action
 		}
+		if b.Status != nil {
+			eventStatus = *b.Status
+		}
+		if b.DedupWindow != nil {
+			dedupWindow = *b.DedupWindow
+		}
+		if b.Severity != nil {
+			severity = *b.Severity
+		}
+		if b.CorrelationKey != nil {
+			correlationKey = *b.CorrelationKey
+		}
+		if b.Meta != nil {
+			meta, err = metaFromJSON(b.Meta)
+			if err != nil {
+				http.Error(w, "invalid meta: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		var payload interface{}
+		err = json.Unmarshal(data, &payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fields, _ := payload.(map[string]interface{})
+		fieldValue = func(name string) string {
+			v, _ := fields[name].(string)
+			return v
+		}
+
+		if mapping != nil {
+			v := mapping.Extract(payload)
+			if v.Summary != "" {
+				summary = v.Summary
+			} else if mapping.Summary != "" && summary == "" {
+				// fall back to the raw body rather than dropping the alert
+				summary = string(data)
+			}
+			if v.Details != "" {
+				details = v.Details
+			} else if mapping.Details != "" && details == "" {
+				details = string(data)
+			}
+			if v.Dedup != "" {
+				dedup = v.Dedup
+			}
+			if _, err := alert.ParseSeverity(v.Severity); v.Severity != "" && err == nil {
+				// unknown severities from the source are ignored
+				severity = v.Severity
+			}
+			if v.CorrelationKey != "" {
+				correlationKey = v.CorrelationKey
+			}
+		}
 	}
 
-	status := alert.StatusTriggered
-	if action == "close" {
-		status = alert.StatusClosed
+	status, err := eventAlertStatus(action, eventStatus)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, err = h.c.IntegrationKeyStore.RouteContext(ctx, fieldValue)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	var window time.Duration
+	if dedupWindow != "" {
+		var err error
+		window, err = time.ParseDuration(dedupWindow)
+		if err != nil {
+			http.Error(w, "invalid dedupWindow: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	sev, err := alert.ParseSeverity(severity)
+	if err != nil {
+		http.Error(w, "invalid severity: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	summary = validate.SanitizeText(summary, alert.MaxSummaryLength)
 	details = validate.SanitizeText(details, alert.MaxDetailsLength)
 
 	a := &alert.Alert{
-		Summary:   summary,
-		Details:   details,
-		Source:    alert.SourceGeneric,
-		ServiceID: serviceID,
-		Dedup:     alert.NewUserDedup(dedup),
-		Status:    status,
+		Summary:     summary,
+		Details:     details,
+		Source:      alert.SourceGeneric,
+		ServiceID:   serviceID,
+		Dedup:       alert.NewUserDedup(dedup),
+		DedupWindow: window,
+		Status:      status,
+		Severity:    sev,
+		Meta:        meta.Sanitize(),
+
+		CorrelationKey: validate.SanitizeText(correlationKey, alert.MaxCorrelationKeyLength),
 	}
 
 	var resp struct {
@@ -161,6 +291,9 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create alert")) {
 		return
 	}
+	if status == alert.StatusClosed && resp.AlertID == 0 {
+		log.Debugf(ctx, "generic API: close event matched no open alert (dedup=%s)", a.DedupKey().Payload)
+	}
 
 	if r.Header.Get("Accept") != "application/json" {
 		w.WriteHeader(204)
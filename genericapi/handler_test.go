@@ -0,0 +1,47 @@
+package genericapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestEventAlertStatus(t *testing.T) {
+	check := func(action, status string, exp alert.Status) {
+		t.Helper()
+		s, err := eventAlertStatus(action, status)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, s)
+	}
+
+	check("", "", alert.StatusTriggered)
+	check("", "firing", alert.StatusTriggered)
+	check("", "resolved", alert.StatusClosed)
+	check("close", "", alert.StatusClosed)
+	check("close", "firing", alert.StatusClosed)
+	check("close", "resolved", alert.StatusClosed)
+
+	_, err := eventAlertStatus("", "acknowledged")
+	assert.Error(t, err, "unknown status")
+	_, err = eventAlertStatus("close", "Resolved")
+	assert.Error(t, err, "status is case sensitive")
+}
+
+func TestServeCreateAlert_InvalidStatus(t *testing.T) {
+	// the status is validated before the integration key is routed, so no stores are needed
+	h := NewHandler(Config{})
+
+	req := httptest.NewRequest("POST", "/api/v2/generic/incoming", strings.NewReader("summary=test&status=ok"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(permission.ServiceContext(req.Context(), "00000000-0000-0000-0000-000000000001"))
+
+	rec := httptest.NewRecorder()
+	h.ServeCreateAlert(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "must be firing or resolved")
+}
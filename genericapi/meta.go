@@ -0,0 +1,30 @@
+package genericapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/target/goalert/alert"
+)
+
+// metaFromJSON converts a JSON `meta` object into alert metadata. Values must be
+// strings, numbers, or booleans.
+func metaFromJSON(m map[string]interface{}) (alert.Meta, error) {
+	meta := make(alert.Meta, len(m))
+	for k, v := range m {
+		switch v := v.(type) {
+		case string:
+			meta[k] = v
+		case float64:
+			meta[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			meta[k] = strconv.FormatBool(v)
+		case nil:
+			// omit null values
+		default:
+			return nil, fmt.Errorf("value for '%s' must be a string, number, or boolean", k)
+		}
+	}
+
+	return meta, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,26 +15,36 @@ import (
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
 type (
 	Alert              App
 	AlertMetric        App
+	AlertFeedback      App
+	AlertFeedbackStats App
 	AlertLogEntry      App
 	AlertLogEntryState App
 )
 
 func (a *App) Alert() graphql2.AlertResolver                 { return (*Alert)(a) }
 func (a *App) AlertMetric() graphql2.AlertMetricResolver     { return (*AlertMetric)(a) }
+func (a *App) AlertFeedback() graphql2.AlertFeedbackResolver { return (*AlertFeedback)(a) }
+func (a *App) AlertFeedbackStats() graphql2.AlertFeedbackStatsResolver {
+	return (*AlertFeedbackStats)(a)
+}
 func (a *App) AlertLogEntry() graphql2.AlertLogEntryResolver { return (*AlertLogEntry)(a) }
 
 func (a *AlertLogEntry) ID(ctx context.Context, obj *alertlog.Entry) (int, error) {
@@ -62,6 +73,19 @@ func (a *AlertLogEntry) Message(ctx context.Context, obj *alertlog.Entry) (strin
 	return e.String(ctx), nil
 }
 
+func (a *AlertLogEntry) Note(ctx context.Context, obj *alertlog.Entry) (*graphql2.AlertLogNote, error) {
+	meta, ok := obj.Meta(ctx).(*alertlog.NoteMetaData)
+	if !ok {
+		return nil, nil
+	}
+
+	return &graphql2.AlertLogNote{
+		Text:     meta.Note,
+		EditedAt: meta.EditedAt,
+		Editable: obj.NoteEditable(ctx),
+	}, nil
+}
+
 func notificationStateFromSendResult(s notification.Status, formattedSrc string) *graphql2.NotificationState {
 	var status graphql2.NotificationStatus
 	switch s.State {
@@ -277,6 +301,7 @@ func (q *Query) Alerts(ctx context.Context, opts *graphql2.AlertSearchOptions) (
 				s.Status = append(s.Status, alert.StatusClosed)
 			}
 		}
+		s.Severity = opts.FilterBySeverity
 		if opts.Sort != nil {
 			switch *opts.Sort {
 			case graphql2.AlertSearchSortStatusID:
@@ -299,6 +324,20 @@ func (q *Query) Alerts(ctx context.Context, opts *graphql2.AlertSearchOptions) (
 		if opts.NotClosedBefore != nil {
 			s.NotClosedBefore = *opts.NotClosedBefore
 		}
+		if opts.EscalationExhausted != nil {
+			s.EscalationExhausted = *opts.EscalationExhausted
+		}
+		if opts.CorrelationKey != nil {
+			s.CorrelationKey = *opts.CorrelationKey
+		}
+	}
+	if ids, ok := apiKeyServiceFilter(ctx); ok {
+		// applied after the cursor is parsed, so it can't be bypassed
+		if s.ServiceFilter.Valid {
+			ids = slices.DeleteFunc(ids, func(id string) bool { return !containsID(s.ServiceFilter.IDs, id) })
+		}
+		s.ServiceFilter = alert.IDFilter{Valid: true, IDs: ids}
+		s.NotifiedUserID = ""
 	}
 
 	s.Limit++
@@ -372,15 +411,57 @@ func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertIn
 	if input.Details != nil {
 		a.Details = *input.Details
 	}
+	if input.Severity != nil {
+		a.Severity = *input.Severity
+	}
+	if input.CorrelationKey != nil {
+		a.CorrelationKey = *input.CorrelationKey
+	}
+	for _, m := range input.Meta {
+		if a.Meta == nil {
+			a.Meta = make(alert.Meta, len(input.Meta))
+		}
+		if _, ok := a.Meta[m.Key]; ok {
+			return nil, validation.NewFieldErrorf("Meta", "duplicate key '%s'", m.Key)
+		}
+		a.Meta[m.Key] = m.Value
+	}
 
 	if input.Sanitize != nil && *input.Sanitize {
 		a.Summary = validate.SanitizeText(a.Summary, alert.MaxSummaryLength)
 		a.Details = validate.SanitizeText(a.Details, alert.MaxDetailsLength)
+		a.Meta = a.Meta.Sanitize()
 	}
 
 	return m.AlertStore.Create(ctx, a)
 }
 
+func (a *Alert) Meta(ctx context.Context, raw *alert.Alert) ([]graphql2.AlertMetadata, error) {
+	result := make([]graphql2.AlertMetadata, 0, len(raw.Meta))
+	for _, k := range raw.Meta.Keys() {
+		result = append(result, graphql2.AlertMetadata{Key: k, Value: raw.Meta[k]})
+	}
+
+	return result, nil
+}
+
+func (a *Alert) MetaValue(ctx context.Context, raw *alert.Alert, key string) (string, error) {
+	return raw.Meta[key], nil
+}
+
+func (a *Alert) RunbookURL(ctx context.Context, raw *alert.Alert) (string, error) {
+	if u := raw.Meta.RunbookURL(""); u != "" {
+		return u, nil
+	}
+
+	svc, err := (*App)(a).FindOneService(ctx, raw.ServiceID)
+	if err != nil {
+		return "", err
+	}
+
+	return svc.RunbookURL, nil
+}
+
 func (a *Alert) NoiseReason(ctx context.Context, raw *alert.Alert) (*string, error) {
 	am, err := (*App)(a).FindOneAlertFeedback(ctx, raw.ID)
 	if err != nil {
@@ -395,6 +476,134 @@ func (a *Alert) NoiseReason(ctx context.Context, raw *alert.Alert) (*string, err
 	return &am.NoiseReason, nil
 }
 
+func (a *Alert) Feedback(ctx context.Context, raw *alert.Alert) (*alert.Feedback, error) {
+	return (*App)(a).FindOneAlertFeedback(ctx, raw.ID)
+}
+
+func (a *Alert) IntegrationKey(ctx context.Context, raw *alert.Alert) (*integrationkey.IntegrationKey, error) {
+	if raw.IntegrationKeyID == "" {
+		return nil, nil
+	}
+
+	return a.IntKeyStore.FindOne(ctx, raw.IntegrationKeyID)
+}
+
+func (a *Alert) CorrelatedAlerts(ctx context.Context, raw *alert.Alert) ([]alert.Alert, error) {
+	if raw.CorrelationKey == "" {
+		return []alert.Alert{}, nil
+	}
+
+	return a.AlertStore.Search(ctx, &alert.SearchOptions{
+		CorrelationKey: raw.CorrelationKey,
+		Omit:           []int{raw.ID},
+		Sort:           alert.SortModeDateID,
+		Limit:          50,
+	})
+}
+
+func (a *AlertFeedback) User(ctx context.Context, raw *alert.Feedback) (*user.User, error) {
+	if raw.UserID == "" {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, raw.UserID)
+}
+
+func (a *AlertFeedbackStats) Service(ctx context.Context, raw *alert.FeedbackStats) (*service.Service, error) {
+	return (*App)(a).FindOneService(ctx, raw.ServiceID)
+}
+
+func (q *Query) AlertFeedbackStats(ctx context.Context, input graphql2.AlertFeedbackStatsInput) ([]alert.FeedbackStats, error) {
+	return q.AlertStore.FeedbackStats(ctx, input.ServiceIDs, input.Start, input.End)
+}
+
+func (m *Mutation) SetAlertFeedback(ctx context.Context, input graphql2.SetAlertFeedbackInput) (bool, error) {
+	f := &alert.Feedback{
+		ID:    input.AlertID,
+		Value: input.Value,
+	}
+	if input.NoiseReason != nil {
+		f.NoiseReason = *input.NoiseReason
+	}
+
+	err := m.AlertStore.UpdateFeedback(ctx, f)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *Alert) CloseReason(ctx context.Context, raw *alert.Alert) (*alert.CloseReason, error) {
+	if raw.Status != alert.StatusClosed {
+		return nil, nil
+	}
+
+	reason, err := a.AlertStore.CloseReason(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		return nil, nil
+	}
+
+	return &reason, nil
+}
+
+func (a *Alert) Assignee(ctx context.Context, raw *alert.Alert) (*user.User, error) {
+	assignment, err := (*App)(a).FindOneAlertAssignment(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, assignment.UserID)
+}
+
+func (m *Mutation) AssignAlert(ctx context.Context, input graphql2.AssignAlertInput) (bool, error) {
+	var pause time.Duration
+	if input.PauseEscalationMinutes != nil {
+		pause = time.Duration(*input.PauseEscalationMinutes) * time.Minute
+	}
+
+	err := m.AlertStore.Assign(ctx, input.AlertID, input.UserID, pause)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Mutation) AddAlertNote(ctx context.Context, input graphql2.AddAlertNoteInput) (bool, error) {
+	// ensure the alert exists, for a friendly error
+	_, err := m.AlertStore.FindOne(ctx, input.AlertID)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.AlertLogStore.AddNoteTx(ctx, nil, input.AlertID, input.Note)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Mutation) UpdateAlertNote(ctx context.Context, input graphql2.UpdateAlertNoteInput) (bool, error) {
+	err := m.AlertLogStore.UpdateNoteTx(ctx, nil, input.ID, input.Note)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Mutation) DeleteAlertNote(ctx context.Context, id int) (bool, error) {
+	err := m.AlertLogStore.DeleteNoteTx(ctx, nil, id)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (m *Mutation) SetAlertNoiseReason(ctx context.Context, input graphql2.SetAlertNoiseReasonInput) (bool, error) {
 	err := m.AlertStore.UpdateFeedback(ctx, &alert.Feedback{
 		ID:          input.AlertID,
@@ -453,6 +662,74 @@ func (a *Alert) RecentEvents(ctx context.Context, obj *alert.Alert, opts *graphq
 	return conn, err
 }
 
+// alertNotificationStatus returns the delivery status of a message in the given state.
+func alertNotificationStatus(state notification.State) graphql2.AlertNotificationStatus {
+	switch state {
+	case notification.StateBundled:
+		return graphql2.AlertNotificationStatusBundled
+	case notification.StateSent:
+		return graphql2.AlertNotificationStatusSent
+	case notification.StateDelivered:
+		return graphql2.AlertNotificationStatusDelivered
+	case notification.StateFailedTemp, notification.StateFailedPerm:
+		return graphql2.AlertNotificationStatusFailed
+	}
+
+	return graphql2.AlertNotificationStatusQueued
+}
+
+func (a *Alert) Notifications(ctx context.Context, obj *alert.Alert) ([]graphql2.AlertNotification, error) {
+	logs, err := a.NotificationStore.FindAlertMessages(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.AlertNotification, 0, len(logs))
+	for _, _l := range logs {
+		l := _l
+		dest, err := (*App)(a).messageDest(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+
+		n := graphql2.AlertNotification{
+			ID:            l.ID,
+			CreatedAt:     l.CreatedAt,
+			SentAt:        l.SentAt,
+			Type:          strings.TrimPrefix(l.MessageType.String(), "MessageType"),
+			Channel:       destChannel(dest.Type),
+			Status:        alertNotificationStatus(l.LastStatus),
+			StatusDetails: l.StatusDetails,
+		}
+
+		switch {
+		case dest.ID == "":
+			n.Destination = n.Channel
+		case dest.Type.IsUserCM() && !permission.Admin(ctx) && permission.UserID(ctx) != l.UserID:
+			// don't expose contact method values of other users
+			n.Destination = n.Channel
+		default:
+			n.Destination, err = (*Query)(a).formatDest(ctx, dest)
+			if err != nil {
+				return nil, fmt.Errorf("format dest: %w", err)
+			}
+		}
+		if l.UserID != "" {
+			n.UserID = &l.UserID
+		}
+		if l.UserName != "" {
+			n.UserName = &l.UserName
+		}
+		if l.ProviderMsgID != nil {
+			n.ProviderID = &l.ProviderMsgID.ExternalID
+		}
+
+		result = append(result, n)
+	}
+
+	return result, nil
+}
+
 // PendingNotifications returns a list of notifications that are waiting to be sent
 func (a *Alert) PendingNotifications(ctx context.Context, obj *alert.Alert) ([]graphql2.AlertPendingNotification, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
@@ -514,8 +791,20 @@ func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsI
 		status = alert.StatusClosed
 	}
 
+	if args.CloseReason != nil && status != alert.StatusClosed {
+		return nil, validation.NewFieldError("CloseReason", "only allowed when closing alerts")
+	}
+
 	var updatedIDs []int
-	updatedIDs, err = m.AlertStore.UpdateManyAlertStatus(ctx, status, args.AlertIDs, nil)
+	if status == alert.StatusClosed {
+		var reason alert.CloseReason
+		if args.CloseReason != nil {
+			reason = *args.CloseReason
+		}
+		updatedIDs, err = m.AlertStore.CloseMany(ctx, args.AlertIDs, reason)
+	} else {
+		updatedIDs, err = m.AlertStore.UpdateManyAlertStatus(ctx, status, args.AlertIDs, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -523,6 +812,39 @@ func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsI
 	return m.AlertStore.FindMany(ctx, updatedIDs)
 }
 
+func (m *Mutation) UpdateAlertsByLabel(ctx context.Context, args graphql2.UpdateAlertsByLabelInput) (int, error) {
+	err := validate.OneOf("NewStatus", args.NewStatus, graphql2.AlertStatusStatusAcknowledged, graphql2.AlertStatusStatusClosed)
+	if err != nil {
+		return 0, err
+	}
+
+	status := alert.StatusActive
+	if args.NewStatus == graphql2.AlertStatusStatusClosed {
+		status = alert.StatusClosed
+	}
+
+	var value string
+	if args.LabelValue != nil {
+		value = *args.LabelValue
+	}
+
+	var reason alert.CloseReason
+	if args.CloseReason != nil {
+		reason = *args.CloseReason
+	}
+
+	return m.AlertStore.UpdateStatusByLabel(ctx, args.LabelKey, value, status, reason)
+}
+
+func (m *Mutation) SnoozeAlerts(ctx context.Context, input graphql2.SnoozeAlertsInput) ([]alert.Alert, error) {
+	ids, err := m.AlertStore.SnoozeMany(ctx, input.AlertIDs, time.Duration(input.DurationMinutes)*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.AlertStore.FindMany(ctx, ids)
+}
+
 func (m *Mutation) UpdateAlertsByService(ctx context.Context, args graphql2.UpdateAlertsByServiceInput) (bool, error) {
 	var status alert.Status
 
@@ -533,10 +855,50 @@ func (m *Mutation) UpdateAlertsByService(ctx context.Context, args graphql2.Upda
 		status = alert.StatusClosed
 	}
 
-	err := m.AlertStore.UpdateStatusByService(ctx, args.ServiceID, status)
+	if args.CloseReason != nil && status != alert.StatusClosed {
+		return false, validation.NewFieldError("CloseReason", "only allowed when closing alerts")
+	}
+
+	var err error
+	if status == alert.StatusClosed {
+		var reason alert.CloseReason
+		if args.CloseReason != nil {
+			reason = *args.CloseReason
+		}
+		err = m.AlertStore.CloseByService(ctx, args.ServiceID, reason)
+	} else {
+		err = m.AlertStore.UpdateStatusByService(ctx, args.ServiceID, status)
+	}
 	if err != nil {
 		return false, err
 	}
 
 	return true, nil
 }
+
+func (m *Mutation) CloseAllAlertsByService(ctx context.Context, input graphql2.CloseAllAlertsByServiceInput) (int, error) {
+	var reason alert.CloseReason
+	if input.CloseReason != nil {
+		reason = *input.CloseReason
+	}
+
+	var n int
+	err := (*App)(m).withAuditTx(ctx, "closeAllAlertsByService", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeService, input.ServiceID)
+		var err error
+		n, err = m.AlertStore.CloseAllByServiceTx(ctx, tx, input.ServiceID, input.Confirm, reason)
+		if err != nil {
+			return err
+		}
+
+		return e.SetAfter(struct {
+			ClosedAlerts int
+			CloseReason  alert.CloseReason
+		}{ClosedAlerts: n, CloseReason: reason})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,73 @@
+package graphqlapp
+
+import (
+	context "context"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation/validate"
+)
+
+type ArchivedAlert App
+
+func (a *App) ArchivedAlert() graphql2.ArchivedAlertResolver { return (*ArchivedAlert)(a) }
+
+func (a *ArchivedAlert) AlertID(ctx context.Context, raw *alert.ArchivedAlert) (int, error) {
+	return raw.ID, nil
+}
+
+func (q *Query) ArchivedAlert(ctx context.Context, id int) (*alert.ArchivedAlert, error) {
+	return q.AlertStore.FindOneArchived(ctx, id)
+}
+
+func (q *Query) ArchivedAlerts(ctx context.Context, input *graphql2.ArchivedAlertSearchOptions) (conn *graphql2.ArchivedAlertConnection, err error) {
+	if input == nil {
+		input = &graphql2.ArchivedAlertSearchOptions{}
+	}
+
+	var opts alert.ArchiveSearchOptions
+	if input.Search != nil {
+		opts.Search = *input.Search
+	}
+	opts.ServiceIDs = input.FilterByServiceID
+	if input.After != nil && *input.After != "" {
+		err = search.ParseCursor(*input.After, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.First != nil {
+		opts.Limit = *input.First
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 15
+	}
+	err = validate.Range("First", opts.Limit, 1, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Limit++
+	alerts, err := q.AlertStore.SearchArchived(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = new(graphql2.ArchivedAlertConnection)
+	conn.PageInfo = &graphql2.PageInfo{}
+	if len(alerts) == opts.Limit {
+		alerts = alerts[:len(alerts)-1]
+		conn.PageInfo.HasNextPage = true
+	}
+	if len(alerts) > 0 {
+		opts.AfterID = alerts[len(alerts)-1].ID
+		cur, err := search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+	conn.Nodes = alerts
+	return conn, nil
+}
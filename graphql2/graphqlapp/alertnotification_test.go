@@ -0,0 +1,34 @@
+package graphqlapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification"
+)
+
+func TestAlertNotificationStatus(t *testing.T) {
+	check := func(state notification.State, exp graphql2.AlertNotificationStatus) {
+		t.Helper()
+		assert.Equal(t, exp, alertNotificationStatus(state), "state %d", state)
+	}
+
+	check(notification.StateUnknown, graphql2.AlertNotificationStatusQueued)
+	check(notification.StatePending, graphql2.AlertNotificationStatusQueued)
+	check(notification.StateSending, graphql2.AlertNotificationStatusQueued)
+	check(notification.StateSent, graphql2.AlertNotificationStatusSent)
+	check(notification.StateDelivered, graphql2.AlertNotificationStatusDelivered)
+	check(notification.StateFailedTemp, graphql2.AlertNotificationStatusFailed)
+	check(notification.StateFailedPerm, graphql2.AlertNotificationStatusFailed)
+	check(notification.StateBundled, graphql2.AlertNotificationStatusBundled)
+}
+
+func TestDestChannel(t *testing.T) {
+	assert.Equal(t, "SMS", destChannel(notification.DestTypeSMS))
+	assert.Equal(t, "Voice", destChannel(notification.DestTypeVoice))
+	assert.Equal(t, "Webhook", destChannel(notification.DestTypeUserWebhook))
+	assert.Equal(t, "Webhook", destChannel(notification.DestTypeChanWebhook))
+	assert.Equal(t, "Slack", destChannel(notification.DestTypeSlackChannel))
+	assert.Equal(t, "Unknown", destChannel(notification.DestTypeUnknown))
+}
@@ -0,0 +1,264 @@
+package graphqlapp
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+)
+
+// apiKeyScope is the set of services, schedules, and alerts a mutation acts on.
+type apiKeyScope struct {
+	ServiceIDs  []string
+	ScheduleIDs []string
+	AlertIDs    []int
+}
+
+// apiKeyMutations lists the mutations available to service-scoped API keys, returning the entities each
+// will act on from its arguments. Mutations not listed can't be restricted to a set of services, so they
+// are denied.
+var apiKeyMutations = map[string]func(args map[string]interface{}) apiKeyScope{
+	"createAlert": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.CreateAlertInput).ServiceID}}
+	},
+	"updateAlerts": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: args["input"].(graphql2.UpdateAlertsInput).AlertIDs}
+	},
+	"escalateAlerts": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: args["input"].([]int)}
+	},
+	"snoozeAlerts": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: args["input"].(graphql2.SnoozeAlertsInput).AlertIDs}
+	},
+	"setAlertNoiseReason": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: []int{args["input"].(graphql2.SetAlertNoiseReasonInput).AlertID}}
+	},
+	"setAlertFeedback": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: []int{args["input"].(graphql2.SetAlertFeedbackInput).AlertID}}
+	},
+	"assignAlert": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: []int{args["input"].(graphql2.AssignAlertInput).AlertID}}
+	},
+	"addAlertNote": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{AlertIDs: []int{args["input"].(graphql2.AddAlertNoteInput).AlertID}}
+	},
+	"updateAlertsByService": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.UpdateAlertsByServiceInput).ServiceID}}
+	},
+	"closeAllAlertsByService": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.CloseAllAlertsByServiceInput).ServiceID}}
+	},
+	"updateService": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.UpdateServiceInput).ID}}
+	},
+	"setServicePaused": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.SetServicePausedInput).ID}}
+	},
+	"createMaintenanceWindow": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ServiceIDs: []string{args["input"].(graphql2.CreateMaintenanceWindowInput).ServiceID}}
+	},
+	"updateSchedule": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ScheduleIDs: []string{args["input"].(graphql2.UpdateScheduleInput).ID}}
+	},
+	"setTemporarySchedule": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ScheduleIDs: []string{args["input"].(graphql2.SetTemporaryScheduleInput).ScheduleID}}
+	},
+	"clearTemporarySchedules": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ScheduleIDs: []string{args["input"].(graphql2.ClearTemporarySchedulesInput).ScheduleID}}
+	},
+	"setScheduleFixedShifts": func(args map[string]interface{}) apiKeyScope {
+		return apiKeyScope{ScheduleIDs: []string{args["input"].(graphql2.SetScheduleFixedShiftsInput).ScheduleID}}
+	},
+	"createUserOverride": func(args map[string]interface{}) apiKeyScope {
+		id := args["input"].(graphql2.CreateUserOverrideInput).ScheduleID
+		if id == nil {
+			// not limited to a schedule, so nothing would be allowed
+			return apiKeyScope{ScheduleIDs: []string{""}}
+		}
+		return apiKeyScope{ScheduleIDs: []string{*id}}
+	},
+}
+
+// apiKeyServiceFilter returns the service IDs the context is restricted to, as strings.
+func apiKeyServiceFilter(ctx context.Context) ([]string, bool) {
+	ids, ok := apikey.ServiceFilterFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res, true
+}
+
+// apiKeyScheduleIDs returns the schedules a service-scoped API key may access: those targeted by the
+// escalation policies of its services.
+func (a *App) apiKeyScheduleIDs(ctx context.Context) ([]string, error) {
+	svcIDs, _ := apikey.ServiceFilterFromContext(ctx)
+	ids, err := gadb.New(a.DB).APIKeyScopeScheduleIDs(ctx, svcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res, nil
+}
+
+// checkAPIKeyMutation will return an access denied error if the context is restricted to a set of services
+// and the mutation would act on anything outside of it. It must be called before the mutation is run.
+func (a *App) checkAPIKeyMutation(ctx context.Context, name string, args map[string]interface{}) error {
+	if _, ok := apikey.ServiceFilterFromContext(ctx); !ok {
+		return nil
+	}
+
+	scopeFn, ok := apiKeyMutations[name]
+	if !ok {
+		return permission.NewAccessDenied("mutation not allowed by service-scoped API key")
+	}
+	scope := scopeFn(args)
+
+	if len(scope.AlertIDs) > 0 {
+		alertIDs := make([]int64, len(scope.AlertIDs))
+		for i, id := range scope.AlertIDs {
+			alertIDs[i] = int64(id)
+		}
+		svcIDs, err := gadb.New(a.DB).APIKeyScopeAlertServiceIDs(ctx, alertIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range svcIDs {
+			scope.ServiceIDs = append(scope.ServiceIDs, id.String())
+		}
+	}
+
+	for _, id := range scope.ServiceIDs {
+		if !apikey.ServiceAllowed(ctx, id) {
+			return permission.NewAccessDenied("service not allowed by API key")
+		}
+	}
+
+	if len(scope.ScheduleIDs) == 0 {
+		return nil
+	}
+	schedIDs, err := a.apiKeyScheduleIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range scope.ScheduleIDs {
+		if !containsID(schedIDs, id) {
+			return permission.NewAccessDenied("schedule not allowed by API key")
+		}
+	}
+
+	return nil
+}
+
+// filterAPIKeyResult will remove alerts, services, and schedules a service-scoped API key may not access from
+// list results. An access denied error is returned for single results that are not allowed.
+func (a *App) filterAPIKeyResult(ctx context.Context, res interface{}) (interface{}, error) {
+	if _, ok := apikey.ServiceFilterFromContext(ctx); !ok {
+		return res, nil
+	}
+
+	svcAllowed := func(id string) bool { return apikey.ServiceAllowed(ctx, id) }
+	errSvc := permission.NewAccessDenied("service not allowed by API key")
+	switch r := res.(type) {
+	case *service.Service:
+		if r != nil && !svcAllowed(r.ID) {
+			return nil, errSvc
+		}
+	case service.Service:
+		if !svcAllowed(r.ID) {
+			return nil, errSvc
+		}
+	case []service.Service:
+		return slices.DeleteFunc(slices.Clone(r), func(s service.Service) bool { return !svcAllowed(s.ID) }), nil
+	case *graphql2.ServiceConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = slices.DeleteFunc(slices.Clone(r.Nodes), func(s service.Service) bool { return !svcAllowed(s.ID) })
+			return &conn, nil
+		}
+	case *alert.Alert:
+		if r != nil && !svcAllowed(r.ServiceID) {
+			return nil, errSvc
+		}
+	case alert.Alert:
+		if !svcAllowed(r.ServiceID) {
+			return nil, errSvc
+		}
+	case []alert.Alert:
+		return slices.DeleteFunc(slices.Clone(r), func(a alert.Alert) bool { return !svcAllowed(a.ServiceID) }), nil
+	case *graphql2.AlertConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = slices.DeleteFunc(slices.Clone(r.Nodes), func(a alert.Alert) bool { return !svcAllowed(a.ServiceID) })
+			return &conn, nil
+		}
+	case []oncall.ServiceOnCall:
+		return slices.DeleteFunc(slices.Clone(r), func(s oncall.ServiceOnCall) bool { return !svcAllowed(s.ServiceID) }), nil
+	case *schedule.Schedule, schedule.Schedule, []schedule.Schedule, *graphql2.ScheduleConnection, *graphql2.UserOnCallShiftConnection:
+		schedIDs, err := a.apiKeyScheduleIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return filterAPIKeySchedules(schedIDs, res)
+	}
+
+	return res, nil
+}
+
+// filterAPIKeySchedules is filterAPIKeyResult for schedule results, given the allowed schedule IDs.
+func filterAPIKeySchedules(allowed []string, res interface{}) (interface{}, error) {
+	errSched := permission.NewAccessDenied("schedule not allowed by API key")
+	notAllowed := func(s schedule.Schedule) bool { return !containsID(allowed, s.ID) }
+	switch r := res.(type) {
+	case *schedule.Schedule:
+		if r != nil && notAllowed(*r) {
+			return nil, errSched
+		}
+	case schedule.Schedule:
+		if notAllowed(r) {
+			return nil, errSched
+		}
+	case []schedule.Schedule:
+		return slices.DeleteFunc(slices.Clone(r), notAllowed), nil
+	case *graphql2.ScheduleConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = slices.DeleteFunc(slices.Clone(r.Nodes), notAllowed)
+			return &conn, nil
+		}
+	case *graphql2.UserOnCallShiftConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = slices.DeleteFunc(slices.Clone(r.Nodes), func(s oncall.UserShift) bool { return !containsID(allowed, s.ScheduleID) })
+			return &conn, nil
+		}
+	}
+
+	return res, nil
+}
+
+// containsID returns true if id is a UUID found in ids.
+func containsID(ids []string, id string) bool {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(ids, parsed.String())
+}
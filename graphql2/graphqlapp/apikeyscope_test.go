@@ -0,0 +1,81 @@
+package graphqlapp
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+)
+
+func TestAPIKeyMutations(t *testing.T) {
+	for name := range apiKeyMutations {
+		assert.Contains(t, graphql2.SchemaFields(), "Mutation."+name)
+	}
+}
+
+func TestFilterAPIKeyResult(t *testing.T) {
+	allowed := uuid.New()
+	other := uuid.NewString()
+	ctx := apikey.ContextWithServiceFilter(context.Background(), []uuid.UUID{allowed})
+	var a App
+
+	res, err := a.filterAPIKeyResult(ctx, []service.Service{{ID: other}, {ID: allowed.String()}})
+	require.NoError(t, err)
+	assert.Equal(t, []service.Service{{ID: allowed.String()}}, res)
+
+	res, err = a.filterAPIKeyResult(ctx, []oncall.ServiceOnCall{{ServiceID: other}, {ServiceID: allowed.String()}})
+	require.NoError(t, err)
+	assert.Equal(t, []oncall.ServiceOnCall{{ServiceID: allowed.String()}}, res)
+
+	alerts := []alert.Alert{{ID: 1, ServiceID: allowed.String()}, {ID: 2, ServiceID: other}}
+	res, err = a.filterAPIKeyResult(ctx, &graphql2.AlertConnection{Nodes: alerts})
+	require.NoError(t, err)
+	assert.Equal(t, []alert.Alert{alerts[0]}, res.(*graphql2.AlertConnection).Nodes)
+	assert.Len(t, alerts, 2, "original results unchanged")
+
+	_, err = a.filterAPIKeyResult(ctx, &alert.Alert{ServiceID: other})
+	assert.True(t, permission.IsPermissionError(err), "single result for other service")
+	_, err = a.filterAPIKeyResult(ctx, &alert.Alert{ServiceID: allowed.String()})
+	assert.NoError(t, err)
+
+	// not restricted
+	res, err = a.filterAPIKeyResult(context.Background(), alerts)
+	require.NoError(t, err)
+	assert.Equal(t, alerts, res)
+}
+
+func TestFilterAPIKeySchedules(t *testing.T) {
+	allowed := uuid.NewString()
+	other := uuid.NewString()
+	scheds := []schedule.Schedule{{ID: allowed}, {ID: other}}
+
+	res, err := filterAPIKeySchedules([]string{allowed}, scheds)
+	require.NoError(t, err)
+	assert.Equal(t, scheds[:1], res)
+
+	res, err = filterAPIKeySchedules(nil, &graphql2.ScheduleConnection{Nodes: scheds})
+	require.NoError(t, err)
+	assert.Empty(t, res.(*graphql2.ScheduleConnection).Nodes)
+
+	_, err = filterAPIKeySchedules([]string{allowed}, &scheds[1])
+	assert.True(t, permission.IsPermissionError(err))
+	_, err = filterAPIKeySchedules([]string{allowed}, &scheds[0])
+	assert.NoError(t, err)
+
+	assert.True(t, slices.Equal([]schedule.Schedule{{ID: allowed}, {ID: other}}, scheds), "original results unchanged")
+
+	shifts := []oncall.UserShift{{ScheduleID: other}, {ScheduleID: allowed}}
+	res, err = filterAPIKeySchedules([]string{allowed}, &graphql2.UserOnCallShiftConnection{Nodes: shifts})
+	require.NoError(t, err)
+	assert.Equal(t, shifts[1:], res.(*graphql2.UserOnCallShiftConnection).Nodes)
+}
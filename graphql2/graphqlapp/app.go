@@ -7,28 +7,35 @@ import (
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/errcode"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/apollotracing"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
 	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
+	"github.com/target/goalert/businesshours"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/configexport"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
@@ -50,36 +57,43 @@ import (
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 type App struct {
-	DB                *sql.DB
-	AuthBasicStore    *basic.Store
-	UserStore         *user.Store
-	CMStore           *contactmethod.Store
-	NRStore           *notificationrule.Store
-	NCStore           *notificationchannel.Store
-	AlertStore        *alert.Store
-	AlertMetricsStore *alertmetrics.Store
-	AlertLogStore     *alertlog.Store
-	ServiceStore      *service.Store
-	FavoriteStore     *favorite.Store
-	PolicyStore       *escalation.Store
-	ScheduleStore     *schedule.Store
-	CalSubStore       *calsub.Store
-	RotationStore     *rotation.Store
-	OnCallStore       *oncall.Store
-	IntKeyStore       *integrationkey.Store
-	LabelStore        *label.Store
-	RuleStore         *rule.Store
-	OverrideStore     *override.Store
-	ConfigStore       *config.Store
-	LimitStore        *limit.Store
-	SlackStore        *slack.ChannelSender
-	HeartbeatStore    *heartbeat.Store
-	NoticeStore       *notice.Store
-	APIKeyStore       *apikey.Store
+	DB                 *sql.DB
+	AuthBasicStore     *basic.Store
+	UserStore          *user.Store
+	CMStore            *contactmethod.Store
+	NRStore            *notificationrule.Store
+	NCStore            *notificationchannel.Store
+	AlertStore         *alert.Store
+	AlertMetricsStore  *alertmetrics.Store
+	AlertEventBroker   *alertlog.Broker
+	AlertLogStore      *alertlog.Store
+	ServiceStore       *service.Store
+	FavoriteStore      *favorite.Store
+	PolicyStore        *escalation.Store
+	ScheduleStore      *schedule.Store
+	CalSubStore        *calsub.Store
+	RotationStore      *rotation.Store
+	OnCallStore        *oncall.Store
+	IntKeyStore        *integrationkey.Store
+	LabelStore         *label.Store
+	MaintenanceStore   *maintenance.Store
+	IncidentStore      *incident.Store
+	BusinessHoursStore *businesshours.Store
+	RuleStore          *rule.Store
+	OverrideStore      *override.Store
+	ConfigStore        *config.Store
+	LimitStore         *limit.Store
+	SlackStore         *slack.ChannelSender
+	HeartbeatStore     *heartbeat.Store
+	ConfigExportStore  *configexport.Store
+	AuditLogStore      *auditlog.Store
+	NoticeStore        *notice.Store
+	APIKeyStore        *apikey.Store
 
 	AuthLinkStore *authlink.Store
 
@@ -142,13 +156,14 @@ func isGQLValidation(gqlErr *gqlerror.Error) bool {
 		return false
 	}
 
-	return code == errcode.ValidationFailed || code == errcode.ParseFailed
+	return code == errcode.ValidationFailed || code == errcode.ParseFailed || code == errCodeComplexityLimit
 }
 
 func (a *App) Handler() http.Handler {
 	h := handler.NewDefaultServer(
-		graphql2.NewExecutableSchema(graphql2.Config{Resolvers: a}),
+		costSchema{ExecutableSchema: graphql2.NewExecutableSchema(graphql2.Config{Resolvers: a})},
 	)
+	h.Use(&extension.ComplexityLimit{Func: complexityLimit})
 
 	type hasTraceKey int
 	h.Use(apolloTracer{Tracer: apollotracing.Tracer{}, shouldTrace: func(ctx context.Context) bool {
@@ -156,6 +171,19 @@ func (a *App) Handler() http.Handler {
 		return ok && enabled
 	}})
 
+	h.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		if !apikey.IsReadOnly(ctx) {
+			return next(ctx)
+		}
+
+		op := graphql.GetOperationContext(ctx)
+		if op.Operation != nil && op.Operation.Operation == ast.Mutation {
+			return graphql.OneShot(graphql.ErrorResponse(ctx, "mutations are not allowed with a read-only API key"))
+		}
+
+		return next(ctx)
+	})
+
 	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 		src := permission.Source(ctx)
 		if src.Type != permission.SourceTypeGQLAPIKey {
@@ -163,7 +191,7 @@ func (a *App) Handler() http.Handler {
 		}
 
 		p := apikey.PolicyFromContext(ctx)
-		if p == nil || p.Version != 1 {
+		if p == nil || !p.IsSupportedVersion() {
 			return nil, permission.NewAccessDenied("invalid API key")
 		}
 
@@ -173,11 +201,25 @@ func (a *App) Handler() http.Handler {
 
 		field := objName + "." + fieldName
 
-		if slices.Contains(p.AllowedFields, field) {
-			return next(ctx)
+		if !slices.Contains(p.AllowedFields, field) {
+			return nil, permission.NewAccessDenied("field not allowed by API key")
+		}
+		if id, ok := f.Args["serviceID"].(string); ok && !apikey.ServiceAllowed(ctx, id) {
+			return nil, permission.NewAccessDenied("service not allowed by API key")
+		}
+		if objName == "Mutation" {
+			err = a.checkAPIKeyMutation(ctx, fieldName, f.Args)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		return nil, permission.NewAccessDenied("field not allowed by API key")
+		res, err = next(ctx)
+		if err != nil {
+			return res, err
+		}
+
+		return a.filterAPIKeyResult(ctx, res)
 	})
 
 	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
@@ -269,8 +311,12 @@ func (a *App) Handler() http.Handler {
 			return
 		}
 
-		ctx = a.registerLoaders(ctx)
-		defer a.closeLoaders(ctx)
+		// Loaders cache results for the life of the request, which would be stale
+		// for subscriptions over a websocket.
+		if !strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+			ctx = a.registerLoaders(ctx)
+			defer a.closeLoaders(ctx)
+		}
 
 		if req.URL.Query().Get("trace") == "1" && permission.Admin(ctx) {
 			ctx = context.WithValue(ctx, hasTraceKey(1), true)
@@ -0,0 +1,186 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/auditlog"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+type AuditLogEntry App
+
+func (a *App) AuditLogEntry() graphql2.AuditLogEntryResolver { return (*AuditLogEntry)(a) }
+
+func (a *AuditLogEntry) ActorID(ctx context.Context, e *auditlog.Entry) (*string, error) {
+	if e.ActorUserID == "" {
+		return nil, nil
+	}
+
+	return &e.ActorUserID, nil
+}
+
+func (a *AuditLogEntry) Actor(ctx context.Context, e *auditlog.Entry) (*user.User, error) {
+	if e.ActorUserID == "" {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, e.ActorUserID)
+}
+
+func (a *AuditLogEntry) EntityType(ctx context.Context, e *auditlog.Entry) (string, error) {
+	return string(e.EntityType), nil
+}
+
+func (a *AuditLogEntry) Before(ctx context.Context, e *auditlog.Entry) (string, error) {
+	return string(e.Before), nil
+}
+
+func (a *AuditLogEntry) After(ctx context.Context, e *auditlog.Entry) (string, error) {
+	return string(e.After), nil
+}
+
+func (a *AuditLogEntry) Changes(ctx context.Context, e *auditlog.Entry) ([]graphql2.AuditLogChange, error) {
+	changes, err := e.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.AuditLogChange, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, graphql2.AuditLogChange{
+			Field:  c.Field,
+			Before: string(c.Before),
+			After:  string(c.After),
+		})
+	}
+
+	return res, nil
+}
+
+func (q *Query) AuditLogs(ctx context.Context, input *graphql2.AuditLogSearchOptions) (conn *graphql2.AuditLogConnection, err error) {
+	if input == nil {
+		input = &graphql2.AuditLogSearchOptions{}
+	}
+
+	var opts auditlog.SearchOptions
+	if input.FilterByActorID != nil {
+		opts.ActorUserID = *input.FilterByActorID
+	}
+	if input.FilterByEntityType != nil {
+		opts.EntityType = auditlog.EntityType(*input.FilterByEntityType)
+	}
+	if input.FilterByEntityID != nil {
+		opts.EntityID = *input.FilterByEntityID
+	}
+	if input.CreatedBefore != nil {
+		opts.Before = *input.CreatedBefore
+	}
+	if input.NotCreatedBefore != nil {
+		opts.NotBefore = *input.NotCreatedBefore
+	}
+	if input.After != nil && *input.After != "" {
+		err = search.ParseCursor(*input.After, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.First != nil {
+		opts.Limit = *input.First
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 15
+	}
+	err = validate.Range("First", opts.Limit, 1, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Limit++
+	entries, err := q.AuditLogStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = new(graphql2.AuditLogConnection)
+	conn.PageInfo = &graphql2.PageInfo{}
+	if len(entries) == opts.Limit {
+		entries = entries[:len(entries)-1]
+		conn.PageInfo.HasNextPage = true
+	}
+	if len(entries) > 0 {
+		opts.AfterID = entries[len(entries)-1].ID
+		cur, err := search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+	conn.Nodes = entries
+	return conn, nil
+}
+
+// withAuditTx will run fn in a transaction and record the mutation in the audit log. fn
+// should set the Before and After snapshots of the entries it changes.
+//
+// Successful mutations are logged in the same transaction, so a change is never committed
+// without its entries. Failed mutations are logged separately, along with the error, so
+// attempts are recorded even though the transaction is rolled back.
+func (a *App) withAuditTx(ctx context.Context, action string, fn func(context.Context, *sql.Tx, *auditEntries) error) error {
+	entries := &auditEntries{action: action}
+	err := withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := fn(ctx, tx, entries)
+		if err != nil {
+			return err
+		}
+
+		return a.AuditLogStore.LogTx(ctx, tx, entries.entries()...)
+	})
+	if err != nil {
+		a.logFailedAudit(ctx, entries, err)
+	}
+
+	return err
+}
+
+// logFailedAudit will record the entries of a failed mutation, outside of any transaction.
+func (a *App) logFailedAudit(ctx context.Context, entries *auditEntries, err error) {
+	if len(entries.list) == 0 {
+		return
+	}
+
+	for _, e := range entries.list {
+		e.Error = err.Error()
+	}
+	// the request may have been canceled, don't lose the entries with it
+	logErr := a.AuditLogStore.LogTx(context.WithoutCancel(ctx), nil, entries.entries()...)
+	if logErr != nil {
+		log.Log(ctx, errors.Wrap(logErr, "audit failed mutation"))
+	}
+}
+
+// auditEntries collects the audit log entries of a single mutation.
+type auditEntries struct {
+	action string
+	list   []*auditlog.Entry
+}
+
+// Add will start a new entry for the given entity, returning it so snapshots can be set.
+func (a *auditEntries) Add(typ auditlog.EntityType, id string) *auditlog.Entry {
+	e := &auditlog.Entry{Action: a.action, EntityType: typ, EntityID: id}
+	a.list = append(a.list, e)
+	return e
+}
+
+func (a *auditEntries) entries() []auditlog.Entry {
+	res := make([]auditlog.Entry, 0, len(a.list))
+	for _, e := range a.list {
+		res = append(res, *e)
+	}
+	return res
+}
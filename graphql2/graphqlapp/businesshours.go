@@ -0,0 +1,100 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/businesshours"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+type BusinessHours App
+
+func (a *App) BusinessHours() graphql2.BusinessHoursResolver { return (*BusinessHours)(a) }
+
+func (bh *BusinessHours) TimeZone(ctx context.Context, raw *businesshours.BusinessHours) (string, error) {
+	return raw.TimeZone.String(), nil
+}
+
+func (q *Query) BusinessHours(ctx context.Context, id string) (*businesshours.BusinessHours, error) {
+	return q.BusinessHoursStore.FindOne(ctx, id)
+}
+
+func (q *Query) AllBusinessHours(ctx context.Context) ([]businesshours.BusinessHours, error) {
+	return q.BusinessHoursStore.FindAll(ctx)
+}
+
+func (m *Mutation) CreateBusinessHours(ctx context.Context, input graphql2.CreateBusinessHoursInput) (bh *businesshours.BusinessHours, err error) {
+	loc, err := util.LoadLocation(input.TimeZone)
+	if err != nil {
+		return nil, validation.NewFieldError("timeZone", err.Error())
+	}
+
+	bh = &businesshours.BusinessHours{
+		Name:          input.Name,
+		TimeZone:      loc,
+		WeekdayFilter: input.WeekdayFilter,
+		Start:         input.Start,
+		End:           input.End,
+	}
+	if input.Description != nil {
+		bh.Description = *input.Description
+	}
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		bh, err = m.BusinessHoursStore.CreateTx(ctx, tx, bh)
+		return err
+	})
+	return bh, err
+}
+
+func (m *Mutation) UpdateBusinessHours(ctx context.Context, input graphql2.UpdateBusinessHoursInput) (bool, error) {
+	bh, err := m.BusinessHoursStore.FindOne(ctx, input.ID)
+	if err != nil {
+		return false, err
+	}
+	if bh == nil {
+		return false, validation.NewFieldError("id", "business hours not found")
+	}
+
+	if input.Name != nil {
+		bh.Name = *input.Name
+	}
+	if input.Description != nil {
+		bh.Description = *input.Description
+	}
+	if input.TimeZone != nil {
+		bh.TimeZone, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return false, validation.NewFieldError("timeZone", err.Error())
+		}
+	}
+	if input.WeekdayFilter != nil {
+		bh.WeekdayFilter = *input.WeekdayFilter
+	}
+	if input.Start != nil {
+		bh.Start = *input.Start
+	}
+	if input.End != nil {
+		bh.End = *input.End
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.BusinessHoursStore.UpdateTx(ctx, tx, bh)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Mutation) DeleteBusinessHours(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.BusinessHoursStore.DeleteTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
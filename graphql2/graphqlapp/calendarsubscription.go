@@ -22,6 +22,14 @@ func (a *UserCalendarSubscription) ReminderMinutes(ctx context.Context, obj *cal
 	return obj.Config.ReminderMinutes, nil
 }
 
+func (a *UserCalendarSubscription) FullSchedule(ctx context.Context, obj *calsub.Subscription) (bool, error) {
+	return obj.Config.FullSchedule, nil
+}
+
+func (a *UserCalendarSubscription) HorizonDays(ctx context.Context, obj *calsub.Subscription) (int, error) {
+	return obj.Config.Horizon(), nil
+}
+
 func (a *UserCalendarSubscription) Schedule(ctx context.Context, obj *calsub.Subscription) (*schedule.Schedule, error) {
 	return a.ScheduleStore.FindOne(ctx, obj.ScheduleID)
 }
@@ -55,6 +63,12 @@ func (m *Mutation) CreateUserCalendarSubscription(ctx context.Context, input gra
 		cs.Disabled = *input.Disabled
 	}
 	cs.Config.ReminderMinutes = input.ReminderMinutes
+	if input.FullSchedule != nil {
+		cs.Config.FullSchedule = *input.FullSchedule
+	}
+	if input.HorizonDays != nil {
+		cs.Config.HorizonDays = *input.HorizonDays
+	}
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		cs, err = m.CalSubStore.CreateTx(ctx, tx, cs)
@@ -80,6 +94,12 @@ func (m *Mutation) UpdateUserCalendarSubscription(ctx context.Context, input gra
 		if input.ReminderMinutes != nil {
 			cs.Config.ReminderMinutes = input.ReminderMinutes
 		}
+		if input.FullSchedule != nil {
+			cs.Config.FullSchedule = *input.FullSchedule
+		}
+		if input.HorizonDays != nil {
+			cs.Config.HorizonDays = *input.HorizonDays
+		}
 
 		return m.CalSubStore.UpdateTx(ctx, tx, cs)
 	})
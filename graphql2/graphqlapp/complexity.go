@@ -0,0 +1,132 @@
+package graphqlapp
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/config"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	// errCodeComplexityLimit is the error code used by gqlgen when an operation exceeds the complexity limit.
+	errCodeComplexityLimit = "COMPLEXITY_LIMIT_EXCEEDED"
+
+	// defaultConnectionSize is used for connection fields when the page size is not known.
+	defaultConnectionSize = 15
+
+	// listCostFactor is the assumed number of items returned by list fields that are not paginated.
+	listCostFactor = 10
+)
+
+// costSchema wraps an ExecutableSchema to estimate the cost of each field based on its
+// type. List fields multiply the cost of their selections by the expected number of items,
+// and connection fields by the requested page size.
+type costSchema struct {
+	graphql.ExecutableSchema
+}
+
+// Complexity implements graphql.ExecutableSchema.
+func (s costSchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	obj := s.Schema().Types[typeName]
+	if obj == nil {
+		return 0, false
+	}
+	def := obj.Fields.ForName(fieldName)
+	if def == nil {
+		return 0, false
+	}
+
+	switch {
+	case def.Type.Elem != nil:
+		return safeAdd(1, safeMul(childComplexity, listCostFactor)), true
+	case strings.HasSuffix(def.Type.Name(), "Connection"):
+		return safeAdd(1, safeMul(childComplexity, s.pageSize(def, args))), true
+	}
+
+	return 0, false
+}
+
+// pageSize returns the `first` argument of a connection field, either directly or from
+// its `input` argument, falling back to the schema default.
+func (s costSchema) pageSize(def *ast.FieldDefinition, args map[string]interface{}) int {
+	if n, ok := intArg(args["first"]); ok {
+		return n
+	}
+	if input, ok := args["input"].(map[string]interface{}); ok {
+		if n, ok := intArg(input["first"]); ok {
+			return n
+		}
+	}
+
+	if arg := def.Arguments.ForName("first"); arg != nil && arg.DefaultValue != nil {
+		if n, err := strconv.Atoi(arg.DefaultValue.Raw); err == nil {
+			return n
+		}
+	}
+	if arg := def.Arguments.ForName("input"); arg != nil {
+		if in := s.Schema().Types[arg.Type.Name()]; in != nil {
+			if f := in.Fields.ForName("first"); f != nil && f.DefaultValue != nil {
+				if n, err := strconv.Atoi(f.DefaultValue.Raw); err == nil {
+					return n
+				}
+			}
+		}
+	}
+
+	return defaultConnectionSize
+}
+
+// complexityLimit returns the maximum complexity allowed for an operation. API keys with a
+// complexity limit in their policy use it in place of the configured limit.
+func complexityLimit(ctx context.Context, rc *graphql.OperationContext) int {
+	limit := config.FromContext(ctx).General.GraphQLMaxComplexity
+	if p := apikey.PolicyFromContext(ctx); p != nil && p.MaxComplexity > 0 {
+		limit = p.MaxComplexity
+	}
+	if limit <= 0 {
+		return math.MaxInt
+	}
+
+	return limit
+}
+
+func intArg(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+
+	return 0, false
+}
+
+// safeAdd adds a and b, saturating at math.MaxInt.
+func safeAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// safeMul multiplies a and b, saturating at math.MaxInt. Negative values are treated as zero.
+func safeMul(a, b int) int {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	if a > math.MaxInt/b {
+		return math.MaxInt
+	}
+	return a * b
+}
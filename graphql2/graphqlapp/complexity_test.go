@@ -0,0 +1,32 @@
+package graphqlapp
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/graphql2"
+	"github.com/vektah/gqlparser/v2"
+)
+
+func TestCostSchema(t *testing.T) {
+	es := costSchema{ExecutableSchema: graphql2.NewExecutableSchema(graphql2.Config{})}
+
+	check := func(t *testing.T, query string, vars map[string]interface{}, expected int) {
+		t.Helper()
+		doc, errs := gqlparser.LoadQuery(es.Schema(), query)
+		require.Empty(t, errs)
+		assert.Equal(t, expected, complexity.Calculate(es, doc.Operations[0], vars))
+	}
+
+	check(t, `{ user { id name } }`, nil, 3)
+
+	// 1 + 15 * (nodes: 1 + 10 * (id + name))
+	check(t, `{ alerts { nodes { id summary } } }`, nil, 1+15*(1+10*2))
+	check(t, `{ alerts(input: {first: 100}) { nodes { id } } }`, nil, 1+100*(1+10))
+	check(t, `query ($n: Int) { alerts(input: {first: $n}) { nodes { id } } }`, map[string]interface{}{"n": 5}, 1+5*(1+10))
+
+	// nested lists multiply
+	check(t, `{ services { nodes { labels { key } } } }`, nil, 1+15*(1+10*(1+10)))
+}
@@ -2,10 +2,14 @@ package graphqlapp
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
 )
 
 func (a *Query) IntegrationKeyTypes(ctx context.Context) ([]graphql2.IntegrationKeyTypeInfo, error) {
@@ -16,6 +20,7 @@ func (a *Query) IntegrationKeyTypes(ctx context.Context) ([]graphql2.Integration
 		{ID: "grafana", Name: "Grafana", Label: "Grafana Webhook URL", Enabled: true},
 		{ID: "site24x7", Name: "Generic", Label: "Site24x7 Webhook URL", Enabled: true},
 		{ID: "prometheusAlertmanager", Label: "Alertmanager Webhook URL", Name: "Prometheus Alertmanager", Enabled: true},
+		{ID: "opsgenie", Name: "OpsGenie", Label: "OpsGenie API URL", Enabled: true},
 	}, nil
 }
 
@@ -47,9 +52,81 @@ func (q *Query) ConfigHints(ctx context.Context) ([]graphql2.ConfigHint, error)
 	return graphql2.MapConfigHints(q.ConfigStore.Config().Hints()), nil
 }
 
+func (q *Query) ExportConfig(ctx context.Context) (string, error) {
+	doc, err := q.ConfigExportStore.Export(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
 func (m *Mutation) SetConfig(ctx context.Context, input []graphql2.ConfigValueInput) (bool, error) {
-	err := m.ConfigStore.UpdateConfig(ctx, func(cfg config.Config) (config.Config, error) {
-		return graphql2.ApplyConfigValues(cfg, input)
+	var id int
+	err := (*App)(m).withAuditTx(ctx, "setConfig", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		var err error
+		id, err = m.ConfigStore.UpdateConfigTx(ctx, tx, func(cfg config.Config) (config.Config, error) {
+			newCfg, err := graphql2.ApplyConfigValues(cfg, input)
+			if err != nil {
+				return newCfg, err
+			}
+
+			return newCfg, auditConfigValues(audit, graphql2.MapConfigValues(cfg), graphql2.MapConfigValues(newCfg))
+		})
+		return err
 	})
+	if err != nil {
+		return false, err
+	}
+
+	log.Logf(ctx, "Set configuration to version %d (schema version %d)", id, config.SchemaVersion)
+	err = m.ConfigStore.Reload(ctx)
 	return err == nil, err
 }
+
+// configAuditValue is the audit log snapshot of a single config value.
+type configAuditValue struct {
+	Value string `json:"value"`
+}
+
+// redactedConfigValue replaces the value of password fields in the audit log.
+const redactedConfigValue = "<redacted>"
+
+// auditConfigValues will add an entry for each config value that differs between before
+// and after. The values of password fields are never logged, only whether they changed.
+func auditConfigValues(audit *auditEntries, before, after []graphql2.ConfigValue) error {
+	for i, b := range before {
+		a := after[i]
+		if a.Value == b.Value {
+			continue
+		}
+
+		bVal, aVal := configAuditValue{Value: b.Value}, configAuditValue{Value: a.Value}
+		if b.Password {
+			bVal.Value, aVal.Value = "", ""
+			if b.Value != "" {
+				bVal.Value = redactedConfigValue
+			}
+			if a.Value != "" {
+				aVal.Value = redactedConfigValue + " (changed)"
+			}
+		}
+
+		e := audit.Add(auditlog.EntityTypeConfig, b.ID)
+		err := e.SetBefore(bVal)
+		if err != nil {
+			return err
+		}
+		err = e.SetAfter(aVal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
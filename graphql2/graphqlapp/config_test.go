@@ -0,0 +1,39 @@
+package graphqlapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/graphql2"
+)
+
+func TestAuditConfigValues(t *testing.T) {
+	before := []graphql2.ConfigValue{
+		{ID: "General.ApplicationName", Value: "GoAlert"},
+		{ID: "General.PublicURL", Value: "http://example.com"},
+		{ID: "SMTP.Password", Value: "old", Password: true},
+		{ID: "Twilio.AuthToken", Value: "", Password: true},
+	}
+	after := []graphql2.ConfigValue{
+		{ID: "General.ApplicationName", Value: "Audited"},
+		{ID: "General.PublicURL", Value: "http://example.com"},
+		{ID: "SMTP.Password", Value: "new", Password: true},
+		{ID: "Twilio.AuthToken", Value: "", Password: true},
+	}
+
+	audit := &auditEntries{action: "setConfig"}
+	err := auditConfigValues(audit, before, after)
+	require.NoError(t, err)
+
+	entries := audit.entries()
+	require.Len(t, entries, 2, "only changed values")
+
+	assert.Equal(t, "General.ApplicationName", entries[0].EntityID)
+	assert.JSONEq(t, `{"value":"GoAlert"}`, string(entries[0].Before))
+	assert.JSONEq(t, `{"value":"Audited"}`, string(entries[0].After))
+
+	assert.Equal(t, "SMTP.Password", entries[1].EntityID)
+	assert.JSONEq(t, `{"value":"<redacted>"}`, string(entries[1].Before))
+	assert.JSONEq(t, `{"value":"<redacted> (changed)"}`, string(entries[1].After))
+}
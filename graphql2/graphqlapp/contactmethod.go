@@ -167,7 +167,8 @@ func (m *Mutation) UpdateUserContactMethod(ctx context.Context, input graphql2.U
 }
 
 func (m *Mutation) SendContactMethodVerification(ctx context.Context, input graphql2.SendContactMethodVerificationInput) (bool, error) {
-	err := m.NotificationStore.SendContactMethodVerification(ctx, input.ContactMethodID)
+	voice := input.Voice != nil && *input.Voice
+	err := m.NotificationStore.SendContactMethodVerification(ctx, input.ContactMethodID, voice)
 	return err == nil, err
 }
 
@@ -39,6 +39,7 @@ const (
 	dataLoaderKeyNC
 	dataLoaderAlertMetrics
 	dataLoaderAlertFeedback
+	dataLoaderAlertAssignment
 
 	dataLoaderKeyLast // always keep as last
 )
@@ -57,6 +58,7 @@ func (a *App) registerLoaders(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, dataLoaderKeyNC, dataloader.NewStoreLoader(ctx, a.NCStore.FindMany))
 	ctx = context.WithValue(ctx, dataLoaderAlertMetrics, dataloader.NewStoreLoaderInt(ctx, a.AlertMetricsStore.FindMetrics))
 	ctx = context.WithValue(ctx, dataLoaderAlertFeedback, dataloader.NewStoreLoaderInt(ctx, a.AlertStore.Feedback))
+	ctx = context.WithValue(ctx, dataLoaderAlertAssignment, dataloader.NewStoreLoaderInt(ctx, a.AlertStore.Assignments))
 	return ctx
 }
 
@@ -99,6 +101,22 @@ func (app *App) FindOneAlertFeedback(ctx context.Context, id int) (*alert.Feedba
 	return loader.FetchOne(ctx, id)
 }
 
+func (app *App) FindOneAlertAssignment(ctx context.Context, id int) (*alert.Assignment, error) {
+	loader, ok := ctx.Value(dataLoaderAlertAssignment).(*dataloader.Loader[int, alert.Assignment])
+	if !ok {
+		assignments, err := app.AlertStore.Assignments(ctx, []int{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(assignments) == 0 {
+			return nil, nil
+		}
+		return &assignments[0], nil
+	}
+
+	return loader.FetchOne(ctx, id)
+}
+
 func (app *App) FindOneRotation(ctx context.Context, id string) (*rotation.Rotation, error) {
 	loader, ok := ctx.Value(dataLoaderKeyRotation).(*dataloader.Loader[string, rotation.Rotation])
 	if !ok {
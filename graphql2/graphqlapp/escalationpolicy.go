@@ -7,6 +7,8 @@ import (
 	"strconv"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
+	"github.com/target/goalert/businesshours"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
@@ -34,6 +36,36 @@ func contains(ids []string, id string) bool {
 	return false
 }
 
+// checkStepTargets returns a validation error for the field if any of the targets are
+// disabled or not allowed by the administrator.
+func checkStepTargets(cfg config.Config, field string, tgts []assignment.RawTarget) error {
+	for _, tgt := range tgts {
+		if tgt.Type == assignment.TargetTypeChanWebhook && !cfg.ValidWebhookURL(tgt.ID) {
+			// UI code expects targets to be un-indexed
+			return validation.NewFieldError(field, "URL not allowed by administrator")
+		}
+		if tgt.Type == assignment.TargetTypeMSTeamsChannel && !cfg.MSTeams.Enable {
+			return validation.NewFieldError(field, "Microsoft Teams is disabled by administrator")
+		}
+		if tgt.Type == assignment.TargetTypePagerDuty && !cfg.PagerDuty.Enable {
+			return validation.NewFieldError(field, "PagerDuty is disabled by administrator")
+		}
+	}
+
+	return nil
+}
+
+func checkRoutingWebhook(cfg config.Config, url *string) error {
+	if url == nil || *url == "" {
+		return nil
+	}
+	if !cfg.ValidWebhookURL(*url) {
+		return validation.NewFieldError("routingWebhookURL", "URL not allowed by administrator")
+	}
+
+	return nil
+}
+
 func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql2.CreateEscalationPolicyStepInput) (step *escalation.Step, err error) {
 	cfg := config.FromContext(ctx)
 	if len(input.Targets) != 0 && input.NewRotation != nil {
@@ -57,25 +89,62 @@ func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql
 		)
 	}
 
-	for _, tgt := range input.Targets {
-		if tgt.Type == assignment.TargetTypeChanWebhook && !cfg.ValidWebhookURL(tgt.ID) {
-			// UI code expects targets to be un-indexed
-			return nil, validation.NewFieldError("targets", "URL not allowed by administrator")
-		}
+	if (len(input.InHoursTargets) != 0 || len(input.AfterHoursTargets) != 0) && (input.RoutingBusinessHoursID == nil || *input.RoutingBusinessHoursID == "") {
+		return nil, validation.NewFieldError("routingBusinessHoursID", "required for inHoursTargets and afterHoursTargets")
 	}
 
-	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err = validate.Many(
+		checkStepTargets(cfg, "targets", input.Targets),
+		checkStepTargets(cfg, "inHoursTargets", input.InHoursTargets),
+		checkStepTargets(cfg, "afterHoursTargets", input.AfterHoursTargets),
+		checkRoutingWebhook(cfg, input.RoutingWebhookURL),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = (*App)(m).withAuditTx(ctx, "createEscalationPolicyStep", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeEscalationPolicyStep, "")
 		s := &escalation.Step{
 			DelayMinutes: input.DelayMinutes,
 		}
 		if input.EscalationPolicyID != nil {
 			s.PolicyID = *input.EscalationPolicyID
 		}
+		err = (*App)(m).requireManage(ctx, tx, assignment.EscalationPolicyTarget(s.PolicyID))
+		if err != nil {
+			return err
+		}
+		if input.OffHoursDelayMinutes != nil {
+			s.OffHoursDelayMinutes = *input.OffHoursDelayMinutes
+		}
+		if input.DelayBusinessHoursID != nil {
+			s.DelayBusinessHoursID = *input.DelayBusinessHoursID
+		}
+		if input.AssignmentStrategy != nil {
+			s.AssignmentStrategy = *input.AssignmentStrategy
+		}
+		if input.MinSeverity != nil {
+			s.MinSeverity = *input.MinSeverity
+		}
+		if input.LowUrgency != nil {
+			s.LowUrgency = *input.LowUrgency
+		}
+		if input.ConferenceBridge != nil {
+			s.ConferenceBridge = *input.ConferenceBridge
+		}
+		if input.RoutingBusinessHoursID != nil {
+			s.RoutingBusinessHoursID = *input.RoutingBusinessHoursID
+		}
+		if input.RoutingWebhookURL != nil {
+			s.RoutingWebhookURL = *input.RoutingWebhookURL
+		}
 
 		step, err = m.PolicyStore.CreateStepTx(ctx, tx, s)
 		if err != nil {
 			return err
 		}
+		e.EntityID = step.ID
 
 		if input.NewRotation != nil {
 			rot, err := m.CreateRotation(ctx, *input.NewRotation)
@@ -108,30 +177,61 @@ func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql
 		}
 
 		userID := permission.UserID(ctx)
-		for i, tgt := range input.Targets {
-			if tgt.Type == assignment.TargetTypeUser && tgt.ID == "__current_user" {
-				tgt.ID = userID
-			}
-			err = m.PolicyStore.AddStepTargetTx(ctx, tx, step.ID, tgt)
-			if err != nil {
-				return validation.AddPrefix("targets["+strconv.Itoa(i)+"].", err)
+		addTargets := func(field string, tgts []assignment.RawTarget, cond escalation.TargetCondition) error {
+			for i, tgt := range tgts {
+				if tgt.Type == assignment.TargetTypeUser && tgt.ID == "__current_user" {
+					tgt.ID = userID
+				}
+				err := m.PolicyStore.AddStepTargetTx(ctx, tx, step.ID, tgt)
+				if err == nil && cond != escalation.TargetConditionAlways {
+					err = m.PolicyStore.SetStepTargetConditionTx(ctx, tx, step.ID, tgt, cond)
+				}
+				if err != nil {
+					return validation.AddPrefix(field+"["+strconv.Itoa(i)+"].", err)
+				}
 			}
+			return nil
 		}
 
-		return err
+		err = addTargets("targets", input.Targets, escalation.TargetConditionAlways)
+		if err != nil {
+			return err
+		}
+		err = addTargets("inHoursTargets", input.InHoursTargets, escalation.TargetConditionInHours)
+		if err != nil {
+			return err
+		}
+
+		err = addTargets("afterHoursTargets", input.AfterHoursTargets, escalation.TargetConditionAfterHours)
+		if err != nil {
+			return err
+		}
+
+		after, err := m.auditStepTx(ctx, tx, step.ID)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(after)
 	})
 
 	return step, err
 }
 
 func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.CreateEscalationPolicyInput) (pol *escalation.Policy, err error) {
-	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err = (*App)(m).withAuditTx(ctx, "createEscalationPolicy", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeEscalationPolicy, "")
 		p := &escalation.Policy{
 			Name: input.Name,
 		}
 		if input.Repeat != nil {
 			p.Repeat = *input.Repeat
 		}
+		if input.MaxNotifications != nil {
+			p.MaxNotifications = *input.MaxNotifications
+		}
+		if input.AckTimeoutMinutes != nil {
+			p.AckTimeoutMinutes = *input.AckTimeoutMinutes
+		}
 		if input.Description != nil {
 			p.Description = *input.Description
 		}
@@ -140,6 +240,7 @@ func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.Cr
 		if err != nil {
 			return err
 		}
+		e.EntityID = pol.ID
 		if input.Favorite != nil && *input.Favorite {
 			err = m.FavoriteStore.SetTx(ctx, tx, permission.UserID(ctx), assignment.EscalationPolicyTarget(pol.ID))
 			if err != nil {
@@ -154,19 +255,59 @@ func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.Cr
 				return validation.AddPrefix("Steps["+strconv.Itoa(i)+"].", err)
 			}
 		}
-		return err
+
+		after, err := m.auditPolicyTx(ctx, tx, pol)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(after)
 	})
 
 	return pol, err
 }
 
+func (m *Mutation) CloneEscalationPolicy(ctx context.Context, input graphql2.CloneEscalationPolicyInput) (id string, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		pol, err := m.PolicyStore.ClonePolicyTx(ctx, tx, input.ID, input.Name)
+		if err != nil {
+			return err
+		}
+		if input.Favorite != nil && *input.Favorite {
+			err = m.FavoriteStore.SetTx(ctx, tx, permission.UserID(ctx), assignment.EscalationPolicyTarget(pol.ID))
+			if err != nil {
+				return err
+			}
+		}
+
+		id = pol.ID
+		return nil
+	})
+
+	return id, err
+}
+
 func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.UpdateEscalationPolicyInput) (bool, error) {
-	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err := (*App)(m).withAuditTx(ctx, "updateEscalationPolicy", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeEscalationPolicy, input.ID)
 		ep, err := m.PolicyStore.FindOnePolicyForUpdateTx(ctx, tx, input.ID)
 		if err != nil {
 			return err
 		}
 
+		err = (*App)(m).requireManage(ctx, tx, assignment.EscalationPolicyTarget(ep.ID))
+		if err != nil {
+			return err
+		}
+
+		before, err := m.auditPolicyTx(ctx, tx, ep)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(before)
+		if err != nil {
+			return err
+		}
+
 		if input.Name != nil {
 			ep.Name = *input.Name
 		}
@@ -179,6 +320,14 @@ func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.Up
 			ep.Repeat = *input.Repeat
 		}
 
+		if input.MaxNotifications != nil {
+			ep.MaxNotifications = *input.MaxNotifications
+		}
+
+		if input.AckTimeoutMinutes != nil {
+			ep.AckTimeoutMinutes = *input.AckTimeoutMinutes
+		}
+
 		err = m.PolicyStore.UpdatePolicyTx(ctx, tx, ep)
 		if err != nil {
 			return err
@@ -220,20 +369,39 @@ func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.Up
 			}
 		}
 
-		return err
+		after, err := m.auditPolicyTx(ctx, tx, ep)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(after)
 	})
 
 	return true, err
 }
 
 func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql2.UpdateEscalationPolicyStepInput) (bool, error) {
-	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err := (*App)(m).withAuditTx(ctx, "updateEscalationPolicyStep", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeEscalationPolicyStep, input.ID)
 		cfg := config.FromContext(ctx)
 		step, err := m.PolicyStore.FindOneStepForUpdateTx(ctx, tx, input.ID) // get delay
 		if err != nil {
 			return err
 		}
 
+		err = (*App)(m).requireManage(ctx, tx, assignment.EscalationPolicyTarget(step.PolicyID))
+		if err != nil {
+			return err
+		}
+
+		before, err := m.auditStepTx(ctx, tx, step.ID)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(before)
+		if err != nil {
+			return err
+		}
+
 		// update delay if provided
 		if input.DelayMinutes != nil {
 			step.DelayMinutes = *input.DelayMinutes
@@ -244,71 +412,248 @@ func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql
 			}
 		}
 
-		// update targets if provided
-		if input.Targets != nil {
-			step.Targets = make([]assignment.Target, len(input.Targets))
-			for i, tgt := range input.Targets {
-				if tgt.Type == assignment.TargetTypeChanWebhook && !cfg.ValidWebhookURL(tgt.ID) {
-					// UI code expects targets to be un-indexed
-					return validation.NewFieldError("targets", "URL not allowed by administrator")
-				}
-				step.Targets[i] = tgt
+		// update off-hours delay if provided, 0 clears it along with delay business hours
+		if input.OffHoursDelayMinutes != nil || input.DelayBusinessHoursID != nil {
+			if input.OffHoursDelayMinutes != nil {
+				step.OffHoursDelayMinutes = *input.OffHoursDelayMinutes
+			}
+			if input.DelayBusinessHoursID != nil {
+				step.DelayBusinessHoursID = *input.DelayBusinessHoursID
+			}
+			if step.OffHoursDelayMinutes == 0 && input.DelayBusinessHoursID == nil {
+				step.DelayBusinessHoursID = ""
 			}
 
-			// get current targets on step
-			curr, err := m.PolicyStore.FindAllStepTargetsTx(ctx, tx, step.ID)
+			err = m.PolicyStore.UpdateStepOverrideTx(ctx, tx, step)
 			if err != nil {
 				return err
 			}
+		}
 
-			wantedTargets := make(map[assignment.RawTarget]int, len(step.Targets))
-			currentTargets := make(map[assignment.RawTarget]bool, len(curr))
+		// update assignment strategy if provided
+		if input.AssignmentStrategy != nil {
+			step.AssignmentStrategy = *input.AssignmentStrategy
 
-			// construct maps
-			for i, tgt := range step.Targets {
-				rt := assignment.NewRawTarget(tgt)
-				if oldIdx, ok := wantedTargets[rt]; ok {
-					return validation.NewFieldError(fmt.Sprintf("Targets[%d]", i), fmt.Sprintf("Duplicates existing target at index %d.", oldIdx))
-				}
-				wantedTargets[rt] = i
+			err = m.PolicyStore.UpdateStepAssignmentStrategyTx(ctx, tx, step.ID, step.AssignmentStrategy)
+			if err != nil {
+				return err
 			}
-			for _, tgt := range curr {
-				currentTargets[assignment.NewRawTarget(tgt)] = true
+		}
+
+		// update minimum severity if provided
+		if input.MinSeverity != nil {
+			step.MinSeverity = *input.MinSeverity
+
+			err = m.PolicyStore.UpdateStepMinSeverityTx(ctx, tx, step)
+			if err != nil {
+				return err
 			}
+		}
 
-			// add targets in wanted that are not in curr
-			for tgt, idx := range wantedTargets {
-				if currentTargets[tgt] {
-					continue
-				}
+		// update low urgency if provided
+		if input.LowUrgency != nil {
+			step.LowUrgency = *input.LowUrgency
 
-				// add new step
-				err = m.PolicyStore.AddStepTargetTx(ctx, tx, step.ID, tgt)
-				if err != nil {
-					return validation.AddPrefix(fmt.Sprintf("Targets[%d].", idx), err)
-				}
+			err = m.PolicyStore.UpdateStepLowUrgencyTx(ctx, tx, step)
+			if err != nil {
+				return err
 			}
+		}
 
-			// remove targets in curr that are not in wanted
-			for tgt := range currentTargets {
-				if _, ok := wantedTargets[tgt]; ok {
-					continue
-				}
+		// update conference bridge if provided
+		if input.ConferenceBridge != nil {
+			step.ConferenceBridge = *input.ConferenceBridge
+
+			err = m.PolicyStore.UpdateStepConferenceBridgeTx(ctx, tx, step)
+			if err != nil {
+				return err
+			}
+		}
+
+		// update routing business hours if provided, an empty ID removes routing
+		if input.RoutingBusinessHoursID != nil {
+			step.RoutingBusinessHoursID = *input.RoutingBusinessHoursID
+
+			err = m.PolicyStore.UpdateStepRoutingTx(ctx, tx, step)
+			if err != nil {
+				return err
+			}
+		}
+
+		// update routing webhook if provided, an empty URL removes it
+		if input.RoutingWebhookURL != nil {
+			err = checkRoutingWebhook(cfg, input.RoutingWebhookURL)
+			if err != nil {
+				return err
+			}
+			step.RoutingWebhookURL = *input.RoutingWebhookURL
+
+			err = m.PolicyStore.UpdateStepRoutingWebhookTx(ctx, tx, step)
+			if err != nil {
+				return err
+			}
+		}
+		if step.RoutingWebhookURL != "" && step.AssignmentStrategy == escalation.AssignmentStrategyRoundRobin {
+			return validation.NewFieldError("routingWebhookURL", "cannot be used with round-robin assignment")
+		}
+
+		// update targets if provided
+		if input.Targets != nil || input.InHoursTargets != nil || input.AfterHoursTargets != nil {
+			err = validate.Many(
+				checkStepTargets(cfg, "targets", input.Targets),
+				checkStepTargets(cfg, "inHoursTargets", input.InHoursTargets),
+				checkStepTargets(cfg, "afterHoursTargets", input.AfterHoursTargets),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = m.updateStepTargets(ctx, tx, step.ID, input)
+			if err != nil {
+				return err
+			}
+		}
 
-				// delete unwanted step
-				err = m.PolicyStore.DeleteStepTargetTx(ctx, tx, step.ID, tgt)
+		// conditional targets are only valid with routing business hours
+		if step.RoutingBusinessHoursID == "" && (input.RoutingBusinessHoursID != nil || input.InHoursTargets != nil || input.AfterHoursTargets != nil) {
+			for _, cond := range []escalation.TargetCondition{escalation.TargetConditionInHours, escalation.TargetConditionAfterHours} {
+				tgts, err := m.PolicyStore.FindStepTargetsByConditionTx(ctx, tx, step.ID, cond)
 				if err != nil {
 					return err
 				}
+				if len(tgts) > 0 {
+					return validation.NewFieldError("routingBusinessHoursID", "required for inHoursTargets and afterHoursTargets")
+				}
 			}
 		}
 
-		return err
+		after, err := m.auditStepTx(ctx, tx, step.ID)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(after)
 	})
 
 	return true, err
 }
 
+// updateStepTargets replaces the targets of a step. Targets keep their current condition unless
+// inHoursTargets or afterHoursTargets are provided, which replace the respective conditional
+// targets and are added to the step if needed.
+func (m *Mutation) updateStepTargets(ctx context.Context, tx *sql.Tx, stepID string, input graphql2.UpdateEscalationPolicyStepInput) error {
+	// get current targets and conditions on step
+	curr, err := m.PolicyStore.FindAllStepTargetsTx(ctx, tx, stepID)
+	if err != nil {
+		return err
+	}
+	currentTargets := make(map[assignment.RawTarget]escalation.TargetCondition, len(curr))
+	for _, tgt := range curr {
+		currentTargets[assignment.NewRawTarget(tgt)] = escalation.TargetConditionAlways
+	}
+	for _, cond := range []escalation.TargetCondition{escalation.TargetConditionInHours, escalation.TargetConditionAfterHours} {
+		tgts, err := m.PolicyStore.FindStepTargetsByConditionTx(ctx, tx, stepID, cond)
+		if err != nil {
+			return err
+		}
+		for _, tgt := range tgts {
+			currentTargets[assignment.NewRawTarget(tgt)] = cond
+		}
+	}
+
+	// construct wanted targets, keeping the current ones if not provided
+	wantedTargets := make(map[assignment.RawTarget]escalation.TargetCondition, len(currentTargets))
+	fields := make(map[assignment.RawTarget]string)
+	if input.Targets == nil {
+		for tgt, cond := range currentTargets {
+			wantedTargets[tgt] = cond
+		}
+	}
+	targetIdx := make(map[assignment.RawTarget]int, len(input.Targets))
+	for i, tgt := range input.Targets {
+		rt := assignment.NewRawTarget(tgt)
+		if oldIdx, ok := targetIdx[rt]; ok {
+			return validation.NewFieldError(fmt.Sprintf("Targets[%d]", i), fmt.Sprintf("Duplicates existing target at index %d.", oldIdx))
+		}
+		targetIdx[rt] = i
+		wantedTargets[rt] = currentTargets[rt]
+		fields[rt] = fmt.Sprintf("Targets[%d]", i)
+	}
+
+	condTargets := []struct {
+		field string
+		tgts  []assignment.RawTarget
+		cond  escalation.TargetCondition
+	}{
+		{field: "InHoursTargets", tgts: input.InHoursTargets, cond: escalation.TargetConditionInHours},
+		{field: "AfterHoursTargets", tgts: input.AfterHoursTargets, cond: escalation.TargetConditionAfterHours},
+	}
+
+	// provided lists replace the conditional targets of the step
+	for _, c := range condTargets {
+		if c.tgts == nil {
+			continue
+		}
+		for tgt, cond := range wantedTargets {
+			if cond == c.cond {
+				wantedTargets[tgt] = escalation.TargetConditionAlways
+			}
+		}
+	}
+	listed := make(map[assignment.RawTarget]string)
+	for _, c := range condTargets {
+		for i, tgt := range c.tgts {
+			rt := assignment.NewRawTarget(tgt)
+			field := fmt.Sprintf("%s[%d]", c.field, i)
+			if oldField, ok := listed[rt]; ok {
+				return validation.NewFieldError(field, fmt.Sprintf("Duplicates existing target at %s.", oldField))
+			}
+			listed[rt] = field
+			wantedTargets[rt] = c.cond
+			if _, ok := fields[rt]; !ok {
+				fields[rt] = field
+			}
+		}
+	}
+
+	// add targets in wanted that are not in curr
+	for tgt := range wantedTargets {
+		if _, ok := currentTargets[tgt]; ok {
+			continue
+		}
+
+		err = m.PolicyStore.AddStepTargetTx(ctx, tx, stepID, tgt)
+		if err != nil {
+			return validation.AddPrefix(fields[tgt]+".", err)
+		}
+	}
+
+	// remove targets in curr that are not in wanted
+	for tgt := range currentTargets {
+		if _, ok := wantedTargets[tgt]; ok {
+			continue
+		}
+
+		err = m.PolicyStore.DeleteStepTargetTx(ctx, tx, stepID, tgt)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update conditions that changed, new targets start as always notified
+	for tgt, cond := range wantedTargets {
+		if currentTargets[tgt] == cond {
+			continue
+		}
+
+		err = m.PolicyStore.SetStepTargetConditionTx(ctx, tx, stepID, tgt, cond)
+		if err != nil {
+			return validation.AddPrefix(fields[tgt]+".", err)
+		}
+	}
+
+	return nil
+}
+
 func (step *EscalationPolicyStep) Targets(ctx context.Context, raw *escalation.Step) ([]assignment.RawTarget, error) {
 	// TODO: use dataloader
 	var targets []assignment.Target
@@ -322,6 +667,45 @@ func (step *EscalationPolicyStep) Targets(ctx context.Context, raw *escalation.S
 		}
 	}
 
+	return rawTargets(targets), nil
+}
+
+
+func (step *EscalationPolicyStep) InHoursTargets(ctx context.Context, raw *escalation.Step) ([]assignment.RawTarget, error) {
+	targets, err := step.PolicyStore.FindStepTargetsByConditionTx(ctx, nil, raw.ID, escalation.TargetConditionInHours)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawTargets(targets), nil
+}
+
+func (step *EscalationPolicyStep) AfterHoursTargets(ctx context.Context, raw *escalation.Step) ([]assignment.RawTarget, error) {
+	targets, err := step.PolicyStore.FindStepTargetsByConditionTx(ctx, nil, raw.ID, escalation.TargetConditionAfterHours)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawTargets(targets), nil
+}
+
+func (step *EscalationPolicyStep) DelayBusinessHours(ctx context.Context, raw *escalation.Step) (*businesshours.BusinessHours, error) {
+	if raw.DelayBusinessHoursID == "" {
+		return nil, nil
+	}
+
+	return step.BusinessHoursStore.FindOne(ctx, raw.DelayBusinessHoursID)
+}
+
+func (step *EscalationPolicyStep) RoutingBusinessHours(ctx context.Context, raw *escalation.Step) (*businesshours.BusinessHours, error) {
+	if raw.RoutingBusinessHoursID == "" {
+		return nil, nil
+	}
+
+	return step.BusinessHoursStore.FindOne(ctx, raw.RoutingBusinessHoursID)
+}
+
+func rawTargets(targets []assignment.Target) []assignment.RawTarget {
 	result := make([]assignment.RawTarget, len(targets))
 	for i, tgt := range targets {
 		switch t := tgt.(type) {
@@ -334,7 +718,7 @@ func (step *EscalationPolicyStep) Targets(ctx context.Context, raw *escalation.S
 		}
 	}
 
-	return result, nil
+	return result
 }
 func (step *EscalationPolicyStep) EscalationPolicy(ctx context.Context, raw *escalation.Step) (*escalation.Policy, error) {
 	return (*App)(step).FindOnePolicy(ctx, raw.PolicyID)
@@ -428,3 +812,38 @@ func (q *Query) EscalationPolicies(ctx context.Context, opts *graphql2.Escalatio
 	conn.Nodes = pols
 	return conn, err
 }
+
+// auditPolicy is the audit log snapshot of an escalation policy, including the order of its steps.
+type auditPolicy struct {
+	*escalation.Policy
+	StepIDs []string `json:"step_ids"`
+}
+
+func (m *Mutation) auditPolicyTx(ctx context.Context, tx *sql.Tx, ep *escalation.Policy) (*auditPolicy, error) {
+	steps, err := m.PolicyStore.FindAllStepsTx(ctx, tx, ep.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &auditPolicy{Policy: ep, StepIDs: []string{}}
+	for _, step := range steps {
+		res.StepIDs = append(res.StepIDs, step.ID)
+	}
+
+	return res, nil
+}
+
+// auditStepTx will return the current state of a step, including its targets, for the audit log.
+func (m *Mutation) auditStepTx(ctx context.Context, tx *sql.Tx, id string) (*escalation.Step, error) {
+	step, err := m.PolicyStore.FindOneStepForUpdateTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	step.Targets, err = m.PolicyStore.FindAllStepTargetsTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
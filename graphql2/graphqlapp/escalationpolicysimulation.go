@@ -0,0 +1,239 @@
+package graphqlapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/businesshours"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule/rotation"
+)
+
+// epSimulation caches lookups made while simulating escalation of a single policy.
+type epSimulation struct {
+	app  *App
+	rots map[string]*oncall.ResolvedRotation
+	bhs  map[string]*businesshours.BusinessHours
+}
+
+// EscalationPolicySimulation calculates who would be notified, and when, for an alert
+// created at the trigger time. Nothing is persisted and no notifications are sent.
+//
+// Rotations are expanded to the active participant at each escalation time, so steps
+// using the round-robin strategy are approximated by the active participant as well.
+// Conditional targets are included based on the routing business hours of each step at
+// the time it is entered. Escalation begins at the step selected by the minimum severity
+// of each step, as it would for a new alert of the simulated severity.
+func (q *Query) EscalationPolicySimulation(ctx context.Context, input graphql2.EscalationPolicySimulationInput) ([]graphql2.EscalationPolicySimulationStep, error) {
+	at := time.Now()
+	if input.TriggerTime != nil {
+		at = *input.TriggerTime
+	}
+	sev := alert.DefaultSeverity
+	if input.Severity != nil {
+		sev = *input.Severity
+	}
+
+	pol, err := (*App)(q).FindOnePolicy(ctx, input.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := q.PolicyStore.FindAllSteps(ctx, pol.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &epSimulation{
+		app:  (*App)(q),
+		rots: make(map[string]*oncall.ResolvedRotation),
+		bhs:  make(map[string]*businesshours.BusinessHours),
+	}
+
+	result := []graphql2.EscalationPolicySimulationStep{}
+	start := escalation.StartStepIndex(steps, sev)
+	for repeat := 0; repeat <= pol.Repeat; repeat++ {
+		for i, step := range steps {
+			if repeat == 0 && i < start {
+				// skipped, below the minimum severity of the step
+				continue
+			}
+			if pol.MaxNotifications > 0 && len(result) >= pol.MaxNotifications {
+				return result, nil
+			}
+
+			tgts := []graphql2.EscalationPolicySimulationTarget{}
+			if sev.AtLeast(step.MinSeverity) {
+				tgts, err = sim.stepTargets(ctx, step, at)
+				if err != nil {
+					return nil, err
+				}
+			}
+			result = append(result, graphql2.EscalationPolicySimulationStep{
+				StepNumber: step.StepNumber,
+				Repeat:     repeat,
+				EscalateAt: at,
+				Targets:    tgts,
+			})
+
+			delay, err := sim.stepDelay(ctx, step, at)
+			if err != nil {
+				return nil, err
+			}
+			at = at.Add(delay)
+		}
+	}
+
+	return result, nil
+}
+
+// stepDelay returns the delay of the step when entered at t, based on its delay business hours.
+func (sim *epSimulation) stepDelay(ctx context.Context, step escalation.Step, t time.Time) (time.Duration, error) {
+	if step.OffHoursDelayMinutes == 0 {
+		return step.Delay(), nil
+	}
+
+	bh, err := sim.businessHours(ctx, step.DelayBusinessHoursID)
+	if err != nil {
+		return 0, err
+	}
+
+	return step.DelayAt(bh, t), nil
+}
+
+// businessHours returns the business hours for id, or nil if id is empty.
+func (sim *epSimulation) businessHours(ctx context.Context, id string) (*businesshours.BusinessHours, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	bh, ok := sim.bhs[id]
+	if !ok {
+		var err error
+		bh, err = sim.app.BusinessHoursStore.FindOne(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sim.bhs[id] = bh
+	}
+
+	return bh, nil
+}
+
+func (sim *epSimulation) stepTargets(ctx context.Context, step escalation.Step, t time.Time) ([]graphql2.EscalationPolicySimulationTarget, error) {
+	stepTgts, err := sim.app.PolicyStore.FindAllStepTargetsTx(ctx, nil, step.ID)
+	if err != nil {
+		return nil, err
+	}
+	skip, err := sim.inactiveTargets(ctx, step, t)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []graphql2.EscalationPolicySimulationTarget{}
+	add := func(via, tgt assignment.Target) {
+		v := assignment.NewRawTarget(via)
+		tg := assignment.NewRawTarget(tgt)
+		result = append(result, graphql2.EscalationPolicySimulationTarget{Target: &tg, Via: &v})
+	}
+
+	for _, via := range stepTgts {
+		if skip[assignment.NewRawTarget(via)] {
+			continue
+		}
+		switch via.TargetType() {
+		case assignment.TargetTypeRotation:
+			userID, err := sim.rotationUser(ctx, via.TargetID(), t)
+			if err != nil {
+				return nil, err
+			}
+			if userID != "" {
+				add(via, assignment.UserTarget(userID))
+			}
+		case assignment.TargetTypeSchedule:
+			shifts, err := sim.app.OnCallStore.HistoryBySchedule(ctx, via.TargetID(), t, t.Add(time.Minute))
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range shifts {
+				if s.Start.After(t) || (!s.End.IsZero() && !s.End.After(t)) {
+					continue
+				}
+				add(via, assignment.UserTarget(s.UserID))
+			}
+		default:
+			// users and channels are notified directly
+			add(via, via)
+		}
+	}
+
+	return result, nil
+}
+
+// inactiveTargets returns the conditional targets of the step that would not be notified at t,
+// based on the routing business hours of the step.
+func (sim *epSimulation) inactiveTargets(ctx context.Context, step escalation.Step, t time.Time) (map[assignment.RawTarget]bool, error) {
+	bh, err := sim.businessHours(ctx, step.RoutingBusinessHoursID)
+	if err != nil {
+		return nil, err
+	}
+	if bh == nil {
+		return nil, nil
+	}
+
+	cond := escalation.TargetConditionInHours
+	if bh.Contains(t) {
+		cond = escalation.TargetConditionAfterHours
+	}
+	tgts, err := sim.app.PolicyStore.FindStepTargetsByConditionTx(ctx, nil, step.ID, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[assignment.RawTarget]bool, len(tgts))
+	for _, tgt := range tgts {
+		result[assignment.NewRawTarget(tgt)] = true
+	}
+
+	return result, nil
+}
+
+// rotationUser returns the ID of the user active in the rotation at t, or an empty
+// string if the rotation has no participants.
+func (sim *epSimulation) rotationUser(ctx context.Context, rotID string, t time.Time) (string, error) {
+	if rot, ok := sim.rots[rotID]; ok {
+		return rot.UserID(t), nil
+	}
+
+	r, err := sim.app.RotationStore.FindRotation(ctx, rotID)
+	if err != nil {
+		return "", err
+	}
+	rot := &oncall.ResolvedRotation{Rotation: *r}
+	sim.rots[rotID] = rot
+
+	state, err := sim.app.RotationStore.State(ctx, rotID)
+	if errors.Is(err, rotation.ErrNoState) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	rot.CurrentIndex = state.Position
+	rot.CurrentStart = state.ShiftStart
+
+	parts, err := sim.app.RotationStore.FindAllParticipants(ctx, rotID)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range parts {
+		rot.Users = append(rot.Users, p.Target.TargetID())
+		rot.Weights = append(rot.Weights, p.Weight)
+	}
+
+	return rot.UserID(t), nil
+}
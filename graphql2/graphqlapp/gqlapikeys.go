@@ -2,13 +2,19 @@ package graphqlapp
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/expflag"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
 )
 
 type GQLAPIKey App
@@ -31,6 +37,33 @@ func (a *GQLAPIKey) UpdatedBy(ctx context.Context, obj *graphql2.GQLAPIKey) (*us
 	return (*App)(a).FindOneUser(ctx, obj.UpdatedBy.ID)
 }
 
+func (a *GQLAPIKey) UsageHistory(ctx context.Context, obj *graphql2.GQLAPIKey, limit *int) ([]graphql2.GQLAPIKeyUsage, error) {
+	id, err := parseUUID("ID", obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	n := 25
+	if limit != nil {
+		n = *limit
+	}
+
+	usage, err := a.APIKeyStore.APIKeyUsageLog(ctx, id, n)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.GQLAPIKeyUsage, len(usage))
+	for i, u := range usage {
+		res[i] = graphql2.GQLAPIKeyUsage{
+			Time: u.Time,
+			Ua:   u.UserAgent,
+			IP:   u.IP,
+		}
+	}
+
+	return res, nil
+}
+
 func (q *Query) GqlAPIKeys(ctx context.Context) ([]graphql2.GQLAPIKey, error) {
 	if !expflag.ContextHas(ctx, expflag.GQLAPIKey) {
 		return nil, validation.NewGenericError("experimental flag not enabled")
@@ -54,7 +87,23 @@ func (q *Query) GqlAPIKeys(ctx context.Context) ([]graphql2.GQLAPIKey, error) {
 			CreatedAt:     k.CreatedAt,
 			UpdatedAt:     k.UpdatedAt,
 			ExpiresAt:     k.ExpiresAt,
+			ExpiringSoon:  k.ExpiringSoon,
+			ReadOnly:      k.ReadOnly,
+			RateLimit:     k.RateLimit,
+			MaxComplexity: k.MaxComplexity,
+			StaleFields:   k.StaleFields,
 			AllowedFields: k.AllowedFields,
+			ServiceIDs:    make([]string, len(k.ServiceIDs)),
+			AllowedCIDRs:  k.AllowedCIDRs,
+		}
+		if res[i].AllowedCIDRs == nil {
+			res[i].AllowedCIDRs = []string{}
+		}
+		if res[i].StaleFields == nil {
+			res[i].StaleFields = []string{}
+		}
+		for j, id := range k.ServiceIDs {
+			res[i].ServiceIDs[j] = id.String()
 		}
 
 		if k.CreatedBy != nil {
@@ -85,7 +134,28 @@ func (a *Mutation) UpdateGQLAPIKey(ctx context.Context, input graphql2.UpdateGQL
 		return false, err
 	}
 
-	err = a.APIKeyStore.UpdateAdminGraphQLKey(ctx, id, input.Name, input.Description)
+	err = (*App)(a).withAuditTx(ctx, "updateGQLAPIKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeGQLAPIKey, id.String())
+		key, err := a.APIKeyStore.FindOneForUpdateTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(key)
+		if err != nil {
+			return err
+		}
+
+		err = a.APIKeyStore.UpdateAdminGraphQLKey(ctx, tx, id, input.Name, input.Description)
+		if err != nil {
+			return err
+		}
+
+		key, err = a.APIKeyStore.FindOneForUpdateTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(key)
+	})
 	return err == nil, err
 }
 
@@ -95,21 +165,151 @@ func (a *Mutation) DeleteGQLAPIKey(ctx context.Context, input string) (bool, err
 		return false, err
 	}
 
-	err = a.APIKeyStore.DeleteAdminGraphQLKey(ctx, id)
+	err = (*App)(a).withAuditTx(ctx, "deleteGQLAPIKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeGQLAPIKey, id.String())
+		key, err := a.APIKeyStore.FindOneForUpdateTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(key)
+		if err != nil {
+			return err
+		}
+
+		return a.APIKeyStore.DeleteAdminGraphQLKey(ctx, tx, id)
+	})
 	return err == nil, err
 }
 
+func (a *Mutation) DeleteGQLAPIKeysByCreator(ctx context.Context, userID string) (int, error) {
+	if !expflag.ContextHas(ctx, expflag.GQLAPIKey) {
+		return 0, validation.NewGenericError("experimental flag not enabled")
+	}
+	id, err := parseUUID("UserID", userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	err = (*App)(a).withAuditTx(ctx, "deleteGQLAPIKeysByCreator", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		ids, err := a.APIKeyStore.DeleteGraphQLKeysByCreator(ctx, tx, id)
+		if err != nil {
+			// nothing was deleted, but the attempt is still recorded
+			audit.Add(auditlog.EntityTypeUser, id.String())
+			return err
+		}
+
+		for _, keyID := range ids {
+			err = audit.Add(auditlog.EntityTypeGQLAPIKey, keyID.String()).SetBefore(struct{ CreatedBy string }{CreatedBy: id.String()})
+			if err != nil {
+				return err
+			}
+		}
+		n = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (a *Mutation) RotateGQLAPIKey(ctx context.Context, input string) (*graphql2.CreatedGQLAPIKey, error) {
+	if !expflag.ContextHas(ctx, expflag.GQLAPIKey) {
+		return nil, validation.NewGenericError("experimental flag not enabled")
+	}
+	id, err := parseUUID("ID", input)
+	if err != nil {
+		return nil, err
+	}
+
+	var tok string
+	err = (*App)(a).withAuditTx(ctx, "rotateGQLAPIKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		// the token itself is a credential, so only the rotation is recorded
+		audit.Add(auditlog.EntityTypeGQLAPIKey, id.String())
+		tok, err = a.APIKeyStore.RotateAdminGraphQLKey(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphql2.CreatedGQLAPIKey{
+		ID:    id.String(),
+		Token: tok,
+	}, nil
+}
+
+func (a *Mutation) PromoteGQLAPIKeySigningKey(ctx context.Context, gracePeriodDays int) (bool, error) {
+	if !expflag.ContextHas(ctx, expflag.GQLAPIKey) {
+		return false, validation.NewGenericError("experimental flag not enabled")
+	}
+	err := validate.Range("GracePeriodDays", gracePeriodDays, 1, 365)
+	if err != nil {
+		return false, err
+	}
+
+	err = (*App)(a).withAuditTx(ctx, "promoteGQLAPIKeySigningKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		// The signing key applies to every key, so there is no entity ID. The keyring commits the
+		// promotion separately, so it can't be rolled back with tx.
+		err := audit.Add(auditlog.EntityTypeGQLAPIKey, "").SetAfter(struct{ GracePeriodDays int }{GracePeriodDays: gracePeriodDays})
+		if err != nil {
+			return err
+		}
+
+		return a.APIKeyStore.PromoteSigningKey(ctx, time.Duration(gracePeriodDays)*24*time.Hour)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (a *Mutation) CreateGQLAPIKey(ctx context.Context, input graphql2.CreateGQLAPIKeyInput) (*graphql2.CreatedGQLAPIKey, error) {
 	if !expflag.ContextHas(ctx, expflag.GQLAPIKey) {
 		return nil, validation.NewGenericError("experimental flag not enabled")
 	}
 
-	id, tok, err := a.APIKeyStore.CreateAdminGraphQLKey(ctx, apikey.NewAdminGQLKeyOpts{
-		Name:    input.Name,
-		Desc:    input.Description,
-		Expires: input.ExpiresAt,
-		Fields:  input.AllowedFields,
-		Role:    permission.Role(input.Role),
+	svcIDs := make([]uuid.UUID, len(input.ServiceIDs))
+	for i, idStr := range input.ServiceIDs {
+		var err error
+		svcIDs[i], err = parseUUID(fmt.Sprintf("ServiceIDs[%d]", i), idStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := apikey.NewAdminGQLKeyOpts{
+		Name:         input.Name,
+		Desc:         input.Description,
+		Expires:      input.ExpiresAt,
+		Fields:       input.AllowedFields,
+		Role:         permission.Role(input.Role),
+		ServiceIDs:   svcIDs,
+		AllowedCIDRs: input.AllowedCIDRs,
+		ReadOnly:     input.ReadOnly != nil && *input.ReadOnly,
+	}
+	if input.RateLimit != nil {
+		opts.RateLimit = *input.RateLimit
+	}
+	if input.MaxComplexity != nil {
+		opts.MaxComplexity = *input.MaxComplexity
+	}
+
+	var id uuid.UUID
+	var tok string
+	err := (*App)(a).withAuditTx(ctx, "createGQLAPIKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeGQLAPIKey, "")
+		var err error
+		id, tok, err = a.APIKeyStore.CreateAdminGraphQLKey(ctx, tx, opts)
+		if err != nil {
+			return err
+		}
+
+		e.EntityID = id.String()
+		return e.SetAfter(opts)
 	})
 	if err != nil {
 		return nil, err
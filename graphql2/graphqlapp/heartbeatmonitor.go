@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
@@ -18,6 +19,9 @@ func (a *App) HeartbeatMonitor() graphql2.HeartbeatMonitorResolver { return (*He
 func (a *HeartbeatMonitor) TimeoutMinutes(ctx context.Context, hb *heartbeat.Monitor) (int, error) {
 	return int(hb.Timeout / time.Minute), nil
 }
+func (a *HeartbeatMonitor) WarningMinutes(ctx context.Context, hb *heartbeat.Monitor) (int, error) {
+	return int(hb.WarningTimeout / time.Minute), nil
+}
 func (a *HeartbeatMonitor) Href(ctx context.Context, hb *heartbeat.Monitor) (string, error) {
 	cfg := config.FromContext(ctx)
 	return cfg.CallbackURL("/api/v2/heartbeat/" + url.PathEscape(hb.ID)), nil
@@ -33,11 +37,19 @@ func (m *Mutation) CreateHeartbeatMonitor(ctx context.Context, input graphql2.Cr
 		serviceID = *input.ServiceID
 	}
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err = (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(serviceID))
+		if err != nil {
+			return err
+		}
+
 		hb = &heartbeat.Monitor{
 			ServiceID: serviceID,
 			Name:      input.Name,
 			Timeout:   time.Duration(input.TimeoutMinutes) * time.Minute,
 		}
+		if input.WarningMinutes != nil {
+			hb.WarningTimeout = time.Duration(*input.WarningMinutes) * time.Minute
+		}
 		hb, err = m.HeartbeatStore.CreateTx(ctx, tx, hb)
 		return err
 	})
@@ -50,12 +62,19 @@ func (m *Mutation) UpdateHeartbeatMonitor(ctx context.Context, input graphql2.Up
 		if err != nil {
 			return err
 		}
+		err = (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(hb.ServiceID))
+		if err != nil {
+			return err
+		}
 		if input.Name != nil {
 			hb.Name = *input.Name
 		}
 		if input.TimeoutMinutes != nil {
 			hb.Timeout = time.Duration(*input.TimeoutMinutes) * time.Minute
 		}
+		if input.WarningMinutes != nil {
+			hb.WarningTimeout = time.Duration(*input.WarningMinutes) * time.Minute
+		}
 
 		return m.HeartbeatStore.UpdateTx(ctx, tx, hb)
 	})
@@ -0,0 +1,148 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"sort"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auditlog"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/incident"
+)
+
+type Incident App
+
+func (a *App) Incident() graphql2.IncidentResolver { return (*Incident)(a) }
+
+func (q *Query) Incident(ctx context.Context, id string) (*incident.Incident, error) {
+	return q.IncidentStore.FindOne(ctx, id)
+}
+
+func (q *Query) IncidentCorrelationRules(ctx context.Context) ([]incident.CorrelationRule, error) {
+	return q.IncidentStore.FindAllRules(ctx)
+}
+
+func (a *Alert) Incident(ctx context.Context, raw *alert.Alert) (*incident.Incident, error) {
+	return a.IncidentStore.FindOneByAlert(ctx, raw.ID)
+}
+
+func (i *Incident) CorrelationRule(ctx context.Context, raw *incident.Incident) (*incident.CorrelationRule, error) {
+	if raw.CorrelationRuleID == "" {
+		return nil, nil
+	}
+
+	return i.IncidentStore.FindOneRule(ctx, raw.CorrelationRuleID)
+}
+
+func (i *Incident) Alerts(ctx context.Context, raw *incident.Incident) ([]alert.Alert, error) {
+	ids, err := i.IncidentStore.AlertIDs(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := i.AlertStore.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].ID > alerts[j].ID })
+
+	return alerts, nil
+}
+
+func (m *Mutation) CreateIncident(ctx context.Context, input graphql2.CreateIncidentInput) (inc *incident.Incident, err error) {
+	inc = &incident.Incident{
+		Summary: input.Summary,
+	}
+	if input.GroupedAck != nil {
+		inc.GroupedAck = *input.GroupedAck
+	}
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		inc, err = m.IncidentStore.CreateTx(ctx, tx, inc, input.AlertIDs)
+		return err
+	})
+	return inc, err
+}
+
+func (m *Mutation) UpdateIncident(ctx context.Context, input graphql2.UpdateIncidentInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.Summary != nil || input.GroupedAck != nil {
+			inc, err := m.IncidentStore.FindOne(ctx, input.ID)
+			if err != nil {
+				return err
+			}
+			if input.Summary != nil {
+				inc.Summary = *input.Summary
+			}
+			if input.GroupedAck != nil {
+				inc.GroupedAck = *input.GroupedAck
+			}
+			err = m.IncidentStore.UpdateTx(ctx, tx, inc)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(input.AddAlertIDs) > 0 {
+			err := m.IncidentStore.AddAlertsTx(ctx, tx, input.ID, input.AddAlertIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(input.RemoveAlertIDs) > 0 {
+			err := m.IncidentStore.RemoveAlertsTx(ctx, tx, input.ID, input.RemoveAlertIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Mutation) CreateIncidentCorrelationRule(ctx context.Context, input graphql2.CreateIncidentCorrelationRuleInput) (r *incident.CorrelationRule, err error) {
+	r = &incident.CorrelationRule{
+		Name:          input.Name,
+		Key:           input.Key,
+		WindowMinutes: input.WindowMinutes,
+	}
+	if input.GroupedAck != nil {
+		r.GroupedAck = *input.GroupedAck
+	}
+	err = (*App)(m).withAuditTx(ctx, "createIncidentCorrelationRule", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeCorrelationRule, "")
+		r, err = m.IncidentStore.CreateRuleTx(ctx, tx, r)
+		if err != nil {
+			return err
+		}
+
+		e.EntityID = r.ID
+		return e.SetAfter(r)
+	})
+	return r, err
+}
+
+func (m *Mutation) DeleteIncidentCorrelationRule(ctx context.Context, id string) (bool, error) {
+	err := (*App)(m).withAuditTx(ctx, "deleteIncidentCorrelationRule", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeCorrelationRule, id)
+		r, err := m.IncidentStore.FindOneRule(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(r)
+		if err != nil {
+			return err
+		}
+
+		return m.IncidentStore.DeleteRuleTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"net/url"
 
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/label"
 	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation"
 )
 
 type IntegrationKey App
@@ -23,17 +27,184 @@ func (m *Mutation) CreateIntegrationKey(ctx context.Context, input graphql2.Crea
 	if input.ServiceID != nil {
 		serviceID = *input.ServiceID
 	}
-	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err = (*App)(m).withAuditTx(ctx, "createIntegrationKey", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeIntegrationKey, "")
+		err = (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(serviceID))
+		if err != nil {
+			return err
+		}
+
 		key = &integrationkey.IntegrationKey{
 			ServiceID: serviceID,
 			Name:      input.Name,
 			Type:      integrationkey.Type(input.Type),
 		}
+		if input.Routing != nil {
+			key.Routing = &integrationkey.Routing{
+				LabelKey: input.Routing.LabelKey,
+				Field:    input.Routing.Field,
+			}
+		}
+		if input.AlertRateLimit != nil {
+			if *input.AlertRateLimit <= 0 {
+				return validation.NewFieldError("AlertRateLimit", "must be positive or null")
+			}
+			key.AlertRateLimit = *input.AlertRateLimit
+		}
+		if input.DedupWindowMinutes != nil {
+			if *input.DedupWindowMinutes <= 0 {
+				return validation.NewFieldError("DedupWindowMinutes", "must be positive or null")
+			}
+			key.DedupWindowMinutes = *input.DedupWindowMinutes
+		}
+		key.FieldMapping = fieldMappingFromInput(input.FieldMapping)
+		key.DefaultLabels, err = defaultLabelsFromInput(input.DefaultLabels)
+		if err != nil {
+			return err
+		}
 		key, err = m.IntKeyStore.Create(ctx, tx, key)
-		return err
+		if err != nil {
+			return err
+		}
+
+		e.EntityID = key.ID
+		return e.SetAfter(key)
 	})
 	return key, err
 }
+
+func (m *Mutation) SetIntegrationKeyAlertRateLimit(ctx context.Context, input graphql2.SetIntegrationKeyAlertRateLimitInput) (bool, error) {
+	var limit int
+	if input.AlertRateLimit != nil {
+		if *input.AlertRateLimit <= 0 {
+			return false, validation.NewFieldError("AlertRateLimit", "must be positive or null")
+		}
+		limit = *input.AlertRateLimit
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.IntegrationKeyTarget(input.ID))
+		if err != nil {
+			return err
+		}
+
+		return m.IntKeyStore.SetAlertRateLimit(ctx, tx, input.ID, limit)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) SetIntegrationKeyDedupWindow(ctx context.Context, input graphql2.SetIntegrationKeyDedupWindowInput) (bool, error) {
+	var minutes int
+	if input.DedupWindowMinutes != nil {
+		if *input.DedupWindowMinutes <= 0 {
+			return false, validation.NewFieldError("DedupWindowMinutes", "must be positive or null")
+		}
+		minutes = *input.DedupWindowMinutes
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.IntegrationKeyTarget(input.ID))
+		if err != nil {
+			return err
+		}
+
+		return m.IntKeyStore.SetDedupWindow(ctx, tx, input.ID, minutes)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) SetIntegrationKeyFieldMapping(ctx context.Context, input graphql2.SetIntegrationKeyFieldMappingInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.IntegrationKeyTarget(input.ID))
+		if err != nil {
+			return err
+		}
+
+		return m.IntKeyStore.SetFieldMapping(ctx, tx, input.ID, fieldMappingFromInput(input.FieldMapping))
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) SetIntegrationKeyDefaultLabels(ctx context.Context, input graphql2.SetIntegrationKeyDefaultLabelsInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.IntegrationKeyTarget(input.ID))
+		if err != nil {
+			return err
+		}
+
+		labels, err := defaultLabelsFromInput(input.DefaultLabels)
+		if err != nil {
+			return err
+		}
+
+		return m.IntKeyStore.SetDefaultLabels(ctx, tx, input.ID, labels)
+	})
+	return err == nil, err
+}
+
+func defaultLabelsFromInput(input []graphql2.IntegrationKeyLabelInput) (integrationkey.DefaultLabels, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	labels := make(integrationkey.DefaultLabels, len(input))
+	for _, l := range input {
+		if _, ok := labels[l.Key]; ok {
+			return nil, validation.NewFieldError("DefaultLabels", "duplicate key: "+l.Key)
+		}
+		labels[l.Key] = l.Value
+	}
+	return labels, nil
+}
+
+func fieldMappingFromInput(input *graphql2.IntegrationKeyFieldMappingInput) *integrationkey.FieldMapping {
+	if input == nil {
+		return nil
+	}
+
+	var m integrationkey.FieldMapping
+	if input.Summary != nil {
+		m.Summary = *input.Summary
+	}
+	if input.Details != nil {
+		m.Details = *input.Details
+	}
+	if input.Dedup != nil {
+		m.Dedup = *input.Dedup
+	}
+	if input.Severity != nil {
+		m.Severity = *input.Severity
+	}
+	if input.CorrelationKey != nil {
+		m.CorrelationKey = *input.CorrelationKey
+	}
+	return &m
+}
+
+func (key *IntegrationKey) AlertRateLimit(ctx context.Context, raw *integrationkey.IntegrationKey) (*int, error) {
+	if raw.AlertRateLimit == 0 {
+		return nil, nil
+	}
+
+	return &raw.AlertRateLimit, nil
+}
+
+func (key *IntegrationKey) DedupWindowMinutes(ctx context.Context, raw *integrationkey.IntegrationKey) (*int, error) {
+	if raw.DedupWindowMinutes == 0 {
+		return nil, nil
+	}
+
+	return &raw.DedupWindowMinutes, nil
+}
+func (key *IntegrationKey) DefaultLabels(ctx context.Context, raw *integrationkey.IntegrationKey) ([]label.Label, error) {
+	result := make([]label.Label, 0, len(raw.DefaultLabels))
+	for _, k := range raw.DefaultLabels.Keys() {
+		result = append(result, label.Label{Key: k, Value: raw.DefaultLabels[k]})
+	}
+
+	return result, nil
+}
+
 func (key *IntegrationKey) Type(ctx context.Context, raw *integrationkey.IntegrationKey) (graphql2.IntegrationKeyType, error) {
 	return graphql2.IntegrationKeyType(raw.Type), nil
 }
@@ -50,6 +221,8 @@ func (key *IntegrationKey) Href(ctx context.Context, raw *integrationkey.Integra
 		return cfg.CallbackURL("/api/v2/site24x7/incoming", q), nil
 	case integrationkey.TypePrometheusAlertmanager:
 		return cfg.CallbackURL("/api/v2/prometheusalertmanager/incoming", q), nil
+	case integrationkey.TypeOpsGenie:
+		return cfg.CallbackURL("/api/v2/opsgenie/incoming", q), nil
 	case integrationkey.TypeEmail:
 		if !cfg.EmailIngressEnabled() {
 			return "", nil
@@ -4,6 +4,7 @@ import (
 	context "context"
 	"database/sql"
 
+	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/label"
@@ -133,29 +134,24 @@ func (q *Query) Labels(ctx context.Context, input *graphql2.LabelSearchOptions)
 }
 func (m *Mutation) SetLabel(ctx context.Context, input graphql2.SetLabelInput) (bool, error) {
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
-		cfg := config.FromContext(ctx)
-		if cfg.General.DisableLabelCreation {
-			allLabels, err := m.LabelStore.UniqueKeysTx(ctx, tx)
-			if err != nil {
-				return err
-			}
-			var keyExists bool
-			for _, l := range allLabels {
-				if input.Key == l {
-					keyExists = true
-					break
-				}
-			}
-			if !keyExists {
-				return validation.NewFieldError("Key", "Creating new labels is currently disabled.")
-			}
+		if input.Target == nil || input.Target.TargetType() != assignment.TargetTypeService {
+			return m.setLabel(ctx, tx, input)
+		}
+
+		// When service management is restricted, the service must be in scope both
+		// before and after the change, so labels can't be used to claim or give away services.
+		tgt := assignment.ServiceTarget(input.Target.TargetID())
+		err := (*App)(m).requireManage(ctx, tx, tgt)
+		if err != nil {
+			return err
 		}
 
-		return m.LabelStore.SetTx(ctx, tx, &label.Label{
-			Key:    input.Key,
-			Value:  input.Value,
-			Target: input.Target,
-		})
+		err = m.setLabel(ctx, tx, input)
+		if err != nil {
+			return err
+		}
+
+		return (*App)(m).requireManage(ctx, tx, tgt)
 	})
 	if err != nil {
 		return false, err
@@ -163,3 +159,29 @@ func (m *Mutation) SetLabel(ctx context.Context, input graphql2.SetLabelInput) (
 
 	return true, nil
 }
+
+func (m *Mutation) setLabel(ctx context.Context, tx *sql.Tx, input graphql2.SetLabelInput) error {
+	cfg := config.FromContext(ctx)
+	if cfg.General.DisableLabelCreation {
+		allLabels, err := m.LabelStore.UniqueKeysTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		var keyExists bool
+		for _, l := range allLabels {
+			if input.Key == l {
+				keyExists = true
+				break
+			}
+		}
+		if !keyExists {
+			return validation.NewFieldError("Key", "Creating new labels is currently disabled.")
+		}
+	}
+
+	return m.LabelStore.SetTx(ctx, tx, &label.Label{
+		Key:    input.Key,
+		Value:  input.Value,
+		Target: input.Target,
+	})
+}
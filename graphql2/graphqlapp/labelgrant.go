@@ -0,0 +1,81 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"strings"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/label"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+)
+
+func (a *User) LabelGrants(ctx context.Context, obj *user.User) ([]label.Selector, error) {
+	grants, err := a.LabelStore.FindAllUserGrants(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if grants == nil {
+		grants = []label.Selector{}
+	}
+
+	return grants, nil
+}
+
+func (m *Mutation) SetUserLabelGrants(ctx context.Context, input graphql2.SetUserLabelGrantsInput) (bool, error) {
+	err := (*App)(m).withAuditTx(ctx, "setUserLabelGrants", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeUser, input.UserID)
+		grants, err := m.LabelStore.FindAllUserGrants(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+		err = e.SetBefore(struct{ LabelGrants []label.Selector }{LabelGrants: grants})
+		if err != nil {
+			return err
+		}
+
+		err = m.LabelStore.SetUserGrantsTx(ctx, tx, input.UserID, input.Grants)
+		if err != nil {
+			return err
+		}
+
+		return e.SetAfter(struct{ LabelGrants []label.Selector }{LabelGrants: input.Grants})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// requireManage will return an access denied error if `General.RestrictServiceManagement` is enabled and
+// the current user is not an admin, and any service referenced by tgts is not matched by one of the user's
+// label grants.
+//
+// Targets may be services, escalation policies, integration keys, or heartbeat monitors.
+func (a *App) requireManage(ctx context.Context, tx *sql.Tx, tgts ...assignment.Target) error {
+	cfg := config.FromContext(ctx)
+	if !cfg.General.RestrictServiceManagement {
+		return nil
+	}
+	if permission.System(ctx) || permission.Admin(ctx) {
+		return nil
+	}
+	if permission.UserID(ctx) == "" {
+		return permission.NewAccessDenied("service management is restricted to users with label grants")
+	}
+
+	ids, err := a.LabelStore.FindUngrantedServicesTx(ctx, tx, permission.UserID(ctx), tgts)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		return permission.NewAccessDenied("no label grant for service(s): " + strings.Join(ids, ", "))
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/limit"
 )
@@ -16,20 +17,43 @@ func (q *Query) SystemLimits(ctx context.Context) ([]graphql2.SystemLimit, error
 	return graphql2.MapLimitValues(limits), nil
 }
 func (m *Mutation) SetSystemLimits(ctx context.Context, input []graphql2.SystemLimitInput) (bool, error) {
-	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err := (*App)(m).withAuditTx(ctx, "setSystemLimits", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
 		l := limit.Limits{}
 		l, err := graphql2.ApplyLimitValues(l, input)
 		if err != nil {
 			return err
 		}
 
+		current, err := m.LimitStore.AllTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+
 		for id, max := range l {
+			e := audit.Add(auditlog.EntityTypeSystemLimit, string(id))
+			if before, ok := current[id]; ok {
+				err = e.SetBefore(limitAuditValue{Value: before})
+				if err != nil {
+					return err
+				}
+			}
+
 			err = m.LimitStore.UpdateLimitsTx(ctx, tx, string(id), max)
 			if err != nil {
 				return err
 			}
+
+			err = e.SetAfter(limitAuditValue{Value: max})
+			if err != nil {
+				return err
+			}
 		}
 		return err
 	})
 	return err == nil, err
 }
+
+// limitAuditValue is the audit log snapshot of a single system limit.
+type limitAuditValue struct {
+	Value int `json:"value"`
+}
@@ -0,0 +1,54 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/maintenance"
+)
+
+func (m *Mutation) CreateMaintenanceWindow(ctx context.Context, input graphql2.CreateMaintenanceWindowInput) (w *maintenance.Window, err error) {
+	w = &maintenance.Window{
+		ServiceID: input.ServiceID,
+		Start:     input.Start,
+		End:       input.End,
+	}
+	if input.Description != nil {
+		w.Description = *input.Description
+	}
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(input.ServiceID))
+		if err != nil {
+			return err
+		}
+
+		w, err = m.MaintenanceStore.CreateWindowTx(ctx, tx, w)
+		return err
+	})
+	return w, err
+}
+
+func (m *Mutation) DeleteMaintenanceWindow(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		svcIDs, err := m.MaintenanceStore.ServiceIDsTx(ctx, tx, []string{id})
+		if err != nil {
+			return err
+		}
+		tgts := make([]assignment.Target, len(svcIDs))
+		for i, svcID := range svcIDs {
+			tgts[i] = assignment.ServiceTarget(svcID)
+		}
+		err = (*App)(m).requireManage(ctx, tx, tgts...)
+		if err != nil {
+			return err
+		}
+
+		return m.MaintenanceStore.DeleteManyWindowsTx(ctx, tx, []string{id})
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -2,11 +2,13 @@ package graphqlapp
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notificationchannel"
@@ -33,6 +35,10 @@ func (a *App) formatNC(ctx context.Context, id string) (string, error) {
 	switch n.Type {
 	case notificationchannel.TypeSlackChan:
 		typeName = "Slack"
+	case notificationchannel.TypeMSTeams:
+		typeName = "Teams"
+	case notificationchannel.TypePagerDuty:
+		typeName = "PagerDuty"
 	default:
 		typeName = string(n.Type)
 	}
@@ -40,6 +46,55 @@ func (a *App) formatNC(ctx context.Context, id string) (string, error) {
 	return fmt.Sprintf("%s (%s)", n.Name, typeName), nil
 }
 
+// messageDest returns the destination of a logged message.
+func (a *App) messageDest(ctx context.Context, log notification.MessageLog) (notification.Dest, error) {
+	switch {
+	case log.ContactMethodID != "":
+		cm, err := a.FindOneCM(ctx, log.ContactMethodID)
+		if err != nil {
+			return notification.Dest{}, fmt.Errorf("lookup contact method %s: %w", log.ContactMethodID, err)
+		}
+		return notification.DestFromPair(cm, nil), nil
+
+	case log.ChannelID != uuid.Nil:
+		nc, err := a.FindOneNC(ctx, log.ChannelID)
+		if err != nil {
+			return notification.Dest{}, fmt.Errorf("lookup notification channel %s: %w", log.ChannelID, err)
+		}
+		return notification.DestFromPair(nil, nc), nil
+	}
+
+	return notification.Dest{}, nil
+}
+
+// destChannel returns a short name for the kind of destination (e.g., SMS).
+func destChannel(t notification.DestType) string {
+	switch t {
+	case notification.DestTypeVoice:
+		return "Voice"
+	case notification.DestTypeSMS:
+		return "SMS"
+	case notification.DestTypeUserEmail:
+		return "Email"
+	case notification.DestTypeUserWebhook, notification.DestTypeChanWebhook:
+		return "Webhook"
+	case notification.DestTypeSlackDM:
+		return "Slack DM"
+	case notification.DestTypeSlackChannel:
+		return "Slack"
+	case notification.DestTypeSlackUG:
+		return "Slack User Group"
+	case notification.DestTypeMSTeams:
+		return "Teams"
+	case notification.DestTypePagerDuty:
+		return "PagerDuty"
+	case notification.DestTypeTelegram:
+		return "Telegram"
+	}
+
+	return "Unknown"
+}
+
 func (q *Query) formatDest(ctx context.Context, dst notification.Dest) (string, error) {
 	if !dst.Type.IsUserCM() {
 		return (*App)(q).formatNC(ctx, dst.ID)
@@ -92,6 +147,29 @@ func msgStatus(stat notification.Status) string {
 	return str.String()
 }
 
+type DebugMessageAttempt App
+
+func (a *App) DebugMessage() graphql2.DebugMessageResolver { return (*DebugMessage)(a) }
+func (a *App) DebugMessageAttempt() graphql2.DebugMessageAttemptResolver {
+	return (*DebugMessageAttempt)(a)
+}
+
+func (m *DebugMessage) Attempts(ctx context.Context, obj *graphql2.DebugMessage) ([]notification.MessageAttempt, error) {
+	return m.NotificationStore.FindMessageAttempts(ctx, obj.ID)
+}
+
+func (m *DebugMessageAttempt) Status(ctx context.Context, obj *notification.MessageAttempt) (string, error) {
+	if obj.State == notification.StateFailedPerm {
+		// attempts don't distinguish temporary failures, only the message itself does
+		if obj.Details == "" {
+			return "Failed", nil
+		}
+		return "Failed: " + obj.Details, nil
+	}
+
+	return msgStatus(notification.Status{State: obj.State, Details: obj.Details}), nil
+}
+
 type MessageLogConnectionStats App
 
 func (a *App) MessageLogConnectionStats() graphql2.MessageLogConnectionStatsResolver {
@@ -158,6 +236,9 @@ func (q *Query) MessageLogs(ctx context.Context, opts *graphql2.MessageLogSearch
 	if opts.CreatedBefore != nil {
 		searchOpts.CreatedBefore = *opts.CreatedBefore
 	}
+	if opts.DeadLettered != nil {
+		searchOpts.DeadLettered = *opts.DeadLettered
+	}
 	if searchOpts.Limit == 0 {
 		searchOpts.Limit = 50
 	}
@@ -192,72 +273,127 @@ func (q *Query) MessageLogs(ctx context.Context, opts *graphql2.MessageLogSearch
 		logs = logs[:searchOpts.Limit]
 	}
 
-	for _, _log := range logs {
-		log := _log
-		var dest notification.Dest
-		switch {
-		case log.ContactMethodID != "":
-			cm, err := (*App)(q).FindOneCM(ctx, log.ContactMethodID)
-			if err != nil {
-				return nil, fmt.Errorf("lookup contact method %s: %w", log.ContactMethodID, err)
-			}
-			dest = notification.DestFromPair(cm, nil)
-
-		case log.ChannelID != uuid.Nil:
-			nc, err := (*App)(q).FindOneNC(ctx, log.ChannelID)
-			if err != nil {
-				return nil, fmt.Errorf("lookup notification channel %s: %w", log.ChannelID, err)
-			}
-			dest = notification.DestFromPair(nil, nc)
+	for _, log := range logs {
+		dm, err := q.debugMessage(ctx, log)
+		if err != nil {
+			return nil, err
 		}
 
-		dm := graphql2.DebugMessage{
-			ID:         log.ID,
-			CreatedAt:  log.CreatedAt,
-			UpdatedAt:  log.LastStatusAt,
-			Type:       strings.TrimPrefix(log.MessageType.String(), "MessageType"),
-			Status:     msgStatus(notification.Status{State: log.LastStatus, Details: log.StatusDetails}),
-			AlertID:    &log.AlertID,
-			RetryCount: log.RetryCount,
-			SentAt:     log.SentAt,
+		conn.Nodes = append(conn.Nodes, *dm)
+	}
+	conn.Stats = &searchOpts
+
+	return conn, nil
+}
+
+// debugMessage converts a logged message to its GraphQL representation.
+func (q *Query) debugMessage(ctx context.Context, log notification.MessageLog) (*graphql2.DebugMessage, error) {
+	dest, err := (*App)(q).messageDest(ctx, log)
+	if err != nil {
+		return nil, err
+	}
+
+	dm := graphql2.DebugMessage{
+		ID:             log.ID,
+		CreatedAt:      log.CreatedAt,
+		UpdatedAt:      log.LastStatusAt,
+		Type:           strings.TrimPrefix(log.MessageType.String(), "MessageType"),
+		Status:         msgStatus(notification.Status{State: log.LastStatus, Details: log.StatusDetails}),
+		AlertID:        &log.AlertID,
+		RetryCount:     log.RetryCount,
+		SentAt:         log.SentAt,
+		DeadLetteredAt: log.DeadLetteredAt,
+	}
+	if dest.ID != "" {
+		dm.Destination, err = q.formatDest(ctx, dest)
+		if err != nil {
+			return nil, fmt.Errorf("format dest: %w", err)
 		}
-		if dest.ID != "" {
-			dm.Destination, err = q.formatDest(ctx, dest)
-			if err != nil {
-				return nil, fmt.Errorf("format dest: %w", err)
-			}
+	}
+	if log.UserID != "" {
+		dm.UserID = &log.UserID
+	}
+	if log.UserName != "" {
+		dm.UserName = &log.UserName
+	}
+	if log.SrcValue != "" {
+		src, err := q.formatDest(ctx, notification.Dest{Type: dest.Type, Value: log.SrcValue})
+		if err != nil {
+			return nil, fmt.Errorf("format src: %w", err)
 		}
-		if log.UserID != "" {
-			dm.UserID = &log.UserID
+		dm.Source = &src
+	}
+	if log.ServiceID != "" {
+		dm.ServiceID = &log.ServiceID
+	}
+	if log.ServiceName != "" {
+		dm.ServiceName = &log.ServiceName
+	}
+	if log.AlertID != 0 {
+		dm.AlertID = &log.AlertID
+	}
+	if log.ReplayOfID != "" {
+		dm.ReplayOfID = &log.ReplayOfID
+	}
+	if log.ProviderMsgID != nil {
+		dm.ProviderID = &log.ProviderMsgID.ExternalID
+	}
+
+	return &dm, nil
+}
+
+func (q *Query) StuckMessages(ctx context.Context, input *graphql2.StuckMessagesInput) ([]graphql2.StuckMessageGroup, error) {
+	if input == nil {
+		input = &graphql2.StuckMessagesInput{}
+	}
+	opts := notification.StuckMessageOptions{
+		Threshold:  10 * time.Minute,
+		ServiceIDs: input.ServiceIDs,
+	}
+	if input.ThresholdMinutes != nil {
+		err := validate.Range("ThresholdMinutes", *input.ThresholdMinutes, 1, 30*24*60)
+		if err != nil {
+			return nil, err
+		}
+		opts.Threshold = time.Duration(*input.ThresholdMinutes) * time.Minute
+	}
+	if input.CreatedAfter != nil {
+		opts.CreatedAfter = *input.CreatedAfter
+	}
+	if input.CreatedBefore != nil {
+		opts.CreatedBefore = *input.CreatedBefore
+	}
+
+	groups, err := q.NotificationStore.FindStuckMessages(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.StuckMessageGroup, 0, len(groups))
+	for _, g := range groups {
+		dest, err := (*App)(q).messageDest(ctx, g.Messages[0])
+		if err != nil {
+			return nil, err
 		}
-		if log.UserName != "" {
-			dm.UserName = &log.UserName
+
+		out := graphql2.StuckMessageGroup{
+			Channel:         destChannel(dest.Type),
+			Count:           len(g.Messages),
+			OldestCreatedAt: g.Messages[0].CreatedAt,
 		}
-		if log.SrcValue != "" {
-			src, err := q.formatDest(ctx, notification.Dest{Type: dest.Type, Value: log.SrcValue})
+		for _, log := range g.Messages {
+			dm, err := q.debugMessage(ctx, log)
 			if err != nil {
-				return nil, fmt.Errorf("format src: %w", err)
+				return nil, err
 			}
-			dm.Source = &src
-		}
-		if log.ServiceID != "" {
-			dm.ServiceID = &log.ServiceID
-		}
-		if log.ServiceName != "" {
-			dm.ServiceName = &log.ServiceName
-		}
-		if log.AlertID != 0 {
-			dm.AlertID = &log.AlertID
-		}
-		if log.ProviderMsgID != nil {
-			dm.ProviderID = &log.ProviderMsgID.ExternalID
+			out.Messages = append(out.Messages, *dm)
 		}
+		out.Destination = out.Messages[0].Destination
 
-		conn.Nodes = append(conn.Nodes, dm)
+		result = append(result, out)
 	}
-	conn.Stats = &searchOpts
 
-	return conn, nil
+	return result, nil
 }
 
 func (q *Query) DebugMessages(ctx context.Context, input *graphql2.DebugMessagesInput) ([]graphql2.DebugMessage, error) {
@@ -275,3 +411,21 @@ func (q *Query) DebugMessages(ctx context.Context, input *graphql2.DebugMessages
 
 	return conn.Nodes, nil
 }
+
+func (m *Mutation) ReplayMessage(ctx context.Context, input graphql2.ReplayMessageInput) (string, error) {
+	var id string
+	err := (*App)(m).withAuditTx(ctx, "replayMessage", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		var err error
+		id, err = m.NotificationStore.ReplayMessageTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		return audit.Add(auditlog.EntityTypeOutgoingMessage, id).SetAfter(struct{ ReplayOfID string }{ReplayOfID: input.ID})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/permission"
@@ -47,6 +50,24 @@ func (a *Mutation) LinkAccount(ctx context.Context, token string) (bool, error)
 	return err == nil, err
 }
 
+func (a *Mutation) SetScheduleHandoffNotification(ctx context.Context, input graphql2.SetScheduleHandoffNotificationInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	var h *schedule.HandoffNotification
+	if input.LeadTimeMinutes != nil {
+		h = &schedule.HandoffNotification{LeadTimeMinutes: *input.LeadTimeMinutes}
+	}
+
+	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return a.ScheduleStore.SetHandoffNotification(ctx, tx, schedID, h)
+	})
+
+	return err == nil, err
+}
+
 func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input graphql2.SetScheduleOnCallNotificationRulesInput) (bool, error) {
 	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
 	if err != nil {
@@ -56,7 +77,7 @@ func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input
 	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
 		rules := make([]schedule.OnCallNotificationRule, 0, len(input.Rules))
 		for i, r := range input.Rules {
-			err := validate.OneOf(fmt.Sprintf("Rules[%d].Target.Type", i), r.Target.Type, assignment.TargetTypeSlackChannel, assignment.TargetTypeSlackUserGroup, assignment.TargetTypeChanWebhook)
+			err := validate.OneOf(fmt.Sprintf("Rules[%d].Target.Type", i), r.Target.Type, assignment.TargetTypeSlackChannel, assignment.TargetTypeSlackUserGroup, assignment.TargetTypeChanWebhook, assignment.TargetTypeMSTeamsChannel)
 			if err != nil {
 				return err
 			}
@@ -110,6 +131,20 @@ func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input
 					Name:  webhook.MaskURLPass(url),
 					Value: r.Target.ID,
 				}
+			case assignment.TargetTypeMSTeamsChannel:
+				if !config.FromContext(ctx).MSTeams.Enable {
+					return validation.NewFieldError(fmt.Sprintf("Rules[%d].Target.Type", i), "Microsoft Teams is disabled by administrator")
+				}
+				url, err := url.Parse(r.Target.ID)
+				if err != nil {
+					return validation.NewFieldError(fmt.Sprintf("Rules[%d].Target.ID", i), "Invalid URL format")
+				}
+
+				nfyChan = &notificationchannel.Channel{
+					Type:  notificationchannel.TypeMSTeams,
+					Name:  "Teams (" + url.Hostname() + ")",
+					Value: r.Target.ID,
+				}
 			}
 
 			r.ChannelID, err = a.NCStore.MapToID(ctx, tx, nfyChan)
@@ -172,6 +207,19 @@ func (a *Mutation) ClearTemporarySchedules(ctx context.Context, input graphql2.C
 	return err == nil, err
 }
 
+func (a *Mutation) SetScheduleFixedShifts(ctx context.Context, input graphql2.SetScheduleFixedShiftsInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return a.ScheduleStore.SetFixedShifts(ctx, tx, schedID, input.Start, input.End, input.Shifts)
+	})
+
+	return err == nil, err
+}
+
 func (a *Mutation) TestContactMethod(ctx context.Context, id string) (bool, error) {
 	err := a.NotificationStore.SendContactMethodTest(ctx, id)
 	if err != nil {
@@ -181,6 +229,10 @@ func (a *Mutation) TestContactMethod(ctx context.Context, id string) (bool, erro
 	return true, nil
 }
 
+func (a *Mutation) TestNotificationRules(ctx context.Context, userID string) ([]string, error) {
+	return a.NotificationStore.SendNotificationRulesTest(ctx, userID)
+}
+
 func (a *Mutation) AddAuthSubject(ctx context.Context, input user.AuthSubject) (bool, error) {
 	err := a.UserStore.AddAuthSubjectTx(ctx, nil, &input)
 	if err != nil {
@@ -208,18 +260,22 @@ func (a *Mutation) EndAllAuthSessionsByCurrentUser(ctx context.Context) (bool, e
 func (a *Mutation) DeleteAll(ctx context.Context, input []assignment.RawTarget) (bool, error) {
 	// Retry because deleting frequently can cause a deadlock
 	// under heavy load.
+	audit := &auditEntries{action: "deleteAll"}
 	err := retry.DoTemporaryError(func(int) error {
-		return a.tryDeleteAll(ctx, input)
+		return a.tryDeleteAll(ctx, input, audit)
 	},
 		retry.Log(ctx),
 		retry.Limit(5),
 		retry.FibBackoff(time.Second),
 	)
+	if err != nil {
+		(*App)(a).logFailedAudit(ctx, audit, err)
+	}
 
 	return err == nil, err
 }
 
-func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarget) error {
+func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarget, audit *auditEntries) error {
 	tx, err := a.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -227,8 +283,35 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 	defer sqlutil.Rollback(ctx, "graphql: delete all", tx)
 
 	m := make(map[assignment.TargetType][]string)
+	var managed []assignment.Target
+	audit.list = nil
 	for _, tgt := range input {
 		m[tgt.TargetType()] = append(m[tgt.TargetType()], tgt.TargetID())
+		switch tgt.TargetType() {
+		case assignment.TargetTypeService, assignment.TargetTypeEscalationPolicy, assignment.TargetTypeEscalationPolicyStep, assignment.TargetTypeIntegrationKey, assignment.TargetTypeHeartbeatMonitor:
+			managed = append(managed, tgt)
+		}
+		switch tgt.TargetType() {
+		case assignment.TargetTypeIntegrationKey:
+			audit.Add(auditlog.EntityTypeIntegrationKey, tgt.TargetID())
+		case assignment.TargetTypeEscalationPolicy:
+			audit.Add(auditlog.EntityTypeEscalationPolicy, tgt.TargetID())
+		case assignment.TargetTypeEscalationPolicyStep:
+			audit.Add(auditlog.EntityTypeEscalationPolicyStep, tgt.TargetID())
+		case assignment.TargetTypeUser:
+			audit.Add(auditlog.EntityTypeUser, tgt.TargetID())
+		}
+	}
+	if len(managed) > 0 {
+		err = (*App)(a).requireManage(ctx, tx, managed...)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = a.auditDeleteTx(ctx, tx, audit)
+	if err != nil {
+		return err
 	}
 
 	order := []assignment.TargetType{
@@ -240,6 +323,7 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 		assignment.TargetTypeIntegrationKey,
 		assignment.TargetTypeHeartbeatMonitor,
 		assignment.TargetTypeService,
+		assignment.TargetTypeEscalationPolicyStep,
 		assignment.TargetTypeEscalationPolicy,
 		assignment.TargetTypeNotificationRule,
 		assignment.TargetTypeContactMethod,
@@ -258,6 +342,18 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 			err = errors.Wrap(a.UserStore.DeleteManyTx(ctx, tx, ids), "delete users")
 		case assignment.TargetTypeService:
 			err = errors.Wrap(a.ServiceStore.DeleteManyTx(ctx, tx, ids), "delete services")
+		case assignment.TargetTypeEscalationPolicyStep:
+			for _, id := range ids {
+				_, err = a.PolicyStore.DeleteStepTx(ctx, tx, id)
+				if errors.Is(err, sql.ErrNoRows) {
+					// already deleted
+					err = nil
+				}
+				if err != nil {
+					err = errors.Wrap(err, "delete escalation policy steps")
+					break
+				}
+			}
 		case assignment.TargetTypeEscalationPolicy:
 			err = errors.Wrap(a.PolicyStore.DeleteManyPoliciesTx(ctx, tx, ids), "delete escalation policies")
 		case assignment.TargetTypeIntegrationKey:
@@ -284,6 +380,11 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 		}
 	}
 
+	err = a.AuditLogStore.LogTx(ctx, tx, audit.entries()...)
+	if err != nil {
+		return errors.Wrap(err, "write audit log")
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -291,3 +392,59 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 
 	return nil
 }
+
+// auditDeleteTx will set the Before snapshot of each entity about to be deleted. Entities
+// that don't exist are left without one.
+func (a *Mutation) auditDeleteTx(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+	for _, e := range audit.list {
+		var err error
+		switch e.EntityType {
+		case auditlog.EntityTypeIntegrationKey:
+			var key *integrationkey.IntegrationKey
+			key, err = a.IntKeyStore.FindOne(ctx, e.EntityID)
+			if err == nil && key != nil {
+				err = e.SetBefore(key)
+			}
+		case auditlog.EntityTypeEscalationPolicy:
+			var pol *escalation.Policy
+			pol, err = a.PolicyStore.FindOnePolicyTx(ctx, tx, e.EntityID)
+			if err == nil {
+				err = e.SetBefore(pol)
+			}
+		case auditlog.EntityTypeEscalationPolicyStep:
+			var step *escalation.Step
+			step, err = a.auditStepTx(ctx, tx, e.EntityID)
+			if err == nil {
+				err = e.SetBefore(step)
+			}
+		case auditlog.EntityTypeUser:
+			var usr *user.User
+			usr, err = a.UserStore.FindOneTx(ctx, tx, e.EntityID, false)
+			if err == nil {
+				err = e.SetBefore(usr)
+			}
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "audit "+string(e.EntityType))
+		}
+	}
+
+	return nil
+}
+
+func (a *Mutation) SetWebhookSecret(ctx context.Context, input graphql2.SetWebhookSecretInput) (bool, error) {
+	var overlap time.Duration
+	if input.OverlapMinutes != nil {
+		overlap = time.Duration(*input.OverlapMinutes) * time.Minute
+	}
+
+	err := a.NCStore.SetWebhookSecret(ctx, nil, input.URL, input.Secret, overlap)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
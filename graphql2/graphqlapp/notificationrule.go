@@ -27,6 +27,23 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 		nr.ContactMethodID = *input.ContactMethodID
 	}
 
+	if input.QuietHours != nil {
+		nr.QuietHours = &notificationrule.QuietHours{
+			Start: input.QuietHours.Start,
+			End:   input.QuietHours.End,
+		}
+		if input.QuietHours.AllowHighUrgency != nil {
+			nr.QuietHours.AllowHighUrgency = *input.QuietHours.AllowHighUrgency
+		}
+	}
+
+	if input.Urgency != nil {
+		nr.Urgency = notificationrule.Urgency(*input.Urgency)
+	}
+	if input.StopOnSuccess != nil {
+		nr.StopOnSuccess = *input.StopOnSuccess
+	}
+
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		nr, err = m.NRStore.CreateTx(ctx, tx, nr)
@@ -40,6 +57,54 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 	return nr, nil
 }
 
+func (m *Mutation) UpdateUserNotificationRule(ctx context.Context, input graphql2.UpdateUserNotificationRuleInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.StopOnSuccess != nil {
+			err := m.NRStore.SetStopOnSuccessTx(ctx, tx, input.ID, *input.StopOnSuccess)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) SetUserNotificationRuleOrder(ctx context.Context, input graphql2.SetUserNotificationRuleOrderInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.NRStore.SetOrderTx(ctx, tx, input.UserID, input.RuleIDs)
+	})
+
+	return err == nil, err
+}
+
 func (nr *UserNotificationRule) ContactMethod(ctx context.Context, raw *notificationrule.NotificationRule) (*contactmethod.ContactMethod, error) {
 	return (*App)(nr).FindOneCM(ctx, raw.ContactMethodID)
 }
+
+func (nr *UserNotificationRule) Urgency(ctx context.Context, raw *notificationrule.NotificationRule) (*graphql2.UserNotificationRuleUrgency, error) {
+	if raw.Urgency == notificationrule.UrgencyAny {
+		return nil, nil
+	}
+
+	u := graphql2.UserNotificationRuleUrgency(raw.Urgency)
+	return &u, nil
+}
+
+func (m *Mutation) SetUserUrgencyWindow(ctx context.Context, input graphql2.SetUserUrgencyWindowInput) (bool, error) {
+	var w *notificationrule.UrgencyWindow
+	if input.Window != nil {
+		w = &notificationrule.UrgencyWindow{
+			Start: input.Window.Start,
+			End:   input.Window.End,
+		}
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.NRStore.SetUrgencyWindowTx(ctx, tx, input.UserID, w)
+	})
+
+	return err == nil, err
+}
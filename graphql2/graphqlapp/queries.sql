@@ -16,3 +16,25 @@ WHERE
         OR (om.message_type = 'alert_notification_bundle'
             AND om.service_id = @service_id::uuid));
 
+
+-- name: APIKeyScopeAlertServiceIDs :many
+-- APIKeyScopeAlertServiceIDs returns the services of the given alerts.
+SELECT DISTINCT
+    service_id::uuid
+FROM
+    alerts
+WHERE
+    id = ANY (@alert_ids::bigint[])
+    AND service_id IS NOT NULL;
+
+-- name: APIKeyScopeScheduleIDs :many
+-- APIKeyScopeScheduleIDs returns the schedules targeted by the escalation policies of the given services.
+SELECT DISTINCT
+    act.schedule_id::uuid
+FROM
+    services svc
+    JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+    JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+WHERE
+    svc.id = ANY (@service_ids::uuid[])
+    AND act.schedule_id IS NOT NULL;
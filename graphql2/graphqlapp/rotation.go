@@ -7,6 +7,7 @@ import (
 
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/search"
@@ -63,6 +64,12 @@ func (m *Mutation) CreateRotation(ctx context.Context, input graphql2.CreateRota
 				return err
 			}
 		}
+		if input.UserWeights != nil {
+			err := m.RotationStore.SetParticipantWeightsTx(ctx, tx, result.ID, input.UserWeights)
+			if err != nil {
+				return err
+			}
+		}
 		return err
 	})
 
@@ -107,16 +114,40 @@ func (r *Rotation) NextHandoffTimes(ctx context.Context, rot *rotation.Rotation,
 		return nil, err
 	}
 
+	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
 	result := make([]time.Time, n)
 	t := s.ShiftStart
+	pos := s.Position % len(parts)
 	for i := range result {
-		t = rot.EndTime(t)
+		t = rot.TurnEndTime(t, parts[pos].Weight)
 		result[i] = t
+		pos = (pos + 1) % len(parts)
 	}
 
 	return result, nil
 }
 
+func (r *Rotation) UserWeights(ctx context.Context, rot *rotation.Rotation) ([]int, error) {
+	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]int, len(parts))
+	for i, p := range parts {
+		weights[i] = p.Weight
+	}
+
+	return weights, nil
+}
+
 func (r *Rotation) UserIDs(ctx context.Context, rot *rotation.Rotation) ([]string, error) {
 	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
 	if err != nil {
@@ -306,6 +337,34 @@ func (m *Mutation) updateRotationParticipants(ctx context.Context, tx *sql.Tx, r
 	return nil
 }
 
+// preservedActiveIndex returns the index the active user will have after the participants
+// are replaced with userIDs, or -1 if there is no active user or they are being removed.
+func (m *Mutation) preservedActiveIndex(ctx context.Context, tx *sql.Tx, rotationID string, userIDs []string) (int, error) {
+	s, err := m.RotationStore.StateTx(ctx, tx, rotationID)
+	if errors.Is(err, rotation.ErrNoState) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+
+	parts, err := m.RotationStore.FindAllParticipantsTx(ctx, tx, rotationID)
+	if err != nil {
+		return -1, err
+	}
+	oldIDs := make([]string, len(parts))
+	for i, p := range parts {
+		oldIDs[i] = p.Target.TargetID()
+	}
+
+	idx, ok := rotation.PreservedPosition(oldIDs, s.Position, userIDs)
+	if !ok {
+		return -1, nil
+	}
+
+	return idx, nil
+}
+
 func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRotationInput) (res bool, err error) {
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		result, err := m.RotationStore.FindRotationForUpdateTx(ctx, tx, input.ID)
@@ -353,6 +412,19 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 			}
 		}
 
+		preserve := input.PreserveActiveUser != nil && *input.PreserveActiveUser
+		if preserve && input.ActiveUserIndex != nil {
+			return validation.NewFieldError("PreserveActiveUser", "cannot be used with ActiveUserIndex")
+		}
+
+		newIndex := -1
+		if preserve && input.UserIDs != nil {
+			newIndex, err = m.preservedActiveIndex(ctx, tx, input.ID, input.UserIDs)
+			if err != nil {
+				return err
+			}
+		}
+
 		if input.UserIDs != nil {
 			err = m.updateRotationParticipants(ctx, tx, input.ID, input.UserIDs, input.ActiveUserIndex == nil)
 			if err != nil {
@@ -360,6 +432,20 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 			}
 		}
 
+		if newIndex != -1 {
+			err = m.RotationStore.SetActiveIndexTx(ctx, tx, input.ID, newIndex)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.UserWeights != nil {
+			err = m.RotationStore.SetParticipantWeightsTx(ctx, tx, input.ID, input.UserWeights)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Update active participant (in rotation state) if specified by input
 		// This should be applicable regardless of whether or not 'UserIDs' as an input has been specified.
 		if input.ActiveUserIndex != nil {
@@ -471,3 +557,92 @@ func setRotationShiftFromISO(rot *rotation.Rotation, dur *timeutil.ISODuration)
 
 	return nil
 }
+
+type RotationShadow App
+
+func (a *App) RotationShadow() graphql2.RotationShadowResolver { return (*RotationShadow)(a) }
+
+func (r *Rotation) Shadows(ctx context.Context, rot *rotation.Rotation) ([]rotation.Shadow, error) {
+	return r.RotationStore.FindAllShadows(ctx, rot.ID)
+}
+
+func (r *RotationShadow) User(ctx context.Context, sh *rotation.Shadow) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, sh.UserID)
+}
+
+func (m *Mutation) SetRotationShadow(ctx context.Context, input graphql2.SetRotationShadowInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.RotationStore.SetShadowTx(ctx, tx, rotation.Shadow{
+			RotationID: input.RotationID,
+			UserID:     input.UserID,
+			ExpiresAt:  input.ExpiresAt,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *Mutation) DeleteRotationShadow(ctx context.Context, input graphql2.DeleteRotationShadowInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.RotationStore.DeleteShadowTx(ctx, tx, input.RotationID, input.UserID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type RotationFairnessStat App
+
+func (a *App) RotationFairnessStat() graphql2.RotationFairnessStatResolver {
+	return (*RotationFairnessStat)(a)
+}
+
+func (r *Rotation) Fairness(ctx context.Context, rot *rotation.Rotation, input *graphql2.RotationFairnessInput) ([]oncall.FairnessStat, error) {
+	if input == nil {
+		input = &graphql2.RotationFairnessInput{}
+	}
+
+	end := time.Now()
+	if input.End != nil {
+		end = *input.End
+	}
+	start := end.AddDate(0, 0, -30)
+	if input.Start != nil {
+		start = *input.Start
+	}
+	if !end.After(start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+	if end.After(start.AddDate(0, 0, 366)) {
+		return nil, validation.NewFieldError("End", "cannot be more than 366 days past Start")
+	}
+
+	var bucket time.Duration
+	if input.BucketDuration != nil {
+		bucket = input.BucketDuration.TimePart()
+		bucket += time.Duration(input.BucketDuration.Days()) * 24 * time.Hour
+		bucket += time.Duration(input.BucketDuration.MonthPart) * 30 * 24 * time.Hour
+		bucket += time.Duration(input.BucketDuration.YearPart) * 365 * 24 * time.Hour
+		if bucket <= 0 {
+			return nil, validation.NewFieldError("BucketDuration", "must be positive")
+		}
+		if end.Sub(start) > 100*bucket {
+			return nil, validation.NewFieldError("BucketDuration", "must result in at most 100 buckets")
+		}
+	}
+
+	return r.OnCallStore.RotationFairness(ctx, rot.ID, start, end, bucket)
+}
+
+func (r *RotationFairnessStat) User(ctx context.Context, stat *oncall.FairnessStat) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, stat.UserID)
+}
+
+func (r *RotationFairnessStat) OnCallHours(ctx context.Context, stat *oncall.FairnessStat) (float64, error) {
+	return stat.OnCall.Hours(), nil
+}
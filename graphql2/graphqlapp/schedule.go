@@ -60,6 +60,12 @@ func (a *OnCallNotificationRule) Target(ctx context.Context, raw *schedule.OnCal
 			ID:   ch.Value,
 			Name: ch.Name,
 		}, nil
+	case notificationchannel.TypeMSTeams:
+		return &assignment.RawTarget{
+			Type: assignment.TargetTypeMSTeamsChannel,
+			ID:   ch.Value,
+			Name: ch.Name,
+		}, nil
 	}
 
 	return &assignment.RawTarget{Type: assignment.TargetTypeNotificationChannel, ID: ch.ID, Name: ch.Name}, nil
@@ -91,6 +97,18 @@ func (s *Schedule) Shifts(ctx context.Context, raw *schedule.Schedule, start, en
 	return s.OnCallStore.HistoryBySchedule(ctx, raw.ID, start, end)
 }
 
+func (s *Schedule) CoverageGaps(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]oncall.CoverageGap, error) {
+	shifts, err := s.Shifts(ctx, raw, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return oncall.CoverageGaps(shifts, start, end), nil
+}
+
+func (s *Schedule) OnCallUsers(ctx context.Context, raw *schedule.Schedule) ([]oncall.ScheduleOnCallUser, error) {
+	return s.OnCallStore.OnCallUsersBySchedule(ctx, raw.ID)
+}
+
 func (s *Schedule) TemporarySchedules(ctx context.Context, raw *schedule.Schedule) ([]schedule.TemporarySchedule, error) {
 	id, err := parseUUID("ScheduleID", raw.ID)
 	if err != nil {
@@ -99,6 +117,31 @@ func (s *Schedule) TemporarySchedules(ctx context.Context, raw *schedule.Schedul
 	return s.ScheduleStore.TemporarySchedules(ctx, nil, id)
 }
 
+func (s *Schedule) FixedShifts(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]oncall.Shift, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+
+	shifts, err := s.ScheduleStore.FixedShifts(ctx, nil, id, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]oncall.Shift, 0, len(shifts))
+	for _, s := range shifts {
+		result = append(result, oncall.Shift{
+			UserID: s.UserID,
+			Start:  s.Start,
+			End:    s.End,
+		})
+	}
+	return result, nil
+}
+
 func (s *Schedule) OnCallNotificationRules(ctx context.Context, raw *schedule.Schedule) ([]schedule.OnCallNotificationRule, error) {
 	id, err := parseUUID("ScheduleID", raw.ID)
 	if err != nil {
@@ -107,6 +150,14 @@ func (s *Schedule) OnCallNotificationRules(ctx context.Context, raw *schedule.Sc
 	return s.ScheduleStore.OnCallNotificationRules(ctx, nil, id)
 }
 
+func (s *Schedule) HandoffNotification(ctx context.Context, raw *schedule.Schedule) (*schedule.HandoffNotification, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.HandoffNotification(ctx, nil, id)
+}
+
 func (s *Schedule) Target(ctx context.Context, raw *schedule.Schedule, input assignment.RawTarget) (*graphql2.ScheduleTarget, error) {
 	rules, err := s.RuleStore.FindByTargetTx(ctx, nil, raw.ID, input)
 	if err != nil {
@@ -320,6 +371,15 @@ func (q *Query) Schedules(ctx context.Context, opts *graphql2.ScheduleSearchOpti
 	if searchOpts.Limit == 0 {
 		searchOpts.Limit = 15
 	}
+	if _, ok := apiKeyServiceFilter(ctx); ok {
+		searchOpts.Only, err = (*App)(q).apiKeyScheduleIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(searchOpts.Only) == 0 {
+			return &graphql2.ScheduleConnection{PageInfo: &graphql2.PageInfo{}}, nil
+		}
+	}
 
 	searchOpts.Limit++
 	scheds, err := q.ScheduleStore.Search(ctx, &searchOpts)
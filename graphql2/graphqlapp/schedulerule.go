@@ -66,6 +66,13 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 			if inputRule.WeekdayFilter != nil {
 				r.WeekdayFilter = *inputRule.WeekdayFilter
 			}
+			if ruleIndex < len(rules) {
+				// keep the existing priority unless it is being changed
+				r.Priority = rules[ruleIndex].Priority
+			}
+			if inputRule.Priority != nil {
+				r.Priority = *inputRule.Priority
+			}
 			if ruleIndex < len(rules) {
 				r.ID = rules[ruleIndex].ID
 				err = errors.Wrap(m.RuleStore.UpdateTx(ctx, tx, r), "update rule")
@@ -4,6 +4,7 @@ import (
 	context "context"
 	"database/sql"
 	"strconv"
+	"time"
 
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/escalation"
@@ -11,11 +12,13 @@ import (
 	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
 	"github.com/target/goalert/util/sqlutil"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
@@ -60,6 +63,9 @@ func (q *Query) Services(ctx context.Context, opts *graphql2.ServiceSearchOption
 	if searchOpts.Limit == 0 {
 		searchOpts.Limit = 15
 	}
+	if ids, ok := apiKeyServiceFilter(ctx); ok {
+		searchOpts.Only = ids
+	}
 
 	searchOpts.Limit++
 	svcs, err := q.ServiceStore.Search(ctx, &searchOpts)
@@ -103,6 +109,32 @@ func (s *Service) IsFavorite(ctx context.Context, raw *service.Service) (bool, e
 	return raw.IsUserFavorite(), nil
 }
 
+func (s *Service) Paused(ctx context.Context, raw *service.Service) (bool, error) {
+	return !raw.PausedAt.IsZero(), nil
+}
+
+func (s *Service) PausedAt(ctx context.Context, raw *service.Service) (*time.Time, error) {
+	if raw.PausedAt.IsZero() {
+		return nil, nil
+	}
+	return &raw.PausedAt, nil
+}
+
+func (s *Service) PausedBy(ctx context.Context, raw *service.Service) (*user.User, error) {
+	if raw.PausedByUserID == "" {
+		return nil, nil
+	}
+	return (*App)(s).FindOneUser(ctx, raw.PausedByUserID)
+}
+
+func (q *Query) ServicesOnCall(ctx context.Context) ([]oncall.ServiceOnCall, error) {
+	return q.OnCallStore.ServicesOnCall(ctx)
+}
+
+func (q *Query) MisconfiguredServices(ctx context.Context) ([]service.MisconfiguredService, error) {
+	return q.ServiceStore.FindMisconfigured(ctx)
+}
+
 func (s *Service) OnCallUsers(ctx context.Context, raw *service.Service) ([]oncall.ServiceOnCallUser, error) {
 	return s.OnCallStore.OnCallUsersByService(ctx, raw.ID)
 }
@@ -115,6 +147,10 @@ func (s *Service) HeartbeatMonitors(ctx context.Context, raw *service.Service) (
 	return s.HeartbeatStore.FindAllByService(ctx, raw.ID)
 }
 
+func (s *Service) MaintenanceWindows(ctx context.Context, raw *service.Service) ([]maintenance.Window, error) {
+	return s.MaintenanceStore.FindAllByService(ctx, raw.ID)
+}
+
 func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServiceInput) (result *service.Service, err error) {
 	if input.NewEscalationPolicy != nil && input.EscalationPolicyID != nil && *input.EscalationPolicyID != "" {
 		return nil, validation.NewFieldError("newEscalationPolicy", "cannot be used with `escalationPolicyID`.")
@@ -130,6 +166,24 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 		if input.Description != nil {
 			svc.Description = *input.Description
 		}
+		if input.NotificationTemplate != nil {
+			svc.NotificationTemplate = *input.NotificationTemplate
+		}
+		if input.RunbookURL != nil {
+			svc.RunbookURL = *input.RunbookURL
+		}
+		if input.EnrichmentURL != nil {
+			svc.EnrichmentURL = *input.EnrichmentURL
+		}
+		if input.NotificationDelayMinutes != nil {
+			svc.NotificationDelayMinutes = *input.NotificationDelayMinutes
+		}
+		if input.RequireCloseReason != nil {
+			svc.RequireCloseReason = *input.RequireCloseReason
+		}
+		if input.TimeZone != nil {
+			svc.TimeZone = *input.TimeZone
+		}
 		if input.NewEscalationPolicy != nil {
 			// Set tempUUID so that Normalize won't fail on the yet-to-be-created
 			// escalation policy.
@@ -171,6 +225,21 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 			return err
 		}
 
+		// Labels are set first, so that nested creation of integration keys and
+		// heartbeat monitors is permitted when service management is restricted.
+		for i, lbl := range input.Labels {
+			lbl.Target = &assignment.RawTarget{Type: assignment.TargetTypeService, ID: result.ID}
+			err = m.setLabel(ctx, tx, lbl)
+			if err != nil {
+				return validation.AddPrefix("labels["+strconv.Itoa(i)+"].", err)
+			}
+		}
+
+		err = (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(result.ID))
+		if err != nil {
+			return err
+		}
+
 		for i, key := range input.NewIntegrationKeys {
 			key.ServiceID = &result.ID
 			_, err = m.CreateIntegrationKey(ctx, key)
@@ -187,14 +256,6 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 			}
 		}
 
-		for i, lbl := range input.Labels {
-			lbl.Target = &assignment.RawTarget{Type: assignment.TargetTypeService, ID: result.ID}
-			_, err = m.SetLabel(ctx, lbl)
-			if err != nil {
-				return validation.AddPrefix("labels["+strconv.Itoa(i)+"].", err)
-			}
-		}
-
 		return err
 	})
 
@@ -213,6 +274,11 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 		return false, err
 	}
 
+	err = (*App)(a).requireManage(ctx, tx, assignment.ServiceTarget(svc.ID))
+	if err != nil {
+		return false, err
+	}
+
 	if input.Name != nil {
 		svc.Name = *input.Name
 	}
@@ -222,6 +288,24 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 	if input.EscalationPolicyID != nil {
 		svc.EscalationPolicyID = *input.EscalationPolicyID
 	}
+	if input.NotificationTemplate != nil {
+		svc.NotificationTemplate = *input.NotificationTemplate
+	}
+	if input.RunbookURL != nil {
+		svc.RunbookURL = *input.RunbookURL
+	}
+	if input.EnrichmentURL != nil {
+		svc.EnrichmentURL = *input.EnrichmentURL
+	}
+	if input.NotificationDelayMinutes != nil {
+		svc.NotificationDelayMinutes = *input.NotificationDelayMinutes
+	}
+	if input.RequireCloseReason != nil {
+		svc.RequireCloseReason = *input.RequireCloseReason
+	}
+	if input.TimeZone != nil {
+		svc.TimeZone = *input.TimeZone
+	}
 
 	if input.MaintenanceExpiresAt != nil {
 		svc.MaintenanceExpiresAt = *input.MaintenanceExpiresAt
@@ -239,3 +323,69 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 
 	return true, nil
 }
+
+func (m *Mutation) SetServicePaused(ctx context.Context, input graphql2.SetServicePausedInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := (*App)(m).requireManage(ctx, tx, assignment.ServiceTarget(input.ID))
+		if err != nil {
+			return err
+		}
+
+		changed, err := m.ServiceStore.SetPausedTx(ctx, tx, input.ID, input.Paused)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+
+		if input.Paused {
+			return m.AlertStore.PauseServiceTx(ctx, tx, input.ID)
+		}
+
+		return m.AlertStore.ResumeServiceTx(ctx, tx, input.ID, input.Renotify != nil && *input.Renotify)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type ServiceWatcher App
+
+func (a *App) ServiceWatcher() graphql2.ServiceWatcherResolver { return (*ServiceWatcher)(a) }
+
+func (s *Service) Watchers(ctx context.Context, svc *service.Service) ([]service.Watcher, error) {
+	return s.ServiceStore.FindAllWatchers(ctx, svc.ID)
+}
+
+func (w *ServiceWatcher) User(ctx context.Context, sw *service.Watcher) (*user.User, error) {
+	return (*App)(w).FindOneUser(ctx, sw.UserID)
+}
+
+func (m *Mutation) SetServiceWatcher(ctx context.Context, input graphql2.SetServiceWatcherInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ServiceStore.SetWatcherTx(ctx, tx, service.Watcher{
+			ServiceID:       input.ServiceID,
+			UserID:          input.UserID,
+			ContactMethodID: input.ContactMethodID,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *Mutation) DeleteServiceWatcher(ctx context.Context, input graphql2.DeleteServiceWatcherInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ServiceStore.DeleteWatcherTx(ctx, tx, input.ServiceID, input.UserID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
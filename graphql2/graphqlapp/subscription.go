@@ -0,0 +1,94 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+type Subscription App
+
+func (a *App) Subscription() graphql2.SubscriptionResolver { return (*Subscription)(a) }
+
+// AlertEvents streams alert state changes until the client disconnects. Permissions
+// are checked when subscribing, and again before each event is sent.
+func (s *Subscription) AlertEvents(ctx context.Context, serviceIDs []string) (<-chan *graphql2.AlertEvent, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.ManyUUID("ServiceIDs", serviceIDs, 50)
+	if err != nil {
+		return nil, err
+	}
+	svcIDs := make(map[string]bool, len(serviceIDs))
+	for _, id := range serviceIDs {
+		if !apikey.ServiceAllowed(ctx, id) {
+			return nil, permission.NewAccessDenied("service not allowed by API key")
+		}
+		svcIDs[id] = true
+	}
+
+	events := s.AlertEventBroker.Subscribe(ctx)
+	ch := make(chan *graphql2.AlertEvent)
+	go func() {
+		defer close(ch)
+		for e := range events {
+			ev, err := s.alertEvent(ctx, e, svcIDs)
+			if permission.IsPermissionError(err) {
+				return
+			}
+			if err != nil {
+				log.Log(ctx, fmt.Errorf("alert events subscription: %w", err))
+				continue
+			}
+			if ev == nil {
+				continue
+			}
+
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// alertEvent returns the event to send to the subscriber, or nil if it should be skipped.
+func (s *Subscription) alertEvent(ctx context.Context, e alertlog.Event, svcIDs map[string]bool) (*graphql2.AlertEvent, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := s.AlertStore.FindOne(ctx, e.AlertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// deleted before we could send it
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(svcIDs) > 0 && !svcIDs[a.ServiceID] {
+		return nil, nil
+	}
+	if !apikey.ServiceAllowed(ctx, a.ServiceID) {
+		return nil, nil
+	}
+
+	return &graphql2.AlertEvent{
+		Type:  graphql2.AlertEventType(e.Type),
+		Alert: a,
+	}, nil
+}
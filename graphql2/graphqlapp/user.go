@@ -3,6 +3,7 @@ package graphqlapp
 import (
 	context "context"
 	"database/sql"
+	"time"
 
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/calsub"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
@@ -18,6 +20,7 @@ import (
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/util"
 )
 
 type (
@@ -74,6 +77,28 @@ func (a *User) NotificationRules(ctx context.Context, obj *user.User) ([]notific
 	return a.NRStore.FindAll(ctx, obj.ID)
 }
 
+func (a *User) UrgencyWindow(ctx context.Context, obj *user.User) (*notificationrule.UrgencyWindow, error) {
+	return a.NRStore.FindUrgencyWindow(ctx, obj.ID)
+}
+
+func (a *User) DoNotDisturb(ctx context.Context, obj *user.User) (*user.DoNotDisturb, error) {
+	return a.UserStore.FindDoNotDisturb(ctx, obj.ID)
+}
+
+func (a *User) TimeZone(ctx context.Context, obj *user.User) (*string, error) {
+	tz, err := a.UserStore.FindTimeZone(ctx, obj.ID)
+	if err != nil || tz == nil {
+		return nil, err
+	}
+
+	name := tz.String()
+	return &name, nil
+}
+
+func (a *User) MaxNotificationsPerHour(ctx context.Context, obj *user.User) (*int, error) {
+	return a.UserStore.FindNotificationRateLimit(ctx, obj.ID)
+}
+
 func (a *User) CalendarSubscriptions(ctx context.Context, obj *user.User) ([]calsub.Subscription, error) {
 	return a.CalSubStore.FindAllByUser(ctx, obj.ID)
 }
@@ -158,12 +183,14 @@ func (a *Mutation) CreateUser(ctx context.Context, input graphql2.CreateUserInpu
 		usr.Role = permission.Role(*input.Role)
 	}
 
-	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err = (*App)(a).withAuditTx(ctx, "createUser", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeUser, "")
 		var err error
 		newUser, err = a.UserStore.InsertTx(ctx, tx, usr)
 		if err != nil {
 			return err
 		}
+		e.EntityID = newUser.ID
 		if input.Favorite != nil && *input.Favorite {
 			err = a.FavoriteStore.SetTx(ctx, tx, permission.UserID(ctx), assignment.UserTarget(newUser.ID))
 			if err != nil {
@@ -174,18 +201,23 @@ func (a *Mutation) CreateUser(ctx context.Context, input graphql2.CreateUserInpu
 		if err != nil {
 			return err
 		}
-		return nil
+		return e.SetAfter(newUser)
 	})
 
 	return newUser, err
 }
 
 func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInput) (bool, error) {
-	err := withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+	err := (*App)(a).withAuditTx(ctx, "updateUser", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		e := audit.Add(auditlog.EntityTypeUser, input.ID)
 		usr, err := a.UserStore.FindOneTx(ctx, tx, input.ID, true)
 		if err != nil {
 			return err
 		}
+		err = e.SetBefore(usr)
+		if err != nil {
+			return err
+		}
 
 		if input.Role != nil {
 			err = a.UserStore.SetUserRoleTx(ctx, tx, input.ID, permission.Role(*input.Role))
@@ -194,6 +226,31 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 			}
 		}
 
+		if input.TimeZone != nil {
+			var tz *time.Location
+			if *input.TimeZone != "" {
+				tz, err = util.LoadLocation(*input.TimeZone)
+				if err != nil {
+					return validation.NewFieldError("TimeZone", "unknown time zone")
+				}
+			}
+			err = a.UserStore.SetTimeZoneTx(ctx, tx, input.ID, tz)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.MaxNotificationsPerHour != nil {
+			limit := input.MaxNotificationsPerHour
+			if *limit < 0 {
+				limit = nil
+			}
+			err = a.UserStore.SetNotificationRateLimitTx(ctx, tx, input.ID, limit)
+			if err != nil {
+				return err
+			}
+		}
+
 		if input.Name != nil {
 			usr.Name = *input.Name
 		}
@@ -201,11 +258,52 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 			usr.Email = *input.Email
 		}
 
-		return a.UserStore.UpdateTx(ctx, tx, usr)
+		err = a.UserStore.UpdateTx(ctx, tx, usr)
+		if err != nil {
+			return err
+		}
+
+		after, err := a.UserStore.FindOneTx(ctx, tx, input.ID, false)
+		if err != nil {
+			return err
+		}
+		return e.SetAfter(after)
 	})
 	return err == nil, err
 }
 
+func (a *Mutation) SetUserDoNotDisturb(ctx context.Context, input graphql2.SetUserDoNotDisturbInput) (bool, error) {
+	var dnd *user.DoNotDisturb
+	if input.Enabled {
+		dnd = &user.DoNotDisturb{}
+		if input.ExpiresAt != nil {
+			dnd.ExpiresAt = *input.ExpiresAt
+		}
+	} else if input.ExpiresAt != nil {
+		return false, validation.NewFieldError("ExpiresAt", "cannot be set when disabling do-not-disturb")
+	}
+
+	err := withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return a.UserStore.SetDoNotDisturbTx(ctx, tx, input.UserID, dnd)
+	})
+
+	return err == nil, err
+}
+
+type UserDoNotDisturb App
+
+func (a *App) UserDoNotDisturb() graphql2.UserDoNotDisturbResolver {
+	return (*UserDoNotDisturb)(a)
+}
+
+func (d *UserDoNotDisturb) ExpiresAt(ctx context.Context, raw *user.DoNotDisturb) (*time.Time, error) {
+	if raw.ExpiresAt.IsZero() {
+		return nil, nil
+	}
+
+	return &raw.ExpiresAt, nil
+}
+
 func (q *Query) Users(ctx context.Context, opts *graphql2.UserSearchOptions, first *int, after, searchStr *string) (conn *graphql2.UserConnection, err error) {
 	if opts == nil {
 		opts = &graphql2.UserSearchOptions{
@@ -0,0 +1,132 @@
+package graphqlapp
+
+import (
+	context "context"
+	"sort"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/validation"
+)
+
+type UserOnCallShift App
+
+func (a *App) UserOnCallShift() graphql2.UserOnCallShiftResolver { return (*UserOnCallShift)(a) }
+
+// userShiftCursor identifies the last shift of a page, as a shift is unique by start time and schedule.
+type userShiftCursor struct {
+	Start      time.Time
+	ScheduleID string
+}
+
+func (q *Query) UserOnCallShifts(ctx context.Context, input graphql2.UserOnCallShiftsInput) (*graphql2.UserOnCallShiftConnection, error) {
+	start := time.Now()
+	if input.Start != nil {
+		start = *input.Start
+	}
+	end := start.AddDate(0, 0, 14)
+	if input.End != nil {
+		end = *input.End
+	}
+	if !end.After(start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+	if end.After(start.AddDate(0, 0, 50)) {
+		return nil, validation.NewFieldError("End", "cannot be more than 50 days past Start")
+	}
+
+	var after userShiftCursor
+	if input.After != nil && *input.After != "" {
+		err := search.ParseCursor(*input.After, &after)
+		if err != nil {
+			return nil, err
+		}
+	}
+	limit := 15
+	if input.First != nil {
+		limit = *input.First
+	}
+	if limit < 1 || limit > 100 {
+		return nil, validation.NewFieldError("First", "must be between 1 and 100")
+	}
+
+	loc, err := q.UserStore.FindTimeZone(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = config.FromContext(ctx).DefaultLocation()
+	}
+
+	conn := &graphql2.UserOnCallShiftConnection{PageInfo: &graphql2.PageInfo{}, TimeZone: loc.String()}
+	shifts, more, err := q.OnCallStore.UserShifts(ctx, oncall.UserShiftsOptions{
+		UserID:          input.UserID,
+		Start:           start,
+		End:             end,
+		AfterStart:      after.Start,
+		AfterScheduleID: after.ScheduleID,
+		Limit:           limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	conn.PageInfo.HasNextPage = more
+	for i := range shifts {
+		shifts[i].Start = shifts[i].Start.In(loc)
+		shifts[i].End = shifts[i].End.In(loc)
+	}
+	if len(shifts) > 0 {
+		last := shifts[len(shifts)-1]
+		cur, err := search.Cursor(userShiftCursor{Start: last.Start, ScheduleID: last.ScheduleID})
+		if err != nil {
+			return nil, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+	conn.Nodes = shifts
+
+	return conn, nil
+}
+
+func (s *UserOnCallShift) LocalStart(ctx context.Context, raw *oncall.UserShift) (string, error) {
+	return raw.Start.Format(time.RFC3339), nil
+}
+
+func (s *UserOnCallShift) LocalEnd(ctx context.Context, raw *oncall.UserShift) (string, error) {
+	return raw.End.Format(time.RFC3339), nil
+}
+
+func (s *UserOnCallShift) Schedule(ctx context.Context, raw *oncall.UserShift) (*schedule.Schedule, error) {
+	return (*App)(s).FindOneSchedule(ctx, raw.ScheduleID)
+}
+
+func (s *UserOnCallShift) Services(ctx context.Context, raw *oncall.UserShift) ([]service.Service, error) {
+	pols, err := s.PolicyStore.FindAllPoliciesBySchedule(ctx, raw.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	result := []service.Service{}
+	for _, p := range pols {
+		svcs, err := s.ServiceStore.FindAllByEP(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range svcs {
+			if seen[svc.ID] {
+				continue
+			}
+			seen[svc.ID] = true
+			result = append(result, svc)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package graphqlapp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/search"
+)
+
+func TestUserShiftCursor(t *testing.T) {
+	exp := userShiftCursor{Start: time.Date(2023, 10, 30, 8, 0, 0, 0, time.UTC), ScheduleID: "a"}
+	cur, err := search.Cursor(exp)
+	require.NoError(t, err)
+
+	var res userShiftCursor
+	require.NoError(t, search.ParseCursor(cur, &res))
+	assert.True(t, exp.Start.Equal(res.Start))
+	assert.Equal(t, exp.ScheduleID, res.ScheduleID)
+}
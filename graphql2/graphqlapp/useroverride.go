@@ -3,13 +3,20 @@ package graphqlapp
 import (
 	context "context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/timeutil"
 	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
 )
 
 type UserOverride App
@@ -76,6 +83,101 @@ func (m *Mutation) CreateUserOverride(ctx context.Context, input graphql2.Create
 	return u, nil
 }
 
+// TakeOnCall adds an override placing the current user on call immediately, on the schedule
+// notified by the service's escalation policy.
+func (m *Mutation) TakeOnCall(ctx context.Context, input graphql2.TakeOnCallInput) (*override.UserOverride, error) {
+	userID := permission.UserID(ctx)
+	if userID == "" {
+		return nil, permission.NewAccessDenied("must be logged in as a user")
+	}
+
+	err := validate.Range("DurationMinutes", input.DurationMinutes, 1, 7*24*60)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *override.UserOverride
+	err = (*App)(m).withAuditTx(ctx, "takeOnCall", func(ctx context.Context, tx *sql.Tx, audit *auditEntries) error {
+		svc, err := m.ServiceStore.FindOne(ctx, input.ServiceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return validation.NewFieldError("ServiceID", "not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		schedID, err := (*App)(m).policyScheduleTx(ctx, tx, svc.EscalationPolicyID, input.ScheduleID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		o, err = m.OverrideStore.CreateUserOverrideTx(ctx, tx, &override.UserOverride{
+			AddUserID: userID,
+			Start:     now,
+			End:       now.Add(time.Duration(input.DurationMinutes) * time.Minute),
+			Target:    assignment.ScheduleTarget(schedID),
+		})
+		if err != nil {
+			return err
+		}
+
+		return audit.Add(auditlog.EntityTypeUserOverride, o.ID).SetAfter(o)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// policyScheduleTx will return the schedule to override for the given escalation policy.
+// If scheduleID is set, it must be one of the policy's schedules. Otherwise, the first step
+// with a schedule must have exactly one.
+func (a *App) policyScheduleTx(ctx context.Context, tx *sql.Tx, policyID string, scheduleID *string) (string, error) {
+	steps, err := a.PolicyStore.FindAllStepsTx(ctx, tx, policyID)
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool)
+	var first []string
+	var firstStep int
+	for _, step := range steps {
+		tgts, err := a.PolicyStore.FindAllStepTargetsTx(ctx, tx, step.ID)
+		if err != nil {
+			return "", err
+		}
+
+		var scheds []string
+		for _, tgt := range tgts {
+			if tgt.TargetType() != assignment.TargetTypeSchedule || used[tgt.TargetID()] {
+				continue
+			}
+			used[tgt.TargetID()] = true
+			scheds = append(scheds, tgt.TargetID())
+		}
+		if first == nil && len(scheds) > 0 {
+			first = scheds
+			firstStep = step.StepNumber
+		}
+	}
+
+	switch {
+	case scheduleID != nil:
+		if !used[*scheduleID] {
+			return "", validation.NewFieldError("ScheduleID", "must be a schedule used by the escalation policy")
+		}
+		return *scheduleID, nil
+	case len(first) == 0:
+		return "", validation.NewFieldError("ServiceID", "escalation policy does not notify any schedules")
+	case len(first) > 1:
+		return "", validation.NewFieldError("ScheduleID", fmt.Sprintf("is required, step #%d of the escalation policy notifies %d schedules", firstStep+1, len(first)))
+	}
+
+	return first[0], nil
+}
+
 func (u *UserOverride) AddUser(ctx context.Context, raw *override.UserOverride) (*user.User, error) {
 	if raw.AddUserID == "" {
 		return nil, nil
@@ -95,6 +197,70 @@ func (u *UserOverride) Target(ctx context.Context, raw *override.UserOverride) (
 	return &tgt, nil
 }
 
+func (u *UserOverride) RecurrenceID(ctx context.Context, raw *override.UserOverride) (*string, error) {
+	if raw.RecurrenceID == "" {
+		return nil, nil
+	}
+	return &raw.RecurrenceID, nil
+}
+
+func (u *UserOverride) Recurrence(ctx context.Context, raw *override.UserOverride) (*override.Recurrence, error) {
+	if raw.RecurrenceID == "" {
+		return nil, nil
+	}
+	return u.OverrideStore.FindOneUserOverrideRecurrence(ctx, raw.RecurrenceID)
+}
+
+type UserOverrideRecurrence App
+
+func (a *App) UserOverrideRecurrence() graphql2.UserOverrideRecurrenceResolver {
+	return (*UserOverrideRecurrence)(a)
+}
+
+func (r *UserOverrideRecurrence) WeekdayFilter(ctx context.Context, raw *override.Recurrence) (timeutil.WeekdayFilter, error) {
+	return raw.Weekdays, nil
+}
+
+func (r *UserOverrideRecurrence) Target(ctx context.Context, raw *override.Recurrence) (*assignment.RawTarget, error) {
+	tgt := assignment.NewRawTarget(raw.Target)
+	return &tgt, nil
+}
+
+func (m *Mutation) CreateUserOverrideRecurrence(ctx context.Context, input graphql2.CreateUserOverrideRecurrenceInput) (*override.Recurrence, error) {
+	r := &override.Recurrence{
+		Target:   assignment.ScheduleTarget(input.ScheduleID),
+		Start:    input.Start,
+		End:      input.End,
+		Weekdays: input.WeekdayFilter,
+		Until:    input.Until,
+	}
+	if input.AddUserID != nil {
+		r.AddUserID = *input.AddUserID
+	}
+	if input.RemoveUserID != nil {
+		r.RemoveUserID = *input.RemoveUserID
+	}
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		r, err = m.OverrideStore.CreateUserOverrideRecurrenceTx(ctx, tx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *Mutation) DeleteUserOverrideRecurrence(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.OverrideStore.DeleteUserOverrideRecurrenceTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (q *Query) UserOverrides(ctx context.Context, input *graphql2.UserOverrideSearchOptions) (conn *graphql2.UserOverrideConnection, err error) {
 	if input == nil {
 		input = &graphql2.UserOverrideSearchOptions{}
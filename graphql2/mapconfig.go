@@ -19,6 +19,8 @@ func MapConfigHints(cfg config.Hints) []ConfigHint {
 		{ID: "Twilio.MessageWebhookURL", Value: cfg.Twilio.MessageWebhookURL},
 		{ID: "Twilio.VoiceWebhookURL", Value: cfg.Twilio.VoiceWebhookURL},
 		{ID: "Slack.InteractivityResponseURL", Value: cfg.Slack.InteractivityResponseURL},
+		{ID: "Slack.SlashCommandURL", Value: cfg.Slack.SlashCommandURL},
+		{ID: "Telegram.WebhookURL", Value: cfg.Telegram.WebhookURL},
 	}
 }
 
@@ -34,10 +36,19 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableSMSLinks", Type: ConfigTypeBoolean, Description: "If set, SMS messages will not contain a URL pointing to GoAlert.", Value: fmt.Sprintf("%t", cfg.General.DisableSMSLinks)},
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
-		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "General.RestrictServiceManagement", Type: ConfigTypeBoolean, Description: "If set, non-admin users may only manage services (and their escalation policies, integration keys, heartbeat monitors, and maintenance windows) matching one of their label grants.", Value: fmt.Sprintf("%t", cfg.General.RestrictServiceManagement)},
+		{ID: "General.GraphQLMaxComplexity", Type: ConfigTypeInteger, Description: "Maximum estimated cost of a GraphQL operation, where list and connection fields are weighted by their expected size. Operations exceeding it are rejected before execution (0 means no limit).", Value: fmt.Sprintf("%d", cfg.General.GraphQLMaxComplexity)},
+		{ID: "General.ContactMethodFailureLimit", Type: ConfigTypeInteger, Description: "Contact methods are disabled after this many consecutive failed deliveries, and the user is notified using another contact method (0 means never disable).", Value: fmt.Sprintf("%d", cfg.General.ContactMethodFailureLimit)},
+		{ID: "General.ContactMethodDisableMinutes", Type: ConfigTypeInteger, Description: "Contact methods disabled after repeated failed deliveries are re-enabled after this many minutes (0 means they stay disabled until the user re-enables them).", Value: fmt.Sprintf("%d", cfg.General.ContactMethodDisableMinutes)},
+		{ID: "General.DefaultTimeZone", Type: ConfigTypeString, Description: "IANA time zone (e.g. America/Chicago) used for timestamps in notifications to users without a time zone set, unless the alert's service has one. Defaults to UTC.", Value: cfg.General.DefaultTimeZone},
+		{ID: "General.MaxUserNotificationsPerHour", Type: ConfigTypeInteger, Description: "Maximum number of alert notifications sent to each user per hour, unless the user has their own limit set. Notifications for critical and fatal alerts are always sent. Additional notifications are held until the limit allows, and bundled by service (0 means no limit).", Value: fmt.Sprintf("%d", cfg.General.MaxUserNotificationsPerHour)},
+		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed and archived alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "Maintenance.AlertArchiveDays", Type: ConfigTypeInteger, Description: "Closed alerts will be moved to the archive after this many days, and remain available read-only (0 means disable archival). Should be less than AlertCleanupDays.", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertArchiveDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.GQLAPIKeyExpireWarningDays", Type: ConfigTypeInteger, Description: "GraphQL API keys will be flagged as expiring soon this many days before they expire (0 means disable).", Value: fmt.Sprintf("%d", cfg.Maintenance.GQLAPIKeyExpireWarningDays)},
+		{ID: "Maintenance.GQLAPIKeyUsageHistoryRows", Type: ConfigTypeInteger, Description: "Number of usage history entries to keep for each GraphQL API key (0 means disable usage history).", Value: fmt.Sprintf("%d", cfg.Maintenance.GQLAPIKeyUsageHistoryRows)},
 		{ID: "Auth.RefererURLs", Type: ConfigTypeStringList, Description: "Allowed referer URLs for auth and redirects.", Value: strings.Join(cfg.Auth.RefererURLs, "\n"), Deprecated: "Use --public-url flag instead, which takes precedence."},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
@@ -66,6 +77,7 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Slack.AccessToken", Type: ConfigTypeString, Description: "Slack app bot user OAuth access token (should start with xoxb-).", Value: cfg.Slack.AccessToken, Password: true},
 		{ID: "Slack.SigningSecret", Type: ConfigTypeString, Description: "Signing secret to verify requests from slack.", Value: cfg.Slack.SigningSecret, Password: true},
 		{ID: "Slack.InteractiveMessages", Type: ConfigTypeBoolean, Description: "Enable interactive messages (e.g. buttons).", Value: fmt.Sprintf("%t", cfg.Slack.InteractiveMessages)},
+		{ID: "Slack.SlashCommands", Type: ConfigTypeBoolean, Description: "Enable the slash command for listing, acknowledging, and closing alerts from Slack.", Value: fmt.Sprintf("%t", cfg.Slack.SlashCommands)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
 		{ID: "Twilio.VoiceName", Type: ConfigTypeString, Description: "The Twilio voice to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceName},
 		{ID: "Twilio.VoiceLanguage", Type: ConfigTypeString, Description: "The Twilio voice language to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceLanguage},
@@ -77,6 +89,9 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Twilio.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.Twilio.DisableTwoWaySMS)},
 		{ID: "Twilio.SMSCarrierLookup", Type: ConfigTypeBoolean, Description: "Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply.", Value: fmt.Sprintf("%t", cfg.Twilio.SMSCarrierLookup)},
 		{ID: "Twilio.SMSFromNumberOverride", Type: ConfigTypeStringList, Description: "List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number.", Value: strings.Join(cfg.Twilio.SMSFromNumberOverride, "\n")},
+		{ID: "Twilio.SMSFromNumberRegion", Type: ConfigTypeStringList, Description: "List of 'prefix=number' pairs, SMS messages to numbers starting with the provided country calling code (e.g. +44) will use the alternate From Number. The longest matching prefix is used, and carrier overrides take precedence.", Value: strings.Join(cfg.Twilio.SMSFromNumberRegion, "\n")},
+		{ID: "Twilio.SMSMaxLength", Type: ConfigTypeInteger, Description: "Maximum length of alert SMS messages, in characters. Summaries are shortened at a word boundary to fit, and the link to the full alert is kept. Defaults to 160 (a single segment) if unset.", Value: fmt.Sprintf("%d", cfg.Twilio.SMSMaxLength)},
+		{ID: "Twilio.SMSAllowMultipart", Type: ConfigTypeBoolean, Description: "Allows SMS messages longer than a single segment, up to SMS Max Length. Otherwise messages are limited to a single segment, unless the link and reply instructions alone do not fit.", Value: fmt.Sprintf("%t", cfg.Twilio.SMSAllowMultipart)},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "SMTP.Address", Type: ConfigTypeString, Description: "The server address to use for sending email. Port is optional and defaults to 465, or 25 if Disable TLS is set. Common ports are: 25 or 587 for STARTTLS (or unencrypted) and 465 for TLS.", Value: cfg.SMTP.Address},
@@ -86,6 +101,16 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.Password", Type: ConfigTypeString, Description: "Password for authentication.", Value: cfg.SMTP.Password, Password: true},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Webhook.RetryMaxAttempts", Type: ConfigTypeInteger, Description: "Maximum number of attempts to send a webhook notification before it is dead-lettered. Defaults to 4 if unset.", Value: fmt.Sprintf("%d", cfg.Webhook.RetryMaxAttempts)},
+		{ID: "Webhook.RetryBaseDelaySeconds", Type: ConfigTypeInteger, Description: "Delay before retrying a failed webhook notification, doubled after each attempt. Defaults to 15 if unset.", Value: fmt.Sprintf("%d", cfg.Webhook.RetryBaseDelaySeconds)},
+		{ID: "Webhook.RetryMaxDelaySeconds", Type: ConfigTypeInteger, Description: "Maximum delay between webhook notification attempts. Defaults to 600 if unset.", Value: fmt.Sprintf("%d", cfg.Webhook.RetryMaxDelaySeconds)},
+		{ID: "MSTeams.Enable", Type: ConfigTypeBoolean, Description: "Enables Microsoft Teams channels (via incoming webhook) as notification targets.", Value: fmt.Sprintf("%t", cfg.MSTeams.Enable)},
+		{ID: "PagerDuty.Enable", Type: ConfigTypeBoolean, Description: "Enables PagerDuty (via Events API v2 routing key) as an escalation target.", Value: fmt.Sprintf("%t", cfg.PagerDuty.Enable)},
+		{ID: "PagerDuty.ResolveOnClose", Type: ConfigTypeBoolean, Description: "Resolve the PagerDuty incident when the alert is closed in GoAlert.", Value: fmt.Sprintf("%t", cfg.PagerDuty.ResolveOnClose)},
+		{ID: "Telegram.Enable", Type: ConfigTypeBoolean, Description: "Enables Telegram as a contact method.", Value: fmt.Sprintf("%t", cfg.Telegram.Enable)},
+		{ID: "Telegram.BotUsername", Type: ConfigTypeString, Description: "Username of the Telegram bot (without the @), shown to users when linking a chat.", Value: cfg.Telegram.BotUsername},
+		{ID: "Telegram.BotToken", Type: ConfigTypeString, Description: "Bot API token provided by @BotFather.", Value: cfg.Telegram.BotToken, Password: true},
+		{ID: "Telegram.WebhookSecret", Type: ConfigTypeString, Description: "Secret token set when registering the bot webhook, used to verify updates from Telegram.", Value: cfg.Telegram.WebhookSecret, Password: true},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -103,10 +128,19 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableSMSLinks", Type: ConfigTypeBoolean, Description: "If set, SMS messages will not contain a URL pointing to GoAlert.", Value: fmt.Sprintf("%t", cfg.General.DisableSMSLinks)},
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
-		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "General.RestrictServiceManagement", Type: ConfigTypeBoolean, Description: "If set, non-admin users may only manage services (and their escalation policies, integration keys, heartbeat monitors, and maintenance windows) matching one of their label grants.", Value: fmt.Sprintf("%t", cfg.General.RestrictServiceManagement)},
+		{ID: "General.GraphQLMaxComplexity", Type: ConfigTypeInteger, Description: "Maximum estimated cost of a GraphQL operation, where list and connection fields are weighted by their expected size. Operations exceeding it are rejected before execution (0 means no limit).", Value: fmt.Sprintf("%d", cfg.General.GraphQLMaxComplexity)},
+		{ID: "General.ContactMethodFailureLimit", Type: ConfigTypeInteger, Description: "Contact methods are disabled after this many consecutive failed deliveries, and the user is notified using another contact method (0 means never disable).", Value: fmt.Sprintf("%d", cfg.General.ContactMethodFailureLimit)},
+		{ID: "General.ContactMethodDisableMinutes", Type: ConfigTypeInteger, Description: "Contact methods disabled after repeated failed deliveries are re-enabled after this many minutes (0 means they stay disabled until the user re-enables them).", Value: fmt.Sprintf("%d", cfg.General.ContactMethodDisableMinutes)},
+		{ID: "General.DefaultTimeZone", Type: ConfigTypeString, Description: "IANA time zone (e.g. America/Chicago) used for timestamps in notifications to users without a time zone set, unless the alert's service has one. Defaults to UTC.", Value: cfg.General.DefaultTimeZone},
+		{ID: "General.MaxUserNotificationsPerHour", Type: ConfigTypeInteger, Description: "Maximum number of alert notifications sent to each user per hour, unless the user has their own limit set. Notifications for critical and fatal alerts are always sent. Additional notifications are held until the limit allows, and bundled by service (0 means no limit).", Value: fmt.Sprintf("%d", cfg.General.MaxUserNotificationsPerHour)},
+		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed and archived alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "Maintenance.AlertArchiveDays", Type: ConfigTypeInteger, Description: "Closed alerts will be moved to the archive after this many days, and remain available read-only (0 means disable archival). Should be less than AlertCleanupDays.", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertArchiveDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.GQLAPIKeyExpireWarningDays", Type: ConfigTypeInteger, Description: "GraphQL API keys will be flagged as expiring soon this many days before they expire (0 means disable).", Value: fmt.Sprintf("%d", cfg.Maintenance.GQLAPIKeyExpireWarningDays)},
+		{ID: "Maintenance.GQLAPIKeyUsageHistoryRows", Type: ConfigTypeInteger, Description: "Number of usage history entries to keep for each GraphQL API key (0 means disable usage history).", Value: fmt.Sprintf("%d", cfg.Maintenance.GQLAPIKeyUsageHistoryRows)},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
@@ -119,6 +153,10 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "MSTeams.Enable", Type: ConfigTypeBoolean, Description: "Enables Microsoft Teams channels (via incoming webhook) as notification targets.", Value: fmt.Sprintf("%t", cfg.MSTeams.Enable)},
+		{ID: "PagerDuty.Enable", Type: ConfigTypeBoolean, Description: "Enables PagerDuty (via Events API v2 routing key) as an escalation target.", Value: fmt.Sprintf("%t", cfg.PagerDuty.Enable)},
+		{ID: "Telegram.Enable", Type: ConfigTypeBoolean, Description: "Enables Telegram as a contact method.", Value: fmt.Sprintf("%t", cfg.Telegram.Enable)},
+		{ID: "Telegram.BotUsername", Type: ConfigTypeString, Description: "Username of the Telegram bot (without the @), shown to users when linking a chat.", Value: cfg.Telegram.BotUsername},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -188,12 +226,50 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.General.DisableCalendarSubscriptions = val
+		case "General.RestrictServiceManagement":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.RestrictServiceManagement = val
+		case "General.GraphQLMaxComplexity":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.GraphQLMaxComplexity = val
+		case "General.ContactMethodFailureLimit":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.ContactMethodFailureLimit = val
+		case "General.ContactMethodDisableMinutes":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.ContactMethodDisableMinutes = val
+		case "General.DefaultTimeZone":
+			cfg.General.DefaultTimeZone = v.Value
+		case "General.MaxUserNotificationsPerHour":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.MaxUserNotificationsPerHour = val
 		case "Maintenance.AlertCleanupDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
 				return cfg, err
 			}
 			cfg.Maintenance.AlertCleanupDays = val
+		case "Maintenance.AlertArchiveDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.AlertArchiveDays = val
 		case "Maintenance.AlertAutoCloseDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
@@ -212,6 +288,18 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Maintenance.ScheduleCleanupDays = val
+		case "Maintenance.GQLAPIKeyExpireWarningDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.GQLAPIKeyExpireWarningDays = val
+		case "Maintenance.GQLAPIKeyUsageHistoryRows":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.GQLAPIKeyUsageHistoryRows = val
 		case "Auth.RefererURLs":
 			cfg.Auth.RefererURLs = parseStringList(v.Value)
 		case "Auth.DisableBasic":
@@ -300,6 +388,12 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Slack.InteractiveMessages = val
+		case "Slack.SlashCommands":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Slack.SlashCommands = val
 		case "Twilio.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -334,6 +428,20 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Twilio.SMSCarrierLookup = val
 		case "Twilio.SMSFromNumberOverride":
 			cfg.Twilio.SMSFromNumberOverride = parseStringList(v.Value)
+		case "Twilio.SMSFromNumberRegion":
+			cfg.Twilio.SMSFromNumberRegion = parseStringList(v.Value)
+		case "Twilio.SMSMaxLength":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Twilio.SMSMaxLength = val
+		case "Twilio.SMSAllowMultipart":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Twilio.SMSAllowMultipart = val
 		case "SMTP.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -368,6 +476,54 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Webhook.Enable = val
 		case "Webhook.AllowedURLs":
 			cfg.Webhook.AllowedURLs = parseStringList(v.Value)
+		case "Webhook.RetryMaxAttempts":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Webhook.RetryMaxAttempts = val
+		case "Webhook.RetryBaseDelaySeconds":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Webhook.RetryBaseDelaySeconds = val
+		case "Webhook.RetryMaxDelaySeconds":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Webhook.RetryMaxDelaySeconds = val
+		case "MSTeams.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MSTeams.Enable = val
+		case "PagerDuty.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.PagerDuty.Enable = val
+		case "PagerDuty.ResolveOnClose":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.PagerDuty.ResolveOnClose = val
+		case "Telegram.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Telegram.Enable = val
+		case "Telegram.BotUsername":
+			cfg.Telegram.BotUsername = v.Value
+		case "Telegram.BotToken":
+			cfg.Telegram.BotToken = v.Value
+		case "Telegram.WebhookSecret":
+			cfg.Telegram.WebhookSecret = v.Value
 		case "Feedback.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -11,12 +11,14 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/auditlog"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
@@ -27,6 +29,11 @@ import (
 	"github.com/target/goalert/util/timeutil"
 )
 
+type AddAlertNoteInput struct {
+	AlertID int    `json:"alertID"`
+	Note    string `json:"note"`
+}
+
 type AlertConnection struct {
 	Nodes    []alert.Alert `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -37,16 +44,57 @@ type AlertDataPoint struct {
 	AlertCount int       `json:"alertCount"`
 }
 
+type AlertEvent struct {
+	Type  AlertEventType `json:"type"`
+	Alert *alert.Alert   `json:"alert"`
+}
+
+type AlertFeedbackStatsInput struct {
+	ServiceIDs []string  `json:"serviceIDs"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+}
+
 type AlertLogEntryConnection struct {
 	Nodes    []alertlog.Entry `json:"nodes"`
 	PageInfo *PageInfo        `json:"pageInfo"`
 }
 
+type AlertLogNote struct {
+	Text     string     `json:"text"`
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	Editable bool       `json:"editable"`
+}
+
+type AlertMetadata struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type AlertMetadataInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 type AlertMetricsOptions struct {
 	RInterval         timeutil.ISORInterval `json:"rInterval"`
 	FilterByServiceID []string              `json:"filterByServiceID,omitempty"`
 }
 
+type AlertNotification struct {
+	ID            string                  `json:"id"`
+	CreatedAt     time.Time               `json:"createdAt"`
+	SentAt        *time.Time              `json:"sentAt,omitempty"`
+	Type          string                  `json:"type"`
+	UserID        *string                 `json:"userID,omitempty"`
+	UserName      *string                 `json:"userName,omitempty"`
+	Destination   string                  `json:"destination"`
+	Channel       string                  `json:"channel"`
+	Status        AlertNotificationStatus `json:"status"`
+	StatusDetails string                  `json:"statusDetails"`
+	ProviderID    *string                 `json:"providerID,omitempty"`
+}
+
 type AlertPendingNotification struct {
 	Destination string `json:"destination"`
 }
@@ -57,19 +105,61 @@ type AlertRecentEventsOptions struct {
 }
 
 type AlertSearchOptions struct {
-	FilterByStatus    []AlertStatus    `json:"filterByStatus,omitempty"`
-	FilterByServiceID []string         `json:"filterByServiceID,omitempty"`
-	Search            *string          `json:"search,omitempty"`
-	First             *int             `json:"first,omitempty"`
-	After             *string          `json:"after,omitempty"`
-	FavoritesOnly     *bool            `json:"favoritesOnly,omitempty"`
-	IncludeNotified   *bool            `json:"includeNotified,omitempty"`
-	Omit              []int            `json:"omit,omitempty"`
-	Sort              *AlertSearchSort `json:"sort,omitempty"`
-	CreatedBefore     *time.Time       `json:"createdBefore,omitempty"`
-	NotCreatedBefore  *time.Time       `json:"notCreatedBefore,omitempty"`
-	ClosedBefore      *time.Time       `json:"closedBefore,omitempty"`
-	NotClosedBefore   *time.Time       `json:"notClosedBefore,omitempty"`
+	FilterByStatus      []AlertStatus    `json:"filterByStatus,omitempty"`
+	FilterBySeverity    []alert.Severity `json:"filterBySeverity,omitempty"`
+	FilterByServiceID   []string         `json:"filterByServiceID,omitempty"`
+	Search              *string          `json:"search,omitempty"`
+	First               *int             `json:"first,omitempty"`
+	After               *string          `json:"after,omitempty"`
+	FavoritesOnly       *bool            `json:"favoritesOnly,omitempty"`
+	IncludeNotified     *bool            `json:"includeNotified,omitempty"`
+	Omit                []int            `json:"omit,omitempty"`
+	Sort                *AlertSearchSort `json:"sort,omitempty"`
+	CreatedBefore       *time.Time       `json:"createdBefore,omitempty"`
+	NotCreatedBefore    *time.Time       `json:"notCreatedBefore,omitempty"`
+	ClosedBefore        *time.Time       `json:"closedBefore,omitempty"`
+	NotClosedBefore     *time.Time       `json:"notClosedBefore,omitempty"`
+	EscalationExhausted *bool            `json:"escalationExhausted,omitempty"`
+	CorrelationKey      *string          `json:"correlationKey,omitempty"`
+}
+
+type ArchivedAlertConnection struct {
+	Nodes    []alert.ArchivedAlert `json:"nodes"`
+	PageInfo *PageInfo             `json:"pageInfo"`
+}
+
+type ArchivedAlertSearchOptions struct {
+	FilterByServiceID []string `json:"filterByServiceID,omitempty"`
+	Search            *string  `json:"search,omitempty"`
+	First             *int     `json:"first,omitempty"`
+	After             *string  `json:"after,omitempty"`
+}
+
+type AssignAlertInput struct {
+	AlertID                int    `json:"alertID"`
+	UserID                 string `json:"userID"`
+	PauseEscalationMinutes *int   `json:"pauseEscalationMinutes,omitempty"`
+}
+
+type AuditLogChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type AuditLogConnection struct {
+	Nodes    []auditlog.Entry `json:"nodes"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type AuditLogSearchOptions struct {
+	FilterByActorID    *string    `json:"filterByActorID,omitempty"`
+	FilterByEntityType *string    `json:"filterByEntityType,omitempty"`
+	FilterByEntityID   *string    `json:"filterByEntityID,omitempty"`
+	CreatedBefore      *time.Time `json:"createdBefore,omitempty"`
+	NotCreatedBefore   *time.Time `json:"notCreatedBefore,omitempty"`
+	First              *int       `json:"first,omitempty"`
+	After              *string    `json:"after,omitempty"`
 }
 
 type AuthSubjectConnection struct {
@@ -92,6 +182,18 @@ type ClearTemporarySchedulesInput struct {
 	End        time.Time `json:"end"`
 }
 
+type CloneEscalationPolicyInput struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Favorite *bool  `json:"favorite,omitempty"`
+}
+
+type CloseAllAlertsByServiceInput struct {
+	ServiceID   string             `json:"serviceID"`
+	Confirm     string             `json:"confirm"`
+	CloseReason *alert.CloseReason `json:"closeReason,omitempty"`
+}
+
 type ConfigHint struct {
 	ID    string `json:"id"`
 	Value string `json:"value"`
@@ -112,10 +214,13 @@ type ConfigValueInput struct {
 }
 
 type CreateAlertInput struct {
-	Summary   string  `json:"summary"`
-	Details   *string `json:"details,omitempty"`
-	ServiceID string  `json:"serviceID"`
-	Sanitize  *bool   `json:"sanitize,omitempty"`
+	Summary        string               `json:"summary"`
+	Details        *string              `json:"details,omitempty"`
+	ServiceID      string               `json:"serviceID"`
+	Sanitize       *bool                `json:"sanitize,omitempty"`
+	Severity       *alert.Severity      `json:"severity,omitempty"`
+	Meta           []AlertMetadataInput `json:"meta,omitempty"`
+	CorrelationKey *string              `json:"correlationKey,omitempty"`
 }
 
 type CreateBasicAuthInput struct {
@@ -124,20 +229,41 @@ type CreateBasicAuthInput struct {
 	UserID   string `json:"userID"`
 }
 
+type CreateBusinessHoursInput struct {
+	Name          string                 `json:"name"`
+	Description   *string                `json:"description,omitempty"`
+	TimeZone      string                 `json:"timeZone"`
+	WeekdayFilter timeutil.WeekdayFilter `json:"weekdayFilter"`
+	Start         timeutil.Clock         `json:"start"`
+	End           timeutil.Clock         `json:"end"`
+}
+
 type CreateEscalationPolicyInput struct {
-	Name        string                            `json:"name"`
-	Description *string                           `json:"description,omitempty"`
-	Repeat      *int                              `json:"repeat,omitempty"`
-	Favorite    *bool                             `json:"favorite,omitempty"`
-	Steps       []CreateEscalationPolicyStepInput `json:"steps,omitempty"`
+	Name              string                            `json:"name"`
+	Description       *string                           `json:"description,omitempty"`
+	Repeat            *int                              `json:"repeat,omitempty"`
+	MaxNotifications  *int                              `json:"maxNotifications,omitempty"`
+	AckTimeoutMinutes *int                              `json:"ackTimeoutMinutes,omitempty"`
+	Favorite          *bool                             `json:"favorite,omitempty"`
+	Steps             []CreateEscalationPolicyStepInput `json:"steps,omitempty"`
 }
 
 type CreateEscalationPolicyStepInput struct {
-	EscalationPolicyID *string                `json:"escalationPolicyID,omitempty"`
-	DelayMinutes       int                    `json:"delayMinutes"`
-	Targets            []assignment.RawTarget `json:"targets,omitempty"`
-	NewRotation        *CreateRotationInput   `json:"newRotation,omitempty"`
-	NewSchedule        *CreateScheduleInput   `json:"newSchedule,omitempty"`
+	EscalationPolicyID     *string                        `json:"escalationPolicyID,omitempty"`
+	DelayMinutes           int                            `json:"delayMinutes"`
+	OffHoursDelayMinutes   *int                           `json:"offHoursDelayMinutes,omitempty"`
+	DelayBusinessHoursID   *string                        `json:"delayBusinessHoursID,omitempty"`
+	AssignmentStrategy     *escalation.AssignmentStrategy `json:"assignmentStrategy,omitempty"`
+	MinSeverity            *alert.Severity                `json:"minSeverity,omitempty"`
+	LowUrgency             *bool                          `json:"lowUrgency,omitempty"`
+	ConferenceBridge       *bool                          `json:"conferenceBridge,omitempty"`
+	RoutingBusinessHoursID *string                        `json:"routingBusinessHoursID,omitempty"`
+	RoutingWebhookURL      *string                        `json:"routingWebhookURL,omitempty"`
+	Targets                []assignment.RawTarget         `json:"targets,omitempty"`
+	InHoursTargets         []assignment.RawTarget         `json:"inHoursTargets,omitempty"`
+	AfterHoursTargets      []assignment.RawTarget         `json:"afterHoursTargets,omitempty"`
+	NewRotation            *CreateRotationInput           `json:"newRotation,omitempty"`
+	NewSchedule            *CreateScheduleInput           `json:"newSchedule,omitempty"`
 }
 
 type CreateGQLAPIKeyInput struct {
@@ -146,18 +272,49 @@ type CreateGQLAPIKeyInput struct {
 	AllowedFields []string  `json:"allowedFields"`
 	ExpiresAt     time.Time `json:"expiresAt"`
 	Role          UserRole  `json:"role"`
+	ServiceIDs    []string  `json:"serviceIDs,omitempty"`
+	AllowedCIDRs  []string  `json:"allowedCIDRs,omitempty"`
+	ReadOnly      *bool     `json:"readOnly,omitempty"`
+	RateLimit     *int      `json:"rateLimit,omitempty"`
+	MaxComplexity *int      `json:"maxComplexity,omitempty"`
 }
 
 type CreateHeartbeatMonitorInput struct {
 	ServiceID      *string `json:"serviceID,omitempty"`
 	Name           string  `json:"name"`
 	TimeoutMinutes int     `json:"timeoutMinutes"`
+	WarningMinutes *int    `json:"warningMinutes,omitempty"`
+}
+
+type CreateIncidentCorrelationRuleInput struct {
+	Name          string `json:"name"`
+	Key           string `json:"key"`
+	WindowMinutes int    `json:"windowMinutes"`
+	GroupedAck    *bool  `json:"groupedAck,omitempty"`
+}
+
+type CreateIncidentInput struct {
+	Summary    string `json:"summary"`
+	AlertIDs   []int  `json:"alertIDs"`
+	GroupedAck *bool  `json:"groupedAck,omitempty"`
 }
 
 type CreateIntegrationKeyInput struct {
-	ServiceID *string            `json:"serviceID,omitempty"`
-	Type      IntegrationKeyType `json:"type"`
-	Name      string             `json:"name"`
+	ServiceID          *string                          `json:"serviceID,omitempty"`
+	Type               IntegrationKeyType               `json:"type"`
+	Name               string                           `json:"name"`
+	Routing            *IntegrationKeyRoutingInput      `json:"routing,omitempty"`
+	AlertRateLimit     *int                             `json:"alertRateLimit,omitempty"`
+	DedupWindowMinutes *int                             `json:"dedupWindowMinutes,omitempty"`
+	FieldMapping       *IntegrationKeyFieldMappingInput `json:"fieldMapping,omitempty"`
+	DefaultLabels      []IntegrationKeyLabelInput       `json:"defaultLabels,omitempty"`
+}
+
+type CreateMaintenanceWindowInput struct {
+	ServiceID   string    `json:"serviceID"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Description *string   `json:"description,omitempty"`
 }
 
 type CreateRotationInput struct {
@@ -169,6 +326,7 @@ type CreateRotationInput struct {
 	Type        rotation.Type `json:"type"`
 	ShiftLength *int          `json:"shiftLength,omitempty"`
 	UserIDs     []string      `json:"userIDs,omitempty"`
+	UserWeights []int         `json:"userWeights,omitempty"`
 }
 
 type CreateScheduleInput struct {
@@ -181,14 +339,20 @@ type CreateScheduleInput struct {
 }
 
 type CreateServiceInput struct {
-	Name                 string                        `json:"name"`
-	Description          *string                       `json:"description,omitempty"`
-	Favorite             *bool                         `json:"favorite,omitempty"`
-	EscalationPolicyID   *string                       `json:"escalationPolicyID,omitempty"`
-	NewEscalationPolicy  *CreateEscalationPolicyInput  `json:"newEscalationPolicy,omitempty"`
-	NewIntegrationKeys   []CreateIntegrationKeyInput   `json:"newIntegrationKeys,omitempty"`
-	Labels               []SetLabelInput               `json:"labels,omitempty"`
-	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors,omitempty"`
+	Name                     string                        `json:"name"`
+	Description              *string                       `json:"description,omitempty"`
+	Favorite                 *bool                         `json:"favorite,omitempty"`
+	EscalationPolicyID       *string                       `json:"escalationPolicyID,omitempty"`
+	NewEscalationPolicy      *CreateEscalationPolicyInput  `json:"newEscalationPolicy,omitempty"`
+	NewIntegrationKeys       []CreateIntegrationKeyInput   `json:"newIntegrationKeys,omitempty"`
+	Labels                   []SetLabelInput               `json:"labels,omitempty"`
+	NewHeartbeatMonitors     []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors,omitempty"`
+	NotificationTemplate     *string                       `json:"notificationTemplate,omitempty"`
+	RunbookURL               *string                       `json:"runbookURL,omitempty"`
+	EnrichmentURL            *string                       `json:"enrichmentURL,omitempty"`
+	NotificationDelayMinutes *int                          `json:"notificationDelayMinutes,omitempty"`
+	RequireCloseReason       *bool                         `json:"requireCloseReason,omitempty"`
+	TimeZone                 *string                       `json:"timeZone,omitempty"`
 }
 
 type CreateUserCalendarSubscriptionInput struct {
@@ -196,6 +360,8 @@ type CreateUserCalendarSubscriptionInput struct {
 	ReminderMinutes []int  `json:"reminderMinutes,omitempty"`
 	ScheduleID      string `json:"scheduleID"`
 	Disabled        *bool  `json:"disabled,omitempty"`
+	FullSchedule    *bool  `json:"fullSchedule,omitempty"`
+	HorizonDays     *int   `json:"horizonDays,omitempty"`
 }
 
 type CreateUserContactMethodInput struct {
@@ -216,9 +382,12 @@ type CreateUserInput struct {
 }
 
 type CreateUserNotificationRuleInput struct {
-	UserID          *string `json:"userID,omitempty"`
-	ContactMethodID *string `json:"contactMethodID,omitempty"`
-	DelayMinutes    int     `json:"delayMinutes"`
+	UserID          *string                              `json:"userID,omitempty"`
+	ContactMethodID *string                              `json:"contactMethodID,omitempty"`
+	DelayMinutes    int                                  `json:"delayMinutes"`
+	QuietHours      *UserNotificationRuleQuietHoursInput `json:"quietHours,omitempty"`
+	Urgency         *UserNotificationRuleUrgency         `json:"urgency,omitempty"`
+	StopOnSuccess   *bool                                `json:"stopOnSuccess,omitempty"`
 }
 
 type CreateUserOverrideInput struct {
@@ -229,6 +398,16 @@ type CreateUserOverrideInput struct {
 	RemoveUserID *string   `json:"removeUserID,omitempty"`
 }
 
+type CreateUserOverrideRecurrenceInput struct {
+	ScheduleID    string                 `json:"scheduleID"`
+	Start         time.Time              `json:"start"`
+	End           time.Time              `json:"end"`
+	WeekdayFilter timeutil.WeekdayFilter `json:"weekdayFilter"`
+	Until         time.Time              `json:"until"`
+	AddUserID     *string                `json:"addUserID,omitempty"`
+	RemoveUserID  *string                `json:"removeUserID,omitempty"`
+}
+
 type CreatedGQLAPIKey struct {
 	ID    string `json:"id"`
 	Token string `json:"token"`
@@ -239,21 +418,24 @@ type DebugCarrierInfoInput struct {
 }
 
 type DebugMessage struct {
-	ID          string     `json:"id"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	Type        string     `json:"type"`
-	Status      string     `json:"status"`
-	UserID      *string    `json:"userID,omitempty"`
-	UserName    *string    `json:"userName,omitempty"`
-	Source      *string    `json:"source,omitempty"`
-	Destination string     `json:"destination"`
-	ServiceID   *string    `json:"serviceID,omitempty"`
-	ServiceName *string    `json:"serviceName,omitempty"`
-	AlertID     *int       `json:"alertID,omitempty"`
-	ProviderID  *string    `json:"providerID,omitempty"`
-	SentAt      *time.Time `json:"sentAt,omitempty"`
-	RetryCount  int        `json:"retryCount"`
+	ID             string                        `json:"id"`
+	CreatedAt      time.Time                     `json:"createdAt"`
+	UpdatedAt      time.Time                     `json:"updatedAt"`
+	Type           string                        `json:"type"`
+	Status         string                        `json:"status"`
+	UserID         *string                       `json:"userID,omitempty"`
+	UserName       *string                       `json:"userName,omitempty"`
+	Source         *string                       `json:"source,omitempty"`
+	Destination    string                        `json:"destination"`
+	ServiceID      *string                       `json:"serviceID,omitempty"`
+	ServiceName    *string                       `json:"serviceName,omitempty"`
+	AlertID        *int                          `json:"alertID,omitempty"`
+	ProviderID     *string                       `json:"providerID,omitempty"`
+	SentAt         *time.Time                    `json:"sentAt,omitempty"`
+	RetryCount     int                           `json:"retryCount"`
+	DeadLetteredAt *time.Time                    `json:"deadLetteredAt,omitempty"`
+	Attempts       []notification.MessageAttempt `json:"attempts"`
+	ReplayOfID     *string                       `json:"replayOfID,omitempty"`
 }
 
 type DebugMessageStatusInfo struct {
@@ -282,6 +464,16 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type DeleteRotationShadowInput struct {
+	RotationID string `json:"rotationID"`
+	UserID     string `json:"userID"`
+}
+
+type DeleteServiceWatcherInput struct {
+	ServiceID string `json:"serviceID"`
+	UserID    string `json:"userID"`
+}
+
 type EscalationPolicyConnection struct {
 	Nodes    []escalation.Policy `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -296,17 +488,43 @@ type EscalationPolicySearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst,omitempty"`
 }
 
+type EscalationPolicySimulationInput struct {
+	PolicyID    string          `json:"policyID"`
+	TriggerTime *time.Time      `json:"triggerTime,omitempty"`
+	Severity    *alert.Severity `json:"severity,omitempty"`
+}
+
+type EscalationPolicySimulationStep struct {
+	StepNumber int                                `json:"stepNumber"`
+	Repeat     int                                `json:"repeat"`
+	EscalateAt time.Time                          `json:"escalateAt"`
+	Targets    []EscalationPolicySimulationTarget `json:"targets"`
+}
+
+type EscalationPolicySimulationTarget struct {
+	Target *assignment.RawTarget `json:"target"`
+	Via    *assignment.RawTarget `json:"via"`
+}
+
 type GQLAPIKey struct {
-	ID            string          `json:"id"`
-	Name          string          `json:"name"`
-	Description   string          `json:"description"`
-	CreatedAt     time.Time       `json:"createdAt"`
-	CreatedBy     *user.User      `json:"createdBy,omitempty"`
-	UpdatedAt     time.Time       `json:"updatedAt"`
-	UpdatedBy     *user.User      `json:"updatedBy,omitempty"`
-	LastUsed      *GQLAPIKeyUsage `json:"lastUsed,omitempty"`
-	ExpiresAt     time.Time       `json:"expiresAt"`
-	AllowedFields []string        `json:"allowedFields"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	CreatedBy     *user.User       `json:"createdBy,omitempty"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	UpdatedBy     *user.User       `json:"updatedBy,omitempty"`
+	LastUsed      *GQLAPIKeyUsage  `json:"lastUsed,omitempty"`
+	UsageHistory  []GQLAPIKeyUsage `json:"usageHistory"`
+	ExpiresAt     time.Time        `json:"expiresAt"`
+	ExpiringSoon  bool             `json:"expiringSoon"`
+	AllowedFields []string         `json:"allowedFields"`
+	ServiceIDs    []string         `json:"serviceIDs"`
+	AllowedCIDRs  []string         `json:"allowedCIDRs"`
+	ReadOnly      bool             `json:"readOnly"`
+	RateLimit     int              `json:"rateLimit"`
+	MaxComplexity int              `json:"maxComplexity"`
+	StaleFields   []string         `json:"staleFields"`
 }
 
 type GQLAPIKeyUsage struct {
@@ -320,6 +538,24 @@ type IntegrationKeyConnection struct {
 	PageInfo *PageInfo                       `json:"pageInfo"`
 }
 
+type IntegrationKeyFieldMappingInput struct {
+	Summary        *string `json:"summary,omitempty"`
+	Details        *string `json:"details,omitempty"`
+	Dedup          *string `json:"dedup,omitempty"`
+	Severity       *string `json:"severity,omitempty"`
+	CorrelationKey *string `json:"correlationKey,omitempty"`
+}
+
+type IntegrationKeyLabelInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type IntegrationKeyRoutingInput struct {
+	LabelKey string `json:"labelKey"`
+	Field    string `json:"field"`
+}
+
 type IntegrationKeySearchOptions struct {
 	First  *int     `json:"first,omitempty"`
 	After  *string  `json:"after,omitempty"`
@@ -381,6 +617,7 @@ type MessageLogSearchOptions struct {
 	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`
 	Search        *string    `json:"search,omitempty"`
 	Omit          []string   `json:"omit,omitempty"`
+	DeadLettered  *bool      `json:"deadLettered,omitempty"`
 }
 
 type NotificationState struct {
@@ -403,11 +640,21 @@ type PhoneNumberInfo struct {
 	Error       string `json:"error"`
 }
 
+type ReplayMessageInput struct {
+	ID string `json:"id"`
+}
+
 type RotationConnection struct {
 	Nodes    []rotation.Rotation `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
 }
 
+type RotationFairnessInput struct {
+	Start          *time.Time            `json:"start,omitempty"`
+	End            *time.Time            `json:"end,omitempty"`
+	BucketDuration *timeutil.ISODuration `json:"bucketDuration,omitempty"`
+}
+
 type RotationSearchOptions struct {
 	First          *int     `json:"first,omitempty"`
 	After          *string  `json:"after,omitempty"`
@@ -454,6 +701,7 @@ type ScheduleRuleInput struct {
 	Start         *timeutil.Clock         `json:"start,omitempty"`
 	End           *timeutil.Clock         `json:"end,omitempty"`
 	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter,omitempty"`
+	Priority      *int                    `json:"priority,omitempty"`
 }
 
 type ScheduleSearchOptions struct {
@@ -480,6 +728,7 @@ type ScheduleTargetInput struct {
 
 type SendContactMethodVerificationInput struct {
 	ContactMethodID string `json:"contactMethodID"`
+	Voice           *bool  `json:"voice,omitempty"`
 }
 
 type ServiceConnection struct {
@@ -496,6 +745,12 @@ type ServiceSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst,omitempty"`
 }
 
+type SetAlertFeedbackInput struct {
+	AlertID     int                 `json:"alertID"`
+	Value       alert.FeedbackValue `json:"value"`
+	NoiseReason *string             `json:"noiseReason,omitempty"`
+}
+
 type SetAlertNoiseReasonInput struct {
 	AlertID     int    `json:"alertID"`
 	NoiseReason string `json:"noiseReason"`
@@ -506,17 +761,67 @@ type SetFavoriteInput struct {
 	Favorite bool                  `json:"favorite"`
 }
 
+type SetIntegrationKeyAlertRateLimitInput struct {
+	ID             string `json:"id"`
+	AlertRateLimit *int   `json:"alertRateLimit,omitempty"`
+}
+
+type SetIntegrationKeyDedupWindowInput struct {
+	ID                 string `json:"id"`
+	DedupWindowMinutes *int   `json:"dedupWindowMinutes,omitempty"`
+}
+
+type SetIntegrationKeyDefaultLabelsInput struct {
+	ID            string                     `json:"id"`
+	DefaultLabels []IntegrationKeyLabelInput `json:"defaultLabels"`
+}
+
+type SetIntegrationKeyFieldMappingInput struct {
+	ID           string                           `json:"id"`
+	FieldMapping *IntegrationKeyFieldMappingInput `json:"fieldMapping,omitempty"`
+}
+
 type SetLabelInput struct {
 	Target *assignment.RawTarget `json:"target,omitempty"`
 	Key    string                `json:"key"`
 	Value  string                `json:"value"`
 }
 
+type SetRotationShadowInput struct {
+	RotationID string    `json:"rotationID"`
+	UserID     string    `json:"userID"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+type SetScheduleFixedShiftsInput struct {
+	ScheduleID string                `json:"scheduleID"`
+	Start      time.Time             `json:"start"`
+	End        time.Time             `json:"end"`
+	Shifts     []schedule.FixedShift `json:"shifts"`
+}
+
+type SetScheduleHandoffNotificationInput struct {
+	ScheduleID      string `json:"scheduleID"`
+	LeadTimeMinutes *int   `json:"leadTimeMinutes,omitempty"`
+}
+
 type SetScheduleOnCallNotificationRulesInput struct {
 	ScheduleID string                        `json:"scheduleID"`
 	Rules      []OnCallNotificationRuleInput `json:"rules"`
 }
 
+type SetServicePausedInput struct {
+	ID       string `json:"id"`
+	Paused   bool   `json:"paused"`
+	Renotify *bool  `json:"renotify,omitempty"`
+}
+
+type SetServiceWatcherInput struct {
+	ServiceID       string `json:"serviceID"`
+	UserID          string `json:"userID"`
+	ContactMethodID string `json:"contactMethodID"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	ClearStart *time.Time            `json:"clearStart,omitempty"`
@@ -526,6 +831,33 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type SetUserDoNotDisturbInput struct {
+	UserID    string     `json:"userID"`
+	Enabled   bool       `json:"enabled"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type SetUserLabelGrantsInput struct {
+	UserID string           `json:"userID"`
+	Grants []label.Selector `json:"grants"`
+}
+
+type SetUserNotificationRuleOrderInput struct {
+	UserID  string   `json:"userID"`
+	RuleIDs []string `json:"ruleIDs"`
+}
+
+type SetUserUrgencyWindowInput struct {
+	UserID string                  `json:"userID"`
+	Window *UserUrgencyWindowInput `json:"window,omitempty"`
+}
+
+type SetWebhookSecretInput struct {
+	URL            string `json:"url"`
+	Secret         string `json:"secret"`
+	OverlapMinutes *int   `json:"overlapMinutes,omitempty"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -550,11 +882,31 @@ type SlackUserGroupSearchOptions struct {
 	Omit   []string `json:"omit,omitempty"`
 }
 
+type SnoozeAlertsInput struct {
+	AlertIDs        []int `json:"alertIDs"`
+	DurationMinutes int   `json:"durationMinutes"`
+}
+
 type StringConnection struct {
 	Nodes    []string  `json:"nodes"`
 	PageInfo *PageInfo `json:"pageInfo"`
 }
 
+type StuckMessageGroup struct {
+	Channel         string         `json:"channel"`
+	Destination     string         `json:"destination"`
+	Count           int            `json:"count"`
+	OldestCreatedAt time.Time      `json:"oldestCreatedAt"`
+	Messages        []DebugMessage `json:"messages"`
+}
+
+type StuckMessagesInput struct {
+	ThresholdMinutes *int       `json:"thresholdMinutes,omitempty"`
+	ServiceIDs       []string   `json:"serviceIDs,omitempty"`
+	CreatedAfter     *time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore    *time.Time `json:"createdBefore,omitempty"`
+}
+
 type SystemLimit struct {
 	ID          limit.ID `json:"id"`
 	Description string   `json:"description"`
@@ -566,6 +918,12 @@ type SystemLimitInput struct {
 	Value int      `json:"value"`
 }
 
+type TakeOnCallInput struct {
+	ServiceID       string  `json:"serviceID"`
+	DurationMinutes int     `json:"durationMinutes"`
+	ScheduleID      *string `json:"scheduleID,omitempty"`
+}
+
 type TimeSeriesBucket struct {
 	Start time.Time `json:"start"`
 	End   time.Time `json:"end"`
@@ -593,14 +951,28 @@ type TimeZoneSearchOptions struct {
 	Omit   []string `json:"omit,omitempty"`
 }
 
+type UpdateAlertNoteInput struct {
+	ID   int    `json:"id"`
+	Note string `json:"note"`
+}
+
+type UpdateAlertsByLabelInput struct {
+	LabelKey    string             `json:"labelKey"`
+	LabelValue  *string            `json:"labelValue,omitempty"`
+	NewStatus   AlertStatus        `json:"newStatus"`
+	CloseReason *alert.CloseReason `json:"closeReason,omitempty"`
+}
+
 type UpdateAlertsByServiceInput struct {
-	ServiceID string      `json:"serviceID"`
-	NewStatus AlertStatus `json:"newStatus"`
+	ServiceID   string             `json:"serviceID"`
+	NewStatus   AlertStatus        `json:"newStatus"`
+	CloseReason *alert.CloseReason `json:"closeReason,omitempty"`
 }
 
 type UpdateAlertsInput struct {
-	AlertIDs  []int       `json:"alertIDs"`
-	NewStatus AlertStatus `json:"newStatus"`
+	AlertIDs    []int              `json:"alertIDs"`
+	NewStatus   AlertStatus        `json:"newStatus"`
+	CloseReason *alert.CloseReason `json:"closeReason,omitempty"`
 }
 
 type UpdateBasicAuthInput struct {
@@ -609,18 +981,40 @@ type UpdateBasicAuthInput struct {
 	UserID      string  `json:"userID"`
 }
 
+type UpdateBusinessHoursInput struct {
+	ID            string                  `json:"id"`
+	Name          *string                 `json:"name,omitempty"`
+	Description   *string                 `json:"description,omitempty"`
+	TimeZone      *string                 `json:"timeZone,omitempty"`
+	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter,omitempty"`
+	Start         *timeutil.Clock         `json:"start,omitempty"`
+	End           *timeutil.Clock         `json:"end,omitempty"`
+}
+
 type UpdateEscalationPolicyInput struct {
-	ID          string   `json:"id"`
-	Name        *string  `json:"name,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Repeat      *int     `json:"repeat,omitempty"`
-	StepIDs     []string `json:"stepIDs,omitempty"`
+	ID                string   `json:"id"`
+	Name              *string  `json:"name,omitempty"`
+	Description       *string  `json:"description,omitempty"`
+	Repeat            *int     `json:"repeat,omitempty"`
+	MaxNotifications  *int     `json:"maxNotifications,omitempty"`
+	AckTimeoutMinutes *int     `json:"ackTimeoutMinutes,omitempty"`
+	StepIDs           []string `json:"stepIDs,omitempty"`
 }
 
 type UpdateEscalationPolicyStepInput struct {
-	ID           string                 `json:"id"`
-	DelayMinutes *int                   `json:"delayMinutes,omitempty"`
-	Targets      []assignment.RawTarget `json:"targets,omitempty"`
+	ID                     string                         `json:"id"`
+	DelayMinutes           *int                           `json:"delayMinutes,omitempty"`
+	OffHoursDelayMinutes   *int                           `json:"offHoursDelayMinutes,omitempty"`
+	DelayBusinessHoursID   *string                        `json:"delayBusinessHoursID,omitempty"`
+	AssignmentStrategy     *escalation.AssignmentStrategy `json:"assignmentStrategy,omitempty"`
+	MinSeverity            *alert.Severity                `json:"minSeverity,omitempty"`
+	LowUrgency             *bool                          `json:"lowUrgency,omitempty"`
+	ConferenceBridge       *bool                          `json:"conferenceBridge,omitempty"`
+	RoutingBusinessHoursID *string                        `json:"routingBusinessHoursID,omitempty"`
+	RoutingWebhookURL      *string                        `json:"routingWebhookURL,omitempty"`
+	Targets                []assignment.RawTarget         `json:"targets,omitempty"`
+	InHoursTargets         []assignment.RawTarget         `json:"inHoursTargets,omitempty"`
+	AfterHoursTargets      []assignment.RawTarget         `json:"afterHoursTargets,omitempty"`
 }
 
 type UpdateGQLAPIKeyInput struct {
@@ -633,18 +1027,29 @@ type UpdateHeartbeatMonitorInput struct {
 	ID             string  `json:"id"`
 	Name           *string `json:"name,omitempty"`
 	TimeoutMinutes *int    `json:"timeoutMinutes,omitempty"`
+	WarningMinutes *int    `json:"warningMinutes,omitempty"`
+}
+
+type UpdateIncidentInput struct {
+	ID             string  `json:"id"`
+	Summary        *string `json:"summary,omitempty"`
+	GroupedAck     *bool   `json:"groupedAck,omitempty"`
+	AddAlertIDs    []int   `json:"addAlertIDs,omitempty"`
+	RemoveAlertIDs []int   `json:"removeAlertIDs,omitempty"`
 }
 
 type UpdateRotationInput struct {
-	ID              string         `json:"id"`
-	Name            *string        `json:"name,omitempty"`
-	Description     *string        `json:"description,omitempty"`
-	TimeZone        *string        `json:"timeZone,omitempty"`
-	Start           *time.Time     `json:"start,omitempty"`
-	Type            *rotation.Type `json:"type,omitempty"`
-	ShiftLength     *int           `json:"shiftLength,omitempty"`
-	ActiveUserIndex *int           `json:"activeUserIndex,omitempty"`
-	UserIDs         []string       `json:"userIDs,omitempty"`
+	ID                 string         `json:"id"`
+	Name               *string        `json:"name,omitempty"`
+	Description        *string        `json:"description,omitempty"`
+	TimeZone           *string        `json:"timeZone,omitempty"`
+	Start              *time.Time     `json:"start,omitempty"`
+	Type               *rotation.Type `json:"type,omitempty"`
+	ShiftLength        *int           `json:"shiftLength,omitempty"`
+	ActiveUserIndex    *int           `json:"activeUserIndex,omitempty"`
+	UserIDs            []string       `json:"userIDs,omitempty"`
+	PreserveActiveUser *bool          `json:"preserveActiveUser,omitempty"`
+	UserWeights        []int          `json:"userWeights,omitempty"`
 }
 
 type UpdateScheduleInput struct {
@@ -655,11 +1060,17 @@ type UpdateScheduleInput struct {
 }
 
 type UpdateServiceInput struct {
-	ID                   string     `json:"id"`
-	Name                 *string    `json:"name,omitempty"`
-	Description          *string    `json:"description,omitempty"`
-	EscalationPolicyID   *string    `json:"escalationPolicyID,omitempty"`
-	MaintenanceExpiresAt *time.Time `json:"maintenanceExpiresAt,omitempty"`
+	ID                       string     `json:"id"`
+	Name                     *string    `json:"name,omitempty"`
+	Description              *string    `json:"description,omitempty"`
+	EscalationPolicyID       *string    `json:"escalationPolicyID,omitempty"`
+	MaintenanceExpiresAt     *time.Time `json:"maintenanceExpiresAt,omitempty"`
+	NotificationTemplate     *string    `json:"notificationTemplate,omitempty"`
+	RunbookURL               *string    `json:"runbookURL,omitempty"`
+	EnrichmentURL            *string    `json:"enrichmentURL,omitempty"`
+	NotificationDelayMinutes *int       `json:"notificationDelayMinutes,omitempty"`
+	RequireCloseReason       *bool      `json:"requireCloseReason,omitempty"`
+	TimeZone                 *string    `json:"timeZone,omitempty"`
 }
 
 type UpdateUserCalendarSubscriptionInput struct {
@@ -667,6 +1078,8 @@ type UpdateUserCalendarSubscriptionInput struct {
 	Name            *string `json:"name,omitempty"`
 	ReminderMinutes []int   `json:"reminderMinutes,omitempty"`
 	Disabled        *bool   `json:"disabled,omitempty"`
+	FullSchedule    *bool   `json:"fullSchedule,omitempty"`
+	HorizonDays     *int    `json:"horizonDays,omitempty"`
 }
 
 type UpdateUserContactMethodInput struct {
@@ -681,9 +1094,16 @@ type UpdateUserInput struct {
 	Name                        *string   `json:"name,omitempty"`
 	Email                       *string   `json:"email,omitempty"`
 	Role                        *UserRole `json:"role,omitempty"`
+	TimeZone                    *string   `json:"timeZone,omitempty"`
+	MaxNotificationsPerHour     *int      `json:"maxNotificationsPerHour,omitempty"`
 	StatusUpdateContactMethodID *string   `json:"statusUpdateContactMethodID,omitempty"`
 }
 
+type UpdateUserNotificationRuleInput struct {
+	ID            string `json:"id"`
+	StopOnSuccess *bool  `json:"stopOnSuccess,omitempty"`
+}
+
 type UpdateUserOverrideInput struct {
 	ID           string     `json:"id"`
 	Start        *time.Time `json:"start,omitempty"`
@@ -697,6 +1117,26 @@ type UserConnection struct {
 	PageInfo *PageInfo   `json:"pageInfo"`
 }
 
+type UserNotificationRuleQuietHoursInput struct {
+	Start            timeutil.Clock `json:"start"`
+	End              timeutil.Clock `json:"end"`
+	AllowHighUrgency *bool          `json:"allowHighUrgency,omitempty"`
+}
+
+type UserOnCallShiftConnection struct {
+	Nodes    []oncall.UserShift `json:"nodes"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+	TimeZone string             `json:"timeZone"`
+}
+
+type UserOnCallShiftsInput struct {
+	UserID string     `json:"userID"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+	First  *int       `json:"first,omitempty"`
+	After  *string    `json:"after,omitempty"`
+}
+
 type UserOverrideConnection struct {
 	Nodes    []override.UserOverride `json:"nodes"`
 	PageInfo *PageInfo               `json:"pageInfo"`
@@ -733,11 +1173,108 @@ type UserSession struct {
 	LastAccessAt time.Time `json:"lastAccessAt"`
 }
 
+type UserUrgencyWindowInput struct {
+	Start timeutil.Clock `json:"start"`
+	End   timeutil.Clock `json:"end"`
+}
+
 type VerifyContactMethodInput struct {
 	ContactMethodID string `json:"contactMethodID"`
 	Code            int    `json:"code"`
 }
 
+type AlertEventType string
+
+const (
+	AlertEventTypeCreated      AlertEventType = "created"
+	AlertEventTypeAcknowledged AlertEventType = "acknowledged"
+	AlertEventTypeClosed       AlertEventType = "closed"
+	AlertEventTypeEscalated    AlertEventType = "escalated"
+)
+
+var AllAlertEventType = []AlertEventType{
+	AlertEventTypeCreated,
+	AlertEventTypeAcknowledged,
+	AlertEventTypeClosed,
+	AlertEventTypeEscalated,
+}
+
+func (e AlertEventType) IsValid() bool {
+	switch e {
+	case AlertEventTypeCreated, AlertEventTypeAcknowledged, AlertEventTypeClosed, AlertEventTypeEscalated:
+		return true
+	}
+	return false
+}
+
+func (e AlertEventType) String() string {
+	return string(e)
+}
+
+func (e *AlertEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertEventType", str)
+	}
+	return nil
+}
+
+func (e AlertEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AlertNotificationStatus string
+
+const (
+	AlertNotificationStatusQueued    AlertNotificationStatus = "queued"
+	AlertNotificationStatusBundled   AlertNotificationStatus = "bundled"
+	AlertNotificationStatusSent      AlertNotificationStatus = "sent"
+	AlertNotificationStatusDelivered AlertNotificationStatus = "delivered"
+	AlertNotificationStatusFailed    AlertNotificationStatus = "failed"
+)
+
+var AllAlertNotificationStatus = []AlertNotificationStatus{
+	AlertNotificationStatusQueued,
+	AlertNotificationStatusBundled,
+	AlertNotificationStatusSent,
+	AlertNotificationStatusDelivered,
+	AlertNotificationStatusFailed,
+}
+
+func (e AlertNotificationStatus) IsValid() bool {
+	switch e {
+	case AlertNotificationStatusQueued, AlertNotificationStatusBundled, AlertNotificationStatusSent, AlertNotificationStatusDelivered, AlertNotificationStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e AlertNotificationStatus) String() string {
+	return string(e)
+}
+
+func (e *AlertNotificationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertNotificationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertNotificationStatus", str)
+	}
+	return nil
+}
+
+func (e AlertNotificationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AlertSearchSort string
 
 const (
@@ -877,6 +1414,7 @@ const (
 	IntegrationKeyTypeSite24x7               IntegrationKeyType = "site24x7"
 	IntegrationKeyTypePrometheusAlertmanager IntegrationKeyType = "prometheusAlertmanager"
 	IntegrationKeyTypeEmail                  IntegrationKeyType = "email"
+	IntegrationKeyTypeOpsgenie               IntegrationKeyType = "opsgenie"
 )
 
 var AllIntegrationKeyType = []IntegrationKeyType{
@@ -885,11 +1423,12 @@ var AllIntegrationKeyType = []IntegrationKeyType{
 	IntegrationKeyTypeSite24x7,
 	IntegrationKeyTypePrometheusAlertmanager,
 	IntegrationKeyTypeEmail,
+	IntegrationKeyTypeOpsgenie,
 }
 
 func (e IntegrationKeyType) IsValid() bool {
 	switch e {
-	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail:
+	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail, IntegrationKeyTypeOpsgenie:
 		return true
 	}
 	return false
@@ -1096,6 +1635,47 @@ func (e StatusUpdateState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type UserNotificationRuleUrgency string
+
+const (
+	UserNotificationRuleUrgencyHigh UserNotificationRuleUrgency = "high"
+	UserNotificationRuleUrgencyLow  UserNotificationRuleUrgency = "low"
+)
+
+var AllUserNotificationRuleUrgency = []UserNotificationRuleUrgency{
+	UserNotificationRuleUrgencyHigh,
+	UserNotificationRuleUrgencyLow,
+}
+
+func (e UserNotificationRuleUrgency) IsValid() bool {
+	switch e {
+	case UserNotificationRuleUrgencyHigh, UserNotificationRuleUrgencyLow:
+		return true
+	}
+	return false
+}
+
+func (e UserNotificationRuleUrgency) String() string {
+	return string(e)
+}
+
+func (e *UserNotificationRuleUrgency) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserNotificationRuleUrgency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserNotificationRuleUrgency", str)
+	}
+	return nil
+}
+
+func (e UserNotificationRuleUrgency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type UserRole string
 
 const (
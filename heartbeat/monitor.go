@@ -15,6 +15,10 @@ type Monitor struct {
 	ServiceID string        `json:"service_id,omitempty"`
 	Timeout   time.Duration `json:"timeout,omitempty"`
 
+	// WarningTimeout, if non-zero, will generate a warning alert if a heartbeat
+	// is not received within it. It must be less than Timeout.
+	WarningTimeout time.Duration `json:"warning_timeout,omitempty"`
+
 	lastState     State
 	lastHeartbeat time.Time
 }
@@ -32,11 +36,15 @@ func (m Monitor) Normalize() (*Monitor, error) {
 		validate.IDName("Name", m.Name),
 		validate.Duration("Timeout", m.Timeout, 5*time.Minute, 9000*time.Hour),
 	)
+	if err == nil && m.WarningTimeout != 0 {
+		err = validate.Duration("WarningTimeout", m.WarningTimeout, time.Minute, m.Timeout-time.Minute)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	m.Timeout = m.Timeout.Truncate(time.Minute)
+	m.WarningTimeout = m.WarningTimeout.Truncate(time.Minute)
 
 	return &m, nil
 }
@@ -45,9 +53,10 @@ func (m *Monitor) scanFrom(scanFn func(...interface{}) error) error {
 	var (
 		t       sqlutil.NullTime
 		timeout pgtype.Interval
+		warning pgtype.Interval
 	)
 
-	err := scanFn(&m.ID, &m.Name, &m.ServiceID, &timeout, &m.lastState, &t)
+	err := scanFn(&m.ID, &m.Name, &m.ServiceID, &timeout, &warning, &m.lastState, &t)
 	if err != nil {
 		return err
 	}
@@ -57,7 +66,31 @@ func (m *Monitor) scanFrom(scanFn func(...interface{}) error) error {
 		return err
 	}
 
+	m.WarningTimeout = 0
+	if warning.Status == pgtype.Present {
+		err = warning.AssignTo(&m.WarningTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	m.lastHeartbeat = t.Time
 
 	return nil
 }
+
+// warningInterval returns the DB value for WarningTimeout, NULL if unset.
+func (m Monitor) warningInterval() (*pgtype.Interval, error) {
+	var warning pgtype.Interval
+	if m.WarningTimeout == 0 {
+		warning.Status = pgtype.Null
+		return &warning, nil
+	}
+
+	err := warning.Set(m.WarningTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &warning, nil
+}
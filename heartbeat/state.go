@@ -12,6 +12,10 @@ const (
 	// StateHealthy indicates a heartbeat was received within the past interval.
 	StateHealthy State = "healthy"
 
+	// StateWarning indicates a heartbeat has not been received within the warning
+	// interval, but the interval has not yet been exceeded.
+	StateWarning State = "warning"
+
 	// StateUnhealthy indicates a heartbeat has not been received since beyond the interval.
 	StateUnhealthy State = "unhealthy"
 )
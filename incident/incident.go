@@ -0,0 +1,38 @@
+package incident
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxAlerts is the maximum number of alerts that can be added to or removed from an
+// incident at once.
+const MaxAlerts = 100
+
+// An Incident groups related alerts (e.g., from services affected by the same root cause)
+// so they can be viewed together. Member alerts keep their own lifecycle unless
+// GroupedAck is set.
+type Incident struct {
+	ID      string
+	Summary string
+
+	// GroupedAck indicates that acknowledging any alert in the incident will acknowledge
+	// all other triggered alerts in it.
+	GroupedAck bool
+
+	// CorrelationRuleID is set if the incident was created by a correlation rule.
+	CorrelationRuleID string
+
+	CreatedAt time.Time
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (inc Incident) Normalize() (*Incident, error) {
+	err := validate.RequiredText("Summary", inc.Summary, 1, 255)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inc, nil
+}
@@ -0,0 +1,108 @@
+-- name: IncidentCreate :exec
+INSERT INTO incidents(id, summary, grouped_ack)
+    VALUES ($1, $2, $3);
+
+-- name: IncidentUpdate :exec
+UPDATE
+    incidents
+SET
+    summary = $2,
+    grouped_ack = $3
+WHERE
+    id = $1;
+
+-- name: IncidentAddAlerts :exec
+INSERT INTO incident_alerts(incident_id, alert_id)
+SELECT
+    @incident_id::uuid,
+    unnest(@alert_ids::bigint[])
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        incident_id = excluded.incident_id, added_at = now();
+
+-- name: IncidentRemoveAlerts :exec
+DELETE FROM incident_alerts
+WHERE incident_id = @incident_id
+    AND alert_id = ANY (@alert_ids::bigint[]);
+
+-- name: IncidentDeleteIfEmpty :exec
+DELETE FROM incidents i
+WHERE i.id = $1
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            incident_alerts ia
+        WHERE
+            ia.incident_id = i.id);
+
+-- name: IncidentFindOne :one
+SELECT
+    correlation_rule_id,
+    created_at,
+    grouped_ack,
+    id,
+    summary
+FROM
+    incidents
+WHERE
+    id = $1;
+
+-- name: IncidentFindByAlert :one
+SELECT
+    i.correlation_rule_id,
+    i.created_at,
+    i.grouped_ack,
+    i.id,
+    i.summary
+FROM
+    incidents i
+    JOIN incident_alerts ia ON ia.incident_id = i.id
+WHERE
+    ia.alert_id = $1;
+
+-- name: IncidentAlertIDs :many
+SELECT
+    alert_id
+FROM
+    incident_alerts
+WHERE
+    incident_id = $1
+ORDER BY
+    alert_id DESC
+LIMIT 500;
+
+-- name: IncidentRuleCreate :exec
+INSERT INTO incident_correlation_rules(id, name, key, window_minutes, grouped_ack, processed_alert_id)
+    VALUES ($1, $2, $3, $4, $5, coalesce((
+            SELECT
+                max(id)
+            FROM alerts), 0));
+
+-- name: IncidentRuleFindAll :many
+SELECT
+    grouped_ack,
+    id,
+    key,
+    name,
+    window_minutes
+FROM
+    incident_correlation_rules
+ORDER BY
+    name;
+
+-- name: IncidentRuleFindOne :one
+SELECT
+    grouped_ack,
+    id,
+    key,
+    name,
+    window_minutes
+FROM
+    incident_correlation_rules
+WHERE
+    id = $1;
+
+-- name: IncidentRuleDelete :exec
+DELETE FROM incident_correlation_rules
+WHERE id = $1;
@@ -0,0 +1,40 @@
+package incident
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxWindowMinutes is the longest correlation window a rule may use.
+const MaxWindowMinutes = 24 * 60
+
+// A CorrelationRule will automatically group new alerts that share the same value for Key
+// into an incident. The value is taken from the alert's metadata, falling back to the
+// label of the alert's service with the same key.
+//
+// An alert is added to an existing incident for the same value if the most recent alert
+// in that incident was created within WindowMinutes, otherwise a new incident is created
+// as soon as two or more un-grouped open alerts share the value within the window.
+type CorrelationRule struct {
+	ID            string
+	Name          string
+	Key           string
+	WindowMinutes int
+
+	// GroupedAck is used for incidents created by the rule.
+	GroupedAck bool
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (r CorrelationRule) Normalize() (*CorrelationRule, error) {
+	err := validate.Many(
+		validate.IDName("Name", r.Name),
+		validate.RequiredText("Key", r.Key, 1, alert.MaxMetaKeyLength),
+		validate.Range("WindowMinutes", r.WindowMinutes, 1, MaxWindowMinutes),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
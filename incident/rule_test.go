@@ -0,0 +1,41 @@
+package incident
+
+import (
+	"testing"
+)
+
+func TestCorrelationRule_Normalize(t *testing.T) {
+	test := func(valid bool, r CorrelationRule) {
+		name := "valid"
+		if !valid {
+			name = "invalid"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Logf("%+v", r)
+			_, err := r.Normalize()
+			if valid && err != nil {
+				t.Errorf("got %v; want nil", err)
+			} else if !valid && err == nil {
+				t.Errorf("got nil err; want non-nil")
+			}
+		})
+	}
+
+	valid := []CorrelationRule{
+		{Name: "By Region", Key: "region", WindowMinutes: 15},
+		{Name: "By Cluster", Key: "example.com/cluster", WindowMinutes: MaxWindowMinutes, GroupedAck: true},
+	}
+	invalid := []CorrelationRule{
+		{},
+		{Name: "By Region", WindowMinutes: 15},
+		{Name: "By Region", Key: "region"},
+		{Name: "By Region", Key: "region", WindowMinutes: MaxWindowMinutes + 1},
+		{Key: "region", WindowMinutes: 15},
+	}
+	for _, r := range valid {
+		test(true, r)
+	}
+	for _, r := range invalid {
+		test(false, r)
+	}
+}
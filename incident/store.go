@@ -0,0 +1,318 @@
+package incident
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages incidents and the correlation rules used to create them.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+func alertIDs(fname string, ids []int) ([]int64, error) {
+	err := validate.Range(fname, len(ids), 1, MaxAlerts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]int64, len(ids))
+	for i, id := range ids {
+		res[i] = int64(id)
+	}
+	return res, nil
+}
+
+// CreateTx will create a new incident containing the given alerts. Alerts that already
+// belong to another incident are moved to the new one.
+func (s *Store) CreateTx(ctx context.Context, dbtx gadb.DBTX, inc *Incident, alertIDList []int) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := inc.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := alertIDs("AlertIDs", alertIDList)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	q := gadb.New(dbtx)
+	err = q.IncidentCreate(ctx, gadb.IncidentCreateParams{
+		ID:         id,
+		Summary:    n.Summary,
+		GroupedAck: n.GroupedAck,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.IncidentAddAlerts(ctx, gadb.IncidentAddAlertsParams{IncidentID: id, AlertIds: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = id.String()
+	return n, nil
+}
+
+// UpdateTx will update the summary and grouped ack setting of an incident.
+func (s *Store) UpdateTx(ctx context.Context, dbtx gadb.DBTX, inc *Incident) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := inc.Normalize()
+	if err != nil {
+		return err
+	}
+	id, err := validate.ParseUUID("IncidentID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IncidentUpdate(ctx, gadb.IncidentUpdateParams{
+		ID:         id,
+		Summary:    n.Summary,
+		GroupedAck: n.GroupedAck,
+	})
+}
+
+// AddAlertsTx will add alerts to an incident, moving them from any other incident they belong to.
+func (s *Store) AddAlertsTx(ctx context.Context, dbtx gadb.DBTX, incidentID string, alertIDList []int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	id, err := validate.ParseUUID("IncidentID", incidentID)
+	if err != nil {
+		return err
+	}
+	ids, err := alertIDs("AddAlertIDs", alertIDList)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IncidentAddAlerts(ctx, gadb.IncidentAddAlertsParams{IncidentID: id, AlertIds: ids})
+}
+
+// RemoveAlertsTx will remove alerts from an incident. The incident is deleted once it has
+// no alerts left.
+func (s *Store) RemoveAlertsTx(ctx context.Context, dbtx gadb.DBTX, incidentID string, alertIDList []int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	id, err := validate.ParseUUID("IncidentID", incidentID)
+	if err != nil {
+		return err
+	}
+	ids, err := alertIDs("RemoveAlertIDs", alertIDList)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	err = q.IncidentRemoveAlerts(ctx, gadb.IncidentRemoveAlertsParams{IncidentID: id, AlertIds: ids})
+	if err != nil {
+		return err
+	}
+
+	return q.IncidentDeleteIfEmpty(ctx, id)
+}
+
+func fromRow(r gadb.IncidentFindOneRow) *Incident {
+	inc := &Incident{
+		ID:         r.ID.String(),
+		Summary:    r.Summary,
+		GroupedAck: r.GroupedAck,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.CorrelationRuleID.Valid {
+		inc.CorrelationRuleID = r.CorrelationRuleID.UUID.String()
+	}
+	return inc
+}
+
+// FindOne will return the incident with the given ID.
+func (s *Store) FindOne(ctx context.Context, id string) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	incID, err := validate.ParseUUID("IncidentID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IncidentFindOne(ctx, incID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("IncidentID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRow(row), nil
+}
+
+// FindOneByAlert will return the incident the alert belongs to, or nil if it is not part of one.
+func (s *Store) FindOneByAlert(ctx context.Context, alertID int) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IncidentFindByAlert(ctx, int64(alertID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRow(gadb.IncidentFindOneRow(row)), nil
+}
+
+// AlertIDs will return the IDs of the 500 most recent alerts in an incident, newest first.
+func (s *Store) AlertIDs(ctx context.Context, incidentID string) ([]int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := validate.ParseUUID("IncidentID", incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IncidentAlertIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(rows))
+	for i, r := range rows {
+		ids[i] = int(r)
+	}
+	return ids, nil
+}
+
+// CreateRuleTx will create a new correlation rule. Only alerts created after the rule
+// will be considered by it.
+func (s *Store) CreateRuleTx(ctx context.Context, dbtx gadb.DBTX, r *CorrelationRule) (*CorrelationRule, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	err = gadb.New(dbtx).IncidentRuleCreate(ctx, gadb.IncidentRuleCreateParams{
+		ID:            id,
+		Name:          n.Name,
+		Key:           n.Key,
+		WindowMinutes: int32(n.WindowMinutes),
+		GroupedAck:    n.GroupedAck,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = id.String()
+	return n, nil
+}
+
+// FindAllRules will return all correlation rules, ordered by name.
+func (s *Store) FindAllRules(ctx context.Context) ([]CorrelationRule, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IncidentRuleFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CorrelationRule, len(rows))
+	for i, r := range rows {
+		result[i] = CorrelationRule{
+			ID:            r.ID.String(),
+			Name:          r.Name,
+			Key:           r.Key,
+			WindowMinutes: int(r.WindowMinutes),
+			GroupedAck:    r.GroupedAck,
+		}
+	}
+
+	return result, nil
+}
+
+// FindOneRule will return the correlation rule with the given ID.
+func (s *Store) FindOneRule(ctx context.Context, id string) (*CorrelationRule, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleID, err := validate.ParseUUID("CorrelationRuleID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gadb.New(s.db).IncidentRuleFindOne(ctx, ruleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("CorrelationRuleID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &CorrelationRule{
+		ID:            r.ID.String(),
+		Name:          r.Name,
+		Key:           r.Key,
+		WindowMinutes: int(r.WindowMinutes),
+		GroupedAck:    r.GroupedAck,
+	}, nil
+}
+
+// DeleteRuleTx will delete a correlation rule. Incidents it created are kept.
+func (s *Store) DeleteRuleTx(ctx context.Context, dbtx gadb.DBTX, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	ruleID, err := validate.ParseUUID("CorrelationRuleID", id)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IncidentRuleDelete(ctx, ruleID)
+}
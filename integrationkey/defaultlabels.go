@@ -0,0 +1,77 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxDefaultLabels is the maximum number of default labels of an integration key.
+const MaxDefaultLabels = 16
+
+// DefaultLabels are key-value pairs added to the metadata of every alert created by an
+// integration key. Keys and values follow the same rules as service labels.
+type DefaultLabels map[string]string
+
+// Keys returns the label keys in sorted order.
+func (l DefaultLabels) Keys() []string {
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate will return an error if any key or value is invalid.
+func (l DefaultLabels) Validate() error {
+	if len(l) > MaxDefaultLabels {
+		return validation.NewFieldError("DefaultLabels", "too many labels")
+	}
+
+	for _, k := range l.Keys() {
+		err := validate.Many(
+			validate.LabelKey("DefaultLabels.Key", k),
+			validate.LabelValue("DefaultLabels.Value", l[k]),
+		)
+		if err != nil {
+			return err
+		}
+		if l[k] == "" {
+			return validation.NewFieldError("DefaultLabels.Value", "must not be empty")
+		}
+	}
+
+	return nil
+}
+
+func marshalDefaultLabels(l DefaultLabels) (pqtype.NullRawMessage, error) {
+	if len(l) == 0 {
+		return pqtype.NullRawMessage{}, nil
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		return pqtype.NullRawMessage{}, errors.Wrap(err, "marshal default labels")
+	}
+
+	return pqtype.NullRawMessage{RawMessage: data, Valid: true}, nil
+}
+
+func parseDefaultLabels(data pqtype.NullRawMessage) (DefaultLabels, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var l DefaultLabels
+	err := json.Unmarshal(data.RawMessage, &l)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal default labels")
+	}
+
+	return l, nil
+}
@@ -0,0 +1,199 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// FieldMapping configures how alert fields are extracted from an arbitrary JSON payload
+// sent to a generic integration key.
+//
+// Each field is a path expression, like `$.alert.labels.severity` or `$.events[0]["title"]`.
+// The leading `$.` is optional. Empty fields are not extracted.
+type FieldMapping struct {
+	Summary  string `json:"summary,omitempty"`
+	Details  string `json:"details,omitempty"`
+	Dedup    string `json:"dedup,omitempty"`
+	Severity string `json:"severity,omitempty"`
+
+	CorrelationKey string `json:"correlationKey,omitempty"`
+}
+
+// MappedFields contains the values extracted from a payload by a FieldMapping.
+//
+// A field is empty if it is not mapped, or if the path did not match the payload.
+type MappedFields struct {
+	Summary  string
+	Details  string
+	Dedup    string
+	Severity string
+
+	CorrelationKey string
+}
+
+// Normalize will validate each path of the FieldMapping.
+func (m FieldMapping) Normalize() (*FieldMapping, error) {
+	fields := []struct {
+		name string
+		path *string
+	}{
+		{"FieldMapping.Summary", &m.Summary},
+		{"FieldMapping.Details", &m.Details},
+		{"FieldMapping.Dedup", &m.Dedup},
+		{"FieldMapping.Severity", &m.Severity},
+		{"FieldMapping.CorrelationKey", &m.CorrelationKey},
+	}
+
+	var set bool
+	for _, f := range fields {
+		*f.path = strings.TrimSpace(*f.path)
+		if *f.path == "" {
+			continue
+		}
+		set = true
+
+		err := validate.ASCII(f.name, *f.path, 1, 255)
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseFieldPath(*f.path)
+		if err != nil {
+			return nil, validation.NewFieldError(f.name, err.Error())
+		}
+	}
+	if !set {
+		return nil, validation.NewFieldError("FieldMapping", "at least one field must be mapped")
+	}
+
+	return &m, nil
+}
+
+// Extract will return the values of each mapped field from the decoded JSON payload.
+func (m FieldMapping) Extract(payload interface{}) MappedFields {
+	return MappedFields{
+		Summary:  extractField(m.Summary, payload),
+		Details:  extractField(m.Details, payload),
+		Dedup:    extractField(m.Dedup, payload),
+		Severity: extractField(m.Severity, payload),
+
+		CorrelationKey: extractField(m.CorrelationKey, payload),
+	}
+}
+
+func extractField(path string, payload interface{}) string {
+	if path == "" {
+		return ""
+	}
+	p, err := parseFieldPath(path)
+	if err != nil {
+		return ""
+	}
+
+	return p.extract(payload)
+}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+type fieldPath []pathSegment
+
+func parseFieldPath(s string) (fieldPath, error) {
+	if s == "$" {
+		return fieldPath{}, nil
+	}
+	switch {
+	case strings.HasPrefix(s, "$."), strings.HasPrefix(s, "$["):
+		s = s[1:]
+	case strings.HasPrefix(s, "$"):
+		return nil, errors.New("expected '.' or '[' after '$'")
+	case strings.HasPrefix(s, "["):
+	default:
+		s = "." + s
+	}
+
+	var p fieldPath
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			if end == 0 {
+				return nil, errors.New("empty field name")
+			}
+			p = append(p, pathSegment{key: s[:end]})
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if strings.HasPrefix(s, `["`) {
+				end = strings.Index(s, `"]`)
+				if end == -1 {
+					return nil, errors.New("unterminated quoted field name")
+				}
+				if end == 2 {
+					return nil, errors.New("empty field name")
+				}
+				p = append(p, pathSegment{key: s[2:end]})
+				s = s[end+2:]
+				continue
+			}
+			if end == -1 {
+				return nil, errors.New("unterminated index")
+			}
+			idx, err := strconv.Atoi(s[1:end])
+			if err != nil || idx < 0 {
+				return nil, errors.New("index must be a non-negative integer")
+			}
+			p = append(p, pathSegment{index: idx, isIndex: true})
+			s = s[end+1:]
+		default:
+			return nil, errors.New("expected '.' or '[' at '" + s + "'")
+		}
+	}
+
+	return p, nil
+}
+
+// extract will return the value at the path as a string. Objects and arrays are
+// returned as JSON.
+func (p fieldPath) extract(v interface{}) string {
+	for _, seg := range p {
+		if seg.isIndex {
+			arr, ok := v.([]interface{})
+			if !ok || seg.index >= len(arr) {
+				return ""
+			}
+			v = arr[seg.index]
+			continue
+		}
+
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = obj[seg.key]
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,67 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMapping_Normalize(t *testing.T) {
+	valid := []FieldMapping{
+		{Summary: "$.alert.title"},
+		{Summary: "alert.title", Details: "$", Dedup: `$.labels["app.kubernetes.io/name"]`, Severity: "$.events[0].level"},
+	}
+	invalid := []FieldMapping{
+		{},
+		{Summary: "  "},
+		{Summary: "$.alert..title"},
+		{Summary: "$.events[-1]"},
+		{Summary: "$.events[0"},
+		{Summary: `$.labels["app`},
+		{Summary: "$alert"},
+	}
+	for _, m := range valid {
+		_, err := m.Normalize()
+		assert.NoErrorf(t, err, "valid: %#v", m)
+	}
+	for _, m := range invalid {
+		_, err := m.Normalize()
+		assert.Errorf(t, err, "invalid: %#v", m)
+	}
+}
+
+func TestFieldMapping_Extract(t *testing.T) {
+	var payload interface{}
+	err := json.Unmarshal([]byte(`{
+		"alert": {"title": "disk full", "count": 3},
+		"labels": {"app.kubernetes.io/name": "db", "cluster": "us-east-1"},
+		"events": [{"level": "critical"}]
+	}`), &payload)
+	require.NoError(t, err)
+
+	m := FieldMapping{
+		Summary:  "alert.title",
+		Details:  "$.alert",
+		Dedup:    `$.labels["app.kubernetes.io/name"]`,
+		Severity: "$.events[0].level",
+
+		CorrelationKey: "labels.cluster",
+	}
+	assert.Equal(t, MappedFields{
+		Summary:  "disk full",
+		Details:  `{"count":3,"title":"disk full"}`,
+		Dedup:    "db",
+		Severity: "critical",
+
+		CorrelationKey: "us-east-1",
+	}, m.Extract(payload))
+
+	m = FieldMapping{
+		Summary:  "$.alert.missing",
+		Details:  "$.alert.count",
+		Severity: "$.events[1].level",
+	}
+	assert.Equal(t, MappedFields{Details: "3"}, m.Extract(payload))
+}
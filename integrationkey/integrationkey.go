@@ -1,6 +1,7 @@
 package integrationkey
 
 import (
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -9,17 +10,85 @@ type IntegrationKey struct {
 	Name      string `json:"name"`
 	Type      Type   `json:"type"`
 	ServiceID string `json:"service_id"`
+
+	// Routing, if set, causes alerts to be created for the service whose label
+	// matches the payload instead of ServiceID.
+	Routing *Routing `json:"routing,omitempty"`
+
+	// FieldMapping, if set, extracts alert fields from arbitrary JSON payloads.
+	FieldMapping *FieldMapping `json:"field_mapping,omitempty"`
+
+	// AlertRateLimit is the maximum number of new alerts per minute, or zero for no limit.
+	AlertRateLimit int `json:"alert_rate_limit,omitempty"`
+
+	// DroppedAlertCount is the total number of alerts dropped due to AlertRateLimit.
+	DroppedAlertCount int `json:"dropped_alert_count,omitempty"`
+
+	// DedupWindowMinutes, if non-zero, limits de-duplication to events within this many
+	// minutes of the last occurrence of an open alert, or of the close of a closed alert.
+	// Zero keeps the default behavior of de-duplicating against any open alert.
+	DedupWindowMinutes int `json:"dedup_window_minutes,omitempty"`
+
+	// DefaultLabels are added to the metadata of alerts created by the key, unless
+	// the alert provides its own value for the same key.
+	DefaultLabels DefaultLabels `json:"default_labels,omitempty"`
+}
+
+// MaxAlertRateLimit is the highest configurable AlertRateLimit.
+const MaxAlertRateLimit = 10000
+
+// MaxDedupWindowMinutes is the longest configurable DedupWindowMinutes (7 days).
+const MaxDedupWindowMinutes = 7 * 24 * 60
+
+// Routing selects the service an alert is created for based on a field of the
+// incoming payload.
+//
+// The value of Field is compared against the value of the LabelKey label of each
+// service, and exactly one service must match.
+type Routing struct {
+	LabelKey string `json:"label_key"`
+	Field    string `json:"field"`
 }
 
 func (i IntegrationKey) Normalize() (*IntegrationKey, error) {
 	err := validate.Many(
 		validate.IDName("Name", i.Name),
 		validate.UUID("ServiceID", i.ServiceID),
-		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail, TypeOpsGenie),
+		validate.Range("AlertRateLimit", i.AlertRateLimit, 0, MaxAlertRateLimit),
+		validate.Range("DedupWindowMinutes", i.DedupWindowMinutes, 0, MaxDedupWindowMinutes),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if i.Routing != nil {
+		if i.Type != TypeGeneric {
+			return nil, validation.NewFieldError("Routing", "only supported for generic integration keys")
+		}
+		err = validate.Many(
+			validate.LabelKey("Routing.LabelKey", i.Routing.LabelKey),
+			validate.ASCII("Routing.Field", i.Routing.Field, 1, 255),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = i.DefaultLabels.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if i.FieldMapping != nil {
+		if i.Type != TypeGeneric {
+			return nil, validation.NewFieldError("FieldMapping", "only supported for generic integration keys")
+		}
+		i.FieldMapping, err = i.FieldMapping.Normalize()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &i, nil
 }
@@ -23,9 +23,23 @@ func TestIntegrationKey_Normalize(t *testing.T) {
 
 	valid := []IntegrationKey{
 		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGrafana},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, Routing: &Routing{LabelKey: "example/team", Field: "team"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, AlertRateLimit: 100},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, DedupWindowMinutes: 5},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGrafana, DefaultLabels: DefaultLabels{"example/team": "platform", "example/env": "prod"}},
 	}
 	invalid := []IntegrationKey{
 		{},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGrafana, Routing: &Routing{LabelKey: "example/team", Field: "team"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, Routing: &Routing{LabelKey: "example/team"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, Routing: &Routing{Field: "team"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGrafana, FieldMapping: &FieldMapping{Summary: "$.alert.title"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, FieldMapping: &FieldMapping{}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, AlertRateLimit: -1},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, AlertRateLimit: MaxAlertRateLimit + 1},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, DedupWindowMinutes: MaxDedupWindowMinutes + 1},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, DefaultLabels: DefaultLabels{"team": "platform"}},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGeneric, DefaultLabels: DefaultLabels{"example/team": ""}},
 	}
 	for _, k := range valid {
 		test(true, k)
@@ -7,16 +7,84 @@ WHERE
     id = $1
     AND type = $2;
 
+-- name: IntKeyGetRoute :one
+SELECT
+    route_label_key,
+    route_field
+FROM
+    integration_keys
+WHERE
+    id = $1;
+
+-- name: IntKeyRouteServiceIDs :many
+SELECT
+    l.tgt_service_id
+FROM
+    integration_keys k
+    JOIN labels l ON l.key = k.route_label_key
+WHERE
+    k.id = $1
+    AND l.value = $2
+ORDER BY
+    l.tgt_service_id;
+
 -- name: IntKeyCreate :exec
-INSERT INTO integration_keys(id, name, type, service_id)
-    VALUES ($1, $2, $3, $4);
+INSERT INTO integration_keys(id, name, type, service_id, route_label_key, route_field, alert_rate_limit, field_mapping, dedup_window_minutes, default_labels)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: IntKeySetAlertRateLimit :exec
+UPDATE
+    integration_keys
+SET
+    alert_rate_limit = $2
+WHERE
+    id = $1;
+
+-- name: IntKeySetDedupWindow :exec
+UPDATE
+    integration_keys
+SET
+    dedup_window_minutes = $2
+WHERE
+    id = $1;
+
+-- name: IntKeySetDefaultLabels :exec
+UPDATE
+    integration_keys
+SET
+    default_labels = $2
+WHERE
+    id = $1;
+
+-- name: IntKeySetFieldMapping :exec
+UPDATE
+    integration_keys
+SET
+    field_mapping = $2
+WHERE
+    id = $1;
+
+-- name: IntKeyGetFieldMapping :one
+SELECT
+    field_mapping
+FROM
+    integration_keys
+WHERE
+    id = $1;
 
 -- name: IntKeyFindOne :one
 SELECT
     id,
     name,
     type,
-    service_id
+    service_id,
+    route_label_key,
+    route_field,
+    alert_rate_limit,
+    dedup_window_minutes,
+    dropped_alert_count,
+    field_mapping,
+    default_labels
 FROM
     integration_keys
 WHERE
@@ -27,7 +95,14 @@ SELECT
     id,
     name,
     type,
-    service_id
+    service_id,
+    route_label_key,
+    route_field,
+    alert_rate_limit,
+    dedup_window_minutes,
+    dropped_alert_count,
+    field_mapping,
+    default_labels
 FROM
     integration_keys
 WHERE
@@ -6,6 +6,7 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+	"github.com/sqlc-dev/pqtype"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/util/sqlutil"
@@ -25,7 +26,7 @@ type InKeySearchOptions struct {
 
 var intKeySearchTemplate = template.Must(template.New("integration-key-search").Parse(`
 	SELECT DISTINCT
-		key.id, key.name, key.type, key.service_id
+		key.id, key.name, key.type, key.service_id, key.route_label_key, key.route_field, coalesce(key.alert_rate_limit, 0), key.dropped_alert_count, key.field_mapping, coalesce(key.dedup_window_minutes, 0), key.default_labels
 	FROM integration_keys key
 	WHERE true
 	{{if .Omit}}
@@ -106,10 +107,21 @@ func (s *Store) Search(ctx context.Context, opts *InKeySearchOptions) ([]Integra
 	var result []IntegrationKey
 	for rows.Next() {
 		var intKey IntegrationKey
-		err = rows.Scan(&intKey.ID, &intKey.Name, &intKey.Type, &intKey.ServiceID)
+		var labelKey, field sql.NullString
+		var mapping, labels pqtype.NullRawMessage
+		err = rows.Scan(&intKey.ID, &intKey.Name, &intKey.Type, &intKey.ServiceID, &labelKey, &field, &intKey.AlertRateLimit, &intKey.DroppedAlertCount, &mapping, &intKey.DedupWindowMinutes, &labels)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row")
 		}
+		intKey.Routing = newRouting(labelKey, field)
+		intKey.FieldMapping, err = parseFieldMapping(mapping)
+		if err != nil {
+			return nil, err
+		}
+		intKey.DefaultLabels, err = parseDefaultLabels(labels)
+		if err != nil {
+			return nil, err
+		}
 
 		result = append(result, intKey)
 	}
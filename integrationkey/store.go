@@ -3,14 +3,17 @@ package integrationkey
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/sqlc-dev/pqtype"
 )
 
 type Store struct {
@@ -44,7 +47,7 @@ func (s *Store) GetServiceID(ctx context.Context, id string, t Type) (string, er
 	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
 	err = validate.Many(
 		err,
-		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail, TypeOpsGenie),
 	)
 	if err != nil {
 		return "", err
@@ -87,18 +90,198 @@ func (s *Store) Create(ctx context.Context, dbtx gadb.DBTX, i *IntegrationKey) (
 
 	keyUUID := uuid.New()
 	n.ID = keyUUID.String()
-	err = gadb.New(dbtx).IntKeyCreate(ctx, gadb.IntKeyCreateParams{
+	params := gadb.IntKeyCreateParams{
 		ID:        keyUUID,
 		Name:      n.Name,
 		Type:      gadb.EnumIntegrationKeysType(n.Type),
 		ServiceID: serviceUUID,
-	})
+	}
+	if n.Routing != nil {
+		params.RouteLabelKey = sql.NullString{String: n.Routing.LabelKey, Valid: true}
+		params.RouteField = sql.NullString{String: n.Routing.Field, Valid: true}
+	}
+	if n.AlertRateLimit > 0 {
+		params.AlertRateLimit = sql.NullInt32{Int32: int32(n.AlertRateLimit), Valid: true}
+	}
+	if n.DedupWindowMinutes > 0 {
+		params.DedupWindowMinutes = sql.NullInt32{Int32: int32(n.DedupWindowMinutes), Valid: true}
+	}
+	params.FieldMapping, err = marshalFieldMapping(n.FieldMapping)
+	if err != nil {
+		return nil, err
+	}
+	params.DefaultLabels, err = marshalDefaultLabels(n.DefaultLabels)
+	if err != nil {
+		return nil, err
+	}
+	err = gadb.New(dbtx).IntKeyCreate(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 	return n, nil
 }
 
+// SetAlertRateLimit sets the maximum number of new alerts per minute for an integration key.
+// A limit of zero removes the limit.
+func (s *Store) SetAlertRateLimit(ctx context.Context, dbtx gadb.DBTX, id string, limit int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
+	err = validate.Many(
+		err,
+		validate.Range("AlertRateLimit", limit, 0, MaxAlertRateLimit),
+	)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IntKeySetAlertRateLimit(ctx, gadb.IntKeySetAlertRateLimitParams{
+		ID:             keyUUID,
+		AlertRateLimit: sql.NullInt32{Int32: int32(limit), Valid: limit > 0},
+	})
+}
+
+// SetDedupWindow sets the dedup window, in minutes, for alerts created by an integration key.
+// A window of zero removes it.
+func (s *Store) SetDedupWindow(ctx context.Context, dbtx gadb.DBTX, id string, minutes int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
+	err = validate.Many(
+		err,
+		validate.Range("DedupWindowMinutes", minutes, 0, MaxDedupWindowMinutes),
+	)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IntKeySetDedupWindow(ctx, gadb.IntKeySetDedupWindowParams{
+		ID:                 keyUUID,
+		DedupWindowMinutes: sql.NullInt32{Int32: int32(minutes), Valid: minutes > 0},
+	})
+}
+
+// SetDefaultLabels sets the labels added to the metadata of alerts created by an integration key.
+// An empty set removes them.
+func (s *Store) SetDefaultLabels(ctx context.Context, dbtx gadb.DBTX, id string, labels DefaultLabels) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
+	err = validate.Many(err, labels.Validate())
+	if err != nil {
+		return err
+	}
+	data, err := marshalDefaultLabels(labels)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).IntKeySetDefaultLabels(ctx, gadb.IntKeySetDefaultLabelsParams{
+		ID:            keyUUID,
+		DefaultLabels: data,
+	})
+}
+
+// SetFieldMapping sets the field mapping used to extract alert fields from payloads sent
+// to a generic integration key. A nil mapping removes it.
+func (s *Store) SetFieldMapping(ctx context.Context, dbtx gadb.DBTX, id string, m *FieldMapping) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
+	if err != nil {
+		return err
+	}
+	if m != nil {
+		m, err = m.Normalize()
+		if err != nil {
+			return err
+		}
+	}
+	data, err := marshalFieldMapping(m)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if m != nil && Type(row.Type) != TypeGeneric {
+		return validation.NewFieldError("FieldMapping", "only supported for generic integration keys")
+	}
+
+	return q.IntKeySetFieldMapping(ctx, gadb.IntKeySetFieldMappingParams{
+		ID:           keyUUID,
+		FieldMapping: data,
+	})
+}
+
+// FieldMapping will return the field mapping of the integration key the context was
+// authorized by, or nil if there is none.
+func (s *Store) FieldMapping(ctx context.Context) (*FieldMapping, error) {
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeIntegrationKey {
+		return nil, nil
+	}
+	keyUUID, err := uuid.Parse(src.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse integration key ID")
+	}
+
+	var data pqtype.NullRawMessage
+	permission.SudoContext(ctx, func(c context.Context) {
+		data, err = gadb.New(s.db).IntKeyGetFieldMapping(c, keyUUID)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup field mapping")
+	}
+
+	return parseFieldMapping(data)
+}
+
+func marshalFieldMapping(m *FieldMapping) (pqtype.NullRawMessage, error) {
+	if m == nil {
+		return pqtype.NullRawMessage{}, nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return pqtype.NullRawMessage{}, errors.Wrap(err, "marshal field mapping")
+	}
+
+	return pqtype.NullRawMessage{RawMessage: data, Valid: true}, nil
+}
+
+func parseFieldMapping(data pqtype.NullRawMessage) (*FieldMapping, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var m FieldMapping
+	err := json.Unmarshal(data.RawMessage, &m)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal field mapping")
+	}
+
+	return &m, nil
+}
+
 func (s *Store) Delete(ctx context.Context, dbtx gadb.DBTX, id string) error {
 	return s.DeleteMany(ctx, dbtx, []string{id})
 }
@@ -137,12 +320,26 @@ func (s *Store) FindOne(ctx context.Context, id string) (*IntegrationKey, error)
 		return nil, err
 	}
 
-	return &IntegrationKey{
-		ID:        row.ID.String(),
-		Name:      row.Name,
-		Type:      Type(row.Type),
-		ServiceID: row.ServiceID.String(),
-	}, nil
+	key := &IntegrationKey{
+		ID:                 row.ID.String(),
+		Name:               row.Name,
+		Type:               Type(row.Type),
+		ServiceID:          row.ServiceID.String(),
+		Routing:            newRouting(row.RouteLabelKey, row.RouteField),
+		AlertRateLimit:     int(row.AlertRateLimit.Int32),
+		DroppedAlertCount:  int(row.DroppedAlertCount),
+		DedupWindowMinutes: int(row.DedupWindowMinutes.Int32),
+	}
+	key.FieldMapping, err = parseFieldMapping(row.FieldMapping)
+	if err != nil {
+		return nil, err
+	}
+	key.DefaultLabels, err = parseDefaultLabels(row.DefaultLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
 }
 
 func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]IntegrationKey, error) {
@@ -163,11 +360,87 @@ func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Integ
 	keys := make([]IntegrationKey, len(rows))
 	for i, row := range rows {
 		keys[i] = IntegrationKey{
-			ID:        row.ID.String(),
-			Name:      row.Name,
-			Type:      Type(row.Type),
-			ServiceID: row.ServiceID.String(),
+			ID:                 row.ID.String(),
+			Name:               row.Name,
+			Type:               Type(row.Type),
+			ServiceID:          row.ServiceID.String(),
+			Routing:            newRouting(row.RouteLabelKey, row.RouteField),
+			AlertRateLimit:     int(row.AlertRateLimit.Int32),
+			DroppedAlertCount:  int(row.DroppedAlertCount),
+			DedupWindowMinutes: int(row.DedupWindowMinutes.Int32),
+		}
+		keys[i].FieldMapping, err = parseFieldMapping(row.FieldMapping)
+		if err != nil {
+			return nil, err
+		}
+		keys[i].DefaultLabels, err = parseDefaultLabels(row.DefaultLabels)
+		if err != nil {
+			return nil, err
 		}
 	}
 	return keys, nil
 }
+
+func newRouting(labelKey, field sql.NullString) *Routing {
+	if !labelKey.Valid || !field.Valid {
+		return nil
+	}
+
+	return &Routing{LabelKey: labelKey.String, Field: field.String}
+}
+
+// RouteContext will return a context authorized for the service an alert should be
+// created for. If the context was not authorized by a routing integration key, it is
+// returned unchanged.
+//
+// fieldValue is called with the configured routing field and should return the
+// value from the incoming payload. A validation error is returned if the value is
+// missing or does not match exactly one service.
+func (s *Store) RouteContext(ctx context.Context, fieldValue func(field string) string) (context.Context, error) {
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeIntegrationKey {
+		return ctx, nil
+	}
+	keyUUID, err := uuid.Parse(src.ID)
+	if err != nil {
+		return ctx, errors.Wrap(err, "parse integration key ID")
+	}
+
+	var route gadb.IntKeyGetRouteRow
+	var serviceIDs []uuid.UUID
+	permission.SudoContext(ctx, func(c context.Context) {
+		q := gadb.New(s.db)
+		route, err = q.IntKeyGetRoute(c, keyUUID)
+		if err != nil || !route.RouteField.Valid {
+			return
+		}
+
+		value := fieldValue(route.RouteField.String)
+		if value == "" {
+			err = validation.NewFieldError(route.RouteField.String, "required for routing")
+			return
+		}
+		serviceIDs, err = q.IntKeyRouteServiceIDs(c, gadb.IntKeyRouteServiceIDsParams{
+			ID:    keyUUID,
+			Value: value,
+		})
+		if err != nil {
+			return
+		}
+		switch len(serviceIDs) {
+		case 0:
+			err = validation.NewFieldError(route.RouteField.String, "no service has label "+route.RouteLabelKey.String+"="+value)
+		case 1:
+		default:
+			err = validation.NewFieldError(route.RouteField.String, "multiple services have label "+route.RouteLabelKey.String+"="+value)
+		}
+	})
+	if err != nil {
+		return ctx, errors.Wrap(err, "route integration key")
+	}
+	if !route.RouteField.Valid {
+		return ctx, nil
+	}
+
+	return permission.ServiceSourceContext(ctx, serviceIDs[0].String(), src), nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -40,6 +41,10 @@ func init() {
 type Keyring interface {
 	RotateKeys(ctx context.Context) error
 
+	// PromoteNextKey will force a key rotation, and schedule all keys older than the
+	// new signing key to stop being accepted for verification after gracePeriod.
+	PromoteNextKey(ctx context.Context, gracePeriod time.Duration) error
+
 	Sign(p []byte) ([]byte, error)
 	Verify(p []byte, signature []byte) (valid, oldKey bool)
 
@@ -87,12 +92,13 @@ type DB struct {
 	cfg Config
 
 	verificationKeys map[byte]ecdsa.PublicKey
+	retireTimes      map[byte]time.Time
 	signingKey       *ecdsa.PrivateKey
 	rotationCount    int
 
 	mx          sync.RWMutex
 	shutdown    chan context.Context
-	forceRotate chan chan error
+	forceRotate chan rotateRequest
 
 	fetchKeys  *sql.Stmt
 	setKeys    *sql.Stmt
@@ -100,6 +106,13 @@ type DB struct {
 	insertKeys *sql.Stmt
 }
 
+// rotateRequest is used to request a forced rotation from the keyring loop. If
+// gracePeriod is non-zero, old keys will be scheduled for retirement.
+type rotateRequest struct {
+	gracePeriod time.Duration
+	errCh       chan error
+}
+
 func marshalVerificationKeys(keys map[byte]ecdsa.PublicKey) ([]byte, error) {
 	m := make(map[byte][]byte, len(keys))
 	var err error
@@ -134,6 +147,20 @@ func parseVerificationKeys(data []byte) (map[byte]ecdsa.PublicKey, error) {
 	return res, nil
 }
 
+func parseRetireTimes(data []byte) (map[byte]time.Time, error) {
+	res := make(map[byte]time.Time)
+	if len(data) == 0 {
+		return res, nil
+	}
+
+	err := json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // NewDB creates a new postgres-backed keyring.
 func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*DB, error) {
 	if cfg == nil {
@@ -161,7 +188,7 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 
 		logger: logger,
 
-		forceRotate: make(chan chan error),
+		forceRotate: make(chan rotateRequest),
 		shutdown:    make(chan context.Context),
 
 		txTime: p.P(`select now()`),
@@ -185,7 +212,8 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 				next_key,
 				now(),
 				next_rotation,
-				rotation_count
+				rotation_count,
+				retire_times
 			from keyring
 			where id = $1
 			for update
@@ -197,7 +225,8 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 				signing_key = $3,
 				next_key = $4,
 				next_rotation = $5,
-				rotation_count = $6
+				rotation_count = $6,
+				retire_times = $7
 			where id = $1
 		`),
 	}
@@ -206,7 +235,7 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 		return nil, p.Err
 	}
 
-	err = d.refreshAndRotateKeys(ctx, false)
+	err = d.refreshAndRotateKeys(ctx, false, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -237,25 +266,25 @@ mainLoop:
 		select {
 		case <-t.C:
 			ctx, cancel := context.WithTimeout(db.logger.BackgroundContext(), time.Minute)
-			err := db.refreshAndRotateKeys(ctx, false)
+			err := db.refreshAndRotateKeys(ctx, false, 0)
 			cancel()
 			if err != nil {
 				log.Log(ctx, err)
 			}
 		case shutdownCtx = <-db.shutdown:
 			break mainLoop
-		case ch := <-db.forceRotate:
+		case req := <-db.forceRotate:
 			ctx, cancel := context.WithTimeout(db.logger.BackgroundContext(), time.Minute)
-			ch <- db.refreshAndRotateKeys(ctx, true)
+			req.errCh <- db.refreshAndRotateKeys(ctx, true, req.gracePeriod)
 			cancel()
 		}
 	}
 
 	// respond to any pending force rotation calls
 	close(db.forceRotate)
-	for ch := range db.forceRotate {
+	for req := range db.forceRotate {
 		ctx, cancel := context.WithTimeout(shutdownCtx, time.Minute)
-		ch <- db.refreshAndRotateKeys(ctx, true)
+		req.errCh <- db.refreshAndRotateKeys(ctx, true, req.gracePeriod)
 		cancel()
 	}
 }
@@ -338,12 +367,13 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 	}
 
 	var rotationCount int
+	retire := make(map[byte]time.Time)
 
 	if rowCount == 0 {
 		// failed to insert the new data, so scan old & refresh
-		var vKeysData, signKeyData, nextKeyData []byte
+		var vKeysData, signKeyData, nextKeyData, retireData []byte
 		var rotateT sql.NullTime
-		err = db.fetchKeys.QueryRowContext(ctx, db.cfg.Name).Scan(&vKeysData, &signKeyData, &nextKeyData, &t, &rotateT, &rotationCount)
+		err = db.fetchKeys.QueryRowContext(ctx, db.cfg.Name).Scan(&vKeysData, &signKeyData, &nextKeyData, &t, &rotateT, &rotationCount, &retireData)
 		if err != nil {
 			return err
 		}
@@ -352,6 +382,10 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 		if err != nil {
 			return err
 		}
+		retire, err = parseRetireTimes(retireData)
+		if err != nil {
+			return err
+		}
 
 		signKey, err = db.loadKey(signKeyData)
 		if err != nil {
@@ -364,6 +398,7 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 	defer db.mx.Unlock()
 
 	db.verificationKeys = v
+	db.retireTimes = retire
 	db.signingKey = signKey
 	db.rotationCount = rotationCount
 
@@ -384,16 +419,67 @@ func (db *DB) rotateVerificationKeys(m map[byte]ecdsa.PublicKey, n int, newKey e
 // RotateKeys will force a key rotation.
 func (db *DB) RotateKeys(ctx context.Context) error {
 	ch := make(chan error)
-	db.forceRotate <- ch
+	db.forceRotate <- rotateRequest{errCh: ch}
 	return <-ch
 }
 
+// PromoteNextKey will force a key rotation, making the next key the signing key. All
+// keys older than the new signing key will no longer be accepted for verification once
+// gracePeriod has elapsed.
+//
+// Other instances will begin signing with the new key on their next refresh.
+func (db *DB) PromoteNextKey(ctx context.Context, gracePeriod time.Duration) error {
+	if gracePeriod <= 0 {
+		return validation.NewFieldError("GracePeriod", "must be positive")
+	}
+
+	ch := make(chan error)
+	db.forceRotate <- rotateRequest{gracePeriod: gracePeriod, errCh: ch}
+	return <-ch
+}
+
+// scheduleRetirement returns a copy of retire with every key in m, other than the current
+// and next keys (n and n+1), set to retire at t, unless already scheduled to retire sooner.
+// Entries for keys no longer in m are dropped.
+func scheduleRetirement(m map[byte]ecdsa.PublicKey, retire map[byte]time.Time, n int, t time.Time) map[byte]time.Time {
+	res := make(map[byte]time.Time, len(m))
+	for id := range m {
+		if id == byte(n) || id == byte(n+1) {
+			continue
+		}
+		if old, ok := retire[id]; ok && old.Before(t) {
+			res[id] = old
+			continue
+		}
+		res[id] = t
+	}
+	return res
+}
+
+// pruneRetirement returns a copy of retire without entries for keys no longer in m.
+func pruneRetirement(m map[byte]ecdsa.PublicKey, retire map[byte]time.Time) map[byte]time.Time {
+	res := make(map[byte]time.Time, len(retire))
+	for id, t := range retire {
+		if _, ok := m[id]; ok {
+			res[id] = t
+		}
+	}
+	return res
+}
+
+// isRetired returns true if the key at index has passed its scheduled retirement.
+func (db *DB) isRetired(index byte) bool {
+	t, ok := db.retireTimes[index]
+	return ok && !time.Now().Before(t)
+}
+
 // refreshAndRotateKeys will perform a key rotation, and cleanup expired keys when appropriate. If forceRotation
 // is true, a rotation will always happen -- even if RotationDays is zero (disabled). It also
 // ensures the current key configuration is up-to-date.
 //
-// When a key is rotated, a new key is generated and inserted.
-func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) error {
+// When a key is rotated, a new key is generated and inserted. If gracePeriod is non-zero, keys
+// older than the new signing key are scheduled for retirement after it has elapsed.
+func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool, gracePeriod time.Duration) error {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -404,11 +490,11 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 
 	var verificationKeys map[byte]ecdsa.PublicKey
 
-	var vKeysData, signKeyData, nextKeyData []byte
+	var vKeysData, signKeyData, nextKeyData, retireData []byte
 	var t time.Time
 	var rotateT sql.NullTime
 	var count int
-	err = row.Scan(&vKeysData, &signKeyData, &nextKeyData, &t, &rotateT, &count)
+	err = row.Scan(&vKeysData, &signKeyData, &nextKeyData, &t, &rotateT, &count, &retireData)
 	if errors.Is(err, sql.ErrNoRows) {
 		return db.commitNewKeyring(ctx, tx)
 	}
@@ -420,6 +506,10 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 	if err != nil {
 		return errors.Wrap(err, "unmarshal verification keys")
 	}
+	retireTimes, err := parseRetireTimes(retireData)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal retire times")
+	}
 
 	if forceRotation || (rotateT.Valid && !t.Before(rotateT.Time)) {
 		// perform a key rotation
@@ -435,6 +525,15 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 		if err != nil {
 			return err
 		}
+		if gracePeriod > 0 {
+			retireTimes = scheduleRetirement(verificationKeys, retireTimes, count, t.Add(gracePeriod))
+		} else {
+			retireTimes = pruneRetirement(verificationKeys, retireTimes)
+		}
+		retireData, err = json.Marshal(retireTimes)
+		if err != nil {
+			return err
+		}
 		var nextRotTime interface{}
 		if db.cfg.RotationDays > 0 {
 			// We want to wait an explicit amount of time, rather than rotating by date.
@@ -443,7 +542,7 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 			// timezones, they should be able to agree on handoff times.
 			nextRotTime = t.Add(time.Hour * 24 * time.Duration(db.cfg.RotationDays))
 		}
-		_, err := tx.Stmt(db.setKeys).ExecContext(ctx, db.cfg.Name, vKeysData, signKeyData, nextKeyData, nextRotTime, count)
+		_, err := tx.Stmt(db.setKeys).ExecContext(ctx, db.cfg.Name, vKeysData, signKeyData, nextKeyData, nextRotTime, count, retireData)
 		if err != nil {
 			return err
 		}
@@ -462,6 +561,7 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 	defer db.mx.Unlock()
 
 	db.verificationKeys = verificationKeys
+	db.retireTimes = retireTimes
 	db.signingKey = signKey
 	db.rotationCount = count
 
@@ -531,7 +631,7 @@ func (db *DB) VerifyJWT(s string, c jwt.Claims, iss, aud string) (bool, error) {
 			return nil, errors.New("invalid key index")
 		}
 		key, ok := db.verificationKeys[byte(keyIndex)]
-		if !ok {
+		if !ok || db.isRetired(byte(keyIndex)) {
 			return nil, errors.New("invalid key")
 		}
 
@@ -584,7 +684,7 @@ func (db *DB) Verify(p []byte, signature []byte) (valid, oldKey bool) {
 	}
 
 	key, ok := db.verificationKeys[hdr.KeyIndex]
-	if !ok {
+	if !ok || db.isRetired(hdr.KeyIndex) {
 		return false, false
 	}
 	// ensure key exists
@@ -6,6 +6,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -49,3 +50,63 @@ func TestSignVerify(t *testing.T) {
 		t.Run("", try)
 	}
 }
+
+func TestVerifyRetired(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := &DB{
+		verificationKeys: map[byte]ecdsa.PublicKey{
+			0: oldKey.PublicKey,
+			1: signKey.PublicKey,
+		},
+		signingKey: oldKey,
+	}
+	msg := []byte("hello")
+	sig, err := db.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// promote the new key, old key is still valid during the grace period
+	db.signingKey = signKey
+	db.rotationCount = 1
+	db.retireTimes = scheduleRetirement(db.verificationKeys, nil, 1, time.Now().Add(time.Hour))
+	valid, old := db.Verify(msg, sig)
+	if !valid {
+		t.Fatal("validation failed during grace period")
+	}
+	if !old {
+		t.Fatal("expected old key")
+	}
+
+	db.retireTimes[0] = time.Now().Add(-time.Second)
+	valid, _ = db.Verify(msg, sig)
+	if valid {
+		t.Fatal("validation succeeded with retired key")
+	}
+}
+
+func TestScheduleRetirement(t *testing.T) {
+	var pub ecdsa.PublicKey
+	m := map[byte]ecdsa.PublicKey{3: pub, 4: pub, 5: pub, 6: pub}
+	now := time.Now()
+	soon := now.Add(time.Minute)
+
+	res := scheduleRetirement(m, map[byte]time.Time{3: soon, 2: soon}, 5, now.Add(time.Hour))
+	if len(res) != 2 {
+		t.Fatalf("got %d entries; want 2", len(res))
+	}
+	if !res[3].Equal(soon) {
+		t.Errorf("key 3 retires at %v; want existing %v", res[3], soon)
+	}
+	if !res[4].Equal(now.Add(time.Hour)) {
+		t.Errorf("key 4 retires at %v; want %v", res[4], now.Add(time.Hour))
+	}
+}
@@ -0,0 +1,30 @@
+package label
+
+import (
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SelectorWildcard is the Selector value that matches any label value.
+const SelectorWildcard = "*"
+
+// A Selector matches labels by key and value. A value of SelectorWildcard matches
+// any value for the key.
+type Selector struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Normalize will validate and normalize the selector, returning a copy.
+func (s Selector) Normalize() (*Selector, error) {
+	err := validate.LabelKey("Key", s.Key)
+	switch s.Value {
+	case "":
+		err = validate.Many(err, validation.NewFieldError("Value", "is required"))
+	case SelectorWildcard:
+	default:
+		err = validate.Many(err, validate.LabelValue("Value", s.Value))
+	}
+
+	return &s, err
+}
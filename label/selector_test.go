@@ -0,0 +1,21 @@
+package label
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Normalize(t *testing.T) {
+	_, err := Selector{Key: "example.com/team", Value: "payments"}.Normalize()
+	assert.NoError(t, err)
+
+	_, err = Selector{Key: "example.com/team", Value: SelectorWildcard}.Normalize()
+	assert.NoError(t, err, "wildcard")
+
+	_, err = Selector{Key: "example.com/team"}.Normalize()
+	assert.Error(t, err, "missing value")
+
+	_, err = Selector{Key: "team", Value: "payments"}.Normalize()
+	assert.Error(t, err, "invalid key")
+}
@@ -3,10 +3,13 @@ package label
 import (
 	"context"
 	"database/sql"
+	"strconv"
 
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/pkg/errors"
@@ -20,6 +23,11 @@ type Store struct {
 	delete           *sql.Stmt
 	findAllByService *sql.Stmt
 	uniqueKeys       *sql.Stmt
+
+	deleteGrants  *sql.Stmt
+	insertGrant   *sql.Stmt
+	findAllGrants *sql.Stmt
+	findUngranted *sql.Stmt
 }
 
 // NewStore will Set a DB backend from a sql.DB. An error will be returned if statements fail to prepare.
@@ -49,6 +57,47 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			FROM labels
 			ORDER BY key ASC
 		`),
+
+		deleteGrants: p.P(`DELETE FROM user_label_grants WHERE user_id = $1`),
+		insertGrant: p.P(`
+			INSERT INTO user_label_grants (user_id, key, value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`),
+		findAllGrants: p.P(`
+			SELECT key, value
+			FROM user_label_grants
+			WHERE user_id = $1
+			ORDER BY key, value
+		`),
+		findUngranted: p.P(`
+			WITH svc AS (
+				SELECT id FROM services WHERE id = any($2::uuid[])
+				UNION
+				SELECT id FROM services WHERE escalation_policy_id = any($3::uuid[])
+				UNION
+				SELECT service_id FROM integration_keys WHERE id = any($4::uuid[])
+				UNION
+				SELECT service_id FROM heartbeat_monitors WHERE id = any($5::uuid[])
+				UNION
+				SELECT s.id
+				FROM services s
+				JOIN escalation_policy_steps step ON step.escalation_policy_id = s.escalation_policy_id
+				WHERE step.id = any($6::uuid[])
+			)
+			SELECT svc.id
+			FROM svc
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM user_label_grants g
+				JOIN labels l ON
+					l.tgt_service_id = svc.id AND
+					l.key = g.key AND
+					(g.value = '*' OR l.value = g.value)
+				WHERE g.user_id = $1
+			)
+			ORDER BY svc.id
+		`),
 	}, p.Err
 }
 
@@ -160,3 +209,142 @@ func (s *Store) UniqueKeysTx(ctx context.Context, tx *sql.Tx) ([]string, error)
 func (s *Store) UniqueKeys(ctx context.Context) ([]string, error) {
 	return s.UniqueKeysTx(ctx, nil)
 }
+
+// SetUserGrantsTx will replace the label grants for the given user.
+func (s *Store) SetUserGrantsTx(ctx context.Context, tx *sql.Tx, userID string, grants []Selector) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("UserID", userID),
+		validate.Range("Grants", len(grants), 0, 50),
+	)
+	if err != nil {
+		return err
+	}
+
+	normalized := make([]Selector, 0, len(grants))
+	for i, g := range grants {
+		n, err := g.Normalize()
+		if err != nil {
+			return validation.AddPrefix("Grants["+strconv.Itoa(i)+"].", err)
+		}
+		normalized = append(normalized, *n)
+	}
+
+	del, insert := s.deleteGrants, s.insertGrant
+	if tx != nil {
+		del = tx.StmtContext(ctx, del)
+		insert = tx.StmtContext(ctx, insert)
+	}
+
+	_, err = del.ExecContext(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "delete grants")
+	}
+
+	for _, g := range normalized {
+		_, err = insert.ExecContext(ctx, userID, g.Key, g.Value)
+		if err != nil {
+			return errors.Wrap(err, "insert grant")
+		}
+	}
+
+	return nil
+}
+
+// FindAllUserGrants will return all label grants for the given user.
+func (s *Store) FindAllUserGrants(ctx context.Context, userID string) ([]Selector, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllGrants.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []Selector
+	for rows.Next() {
+		var g Selector
+		err = rows.Scan(&g.Key, &g.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan row")
+		}
+		grants = append(grants, g)
+	}
+
+	return grants, rows.Err()
+}
+
+// FindUngrantedServicesTx will return the IDs of services that are not matched by any of
+// the user's label grants. Services are resolved from the given targets, which may be
+// services, escalation policies (every service using the policy), integration keys, or
+// heartbeat monitors.
+func (s *Store) FindUngrantedServicesTx(ctx context.Context, tx *sql.Tx, userID string, tgts []assignment.Target) ([]string, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var svcIDs, epIDs, stepIDs, keyIDs, hbIDs []string
+	for _, t := range tgts {
+		switch t.TargetType() {
+		case assignment.TargetTypeService:
+			svcIDs = append(svcIDs, t.TargetID())
+		case assignment.TargetTypeEscalationPolicy:
+			epIDs = append(epIDs, t.TargetID())
+		case assignment.TargetTypeEscalationPolicyStep:
+			stepIDs = append(stepIDs, t.TargetID())
+		case assignment.TargetTypeIntegrationKey:
+			keyIDs = append(keyIDs, t.TargetID())
+		case assignment.TargetTypeHeartbeatMonitor:
+			hbIDs = append(hbIDs, t.TargetID())
+		default:
+			return nil, validation.NewFieldError("TargetType", "unsupported type "+t.TargetType().String())
+		}
+	}
+	err = validate.Many(
+		validate.UUID("UserID", userID),
+		validate.ManyUUID("ServiceID", svcIDs, -1),
+		validate.ManyUUID("EscalationPolicyID", epIDs, -1),
+		validate.ManyUUID("EscalationPolicyStepID", stepIDs, -1),
+		validate.ManyUUID("IntegrationKeyID", keyIDs, -1),
+		validate.ManyUUID("HeartbeatMonitorID", hbIDs, -1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := s.findUngranted
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID, sqlutil.UUIDArray(svcIDs), sqlutil.UUIDArray(epIDs), sqlutil.UUIDArray(keyIDs), sqlutil.UUIDArray(hbIDs), sqlutil.UUIDArray(stepIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan row")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -86,11 +86,20 @@ func (s *Store) Max(ctx context.Context, id ID) (int, error) {
 
 // All will get the current value of all limits.
 func (s *Store) All(ctx context.Context) (Limits, error) {
+	return s.AllTx(ctx, nil)
+}
+
+// AllTx will get the current value of all limits, as part of tx if it is not nil.
+func (s *Store) AllTx(ctx context.Context, tx *sql.Tx) (Limits, error) {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := s.findAll.QueryContext(ctx)
+	stmt := s.findAll
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/errutil"
@@ -82,6 +83,7 @@ func validSignature(ctx context.Context, req *http.Request, apikey string) bool
 type ingressHandler struct {
 	alerts  *alert.Store
 	intKeys *integrationkey.Store
+	replies *email.Sender
 }
 
 func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -146,6 +148,21 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if token, ok := email.ParseReplyMailbox(parts[0]); ok {
+		reply := email.Reply{
+			From:       r.FormValue("from"),
+			Token:      token,
+			MessageIDs: append(strings.Fields(r.FormValue("In-Reply-To")), strings.Fields(r.FormValue("References"))...),
+			Body:       r.FormValue("body-plain"),
+		}
+		if stripped := r.FormValue("stripped-text"); stripped != "" {
+			// Mailgun has already removed quoted text and signatures
+			reply.Body = stripped
+		}
+		httpError(ctx, w, h.replies.ReceiveReply(ctx, reply))
+		return
+	}
+
 	// support for dedup key
 	parts = strings.SplitN(parts[0], "+", 2)
 	err = validate.UUID("recipient", parts[0])
@@ -201,9 +218,12 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // IngressWebhooks is used to accept webhooks from Mailgun to support email as an alert creation mechanism.
 // Will read POST form parameters, validate, sanitize and use to create a new alert.
 // https://documentation.mailgun.com/en/latest/user_manual.html#parsed-messages-parameters
-func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+//
+// Replies to notification emails, sent to the email.ReplyMailbox, are passed to replies.
+func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store, replies *email.Sender) http.HandlerFunc {
 	return (&ingressHandler{
 		alerts:  aDB,
 		intKeys: intDB,
+		replies: replies,
 	}).ServeHTTP
 }
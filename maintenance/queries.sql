@@ -0,0 +1,30 @@
+-- name: MaintWindowCreate :exec
+INSERT INTO service_maintenance_windows(id, service_id, start_time, end_time, description)
+    VALUES ($1, $2, $3, $4, $5);
+
+-- name: MaintWindowFindByService :many
+SELECT
+    description,
+    end_time,
+    id,
+    service_id,
+    start_time
+FROM
+    service_maintenance_windows
+WHERE
+    service_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time;
+
+-- name: MaintWindowDelete :exec
+DELETE FROM service_maintenance_windows
+WHERE id = ANY (@ids::uuid[]);
+
+-- name: MaintWindowServiceIDs :many
+SELECT DISTINCT
+    service_id
+FROM
+    service_maintenance_windows
+WHERE
+    id = ANY (@ids::uuid[]);
@@ -0,0 +1,119 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages scheduled maintenance windows for services.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// CreateWindowTx will create a new maintenance window for a service.
+func (s *Store) CreateWindowTx(ctx context.Context, dbtx gadb.DBTX, w *Window) (*Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	err = gadb.New(dbtx).MaintWindowCreate(ctx, gadb.MaintWindowCreateParams{
+		ID:          id,
+		ServiceID:   uuid.MustParse(n.ServiceID),
+		StartTime:   n.Start,
+		EndTime:     n.End,
+		Description: n.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = id.String()
+	return n, nil
+}
+
+// DeleteManyWindowsTx will delete the maintenance windows with the given IDs.
+func (s *Store) DeleteManyWindowsTx(ctx context.Context, dbtx gadb.DBTX, ids []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	uuids, err := validate.ParseManyUUID("MaintenanceWindowID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(dbtx).MaintWindowDelete(ctx, uuids)
+}
+
+// ServiceIDsTx will return the IDs of the services the maintenance windows belong to.
+func (s *Store) ServiceIDsTx(ctx context.Context, dbtx gadb.DBTX, ids []string) ([]string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	uuids, err := validate.ParseManyUUID("MaintenanceWindowID", ids, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	svcIDs, err := gadb.New(dbtx).MaintWindowServiceIDs(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(svcIDs))
+	for i, id := range svcIDs {
+		result[i] = id.String()
+	}
+	return result, nil
+}
+
+// FindAllByService will return all current and upcoming maintenance windows for a service,
+// ordered by start time.
+func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Window, error) {
+	svcID, err := validate.ParseUUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).MaintWindowFindByService(ctx, svcID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Window, len(rows))
+	for i, r := range rows {
+		result[i] = Window{
+			ID:          r.ID.String(),
+			ServiceID:   r.ServiceID.String(),
+			Start:       r.StartTime,
+			End:         r.EndTime,
+			Description: r.Description,
+		}
+	}
+
+	return result, nil
+}
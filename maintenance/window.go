@@ -0,0 +1,47 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxDuration is the longest a single maintenance window may last.
+const MaxDuration = 30 * 24 * time.Hour
+
+// A Window is a scheduled period of time during which new alerts for a service
+// are automatically acknowledged, and no notifications are sent.
+type Window struct {
+	ID          string    `json:"id,omitempty"`
+	ServiceID   string    `json:"service_id,omitempty"`
+	Start       time.Time `json:"start_time,omitempty"`
+	End         time.Time `json:"end_time,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// Active will return true if the window is in effect at the given time.
+func (w Window) Active(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (w Window) Normalize() (*Window, error) {
+	err := validate.Many(
+		validate.UUID("ServiceID", w.ServiceID),
+		validate.Text("Description", w.Description, 0, 255),
+	)
+	if !w.Start.Before(w.End) {
+		err = validate.Many(err, validation.NewFieldError("End", "must occur after Start time"))
+	} else if w.End.Sub(w.Start) > MaxDuration {
+		err = validate.Many(err, validation.NewFieldError("End", "must be within 30 days of Start time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	w.Start = w.Start.Truncate(time.Minute)
+	w.End = w.End.Truncate(time.Minute)
+
+	return &w, nil
+}
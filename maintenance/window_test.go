@@ -0,0 +1,65 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow_Normalize(t *testing.T) {
+	test := func(valid bool, w Window) {
+		name := "valid"
+		if !valid {
+			name = "invalid"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Logf("%+v", w)
+			_, err := w.Normalize()
+			if valid && err != nil {
+				t.Errorf("got %v; want nil", err)
+			} else if !valid && err == nil {
+				t.Errorf("got nil err; want non-nil")
+			}
+		})
+	}
+
+	start := time.Date(2023, 9, 26, 9, 0, 0, 0, time.UTC)
+	svcID := "e93facc0-4764-012d-7bfb-002500d5d1a6"
+
+	valid := []Window{
+		{ServiceID: svcID, Start: start, End: start.Add(time.Hour)},
+		{ServiceID: svcID, Start: start, End: start.Add(MaxDuration), Description: "Database upgrade"},
+	}
+	invalid := []Window{
+		{},
+		{ServiceID: svcID, Start: start, End: start},
+		{ServiceID: svcID, Start: start, End: start.Add(-time.Hour)},
+		{ServiceID: svcID, Start: start, End: start.Add(MaxDuration + time.Minute)},
+		{ServiceID: "foo", Start: start, End: start.Add(time.Hour)},
+	}
+	for _, w := range valid {
+		test(true, w)
+	}
+	for _, w := range invalid {
+		test(false, w)
+	}
+}
+
+func TestWindow_Active(t *testing.T) {
+	start := time.Date(2023, 9, 26, 9, 0, 0, 0, time.UTC)
+	w := Window{Start: start, End: start.Add(time.Hour)}
+
+	cases := []struct {
+		at  time.Time
+		exp bool
+	}{
+		{start.Add(-time.Minute), false},
+		{start, true},
+		{start.Add(30 * time.Minute), true},
+		{start.Add(time.Hour), false},
+	}
+	for _, c := range cases {
+		if got := w.Active(c.at); got != c.exp {
+			t.Errorf("Active(%s) = %t; want %t", c.at, got, c.exp)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE gql_api_key_usage_history(
+    id bigserial PRIMARY KEY,
+    api_key_id uuid NOT NULL REFERENCES gql_api_keys(id) ON DELETE CASCADE,
+    used_at timestamp with time zone NOT NULL DEFAULT now(),
+    user_agent text NOT NULL,
+    ip_address inet
+);
+
+CREATE INDEX idx_gql_api_key_usage_history_key ON gql_api_key_usage_history(api_key_id, id);
+
+-- +migrate Down
+DROP TABLE gql_api_key_usage_history;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TYPE enum_ep_step_assignment_strategy AS ENUM (
+    'sequential',
+    'round_robin'
+);
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN assignment_strategy enum_ep_step_assignment_strategy NOT NULL DEFAULT 'sequential';
+
+CREATE TABLE ep_step_round_robin_state(
+    ep_step_id uuid NOT NULL REFERENCES escalation_policy_steps(id) ON DELETE CASCADE,
+    rotation_id uuid NOT NULL REFERENCES rotations(id) ON DELETE CASCADE,
+    position integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (ep_step_id, rotation_id)
+);
+
+-- +migrate Down
+DROP TABLE ep_step_round_robin_state;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN assignment_strategy;
+
+DROP TYPE enum_ep_step_assignment_strategy;
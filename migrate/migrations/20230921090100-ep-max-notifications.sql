@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE escalation_policies
+    ADD COLUMN max_notifications integer NOT NULL DEFAULT 0;
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN notification_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN escalation_exhausted_at timestamp with time zone;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_state
+    DROP COLUMN notification_count,
+    DROP COLUMN escalation_exhausted_at;
+
+ALTER TABLE escalation_policies
+    DROP COLUMN max_notifications;
@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_heartbeat_state ADD VALUE IF NOT EXISTS 'warning';
+
+-- +migrate Down
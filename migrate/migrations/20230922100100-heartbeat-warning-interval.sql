@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE heartbeat_monitors
+    ADD COLUMN warning_interval interval;
+
+-- +migrate Down
+ALTER TABLE heartbeat_monitors
+    DROP COLUMN warning_interval;
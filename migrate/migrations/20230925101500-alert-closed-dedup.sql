@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE alert_closed_dedup(
+    service_id uuid NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    dedup_key text NOT NULL,
+    alert_id bigint NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    closed_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (service_id, dedup_key)
+);
+
+CREATE INDEX idx_alert_closed_dedup_alert_id ON alert_closed_dedup(alert_id);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_clear_dedup_on_close() RETURNS trigger AS $$
+BEGIN
+    IF OLD.dedup_key NOTNULL AND NEW.service_id NOTNULL THEN
+        INSERT INTO alert_closed_dedup (service_id, dedup_key, alert_id, closed_at)
+        VALUES (NEW.service_id, OLD.dedup_key, NEW.id, now())
+        ON CONFLICT (service_id, dedup_key) DO UPDATE
+        SET alert_id = excluded.alert_id, closed_at = excluded.closed_at;
+    END IF;
+
+    NEW.dedup_key = NULL;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_clear_dedup_on_close() RETURNS trigger AS $$
+BEGIN
+    NEW.dedup_key = NULL;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+DROP TABLE alert_closed_dedup;
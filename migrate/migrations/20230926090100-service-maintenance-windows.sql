@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE service_maintenance_windows(
+    id uuid PRIMARY KEY,
+    service_id uuid NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    description text NOT NULL DEFAULT '',
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_svc_maint_windows_service_id ON service_maintenance_windows(service_id, end_time);
+
+-- +migrate Down
+DROP TABLE service_maintenance_windows;
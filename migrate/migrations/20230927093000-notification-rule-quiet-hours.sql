@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE user_notification_rules
+    ADD COLUMN quiet_hours_start time without time zone,
+    ADD COLUMN quiet_hours_end time without time zone,
+    ADD COLUMN quiet_hours_time_zone text,
+    ADD CONSTRAINT user_notification_rules_quiet_hours_check CHECK (
+        (quiet_hours_start ISNULL AND quiet_hours_end ISNULL AND quiet_hours_time_zone ISNULL)
+        OR (quiet_hours_start NOTNULL AND quiet_hours_end NOTNULL AND quiet_hours_time_zone NOTNULL)
+    );
+
+-- +migrate Down
+ALTER TABLE user_notification_rules
+    DROP CONSTRAINT user_notification_rules_quiet_hours_check,
+    DROP COLUMN quiet_hours_start,
+    DROP COLUMN quiet_hours_end,
+    DROP COLUMN quiet_hours_time_zone;
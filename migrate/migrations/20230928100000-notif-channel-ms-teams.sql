@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_notif_channel_type ADD VALUE IF NOT EXISTS 'MS_TEAMS';
+
+-- +migrate Down
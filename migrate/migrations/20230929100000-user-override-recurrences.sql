@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE user_override_recurrences(
+    id uuid PRIMARY KEY,
+    tgt_schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    add_user_id uuid REFERENCES users(id) ON DELETE CASCADE,
+    remove_user_id uuid REFERENCES users(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    weekdays boolean[] NOT NULL,
+    until timestamp with time zone NOT NULL,
+    CHECK (end_time > start_time),
+    CHECK (until > start_time),
+    CHECK (COALESCE(add_user_id, remove_user_id) NOTNULL),
+    CHECK (add_user_id != remove_user_id)
+);
+
+CREATE INDEX idx_user_override_recurrences_schedule ON user_override_recurrences(tgt_schedule_id, until);
+
+ALTER TABLE user_overrides
+    ADD COLUMN recurrence_id uuid REFERENCES user_override_recurrences(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_user_overrides_recurrence ON user_overrides(recurrence_id);
+
+-- +migrate Down
+ALTER TABLE user_overrides
+    DROP COLUMN recurrence_id;
+
+DROP TABLE user_override_recurrences;
@@ -0,0 +1,15 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN snooze_until timestamp with time zone;
+
+CREATE INDEX idx_ep_state_snooze_until ON escalation_policy_state(snooze_until)
+WHERE
+    snooze_until NOTNULL;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_state
+    DROP COLUMN snooze_until;
+
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'escalation';
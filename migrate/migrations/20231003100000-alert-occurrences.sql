@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN occurrence_count integer NOT NULL DEFAULT 1,
+    ADD COLUMN last_occurrence timestamp with time zone;
+
+-- +migrate Down
+ALTER TABLE alerts
+    DROP COLUMN occurrence_count,
+    DROP COLUMN last_occurrence;
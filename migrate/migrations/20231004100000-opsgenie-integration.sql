@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'opsgenie';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'opsgenie';
+
+-- +migrate Down
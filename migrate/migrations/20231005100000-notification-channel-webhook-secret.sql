@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE notification_channels
+    ADD COLUMN webhook_secret bytea;
+
+-- +migrate Down
+ALTER TABLE notification_channels
+    DROP COLUMN webhook_secret;
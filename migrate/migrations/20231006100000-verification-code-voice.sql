@@ -0,0 +1,11 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'message';
+
+ALTER TABLE user_verification_codes
+    ADD COLUMN voice boolean NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE user_verification_codes
+    DROP COLUMN voice;
+
+UPDATE engine_processing_versions SET "version" = 9 WHERE type_id = 'message';
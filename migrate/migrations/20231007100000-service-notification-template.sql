@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN notification_template text NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN notification_template;
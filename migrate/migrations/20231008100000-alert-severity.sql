@@ -0,0 +1,27 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 6 WHERE type_id = 'escalation';
+
+CREATE TYPE enum_alert_severity AS ENUM (
+    'info',
+    'warning',
+    'critical',
+    'fatal'
+);
+
+-- existing alerts all page the same way, so they are treated as critical
+ALTER TABLE alerts
+    ADD COLUMN severity enum_alert_severity NOT NULL DEFAULT 'critical';
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN min_severity enum_alert_severity;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN min_severity;
+
+ALTER TABLE alerts
+    DROP COLUMN severity;
+
+DROP TYPE enum_alert_severity;
+
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'escalation';
@@ -0,0 +1,33 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 2 WHERE type_id = 'cleanup';
+UPDATE engine_processing_versions SET "version" = 11 WHERE type_id = 'message';
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'schedule';
+
+CREATE TABLE schedule_handoff_notices (
+    id UUID PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    on_call BOOLEAN NOT NULL,
+    shift_time TIMESTAMPTZ NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- only one pending notice (of an upcoming shift) per user and schedule
+CREATE UNIQUE INDEX idx_schedule_handoff_pending ON schedule_handoff_notices (schedule_id, user_id)
+WHERE
+    on_call AND NOT resolved;
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN schedule_handoff_notice_id UUID REFERENCES schedule_handoff_notices (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_schedule_handoff_notice_id CHECK (message_type <> 'schedule_handoff_notification' OR schedule_handoff_notice_id IS NOT NULL);
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN schedule_handoff_notice_id;
+
+DROP TABLE schedule_handoff_notices;
+
+UPDATE engine_processing_versions SET "version" = 3 WHERE type_id = 'schedule';
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'message';
+UPDATE engine_processing_versions SET "version" = 1 WHERE type_id = 'cleanup';
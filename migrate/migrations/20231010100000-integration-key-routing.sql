@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE integration_keys
+    ADD COLUMN route_label_key TEXT,
+    ADD COLUMN route_field TEXT,
+    ADD CONSTRAINT integration_keys_route_check CHECK ((route_label_key IS NULL) = (route_field IS NULL));
+
+-- +migrate Down
+ALTER TABLE integration_keys
+    DROP CONSTRAINT integration_keys_route_check,
+    DROP COLUMN route_field,
+    DROP COLUMN route_label_key;
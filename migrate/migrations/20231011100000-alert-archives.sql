@@ -0,0 +1,23 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 3 WHERE type_id = 'cleanup';
+
+CREATE TABLE alert_archives (
+    id BIGINT PRIMARY KEY,
+    service_id UUID,
+    service_name TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    source enum_alert_source NOT NULL,
+    severity enum_alert_severity NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    logs JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX idx_alert_archives_created_at ON alert_archives(created_at);
+CREATE INDEX idx_alert_archives_service_id ON alert_archives(service_id);
+
+-- +migrate Down
+DROP TABLE alert_archives;
+
+UPDATE engine_processing_versions SET "version" = 2 WHERE type_id = 'cleanup';
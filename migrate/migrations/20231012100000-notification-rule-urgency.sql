@@ -0,0 +1,28 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 3 WHERE type_id = 'np_cycle';
+
+CREATE TYPE enum_notification_rule_urgency AS ENUM (
+    'high',
+    'low'
+);
+
+ALTER TABLE user_notification_rules
+    ADD COLUMN urgency enum_notification_rule_urgency;
+
+CREATE TABLE user_urgency_windows (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    start_time TIME WITHOUT TIME ZONE NOT NULL,
+    end_time TIME WITHOUT TIME ZONE NOT NULL,
+    time_zone TEXT NOT NULL,
+    CHECK (start_time != end_time)
+);
+
+-- +migrate Down
+DROP TABLE user_urgency_windows;
+
+ALTER TABLE user_notification_rules
+    DROP COLUMN urgency;
+
+DROP TYPE enum_notification_rule_urgency;
+
+UPDATE engine_processing_versions SET "version" = 2 WHERE type_id = 'np_cycle';
@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_rotation_type ADD VALUE IF NOT EXISTS 'fixed_hours';
+
+-- +migrate Down
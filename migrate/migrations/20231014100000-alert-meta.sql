@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN meta jsonb;
+
+-- +migrate Down
+ALTER TABLE alerts
+    DROP COLUMN meta;
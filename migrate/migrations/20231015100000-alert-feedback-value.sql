@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TYPE enum_alert_feedback AS ENUM (
+    'actionable',
+    'noise'
+);
+
+ALTER TABLE alert_feedback
+    ADD COLUMN feedback enum_alert_feedback,
+    ADD COLUMN user_id uuid REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    ALTER COLUMN noise_reason SET DEFAULT '';
+
+-- existing feedback could only be recorded as a noise reason
+UPDATE
+    alert_feedback
+SET
+    feedback = 'noise';
+
+ALTER TABLE alert_feedback
+    ALTER COLUMN feedback SET NOT NULL;
+
+-- +migrate Down
+ALTER TABLE alert_feedback
+    DROP COLUMN feedback,
+    DROP COLUMN user_id,
+    DROP COLUMN updated_at,
+    ALTER COLUMN noise_reason DROP DEFAULT;
+
+DROP TYPE enum_alert_feedback;
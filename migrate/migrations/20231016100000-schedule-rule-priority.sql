@@ -0,0 +1,19 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 7 WHERE type_id = 'escalation';
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'schedule';
+
+ALTER TABLE schedule_rules
+    ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+ALTER TABLE schedule_on_call_users
+    ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE schedule_on_call_users
+    DROP COLUMN priority;
+
+ALTER TABLE schedule_rules
+    DROP COLUMN priority;
+
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'schedule';
+UPDATE engine_processing_versions SET "version" = 6 WHERE type_id = 'escalation';
@@ -0,0 +1,13 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'np_cycle';
+
+CREATE TABLE user_do_not_disturb (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE user_do_not_disturb;
+
+UPDATE engine_processing_versions SET "version" = 3 WHERE type_id = 'np_cycle';
@@ -0,0 +1,27 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 12 WHERE type_id = 'message';
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN dead_lettered_at TIMESTAMPTZ;
+
+CREATE INDEX idx_om_dead_lettered ON outgoing_messages (dead_lettered_at)
+WHERE
+    dead_lettered_at NOTNULL;
+
+CREATE TABLE outgoing_message_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES outgoing_messages (id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status enum_outgoing_messages_status NOT NULL,
+    status_details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (message_id, attempt)
+);
+
+-- +migrate Down
+DROP TABLE outgoing_message_attempts;
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN dead_lettered_at;
+
+UPDATE engine_processing_versions SET "version" = 11 WHERE type_id = 'message';
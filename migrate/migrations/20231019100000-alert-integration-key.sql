@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN integration_key_id UUID REFERENCES integration_keys (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_alert_integration_key ON alerts (integration_key_id);
+
+-- +migrate Down
+ALTER TABLE alerts
+    DROP COLUMN integration_key_id;
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_alert_event() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/alert-events', json_build_object('alert_id', NEW.alert_id, 'event', NEW.event)::text);
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_notify_alert_event
+    AFTER INSERT ON alert_logs
+    FOR EACH ROW
+    WHEN (NEW.event IN ('created', 'acknowledged', 'closed', 'escalated'))
+    EXECUTE PROCEDURE fn_notify_alert_event();
+
+-- +migrate Down
+
+DROP TRIGGER trg_notify_alert_event ON alert_logs;
+DROP FUNCTION fn_notify_alert_event();
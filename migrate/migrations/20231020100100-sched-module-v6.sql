@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 6 WHERE type_id = 'schedule';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'schedule';
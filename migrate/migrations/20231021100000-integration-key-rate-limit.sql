@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE integration_keys
+    ADD COLUMN alert_rate_limit INT CHECK (alert_rate_limit > 0),
+    ADD COLUMN dropped_alert_count BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE integration_keys
+    DROP COLUMN alert_rate_limit,
+    DROP COLUMN dropped_alert_count;
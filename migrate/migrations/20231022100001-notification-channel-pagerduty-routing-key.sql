@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE notification_channels
+    ADD COLUMN pagerduty_routing_key bytea;
+
+-- +migrate Down
+ALTER TABLE notification_channels
+    DROP COLUMN pagerduty_routing_key;
@@ -0,0 +1,38 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 8 WHERE type_id = 'escalation';
+
+CREATE TABLE business_hours(
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    description text NOT NULL DEFAULT '',
+    time_zone text NOT NULL,
+    weekday_filter boolean[] NOT NULL,
+    start_time time without time zone NOT NULL,
+    end_time time without time zone NOT NULL,
+    CHECK (array_length(weekday_filter, 1) = 7),
+    CHECK (start_time != end_time)
+);
+
+CREATE TYPE enum_business_hours_condition AS ENUM(
+    'in_hours',
+    'after_hours'
+);
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN routing_business_hours_id uuid REFERENCES business_hours(id) ON DELETE RESTRICT;
+
+ALTER TABLE escalation_policy_actions
+    ADD COLUMN business_hours_condition enum_business_hours_condition;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_actions
+    DROP COLUMN business_hours_condition;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN routing_business_hours_id;
+
+DROP TYPE enum_business_hours_condition;
+
+DROP TABLE business_hours;
+
+UPDATE engine_processing_versions SET "version" = 7 WHERE type_id = 'escalation';
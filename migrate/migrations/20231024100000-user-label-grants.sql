@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE user_label_grants(
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key text NOT NULL,
+    value text NOT NULL,
+    PRIMARY KEY (user_id, key, value)
+);
+
+-- +migrate Down
+DROP TABLE user_label_grants;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN runbook_url text NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN runbook_url;
@@ -0,0 +1,17 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 9 WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policies
+    ADD COLUMN ack_timeout_minutes integer NOT NULL DEFAULT 0;
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN acknowledged_at timestamp with time zone;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_state
+    DROP COLUMN acknowledged_at;
+
+ALTER TABLE escalation_policies
+    DROP COLUMN ack_timeout_minutes;
+
+UPDATE engine_processing_versions SET "version" = 8 WHERE type_id = 'escalation';
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE keyring
+    ADD COLUMN retire_times bytea;
+
+-- +migrate Down
+ALTER TABLE keyring
+    DROP COLUMN retire_times;
@@ -0,0 +1,11 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'escalation';
+
+ALTER TABLE services
+    ADD COLUMN notification_delay_minutes int NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN notification_delay_minutes;
+
+UPDATE engine_processing_versions SET "version" = 9 WHERE type_id = 'escalation';
@@ -0,0 +1,12 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 3 WHERE type_id = 'rotation';
+
+ALTER TABLE rotation_participants
+    ADD COLUMN weight int NOT NULL DEFAULT 1,
+    ADD CONSTRAINT rotation_participants_weight_check CHECK (weight > 0);
+
+-- +migrate Down
+ALTER TABLE rotation_participants
+    DROP COLUMN weight;
+
+UPDATE engine_processing_versions SET "version" = 2 WHERE type_id = 'rotation';
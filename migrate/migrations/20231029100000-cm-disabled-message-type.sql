@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_outgoing_messages_type ADD VALUE IF NOT EXISTS 'contact_method_disabled_notification';
+
+-- +migrate Down
@@ -0,0 +1,20 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 13 WHERE type_id = 'message';
+
+ALTER TABLE user_contact_methods
+    ADD COLUMN consecutive_failures int NOT NULL DEFAULT 0,
+    ADD COLUMN auto_disabled_at timestamptz;
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN disabled_contact_method_id UUID REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_disabled_contact_method_id CHECK (message_type <> 'contact_method_disabled_notification' OR disabled_contact_method_id IS NOT NULL);
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN disabled_contact_method_id;
+
+ALTER TABLE user_contact_methods
+    DROP COLUMN consecutive_failures,
+    DROP COLUMN auto_disabled_at;
+
+UPDATE engine_processing_versions SET "version" = 12 WHERE type_id = 'message';
@@ -0,0 +1,12 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type
+ADD VALUE IF NOT EXISTS 'incident';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('incident', 1) ON CONFLICT DO NOTHING;
+
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'incident_acknowledged';
+
+-- +migrate Down
+DELETE FROM engine_processing_versions
+WHERE type_id = 'incident';
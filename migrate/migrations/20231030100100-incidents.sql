@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE incident_correlation_rules(
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    key text NOT NULL,
+    window_minutes int NOT NULL CHECK (window_minutes > 0),
+    grouped_ack boolean NOT NULL DEFAULT FALSE,
+    processed_alert_id bigint NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE incidents(
+    id uuid PRIMARY KEY,
+    summary text NOT NULL,
+    grouped_ack boolean NOT NULL DEFAULT FALSE,
+    correlation_rule_id uuid REFERENCES incident_correlation_rules(id) ON DELETE SET NULL,
+    correlation_value text,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_incidents_correlation ON incidents(correlation_rule_id, correlation_value);
+
+CREATE TABLE incident_alerts(
+    alert_id bigint PRIMARY KEY REFERENCES alerts(id) ON DELETE CASCADE,
+    incident_id uuid NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    added_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_incident_alerts_incident_id ON incident_alerts(incident_id);
+
+-- +migrate Down
+DROP TABLE incident_alerts;
+DROP TABLE incidents;
+DROP TABLE incident_correlation_rules;
@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'service_paused';
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'service_resumed';
+
+-- +migrate Down
@@ -0,0 +1,11 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 11 WHERE type_id = 'escalation';
+ALTER TABLE services
+    ADD COLUMN paused_at timestamp with time zone,
+    ADD COLUMN paused_by_user_id uuid REFERENCES users(id) ON DELETE SET NULL;
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'escalation';
+ALTER TABLE services
+    DROP COLUMN paused_at,
+    DROP COLUMN paused_by_user_id;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE notification_channels
+    ADD COLUMN webhook_secret_prev bytea,
+    ADD COLUMN webhook_secret_prev_expires_at timestamptz;
+
+-- +migrate Down
+ALTER TABLE notification_channels
+    DROP COLUMN webhook_secret_prev,
+    DROP COLUMN webhook_secret_prev_expires_at;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TYPE enum_alert_close_reason AS ENUM (
+    'duplicate',
+    'falsePositive',
+    'resolved',
+    'wontfix'
+);
+
+ALTER TABLE alerts
+    ADD COLUMN close_reason enum_alert_close_reason;
+
+ALTER TABLE services
+    ADD COLUMN require_close_reason boolean NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN require_close_reason;
+
+ALTER TABLE alerts
+    DROP COLUMN close_reason;
+
+DROP TYPE enum_alert_close_reason;
@@ -0,0 +1,30 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 13 WHERE type_id = 'escalation';
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'np_cycle';
+
+CREATE TABLE rotation_shadows (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    rotation_id uuid NOT NULL REFERENCES rotations (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (rotation_id, user_id)
+);
+
+ALTER TABLE notification_policy_cycles
+    ADD COLUMN shadow boolean NOT NULL DEFAULT FALSE;
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN shadow boolean NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN shadow;
+
+ALTER TABLE notification_policy_cycles
+    DROP COLUMN shadow;
+
+DROP TABLE rotation_shadows;
+
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'np_cycle';
+UPDATE engine_processing_versions SET "version" = 12 WHERE type_id = 'escalation';
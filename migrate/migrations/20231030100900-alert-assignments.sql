@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE alert_assignments (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    assigned_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_alert_assignments_user_id ON alert_assignments (user_id);
+
+-- +migrate Down
+DROP TABLE alert_assignments;
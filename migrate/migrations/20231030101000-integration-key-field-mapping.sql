@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE integration_keys
+    ADD COLUMN field_mapping JSONB;
+
+-- +migrate Down
+ALTER TABLE integration_keys
+    DROP COLUMN field_mapping;
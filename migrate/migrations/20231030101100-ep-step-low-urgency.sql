@@ -0,0 +1,19 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 14 WHERE type_id = 'escalation';
+UPDATE engine_processing_versions SET "version" = 6 WHERE type_id = 'np_cycle';
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN low_urgency boolean NOT NULL DEFAULT FALSE;
+
+ALTER TABLE notification_policy_cycles
+    ADD COLUMN low_urgency boolean NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE notification_policy_cycles
+    DROP COLUMN low_urgency;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN low_urgency;
+
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'np_cycle';
+UPDATE engine_processing_versions SET "version" = 13 WHERE type_id = 'escalation';
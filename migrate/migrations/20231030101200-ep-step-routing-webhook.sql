@@ -0,0 +1,20 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 15 WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN routing_webhook_url text;
+
+CREATE TABLE ep_step_routing_requests(
+    alert_id bigint NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    ep_step_id uuid NOT NULL REFERENCES escalation_policy_steps(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (alert_id, ep_step_id)
+);
+
+-- +migrate Down
+DROP TABLE ep_step_routing_requests;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN routing_webhook_url;
+
+UPDATE engine_processing_versions SET "version" = 14 WHERE type_id = 'escalation';
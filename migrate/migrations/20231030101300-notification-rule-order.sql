@@ -0,0 +1,21 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 7 WHERE type_id = 'np_cycle';
+
+ALTER TABLE user_notification_rules
+    ADD COLUMN position integer NOT NULL DEFAULT 0,
+    ADD COLUMN stop_on_success boolean NOT NULL DEFAULT FALSE;
+
+UPDATE user_notification_rules rule
+SET position = ordered.position
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY delay_minutes, created_at, id) - 1 AS position
+    FROM user_notification_rules
+) ordered
+WHERE ordered.id = rule.id;
+
+-- +migrate Down
+ALTER TABLE user_notification_rules
+    DROP COLUMN stop_on_success,
+    DROP COLUMN position;
+
+UPDATE engine_processing_versions SET "version" = 6 WHERE type_id = 'np_cycle';
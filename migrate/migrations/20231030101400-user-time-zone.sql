@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN time_zone text;
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN time_zone;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE integration_keys
+    ADD COLUMN dedup_window_minutes INT CHECK (dedup_window_minutes > 0);
+
+-- +migrate Down
+ALTER TABLE integration_keys
+    DROP COLUMN dedup_window_minutes;
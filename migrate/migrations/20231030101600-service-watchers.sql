@@ -0,0 +1,22 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 5 WHERE type_id = 'status_update';
+
+CREATE TABLE service_watchers (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_id uuid NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    contact_method_id uuid NOT NULL REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (service_id, user_id)
+);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN watcher boolean NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN watcher;
+
+DROP TABLE service_watchers;
+
+UPDATE engine_processing_versions SET "version" = 4 WHERE type_id = 'status_update';
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE audit_logs (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    actor_user_id uuid,
+    actor_source text NOT NULL DEFAULT '',
+    action text NOT NULL,
+    entity_type text NOT NULL,
+    entity_id text NOT NULL DEFAULT '',
+    before_data jsonb,
+    after_data jsonb,
+    error text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_audit_logs_actor ON audit_logs (actor_user_id, id);
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, id);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_audit_logs_immutable() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        RAISE EXCEPTION 'audit log entries cannot be modified';
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_audit_logs_immutable
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW
+    EXECUTE PROCEDURE fn_audit_logs_immutable();
+
+-- +migrate Down
+DROP TRIGGER trg_audit_logs_immutable ON audit_logs;
+DROP FUNCTION fn_audit_logs_immutable();
+DROP TABLE audit_logs;
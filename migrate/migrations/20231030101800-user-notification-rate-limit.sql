@@ -0,0 +1,11 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 14 WHERE type_id = 'message';
+
+ALTER TABLE users
+    ADD COLUMN max_notifications_per_hour int CHECK (max_notifications_per_hour >= 0);
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN max_notifications_per_hour;
+
+UPDATE engine_processing_versions SET "version" = 13 WHERE type_id = 'message';
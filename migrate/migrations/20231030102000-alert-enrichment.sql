@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN enrichment_url text NOT NULL DEFAULT '';
+
+CREATE TABLE alert_enrichments (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    error text NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+DROP TABLE alert_enrichments;
+
+ALTER TABLE services
+    DROP COLUMN enrichment_url;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE integration_keys
+    ADD COLUMN default_labels JSONB;
+
+-- +migrate Down
+ALTER TABLE integration_keys
+    DROP COLUMN default_labels;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE outgoing_messages
+    ADD COLUMN replay_of_id UUID REFERENCES outgoing_messages(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_om_replay_of_id ON outgoing_messages(replay_of_id);
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN replay_of_id;
@@ -0,0 +1,20 @@
+-- +migrate Up notransaction
+UPDATE engine_processing_versions SET "version" = 16 WHERE type_id = 'escalation';
+
+ALTER TYPE engine_processing_type
+ADD VALUE IF NOT EXISTS 'conference_bridge';
+
+ALTER TYPE enum_outgoing_messages_type
+ADD VALUE IF NOT EXISTS 'alert_bridge_invite';
+
+ALTER TYPE enum_alert_log_event
+ADD VALUE IF NOT EXISTS 'conference_bridge';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('conference_bridge', 1) ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+DELETE FROM engine_processing_versions
+WHERE type_id = 'conference_bridge';
+
+UPDATE engine_processing_versions SET "version" = 15 WHERE type_id = 'escalation';
@@ -0,0 +1,33 @@
+-- +migrate Up
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN conference_bridge boolean NOT NULL DEFAULT FALSE;
+
+CREATE TABLE alert_conference_bridges (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    announced_at timestamptz,
+    conference_sid text,
+    ended_at timestamptz
+);
+
+CREATE TABLE alert_conference_participants (
+    alert_id bigint NOT NULL REFERENCES alert_conference_bridges (alert_id) ON DELETE CASCADE,
+    call_sid text NOT NULL,
+    user_id uuid REFERENCES users (id) ON DELETE SET NULL,
+    contact_method_id uuid REFERENCES user_contact_methods (id) ON DELETE SET NULL,
+    joined_at timestamptz NOT NULL DEFAULT now(),
+    left_at timestamptz,
+    PRIMARY KEY (alert_id, call_sid)
+);
+
+CREATE INDEX idx_alert_conference_bridges_open ON alert_conference_bridges (alert_id)
+WHERE
+    ended_at IS NULL;
+
+-- +migrate Down
+DROP TABLE alert_conference_participants;
+
+DROP TABLE alert_conference_bridges;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN conference_bridge;
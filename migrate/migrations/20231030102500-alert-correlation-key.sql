@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN correlation_key text;
+
+CREATE INDEX idx_alert_correlation_key ON alerts (correlation_key)
+WHERE
+    correlation_key IS NOT NULL;
+
+-- +migrate Down
+ALTER TABLE alerts
+    DROP COLUMN correlation_key;
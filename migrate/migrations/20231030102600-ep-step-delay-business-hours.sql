@@ -0,0 +1,15 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 17 WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN off_hours_delay integer,
+    ADD COLUMN delay_business_hours_id uuid REFERENCES business_hours(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT escalation_policy_steps_off_hours_delay_check CHECK ((off_hours_delay ISNULL) = (delay_business_hours_id ISNULL));
+
+-- +migrate Down
+ALTER TABLE escalation_policy_steps
+    DROP CONSTRAINT escalation_policy_steps_off_hours_delay_check,
+    DROP COLUMN off_hours_delay,
+    DROP COLUMN delay_business_hours_id;
+
+UPDATE engine_processing_versions SET "version" = 16 WHERE type_id = 'escalation';
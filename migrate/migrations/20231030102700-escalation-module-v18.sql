@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 18 WHERE type_id = 'escalation';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 17 WHERE type_id = 'escalation';
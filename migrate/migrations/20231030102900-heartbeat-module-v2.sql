@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 2 WHERE type_id = 'heartbeat';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 1 WHERE type_id = 'heartbeat';
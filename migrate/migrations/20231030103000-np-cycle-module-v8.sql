@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 8 WHERE type_id = 'np_cycle';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 7 WHERE type_id = 'np_cycle';
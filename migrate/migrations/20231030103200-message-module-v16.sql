@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 16 WHERE type_id = 'message';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 15 WHERE type_id = 'message';
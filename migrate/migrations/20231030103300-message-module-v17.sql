@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 17 WHERE type_id = 'message';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 16 WHERE type_id = 'message';
@@ -0,0 +1,11 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 21 WHERE type_id = 'escalation';
+
+ALTER TABLE ep_step_routing_requests
+    ADD COLUMN claimed_at timestamptz;
+
+-- +migrate Down
+ALTER TABLE ep_step_routing_requests
+    DROP COLUMN claimed_at;
+
+UPDATE engine_processing_versions SET "version" = 20 WHERE type_id = 'escalation';
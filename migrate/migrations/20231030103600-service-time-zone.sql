@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN time_zone text NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN time_zone;
@@ -0,0 +1,38 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 9 WHERE type_id = 'np_cycle';
+
+-- keep existing quiet hours in the same zone for users without one
+UPDATE users u
+SET time_zone = r.quiet_hours_time_zone
+FROM user_notification_rules r
+WHERE
+    r.user_id = u.id
+    AND u.time_zone ISNULL
+    AND r.quiet_hours_time_zone NOTNULL;
+
+ALTER TABLE user_notification_rules
+    DROP CONSTRAINT user_notification_rules_quiet_hours_check,
+    DROP COLUMN quiet_hours_time_zone,
+    ADD COLUMN quiet_hours_allow_high_urgency boolean NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT user_notification_rules_quiet_hours_check CHECK (
+        (quiet_hours_start ISNULL AND quiet_hours_end ISNULL AND NOT quiet_hours_allow_high_urgency)
+        OR (quiet_hours_start NOTNULL AND quiet_hours_end NOTNULL)
+    );
+
+-- +migrate Down
+ALTER TABLE user_notification_rules
+    DROP CONSTRAINT user_notification_rules_quiet_hours_check,
+    DROP COLUMN quiet_hours_allow_high_urgency,
+    ADD COLUMN quiet_hours_time_zone text;
+
+UPDATE user_notification_rules r
+SET quiet_hours_time_zone = coalesce((SELECT time_zone FROM users u WHERE u.id = r.user_id), 'UTC')
+WHERE quiet_hours_start NOTNULL;
+
+ALTER TABLE user_notification_rules
+    ADD CONSTRAINT user_notification_rules_quiet_hours_check CHECK (
+        (quiet_hours_start ISNULL AND quiet_hours_end ISNULL AND quiet_hours_time_zone ISNULL)
+        OR (quiet_hours_start NOTNULL AND quiet_hours_end NOTNULL AND quiet_hours_time_zone NOTNULL)
+    );
+
+UPDATE engine_processing_versions SET "version" = 8 WHERE type_id = 'np_cycle';
@@ -0,0 +1,25 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'np_cycle';
+
+-- keep existing windows in the same zone for users without one
+UPDATE users u
+SET time_zone = win.time_zone
+FROM user_urgency_windows win
+WHERE
+    win.user_id = u.id
+    AND u.time_zone ISNULL;
+
+ALTER TABLE user_urgency_windows
+    DROP COLUMN time_zone;
+
+-- +migrate Down
+ALTER TABLE user_urgency_windows
+    ADD COLUMN time_zone text;
+
+UPDATE user_urgency_windows win
+SET time_zone = coalesce((SELECT time_zone FROM users u WHERE u.id = win.user_id), 'UTC');
+
+ALTER TABLE user_urgency_windows
+    ALTER COLUMN time_zone SET NOT NULL;
+
+UPDATE engine_processing_versions SET "version" = 9 WHERE type_id = 'np_cycle';
@@ -0,0 +1,7 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 22 WHERE type_id = 'escalation';
+ALTER TABLE escalation_policy_steps DROP COLUMN start_severity;
+
+-- +migrate Down
+ALTER TABLE escalation_policy_steps ADD COLUMN start_severity enum_alert_severity;
+UPDATE engine_processing_versions SET "version" = 21 WHERE type_id = 'escalation';
@@ -0,0 +1,5 @@
+-- +migrate Up
+UPDATE engine_processing_versions SET "version" = 11 WHERE type_id = 'np_cycle';
+
+-- +migrate Down
+UPDATE engine_processing_versions SET "version" = 10 WHERE type_id = 'np_cycle';
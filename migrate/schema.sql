@@ -1,7 +1,7 @@
 -- This file is auto-generated by "make db-schema"; DO NOT EDIT
--- DATA=dbbdca49a8edf8bb0b28f98d2fd10e27337a8d307c7d282e5e7c79ee32532c6c  -
--- DISK=ae69f63824f64c19eb35165923710c91e6db1acf67c4c4eaede15cd55112308c  -
--- PSQL=ae69f63824f64c19eb35165923710c91e6db1acf67c4c4eaede15cd55112308c  -
+-- DATA=97a57aa4dacc8fd3aa2572202455213d15e73ef7758174a715c82b5bba16bc95  -
+-- DISK=7abfc2dff8016362d41dd433dae68573d457e348338fe5345362b669d0b0072a  -
+-- PSQL=7abfc2dff8016362d41dd433dae68573d457e348338fe5345362b669d0b0072a  -
 --
 -- pgdump-lite database dump
 --
@@ -15,8 +15,11 @@ CREATE EXTENSION IF NOT EXISTS pgcrypto;
 CREATE TYPE engine_processing_type AS ENUM (
 	'cleanup',
 	'compat',
+	'conference_bridge',
+	'enrichment',
 	'escalation',
 	'heartbeat',
+	'incident',
 	'message',
 	'metrics',
 	'np_cycle',
@@ -26,20 +29,42 @@ CREATE TYPE engine_processing_type AS ENUM (
 	'verify'
 );
 
+CREATE TYPE enum_alert_close_reason AS ENUM (
+	'duplicate',
+	'falsePositive',
+	'resolved',
+	'wontfix'
+);
+
+CREATE TYPE enum_alert_feedback AS ENUM (
+	'actionable',
+	'noise'
+);
+
 CREATE TYPE enum_alert_log_event AS ENUM (
+	'ack_timeout',
 	'acknowledged',
 	'assignment_changed',
 	'closed',
+	'conference_bridge',
 	'created',
 	'duplicate_suppressed',
 	'escalated',
+	'escalation_exhausted',
 	'escalation_request',
+	'incident_acknowledged',
+	'maintenance_suppressed',
 	'no_notification_sent',
+	'note',
 	'notification_sent',
 	'policy_updated',
 	'reopened',
 	'response_received',
-	'status_changed'
+	'service_paused',
+	'service_resumed',
+	'snoozed',
+	'status_changed',
+	'unsnoozed'
 );
 
 CREATE TYPE enum_alert_log_subject_type AS ENUM (
@@ -49,11 +74,19 @@ CREATE TYPE enum_alert_log_subject_type AS ENUM (
 	'user'
 );
 
+CREATE TYPE enum_alert_severity AS ENUM (
+	'critical',
+	'fatal',
+	'info',
+	'warning'
+);
+
 CREATE TYPE enum_alert_source AS ENUM (
 	'email',
 	'generic',
 	'grafana',
 	'manual',
+	'opsgenie',
 	'prometheusAlertmanager',
 	'site24x7'
 );
@@ -64,16 +97,28 @@ CREATE TYPE enum_alert_status AS ENUM (
 	'triggered'
 );
 
+CREATE TYPE enum_business_hours_condition AS ENUM (
+	'after_hours',
+	'in_hours'
+);
+
+CREATE TYPE enum_ep_step_assignment_strategy AS ENUM (
+	'round_robin',
+	'sequential'
+);
+
 CREATE TYPE enum_heartbeat_state AS ENUM (
 	'healthy',
 	'inactive',
-	'unhealthy'
+	'unhealthy',
+	'warning'
 );
 
 CREATE TYPE enum_integration_keys_type AS ENUM (
 	'email',
 	'generic',
 	'grafana',
+	'opsgenie',
 	'prometheusAlertmanager',
 	'site24x7'
 );
@@ -94,11 +139,18 @@ CREATE TYPE enum_limit_type AS ENUM (
 );
 
 CREATE TYPE enum_notif_channel_type AS ENUM (
+	'MS_TEAMS',
+	'PAGERDUTY',
 	'SLACK',
 	'SLACK_USER_GROUP',
 	'WEBHOOK'
 );
 
+CREATE TYPE enum_notification_rule_urgency AS ENUM (
+	'high',
+	'low'
+);
+
 CREATE TYPE enum_outgoing_messages_status AS ENUM (
 	'bundled',
 	'delivered',
@@ -110,10 +162,13 @@ CREATE TYPE enum_outgoing_messages_status AS ENUM (
 );
 
 CREATE TYPE enum_outgoing_messages_type AS ENUM (
+	'alert_bridge_invite',
 	'alert_notification',
 	'alert_notification_bundle',
 	'alert_status_update',
 	'alert_status_update_bundle',
+	'contact_method_disabled_notification',
+	'schedule_handoff_notification',
 	'schedule_on_call_notification',
 	'test_notification',
 	'verification_message'
@@ -121,6 +176,7 @@ CREATE TYPE enum_outgoing_messages_type AS ENUM (
 
 CREATE TYPE enum_rotation_type AS ENUM (
 	'daily',
+	'fixed_hours',
 	'hourly',
 	'monthly',
 	'weekly'
@@ -142,6 +198,7 @@ CREATE TYPE enum_user_contact_method_type AS ENUM (
 	'PUSH',
 	'SLACK_DM',
 	'SMS',
+	'TELEGRAM',
 	'VOICE',
 	'WEBHOOK'
 );
@@ -246,11 +303,28 @@ END;
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.fn_audit_logs_immutable()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+    BEGIN
+        RAISE EXCEPTION 'audit log entries cannot be modified';
+    END;
+    $function$
+;
+
 CREATE OR REPLACE FUNCTION public.fn_clear_dedup_on_close()
  RETURNS trigger
  LANGUAGE plpgsql
 AS $function$
 BEGIN
+    IF OLD.dedup_key NOTNULL AND NEW.service_id NOTNULL THEN
+        INSERT INTO alert_closed_dedup (service_id, dedup_key, alert_id, closed_at)
+        VALUES (NEW.service_id, OLD.dedup_key, NEW.id, now())
+        ON CONFLICT (service_id, dedup_key) DO UPDATE
+        SET alert_id = excluded.alert_id, closed_at = excluded.closed_at;
+    END IF;
+
     NEW.dedup_key = NULL;
     RETURN NEW;
 END;
@@ -1017,6 +1091,17 @@ END;
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.fn_notify_alert_event()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+    BEGIN
+        PERFORM pg_notify('/goalert/alert-events', json_build_object('alert_id', NEW.alert_id, 'event', NEW.event)::text);
+        RETURN NEW;
+    END;
+    $function$
+;
+
 CREATE OR REPLACE FUNCTION public.fn_notify_config_refresh()
  RETURNS trigger
  LANGUAGE plpgsql
@@ -1254,13 +1339,104 @@ AS $function$
 
 -- Tables
 
+CREATE TABLE alert_archives (
+	archived_at timestamp with time zone DEFAULT now() NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	details text DEFAULT ''::text NOT NULL,
+	id bigint NOT NULL,
+	logs jsonb DEFAULT '[]'::jsonb NOT NULL,
+	service_id uuid,
+	service_name text DEFAULT ''::text NOT NULL,
+	severity enum_alert_severity NOT NULL,
+	source enum_alert_source NOT NULL,
+	summary text NOT NULL,
+	CONSTRAINT alert_archives_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX alert_archives_pkey ON public.alert_archives USING btree (id);
+CREATE INDEX idx_alert_archives_created_at ON public.alert_archives USING btree (created_at);
+CREATE INDEX idx_alert_archives_service_id ON public.alert_archives USING btree (service_id);
+
+
+CREATE TABLE alert_assignments (
+	alert_id bigint NOT NULL,
+	assigned_at timestamp with time zone DEFAULT now() NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT alert_assignments_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_assignments_pkey PRIMARY KEY (alert_id),
+	CONSTRAINT alert_assignments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX alert_assignments_pkey ON public.alert_assignments USING btree (alert_id);
+CREATE INDEX idx_alert_assignments_user_id ON public.alert_assignments USING btree (user_id);
+
+
+CREATE TABLE alert_closed_dedup (
+	alert_id bigint NOT NULL,
+	closed_at timestamp with time zone DEFAULT now() NOT NULL,
+	dedup_key text NOT NULL,
+	service_id uuid NOT NULL,
+	CONSTRAINT alert_closed_dedup_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_closed_dedup_pkey PRIMARY KEY (service_id, dedup_key),
+	CONSTRAINT alert_closed_dedup_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX alert_closed_dedup_pkey ON public.alert_closed_dedup USING btree (service_id, dedup_key);
+CREATE INDEX idx_alert_closed_dedup_alert_id ON public.alert_closed_dedup USING btree (alert_id);
+
+
+CREATE TABLE alert_conference_bridges (
+	alert_id bigint NOT NULL,
+	announced_at timestamp with time zone,
+	conference_sid text,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	ended_at timestamp with time zone,
+	CONSTRAINT alert_conference_bridges_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_conference_bridges_pkey PRIMARY KEY (alert_id)
+);
+
+CREATE UNIQUE INDEX alert_conference_bridges_pkey ON public.alert_conference_bridges USING btree (alert_id);
+CREATE INDEX idx_alert_conference_bridges_open ON public.alert_conference_bridges USING btree (alert_id) WHERE (ended_at IS NULL);
+
+
+CREATE TABLE alert_conference_participants (
+	alert_id bigint NOT NULL,
+	call_sid text NOT NULL,
+	contact_method_id uuid,
+	joined_at timestamp with time zone DEFAULT now() NOT NULL,
+	left_at timestamp with time zone,
+	user_id uuid,
+	CONSTRAINT alert_conference_participants_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alert_conference_bridges(alert_id) ON DELETE CASCADE,
+	CONSTRAINT alert_conference_participants_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE SET NULL,
+	CONSTRAINT alert_conference_participants_pkey PRIMARY KEY (alert_id, call_sid),
+	CONSTRAINT alert_conference_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX alert_conference_participants_pkey ON public.alert_conference_participants USING btree (alert_id, call_sid);
+
+
+CREATE TABLE alert_enrichments (
+	alert_id bigint NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	error text DEFAULT ''::text NOT NULL,
+	CONSTRAINT alert_enrichments_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_enrichments_pkey PRIMARY KEY (alert_id)
+);
+
+CREATE UNIQUE INDEX alert_enrichments_pkey ON public.alert_enrichments USING btree (alert_id);
+
+
 CREATE TABLE alert_feedback (
 	alert_id bigint NOT NULL,
+	feedback enum_alert_feedback NOT NULL,
 	id bigint DEFAULT nextval('alert_feedback_id_seq'::regclass) NOT NULL,
-	noise_reason text NOT NULL,
+	noise_reason text DEFAULT ''::text NOT NULL,
+	updated_at timestamp with time zone DEFAULT now() NOT NULL,
+	user_id uuid,
 	CONSTRAINT alert_feedback_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
 	CONSTRAINT alert_feedback_id_key UNIQUE (id),
-	CONSTRAINT alert_feedback_pkey PRIMARY KEY (alert_id)
+	CONSTRAINT alert_feedback_pkey PRIMARY KEY (alert_id),
+	CONSTRAINT alert_feedback_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
 );
 
 CREATE UNIQUE INDEX alert_feedback_id_key ON public.alert_feedback USING btree (id);
@@ -1292,6 +1468,8 @@ CREATE INDEX idx_alert_logs_int_id ON public.alert_logs USING btree (sub_integra
 CREATE INDEX idx_alert_logs_user_id ON public.alert_logs USING btree (sub_user_id);
 CREATE INDEX idx_closed_events ON public.alert_logs USING btree ("timestamp") WHERE (event = 'closed'::enum_alert_log_event);
 
+CREATE TRIGGER trg_notify_alert_event AFTER INSERT ON public.alert_logs FOR EACH ROW WHEN ((new.event = ANY (ARRAY['created'::enum_alert_log_event, 'acknowledged'::enum_alert_log_event, 'closed'::enum_alert_log_event, 'escalated'::enum_alert_log_event]))) EXECUTE FUNCTION fn_notify_alert_event();
+
 
 CREATE TABLE alert_metrics (
 	alert_id bigint NOT NULL,
@@ -1330,17 +1508,25 @@ CREATE UNIQUE INDEX alert_status_subscriptions_pkey ON public.alert_status_subsc
 
 
 CREATE TABLE alerts (
+	close_reason enum_alert_close_reason,
+	correlation_key text,
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	dedup_key text,
 	details text DEFAULT ''::text NOT NULL,
 	escalation_level integer DEFAULT 0 NOT NULL,
 	id bigint DEFAULT nextval('alerts_id_seq'::regclass) NOT NULL,
+	integration_key_id uuid,
 	last_escalation timestamp with time zone DEFAULT now(),
+	last_occurrence timestamp with time zone,
 	last_processed timestamp with time zone,
+	meta jsonb,
+	occurrence_count integer DEFAULT 1 NOT NULL,
 	service_id uuid,
+	severity enum_alert_severity DEFAULT 'critical'::enum_alert_severity NOT NULL,
 	source enum_alert_source DEFAULT 'manual'::enum_alert_source NOT NULL,
 	status enum_alert_status DEFAULT 'triggered'::enum_alert_status NOT NULL,
 	summary text NOT NULL,
+	CONSTRAINT alerts_integration_key_id_fkey FOREIGN KEY (integration_key_id) REFERENCES integration_keys(id) ON DELETE SET NULL,
 	CONSTRAINT alerts_pkey PRIMARY KEY (id),
 	CONSTRAINT alerts_services_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
 	CONSTRAINT dedup_key_only_for_open_alerts CHECK ((status = 'closed'::enum_alert_status) = (dedup_key IS NULL))
@@ -1348,6 +1534,8 @@ CREATE TABLE alerts (
 
 CREATE UNIQUE INDEX alerts_pkey ON public.alerts USING btree (id);
 CREATE INDEX idx_alert_cleanup ON public.alerts USING btree (id, created_at) WHERE (status = 'closed'::enum_alert_status);
+CREATE INDEX idx_alert_correlation_key ON public.alerts USING btree (correlation_key) WHERE (correlation_key IS NOT NULL);
+CREATE INDEX idx_alert_integration_key ON public.alerts USING btree (integration_key_id);
 CREATE INDEX idx_alert_service_id ON public.alerts USING btree (service_id);
 CREATE INDEX idx_dedup_alerts ON public.alerts USING btree (dedup_key);
 CREATE UNIQUE INDEX idx_no_alert_duplicates ON public.alerts USING btree (service_id, dedup_key);
@@ -1362,6 +1550,27 @@ CREATE CONSTRAINT TRIGGER trg_enforce_alert_limit AFTER INSERT ON public.alerts
 CREATE TRIGGER trg_prevent_reopen BEFORE UPDATE OF status ON public.alerts FOR EACH ROW EXECUTE FUNCTION fn_prevent_reopen();
 
 
+CREATE TABLE audit_logs (
+	action text NOT NULL,
+	actor_source text DEFAULT ''::text NOT NULL,
+	actor_user_id uuid,
+	after_data jsonb,
+	before_data jsonb,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	entity_id text DEFAULT ''::text NOT NULL,
+	entity_type text NOT NULL,
+	error text DEFAULT ''::text NOT NULL,
+	id bigint DEFAULT nextval('audit_logs_id_seq'::regclass) NOT NULL,
+	CONSTRAINT audit_logs_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX audit_logs_pkey ON public.audit_logs USING btree (id);
+CREATE INDEX idx_audit_logs_actor ON public.audit_logs USING btree (actor_user_id, id);
+CREATE INDEX idx_audit_logs_entity ON public.audit_logs USING btree (entity_type, entity_id, id);
+
+CREATE TRIGGER trg_audit_logs_immutable BEFORE DELETE OR UPDATE ON public.audit_logs FOR EACH ROW EXECUTE FUNCTION fn_audit_logs_immutable();
+
+
 CREATE TABLE auth_basic_users (
 	id bigint DEFAULT nextval('auth_basic_users_id_seq'::regclass) NOT NULL,
 	password_hash text NOT NULL,
@@ -1431,6 +1640,24 @@ CREATE TABLE auth_user_sessions (
 CREATE UNIQUE INDEX auth_user_sessions_pkey ON public.auth_user_sessions USING btree (id);
 
 
+CREATE TABLE business_hours (
+	description text DEFAULT ''::text NOT NULL,
+	end_time time without time zone NOT NULL,
+	id uuid NOT NULL,
+	name text NOT NULL,
+	start_time time without time zone NOT NULL,
+	time_zone text NOT NULL,
+	weekday_filter boolean[] NOT NULL,
+	CONSTRAINT business_hours_check CHECK ((start_time <> end_time)),
+	CONSTRAINT business_hours_name_key UNIQUE (name),
+	CONSTRAINT business_hours_pkey PRIMARY KEY (id),
+	CONSTRAINT business_hours_weekday_filter_check CHECK ((array_length(weekday_filter, 1) = 7))
+);
+
+CREATE UNIQUE INDEX business_hours_name_key ON public.business_hours USING btree (name);
+CREATE UNIQUE INDEX business_hours_pkey ON public.business_hours USING btree (id);
+
+
 CREATE TABLE config (
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	data bytea NOT NULL,
@@ -1478,9 +1705,36 @@ CREATE UNIQUE INDEX ep_step_on_call_users_uniq_id ON public.ep_step_on_call_user
 CREATE UNIQUE INDEX idx_ep_step_on_call ON public.ep_step_on_call_users USING btree (user_id, ep_step_id) WHERE (end_time IS NULL);
 
 
+CREATE TABLE ep_step_round_robin_state (
+	ep_step_id uuid NOT NULL,
+	position integer DEFAULT 0 NOT NULL,
+	rotation_id uuid NOT NULL,
+	CONSTRAINT ep_step_round_robin_state_ep_step_id_fkey FOREIGN KEY (ep_step_id) REFERENCES escalation_policy_steps(id) ON DELETE CASCADE,
+	CONSTRAINT ep_step_round_robin_state_pkey PRIMARY KEY (ep_step_id, rotation_id),
+	CONSTRAINT ep_step_round_robin_state_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ep_step_round_robin_state_pkey ON public.ep_step_round_robin_state USING btree (ep_step_id, rotation_id);
+
+
+CREATE TABLE ep_step_routing_requests (
+	alert_id bigint NOT NULL,
+	claimed_at timestamp with time zone,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	ep_step_id uuid NOT NULL,
+	CONSTRAINT ep_step_routing_requests_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT ep_step_routing_requests_ep_step_id_fkey FOREIGN KEY (ep_step_id) REFERENCES escalation_policy_steps(id) ON DELETE CASCADE,
+	CONSTRAINT ep_step_routing_requests_pkey PRIMARY KEY (alert_id, ep_step_id)
+);
+
+CREATE UNIQUE INDEX ep_step_routing_requests_pkey ON public.ep_step_routing_requests USING btree (alert_id, ep_step_id);
+
+
 CREATE TABLE escalation_policies (
+	ack_timeout_minutes integer DEFAULT 0 NOT NULL,
 	description text DEFAULT ''::text NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	max_notifications integer DEFAULT 0 NOT NULL,
 	name text NOT NULL,
 	repeat integer DEFAULT 0 NOT NULL,
 	step_count integer DEFAULT 0 NOT NULL,
@@ -1496,6 +1750,7 @@ CREATE INDEX idx_search_escalation_policies_name_eng ON public.escalation_polici
 
 
 CREATE TABLE escalation_policy_actions (
+	business_hours_condition enum_business_hours_condition,
 	channel_id uuid,
 	escalation_policy_step_id uuid NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -1542,7 +1797,9 @@ CREATE CONSTRAINT TRIGGER trg_enforce_ep_step_action_limit AFTER INSERT ON publi
 
 
 CREATE TABLE escalation_policy_state (
+	acknowledged_at timestamp with time zone,
 	alert_id bigint NOT NULL,
+	escalation_exhausted_at timestamp with time zone,
 	escalation_policy_id uuid NOT NULL,
 	escalation_policy_step_id uuid,
 	escalation_policy_step_number integer DEFAULT 0 NOT NULL,
@@ -1551,7 +1808,9 @@ CREATE TABLE escalation_policy_state (
 	last_escalation timestamp with time zone,
 	loop_count integer DEFAULT 0 NOT NULL,
 	next_escalation timestamp with time zone,
+	notification_count integer DEFAULT 0 NOT NULL,
 	service_id uuid NOT NULL,
+	snooze_until timestamp with time zone,
 	CONSTRAINT escalation_policy_state_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_state_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_state_escalation_policy_step_id_fkey FOREIGN KEY (escalation_policy_step_id) REFERENCES escalation_policy_steps(id) ON DELETE SET NULL,
@@ -1564,6 +1823,7 @@ CREATE TABLE escalation_policy_state (
 CREATE INDEX escalation_policy_state_next_escalation_force_escalation_idx ON public.escalation_policy_state USING btree (next_escalation, force_escalation);
 CREATE UNIQUE INDEX escalation_policy_state_pkey ON public.escalation_policy_state USING btree (alert_id);
 CREATE UNIQUE INDEX escalation_policy_state_uniq_id ON public.escalation_policy_state USING btree (id);
+CREATE INDEX idx_ep_state_snooze_until ON public.escalation_policy_state USING btree (snooze_until) WHERE (snooze_until IS NOT NULL);
 CREATE INDEX idx_escalation_policy_state_policy_ids ON public.escalation_policy_state USING btree (escalation_policy_id, service_id);
 
 CREATE TRIGGER trg_10_set_ep_state_svc_id_on_insert BEFORE INSERT ON public.escalation_policy_state FOR EACH ROW WHEN ((new.service_id IS NULL)) EXECUTE FUNCTION fn_set_ep_state_svc_id_on_insert();
@@ -1572,13 +1832,24 @@ CREATE TRIGGER trg_30_trig_alert_on_force_escalation AFTER UPDATE ON public.esca
 
 
 CREATE TABLE escalation_policy_steps (
+	assignment_strategy enum_ep_step_assignment_strategy DEFAULT 'sequential'::enum_ep_step_assignment_strategy NOT NULL,
+	conference_bridge boolean DEFAULT false NOT NULL,
 	delay integer DEFAULT 1 NOT NULL,
+	delay_business_hours_id uuid,
 	escalation_policy_id uuid NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	low_urgency boolean DEFAULT false NOT NULL,
+	min_severity enum_alert_severity,
+	off_hours_delay integer,
+	routing_business_hours_id uuid,
+	routing_webhook_url text,
 	step_number integer DEFAULT '-1'::integer NOT NULL,
+	CONSTRAINT escalation_policy_steps_delay_business_hours_id_fkey FOREIGN KEY (delay_business_hours_id) REFERENCES business_hours(id) ON DELETE RESTRICT,
 	CONSTRAINT escalation_policy_steps_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_steps_escalation_policy_id_step_number_key UNIQUE (escalation_policy_id, step_number) DEFERRABLE INITIALLY DEFERRED,
-	CONSTRAINT escalation_policy_steps_pkey PRIMARY KEY (id)
+	CONSTRAINT escalation_policy_steps_off_hours_delay_check CHECK (((off_hours_delay IS NULL) = (delay_business_hours_id IS NULL))),
+	CONSTRAINT escalation_policy_steps_pkey PRIMARY KEY (id),
+	CONSTRAINT escalation_policy_steps_routing_business_hours_id_fkey FOREIGN KEY (routing_business_hours_id) REFERENCES business_hours(id) ON DELETE RESTRICT
 );
 
 CREATE UNIQUE INDEX escalation_policy_steps_escalation_policy_id_step_number_key ON public.escalation_policy_steps USING btree (escalation_policy_id, step_number);
@@ -1618,6 +1889,20 @@ CREATE UNIQUE INDEX gql_api_key_usage_api_key_id_key ON public.gql_api_key_usage
 CREATE UNIQUE INDEX gql_api_key_usage_pkey ON public.gql_api_key_usage USING btree (id);
 
 
+CREATE TABLE gql_api_key_usage_history (
+	api_key_id uuid NOT NULL,
+	id bigint DEFAULT nextval('gql_api_key_usage_history_id_seq'::regclass) NOT NULL,
+	ip_address inet,
+	used_at timestamp with time zone DEFAULT now() NOT NULL,
+	user_agent text NOT NULL,
+	CONSTRAINT gql_api_key_usage_history_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES gql_api_keys(id) ON DELETE CASCADE,
+	CONSTRAINT gql_api_key_usage_history_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX gql_api_key_usage_history_pkey ON public.gql_api_key_usage_history USING btree (id);
+CREATE INDEX idx_gql_api_key_usage_history_key ON public.gql_api_key_usage_history USING btree (api_key_id, id);
+
+
 CREATE TABLE gql_api_keys (
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	created_by uuid,
@@ -1648,6 +1933,7 @@ CREATE TABLE heartbeat_monitors (
 	last_state enum_heartbeat_state DEFAULT 'inactive'::enum_heartbeat_state NOT NULL,
 	name text NOT NULL,
 	service_id uuid NOT NULL,
+	warning_interval interval,
 	CONSTRAINT heartbeat_monitors_pkey PRIMARY KEY (id),
 	CONSTRAINT heartbeat_monitors_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
 );
@@ -1659,13 +1945,68 @@ CREATE INDEX idx_heartbeat_monitor_service ON public.heartbeat_monitors USING bt
 CREATE CONSTRAINT TRIGGER trg_enforce_heartbeat_monitor_limit AFTER INSERT ON public.heartbeat_monitors NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_heartbeat_limit();
 
 
+CREATE TABLE incident_alerts (
+	added_at timestamp with time zone DEFAULT now() NOT NULL,
+	alert_id bigint NOT NULL,
+	incident_id uuid NOT NULL,
+	CONSTRAINT incident_alerts_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT incident_alerts_incident_id_fkey FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE,
+	CONSTRAINT incident_alerts_pkey PRIMARY KEY (alert_id)
+);
+
+CREATE INDEX idx_incident_alerts_incident_id ON public.incident_alerts USING btree (incident_id);
+CREATE UNIQUE INDEX incident_alerts_pkey ON public.incident_alerts USING btree (alert_id);
+
+
+CREATE TABLE incident_correlation_rules (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	grouped_ack boolean DEFAULT false NOT NULL,
+	id uuid NOT NULL,
+	key text NOT NULL,
+	name text NOT NULL,
+	processed_alert_id bigint DEFAULT 0 NOT NULL,
+	window_minutes integer NOT NULL,
+	CONSTRAINT incident_correlation_rules_name_key UNIQUE (name),
+	CONSTRAINT incident_correlation_rules_pkey PRIMARY KEY (id),
+	CONSTRAINT incident_correlation_rules_window_minutes_check CHECK ((window_minutes > 0))
+);
+
+CREATE UNIQUE INDEX incident_correlation_rules_name_key ON public.incident_correlation_rules USING btree (name);
+CREATE UNIQUE INDEX incident_correlation_rules_pkey ON public.incident_correlation_rules USING btree (id);
+
+
+CREATE TABLE incidents (
+	correlation_rule_id uuid,
+	correlation_value text,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	grouped_ack boolean DEFAULT false NOT NULL,
+	id uuid NOT NULL,
+	summary text NOT NULL,
+	CONSTRAINT incidents_correlation_rule_id_fkey FOREIGN KEY (correlation_rule_id) REFERENCES incident_correlation_rules(id) ON DELETE SET NULL,
+	CONSTRAINT incidents_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_incidents_correlation ON public.incidents USING btree (correlation_rule_id, correlation_value);
+CREATE UNIQUE INDEX incidents_pkey ON public.incidents USING btree (id);
+
+
 CREATE TABLE integration_keys (
+	alert_rate_limit integer,
+	dedup_window_minutes integer,
+	default_labels jsonb,
+	dropped_alert_count bigint DEFAULT 0 NOT NULL,
+	field_mapping jsonb,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	name text NOT NULL,
+	route_field text,
+	route_label_key text,
 	service_id uuid NOT NULL,
 	type enum_integration_keys_type NOT NULL,
+	CONSTRAINT integration_keys_alert_rate_limit_check CHECK ((alert_rate_limit > 0)),
+	CONSTRAINT integration_keys_dedup_window_minutes_check CHECK ((dedup_window_minutes > 0)),
 	CONSTRAINT integration_keys_name_service_id_key UNIQUE (name, service_id),
 	CONSTRAINT integration_keys_pkey PRIMARY KEY (id),
+	CONSTRAINT integration_keys_route_check CHECK ((route_label_key IS NULL) = (route_field IS NULL)),
 	CONSTRAINT integration_keys_services_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
 );
 
@@ -1681,6 +2022,7 @@ CREATE TABLE keyring (
 	id text NOT NULL,
 	next_key bytea NOT NULL,
 	next_rotation timestamp with time zone,
+	retire_times bytea,
 	rotation_count bigint NOT NULL,
 	signing_key bytea NOT NULL,
 	verification_keys bytea NOT NULL,
@@ -1710,8 +2052,12 @@ CREATE TABLE notification_channels (
 	id uuid NOT NULL,
 	meta jsonb DEFAULT '{}'::jsonb NOT NULL,
 	name text NOT NULL,
+	pagerduty_routing_key bytea,
 	type enum_notif_channel_type NOT NULL,
 	value text NOT NULL,
+	webhook_secret bytea,
+	webhook_secret_prev bytea,
+	webhook_secret_prev_expires_at timestamp with time zone,
 	CONSTRAINT notification_channels_pkey PRIMARY KEY (id)
 );
 
@@ -1723,7 +2069,9 @@ CREATE TABLE notification_policy_cycles (
 	checked boolean DEFAULT true NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	last_tick timestamp with time zone,
+	low_urgency boolean DEFAULT false NOT NULL,
 	repeat_count integer DEFAULT 0 NOT NULL,
+	shadow boolean DEFAULT false NOT NULL,
 	started_at timestamp with time zone DEFAULT now() NOT NULL,
 	user_id uuid NOT NULL,
 	CONSTRAINT notification_policy_cycles_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
@@ -1735,6 +2083,22 @@ CREATE INDEX idx_np_cycle_alert_id ON public.notification_policy_cycles USING bt
 CREATE UNIQUE INDEX notification_policy_cycles_pkey ON public.notification_policy_cycles USING btree (id);
 
 
+CREATE TABLE outgoing_message_attempts (
+	attempt integer NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id bigint DEFAULT nextval('outgoing_message_attempts_id_seq'::regclass) NOT NULL,
+	message_id uuid NOT NULL,
+	status enum_outgoing_messages_status NOT NULL,
+	status_details text DEFAULT ''::text NOT NULL,
+	CONSTRAINT outgoing_message_attempts_message_id_attempt_key UNIQUE (message_id, attempt),
+	CONSTRAINT outgoing_message_attempts_message_id_fkey FOREIGN KEY (message_id) REFERENCES outgoing_messages(id) ON DELETE CASCADE,
+	CONSTRAINT outgoing_message_attempts_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX outgoing_message_attempts_message_id_attempt_key ON public.outgoing_message_attempts USING btree (message_id, attempt);
+CREATE UNIQUE INDEX outgoing_message_attempts_pkey ON public.outgoing_message_attempts USING btree (id);
+
+
 CREATE TABLE outgoing_messages (
 	alert_id bigint,
 	alert_log_id bigint,
@@ -1742,6 +2106,8 @@ CREATE TABLE outgoing_messages (
 	contact_method_id uuid,
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	cycle_id uuid,
+	dead_lettered_at timestamp with time zone,
+	disabled_contact_method_id uuid,
 	escalation_policy_id uuid,
 	fired_at timestamp with time zone,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -1751,20 +2117,26 @@ CREATE TABLE outgoing_messages (
 	next_retry_at timestamp with time zone,
 	provider_msg_id text,
 	provider_seq integer DEFAULT 0 NOT NULL,
+	replay_of_id uuid,
 	retry_count integer DEFAULT 0 NOT NULL,
+	schedule_handoff_notice_id uuid,
 	schedule_id uuid,
 	sending_deadline timestamp with time zone,
 	sent_at timestamp with time zone,
 	service_id uuid,
+	shadow boolean DEFAULT false NOT NULL,
 	src_value text,
 	status_alert_ids bigint[],
 	status_details text DEFAULT ''::text NOT NULL,
 	user_id uuid,
 	user_verification_code_id uuid,
+	watcher boolean DEFAULT false NOT NULL,
 	CONSTRAINT om_alert_svc_ep_ids CHECK (message_type <> 'alert_notification'::enum_outgoing_messages_type OR alert_id IS NOT NULL AND service_id IS NOT NULL AND escalation_policy_id IS NOT NULL),
+	CONSTRAINT om_disabled_contact_method_id CHECK (message_type <> 'contact_method_disabled_notification'::enum_outgoing_messages_type OR disabled_contact_method_id IS NOT NULL),
 	CONSTRAINT om_no_status_bundles CHECK (message_type <> 'alert_status_update_bundle'::enum_outgoing_messages_type OR last_status <> 'pending'::enum_outgoing_messages_status),
 	CONSTRAINT om_pending_no_fired_no_sent CHECK (last_status <> 'pending'::enum_outgoing_messages_status OR fired_at IS NULL AND sent_at IS NULL),
 	CONSTRAINT om_processed_no_fired_sent CHECK ((last_status = ANY (ARRAY['pending'::enum_outgoing_messages_status, 'sending'::enum_outgoing_messages_status, 'failed'::enum_outgoing_messages_status, 'bundled'::enum_outgoing_messages_status])) OR fired_at IS NULL AND sent_at IS NOT NULL),
+	CONSTRAINT om_schedule_handoff_notice_id CHECK (message_type <> 'schedule_handoff_notification'::enum_outgoing_messages_type OR schedule_handoff_notice_id IS NOT NULL),
 	CONSTRAINT om_sending_deadline_reqd CHECK (last_status <> 'sending'::enum_outgoing_messages_status OR sending_deadline IS NOT NULL),
 	CONSTRAINT om_sending_fired_no_sent CHECK (last_status <> 'sending'::enum_outgoing_messages_status OR fired_at IS NOT NULL AND sent_at IS NULL),
 	CONSTRAINT om_status_alert_ids CHECK (message_type <> 'alert_status_update_bundle'::enum_outgoing_messages_type OR status_alert_ids IS NOT NULL),
@@ -1775,8 +2147,11 @@ CREATE TABLE outgoing_messages (
 	CONSTRAINT outgoing_messages_channel_id_fkey FOREIGN KEY (channel_id) REFERENCES notification_channels(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_cycle_id_fkey FOREIGN KEY (cycle_id) REFERENCES notification_policy_cycles(id) ON DELETE CASCADE,
+	CONSTRAINT outgoing_messages_disabled_contact_method_id_fkey FOREIGN KEY (disabled_contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_pkey PRIMARY KEY (id),
+	CONSTRAINT outgoing_messages_replay_of_id_fkey FOREIGN KEY (replay_of_id) REFERENCES outgoing_messages(id) ON DELETE SET NULL,
+	CONSTRAINT outgoing_messages_schedule_handoff_notice_id_fkey FOREIGN KEY (schedule_handoff_notice_id) REFERENCES schedule_handoff_notices(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
@@ -1787,8 +2162,10 @@ CREATE TABLE outgoing_messages (
 CREATE INDEX idx_om_alert_log_id ON public.outgoing_messages USING btree (alert_log_id);
 CREATE INDEX idx_om_alert_sent ON public.outgoing_messages USING btree (alert_id, sent_at);
 CREATE INDEX idx_om_cm_sent ON public.outgoing_messages USING btree (contact_method_id, sent_at);
+CREATE INDEX idx_om_dead_lettered ON public.outgoing_messages USING btree (dead_lettered_at) WHERE (dead_lettered_at IS NOT NULL);
 CREATE INDEX idx_om_ep_sent ON public.outgoing_messages USING btree (escalation_policy_id, sent_at);
 CREATE INDEX idx_om_last_status_sent ON public.outgoing_messages USING btree (last_status, sent_at);
+CREATE INDEX idx_om_replay_of_id ON public.outgoing_messages USING btree (replay_of_id);
 CREATE INDEX idx_om_service_sent ON public.outgoing_messages USING btree (service_id, sent_at);
 CREATE INDEX idx_om_user_sent ON public.outgoing_messages USING btree (user_id, sent_at);
 CREATE INDEX idx_om_vcode_id ON public.outgoing_messages USING btree (user_verification_code_id);
@@ -1814,10 +2191,12 @@ CREATE TABLE rotation_participants (
 	position integer NOT NULL,
 	rotation_id uuid NOT NULL,
 	user_id uuid NOT NULL,
+	weight integer DEFAULT 1 NOT NULL,
 	CONSTRAINT rotation_participants_pkey PRIMARY KEY (id),
 	CONSTRAINT rotation_participants_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
 	CONSTRAINT rotation_participants_rotation_id_position_key UNIQUE (rotation_id, "position") DEFERRABLE INITIALLY DEFERRED,
-	CONSTRAINT rotation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	CONSTRAINT rotation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT rotation_participants_weight_check CHECK ((weight > 0))
 );
 
 CREATE INDEX idx_participant_rotation ON public.rotation_participants USING btree (rotation_id);
@@ -1835,6 +2214,22 @@ CREATE TRIGGER trg_set_rot_state_pos_on_part_reorder BEFORE UPDATE ON public.rot
 CREATE TRIGGER trg_start_rotation_on_first_part_add AFTER INSERT ON public.rotation_participants FOR EACH ROW EXECUTE FUNCTION fn_start_rotation_on_first_part_add();
 
 
+CREATE TABLE rotation_shadows (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	rotation_id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT rotation_shadows_pkey PRIMARY KEY (id),
+	CONSTRAINT rotation_shadows_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
+	CONSTRAINT rotation_shadows_rotation_id_user_id_key UNIQUE (rotation_id, user_id),
+	CONSTRAINT rotation_shadows_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX rotation_shadows_pkey ON public.rotation_shadows USING btree (id);
+CREATE UNIQUE INDEX rotation_shadows_rotation_id_user_id_key ON public.rotation_shadows USING btree (rotation_id, user_id);
+
+
 CREATE TABLE rotation_state (
 	id bigint DEFAULT nextval('rotation_state_id_seq'::regclass) NOT NULL,
 	position integer DEFAULT 0 NOT NULL,
@@ -1890,9 +2285,27 @@ CREATE UNIQUE INDEX schedule_data_id_key ON public.schedule_data USING btree (id
 CREATE UNIQUE INDEX schedule_data_pkey ON public.schedule_data USING btree (schedule_id);
 
 
+CREATE TABLE schedule_handoff_notices (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid NOT NULL,
+	on_call boolean NOT NULL,
+	resolved boolean DEFAULT false NOT NULL,
+	schedule_id uuid NOT NULL,
+	shift_time timestamp with time zone NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT schedule_handoff_notices_pkey PRIMARY KEY (id),
+	CONSTRAINT schedule_handoff_notices_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT schedule_handoff_notices_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_schedule_handoff_pending ON public.schedule_handoff_notices USING btree (schedule_id, user_id) WHERE (on_call AND (NOT resolved));
+CREATE UNIQUE INDEX schedule_handoff_notices_pkey ON public.schedule_handoff_notices USING btree (id);
+
+
 CREATE TABLE schedule_on_call_users (
 	end_time timestamp with time zone,
 	id bigint DEFAULT nextval('schedule_on_call_users_id_seq'::regclass) NOT NULL,
+	priority integer DEFAULT 0 NOT NULL,
 	schedule_id uuid NOT NULL,
 	start_time timestamp with time zone DEFAULT now() NOT NULL,
 	user_id uuid NOT NULL,
@@ -1914,6 +2327,7 @@ CREATE TABLE schedule_rules (
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	is_active boolean DEFAULT false NOT NULL,
 	monday boolean DEFAULT true NOT NULL,
+	priority integer DEFAULT 0 NOT NULL,
 	saturday boolean DEFAULT true NOT NULL,
 	schedule_id uuid NOT NULL,
 	start_time time without time zone DEFAULT '00:00:00'::time without time zone NOT NULL,
@@ -1955,14 +2369,55 @@ CREATE UNIQUE INDEX schedules_name_key ON public.schedules USING btree (name);
 CREATE UNIQUE INDEX schedules_pkey ON public.schedules USING btree (id);
 
 
+CREATE TABLE service_maintenance_windows (
+	description text DEFAULT ''::text NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	service_id uuid NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	CONSTRAINT service_maintenance_windows_check CHECK ((end_time > start_time)),
+	CONSTRAINT service_maintenance_windows_pkey PRIMARY KEY (id),
+	CONSTRAINT service_maintenance_windows_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_svc_maint_windows_service_id ON public.service_maintenance_windows USING btree (service_id, end_time);
+CREATE UNIQUE INDEX service_maintenance_windows_pkey ON public.service_maintenance_windows USING btree (id);
+
+
+CREATE TABLE service_watchers (
+	contact_method_id uuid NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	service_id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT service_watchers_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
+	CONSTRAINT service_watchers_pkey PRIMARY KEY (id),
+	CONSTRAINT service_watchers_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
+	CONSTRAINT service_watchers_service_id_user_id_key UNIQUE (service_id, user_id),
+	CONSTRAINT service_watchers_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX service_watchers_pkey ON public.service_watchers USING btree (id);
+CREATE UNIQUE INDEX service_watchers_service_id_user_id_key ON public.service_watchers USING btree (service_id, user_id);
+
+
 CREATE TABLE services (
 	description text DEFAULT ''::text NOT NULL,
+	enrichment_url text DEFAULT ''::text NOT NULL,
 	escalation_policy_id uuid NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	maintenance_expires_at timestamp with time zone,
 	name text NOT NULL,
+	notification_delay_minutes integer DEFAULT 0 NOT NULL,
+	notification_template text DEFAULT ''::text NOT NULL,
+	paused_at timestamp with time zone,
+	paused_by_user_id uuid,
+	require_close_reason boolean DEFAULT false NOT NULL,
+	runbook_url text DEFAULT ''::text NOT NULL,
+	time_zone text DEFAULT ''::text NOT NULL,
 	CONSTRAINT services_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id),
 	CONSTRAINT services_name_key UNIQUE (name),
+	CONSTRAINT services_paused_by_user_id_fkey FOREIGN KEY (paused_by_user_id) REFERENCES users(id) ON DELETE SET NULL,
 	CONSTRAINT services_pkey PRIMARY KEY (id),
 	CONSTRAINT svc_ep_uniq UNIQUE (id, escalation_policy_id)
 );
@@ -2066,6 +2521,8 @@ CREATE CONSTRAINT TRIGGER trg_enforce_calendar_subscriptions_per_user_limit AFTE
 
 
 CREATE TABLE user_contact_methods (
+	auto_disabled_at timestamp with time zone,
+	consecutive_failures integer DEFAULT 0 NOT NULL,
 	disabled boolean DEFAULT false NOT NULL,
 	enable_status_updates boolean DEFAULT false NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -2090,6 +2547,17 @@ CREATE TRIGGER trg_cm_set_not_pending_on_verify BEFORE UPDATE OF disabled ON pub
 CREATE CONSTRAINT TRIGGER trg_enforce_contact_method_limit AFTER INSERT ON public.user_contact_methods NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_contact_method_limit();
 
 
+CREATE TABLE user_do_not_disturb (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	expires_at timestamp with time zone,
+	user_id uuid NOT NULL,
+	CONSTRAINT user_do_not_disturb_pkey PRIMARY KEY (user_id),
+	CONSTRAINT user_do_not_disturb_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_do_not_disturb_pkey ON public.user_do_not_disturb USING btree (user_id);
+
+
 CREATE TABLE user_favorites (
 	id bigint DEFAULT nextval('user_favorites_id_seq'::regclass) NOT NULL,
 	tgt_escalation_policy_id uuid,
@@ -2120,15 +2588,33 @@ CREATE UNIQUE INDEX user_favorites_user_id_tgt_service_id_key ON public.user_fav
 CREATE UNIQUE INDEX user_favorites_user_id_tgt_user_id_key ON public.user_favorites USING btree (user_id, tgt_user_id);
 
 
+CREATE TABLE user_label_grants (
+	key text NOT NULL,
+	user_id uuid NOT NULL,
+	value text NOT NULL,
+	CONSTRAINT user_label_grants_pkey PRIMARY KEY (user_id, key, value),
+	CONSTRAINT user_label_grants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_label_grants_pkey ON public.user_label_grants USING btree (user_id, key, value);
+
+
 CREATE TABLE user_notification_rules (
 	contact_method_id uuid NOT NULL,
 	created_at timestamp with time zone DEFAULT now(),
 	delay_minutes integer DEFAULT 0 NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	position integer DEFAULT 0 NOT NULL,
+	quiet_hours_allow_high_urgency boolean DEFAULT false NOT NULL,
+	quiet_hours_end time without time zone,
+	quiet_hours_start time without time zone,
+	stop_on_success boolean DEFAULT false NOT NULL,
+	urgency enum_notification_rule_urgency,
 	user_id uuid NOT NULL,
 	CONSTRAINT user_notification_rules_contact_method_id_delay_minutes_key UNIQUE (contact_method_id, delay_minutes),
 	CONSTRAINT user_notification_rules_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
 	CONSTRAINT user_notification_rules_pkey PRIMARY KEY (id),
+	CONSTRAINT user_notification_rules_quiet_hours_check CHECK ((((quiet_hours_start IS NULL) AND (quiet_hours_end IS NULL) AND (NOT quiet_hours_allow_high_urgency)) OR ((quiet_hours_start IS NOT NULL) AND (quiet_hours_end IS NOT NULL)))),
 	CONSTRAINT user_notification_rules_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
@@ -2141,10 +2627,34 @@ CREATE CONSTRAINT TRIGGER trg_enforce_notification_rule_limit AFTER INSERT ON pu
 CREATE TRIGGER trg_notification_rule_same_user BEFORE INSERT OR UPDATE ON public.user_notification_rules FOR EACH ROW EXECUTE FUNCTION fn_notification_rule_same_user();
 
 
+CREATE TABLE user_override_recurrences (
+	add_user_id uuid,
+	end_time timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	remove_user_id uuid,
+	start_time timestamp with time zone NOT NULL,
+	tgt_schedule_id uuid NOT NULL,
+	until timestamp with time zone NOT NULL,
+	weekdays boolean[] NOT NULL,
+	CONSTRAINT user_override_recurrences_add_user_id_fkey FOREIGN KEY (add_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT user_override_recurrences_check CHECK ((end_time > start_time)),
+	CONSTRAINT user_override_recurrences_check1 CHECK ((until > start_time)),
+	CONSTRAINT user_override_recurrences_check2 CHECK ((COALESCE(add_user_id, remove_user_id) IS NOT NULL)),
+	CONSTRAINT user_override_recurrences_check3 CHECK ((add_user_id <> remove_user_id)),
+	CONSTRAINT user_override_recurrences_pkey PRIMARY KEY (id),
+	CONSTRAINT user_override_recurrences_remove_user_id_fkey FOREIGN KEY (remove_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT user_override_recurrences_tgt_schedule_id_fkey FOREIGN KEY (tgt_schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_override_recurrences_schedule ON public.user_override_recurrences USING btree (tgt_schedule_id, until);
+CREATE UNIQUE INDEX user_override_recurrences_pkey ON public.user_override_recurrences USING btree (id);
+
+
 CREATE TABLE user_overrides (
 	add_user_id uuid,
 	end_time timestamp with time zone NOT NULL,
 	id uuid NOT NULL,
+	recurrence_id uuid,
 	remove_user_id uuid,
 	start_time timestamp with time zone NOT NULL,
 	tgt_schedule_id uuid NOT NULL,
@@ -2153,10 +2663,12 @@ CREATE TABLE user_overrides (
 	CONSTRAINT user_overrides_check1 CHECK (COALESCE(add_user_id, remove_user_id) IS NOT NULL),
 	CONSTRAINT user_overrides_check2 CHECK (add_user_id <> remove_user_id),
 	CONSTRAINT user_overrides_pkey PRIMARY KEY (id),
+	CONSTRAINT user_overrides_recurrence_id_fkey FOREIGN KEY (recurrence_id) REFERENCES user_override_recurrences(id) ON DELETE SET NULL,
 	CONSTRAINT user_overrides_remove_user_id_fkey FOREIGN KEY (remove_user_id) REFERENCES users(id) ON DELETE CASCADE,
 	CONSTRAINT user_overrides_tgt_schedule_id_fkey FOREIGN KEY (tgt_schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
 );
 
+CREATE INDEX idx_user_overrides_recurrence ON public.user_overrides USING btree (recurrence_id);
 CREATE INDEX idx_user_overrides_schedule ON public.user_overrides USING btree (tgt_schedule_id, end_time);
 CREATE UNIQUE INDEX user_overrides_pkey ON public.user_overrides USING btree (id);
 
@@ -2174,12 +2686,25 @@ CREATE TABLE user_slack_data (
 CREATE UNIQUE INDEX user_slack_data_pkey ON public.user_slack_data USING btree (id);
 
 
+CREATE TABLE user_urgency_windows (
+	end_time time without time zone NOT NULL,
+	start_time time without time zone NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT user_urgency_windows_check CHECK (start_time <> end_time),
+	CONSTRAINT user_urgency_windows_pkey PRIMARY KEY (user_id),
+	CONSTRAINT user_urgency_windows_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_urgency_windows_pkey ON public.user_urgency_windows USING btree (user_id);
+
+
 CREATE TABLE user_verification_codes (
 	code integer NOT NULL,
 	contact_method_id uuid NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
 	id uuid NOT NULL,
 	sent boolean DEFAULT false NOT NULL,
+	voice boolean DEFAULT false NOT NULL,
 	CONSTRAINT user_verification_codes_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
 	CONSTRAINT user_verification_codes_contact_method_id_key UNIQUE (contact_method_id),
 	CONSTRAINT user_verification_codes_pkey PRIMARY KEY (id)
@@ -2195,10 +2720,13 @@ CREATE TABLE users (
 	bio text DEFAULT ''::text NOT NULL,
 	email text DEFAULT ''::text NOT NULL,
 	id uuid NOT NULL,
+	max_notifications_per_hour integer,
 	name text NOT NULL,
 	role enum_user_role DEFAULT 'unknown'::enum_user_role NOT NULL,
+	time_zone text,
 	CONSTRAINT goalert_user_pkey PRIMARY KEY (id),
-	CONSTRAINT users_alert_status_log_contact_method_id_fkey FOREIGN KEY (alert_status_log_contact_method_id) REFERENCES user_contact_methods(id) ON DELETE SET NULL DEFERRABLE
+	CONSTRAINT users_alert_status_log_contact_method_id_fkey FOREIGN KEY (alert_status_log_contact_method_id) REFERENCES user_contact_methods(id) ON DELETE SET NULL DEFERRABLE,
+	CONSTRAINT users_max_notifications_per_hour_check CHECK ((max_notifications_per_hour >= 0))
 );
 
 CREATE UNIQUE INDEX goalert_user_pkey ON public.users USING btree (id);
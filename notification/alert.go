@@ -1,5 +1,10 @@
 package notification
 
+import (
+	"sort"
+	"time"
+)
+
 // Alert represents outgoing notifications for alerts.
 type Alert struct {
 	Dest        Dest
@@ -10,6 +15,15 @@ type Alert struct {
 	ServiceID   string
 	ServiceName string
 
+	// Meta contains optional structured context for the alert.
+	Meta map[string]string
+
+	// RunbookURL, if set, links to the runbook for the alert.
+	RunbookURL string
+
+	// CreatedAt is when the alert was created, in the time zone of the recipient.
+	CreatedAt time.Time
+
 	// OriginalStatus is the status of the first Alert notification to this Dest for this AlertID.
 	OriginalStatus *SendResult
 }
@@ -27,3 +41,16 @@ func (a Alert) Destination() Dest    { return a.Dest }
 func (a Alert) Body() string         { return a.Summary }
 func (a Alert) ExtendedBody() string { return a.Details }
 func (a Alert) SubjectID() int       { return a.AlertID }
+
+// CreatedAtText returns CreatedAt formatted for display, or an empty string if unset.
+func (a Alert) CreatedAtText() string { return FormatTime(a.CreatedAt) }
+
+// MetaKeys returns the keys of Meta in sorted order.
+func (a Alert) MetaKeys() []string {
+	keys := make([]string, 0, len(a.Meta))
+	for k := range a.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
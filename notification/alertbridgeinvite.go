@@ -0,0 +1,24 @@
+package notification
+
+import "fmt"
+
+// AlertBridgeInvite is a Message that calls a responder to join the conference bridge of an alert.
+type AlertBridgeInvite struct {
+	Dest       Dest
+	CallbackID string
+
+	AlertID     int
+	Summary     string
+	ServiceName string
+}
+
+var _ Message = &AlertBridgeInvite{}
+
+func (b AlertBridgeInvite) ID() string        { return b.CallbackID }
+func (b AlertBridgeInvite) Destination() Dest { return b.Dest }
+func (b AlertBridgeInvite) Type() MessageType { return MessageTypeAlertBridgeInvite }
+
+// Body returns a plain-text description of the invitation.
+func (b AlertBridgeInvite) Body() string {
+	return fmt.Sprintf("A conference bridge was started for alert #%d: %s", b.AlertID, b.Summary)
+}
@@ -21,6 +21,8 @@ type AlertStatus struct {
 	Summary string
 	// Details of the alert that this status is in regards to.
 	Details string
+	// RunbookURL, if set, links to the runbook for the alert.
+	RunbookURL string
 
 	// OriginalStatus is the status of the first Alert notification to this Dest for this AlertID.
 	OriginalStatus SendResult
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MessageAttempt records the result of a single attempt to send a message.
+type MessageAttempt struct {
+	Attempt   int
+	State     State
+	Details   string
+	CreatedAt time.Time
+}
+
+// FindMessageAttempts will return all recorded send attempts for the given message, in order.
+// Attempts are only recorded for destinations that support dead-lettering (e.g., webhooks).
+func (s *Store) FindMessageAttempts(ctx context.Context, messageID string) ([]MessageAttempt, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("MessageID", messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMessageAttempts.QueryContext(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []MessageAttempt
+	for rows.Next() {
+		var a MessageAttempt
+		err = rows.Scan(&a.Attempt, &a.State, &a.Details, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,40 @@
+package notification
+
+import "fmt"
+
+// ContactMethodDisabled is a Message that notifies a user that one of their contact methods
+// was disabled after too many consecutive failed deliveries.
+type ContactMethodDisabled struct {
+	Dest       Dest
+	CallbackID string
+
+	ContactMethodID   string
+	ContactMethodName string
+	ContactMethodType string
+
+	// Failures is the number of consecutive failed deliveries.
+	Failures int
+
+	// ReenableMinutes, if non-zero, is the number of minutes until the contact method
+	// is re-enabled automatically.
+	ReenableMinutes int
+
+	// ProfileURL links to the page where the contact method can be re-enabled.
+	ProfileURL string
+}
+
+var _ Message = &ContactMethodDisabled{}
+
+func (c ContactMethodDisabled) ID() string        { return c.CallbackID }
+func (c ContactMethodDisabled) Destination() Dest { return c.Dest }
+func (c ContactMethodDisabled) Type() MessageType { return MessageTypeContactMethodDisabled }
+
+// Body returns a plain-text description of the disabled contact method.
+func (c ContactMethodDisabled) Body() string {
+	body := fmt.Sprintf("Your %s contact method '%s' was disabled after %d failed deliveries.", c.ContactMethodType, c.ContactMethodName, c.Failures)
+	if c.ReenableMinutes > 0 {
+		return body + fmt.Sprintf(" It will be re-enabled automatically in %d minutes, or you can update or re-enable it from your profile.", c.ReenableMinutes)
+	}
+
+	return body + " Update or re-enable it from your profile to receive notifications there again."
+}
@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactMethodDisabled_Body(t *testing.T) {
+	c := ContactMethodDisabled{ContactMethodType: "SMS", ContactMethodName: "Work Phone", Failures: 5}
+	assert.Equal(t, "Your SMS contact method 'Work Phone' was disabled after 5 failed deliveries. Update or re-enable it from your profile to receive notifications there again.", c.Body())
+
+	c.ReenableMinutes = 60
+	assert.Equal(t, "Your SMS contact method 'Work Phone' was disabled after 5 failed deliveries. It will be re-enabled automatically in 60 minutes, or you can update or re-enable it from your profile.", c.Body())
+}
@@ -45,6 +45,9 @@ const (
 	DestTypeUserWebhook
 	DestTypeChanWebhook
 	DestTypeSlackUG
+	DestTypeMSTeams
+	DestTypePagerDuty
+	DestTypeTelegram
 )
 
 func (d Dest) String() string { return fmt.Sprintf("%s(%s)", d.Type.String(), d.ID) }
@@ -74,6 +77,8 @@ func (t ScannableDestType) DestType() DestType {
 		return DestTypeUserWebhook
 	case contactmethod.TypeSlackDM:
 		return DestTypeSlackDM
+	case contactmethod.TypeTelegram:
+		return DestTypeTelegram
 	}
 
 	switch t.NC {
@@ -83,6 +88,10 @@ func (t ScannableDestType) DestType() DestType {
 		return DestTypeChanWebhook
 	case notificationchannel.TypeSlackUG:
 		return DestTypeSlackUG
+	case notificationchannel.TypeMSTeams:
+		return DestTypeMSTeams
+	case notificationchannel.TypePagerDuty:
+		return DestTypePagerDuty
 	}
 
 	return DestTypeUnknown
@@ -97,6 +106,10 @@ func (t DestType) NCType() notificationchannel.Type {
 		return notificationchannel.TypeWebhook
 	case DestTypeSlackUG:
 		return notificationchannel.TypeSlackUG
+	case DestTypeMSTeams:
+		return notificationchannel.TypeMSTeams
+	case DestTypePagerDuty:
+		return notificationchannel.TypePagerDuty
 	}
 
 	return notificationchannel.TypeUnknown
@@ -115,6 +128,8 @@ func (t DestType) CMType() contactmethod.Type {
 		return contactmethod.TypeWebhook
 	case DestTypeSlackDM:
 		return contactmethod.TypeSlackDM
+	case DestTypeTelegram:
+		return contactmethod.TypeTelegram
 	}
 
 	return contactmethod.TypeUnknown
@@ -0,0 +1,134 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ReplyMailbox is the mailbox (local part of the address) that replies to notification
+// emails are sent to.
+const ReplyMailbox = "reply"
+
+// ReplyAddress will return the address replies to the message with the given callback ID
+// should be sent to.
+func ReplyAddress(domain, callbackID string) string {
+	return ReplyMailbox + "+" + callbackID + "@" + domain
+}
+
+// MessageID will return the Message-ID header value for the message with the given
+// callback ID, so threaded replies can be matched by their In-Reply-To and References
+// headers.
+func MessageID(domain, callbackID string) string {
+	return "<" + callbackID + "@" + domain + ">"
+}
+
+// ParseReplyMailbox will return true if mailbox is the ReplyMailbox, along with the
+// callback ID token following the `+` if present.
+func ParseReplyMailbox(mailbox string) (token string, ok bool) {
+	name, token, _ := strings.Cut(mailbox, "+")
+	if !strings.EqualFold(name, ReplyMailbox) {
+		return "", false
+	}
+
+	return token, true
+}
+
+// A Reply is an email sent in response to a notification.
+type Reply struct {
+	// From is the address of the sender.
+	From string
+
+	// Token is the callback ID from the recipient address, if present.
+	Token string
+
+	// MessageIDs are the IDs from the In-Reply-To and References headers, with or
+	// without angle brackets.
+	MessageIDs []string
+
+	// Body is the plain-text body of the reply, which may include quoted text.
+	Body string
+}
+
+// CallbackID will return the callback ID of the message being replied to, or an empty
+// string if it cannot be determined.
+func (r Reply) CallbackID() string {
+	if validate.UUID("Token", r.Token) == nil {
+		return r.Token
+	}
+
+	for _, id := range r.MessageIDs {
+		id = strings.Trim(strings.TrimSpace(id), "<>")
+		id, _, _ = strings.Cut(id, "@")
+		if validate.UUID("MessageID", id) == nil {
+			return id
+		}
+	}
+
+	return ""
+}
+
+var replyQuoteHeader = regexp.MustCompile(`(?i)^(on\s.+wrote:|-+\s*original message\s*-+|from:\s)`)
+
+// Result will return the response in the body of the reply, ignoring quoted text. The
+// first word of the first line written by the sender must be "ack" or "close".
+func (r Reply) Result() (notification.Result, bool) {
+	for _, line := range strings.Split(r.Body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, ">") || replyQuoteHeader.MatchString(line) {
+			break
+		}
+
+		word, _, _ := strings.Cut(strings.ToLower(line), " ")
+		switch strings.Trim(word, ".,!'\"") {
+		case "a", "ack", "acknowledge":
+			return notification.ResultAcknowledge, true
+		case "c", "close", "resolve":
+			return notification.ResultResolve, true
+		}
+		break
+	}
+
+	return 0, false
+}
+
+// ReceiveReply will process a reply to a notification email. The sender must be the
+// address the original notification was sent to.
+func (s *Sender) ReceiveReply(ctx context.Context, r Reply) error {
+	if s.r == nil {
+		return validation.NewGenericError("email replies are not supported")
+	}
+
+	from, err := mail.ParseAddress(r.From)
+	if err != nil {
+		return validation.NewFieldError("From", "invalid address")
+	}
+
+	callbackID := r.CallbackID()
+	if callbackID == "" {
+		return validation.NewGenericError("could not find the notification being replied to")
+	}
+
+	result, ok := r.Result()
+	if !ok {
+		return validation.NewGenericError("reply with 'ack' to acknowledge or 'close' to close the alert")
+	}
+
+	dest := notification.Dest{Type: notification.DestTypeUserEmail, Value: from.Address}
+	err = s.r.ReceiveFrom(ctx, callbackID, dest, result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewGenericError("could not find the notification being replied to")
+	}
+
+	return err
+}
@@ -0,0 +1,53 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestParseReplyMailbox(t *testing.T) {
+	token, ok := ParseReplyMailbox("reply+abc")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", token)
+
+	token, ok = ParseReplyMailbox("Reply")
+	assert.True(t, ok)
+	assert.Empty(t, token)
+
+	_, ok = ParseReplyMailbox("00000000-0000-0000-0000-000000000000+dedup")
+	assert.False(t, ok)
+}
+
+func TestReply_CallbackID(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	assert.Equal(t, id, Reply{Token: id}.CallbackID())
+	assert.Equal(t, id, Reply{MessageIDs: []string{"other@example.com", "<" + id + "@example.com>"}}.CallbackID(), "threaded reply")
+	assert.Equal(t, id, Reply{Token: "bad", MessageIDs: []string{id + "@example.com"}}.CallbackID())
+	assert.Empty(t, Reply{MessageIDs: []string{"other@example.com"}}.CallbackID())
+}
+
+func TestReply_Result(t *testing.T) {
+	check := func(body string, exp notification.Result) {
+		t.Helper()
+		res, ok := Reply{Body: body}.Result()
+		assert.True(t, ok, body)
+		assert.Equal(t, exp, res, body)
+	}
+	check("ack", notification.ResultAcknowledge)
+	check("\n  Ack.\n\nThanks", notification.ResultAcknowledge)
+	check("close it please\n\nOn Mon, Jan 2, 2023 at 1:00 PM GoAlert wrote:\n> ack", notification.ResultResolve)
+
+	noResult := func(body string) {
+		t.Helper()
+		_, ok := Reply{Body: body}.Result()
+		assert.False(t, ok, body)
+	}
+	noResult("")
+	noResult("looking into it\nack")
+	noResult("> ack")
+	noResult("On Mon, Jan 2, 2023 at 1:00 PM GoAlert wrote:\n> Reply with 'ack' to acknowledge")
+	noResult("-----Original Message-----\nack")
+}
@@ -18,13 +18,21 @@ import (
 	"gopkg.in/gomail.v2"
 )
 
-type Sender struct{}
+type Sender struct {
+	r notification.Receiver
+}
 
 func NewSender(ctx context.Context) *Sender {
 	return &Sender{}
 }
 
-var _ notification.Sender = &Sender{}
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+// SetReceiver sets the notification.Receiver for replies to notification emails.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
 
 // Send will send an for the provided message type.
 func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
@@ -51,6 +59,7 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 	}
 	var e hermes.Email
 	var subject string
+	var replyCallbackID string
 	switch m := msg.(type) {
 	case notification.Test:
 		subject = "Test Message"
@@ -68,12 +77,27 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
 		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
 		e.Body.Intros = []string{m.Summary, m.Details}
+		if created := m.CreatedAtText(); created != "" {
+			e.Body.Dictionary = append(e.Body.Dictionary, hermes.Entry{Key: "Created", Value: created})
+		}
+		for _, k := range m.MetaKeys() {
+			e.Body.Dictionary = append(e.Body.Dictionary, hermes.Entry{Key: k, Value: m.Meta[k]})
+		}
 		e.Body.Actions = []hermes.Action{{
 			Button: hermes.Button{
 				Text: "Open Alert Details",
 				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
 			},
 		}}
+		if m.RunbookURL != "" {
+			e.Body.Actions = append(e.Body.Actions, hermes.Action{
+				Button: hermes.Button{
+					Text: "Open Runbook",
+					Link: m.RunbookURL,
+				},
+			})
+		}
+		replyCallbackID = m.CallbackID
 	case notification.AlertBundle:
 		subject = fmt.Sprintf("Service %s has %d unacknowledged alerts", m.ServiceName, m.Count)
 		e.Body.Title = "Multiple Unacknowledged Alerts"
@@ -84,6 +108,7 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 				Link: cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", m.ServiceID)),
 			},
 		}}
+		replyCallbackID = m.CallbackID
 	case notification.AlertStatus:
 		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry)
 		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
@@ -95,10 +120,35 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			},
 		}}
 		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
+	case notification.ScheduleHandoff:
+		subject = fmt.Sprintf("On-Call Handoff: %s", m.ScheduleName)
+		e.Body.Title = "On-Call Handoff"
+		e.Body.Intros = []string{m.Body()}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule",
+				Link: m.ScheduleURL,
+			},
+		}}
+	case notification.ContactMethodDisabled:
+		subject = fmt.Sprintf("Contact Method Disabled: %s", m.ContactMethodName)
+		e.Body.Title = "Contact Method Disabled"
+		e.Body.Intros = []string{m.Body()}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Profile",
+				Link: m.ProfileURL,
+			},
+		}}
 	default:
 		return nil, errors.New("message type not supported")
 	}
 
+	canReply := replyCallbackID != "" && s.r != nil && cfg.EmailIngressEnabled()
+	if canReply {
+		e.Body.Outros = append(e.Body.Outros, "Reply with 'ack' to acknowledge or 'close' to close.")
+	}
+
 	htmlBody, err := h.GenerateHTML(e)
 	if err != nil {
 		return nil, err
@@ -112,6 +162,10 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 	g.SetHeader("From", fromAddr.String())
 	g.SetAddressHeader("To", toAddr.Address, toAddr.Name)
 	g.SetHeader("Subject", subject)
+	if canReply {
+		g.SetHeader("Reply-To", ReplyAddress(cfg.EmailIngressDomain(), replyCallbackID))
+		g.SetHeader("Message-ID", MessageID(cfg.EmailIngressDomain(), replyCallbackID))
+	}
 	g.SetBody("text/plain", textBody)
 	g.AddAlternative("text/html", htmlBody)
 
@@ -25,6 +25,9 @@ const (
 	// messages are now dropped.
 	MessageTypeAlertStatusBundle
 	MessageTypeScheduleOnCallUsers
+	MessageTypeScheduleHandoff
+	MessageTypeContactMethodDisabled
+	MessageTypeAlertBridgeInvite
 )
 
 func (s MessageType) Value() (driver.Value, error) {
@@ -43,6 +46,12 @@ func (s MessageType) Value() (driver.Value, error) {
 		return "alert_status_update_bundle", nil
 	case MessageTypeScheduleOnCallUsers:
 		return "schedule_on_call_notification", nil
+	case MessageTypeScheduleHandoff:
+		return "schedule_handoff_notification", nil
+	case MessageTypeContactMethodDisabled:
+		return "contact_method_disabled_notification", nil
+	case MessageTypeAlertBridgeInvite:
+		return "alert_bridge_invite", nil
 	}
 	return nil, fmt.Errorf("could not process unknown type for MessageType %s", s)
 }
@@ -65,6 +74,12 @@ func (s *MessageType) Scan(value interface{}) error {
 		*s = MessageTypeAlertStatusBundle
 	case "schedule_on_call_notification":
 		*s = MessageTypeScheduleOnCallUsers
+	case "schedule_handoff_notification":
+		*s = MessageTypeScheduleHandoff
+	case "contact_method_disabled_notification":
+		*s = MessageTypeContactMethodDisabled
+	case "alert_bridge_invite":
+		*s = MessageTypeAlertBridgeInvite
 	default:
 		return fmt.Errorf("could not process unknown type for MessageType %str", str)
 	}
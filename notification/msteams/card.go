@@ -0,0 +1,158 @@
+package msteams
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message is the payload accepted by a Teams incoming webhook.
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment wraps an adaptive card within a Message.
+type Attachment struct {
+	ContentType string `json:"contentType"`
+	Content     Card   `json:"content"`
+}
+
+// Card is an adaptive card.
+type Card struct {
+	Schema  string    `json:"$schema"`
+	Type    string    `json:"type"`
+	Version string    `json:"version"`
+	Body    []Element `json:"body"`
+	Actions []Action  `json:"actions,omitempty"`
+}
+
+// Element is a TextBlock or FactSet element of an adaptive card body.
+type Element struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Wrap     bool   `json:"wrap,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Facts    []Fact `json:"facts,omitempty"`
+}
+
+// Fact is a single title/value pair of a FactSet.
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Action is an adaptive card action.
+type Action struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Card colors, as defined by the adaptive card schema.
+const (
+	colorUnacked = "attention"
+	colorAcked   = "warning"
+	colorClosed  = "good"
+)
+
+// maxDetailsLen is the number of characters of alert details included in a card.
+const maxDetailsLen = 1000
+
+func newMessage(body []Element, actions ...Action) Message {
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: Card{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+			},
+		}},
+	}
+}
+
+func textBlock(text string) Element {
+	return Element{Type: "TextBlock", Text: text, Wrap: true}
+}
+
+func titleBlock(text, color string) Element {
+	return Element{Type: "TextBlock", Text: text, Weight: "bolder", Size: "medium", Color: color, Wrap: true}
+}
+
+func openURL(title, url string) Action {
+	return Action{Type: "Action.OpenUrl", Title: title, URL: url}
+}
+
+// truncate will shorten s to at most n runes, adding an ellipsis if anything was removed.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n-1]) + "…"
+}
+
+// alertCard returns a card for a new alert.
+//
+// Incoming webhooks cannot deliver action callbacks to GoAlert, so
+// acknowledging or closing is done from the linked alert page.
+func alertCard(appName, alertURL string, id int, summary, details, serviceName, runbookURL string, meta []Fact) Message {
+	body := []Element{
+		titleBlock(fmt.Sprintf("Alert #%d: %s", id, summary), colorUnacked),
+		{Type: "FactSet", Facts: []Fact{
+			{Title: "Service", Value: serviceName},
+			{Title: "Status", Value: "Unacknowledged"},
+		}},
+	}
+	if details = strings.TrimSpace(details); details != "" {
+		body = append(body, textBlock(truncate(details, maxDetailsLen)))
+	}
+	if len(meta) > 0 {
+		body = append(body, Element{Type: "FactSet", Facts: meta})
+	}
+	body = append(body, Element{Type: "TextBlock", Text: appName, IsSubtle: true, Size: "small"})
+
+	actions := []Action{openURL("Acknowledge or Close", alertURL)}
+	if runbookURL != "" {
+		actions = append(actions, openURL("Open Runbook", runbookURL))
+	}
+
+	return newMessage(body, actions...)
+}
+
+// alertStatusCard returns a card for an alert status update.
+func alertStatusCard(alertURL string, id int, summary, logEntry string, closed bool) Message {
+	color := colorAcked
+	if closed {
+		color = colorClosed
+	}
+
+	return newMessage([]Element{
+		titleBlock(fmt.Sprintf("Alert #%d: %s", id, summary), color),
+		textBlock(logEntry),
+	}, openURL("View Alert", alertURL))
+}
+
+// alertBundleCard returns a card for a bundle of unacknowledged alerts.
+func alertBundleCard(serviceURL, serviceName string, count int) Message {
+	return newMessage([]Element{
+		titleBlock(fmt.Sprintf("Service '%s' has %d unacknowledged alerts.", serviceName, count), colorUnacked),
+	}, openURL("View Alerts", serviceURL))
+}
+
+// onCallCard returns a card listing the users currently on call for a schedule.
+func onCallCard(scheduleURL, scheduleName string, users []string) Message {
+	text := "No users are on-call for " + scheduleName + "."
+	if len(users) > 0 {
+		text = "Users on-call for " + scheduleName + ": " + strings.Join(users, ", ")
+	}
+
+	return newMessage([]Element{textBlock(text)}, openURL("View Schedule", scheduleURL))
+}
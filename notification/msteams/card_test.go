@@ -0,0 +1,69 @@
+package msteams
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertCard(t *testing.T) {
+	msg := alertCard("GoAlert", "https://example.com/alerts/123", 123, "Disk full", "Only 1% remaining", "Storage", "", nil)
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	var raw struct {
+		Type        string
+		Attachments []struct {
+			ContentType string
+			Content     struct {
+				Type    string
+				Body    []map[string]any
+				Actions []map[string]any
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(data, &raw))
+
+	assert.Equal(t, "message", raw.Type)
+	require.Len(t, raw.Attachments, 1)
+	card := raw.Attachments[0]
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", card.ContentType)
+	assert.Equal(t, "AdaptiveCard", card.Content.Type)
+	assert.Equal(t, "Alert #123: Disk full", card.Content.Body[0]["text"])
+	assert.Equal(t, "Only 1% remaining", card.Content.Body[2]["text"])
+	require.Len(t, card.Content.Actions, 1)
+	assert.Equal(t, "Action.OpenUrl", card.Content.Actions[0]["type"])
+	assert.Equal(t, "https://example.com/alerts/123", card.Content.Actions[0]["url"])
+}
+
+func TestAlertCard_NoDetails(t *testing.T) {
+	msg := alertCard("GoAlert", "https://example.com/alerts/1", 1, "Summary", "  ", "Svc", "", nil)
+	body := msg.Attachments[0].Content.Body
+	require.Len(t, body, 3, "details block should be omitted")
+	assert.True(t, body[2].IsSubtle)
+}
+
+func TestAlertCard_Meta(t *testing.T) {
+	msg := alertCard("GoAlert", "https://example.com/alerts/1", 1, "Summary", "Details", "Svc", "", []Fact{{Title: "runbook", Value: "https://example.com/runbook"}})
+	body := msg.Attachments[0].Content.Body
+	require.Len(t, body, 5)
+	assert.Equal(t, "FactSet", body[3].Type)
+	assert.Equal(t, []Fact{{Title: "runbook", Value: "https://example.com/runbook"}}, body[3].Facts)
+}
+
+func TestAlertCard_Runbook(t *testing.T) {
+	msg := alertCard("GoAlert", "https://example.com/alerts/1", 1, "Summary", "Details", "Svc", "https://example.com/runbook", nil)
+	actions := msg.Attachments[0].Content.Actions
+	require.Len(t, actions, 2)
+	assert.Equal(t, "https://example.com/runbook", actions[1].URL)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abcd…", truncate("abcdefgh", 5))
+	assert.Len(t, []rune(truncate(strings.Repeat("é", 2000), maxDetailsLen)), maxDetailsLen)
+}
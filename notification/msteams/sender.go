@@ -0,0 +1,133 @@
+package msteams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/retry"
+)
+
+// maxAttempts is the number of times a rate-limited request will be attempted.
+const maxAttempts = 3
+
+// Sender posts notifications to Microsoft Teams channels using incoming webhooks.
+type Sender struct{}
+
+var _ notification.Sender = &Sender{}
+
+// NewSender will create a new Sender.
+func NewSender(ctx context.Context) *Sender {
+	return &Sender{}
+}
+
+// Send will post the message as an adaptive card to the destination webhook URL.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.MSTeams.Enable {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "Microsoft Teams is disabled",
+		}, nil
+	}
+
+	var payload Message
+	switch m := msg.(type) {
+	case notification.Test:
+		payload = newMessage([]Element{textBlock(fmt.Sprintf("This is a test message from %s.", cfg.ApplicationName()))})
+	case notification.Alert:
+		var meta []Fact
+		for _, k := range m.MetaKeys() {
+			meta = append(meta, Fact{Title: k, Value: m.Meta[k]})
+		}
+		payload = alertCard(cfg.ApplicationName(), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)), m.AlertID, m.Summary, m.Details, m.ServiceName, m.RunbookURL, meta)
+	case notification.AlertStatus:
+		payload = alertStatusCard(cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)), m.AlertID, m.Summary, m.LogEntry, m.NewAlertState == notification.AlertStateClosed)
+	case notification.AlertBundle:
+		payload = alertBundleCard(cfg.CallbackURL("/services/"+m.ServiceID+"/alerts"), m.ServiceName, m.Count)
+	case notification.ScheduleOnCallUsers:
+		names := make([]string, len(m.Users))
+		for i, u := range m.Users {
+			names[i] = u.Name
+		}
+		payload = onCallCard(m.ScheduleURL, m.ScheduleName, names)
+	default:
+		return nil, fmt.Errorf("message type '%s' not supported", m.Type().String())
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.post(ctx, msg.Destination().Value, data)
+}
+
+func waitContext(ctx context.Context, delay time.Duration) error {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryAfter returns the delay requested by a rate-limited response, defaulting to one second.
+func retryAfter(resp *http.Response) time.Duration {
+	sec, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || sec <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(sec) * time.Second
+}
+
+// post will send data to the webhook URL, waiting out rate limits. Server errors
+// are returned as temporary so that the message is retried.
+func (s *Sender) post(ctx context.Context, webhookURL string, data []byte) (*notification.SentMessage, error) {
+	for i := 0; i < maxAttempts; i++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			err = waitContext(ctx, retryAfter(resp))
+			if err != nil {
+				return nil, retry.TemporaryError(fmt.Errorf("rate limited: %w", err))
+			}
+
+			// retry
+			continue
+		case resp.StatusCode >= 500:
+			return nil, retry.TemporaryError(fmt.Errorf("teams webhook: %s", resp.Status))
+		case resp.StatusCode >= 400:
+			return &notification.SentMessage{
+				State:        notification.StateFailedPerm,
+				StateDetails: fmt.Sprintf("%s: %s", resp.Status, bytes.TrimSpace(body)),
+			}, nil
+		}
+
+		return &notification.SentMessage{State: notification.StateSent}, nil
+	}
+
+	return nil, retry.TemporaryError(fmt.Errorf("teams webhook: rate limited after %d attempts", maxAttempts))
+}
@@ -50,6 +50,12 @@ func (nr *namedReceiver) Receive(ctx context.Context, callbackID string, result
 	return nr.r.Receive(ctx, callbackID, result)
 }
 
+// ReceiveFrom implements the Receiver interface by calling the underlying Receiver.ReceiveFrom method.
+func (nr *namedReceiver) ReceiveFrom(ctx context.Context, callbackID string, from Dest, result Result) error {
+	metricRecvTotal.WithLabelValues(nr.ns.destType.String(), result.String())
+	return nr.r.ReceiveFrom(ctx, callbackID, from, result)
+}
+
 // Receive implements the Receiver interface by calling the underlying Receiver.ReceiveSubject method.
 func (nr *namedReceiver) ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error {
 	metricRecvTotal.WithLabelValues(nr.ns.destType.String(), result.String())
@@ -0,0 +1,104 @@
+package pagerduty
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Event is a PagerDuty Events API v2 event.
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key"`
+	Client      string   `json:"client,omitempty"`
+	ClientURL   string   `json:"client_url,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+	Links       []Link   `json:"links,omitempty"`
+}
+
+// Payload contains the details of a triggered event.
+type Payload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Link is a link attached to a triggered event.
+type Link struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// maxSummaryLength is the longest summary accepted by the Events API.
+const maxSummaryLength = 1024
+
+// alertDedupKey returns the dedup key of the PagerDuty incident for an alert, so
+// that later events (e.g., resolve) apply to the same incident.
+func alertDedupKey(alertID int) string { return "goalert-alert-" + strconv.Itoa(alertID) }
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+// triggerEvent returns a trigger event for an alert. Alert details, and any metadata,
+// are included as custom details.
+func triggerEvent(appName, alertURL string, alertID int, summary, details, serviceName, runbookURL string, meta map[string]string) Event {
+	custom := make(map[string]string, len(meta)+1)
+	for k, v := range meta {
+		custom[k] = v
+	}
+	if details != "" {
+		custom["details"] = details
+	}
+
+	links := []Link{{Href: alertURL, Text: fmt.Sprintf("%s Alert #%d", appName, alertID)}}
+	if runbookURL != "" {
+		links = append(links, Link{Href: runbookURL, Text: "Runbook"})
+	}
+
+	return Event{
+		EventAction: "trigger",
+		DedupKey:    alertDedupKey(alertID),
+		Client:      appName,
+		ClientURL:   alertURL,
+		Payload: &Payload{
+			Summary:       truncate(fmt.Sprintf("Alert #%d: %s", alertID, summary), maxSummaryLength),
+			Source:        appName,
+			Severity:      "critical",
+			Component:     serviceName,
+			CustomDetails: custom,
+		},
+		Links: links,
+	}
+}
+
+// bundleEvent returns a trigger event for an alert bundle. Bundled alerts share a single
+// incident that is not resolved automatically.
+func bundleEvent(appName, alertsURL, bundleID, serviceName string, count int) Event {
+	return Event{
+		EventAction: "trigger",
+		DedupKey:    "goalert-bundle-" + bundleID,
+		Client:      appName,
+		ClientURL:   alertsURL,
+		Payload: &Payload{
+			Summary:   truncate(fmt.Sprintf("Service '%s' has %d unacknowledged alerts", serviceName, count), maxSummaryLength),
+			Source:    appName,
+			Severity:  "critical",
+			Component: serviceName,
+		},
+		Links: []Link{{Href: alertsURL, Text: serviceName + " alerts"}},
+	}
+}
+
+// resolveEvent returns an event resolving the incident for an alert.
+func resolveEvent(alertID int) Event {
+	return Event{
+		EventAction: "resolve",
+		DedupKey:    alertDedupKey(alertID),
+	}
+}
@@ -0,0 +1,45 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerEvent(t *testing.T) {
+	ev := triggerEvent("GoAlert", "https://example.com/alerts/123", 123, "Disk full", "Only 1% remaining", "Storage", "https://example.com/runbook", map[string]string{"host": "db1"})
+	ev.RoutingKey = "abc"
+
+	data, err := json.Marshal(ev)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "trigger", raw["event_action"])
+	assert.Equal(t, "goalert-alert-123", raw["dedup_key"])
+	assert.Equal(t, "abc", raw["routing_key"])
+
+	payload := raw["payload"].(map[string]any)
+	assert.Equal(t, "Alert #123: Disk full", payload["summary"])
+	assert.Equal(t, "critical", payload["severity"])
+	assert.Equal(t, map[string]any{"host": "db1", "details": "Only 1% remaining"}, payload["custom_details"])
+	assert.Equal(t, []any{
+		map[string]any{"href": "https://example.com/alerts/123", "text": "GoAlert Alert #123"},
+		map[string]any{"href": "https://example.com/runbook", "text": "Runbook"},
+	}, raw["links"])
+
+	long := triggerEvent("GoAlert", "", 1, strings.Repeat("a", 2000), "", "", "", nil)
+	assert.Len(t, long.Payload.Summary, maxSummaryLength)
+	assert.Empty(t, long.Payload.CustomDetails)
+	assert.Len(t, long.Links, 1)
+}
+
+func TestResolveEvent(t *testing.T) {
+	data, err := json.Marshal(resolveEvent(123))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"routing_key":"","event_action":"resolve","dedup_key":"goalert-alert-123"}`, string(data))
+}
@@ -0,0 +1,120 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/retry"
+)
+
+// EventsURL is the endpoint of the PagerDuty Events API v2.
+const EventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Sender triggers (and optionally resolves) PagerDuty incidents for alerts using the Events API v2.
+type Sender struct {
+	nc *notificationchannel.Store
+}
+
+var _ notification.Sender = &Sender{}
+
+// NewSender will create a new Sender, using nc to look up the routing key for each channel.
+func NewSender(ctx context.Context, nc *notificationchannel.Store) *Sender {
+	return &Sender{nc: nc}
+}
+
+// Send will trigger a PagerDuty incident for an alert (or alert bundle), or resolve it once the
+// alert is closed if PagerDuty.ResolveOnClose is set. Other status updates are not forwarded.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.PagerDuty.Enable {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "PagerDuty is disabled",
+		}, nil
+	}
+
+	var ev Event
+	switch m := msg.(type) {
+	case notification.Alert:
+		ev = triggerEvent(cfg.ApplicationName(), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)), m.AlertID, m.Summary, m.Details, m.ServiceName, m.RunbookURL, m.Meta)
+	case notification.AlertBundle:
+		ev = bundleEvent(cfg.ApplicationName(), cfg.CallbackURL("/services/"+m.ServiceID+"/alerts"), m.CallbackID, m.ServiceName, m.Count)
+	case notification.AlertStatus:
+		if m.NewAlertState != notification.AlertStateClosed || !cfg.PagerDuty.ResolveOnClose {
+			return &notification.SentMessage{
+				State:        notification.StateSent,
+				StateDetails: "not forwarded to PagerDuty",
+			}, nil
+		}
+		ev = resolveEvent(m.AlertID)
+	default:
+		return nil, fmt.Errorf("message type '%s' not supported", m.Type().String())
+	}
+
+	id, err := uuid.Parse(msg.Destination().ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse channel ID: %w", err)
+	}
+	ev.RoutingKey, err = s.nc.PagerDutyRoutingKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup routing key: %w", err)
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.post(ctx, data)
+}
+
+// post will send the event to PagerDuty. Rate limits and server errors are returned
+// as temporary so that the message is retried.
+func (s *Sender) post(ctx context.Context, data []byte) (*notification.SentMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", EventsURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, retry.TemporaryError(fmt.Errorf("pagerduty events api: %w", err))
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Status  string
+		Message string
+		Errors  []string
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = json.Unmarshal(raw, &body)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return nil, retry.TemporaryError(fmt.Errorf("pagerduty events api: %s", resp.Status))
+	case resp.StatusCode >= 400:
+		details := resp.Status
+		if body.Message != "" {
+			details += ": " + body.Message
+		}
+		for _, e := range body.Errors {
+			details += "; " + e
+		}
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: details,
+		}, nil
+	}
+
+	return &notification.SentMessage{State: notification.StateSent}, nil
+}
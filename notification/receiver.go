@@ -14,6 +14,9 @@ type Receiver interface {
 	// Receive records a response to a previously sent message.
 	Receive(ctx context.Context, callbackID string, result Result) error
 
+	// ReceiveFrom records a response to a previously sent message, if from is the destination it was sent to.
+	ReceiveFrom(ctx context.Context, callbackID string, from Dest, result Result) error
+
 	// ReceiveSubject records a response to a previously sent message from a provider/subject (e.g. Slack user).
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
 
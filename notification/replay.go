@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// minTimeBetweenReplays is how long after replaying a message it can be replayed again.
+	minTimeBetweenReplays = time.Minute
+
+	// maxReplays is the maximum number of times a single message can be replayed.
+	maxReplays = 5
+)
+
+// ReplayMessageTx will send a copy of a previously processed message to its original destination,
+// returning the ID of the new message. The copy references the original, and is sent as-is:
+// it is never bundled, is sent even if the alert is no longer active, and does not count toward
+// user notification limits.
+//
+// Bundles and verification messages cannot be replayed.
+func (s *Store) ReplayMessageTx(ctx context.Context, tx *sql.Tx, id string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return "", err
+	}
+	err = validate.UUID("MessageID", id)
+	if err != nil {
+		return "", err
+	}
+
+	// serialize replays of the same message, so concurrent requests can't exceed the limits
+	err = tx.StmtContext(ctx, s.replayLock).QueryRowContext(ctx, id).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", validation.NewFieldError("MessageID", "not found")
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "lock message")
+	}
+
+	var typ, status string
+	var replays int
+	var tooSoon bool
+	err = tx.StmtContext(ctx, s.replayInfo).QueryRowContext(ctx, id, fmt.Sprintf("%f seconds", minTimeBetweenReplays.Seconds())).Scan(&typ, &status, &replays, &tooSoon)
+	if err != nil {
+		return "", errors.Wrap(err, "lookup message")
+	}
+
+	switch typ {
+	case "alert_notification_bundle", "alert_status_update_bundle":
+		return "", validation.NewFieldError("MessageID", "bundled messages cannot be replayed")
+	case "verification_message":
+		return "", validation.NewFieldError("MessageID", "verification messages cannot be replayed")
+	}
+	switch status {
+	case "pending", "sending":
+		return "", validation.NewFieldError("MessageID", "message has not been sent yet")
+	case "bundled":
+		return "", validation.NewFieldError("MessageID", "message was bundled; replay the bundle's original alert notification instead")
+	}
+	if replays >= maxReplays {
+		return "", validation.NewFieldError("MessageID", fmt.Sprintf("message cannot be replayed more than %d times", maxReplays))
+	}
+	if tooSoon {
+		return "", validation.NewFieldError("MessageID", "replay rate-limit exceeded")
+	}
+
+	newID := uuid.New().String()
+	_, err = tx.StmtContext(ctx, s.insertReplay).ExecContext(ctx, id, newID)
+	if err != nil {
+		return "", errors.Wrap(err, "insert replay")
+	}
+
+	return newID, nil
+}
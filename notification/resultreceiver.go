@@ -11,6 +11,7 @@ type ResultReceiver interface {
 	SetSendResult(ctx context.Context, res *SendResult) error
 
 	Receive(ctx context.Context, callbackID string, result Result) error
+	ReceiveFrom(ctx context.Context, callbackID string, from Dest, result Result) error
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
 	AuthLinkURL(ctx context.Context, providerID, subjectID string, meta authlink.Metadata) (string, error)
 	Start(context.Context, Dest) error
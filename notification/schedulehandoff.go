@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleHandoff is a Message that notifies a user of an on-call handoff for a Schedule.
+type ScheduleHandoff struct {
+	Dest       Dest
+	CallbackID string
+
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	// OnCall is true if the user is about to go on call, and false if
+	// they have gone off call.
+	OnCall bool
+
+	// ShiftTime is the expected start of the shift if OnCall is true,
+	// otherwise it is when the shift ended.
+	ShiftTime time.Time
+}
+
+var _ Message = &ScheduleHandoff{}
+
+func (s ScheduleHandoff) ID() string        { return s.CallbackID }
+func (s ScheduleHandoff) Destination() Dest { return s.Dest }
+func (s ScheduleHandoff) Type() MessageType { return MessageTypeScheduleHandoff }
+
+// Body returns a plain-text description of the handoff.
+func (s ScheduleHandoff) Body() string {
+	if s.OnCall {
+		return fmt.Sprintf("You will be on call for schedule '%s' starting at %s.", s.ScheduleName, s.ShiftTime.Format("3:04 PM MST"))
+	}
+
+	return fmt.Sprintf("You are no longer on call for schedule '%s'.", s.ScheduleName)
+}
@@ -3,6 +3,7 @@ package notification
 import (
 	"context"
 	"database/sql"
+	"math"
 	"text/template"
 	"time"
 
@@ -40,6 +41,12 @@ type MessageLog struct {
 
 	SentAt     *time.Time
 	RetryCount int
+
+	// DeadLetteredAt is set if the message was dead-lettered after exhausting all retries.
+	DeadLetteredAt *time.Time
+
+	// ReplayOfID is the ID of the original message, if this message is a replay.
+	ReplayOfID string
 }
 
 // SearchOptions allow filtering and paginating the list of messages.
@@ -53,6 +60,19 @@ type SearchOptions struct {
 	// Omit specifies a list of message IDs to exclude from the results
 	Omit []string `json:"o,omitempty"`
 
+	// DeadLettered will limit results to messages that were dead-lettered.
+	DeadLettered bool `json:"dl,omitempty"`
+
+	// AlertID will limit results to messages for a single alert, including bundled messages.
+	AlertID int `json:"al,omitempty"`
+
+	// ServiceIDs will limit results to messages for the given services.
+	ServiceIDs []string `json:"svc,omitempty"`
+
+	// StuckFor will limit results to messages that have been pending or sending for at
+	// least the given duration, oldest first.
+	StuckFor time.Duration `json:"sf,omitempty"`
+
 	Limit int `json:"-"`
 }
 
@@ -72,7 +92,7 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		om.id, om.created_at, om.last_status_at, om.message_type, om.last_status, om.status_details,
 		om.src_value, om.alert_id, om.provider_msg_id,
 		om.user_id, u.name, om.contact_method_id, om.channel_id, om.service_id, s.name,
-		om.sent_at, om.retry_count
+		om.sent_at, om.retry_count, om.dead_lettered_at, om.replay_of_id
 	{{end}}
 	FROM outgoing_messages om
 	LEFT JOIN users u ON om.user_id = u.id
@@ -83,6 +103,19 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 	{{if .Omit}}
 		AND NOT om.id = any(:omit)
 	{{end}}
+	{{if .DeadLettered}}
+		AND om.dead_lettered_at NOTNULL
+	{{end}}
+	{{if .AlertID}}
+		AND om.alert_id = :alertID
+	{{end}}
+	{{if .ServiceIDs}}
+		AND om.service_id = any(:serviceIDs)
+	{{end}}
+	{{if .StuckFor}}
+		AND om.last_status IN ('pending', 'sending')
+		AND coalesce(om.last_status_at, om.created_at) <= now() - :stuckSeconds * '1 second'::interval
+	{{end}}
 	{{if not .CreatedAfter.IsZero}}
 		AND om.created_at >= :createdAfter
 	{{end}}
@@ -108,9 +141,14 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		AND om.created_at < :cursorCreatedAt
 		OR (om.created_at = :cursorCreatedAt AND om.id > :afterID)
 	{{end}}
+	{{if not .AlertID}}
 		AND om.last_status != 'bundled'
+	{{end}}
 	{{if .TimeSeries}}
 	GROUP BY bucket
+	{{else if .StuckFor}}
+	ORDER BY om.created_at, om.id
+	LIMIT {{.Limit}}
 	{{else}}
 	ORDER BY om.last_status = 'pending' desc, coalesce(om.sent_at, om.last_status_at) desc, om.created_at desc, om.id asc
 	LIMIT {{.Limit}}
@@ -135,6 +173,8 @@ func (opts renderData) Normalize() (*renderData, error) {
 		// should be 1 more than the expected limit
 		validate.Range("Limit", opts.Limit, 0, 101),
 		validate.ManyUUID("Omit", opts.Omit, 50),
+		validate.ManyUUID("ServiceIDs", opts.ServiceIDs, 50),
+		validate.Duration("StuckFor", opts.StuckFor, 0, 30*24*time.Hour),
 	)
 	if err != nil {
 		return nil, err
@@ -169,6 +209,9 @@ func (opts renderData) QueryArgs() []sql.NamedArg {
 		sql.Named("afterID", opts.After.ID),
 		sql.Named("createdBefore", opts.CreatedBefore),
 		sql.Named("omit", sqlutil.UUIDArray(opts.Omit)),
+		sql.Named("alertID", opts.AlertID),
+		sql.Named("serviceIDs", sqlutil.UUIDArray(opts.ServiceIDs)),
+		sql.Named("stuckSeconds", int(opts.StuckFor.Seconds())),
 		sql.Named("timeSeriesOrigin", opts.TimeSeriesOrigin.Unix()),
 		sql.Named("timeSeriesInterval", int(opts.TimeSeriesInterval.Seconds())),
 	}
@@ -248,6 +291,21 @@ func makeTimeSeries(start, end, origin time.Time, duration time.Duration, counts
 	return buckets
 }
 
+// FindAlertMessages returns the most recent messages sent (or queued) for an alert,
+// including those that were bundled with other alerts.
+func (s *Store) FindAlertMessages(ctx context.Context, alertID int) ([]MessageLog, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("AlertID", alertID, 1, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.search(ctx, &SearchOptions{AlertID: alertID, Limit: 100})
+}
+
 func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog, error) {
 	if opts == nil {
 		opts = &SearchOptions{}
@@ -258,8 +316,12 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog,
 		return nil, err
 	}
 
+	return s.search(ctx, opts)
+}
+
+func (s *Store) search(ctx context.Context, opts *SearchOptions) ([]MessageLog, error) {
 	data := &renderData{SearchOptions: *opts}
-	data, err = data.Normalize()
+	data, err := data.Normalize()
 	if err != nil {
 		return nil, err
 	}
@@ -287,9 +349,9 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog,
 		var serviceID, svcName sql.NullString
 		var srcValue sql.NullString
 		var userID, userName sql.NullString
-		var cmID sql.NullString
+		var cmID, replayOfID sql.NullString
 		var providerID sql.NullString
-		var lastStatusAt, sentAt sql.NullTime
+		var lastStatusAt, sentAt, deadLetteredAt sql.NullTime
 		err = rows.Scan(
 			&l.ID,
 			&l.CreatedAt,
@@ -308,6 +370,8 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog,
 			&svcName,
 			&sentAt,
 			&retryCount,
+			&deadLetteredAt,
+			&replayOfID,
 		)
 		if err != nil {
 			return nil, err
@@ -329,11 +393,15 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog,
 		l.UserID = userID.String
 		l.UserName = userName.String
 		l.ContactMethodID = cmID.String
+		l.ReplayOfID = replayOfID.String
 		l.LastStatusAt = lastStatusAt.Time
 		if sentAt.Valid {
 			l.SentAt = &sentAt.Time
 		}
 		l.RetryCount = int(retryCount.Int32)
+		if deadLetteredAt.Valid {
+			l.DeadLetteredAt = &deadLetteredAt.Time
+		}
 
 		result = append(result, l)
 	}
@@ -297,7 +297,7 @@ const (
 )
 
 // alertMsgOption will return the slack.MsgOption for an alert-type message (e.g., notification or status update).
-func alertMsgOption(ctx context.Context, callbackID string, id int, summary, logEntry string, state notification.AlertState) slack.MsgOption {
+func alertMsgOption(ctx context.Context, callbackID string, id int, summary, logEntry, runbookURL string, state notification.AlertState) slack.MsgOption {
 	blocks := []slack.Block{
 		slack.NewSectionBlock(
 			slack.NewTextBlockObject("mrkdwn", alertLink(ctx, id, summary), false, false), nil, nil),
@@ -330,6 +330,11 @@ func alertMsgOption(ctx context.Context, callbackID string, id int, summary, log
 	blocks = append(blocks,
 		slack.NewContextBlock("", slack.NewTextBlockObject("plain_text", logEntry, false, false)),
 	)
+	if runbookURL != "" {
+		blocks = append(blocks,
+			slack.NewContextBlock("", slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("<%s|Runbook>", runbookURL), false, false)),
+		)
+	}
 	cfg := config.FromContext(ctx)
 	if len(actions) > 0 && cfg.Slack.InteractiveMessages {
 		blocks = append(blocks, actions...)
@@ -385,14 +390,14 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 			break
 		}
 
-		opts = append(opts, alertMsgOption(ctx, t.CallbackID, t.AlertID, t.Summary, "Unacknowledged", notification.AlertStateUnacknowledged))
+		opts = append(opts, alertMsgOption(ctx, t.CallbackID, t.AlertID, t.Summary, "Unacknowledged", t.RunbookURL, notification.AlertStateUnacknowledged))
 	case notification.AlertStatus:
 		isUpdate = true
 		var ts string
 		channelID, ts = chanTS(channelID, t.OriginalStatus.ProviderMessageID.ExternalID)
 		opts = append(opts,
 			slack.MsgOptionUpdate(ts),
-			alertMsgOption(ctx, t.OriginalStatus.ID, t.AlertID, t.Summary, t.LogEntry, t.NewAlertState),
+			alertMsgOption(ctx, t.OriginalStatus.ID, t.AlertID, t.Summary, t.LogEntry, t.RunbookURL, t.NewAlertState),
 		)
 	case notification.AlertBundle:
 		opts = append(opts, slack.MsgOptionText(
@@ -400,6 +405,10 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 			false))
 	case notification.ScheduleOnCallUsers:
 		opts = append(opts, slack.MsgOptionText(s.onCallNotificationText(ctx, t), false))
+	case notification.ScheduleHandoff:
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("%s\n\n<%s>", slackutilsx.EscapeMessage(t.Body()), t.ScheduleURL), false))
+	case notification.ContactMethodDisabled:
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("%s\n\n<%s>", slackutilsx.EscapeMessage(t.Body()), t.ProfileURL), false))
 	default:
 		return nil, errors.Errorf("unsupported message type: %T", t)
 	}
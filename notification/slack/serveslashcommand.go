@@ -0,0 +1,202 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// slashCommandListLimit is the maximum number of alerts returned by the list command.
+const slashCommandListLimit = 15
+
+const slashCommandUsage = "Usage:\n" +
+	"• `list [search]` - list open alerts\n" +
+	"• `ack <alert ID>` - acknowledge an alert\n" +
+	"• `close <alert ID>` - close an alert"
+
+type slashCommand struct {
+	Action  string
+	AlertID int
+	Search  string
+}
+
+// parseSlashCommand parses the text of a slash command (e.g. `ack 123`).
+func parseSlashCommand(text string) (*slashCommand, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil, validation.NewFieldError("text", "command is required")
+	}
+
+	cmd := &slashCommand{Action: strings.ToLower(fields[0])}
+	switch cmd.Action {
+	case "list":
+		cmd.Search = strings.Join(fields[1:], " ")
+	case "ack", "close":
+		if len(fields) != 2 {
+			return nil, validation.NewFieldErrorf("text", "%s requires a single alert ID", cmd.Action)
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+		if err != nil || id <= 0 {
+			return nil, validation.NewFieldErrorf("text", "invalid alert ID '%s'", fields[1])
+		}
+		cmd.AlertID = id
+	default:
+		return nil, validation.NewFieldErrorf("text", "unknown command '%s'", cmd.Action)
+	}
+
+	return cmd, nil
+}
+
+func writeEphemeral(ctx context.Context, w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("write slash command response: %w", err))
+	}
+}
+
+// ServeSlashCommand handles slash commands (e.g. `/goalert ack 123`) from Slack.
+//
+// The Slack user must be linked to a GoAlert user, and actions are performed with
+// that user's permissions. All responses are ephemeral.
+func (s *ChannelSender) ServeSlashCommand(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !cfg.Slack.SlashCommands {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	err := validateRequestSignature(time.Now(), req)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	teamID := req.FormValue("team_id")
+	teamDomain := req.FormValue("team_domain")
+	slackUserID := req.FormValue("user_id")
+	slackUserName := req.FormValue("user_name")
+
+	cmd, err := parseSlashCommand(req.FormValue("text"))
+	if err != nil {
+		writeEphemeral(ctx, w, err.Error()+"\n\n"+slashCommandUsage)
+		return
+	}
+
+	var usr *user.User
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		usr, err = s.cfg.UserStore.FindOneBySubject(ctx, "slack:"+teamID, slackUserID)
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("slash command: find user: %w", err))
+		writeEphemeral(ctx, w, "Failed to look up your GoAlert account, please try again later.")
+		return
+	}
+	if usr == nil {
+		writeEphemeral(ctx, w, s.slashCommandLinkText(ctx, teamID, teamDomain, slackUserID, slackUserName))
+		return
+	}
+
+	ctx = permission.UserSourceContext(ctx, usr.ID, usr.Role, &permission.SourceInfo{
+		Type: permission.SourceTypeSlashCommand,
+		ID:   "slack:" + teamID + ":" + slackUserID,
+	})
+
+	var text string
+	switch cmd.Action {
+	case "list":
+		text, err = s.slashCommandList(ctx, cmd.Search)
+	case "ack":
+		err = s.cfg.AlertStore.UpdateStatus(ctx, cmd.AlertID, alert.StatusActive)
+		text = fmt.Sprintf("Acknowledged alert #%d.", cmd.AlertID)
+		if alert.IsAlreadyAcknowledged(err) {
+			err = nil
+			text = fmt.Sprintf("Alert #%d is already acknowledged.", cmd.AlertID)
+		}
+	case "close":
+		err = s.cfg.AlertStore.UpdateStatus(ctx, cmd.AlertID, alert.StatusClosed)
+		text = fmt.Sprintf("Closed alert #%d.", cmd.AlertID)
+		if alert.IsAlreadyClosed(err) {
+			err = nil
+			text = fmt.Sprintf("Alert #%d is already closed.", cmd.AlertID)
+		}
+	}
+	switch {
+	case err == nil:
+	case permission.IsPermissionError(err), validation.IsClientError(err):
+		text = "Error: " + err.Error()
+	default:
+		log.Log(ctx, fmt.Errorf("slash command %s: %w", cmd.Action, err))
+		text = "Something went wrong, please try again later."
+	}
+
+	writeEphemeral(ctx, w, text)
+}
+
+// slashCommandLinkText returns the response for a Slack user that is not yet linked to a GoAlert user.
+func (s *ChannelSender) slashCommandLinkText(ctx context.Context, teamID, teamDomain, userID, userName string) string {
+	if teamID == "" || teamDomain == "" || userID == "" || userName == "" {
+		// missing data, don't allow linking
+		log.Log(ctx, fmt.Errorf("slack slash command missing required data"))
+		return "Your Slack account isn't currently linked to GoAlert, please try again later."
+	}
+
+	linkURL, err := s.recv.AuthLinkURL(ctx, "slack:"+teamID, userID, authlink.Metadata{
+		UserDetails: fmt.Sprintf("Slack user @%s from %s.slack.com", userName, teamDomain),
+	})
+	if err != nil {
+		log.Log(ctx, err)
+	}
+	if linkURL == "" {
+		return "Your Slack account isn't currently linked to GoAlert, please try again later."
+	}
+
+	return fmt.Sprintf("Please <%s|link your Slack account> with GoAlert, then try again.", linkURL)
+}
+
+func (s *ChannelSender) slashCommandList(ctx context.Context, search string) (string, error) {
+	alerts, err := s.cfg.AlertStore.Search(ctx, &alert.SearchOptions{
+		Search: search,
+		Status: []alert.Status{alert.StatusTriggered, alert.StatusActive},
+		Limit:  slashCommandListLimit + 1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(alerts) == 0 {
+		return "No open alerts.", nil
+	}
+
+	var b strings.Builder
+	for i, a := range alerts {
+		if i == slashCommandListLimit {
+			fmt.Fprintf(&b, "\n_Only the first %d alerts are shown._", slashCommandListLimit)
+			break
+		}
+		status := "Unacknowledged"
+		if a.Status == alert.StatusActive {
+			status = "Acknowledged"
+		}
+		fmt.Fprintf(&b, "• %s (%s)\n", alertLink(ctx, a.ID, a.Summary), status)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,34 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlashCommand(t *testing.T) {
+	check := func(text string, exp *slashCommand) {
+		t.Helper()
+		cmd, err := parseSlashCommand(text)
+		require.NoError(t, err, text)
+		assert.Equal(t, exp, cmd, text)
+	}
+	checkErr := func(text string) {
+		t.Helper()
+		_, err := parseSlashCommand(text)
+		assert.Error(t, err, text)
+	}
+
+	check("list", &slashCommand{Action: "list"})
+	check("LIST  db  errors", &slashCommand{Action: "list", Search: "db errors"})
+	check("ack 123", &slashCommand{Action: "ack", AlertID: 123})
+	check("close #42", &slashCommand{Action: "close", AlertID: 42})
+
+	checkErr("")
+	checkErr("ack")
+	checkErr("ack 1 2")
+	checkErr("ack foo")
+	checkErr("close -1")
+	checkErr("escalate 1")
+}
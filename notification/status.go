@@ -40,6 +40,11 @@ type SendResult struct {
 	Status
 
 	DestType DestType
+
+	// Skipped indicates the message was never passed to a provider, for example because the
+	// alert was acknowledged before it was sent. Skipped messages are failed without counting
+	// against the contact method.
+	Skipped bool
 }
 
 // State represents the current state of an outgoing message.
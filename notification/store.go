@@ -28,6 +28,7 @@ type Store struct {
 	db                           *sql.DB
 	getCMUserID                  *sql.Stmt
 	setVerificationCode          *sql.Stmt
+	voiceVerifyInfo              *sql.Stmt
 	verifyAndEnableContactMethod *sql.Stmt
 	insertTestNotification       *sql.Stmt
 	updateLastSendTime           *sql.Stmt
@@ -36,9 +37,15 @@ type Store struct {
 	sendTestLock                 *sql.Stmt
 	findManyMessageStatuses      *sql.Stmt
 	lastMessageStatus            *sql.Stmt
+	findMessageAttempts          *sql.Stmt
+	findRuleCMs                  *sql.Stmt
 
 	origAlertMessage *sql.Stmt
 
+	replayLock   *sql.Stmt
+	replayInfo   *sql.Stmt
+	insertReplay *sql.Stmt
+
 	rand *rand.Rand
 }
 
@@ -72,6 +79,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			from outgoing_messages om
 			where
 				message_type = 'alert_notification' and
+				not watcher and
 				alert_id = $1 and
 				(contact_method_id = $2 or channel_id = $3)
 			order by sent_at
@@ -82,6 +90,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		sendTestLock: p.P(`lock outgoing_messages, user_contact_methods in row exclusive mode`),
 
+		findRuleCMs: p.P(`
+			select distinct cm.id
+			from user_contact_methods cm
+			join user_notification_rules nr on nr.contact_method_id = cm.id
+			where cm.user_id = $1 and not cm.disabled
+			order by cm.id
+		`),
+
 		getCode: p.P(`
 			select code
 			from user_verification_codes
@@ -95,13 +111,24 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		// should result in sending a verification code to the specified contact method
+		// the existing code is kept on conflict, so a code already sent remains valid
+		// if it is re-sent by a different channel
 		setVerificationCode: p.P(`
-			insert into user_verification_codes (id, contact_method_id, code, expires_at)
-			values ($1, $2, $3, NOW() + '15 minutes'::interval)
+			insert into user_verification_codes (id, contact_method_id, code, expires_at, voice)
+			values ($1, $2, $3, NOW() + '15 minutes'::interval, $4)
 			on conflict (contact_method_id) do update
 			set
 				sent = false,
-				expires_at = EXCLUDED.expires_at
+				expires_at = EXCLUDED.expires_at,
+				voice = EXCLUDED.voice
+		`),
+
+		// returns if the contact method supports voice verification, and if a valid code was already sent by SMS
+		voiceVerifyInfo: p.P(`
+			select cm.type = 'SMS', coalesce(not code.voice and code.sent and now() < code.expires_at, false)
+			from user_contact_methods cm
+			left join user_verification_codes code on code.contact_method_id = cm.id
+			where cm.id = $1
 		`),
 
 		// should reactivate a contact method if specified code matches what was set
@@ -112,7 +139,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				returning contact_method_id id
 			)
 			update user_contact_methods cm
-			set disabled = false
+			set disabled = false, consecutive_failures = 0, auto_disabled_at = null
 			from v
 			where cm.id = v.id
 			returning cm.id
@@ -173,6 +200,61 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			from outgoing_messages om
 			where message_type = $1 and contact_method_id = $2 and created_at >= $3
 		`),
+		replayLock: p.P(`select id from outgoing_messages where id = $1 for update`),
+		replayInfo: p.P(`
+			select
+				om.message_type,
+				om.last_status,
+				(select count(*) from outgoing_messages r where r.replay_of_id = om.id),
+				coalesce((select max(r.created_at) from outgoing_messages r where r.replay_of_id = om.id) + cast($2 as interval) > now(), false)
+			from outgoing_messages om
+			where om.id = $1
+		`),
+		insertReplay: p.P(`
+			insert into outgoing_messages (
+				id,
+				replay_of_id,
+				message_type,
+				contact_method_id,
+				channel_id,
+				user_id,
+				alert_id,
+				alert_log_id,
+				service_id,
+				escalation_policy_id,
+				schedule_id,
+				schedule_handoff_notice_id,
+				disabled_contact_method_id,
+				status_alert_ids,
+				shadow,
+				watcher
+			)
+			select
+				$2,
+				id,
+				message_type,
+				contact_method_id,
+				channel_id,
+				user_id,
+				alert_id,
+				alert_log_id,
+				service_id,
+				escalation_policy_id,
+				schedule_id,
+				schedule_handoff_notice_id,
+				disabled_contact_method_id,
+				status_alert_ids,
+				shadow,
+				watcher
+			from outgoing_messages
+			where id = $1
+		`),
+		findMessageAttempts: p.P(`
+			select attempt, status, status_details, created_at
+			from outgoing_message_attempts
+			where message_id = $1
+			order by attempt
+		`),
 	}, p.Err
 }
 
@@ -307,7 +389,95 @@ func (s *Store) SendContactMethodTest(ctx context.Context, id string) error {
 	return tx.Commit()
 }
 
-func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string) error {
+// SendNotificationRulesTest will send a test message to every enabled contact method the user has
+// a notification rule for, returning the IDs of the contact methods a test was sent to.
+//
+// Contact methods tested within the last minute are skipped; if all of them are, a rate-limit
+// error is returned.
+func (s *Store) SendNotificationRulesTest(ctx context.Context, userID string) ([]string, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// only allow users to send test messages to their own contact methods
+	err = permission.LimitCheckAny(ctx, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "notification: send notification rules test", tx)
+
+	_, err = tx.StmtContext(ctx, s.sendTestLock).ExecContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.StmtContext(ctx, s.findRuleCMs).QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cmIDs []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		cmIDs = append(cmIDs, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(cmIDs) == 0 {
+		return nil, validation.NewFieldError("UserID", "no enabled contact methods with notification rules")
+	}
+
+	sent := []string{}
+	for _, id := range cmIDs {
+		r, err := tx.StmtContext(ctx, s.updateLastSendTime).ExecContext(ctx, id, fmt.Sprintf("%f seconds", minTimeBetweenTests.Seconds()))
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if n != 1 {
+			// tested recently
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, s.insertTestNotification).ExecContext(ctx, uuid.New().String(), id)
+		if err != nil {
+			return nil, err
+		}
+		sent = append(sent, id)
+	}
+	if len(sent) == 0 {
+		return nil, validation.NewFieldError("UserID", "test message rate-limit exceeded")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return sent, nil
+}
+
+// SendContactMethodVerification will send a verification code to the contact method. If voice is
+// true, the code is read aloud by a voice call instead of being sent by SMS.
+//
+// An existing code is re-sent rather than replaced, so either message may be used to verify. Switching
+// to voice after a code was sent by SMS is not subject to the rate limit.
+func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string, voice bool) error {
 	_, err := s.cmUserID(ctx, cmID)
 	if err != nil {
 		return err
@@ -319,7 +489,22 @@ func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string)
 	}
 	defer sqlutil.Rollback(ctx, "notification: send verification message", tx)
 
-	r, err := tx.StmtContext(ctx, s.updateLastSendTime).ExecContext(ctx, cmID, fmt.Sprintf("%f seconds", minTimeBetweenTests.Seconds()))
+	minTime := minTimeBetweenTests
+	if voice {
+		var isSMS, sentBySMS bool
+		err = tx.StmtContext(ctx, s.voiceVerifyInfo).QueryRowContext(ctx, cmID).Scan(&isSMS, &sentBySMS)
+		if err != nil {
+			return errors.Wrap(err, "lookup verification info")
+		}
+		if !isSMS {
+			return validation.NewFieldError("Voice", "only supported for SMS contact methods")
+		}
+		if sentBySMS {
+			minTime = 0
+		}
+	}
+
+	r, err := tx.StmtContext(ctx, s.updateLastSendTime).ExecContext(ctx, cmID, fmt.Sprintf("%f seconds", minTime.Seconds()))
 	if err != nil {
 		return err
 	}
@@ -333,7 +518,7 @@ func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string)
 
 	vcID := uuid.New().String()
 	code := s.rand.Intn(900000) + 100000
-	_, err = tx.StmtContext(ctx, s.setVerificationCode).ExecContext(ctx, vcID, cmID, code)
+	_, err = tx.StmtContext(ctx, s.setVerificationCode).ExecContext(ctx, vcID, cmID, code, voice)
 	if err != nil {
 		return errors.Wrap(err, "set verification code")
 	}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxStuckMessages is the maximum number of messages returned by FindStuckMessages.
+const MaxStuckMessages = 100
+
+// StuckMessageOptions allow filtering the list of stuck messages.
+type StuckMessageOptions struct {
+	// Threshold is how long a message must have been pending or sending to be considered stuck.
+	Threshold time.Duration
+
+	ServiceIDs    []string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// StuckMessageGroup contains the stuck messages for a single destination.
+type StuckMessageGroup struct {
+	ContactMethodID string
+	ChannelID       uuid.UUID
+
+	// Messages are ordered oldest first.
+	Messages []MessageLog
+}
+
+// groupByDest groups messages by contact method or notification channel, preserving
+// the order in which each destination first appears.
+func groupByDest(logs []MessageLog) []StuckMessageGroup {
+	type key struct {
+		cmID   string
+		chanID uuid.UUID
+	}
+
+	var groups []StuckMessageGroup
+	index := make(map[key]int)
+	for _, l := range logs {
+		k := key{cmID: l.ContactMethodID, chanID: l.ChannelID}
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, StuckMessageGroup{ContactMethodID: l.ContactMethodID, ChannelID: l.ChannelID})
+		}
+		groups[i].Messages = append(groups[i].Messages, l)
+	}
+
+	return groups
+}
+
+// FindStuckMessages returns up to MaxStuckMessages of the oldest messages that have been
+// pending or sending for longer than the threshold, grouped by destination.
+func (s *Store) FindStuckMessages(ctx context.Context, opts StuckMessageOptions) ([]StuckMessageGroup, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.Duration("Threshold", opts.Threshold, time.Minute, 30*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.search(ctx, &SearchOptions{
+		StuckFor:      opts.Threshold,
+		ServiceIDs:    opts.ServiceIDs,
+		CreatedAfter:  opts.CreatedAfter,
+		CreatedBefore: opts.CreatedBefore,
+		Limit:         MaxStuckMessages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groupByDest(logs), nil
+}
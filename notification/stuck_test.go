@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByDest(t *testing.T) {
+	chanID := uuid.New()
+	logs := []MessageLog{
+		{ID: "1", ContactMethodID: "cm1"},
+		{ID: "2", ChannelID: chanID},
+		{ID: "3", ContactMethodID: "cm2"},
+		{ID: "4", ContactMethodID: "cm1"},
+		{ID: "5", ChannelID: chanID},
+	}
+
+	groups := groupByDest(logs)
+	assert.Len(t, groups, 3)
+
+	ids := func(g StuckMessageGroup) []string {
+		var res []string
+		for _, m := range g.Messages {
+			res = append(res, m.ID)
+		}
+		return res
+	}
+
+	assert.Equal(t, "cm1", groups[0].ContactMethodID)
+	assert.Equal(t, []string{"1", "4"}, ids(groups[0]))
+	assert.Equal(t, chanID, groups[1].ChannelID)
+	assert.Equal(t, []string{"2", "5"}, ids(groups[1]))
+	assert.Equal(t, "cm2", groups[2].ContactMethodID)
+	assert.Equal(t, []string{"3"}, ids(groups[2]))
+
+	assert.Empty(t, groupByDest(nil))
+}
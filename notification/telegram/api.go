@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/retry"
+)
+
+// maxAttempts is the number of times a rate-limited request will be attempted.
+const maxAttempts = 3
+
+// apiError is a permanent error returned by the Bot API.
+type apiError struct {
+	Code        int
+	Description string
+}
+
+func (e *apiError) Error() string { return fmt.Sprintf("telegram: %d %s", e.Code, e.Description) }
+
+// Blocked returns true if the error indicates the user blocked the bot or
+// the bot was removed from the chat.
+func (e *apiError) Blocked() bool {
+	if e.Code != http.StatusForbidden {
+		return false
+	}
+	desc := strings.ToLower(e.Description)
+	return strings.Contains(desc, "blocked by the user") ||
+		strings.Contains(desc, "user is deactivated") ||
+		strings.Contains(desc, "kicked from") ||
+		strings.Contains(desc, "not a member")
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+func waitContext(ctx context.Context, delay time.Duration) error {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// call will invoke a Bot API method, waiting out rate limits. Server errors are
+// returned as temporary so that the message is retried, and other failures as
+// an *apiError.
+func (s *Sender) call(ctx context.Context, token, method string, params, result interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < maxAttempts; i++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL+"/bot"+token+"/"+method, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		var res apiResponse
+		if resp.StatusCode >= 500 {
+			return retry.TemporaryError(fmt.Errorf("telegram %s: %s", method, resp.Status))
+		}
+		err = json.Unmarshal(body, &res)
+		if err != nil {
+			return fmt.Errorf("telegram %s: decode response: %w", method, err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			delay := time.Duration(res.Parameters.RetryAfter) * time.Second
+			if delay <= 0 {
+				delay = time.Second
+			}
+			err = waitContext(ctx, delay)
+			if err != nil {
+				return retry.TemporaryError(fmt.Errorf("rate limited: %w", err))
+			}
+
+			// retry
+			continue
+		case !res.OK:
+			return &apiError{Code: res.ErrorCode, Description: res.Description}
+		}
+
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(res.Result, result)
+	}
+
+	return retry.TemporaryError(fmt.Errorf("telegram %s: rate limited after %d attempts", method, maxAttempts))
+}
@@ -0,0 +1,122 @@
+package telegram
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/target/goalert/notification"
+)
+
+// sendMessage holds the parameters of the sendMessage Bot API method.
+type sendMessage struct {
+	ChatID              string          `json:"chat_id"`
+	Text                string          `json:"text"`
+	ParseMode           string          `json:"parse_mode"`
+	DisableWebPreview   bool            `json:"disable_web_page_preview,omitempty"`
+	ReplyToMessageID    int64           `json:"reply_to_message_id,omitempty"`
+	AllowSendingNoReply bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyMarkup         *inlineKeyboard `json:"reply_markup,omitempty"`
+}
+
+type inlineKeyboard struct {
+	InlineKeyboard [][]inlineButton `json:"inline_keyboard"`
+}
+
+type inlineButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// Callback data prefixes for inline keyboard buttons.
+const (
+	actionAck   = "ack"
+	actionClose = "close"
+)
+
+// maxDetailsLen is the number of characters of alert details included in a message.
+// Telegram limits messages to 4096 characters.
+const maxDetailsLen = 3000
+
+// truncate will shorten s to at most n runes, adding an ellipsis if anything was removed.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n-1]) + "…"
+}
+
+func link(text, url string) string {
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+}
+
+// callbackData encodes an action and callback ID for an inline keyboard button.
+func callbackData(action, callbackID string) string { return action + ":" + callbackID }
+
+// parseCallbackData returns the result and callback ID encoded in button data.
+func parseCallbackData(data string) (notification.Result, string, bool) {
+	action, id, ok := strings.Cut(data, ":")
+	if !ok || id == "" {
+		return 0, "", false
+	}
+
+	switch action {
+	case actionAck:
+		return notification.ResultAcknowledge, id, true
+	case actionClose:
+		return notification.ResultResolve, id, true
+	}
+
+	return 0, "", false
+}
+
+// responseKeyboard returns buttons to acknowledge or close the alert(s) associated with callbackID.
+func responseKeyboard(callbackID string, ackLabel, closeLabel string) *inlineKeyboard {
+	return &inlineKeyboard{InlineKeyboard: [][]inlineButton{{
+		{Text: ackLabel, CallbackData: callbackData(actionAck, callbackID)},
+		{Text: closeLabel, CallbackData: callbackData(actionClose, callbackID)},
+	}}}
+}
+
+// alertText returns the HTML text of a new alert message.
+func alertText(appName, alertURL string, a notification.Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<b>Alert #%d: %s</b>\n", a.AlertID, html.EscapeString(a.Summary))
+	fmt.Fprintf(&b, "Service: %s\n", html.EscapeString(a.ServiceName))
+	if created := a.CreatedAtText(); created != "" {
+		fmt.Fprintf(&b, "Created: %s\n", html.EscapeString(created))
+	}
+	if details := strings.TrimSpace(a.Details); details != "" {
+		fmt.Fprintf(&b, "\n%s\n", html.EscapeString(truncate(details, maxDetailsLen)))
+	}
+	if keys := a.MetaKeys(); len(keys) > 0 {
+		b.WriteString("\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s: %s\n", html.EscapeString(k), html.EscapeString(a.Meta[k]))
+		}
+	}
+
+	b.WriteString("\n" + link("Open in "+appName, alertURL))
+	if a.RunbookURL != "" {
+		b.WriteString(" | " + link("Runbook", a.RunbookURL))
+	}
+
+	return b.String()
+}
+
+// alertStatusText returns the HTML text of an alert status update.
+func alertStatusText(alertURL string, s notification.AlertStatus) string {
+	return fmt.Sprintf("<b>Alert #%d: %s</b>\n%s\n\n%s",
+		s.AlertID, html.EscapeString(s.Summary),
+		html.EscapeString(s.LogEntry),
+		link("View Alert", alertURL),
+	)
+}
+
+// alertBundleText returns the HTML text of a bundle of unacknowledged alerts.
+func alertBundleText(serviceURL string, b notification.AlertBundle) string {
+	return fmt.Sprintf("<b>Service '%s' has %d unacknowledged alerts.</b>\n\n%s",
+		html.EscapeString(b.ServiceName), b.Count, link("View Alerts", serviceURL))
+}
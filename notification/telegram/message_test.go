@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/notification"
+)
+
+func TestAlertText(t *testing.T) {
+	text := alertText("GoAlert", "https://example.com/alerts/123", notification.Alert{
+		AlertID:     123,
+		Summary:     "Disk <full>",
+		Details:     "Only 1% remaining",
+		ServiceName: "Storage",
+		Meta:        map[string]string{"host": "db1"},
+		RunbookURL:  "https://example.com/runbook?a=1&b=2",
+	})
+
+	assert.Equal(t, "<b>Alert #123: Disk &lt;full&gt;</b>\n"+
+		"Service: Storage\n"+
+		"\nOnly 1% remaining\n"+
+		"\nhost: db1\n"+
+		"\n<a href=\"https://example.com/alerts/123\">Open in GoAlert</a>"+
+		" | <a href=\"https://example.com/runbook?a=1&amp;b=2\">Runbook</a>", text)
+}
+
+func TestCallbackData(t *testing.T) {
+	const id = "2cc9a9f6-e1bb-4a04-8d6f-a4ed1f0b2a3e"
+
+	kb := responseKeyboard(id, "Acknowledge", "Close")
+	require.Len(t, kb.InlineKeyboard, 1)
+	require.Len(t, kb.InlineKeyboard[0], 2)
+	for _, btn := range kb.InlineKeyboard[0] {
+		// Telegram limits callback data to 64 bytes.
+		assert.LessOrEqual(t, len(btn.CallbackData), 64)
+	}
+
+	res, cbID, ok := parseCallbackData(kb.InlineKeyboard[0][0].CallbackData)
+	assert.True(t, ok)
+	assert.Equal(t, notification.ResultAcknowledge, res)
+	assert.Equal(t, id, cbID)
+
+	res, cbID, ok = parseCallbackData(kb.InlineKeyboard[0][1].CallbackData)
+	assert.True(t, ok)
+	assert.Equal(t, notification.ResultResolve, res)
+	assert.Equal(t, id, cbID)
+
+	_, _, ok = parseCallbackData("escalate:" + id)
+	assert.False(t, ok)
+	_, _, ok = parseCallbackData("ack:")
+	assert.False(t, ok)
+}
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "/start", command("/start"))
+	assert.Equal(t, "/start", command(" /Start@GoAlertBot extra"))
+	assert.Equal(t, "hello", command("hello world"))
+}
+
+func TestSender_Call(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		assert.Equal(t, "/bottoken/sendMessage", req.URL.Path)
+		_, _ = io.ReadAll(req.Body)
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0","parameters":{"retry_after":0}}`)
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`)
+		default:
+			_, _ = io.WriteString(w, `{"ok":true,"result":{"message_id":42}}`)
+		}
+	}))
+	defer srv.Close()
+
+	s := &Sender{apiURL: srv.URL}
+	ctx := context.Background()
+
+	var apiErr *apiError
+	err := s.call(ctx, "token", "sendMessage", sendMessage{ChatID: "1", Text: "hi"}, nil)
+	require.ErrorAs(t, err, &apiErr, "rate limit should be retried, then return the permanent error")
+	assert.True(t, apiErr.Blocked())
+	assert.Equal(t, 2, calls)
+
+	var res sentMessage
+	err = s.call(ctx, "token", "sendMessage", sendMessage{ChatID: "1", Text: "hi"}, &res)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, res.MessageID)
+}
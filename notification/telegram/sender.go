@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
+)
+
+// Sender delivers notifications to Telegram chats using the Bot API.
+type Sender struct {
+	r      notification.Receiver
+	apiURL string
+}
+
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+// NewSender will create a new Sender.
+func NewSender(ctx context.Context) *Sender {
+	return &Sender{apiURL: "https://api.telegram.org"}
+}
+
+// SetReceiver sets the notification.Receiver for incoming responses and status updates.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+type sentMessage struct {
+	MessageID int64 `json:"message_id"`
+}
+
+// Send will send the message to the destination chat.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Telegram.Enable {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "Telegram is disabled",
+		}, nil
+	}
+
+	req := sendMessage{
+		ChatID:            msg.Destination().Value,
+		ParseMode:         "HTML",
+		DisableWebPreview: true,
+	}
+	switch m := msg.(type) {
+	case notification.Test:
+		req.Text = fmt.Sprintf("This is a test message from %s.", cfg.ApplicationName())
+	case notification.Verification:
+		req.Text = fmt.Sprintf("%s verification code: %d", cfg.ApplicationName(), m.Code)
+	case notification.Alert:
+		req.Text = alertText(cfg.ApplicationName(), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)), m)
+		req.ReplyMarkup = responseKeyboard(m.CallbackID, "Acknowledge", "Close")
+	case notification.AlertStatus:
+		req.Text = alertStatusText(cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)), m)
+		origID, _ := strconv.ParseInt(m.OriginalStatus.ProviderMessageID.ExternalID, 10, 64)
+		if origID != 0 {
+			req.ReplyToMessageID = origID
+			req.AllowSendingNoReply = true
+		}
+		if origID != 0 && m.NewAlertState == notification.AlertStateClosed {
+			s.removeKeyboard(ctx, req.ChatID, origID)
+		}
+	case notification.AlertBundle:
+		req.Text = alertBundleText(cfg.CallbackURL("/services/"+m.ServiceID+"/alerts"), m)
+		req.ReplyMarkup = responseKeyboard(m.CallbackID, "Acknowledge All", "Close All")
+	case notification.ScheduleHandoff:
+		req.Text = m.Body()
+		req.ParseMode = ""
+	case notification.ContactMethodDisabled:
+		req.Text = m.Body() + "\n\n" + m.ProfileURL
+		req.ParseMode = ""
+	default:
+		return nil, fmt.Errorf("message type '%s' not supported", m.Type().String())
+	}
+
+	var res sentMessage
+	err := s.call(ctx, cfg.Telegram.BotToken, "sendMessage", req, &res)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		if apiErr.Blocked() && s.r != nil {
+			// Treat it like a STOP so that the contact method is disabled until the
+			// user messages the bot again.
+			stopErr := s.r.Stop(ctx, msg.Destination())
+			if stopErr != nil {
+				log.Log(ctx, fmt.Errorf("disable blocked Telegram chat: %w", stopErr))
+			}
+		}
+
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: apiErr.Description,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.SentMessage{
+		ExternalID: strconv.FormatInt(res.MessageID, 10),
+		State:      notification.StateSent,
+	}, nil
+}
+
+// removeKeyboard will remove the response buttons from a previously sent alert message.
+func (s *Sender) removeKeyboard(ctx context.Context, chatID string, messageID int64) {
+	err := s.call(ctx, config.FromContext(ctx).Telegram.BotToken, "editMessageReplyMarkup", struct {
+		ChatID    string `json:"chat_id"`
+		MessageID int64  `json:"message_id"`
+	}{ChatID: chatID, MessageID: messageID}, nil)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("remove Telegram response buttons: %w", err))
+	}
+}
@@ -0,0 +1,159 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// update is the subset of a Bot API Update handled by GoAlert.
+type update struct {
+	Message *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+	CallbackQuery *struct {
+		ID      string `json:"id"`
+		Data    string `json:"data"`
+		Message *struct {
+			Chat struct {
+				ID int64 `json:"id"`
+			} `json:"chat"`
+		} `json:"message"`
+	} `json:"callback_query"`
+}
+
+// command returns the bot command in text, without any @botname suffix or arguments.
+func command(text string) string {
+	cmd, _, _ := strings.Cut(strings.TrimSpace(text), " ")
+	cmd, _, _ = strings.Cut(cmd, "@")
+	return strings.ToLower(cmd)
+}
+
+// ServeWebhook handles updates delivered by the Bot API webhook. Commands allow a user
+// to find their chat ID and opt in or out of notifications, and callback queries are
+// sent by the Acknowledge and Close buttons of alert messages.
+func (s *Sender) ServeWebhook(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !cfg.Telegram.Enable {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(req.Header.Get("X-Telegram-Bot-Api-Secret-Token")), []byte(cfg.Telegram.WebhookSecret)) != 1 {
+		errutil.HTTPError(ctx, w, permission.Unauthorized())
+		return
+	}
+
+	var u update
+	err := json.NewDecoder(req.Body).Decode(&u)
+	if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
+		return
+	}
+
+	ctx = log.WithField(ctx, "Type", "Telegram")
+	switch {
+	case u.CallbackQuery != nil:
+		s.handleCallbackQuery(ctx, u.CallbackQuery.ID, u.CallbackQuery.Data)
+	case u.Message != nil:
+		s.handleCommand(ctx, strconv.FormatInt(u.Message.Chat.ID, 10), command(u.Message.Text))
+	}
+
+	// Always acknowledge the update, otherwise Telegram will keep redelivering it.
+}
+
+func (s *Sender) reply(ctx context.Context, chatID, text string) {
+	err := s.call(ctx, config.FromContext(ctx).Telegram.BotToken, "sendMessage", sendMessage{ChatID: chatID, Text: text}, nil)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("send Telegram reply: %w", err))
+	}
+}
+
+func (s *Sender) handleCommand(ctx context.Context, chatID, cmd string) {
+	cfg := config.FromContext(ctx)
+	retryOpts := []retry.Option{
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	}
+	dest := notification.Dest{Type: notification.DestTypeTelegram, Value: chatID}
+
+	switch cmd {
+	case "/start":
+		err := retry.DoTemporaryError(func(int) error { return s.r.Start(ctx, dest) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process /start command: %w", err))
+		}
+		s.reply(ctx, chatID, fmt.Sprintf("Your Telegram chat ID is %s. Add it as a Telegram contact method in %s to receive notifications here.\n\nSend /stop to stop receiving notifications.", chatID, cfg.ApplicationName()))
+	case "/stop":
+		err := retry.DoTemporaryError(func(int) error { return s.r.Stop(ctx, dest) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process /stop command: %w", err))
+			s.reply(ctx, chatID, "System error, please try again later.")
+			return
+		}
+		s.reply(ctx, chatID, fmt.Sprintf("You will no longer receive notifications from %s in this chat. Send /start to enable them again.", cfg.ApplicationName()))
+	}
+}
+
+func (s *Sender) handleCallbackQuery(ctx context.Context, queryID, data string) {
+	text := "Unknown action."
+	res, callbackID, ok := parseCallbackData(data)
+	if ok {
+		text = s.receive(ctx, callbackID, res)
+	}
+
+	err := s.call(ctx, config.FromContext(ctx).Telegram.BotToken, "answerCallbackQuery", struct {
+		CallbackQueryID string `json:"callback_query_id"`
+		Text            string `json:"text"`
+	}{CallbackQueryID: queryID, Text: text}, nil)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("answer Telegram callback query: %w", err))
+	}
+}
+
+// receive will process a button press, returning the text to show the user.
+func (s *Sender) receive(ctx context.Context, callbackID string, res notification.Result) string {
+	err := retry.DoTemporaryError(func(int) error {
+		return s.r.Receive(ctx, callbackID, res)
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+
+	var e validation.FieldError
+	switch {
+	case err == nil && res == notification.ResultAcknowledge:
+		return "Acknowledged"
+	case err == nil:
+		return "Closed"
+	case alert.IsAlreadyClosed(err):
+		return fmt.Sprintf("Alert #%d already closed", alert.AlertID(err))
+	case alert.IsAlreadyAcknowledged(err):
+		return fmt.Sprintf("Alert #%d already acknowledged", alert.AlertID(err))
+	case errors.As(err, &e):
+		return "Error: " + e.Reason()
+	}
+
+	log.Log(ctx, fmt.Errorf("process notification response: %w", err))
+	return "System error. Visit the dashboard to manage alerts."
+}
@@ -0,0 +1,17 @@
+package notification
+
+import "time"
+
+// TimeFormat is the layout used for timestamps in notifications. The zone abbreviation
+// is included so recipients can tell which time zone it is in.
+const TimeFormat = "Jan 2, 2006 3:04 PM MST"
+
+// FormatTime will format t for display in a notification, in the time zone of t. An empty
+// string is returned if t is the zero time.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(TimeFormat)
+}
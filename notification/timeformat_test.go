@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	assert.Empty(t, FormatTime(time.Time{}))
+
+	// standard time
+	ts := time.Date(2023, 1, 5, 18, 30, 0, 0, time.UTC)
+	assert.Equal(t, "Jan 5, 2023 6:30 PM UTC", FormatTime(ts))
+	assert.Equal(t, "Jan 5, 2023 12:30 PM CST", FormatTime(ts.In(loc)))
+
+	// daylight saving time
+	ts = time.Date(2023, 7, 5, 18, 30, 0, 0, time.UTC)
+	assert.Equal(t, "Jul 5, 2023 1:30 PM CDT", FormatTime(ts.In(loc)))
+
+	// across the transition (2023-03-12 2:00 AM CST -> 3:00 AM CDT)
+	ts = time.Date(2023, 3, 12, 7, 59, 0, 0, time.UTC)
+	assert.Equal(t, "Mar 12, 2023 1:59 AM CST", FormatTime(ts.In(loc)))
+	assert.Equal(t, "Mar 12, 2023 3:00 AM CDT", FormatTime(ts.Add(time.Minute).In(loc)))
+}
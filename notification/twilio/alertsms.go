@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sort"
 	"strings"
 	"text/template"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
@@ -139,14 +141,102 @@ func normalizeGSM(str string) (s string) {
 	return s
 }
 
-func renderMinGSMSegments(inputs []string, render func(inputs []string) (string, error)) (string, error) {
+// smsEllipsis is appended to truncated fields. The GSM alphabet does not include
+// the single-character ellipsis.
+const smsEllipsis = "..."
+
+// maxSMSLen will return the maximum length of an SMS message, in characters.
+func maxSMSLen(cfg config.Config) int {
+	n := cfg.Twilio.SMSMaxLength
+	if n <= 0 {
+		n = maxGSMLen
+	}
+	if n > maxGSMLen && !cfg.Twilio.SMSAllowMultipart {
+		n = maxGSMLen
+	}
+
+	return n
+}
+
+// truncateWords will return s limited to n characters. If s is longer, it is cut at the
+// last word boundary and an ellipsis is appended (within the limit). A single word longer
+// than the limit is cut mid-word.
+func truncateWords(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= len(smsEllipsis) {
+		return ""
+	}
+
+	cut := r[:n-len(smsEllipsis)]
+	if r[len(cut)] != ' ' {
+		for i := len(cut) - 1; i > 0; i-- {
+			if cut[i] == ' ' {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+
+	return strings.TrimRight(string(cut), " ") + smsEllipsis
+}
+
+// renderSizeGSM will render the inputs within maxLen characters. Inputs are truncated at
+// word boundaries one by one, starting with the last, until the result fits, so earlier
+// inputs (e.g., the summary) are kept intact when possible.
+func renderSizeGSM(maxLen int, inputs []string, render func(inputs []string) (string, error)) (string, error) {
+	cpy := make([]string, len(inputs))
+	copy(cpy, inputs)
+	fits := func() (string, bool, error) {
+		result, err := render(cpy)
+		return result, utf8.RuneCountInString(result) <= maxLen, err
+	}
+
+	result, ok, err := fits()
+	if err != nil || ok {
+		return result, err
+	}
+
+	for i := len(inputs) - 1; i >= 0; i-- {
+		s := inputs[i]
+		var renderErr error
+		// find the first length that is too long
+		n := sort.Search(utf8.RuneCountInString(s)+1, func(n int) bool {
+			cpy[i] = truncateWords(s, n)
+			_, ok, err := fits()
+			if err != nil {
+				renderErr = err
+				return true
+			}
+			return !ok
+		})
+		if renderErr != nil {
+			return "", renderErr
+		}
+		if n > 0 {
+			cpy[i] = truncateWords(s, n-1)
+			result, _, err = fits()
+			return result, err
+		}
+		cpy[i] = ""
+	}
+
+	return "", util.ErrNoSolution
+}
+
+// renderMinGSMSegments will render the inputs within maxLen characters. If the message
+// cannot fit, even with all inputs removed, it is rendered using the minimum number of
+// additional segments instead.
+func renderMinGSMSegments(maxLen int, inputs []string, render func(inputs []string) (string, error)) (string, error) {
 	for i, s := range inputs {
 		inputs[i] = normalizeGSM(s)
 	}
 
-	size := maxGSMLen
+	size := maxLen
 	for {
-		result, err := util.RenderSizeN(size, inputs, func(inputs []string) (string, error) {
+		result, err := renderSizeGSM(size, inputs, func(inputs []string) (string, error) {
 			cpy := make([]string, len(inputs))
 			for i, s := range inputs {
 				cpy[i] = strings.TrimSpace(s)
@@ -169,8 +259,8 @@ func renderMinGSMSegments(inputs []string, render func(inputs []string) (string,
 // renderAlertMessage will render a SMS message for an Alert.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
-// truncated (as needed) to use the minimum number of message segments.
-func renderAlertMessage(appName string, a notification.Alert, link string, code int) (string, error) {
+// truncated (as needed) to fit within maxLen characters.
+func renderAlertMessage(maxLen int, appName string, a notification.Alert, link string, code int) (string, error) {
 	var buf bytes.Buffer
 	var data struct {
 		AppName string
@@ -183,7 +273,7 @@ func renderAlertMessage(appName string, a notification.Alert, link string, code
 	data.Link = link
 	data.Code = code
 
-	result, err := renderMinGSMSegments([]string{a.Summary}, func(inputs []string) (string, error) {
+	result, err := renderMinGSMSegments(maxLen, []string{a.Summary}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.Summary = inputs[0]
 		err := alertTempl.Execute(&buf, data)
@@ -202,8 +292,8 @@ func renderAlertMessage(appName string, a notification.Alert, link string, code
 // renderAlertStatusMessage will render a SMS message for an Alert Status.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
-// truncated (as needed) to use the minimum number of message segments.
-func renderAlertStatusMessage(appName string, a notification.AlertStatus) (string, error) {
+// truncated (as needed) to fit within maxLen characters.
+func renderAlertStatusMessage(maxLen int, appName string, a notification.AlertStatus) (string, error) {
 	var buf bytes.Buffer
 	var data struct {
 		AppName string
@@ -211,7 +301,7 @@ func renderAlertStatusMessage(appName string, a notification.AlertStatus) (strin
 	}
 	data.AppName = appName
 	data.AlertStatus = a
-	result, err := renderMinGSMSegments([]string{a.Summary, a.LogEntry}, func(inputs []string) (string, error) {
+	result, err := renderMinGSMSegments(maxLen, []string{a.Summary, a.LogEntry}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.Summary = inputs[0]
 		data.LogEntry = inputs[1]
@@ -231,8 +321,8 @@ func renderAlertStatusMessage(appName string, a notification.AlertStatus) (strin
 // renderAlertBundleMessage will render an SMS message for an Alert Bundle.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
-// truncated (as needed) to use the minimum number of message segments.
-func renderAlertBundleMessage(appName string, a notification.AlertBundle, link string, code int) (string, error) {
+// truncated (as needed) to fit within maxLen characters.
+func renderAlertBundleMessage(maxLen int, appName string, a notification.AlertBundle, link string, code int) (string, error) {
 	var buf bytes.Buffer
 
 	var data struct {
@@ -246,7 +336,7 @@ func renderAlertBundleMessage(appName string, a notification.AlertBundle, link s
 	data.Link = link
 	data.Code = code
 
-	result, err := renderMinGSMSegments([]string{data.AlertBundle.ServiceName}, func(inputs []string) (string, error) {
+	result, err := renderMinGSMSegments(maxLen, []string{data.AlertBundle.ServiceName}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.ServiceName = inputs[0]
 		err := bundleTempl.Execute(&buf, data)
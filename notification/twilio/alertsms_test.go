@@ -4,8 +4,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 )
 
@@ -36,7 +40,7 @@ func TestMapGSM(t *testing.T) {
 func TestSMS_RenderAlert(t *testing.T) {
 	check := func(name string, a notification.Alert, link string, code int, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertMessage("TestApp", a, link, code)
+			res, err := renderAlertMessage(maxGSMLen, "TestApp", a, link, code)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -64,6 +68,20 @@ Reply '1a' to ack, '1e' to escalate, '1c' to close.`,
 		0,
 		`TestApp: Alert #123: Testing
 
+https://example.com/alerts/123`,
+	)
+
+	// the creation time is left out to keep messages to a single segment
+	check("created-at",
+		notification.Alert{
+			AlertID:   123,
+			Summary:   "Testing",
+			CreatedAt: time.Date(2023, 7, 5, 18, 30, 0, 0, time.UTC),
+		},
+		"https://example.com/alerts/123",
+		0,
+		`TestApp: Alert #123: Testing
+
 https://example.com/alerts/123`,
 	)
 
@@ -96,7 +114,7 @@ Reply '1a' to ack, '1e' to escalate, '1c' to close.`,
 		},
 		"https://example.com/alerts/123",
 		1,
-		`TestApp: Alert #123: Testing with a really really obnoxiously long message
+		`TestApp: Alert #123: Testing with a really really obnoxiously long...
 
 https://example.com/alerts/123
 
@@ -110,7 +128,7 @@ Reply '1a' to ack, '1e' to escalate, '1c' to close.`,
 		},
 		"https://example.com/alerts/123",
 		1,
-		`TestApp: Alert #123456789: Testing with a really really obnoxiously long me
+		`TestApp: Alert #123456789: Testing with a really really obnoxiously long...
 
 https://example.com/alerts/123
 
@@ -118,14 +136,14 @@ Reply '1a' to ack, '1e' to escalate, '1c' to close.`,
 	)
 
 	check("message-too-long",
-		// can't fit summary
+		// can't fit in a single segment, so the summary is kept in the second
 		notification.Alert{
 			AlertID: 123456789,
 			Summary: "Testing with a really really obnoxiously long message that will be need to be truncated at some point.",
 		},
 		"https://example.com/alerts/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff",
 		123456789,
-		`TestApp: Alert #123456789: 
+		`TestApp: Alert #123456789: Testing with a really really obnoxiously long message that will be need to be truncated at some point.
 
 https://example.com/alerts/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff
 
@@ -133,10 +151,76 @@ Reply '123456789a' to ack, '123456789e' to escalate, '123456789c' to close.`,
 	)
 }
 
+func TestSMS_RenderAlertLength(t *testing.T) {
+	a := notification.Alert{AlertID: 123}
+	const prefix = "TestApp: Alert #123: "
+	const link = "https://example.com/alerts/123"
+	render := func(maxLen int, summary string) string {
+		t.Helper()
+		a.Summary = summary
+		res, err := renderAlertMessage(maxLen, "TestApp", a, link, 0)
+		require.NoError(t, err)
+		return res
+	}
+	// length of the message with an empty summary
+	base := utf8.RuneCountInString(prefix + "\n\n" + link)
+
+	exact := strings.Repeat("a", 160-base)
+	assert.Equal(t, prefix+exact+"\n\n"+link, render(160, exact), "exactly fits")
+
+	res := render(160, exact+"b")
+	assert.Equal(t, prefix+strings.Repeat("a", 160-base-3)+"...\n\n"+link, res, "single word cut mid-word")
+	assert.Equal(t, 160, utf8.RuneCountInString(res))
+
+	words := strings.Repeat("word ", 40)
+	res = render(160, words)
+	assert.LessOrEqual(t, utf8.RuneCountInString(res), 160)
+	assert.Contains(t, res, "word word...\n\n"+link, "cut at a word boundary")
+
+	res = render(320, words)
+	assert.Equal(t, prefix+strings.TrimSpace(words)+"\n\n"+link, res, "multipart allowed")
+
+	// non-ASCII GSM characters count as a single character
+	exact = strings.Repeat("é", 160-base)
+	assert.Equal(t, prefix+exact+"\n\n"+link, render(160, exact), "unicode exactly fits")
+
+	res = render(160, strings.Repeat("ñé ", 50))
+	assert.True(t, utf8.ValidString(res), "valid UTF-8")
+	assert.LessOrEqual(t, utf8.RuneCountInString(res), 160)
+	assert.Contains(t, res, "ñé ñé...\n\n"+link)
+
+	// non-GSM characters are replaced before truncating
+	res = render(160, strings.Repeat("🔥", 200))
+	assert.Equal(t, prefix+strings.Repeat("?", 160-base-3)+"...\n\n"+link, res)
+}
+
+func TestTruncateWords(t *testing.T) {
+	assert.Equal(t, "hello world", truncateWords("hello world", 11))
+	assert.Equal(t, "hello...", truncateWords("hello world", 10))
+	assert.Equal(t, "hello...", truncateWords("hello world", 8), "word ends at the cut")
+	assert.Equal(t, "hell...", truncateWords("hello world", 7))
+	assert.Equal(t, "", truncateWords("hello world", 3))
+	assert.Equal(t, "ñé...", truncateWords("ñé ñé ñé", 7))
+}
+
+func TestMaxSMSLen(t *testing.T) {
+	var cfg config.Config
+	assert.Equal(t, 160, maxSMSLen(cfg), "default")
+
+	cfg.Twilio.SMSMaxLength = 100
+	assert.Equal(t, 100, maxSMSLen(cfg))
+
+	cfg.Twilio.SMSMaxLength = 500
+	assert.Equal(t, 160, maxSMSLen(cfg), "multipart not allowed")
+
+	cfg.Twilio.SMSAllowMultipart = true
+	assert.Equal(t, 500, maxSMSLen(cfg))
+}
+
 func TestSMS_RenderAlertBundle(t *testing.T) {
 	check := func(name string, a notification.AlertBundle, link string, code int, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertBundleMessage("TestApp", a, link, code)
+			res, err := renderAlertBundleMessage(maxGSMLen, "TestApp", a, link, code)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -173,7 +257,7 @@ func TestSMS_RenderAlertBundle(t *testing.T) {
 func TestSMS_RenderAlertStatus(t *testing.T) {
 	check := func(name string, a notification.AlertStatus, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertStatusMessage("TestApp", a)
+			res, err := renderAlertStatusMessage(maxGSMLen, "TestApp", a)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -188,4 +272,15 @@ func TestSMS_RenderAlertStatus(t *testing.T) {
 
 	Some log entry`,
 	)
+
+	check("alert-status-long",
+		notification.AlertStatus{
+			AlertID:  123,
+			Summary:  "Testing with a summary that is long enough to matter",
+			LogEntry: strings.Repeat("Some log entry that goes on ", 10),
+		},
+		`TestApp: Alert #123: Testing with a summary that is long enough to matter
+
+	Some log entry that goes on Some log entry that goes on Some log entry that goes...`,
+	)
 }
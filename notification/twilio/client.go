@@ -227,6 +227,14 @@ func (voice *VoiceOptions) setMsgParams(msg notification.Message) (err error) {
 		voice.CallType = CallTypeTest
 	case notification.Verification:
 		voice.CallType = CallTypeVerify
+	case notification.ScheduleHandoff:
+		// handoff notices are informational, and use the same call flow as test messages
+		voice.CallType = CallTypeTest
+	case notification.ContactMethodDisabled:
+		voice.CallType = CallTypeTest
+	case notification.AlertBridgeInvite:
+		voice.CallType = CallTypeBridge
+		subID = t.AlertID
 	default:
 		return errors.Errorf("unhandled message type: %T", t)
 	}
@@ -237,6 +245,35 @@ func (voice *VoiceOptions) setMsgParams(msg notification.Message) (err error) {
 	return nil
 }
 
+// EndConference will end an in-progress conference, disconnecting all participants.
+func (c *Config) EndConference(ctx context.Context, sid string) error {
+	cfg := config.FromContext(ctx)
+	v := make(url.Values)
+	v.Set("Status", "completed")
+	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Conferences", sid+".json")
+	resp, err := c.post(ctx, urlStr, v)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != 200 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return errors.Wrap(err, "parse error response")
+		}
+		return &e
+	}
+
+	return nil
+}
+
 // StatusCallbackURL will return the status callback url for the given configuration.
 func (voice *VoiceOptions) StatusCallbackURL(cfg config.Config) (string, error) {
 	if voice == nil {
@@ -328,9 +365,9 @@ func (c *Config) SendSMS(ctx context.Context, to, body string, o *SMSOptions) (*
 			log.Log(ctx, err)
 		}
 		if info != nil {
-			v.Set("From", cfg.TwilioSMSFromNumber(info.Name))
+			v.Set("From", cfg.TwilioSMSFromNumber(to, info.Name))
 		} else {
-			v.Set("From", cfg.TwilioSMSFromNumber(""))
+			v.Set("From", cfg.TwilioSMSFromNumber(to, ""))
 		}
 	}
 	v.Set("Body", body)
@@ -3,6 +3,7 @@ package twilio
 import (
 	"net/http"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/user/contactmethod"
 )
 
@@ -24,4 +25,7 @@ type Config struct {
 
 	// CMStore is used for storing and fetching metadata (like carrier information).
 	CMStore *contactmethod.Store
+
+	// AlertStore is used for tracking participants of alert conference bridges.
+	AlertStore *alert.Store
 }
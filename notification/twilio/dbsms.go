@@ -16,6 +16,7 @@ type dbSMS struct {
 	insert       *sql.Stmt
 	lookupByCode *sql.Stmt
 	lookupLatest *sql.Stmt
+	lookupRecent *sql.Stmt
 	existingCode *sql.Stmt
 
 	lookupByAlert   *sql.Stmt
@@ -83,6 +84,16 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 			ORDER BY sent_at DESC
 			LIMIT 1
 		`),
+
+		// open alerts recently sent to the number, acknowledged ones only if $2 is true
+		lookupRecent: p(`
+			SELECT cb.code, cb.alert_id
+			FROM twilio_sms_callbacks cb
+			JOIN alerts a ON a.id = cb.alert_id AND (a.status = 'triggered' OR ($2 AND a.status = 'active'))
+			WHERE cb.phone_number = $1 AND cb.sent_at > now() - '1 hour'::interval
+			ORDER BY cb.sent_at DESC
+			LIMIT 5
+		`),
 	}, prep.Err
 }
 
@@ -199,3 +210,31 @@ func (db *dbSMS) LookupSvcByCode(ctx context.Context, phoneNumber string, code i
 	err := info.scanFrom(row)
 	return info, err
 }
+
+// recentCode is a reply code for an alert recently sent to a number.
+type recentCode struct {
+	Code    int
+	AlertID int
+}
+
+// RecentCodes returns the reply codes of open alerts sent to the number within the last hour, newest first.
+// Acknowledged alerts are only included if includeAcked is true.
+func (db *dbSMS) RecentCodes(ctx context.Context, phoneNumber string, includeAcked bool) ([]recentCode, error) {
+	rows, err := db.lookupRecent.QueryContext(ctx, phoneNumber, includeAcked)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []recentCode
+	for rows.Next() {
+		var c recentCode
+		err = rows.Scan(&c.Code, &c.AlertID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
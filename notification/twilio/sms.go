@@ -25,13 +25,28 @@ import (
 )
 
 var (
-	lastReplyRx  = regexp.MustCompile(`^'?\s*(c|close|a|e|ack[a-z]*)\s*'?$`)
+	lastReplyRx  = regexp.MustCompile(`^'?\s*(c|close|resolve[a-z]*|a|e|ack[a-z]*)\s*'?$`)
 	shortReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(c|a|e)\s*'?$`)
-	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|e|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
+	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|resolve[a-z]*|e|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
 
 	svcReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(cc|aa)\s*'?$`)
 )
 
+// ambiguousReplyError is returned when a reply without a code could refer to more than one recent alert.
+type ambiguousReplyError []recentCode
+
+func (e ambiguousReplyError) Error() string { return "ambiguous reply" }
+
+// replyMessage returns the response listing the reply codes that can be used instead.
+func (e ambiguousReplyError) replyMessage(suffix string) string {
+	opts := make([]string, len(e))
+	for i, c := range e {
+		opts[i] = fmt.Sprintf("'%d%s' for alert #%d", c.Code, suffix, c.AlertID)
+	}
+
+	return "Multiple recent alerts, please reply with a code: " + strings.Join(opts, ", ")
+}
+
 // SMS implements a notification.Sender for Twilio SMS.
 type SMS struct {
 	b *dbSMS
@@ -116,25 +131,29 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification
 	var err error
 	switch t := msg.(type) {
 	case notification.AlertStatus:
-		message, err = renderAlertStatusMessage(cfg.ApplicationName(), t)
+		message, err = renderAlertStatusMessage(maxSMSLen(cfg), cfg.ApplicationName(), t)
 	case notification.AlertBundle:
 		var link string
 		if canContainURL(ctx, destNumber) {
 			link = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
 		}
 
-		message, err = renderAlertBundleMessage(cfg.ApplicationName(), t, link, makeSMSCode(0, t.ServiceID))
+		message, err = renderAlertBundleMessage(maxSMSLen(cfg), cfg.ApplicationName(), t, link, makeSMSCode(0, t.ServiceID))
 	case notification.Alert:
 		var link string
 		if canContainURL(ctx, destNumber) {
 			link = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
 		}
 
-		message, err = renderAlertMessage(cfg.ApplicationName(), t, link, makeSMSCode(t.AlertID, ""))
+		message, err = renderAlertMessage(maxSMSLen(cfg), cfg.ApplicationName(), t, link, makeSMSCode(t.AlertID, ""))
 	case notification.Test:
 		message = fmt.Sprintf("%s: Test message.", cfg.ApplicationName())
 	case notification.Verification:
 		message = fmt.Sprintf("%s: Verification code: %d", cfg.ApplicationName(), t.Code)
+	case notification.ScheduleHandoff:
+		message = fmt.Sprintf("%s: %s", cfg.ApplicationName(), t.Body())
+	case notification.ContactMethodDisabled:
+		message = fmt.Sprintf("%s: %s", cfg.ApplicationName(), t.Body())
 	default:
 		return nil, errors.Errorf("unhandled message type %T", t)
 	}
@@ -310,7 +329,22 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 		} else {
 			result = notification.ResultResolve
 		}
-		lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, 0) }
+		lookupFn = func() (*codeInfo, error) {
+			// acknowledged alerts are only candidates for actions that apply to them
+			recent, err := s.b.RecentCodes(ctx, from, result != notification.ResultAcknowledge)
+			if err != nil {
+				return &codeInfo{}, err
+			}
+			if len(recent) > 1 {
+				return &codeInfo{}, ambiguousReplyError(recent)
+			}
+			if len(recent) == 1 {
+				return s.b.LookupByCode(ctx, from, recent[0].Code)
+			}
+
+			// fall back to the latest alert, so the reply reports its current status
+			return s.b.LookupByCode(ctx, from, 0)
+		}
 	} else if m := shortReplyRx.FindStringSubmatch(body); len(m) == 3 {
 		if strings.HasPrefix(m[2], "a") {
 			result = notification.ResultAcknowledge
@@ -390,6 +424,18 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 		return nil
 	}, retryOpts...)
 
+	var ambErr ambiguousReplyError
+	if errors.As(err, &ambErr) {
+		suffix := "c"
+		if result == notification.ResultAcknowledge {
+			suffix = "a"
+		} else if result == notification.ResultEscalate {
+			suffix = "e"
+		}
+		respond(true, ambErr.replyMessage(suffix))
+		return
+	}
+
 	if errors.Is(err, sql.ErrNoRows) || (isSvc && info.ServiceName == "") || (!isSvc && info.AlertID == 0) {
 		respond(true, "Unknown reply code for this action. Visit the dashboard to manage alerts.")
 		return
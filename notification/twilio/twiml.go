@@ -18,10 +18,14 @@ type twiMLResponse struct {
 	voiceLanguage string
 
 	gatherURL        string
+	gatherNumber     bool
 	redirectURL      string
 	redirectPauseSec int
 	hangup           bool
 
+	conference          string
+	conferenceStatusURL string
+
 	hasOptions     bool
 	expectResponse bool
 
@@ -63,6 +67,8 @@ const (
 	optionCloseAll
 	optionStop
 	optionRepeat
+	optionJoinBridge
+	optionJoinAnyBridge
 )
 
 func (t *twiMLResponse) AddOptions(options ...menuOption) {
@@ -94,6 +100,11 @@ func (t *twiMLResponse) AddOptions(options ...menuOption) {
 		case optionCloseAll:
 			t.expectResponse = true
 			t.Sayf("To close all, press %s.", digitClose)
+		case optionJoinBridge:
+			t.expectResponse = true
+			t.Sayf("To join the conference bridge, press %s.", digitJoin)
+		case optionJoinAnyBridge:
+			t.Sayf("To join a conference bridge, press %s.", digitJoin)
 		default:
 			panic("Unknown option")
 		}
@@ -109,6 +120,21 @@ func (t *twiMLResponse) Gather(url string) {
 	t.sendResponse()
 }
 
+// GatherNumber is like Gather, but collects digits until the pound key is pressed.
+func (t *twiMLResponse) GatherNumber(url string) {
+	t.gatherURL = url
+	t.gatherNumber = true
+	t.sendResponse()
+}
+
+// JoinConference will join the call to the named conference, starting it if necessary.
+// Conference and participant events are sent to statusURL.
+func (t *twiMLResponse) JoinConference(name, statusURL string) {
+	t.conference = name
+	t.conferenceStatusURL = statusURL
+	t.sendResponse()
+}
+
 func (t *twiMLResponse) SayUnknownDigit() *twiMLResponse {
 	t.Say("Sorry, I didn't understand that.")
 	return t
@@ -159,11 +185,24 @@ type verbHangup struct {
 	XMLName xml.Name `xml:"Hangup"`
 }
 type verbGather struct {
-	XMLName    xml.Name `xml:"Gather"`
-	NumDigits  int      `xml:"numDigits,attr"`
-	TimeoutSec int      `xml:"timeout,attr"`
-	Action     string   `xml:"action,attr"`
-	Verbs      []any    `xml:",any"`
+	XMLName     xml.Name `xml:"Gather"`
+	NumDigits   int      `xml:"numDigits,attr,omitempty"`
+	FinishOnKey string   `xml:"finishOnKey,attr,omitempty"`
+	TimeoutSec  int      `xml:"timeout,attr"`
+	Action      string   `xml:"action,attr"`
+	Verbs       []any    `xml:",any"`
+}
+type verbDial struct {
+	XMLName    xml.Name `xml:"Dial"`
+	Conference verbConference
+}
+type verbConference struct {
+	XMLName                xml.Name `xml:"Conference"`
+	StartConferenceOnEnter bool     `xml:"startConferenceOnEnter,attr"`
+	EndConferenceOnExit    bool     `xml:"endConferenceOnExit,attr"`
+	StatusCallback         string   `xml:"statusCallback,attr"`
+	StatusCallbackEvent    string   `xml:"statusCallbackEvent,attr"`
+	Name                   string   `xml:",chardata"`
 }
 
 func (t *twiMLResponse) sendResponse() {
@@ -201,12 +240,28 @@ func (t *twiMLResponse) sendResponse() {
 	}
 
 	if t.gatherURL != "" {
-		doc.Verbs = []any{verbGather{
+		g := verbGather{
 			Action:     t.gatherURL,
 			TimeoutSec: 10,
 			NumDigits:  1,
 			Verbs:      doc.Verbs,
-		}}
+		}
+		if t.gatherNumber {
+			g.NumDigits = 0
+			g.FinishOnKey = "#"
+		}
+		doc.Verbs = []any{g}
+	}
+
+	if t.conference != "" {
+		doc.Verbs = append(doc.Verbs, verbDial{Conference: verbConference{
+			// the bridge stays open for others when a participant hangs up
+			StartConferenceOnEnter: true,
+			EndConferenceOnExit:    false,
+			StatusCallback:         t.conferenceStatusURL,
+			StatusCallbackEvent:    "start end join leave",
+			Name:                   t.conference,
+		}})
 	}
 
 	if t.hangup {
@@ -180,6 +180,30 @@ func TestTwiMLResponse(t *testing.T) {
 			<prosody rate="slow">To repeat this message, press star.</prosody>
 		</Say>
 	</Gather>
+</Response>`, string(data))
+	})
+
+	t.Run("conference", func(t *testing.T) {
+		var mockConfig config.Config
+		ctx := mockConfig.Context(context.Background())
+		rec := httptest.NewRecorder()
+
+		r := newTwiMLResponse(ctx, rec)
+		r.Say("Joining.")
+		r.JoinConference("alert-123", "http://example.com")
+
+		resp := rec.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+	<Say>
+		<prosody rate="slow">Joining.</prosody>
+	</Say>
+	<Dial>
+		<Conference startConferenceOnEnter="true" endConferenceOnExit="false" statusCallback="http://example.com" statusCallbackEvent="start end join leave">alert-123</Conference>
+	</Dial>
 </Response>`, string(data))
 	})
 }
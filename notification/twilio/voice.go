@@ -44,6 +44,8 @@ const (
 	CallTypeTest        = CallType("test")
 	CallTypeVerify      = CallType("verify")
 	CallTypeStop        = CallType("stop")
+	CallTypeBridge      = CallType("bridge")
+	CallTypeBridgeJoin  = CallType("bridge-join")
 
 	// Possible keys pressed from the Menu mapped to their actions.
 	digitAck      = "4"
@@ -55,6 +57,7 @@ const (
 	digitOldAck   = "8"
 	digitOldClose = "9"
 	digitEscalate = "5"
+	digitJoin     = "2"
 	sayRepeat     = "star"
 )
 
@@ -134,6 +137,10 @@ func (v *Voice) ServeCall(w http.ResponseWriter, req *http.Request) {
 		v.ServeStop(w, req)
 	case CallTypeVerify:
 		v.ServeVerify(w, req)
+	case CallTypeBridge:
+		v.ServeBridge(w, req)
+	case CallTypeBridgeJoin:
+		v.ServeBridgeJoin(w, req)
 	default:
 		_, call, _ := v.getCall(w, req)
 		if !call.Outbound {
@@ -523,9 +530,12 @@ func (v *Voice) ServeInbound(w http.ResponseWriter, req *http.Request) {
 	case "", digitRepeat:
 		resp.Sayf("Hello! This is %s. ", cfg.ApplicationName())
 		resp.Say("Please use the application dashboard to manage alerts.")
-		resp.AddOptions(optionStop)
+		resp.AddOptions(optionJoinAnyBridge, optionStop)
 		resp.Gather(v.callbackURL(ctx, call.Q, ""))
 		return
+	case digitJoin:
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeBridgeJoin))
+		return
 	case digitStop:
 		call.Q.Set("previous", "")
 		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeStop))
@@ -533,6 +543,125 @@ func (v *Voice) ServeInbound(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// ServeBridge serves a call inviting a responder to the conference bridge of an alert.
+func (v *Voice) ServeBridge(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, errResp := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	resp := newTwiMLResponse(ctx, w)
+	switch call.Digits {
+	default:
+		resp.SayUnknownDigit()
+		fallthrough
+	case "", digitRepeat:
+		resp.Say(call.msgBody)
+		resp.AddOptions(optionJoinBridge, optionStop)
+		resp.Gather(v.callbackURL(ctx, call.Q, CallTypeBridge))
+		return
+	case digitJoin:
+		v.joinBridge(ctx, w, call, errResp, call.msgSubjectID)
+		return
+	case digitStop:
+		call.Q.Set("previous", string(CallTypeBridge))
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeStop))
+		return
+	}
+}
+
+// ServeBridgeJoin serves an inbound call joining the conference bridge of an alert by its number.
+func (v *Voice) ServeBridgeJoin(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, errResp := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	alertID, err := strconv.Atoi(call.Digits)
+	if call.Digits == "" || call.Digits == digitRepeat || err != nil {
+		resp := newTwiMLResponse(ctx, w)
+		if call.Digits != "" && call.Digits != digitRepeat {
+			resp.SayUnknownDigit()
+		}
+		resp.Say("Please enter the alert number, followed by the pound key.")
+		resp.GatherNumber(v.callbackURL(ctx, call.Q, CallTypeBridgeJoin))
+		return
+	}
+
+	v.joinBridge(ctx, w, call, errResp, alertID)
+}
+
+// bridgeConferenceName returns the name of the Twilio conference used for an alert's bridge.
+func bridgeConferenceName(alertID int) string { return "alert-" + strconv.Itoa(alertID) }
+
+func (v *Voice) joinBridge(ctx context.Context, w http.ResponseWriter, call *call, errResp errRespFn, alertID int) {
+	var err error
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		err = doDeadline(ctx, func() error {
+			return v.c.AlertStore.JoinConferenceBridge(ctx, alertID, call.SID, call.Number)
+		})
+	})
+	if validation.IsClientError(err) {
+		msg, _ := voiceErrorMessage(ctx, fmt.Errorf("join conference bridge: %w", err))
+		newTwiMLResponse(ctx, w).Say(msg).Hangup()
+		return
+	}
+	if errResp(false, errors.Wrap(err, "join conference bridge"), "") {
+		return
+	}
+
+	cfg := config.FromContext(ctx)
+	p := make(url.Values)
+	p.Set("alertID", strconv.Itoa(alertID))
+	statusURL := cfg.CallbackURL("/api/v2/twilio/call/conference", p)
+
+	resp := newTwiMLResponse(ctx, w)
+	resp.Say("Joining the conference bridge.")
+	resp.JoinConference(bridgeConferenceName(alertID), statusURL)
+}
+
+// ServeConferenceStatus handles conference and participant events for alert conference bridges.
+func (v *Voice) ServeConferenceStatus(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	alertID, err := strconv.Atoi(req.URL.Query().Get("alertID"))
+	if err != nil {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	event := req.FormValue("StatusCallbackEvent")
+	ctx = log.WithFields(ctx, log.Fields{
+		"AlertID": alertID,
+		"Event":   event,
+		"Type":    "TwilioVoice",
+	})
+
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		switch event {
+		case "conference-start":
+			err = v.c.AlertStore.SetConferenceBridgeSID(ctx, alertID, validSID(req.FormValue("ConferenceSid")))
+		case "conference-end":
+			err = v.c.AlertStore.SetConferenceBridgeSID(ctx, alertID, "")
+		case "participant-leave":
+			err = v.c.AlertStore.LeaveConferenceBridge(ctx, alertID, validSID(req.FormValue("CallSid")))
+		}
+	})
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "update conference bridge"))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
 // ServeAlert serves a call for an alert notification.
 func (v *Voice) ServeAlert(w http.ResponseWriter, req *http.Request) {
 	if disabled(w, req) {
@@ -637,6 +766,15 @@ func buildMessage(prefix string, msg notification.Message) (message string, err
 			"%s with your %d-digit verification code. The code is: %s. Again, your %d-digit verification code is: %s.",
 			prefix, count, spellNumber(t.Code), count, spellNumber(t.Code),
 		)
+	case notification.ScheduleHandoff:
+		message = fmt.Sprintf("%s with an on-call handoff notice. %s", prefix, t.Body())
+	case notification.ContactMethodDisabled:
+		message = fmt.Sprintf("%s with a contact method notice. %s", prefix, t.Body())
+	case notification.AlertBridgeInvite:
+		if t.Summary == "" {
+			t.Summary = "No summary provided"
+		}
+		message = fmt.Sprintf("%s with a conference bridge invitation. Service '%s' started a bridge for alert %d. %s.", prefix, t.ServiceName, t.AlertID, t.Summary)
 	default:
 		return "", errors.Errorf("unhandled message type: %T", t)
 	}
@@ -75,6 +75,19 @@ func TestBuildMessage(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s with your 4-digit verification code. The code is: %s. Again, your 4-digit verification code is: %s.", prefix, spellNumber(1234), spellNumber(1234)), result)
 	assert.NoError(t, err)
 
+	// AlertBridgeInvite Notification
+	result, err = buildMessage(
+		prefix,
+		notification.AlertBridgeInvite{
+			CallbackID:  "2",
+			AlertID:     3,
+			Summary:     "Widget is Broken",
+			ServiceName: "Widget",
+		},
+	)
+	assert.Equal(t, fmt.Sprintf("%s with a conference bridge invitation. Service 'Widget' started a bridge for alert 3. Widget is Broken.", prefix), result)
+	assert.NoError(t, err)
+
 	// Bad Type
 	result, err = buildMessage(
 		prefix,
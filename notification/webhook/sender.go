@@ -9,11 +9,15 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notificationchannel"
 )
 
-type Sender struct{}
+type Sender struct {
+	nc *notificationchannel.Store
+}
 
 // POSTDataAlert represents fields in outgoing alert notification.
 type POSTDataAlert struct {
@@ -24,6 +28,8 @@ type POSTDataAlert struct {
 	Details     string
 	ServiceID   string
 	ServiceName string
+	Meta        map[string]string `json:",omitempty"`
+	RunbookURL  string            `json:",omitempty"`
 }
 
 // POSTDataAlertBundle represents fields in outgoing alert bundle notification.
@@ -76,14 +82,38 @@ type POSTDataOnCallNotification struct {
 	ScheduleURL  string
 }
 
+// POSTDataScheduleHandoff represents fields in outgoing schedule handoff notification.
+type POSTDataScheduleHandoff struct {
+	AppName      string
+	Type         string
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+	OnCall       bool
+	ShiftTime    time.Time
+}
+
+// POSTDataContactMethodDisabled represents fields in outgoing contact method disabled notification.
+type POSTDataContactMethodDisabled struct {
+	AppName           string
+	Type              string
+	ContactMethodID   string
+	ContactMethodName string
+	ContactMethodType string
+	Failures          int
+	ProfileURL        string
+}
+
 // POSTDataTest represents fields in outgoing test notification.
 type POSTDataTest struct {
 	AppName string
 	Type    string
 }
 
-func NewSender(ctx context.Context) *Sender {
-	return &Sender{}
+// NewSender creates a new webhook Sender. If nc is provided, requests to webhook
+// notification channels with a secret configured will be signed.
+func NewSender(ctx context.Context, nc *notificationchannel.Store) *Sender {
+	return &Sender{nc: nc}
 }
 
 // Send will send an alert for the provided message type
@@ -111,6 +141,8 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			Summary:     m.Summary,
 			ServiceID:   m.ServiceID,
 			ServiceName: m.ServiceName,
+			Meta:        m.Meta,
+			RunbookURL:  m.RunbookURL,
 		}
 	case notification.AlertBundle:
 		payload = POSTDataAlertBundle{
@@ -142,6 +174,26 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			ScheduleName: m.ScheduleName,
 			ScheduleURL:  m.ScheduleURL,
 		}
+	case notification.ScheduleHandoff:
+		payload = POSTDataScheduleHandoff{
+			AppName:      cfg.ApplicationName(),
+			Type:         "ScheduleHandoff",
+			ScheduleID:   m.ScheduleID,
+			ScheduleName: m.ScheduleName,
+			ScheduleURL:  m.ScheduleURL,
+			OnCall:       m.OnCall,
+			ShiftTime:    m.ShiftTime,
+		}
+	case notification.ContactMethodDisabled:
+		payload = POSTDataContactMethodDisabled{
+			AppName:           cfg.ApplicationName(),
+			Type:              "ContactMethodDisabled",
+			ContactMethodID:   m.ContactMethodID,
+			ContactMethodName: m.ContactMethodName,
+			ContactMethodType: m.ContactMethodType,
+			Failures:          m.Failures,
+			ProfileURL:        m.ProfileURL,
+		}
 	default:
 		return nil, fmt.Errorf("message type '%s' not supported", m.Type().String())
 	}
@@ -169,10 +221,74 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 
 	req.Header.Add("Content-Type", "application/json")
 
-	_, err = http.DefaultClient.Do(req)
+	secrets, err := s.secrets(ctx, msg.Destination())
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) > 0 {
+		err = sign(req, msg.ID(), secrets, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusTooManyRequests:
+		// the receiver may recover, so allow the message to be retried
+		return &notification.SentMessage{
+			State:        notification.StateFailedTemp,
+			StateDetails: resp.Status,
+		}, nil
+	case resp.StatusCode >= 400:
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: resp.Status,
+		}, nil
+	}
 
 	return &notification.SentMessage{State: notification.StateSent}, nil
 }
+
+// secrets returns the signing secrets for the destination, if any.
+func (s *Sender) secrets(ctx context.Context, dest notification.Dest) ([][]byte, error) {
+	if s.nc == nil || dest.Type != notification.DestTypeChanWebhook {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(dest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse channel ID: %w", err)
+	}
+
+	return s.nc.WebhookSecrets(ctx, id)
+}
+
+// sign will set the signature headers on the request. The legacy X-GoAlert headers only
+// use the current secret, while the Standard Webhooks headers include a signature for
+// each secret.
+func sign(req *http.Request, msgID string, secrets [][]byte, body []byte) error {
+	if msgID == "" {
+		msgID = uuid.NewString()
+	}
+
+	ts := time.Now()
+	sig, err := StandardSignature(secrets, msgID, ts, body)
+	if err != nil {
+		return err
+	}
+
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	req.Header.Set(TimestampHeader, unix)
+	req.Header.Set(SignatureHeader, Signature(secrets[0], ts, body))
+	req.Header.Set(IDHeader, msgID)
+	req.Header.Set(StandardTimestampHeader, unix)
+	req.Header.Set(StandardSignatureHeader, sig)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampHeader contains the unix time, in seconds, that the request was signed.
+	TimestampHeader = "X-GoAlert-Timestamp"
+
+	// SignatureHeader contains the HMAC-SHA256 signature of the request, prefixed with `sha256=`.
+	SignatureHeader = "X-GoAlert-Signature"
+)
+
+// Signature returns the value of the SignatureHeader for a request body sent at
+// the given time.
+//
+// The signature is the hex-encoded HMAC-SHA256, keyed by the secret, of the
+// TimestampHeader value, a single `.`, and the raw request body.
+func Signature(secret []byte, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Headers used for signatures compatible with the Standard Webhooks specification
+// (https://www.standardwebhooks.com).
+const (
+	// IDHeader contains a unique identifier for the message, which is the same
+	// if a message is retried.
+	IDHeader = "webhook-id"
+
+	// StandardTimestampHeader contains the unix time, in seconds, that the request was signed.
+	StandardTimestampHeader = "webhook-timestamp"
+
+	// StandardSignatureHeader contains a space-delimited list of signatures, one for each
+	// active secret, each prefixed with `v1,`.
+	StandardSignatureHeader = "webhook-signature"
+)
+
+// SecretPrefix is the prefix of secrets in the Standard Webhooks format. The remainder
+// of such a secret is the base64-encoded signing key.
+const SecretPrefix = "whsec_"
+
+// SigningKey returns the HMAC key for a secret. For secrets with the SecretPrefix,
+// this is the base64-decoded remainder; otherwise the secret is used as-is.
+func SigningKey(secret []byte) ([]byte, error) {
+	if !bytes.HasPrefix(secret, []byte(SecretPrefix)) {
+		return secret, nil
+	}
+
+	return base64.StdEncoding.DecodeString(string(secret[len(SecretPrefix):]))
+}
+
+// StandardSignature returns the value of the StandardSignatureHeader for a message
+// sent at the given time, with one signature for each secret.
+//
+// Each signature is `v1,` followed by the base64-encoded HMAC-SHA256, keyed by the
+// secret's SigningKey, of the message ID, the timestamp, and the raw request body,
+// separated by `.`.
+func StandardSignature(secrets [][]byte, id string, ts time.Time, body []byte) (string, error) {
+	sigs := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		key, err := SigningKey(secret)
+		if err != nil {
+			return "", fmt.Errorf("decode secret: %w", err)
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(id))
+		mac.Write([]byte("."))
+		mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+		mac.Write([]byte("."))
+		mac.Write(body)
+		sigs = append(sigs, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	return strings.Join(sigs, " "), nil
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	ts := time.Unix(1696500000, 0)
+	body := []byte(`{"AppName":"GoAlert","Type":"Test"}`)
+
+	sig := Signature([]byte("secret"), ts, body)
+	const expected = "sha256=43756597dbfa57e6b4ec63b0998023cdee25a1d9e603d64f3f1d736e58c1e792"
+	if sig != expected {
+		t.Errorf("got %q; want %q", sig, expected)
+	}
+
+	if Signature([]byte("other"), ts, body) == sig {
+		t.Error("expected signature to depend on the secret")
+	}
+	if Signature([]byte("secret"), ts.Add(time.Second), body) == sig {
+		t.Error("expected signature to depend on the timestamp")
+	}
+}
+
+func TestStandardSignature(t *testing.T) {
+	// example from the Standard Webhooks specification
+	secret := []byte("whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	ts := time.Unix(1614265330, 0)
+	body := []byte(`{"test": 2432232314}`)
+	const expected = "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="
+
+	sig, err := StandardSignature([][]byte{secret}, "msg_p5jXN8AQM9LWM0D4loKWxJek", ts, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig != expected {
+		t.Errorf("got %q; want %q", sig, expected)
+	}
+
+	sig, err = StandardSignature([][]byte{[]byte("new-secret-value"), secret}, "msg_p5jXN8AQM9LWM0D4loKWxJek", ts, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(sig, " ")
+	if len(parts) != 2 || parts[1] != expected {
+		t.Errorf("got %q; want second signature %q", sig, expected)
+	}
+
+	_, err = StandardSignature([][]byte{[]byte("whsec_not-base64!")}, "msg_1", ts, body)
+	if err == nil {
+		t.Error("expected error for invalid secret")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -22,7 +23,7 @@ func (c Channel) Normalize() (*Channel, error) {
 	err := validate.Many(
 		validate.UUID("ID", c.ID),
 		validate.Text("Name", c.Name, 1, 255),
-		validate.OneOf("Type", c.Type, TypeSlackChan, TypeWebhook, TypeSlackUG),
+		validate.OneOf("Type", c.Type, TypeSlackChan, TypeWebhook, TypeSlackUG, TypeMSTeams, TypePagerDuty),
 	)
 
 	switch c.Type {
@@ -36,6 +37,14 @@ func (c Channel) Normalize() (*Channel, error) {
 		err = validate.Many(err, validate.RequiredText("Value", c.Value, 1, 32))
 	case TypeWebhook:
 		err = validate.Many(err, validate.URL("Value", c.Value))
+	case TypeMSTeams:
+		err = validate.Many(err, validate.AbsoluteURL("Value", c.Value))
+		if !strings.HasPrefix(c.Value, "https://") {
+			err = validate.Many(err, validation.NewFieldError("Value", "must be an https URL"))
+		}
+	case TypePagerDuty:
+		// the routing key itself is stored encrypted, Value is only used to identify the channel
+		err = validate.Many(err, validate.RequiredText("Value", c.Value, 1, 64))
 	}
 
 	return &c, err
@@ -2,20 +2,28 @@ package notificationchannel
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
 type Store struct {
-	db *sql.DB
+	db   *sql.DB
+	keys keyring.Keys
 
 	findAll    *sql.Stmt
 	findOne    *sql.Stmt
@@ -26,13 +34,22 @@ type Store struct {
 	updateName  *sql.Stmt
 	findByValue *sql.Stmt
 	lock        *sql.Stmt
+
+	setWebhookSecret   *sql.Stmt
+	findWebhookSecret  *sql.Stmt
+	webhookSecretOwner *sql.Stmt
+
+	setPagerDutyKey  *sql.Stmt
+	findPagerDutyKey *sql.Stmt
 }
 
-func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+// NewStore creates a new Store, using keys to encrypt webhook secrets and PagerDuty routing keys.
+func NewStore(ctx context.Context, db *sql.DB, keys keyring.Keys) (*Store, error) {
 	p := &util.Prepare{DB: db, Ctx: ctx}
 
 	return &Store{
-		db: db,
+		db:   db,
+		keys: keys,
 
 		findAll: p.P(`
 			select id, name, type, value from notification_channels
@@ -55,6 +72,65 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		// Lock the table so only one tx can insert/update at a time, but allows the above SELECT FOR UPDATE to run
 		// so only required changes block.
 		lock: p.P(`LOCK notification_channels IN SHARE ROW EXCLUSIVE MODE`),
+
+		// The current secret is kept as the previous one for $3 minutes, if set.
+		setWebhookSecret: p.P(`
+			update notification_channels
+			set
+				webhook_secret = $2,
+				webhook_secret_prev = case when $3 > 0 then webhook_secret end,
+				webhook_secret_prev_expires_at = case when $3 > 0 and webhook_secret notnull then now() + $3 * '1 minute'::interval end
+			where type = 'WEBHOOK' and value = $1
+		`),
+		// A user owns a webhook channel if they are a target of an escalation policy notifying
+		// it, or of a schedule sending on-call notifications to it, directly or via a rotation.
+		webhookSecretOwner: p.P(`
+			with user_rotations as (
+				select rotation_id from rotation_participants where user_id = $2
+			), user_schedules as (
+				select schedule_id
+				from schedule_rules
+				where tgt_user_id = $2 or tgt_rotation_id in (select rotation_id from user_rotations)
+			)
+			select exists (
+				select null
+				from notification_channels nc
+				where
+					nc.type = 'WEBHOOK' and
+					nc.value = $1 and (
+						exists (
+							select null
+							from escalation_policy_actions chan
+							join escalation_policy_steps chan_step on chan_step.id = chan.escalation_policy_step_id
+							join escalation_policy_steps step on step.escalation_policy_id = chan_step.escalation_policy_id
+							join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+							where
+								chan.channel_id = nc.id and (
+									act.user_id = $2 or
+									act.rotation_id in (select rotation_id from user_rotations) or
+									act.schedule_id in (select schedule_id from user_schedules)
+								)
+						) or
+						exists (
+							select null
+							from schedule_data data
+							where
+								data.schedule_id in (select schedule_id from user_schedules) and
+								data.data @> jsonb_build_object('V1', jsonb_build_object('OnCallNotificationRules', jsonb_build_array(jsonb_build_object('ChannelID', nc.id::text))))
+						)
+					)
+			)
+		`),
+		findWebhookSecret: p.P(`
+			select
+				webhook_secret,
+				case when webhook_secret_prev_expires_at > now() then webhook_secret_prev end
+			from notification_channels
+			where id = $1
+		`),
+
+		setPagerDutyKey:  p.P(`update notification_channels set pagerduty_routing_key = $2 where id = $1 and type = 'PAGERDUTY' and pagerduty_routing_key isnull`),
+		findPagerDutyKey: p.P(`select pagerduty_routing_key from notification_channels where id = $1`),
 	}, p.Err
 }
 
@@ -233,3 +309,200 @@ func (s *Store) FindAll(ctx context.Context) ([]Channel, error) {
 
 	return channels, nil
 }
+
+// MaxWebhookSecretOverlap is the longest a previous webhook secret can remain valid after
+// being replaced.
+const MaxWebhookSecretOverlap = 7 * 24 * time.Hour
+
+// SetWebhookSecret sets the secret used to sign requests to the webhook channel with the
+// given URL. The secret is stored encrypted, and an empty secret disables signing.
+//
+// Only admins, or users that are a target of an escalation policy or schedule that notifies
+// the webhook, may set its secret.
+//
+// If overlap is non-zero, requests will also be signed with the replaced secret for that
+// long, so that receivers can be updated without rejecting requests.
+func (s *Store) SetWebhookSecret(ctx context.Context, tx *sql.Tx, webhookURL, secret string, overlap time.Duration) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.URL("URL", webhookURL),
+		validate.Duration("Overlap", overlap, 0, MaxWebhookSecretOverlap),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !permission.Admin(ctx) {
+		var owner bool
+		err = stmt(ctx, tx, s.webhookSecretOwner).QueryRowContext(ctx, webhookURL, permission.UserID(ctx)).Scan(&owner)
+		if err != nil {
+			return err
+		}
+		if !owner {
+			return permission.NewAccessDenied("must be an admin, or a target of an escalation policy or schedule using the webhook")
+		}
+	}
+
+	var data []byte
+	if secret != "" {
+		err = validateWebhookSecret(secret)
+		if err != nil {
+			return err
+		}
+		data, err = s.keys.Encrypt("WEBHOOK_SECRET", []byte(secret))
+		if err != nil {
+			return fmt.Errorf("encrypt webhook secret: %w", err)
+		}
+	}
+
+	res, err := stmt(ctx, tx, s.setWebhookSecret).ExecContext(ctx, webhookURL, data, int(overlap/time.Minute))
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("URL", "no webhook channel exists for this URL")
+	}
+
+	return nil
+}
+
+// validateWebhookSecret checks the length of a secret, and that the key of a
+// Standard Webhooks (`whsec_` prefixed) secret is valid base64.
+func validateWebhookSecret(secret string) error {
+	err := validate.Text("Secret", secret, 16, 255)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(secret, "whsec_") {
+		return nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return validation.NewFieldError("Secret", "must be base64 encoded after the 'whsec_' prefix")
+	}
+	if len(key) < 24 || len(key) > 64 {
+		return validation.NewFieldError("Secret", "key must be between 24 and 64 bytes")
+	}
+
+	return nil
+}
+
+// WebhookSecrets returns the decrypted signing secrets for a webhook channel, or nil if
+// one is not set. The current secret is first, followed by the previous secret if it
+// has not yet expired.
+func (s *Store) WebhookSecrets(ctx context.Context, id uuid.UUID) ([][]byte, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	var cur, prev []byte
+	err = s.findWebhookSecret.QueryRowContext(ctx, id).Scan(&cur, &prev)
+	if err != nil {
+		return nil, err
+	}
+	if len(cur) == 0 {
+		return nil, nil
+	}
+
+	var secrets [][]byte
+	for _, data := range [][]byte{cur, prev} {
+		if len(data) == 0 {
+			continue
+		}
+
+		data, _, err = s.keys.Decrypt(data)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt webhook secret: %w", err)
+		}
+		secrets = append(secrets, data)
+	}
+
+	return secrets, nil
+}
+
+// PagerDutyChannel returns the channel for a PagerDuty Events API v2 routing key. The Value of the
+// channel is derived from the key, so that the key itself is only ever stored encrypted.
+func PagerDutyChannel(routingKey string) (*Channel, error) {
+	err := validate.Text("RoutingKey", routingKey, 32, 32)
+	if err == nil && !isAlphanumeric(routingKey) {
+		err = validation.NewFieldError("RoutingKey", "must only contain letters and digits")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(routingKey))
+	return &Channel{
+		Type:  TypePagerDuty,
+		Name:  "PagerDuty (..." + routingKey[len(routingKey)-4:] + ")",
+		Value: hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// MapPagerDutyToID returns the ID of the channel for a PagerDuty routing key, creating it
+// (and storing the key encrypted) if it does not already exist.
+func (s *Store) MapPagerDutyToID(ctx context.Context, tx *sql.Tx, routingKey string) (uuid.UUID, error) {
+	c, err := PagerDutyChannel(routingKey)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	id, err := s.MapToID(ctx, tx, c)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	data, err := s.keys.Encrypt("PAGERDUTY_ROUTING_KEY", []byte(routingKey))
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("encrypt routing key: %w", err)
+	}
+
+	_, err = stmt(ctx, tx, s.setPagerDutyKey).ExecContext(ctx, id, data)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("set routing key: %w", err)
+	}
+
+	return id, nil
+}
+
+// PagerDutyRoutingKey returns the decrypted routing key for a PagerDuty channel.
+func (s *Store) PagerDutyRoutingKey(ctx context.Context, id uuid.UUID) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return "", err
+	}
+
+	var data []byte
+	err = s.findPagerDutyKey.QueryRowContext(ctx, id).Scan(&data)
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", errors.New("routing key not set")
+	}
+
+	data, _, err = s.keys.Decrypt(data)
+	if err != nil {
+		return "", fmt.Errorf("decrypt routing key: %w", err)
+	}
+
+	return string(data), nil
+}
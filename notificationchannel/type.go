@@ -12,6 +12,8 @@ const (
 	TypeSlackChan Type = "SLACK"
 	TypeWebhook   Type = "WEBHOOK"
 	TypeSlackUG   Type = "SLACK_USER_GROUP"
+	TypeMSTeams   Type = "MS_TEAMS"
+	TypePagerDuty Type = "PAGERDUTY"
 )
 
 // Valid returns true if t is a known Type.
@@ -0,0 +1,45 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+)
+
+// A CoverageGap is a window of time where nobody is on call.
+type CoverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CoverageGaps will return the windows between start and end that are not covered by
+// any of the provided shifts, in chronological order.
+func CoverageGaps(shifts []Shift, start, end time.Time) []CoverageGap {
+	sorted := make([]Shift, len(shifts))
+	copy(sorted, shifts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var gaps []CoverageGap
+	covered := start
+	for _, s := range sorted {
+		if !s.End.After(covered) {
+			continue
+		}
+		if s.Start.After(covered) {
+			gapEnd := s.Start
+			if gapEnd.After(end) {
+				gapEnd = end
+			}
+			gaps = append(gaps, CoverageGap{Start: covered, End: gapEnd})
+		}
+		covered = s.End
+		if !covered.Before(end) {
+			return gaps
+		}
+	}
+
+	if covered.Before(end) {
+		gaps = append(gaps, CoverageGap{Start: covered, End: end})
+	}
+
+	return gaps
+}
@@ -0,0 +1,32 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageGaps(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	end := at(24)
+
+	gaps := CoverageGaps(nil, start, end)
+	assert.Equal(t, []CoverageGap{{Start: start, End: end}}, gaps, "no shifts")
+
+	gaps = CoverageGaps([]Shift{
+		// overlapping shifts are merged, and shifts outside the range are clamped
+		{UserID: "a", Start: at(-2), End: at(4)},
+		{UserID: "b", Start: at(10), End: at(14)},
+		{UserID: "c", Start: at(2), End: at(6)},
+		{UserID: "d", Start: at(12), End: at(30)},
+	}, start, end)
+	assert.Equal(t, []CoverageGap{{Start: at(6), End: at(10)}}, gaps)
+
+	gaps = CoverageGaps([]Shift{
+		{UserID: "a", Start: at(0), End: at(8)},
+		{UserID: "b", Start: at(8), End: at(20)},
+	}, start, end)
+	assert.Equal(t, []CoverageGap{{Start: at(20), End: end}}, gaps, "back-to-back shifts")
+}
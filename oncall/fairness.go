@@ -0,0 +1,119 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+)
+
+// A FairnessStat is the on-call time and number of pages of a rotation participant
+// during a single time bucket.
+type FairnessStat struct {
+	UserID string
+	Start  time.Time
+	End    time.Time
+
+	OnCall time.Duration
+	Pages  int
+}
+
+// A Page is a notification sent to a user for an alert. Bundled notifications count
+// as a single page.
+type Page struct {
+	UserID string
+	SentAt time.Time
+}
+
+// FairnessStats will return a stat for each user and bucket between start and end,
+// ordered by user (in the order provided) and then by time. If bucket is zero, a single
+// bucket covers the entire range.
+//
+// Overlapping shifts of the same user (e.g., from different schedules) are only counted once.
+func FairnessStats(userIDs []string, start, end time.Time, bucket time.Duration, shifts []Shift, pages []Page) []FairnessStat {
+	if bucket <= 0 {
+		bucket = end.Sub(start)
+	}
+
+	var bucketStarts []time.Time
+	for t := start; t.Before(end); t = t.Add(bucket) {
+		bucketStarts = append(bucketStarts, t)
+	}
+	bucketEnd := func(i int) time.Time {
+		e := bucketStarts[i].Add(bucket)
+		if e.After(end) {
+			return end
+		}
+		return e
+	}
+
+	byUser := make(map[string][]Shift)
+	for _, s := range shifts {
+		byUser[s.UserID] = append(byUser[s.UserID], s)
+	}
+
+	pageCount := make(map[string][]int)
+	for _, p := range pages {
+		if p.SentAt.Before(start) || !p.SentAt.Before(end) {
+			continue
+		}
+		if pageCount[p.UserID] == nil {
+			pageCount[p.UserID] = make([]int, len(bucketStarts))
+		}
+		pageCount[p.UserID][int(p.SentAt.Sub(start)/bucket)]++
+	}
+
+	var result []FairnessStat
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		onCall := make([]time.Duration, len(bucketStarts))
+		for _, s := range mergeShifts(byUser[userID]) {
+			for i, bStart := range bucketStarts {
+				sStart, sEnd := s.Start, s.End
+				if sStart.Before(bStart) {
+					sStart = bStart
+				}
+				if bEnd := bucketEnd(i); sEnd.After(bEnd) {
+					sEnd = bEnd
+				}
+				if sEnd.After(sStart) {
+					onCall[i] += sEnd.Sub(sStart)
+				}
+			}
+		}
+
+		for i, bStart := range bucketStarts {
+			stat := FairnessStat{UserID: userID, Start: bStart, End: bucketEnd(i), OnCall: onCall[i]}
+			if pageCount[userID] != nil {
+				stat.Pages = pageCount[userID][i]
+			}
+			result = append(result, stat)
+		}
+	}
+
+	return result
+}
+
+// mergeShifts will return the shifts with overlapping ones combined, in chronological order.
+func mergeShifts(shifts []Shift) []Shift {
+	sorted := make([]Shift, len(shifts))
+	copy(sorted, shifts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var merged []Shift
+	for _, s := range sorted {
+		if len(merged) > 0 && !s.Start.After(merged[len(merged)-1].End) {
+			last := &merged[len(merged)-1]
+			if s.End.After(last.End) {
+				last.End = s.End
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	return merged
+}
@@ -0,0 +1,46 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFairnessStats(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	end := at(48)
+
+	shifts := []Shift{
+		// overlapping shifts from different schedules are only counted once
+		{UserID: "a", Start: at(-4), End: at(10)},
+		{UserID: "a", Start: at(6), End: at(12)},
+		{UserID: "b", Start: at(20), End: at(30)},
+		{UserID: "c", Start: at(0), End: at(48)},
+	}
+	pages := []Page{
+		{UserID: "a", SentAt: at(1)},
+		{UserID: "a", SentAt: at(2)},
+		{UserID: "b", SentAt: at(25)},
+		{UserID: "b", SentAt: at(48)}, // outside the range
+	}
+
+	stats := FairnessStats([]string{"a", "b", "a"}, start, end, 0, shifts, pages)
+	assert.Equal(t, []FairnessStat{
+		{UserID: "a", Start: start, End: end, OnCall: 12 * time.Hour, Pages: 2},
+		{UserID: "b", Start: start, End: end, OnCall: 10 * time.Hour, Pages: 1},
+	}, stats, "single bucket")
+
+	stats = FairnessStats([]string{"b"}, start, end, 24*time.Hour, shifts, pages)
+	assert.Equal(t, []FairnessStat{
+		{UserID: "b", Start: start, End: at(24), OnCall: 4 * time.Hour},
+		{UserID: "b", Start: at(24), End: end, OnCall: 6 * time.Hour, Pages: 1},
+	}, stats, "daily buckets")
+
+	stats = FairnessStats([]string{"c"}, start, at(30), 24*time.Hour, shifts, nil)
+	assert.Equal(t, []FairnessStat{
+		{UserID: "c", Start: start, End: at(24), OnCall: 24 * time.Hour},
+		{UserID: "c", Start: at(24), End: at(30), OnCall: 6 * time.Hour},
+	}, stats, "last bucket is truncated")
+}
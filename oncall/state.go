@@ -21,15 +21,41 @@ type ResolvedRotation struct {
 	CurrentStart time.Time
 	CurrentEnd   time.Time
 	Users        []string
+
+	// Weights holds the weight of each user, in the same order as Users. A user remains on call
+	// for as many consecutive shifts as their weight. If nil, all users have a weight of 1.
+	Weights []int
 }
 
 type state struct {
-	tempScheds []schedule.TemporarySchedule
-	rules      []ResolvedRule
-	overrides  []override.UserOverride
-	history    []Shift
-	now        time.Time
-	loc        *time.Location
+	tempScheds  []schedule.TemporarySchedule
+	fixedShifts []schedule.FixedShift
+	rules       []ResolvedRule
+	overrides   []override.UserOverride
+	history     []Shift
+	now         time.Time
+	loc         *time.Location
+}
+
+// weight returns the weight of the user at idx, which may be out of range.
+func (r *ResolvedRotation) weight(idx int) int {
+	if len(r.Weights) != len(r.Users) {
+		return 1
+	}
+
+	idx %= len(r.Users)
+	if idx < 0 {
+		idx += len(r.Users)
+	}
+	return r.Weights[idx]
+}
+
+// turnStartTime returns the start of the turn of the user at idx that ended at t.
+func (r *ResolvedRotation) turnStartTime(t time.Time, idx int) time.Time {
+	for i := r.weight(idx); i > 0; i-- {
+		t = r.StartTime(t.Add(-1))
+	}
+	return t
 }
 
 func (r *ResolvedRotation) UserID(t time.Time) string {
@@ -46,7 +72,7 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 
 	if r.CurrentEnd.IsZero() {
 		r.CurrentStart = r.StartTime(r.CurrentStart)
-		r.CurrentEnd = r.EndTime(r.CurrentStart)
+		r.CurrentEnd = r.TurnEndTime(r.CurrentStart, r.weight(r.CurrentIndex))
 	}
 
 	if t.Before(r.CurrentEnd) && !t.Before(r.CurrentStart) {
@@ -55,13 +81,13 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 
 	for !t.Before(r.CurrentEnd) {
 		r.CurrentStart = r.CurrentEnd
-		r.CurrentEnd = r.EndTime(r.CurrentStart)
 		r.CurrentIndex++
+		r.CurrentEnd = r.TurnEndTime(r.CurrentStart, r.weight(r.CurrentIndex))
 	}
 	for t.Before(r.CurrentStart) {
 		r.CurrentEnd = r.CurrentStart
-		r.CurrentStart = r.StartTime(r.CurrentStart.Add(-1))
 		r.CurrentIndex--
+		r.CurrentStart = r.turnStartTime(r.CurrentStart, r.CurrentIndex)
 	}
 	r.CurrentIndex %= len(r.Users)
 	if r.CurrentIndex < 0 {
@@ -115,6 +141,14 @@ func (s *state) CalculateShifts(start, end time.Time) []Shift {
 	}
 	hist.Init()
 	tempScheds := t.NewTemporaryScheduleCalculator(s.tempScheds)
+
+	fixed := t.NewUserCalculator()
+	sort.Slice(s.fixedShifts, func(i, j int) bool { return s.fixedShifts[i].Start.Before(s.fixedShifts[j].Start) })
+	for _, s := range s.fixedShifts {
+		fixed.SetSpan(s.Start, s.End, s.UserID)
+	}
+	fixed.Init()
+
 	// sort overrides so that overlapping spans are merged properly
 
 	overrides := t.NewOverrideCalculator(s.overrides)
@@ -169,8 +203,12 @@ func (s *state) CalculateShifts(start, end time.Time) []Shift {
 			continue
 		}
 
-		// apply any overrides
-		setOnCall(overrides.MapUsers(rules.ActiveUsers()))
+		// fixed shifts are on call alongside rules, then apply any overrides
+		users := rules.ActiveUsers()
+		if len(fixed.ActiveUsers()) > 0 {
+			users = append(users[:len(users):len(users)], fixed.ActiveUsers()...)
+		}
+		setOnCall(overrides.MapUsers(users))
 	}
 
 	// remaining shifts are truncated
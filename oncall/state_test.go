@@ -174,6 +174,90 @@ func TestResolvedRotation_UserID(t *testing.T) {
 	}
 }
 
+func TestResolvedRotation_UserID_FixedHours(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rot := &ResolvedRotation{
+		Rotation: rotation.Rotation{
+			ID:          "rot",
+			Type:        rotation.TypeFixedHours,
+			Start:       time.Date(2020, 10, 30, 6, 0, 0, 0, loc),
+			ShiftLength: 8,
+		},
+		CurrentIndex: 0,
+		CurrentStart: time.Date(2020, 10, 31, 22, 0, 0, 0, loc),
+		Users:        []string{"a", "b", "c"},
+	}
+
+	check := func(at time.Time, exp string) {
+		t.Helper()
+		id := rot.UserID(at)
+		if id != exp {
+			t.Errorf("at %s: got '%s'; want '%s'", at, id, exp)
+		}
+	}
+
+	// 10PM CDT + 8 hours is 5AM CST after falling back
+	check(time.Date(2020, 11, 1, 4, 59, 0, 0, loc), "a")
+	check(time.Date(2020, 11, 1, 5, 0, 0, 0, loc), "b")
+	check(time.Date(2020, 11, 1, 13, 0, 0, 0, loc), "c")
+	check(time.Date(2020, 11, 1, 21, 0, 0, 0, loc), "a")
+	check(time.Date(2020, 10, 31, 21, 59, 0, 0, loc), "c")
+}
+
+func TestResolvedRotation_UserID_Weighted(t *testing.T) {
+	newRot := func() *ResolvedRotation {
+		return &ResolvedRotation{
+			Rotation: rotation.Rotation{
+				ID:          "rot",
+				Type:        rotation.TypeDaily,
+				Start:       time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+				ShiftLength: 1,
+			},
+			CurrentIndex: 0,
+			CurrentStart: time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+			Users:        []string{"a", "b", "c"},
+			Weights:      []int{2, 1, 1},
+		}
+	}
+
+	check := func(rot *ResolvedRotation, at time.Time, exp string) {
+		t.Helper()
+		id := rot.UserID(at)
+		if id != exp {
+			t.Errorf("at %s: got '%s'; want '%s'", at, id, exp)
+		}
+	}
+
+	rot := newRot()
+	day := func(n int) time.Time { return time.Date(2020, 1, 1+n, 10, 0, 0, 0, time.UTC) }
+	for i, exp := range []string{"a", "a", "b", "c", "a", "a", "b", "c"} {
+		check(rot, day(i), exp)
+	}
+
+	// going backwards must walk the same turns in reverse
+	rot = newRot()
+	check(rot, day(9), "a")
+	check(rot, day(-1), "c")
+	check(rot, day(-2), "b")
+	check(rot, day(-3), "a")
+	check(rot, day(-4), "a")
+	check(rot, day(-5), "c")
+
+	// over a long period, each user is on call in proportion to their weight
+	rot = newRot()
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		counts[rot.UserID(day(i))]++
+	}
+	if counts["a"] != 200 || counts["b"] != 100 || counts["c"] != 100 {
+		t.Errorf("got distribution %v; want a=200 b=100 c=100", counts)
+	}
+}
+
 func TestState_CalculateShifts(t *testing.T) {
 	check := func(name string, start, end time.Time, s *state, exp []Shift) {
 		t.Helper()
@@ -351,6 +435,41 @@ func TestState_CalculateShifts(t *testing.T) {
 		},
 	)
 
+	check("Fixed Shifts",
+		time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC), // 8:00AM
+		time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), // 9:00AM
+		&state{
+			loc: time.UTC,
+			rules: []ResolvedRule{
+				{Rule: rule.Rule{
+					WeekdayFilter: timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+					Start:         timeutil.NewClock(8, 0),
+					End:           timeutil.NewClock(10, 0),
+					Target:        assignment.UserTarget("foobar"),
+				}},
+			},
+			fixedShifts: []schedule.FixedShift{{
+				Start:  time.Date(2018, 1, 1, 8, 25, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 1, 8, 35, 0, 0, time.UTC),
+				UserID: "baz",
+			}},
+		},
+		[]Shift{
+			{
+				Start:     time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC),
+				End:       time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC),
+				Truncated: true,
+				UserID:    "foobar",
+			},
+			{
+				Start:     time.Date(2018, 1, 1, 8, 25, 0, 0, time.UTC),
+				End:       time.Date(2018, 1, 1, 8, 35, 0, 0, time.UTC),
+				Truncated: false,
+				UserID:    "baz",
+			},
+		},
+	)
+
 	check("SimpleWeek",
 		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
 		time.Date(2018, 1, 8, 0, 0, 0, 0, time.UTC),
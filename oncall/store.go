@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,6 +23,10 @@ import (
 type ScheduleOnCallUser struct {
 	ID   string
 	Name string
+
+	// Priority is the highest priority of the rules (or overrides) that put the user on call,
+	// where 0 is the highest.
+	Priority int
 }
 
 // ServiceOnCallUser represents a currently on-call user for a service.
@@ -31,6 +36,24 @@ type ServiceOnCallUser struct {
 	UserName   string `json:"user_name"`
 }
 
+// ServiceOnCall represents the users currently on-call for the first step of a
+// service's escalation policy.
+type ServiceOnCall struct {
+	ServiceID   string
+	ServiceName string
+	Users       []OnCallUser
+}
+
+// OnCallUser represents a currently on-call user.
+type OnCallUser struct {
+	UserID   string
+	UserName string
+
+	// Shadow is true if the user is shadowing an on-call rotation participant, and only receives
+	// training notifications.
+	Shadow bool
+}
+
 // A Shift represents a duration a user is on-call.
 // If truncated is true, then the End time does not represent
 // the time the user stopped being on call, instead it indicates
@@ -42,19 +65,31 @@ type Shift struct {
 	Truncated bool      `json:"truncated"`
 }
 
+// UserShift is a Shift of a single user for a schedule.
+type UserShift struct {
+	ScheduleID string
+	Shift
+}
+
 // Store allows retrieving and calculating on-call information.
 type Store struct {
 	db *sql.DB
 
 	onCallUsersSvc      *sql.Stmt
 	onCallUsersSchedule *sql.Stmt
+	servicesOnCall      *sql.Stmt
 	schedOverrides      *sql.Stmt
+	userScheds          *sql.Stmt
 
 	schedOnCall *sql.Stmt
 	schedTZ     *sql.Stmt
 	schedRot    *sql.Stmt
 	rotParts    *sql.Stmt
 
+	rotFairUsers  *sql.Stmt
+	rotFairShifts *sql.Stmt
+	rotFairPages  *sql.Stmt
+
 	ruleStore  *rule.Store
 	schedStore *schedule.Store
 }
@@ -81,6 +116,33 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 				($2, $3) OVERLAPS(start_time, end_time)
 		`),
 
+		// schedules that target the user with a rule, rotation, override, fixed shift, or
+		// temporary schedule shift; only the schedule data of the user's shifts is matched
+		userScheds: p.P(`
+			select r.schedule_id
+			from schedule_rules r
+			where r.tgt_user_id = $1
+			union
+			select r.schedule_id
+			from schedule_rules r
+			join rotation_participants p on p.rotation_id = r.tgt_rotation_id and p.user_id = $1
+			union
+			select o.tgt_schedule_id
+			from user_overrides o
+			where
+				o.add_user_id = $1 and
+				($2, $3) OVERLAPS(o.start_time, o.end_time)
+			union
+			select d.schedule_id
+			from schedule_data d
+			where
+				d.data @> jsonb_build_object('V1', jsonb_build_object('FixedShifts', jsonb_build_array(jsonb_build_object('UserID', $1::text)))) or
+				d.data @> jsonb_build_object('V1', jsonb_build_object('TemporarySchedules', jsonb_build_array(
+					jsonb_build_object('Shifts', jsonb_build_array(jsonb_build_object('UserID', $1::text)))
+				)))
+			order by 1
+		`),
+
 		onCallUsersSvc: p.P(`
 			select step.step_number, oc.user_id, u.name as user_name
 			from services svc
@@ -90,11 +152,54 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			where svc.id = $1
 			order by step.step_number, oc.start_time
 		`),
+		// users are read from the on-call state the escalation engine keeps for each step, so they
+		// match who would be notified; shadows are those the engine would send training notifications
+		servicesOnCall: p.P(`
+			with first_step as (
+				select distinct on (escalation_policy_id) id, escalation_policy_id
+				from escalation_policy_steps
+				order by escalation_policy_id, step_number
+			), step_users as (
+				select oc.ep_step_id step_id, oc.user_id, false shadow
+				from ep_step_on_call_users oc
+				where oc.end_time isnull
+			), step_shadows as (
+				select distinct oc.step_id, sh.user_id, true shadow
+				from step_users oc
+				join escalation_policy_actions act on act.escalation_policy_step_id = oc.step_id
+				left join schedule_rules rule on
+					rule.schedule_id = act.schedule_id and
+					rule.tgt_rotation_id notnull
+				join rotation_state rState on rState.rotation_id = coalesce(act.rotation_id, rule.tgt_rotation_id)
+				join rotation_participants part on
+					part.id = rState.rotation_participant_id and
+					part.user_id = oc.user_id
+				join rotation_shadows sh on
+					sh.rotation_id = rState.rotation_id and
+					sh.expires_at > now()
+				where not exists (
+					select null
+					from step_users su
+					where su.step_id = oc.step_id and su.user_id = sh.user_id
+				)
+			), all_users as (
+				select * from step_users
+				union all
+				select * from step_shadows
+			)
+			select svc.id, svc.name, u.id, u.name, su.shadow
+			from services svc
+			left join first_step step on step.escalation_policy_id = svc.escalation_policy_id
+			left join all_users su on su.step_id = step.id
+			left join users u on u.id = su.user_id
+			order by lower(svc.name), svc.id, su.shadow, lower(u.name), u.id
+		`),
 		onCallUsersSchedule: p.P(`
-			SELECT s.user_id, u.name
+			SELECT s.user_id, u.name, s.priority
 			FROM schedule_on_call_users s
 			JOIN users u ON u.id = s.user_id
 			WHERE s.schedule_id = $1 AND s.end_time IS NULL
+			ORDER BY s.priority, lower(u.name), u.id
 		`),
 		schedOnCall: p.P(`
 			select
@@ -125,13 +230,82 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 		rotParts: p.P(`
 			select
 				rotation_id,
-				user_id
+				user_id,
+				weight
 			from rotation_participants
 			where rotation_id = any($1)
 			order by
 				rotation_id,
 				position
 		`),
+		rotFairUsers: p.P(`
+			select user_id, now()
+			from rotation_participants
+			where rotation_id = $1
+			order by position
+		`),
+		rotFairShifts: p.P(`
+			select
+				oc.user_id,
+				oc.start_time,
+				oc.end_time
+			from schedule_on_call_users oc
+			where
+				oc.schedule_id in (select schedule_id from schedule_rules where tgt_rotation_id = $1) and
+				oc.user_id = any($2) and
+				tstzrange($3, $4) && tstzrange(oc.start_time, oc.end_time) and
+				not exists (
+					select 1
+					from schedule_rules r
+					where
+						r.schedule_id = oc.schedule_id and
+						(
+							r.tgt_user_id = oc.user_id or
+							r.tgt_rotation_id in (select rotation_id from rotation_participants where user_id = oc.user_id and rotation_id <> $1)
+						)
+				)
+		`),
+		rotFairPages: p.P(`
+			select distinct on (coalesce(b.id, msg.id))
+				msg.user_id,
+				coalesce(b.sent_at, msg.sent_at)
+			from outgoing_messages msg
+			left join outgoing_messages b on
+				b.id = case when msg.last_status = 'bundled' then msg.status_details::uuid end
+			where
+				msg.message_type = 'alert_notification' and
+				not msg.shadow and
+				not msg.watcher and
+				msg.user_id = any($2) and
+				msg.escalation_policy_id in (
+					select step.escalation_policy_id
+					from escalation_policy_actions act
+					join escalation_policy_steps step on step.id = act.escalation_policy_step_id
+					where
+						act.rotation_id = $1 or
+						act.schedule_id in (select schedule_id from schedule_rules where tgt_rotation_id = $1)
+				) and
+				not exists (
+					select 1
+					from escalation_policy_actions act
+					join escalation_policy_steps step on step.id = act.escalation_policy_step_id
+					where
+						step.escalation_policy_id = msg.escalation_policy_id and
+						(
+							act.user_id = msg.user_id or
+							act.rotation_id in (select rotation_id from rotation_participants where user_id = msg.user_id and rotation_id <> $1) or
+							act.schedule_id in (
+								select schedule_id
+								from schedule_rules
+								where
+									tgt_user_id = msg.user_id or
+									tgt_rotation_id in (select rotation_id from rotation_participants where user_id = msg.user_id and rotation_id <> $1)
+							)
+						)
+				) and
+				coalesce(b.sent_at, msg.sent_at) >= $3 and
+				coalesce(b.sent_at, msg.sent_at) < $4
+		`),
 	}, p.Err
 }
 
@@ -162,6 +336,46 @@ func (s *Store) OnCallUsersByService(ctx context.Context, serviceID string) ([]S
 	return onCall, nil
 }
 
+// ServicesOnCall will return the users currently on-call for the first step of
+// every service's escalation policy, ordered by service name.
+//
+// Users are resolved the same way the escalation engine does, including business hours
+// conditions and schedule priorities, followed by any rotation shadows.
+func (s *Store) ServicesOnCall(ctx context.Context) ([]ServiceOnCall, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.servicesOnCall.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch on-call users for services: %w", err)
+	}
+	defer rows.Close()
+
+	var result []ServiceOnCall
+	for rows.Next() {
+		var svcID, svcName string
+		var userID, userName sql.NullString
+		var shadow sql.NullBool
+		err = rows.Scan(&svcID, &svcName, &userID, &userName, &shadow)
+		if err != nil {
+			return nil, fmt.Errorf("scan on-call user for services: %w", err)
+		}
+
+		if len(result) == 0 || result[len(result)-1].ServiceID != svcID {
+			result = append(result, ServiceOnCall{ServiceID: svcID, ServiceName: svcName, Users: []OnCallUser{}})
+		}
+		if !userID.Valid {
+			continue
+		}
+		svc := &result[len(result)-1]
+		svc.Users = append(svc.Users, OnCallUser{UserID: userID.String, UserName: userName.String, Shadow: shadow.Bool})
+	}
+
+	return result, rows.Err()
+}
+
 func (s *Store) OnCallUsersBySchedule(ctx context.Context, scheduleID string) ([]ScheduleOnCallUser, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
 	if err != nil {
@@ -180,7 +394,7 @@ func (s *Store) OnCallUsersBySchedule(ctx context.Context, scheduleID string) ([
 	var result []ScheduleOnCallUser
 	for rows.Next() {
 		var u ScheduleOnCallUser
-		err = rows.Scan(&u.ID, &u.Name)
+		err = rows.Scan(&u.ID, &u.Name, &u.Priority)
 		if err != nil {
 			return nil, fmt.Errorf("scan on-call user entry #%d for schedule '%s': %w", len(result), scheduleID, err)
 		}
@@ -191,6 +405,105 @@ func (s *Store) OnCallUsersBySchedule(ctx context.Context, scheduleID string) ([
 	return result, nil
 }
 
+// UserShiftsOptions controls which of a user's shifts are returned by UserShifts.
+type UserShiftsOptions struct {
+	UserID     string
+	Start, End time.Time
+
+	// AfterStart and AfterScheduleID, if set, skip shifts up to and including the one
+	// starting at AfterStart for AfterScheduleID.
+	AfterStart      time.Time
+	AfterScheduleID string
+
+	// Limit is the maximum number of shifts to return.
+	Limit int
+}
+
+// UserShifts will return the shifts of the user, across all schedules, that overlap the start and end time,
+// ordered by start time and schedule ID. Shifts from rules, fixed shifts, temporary schedules and overrides
+// are included. The returned bool is true if more shifts follow the last one returned.
+//
+// Only schedules that target the user are calculated, and overrides that end before AfterStart are
+// skipped when looking them up.
+func (s *Store) UserShifts(ctx context.Context, opts UserShiftsOptions) ([]UserShift, bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, false, err
+	}
+	id, err := validate.ParseUUID("UserID", opts.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	userID := id.String()
+	// overrides ending before the cursor can't add shifts to the page
+	overrideStart := opts.Start
+	if opts.AfterStart.After(overrideStart) {
+		overrideStart = opts.AfterStart
+	}
+
+	rows, err := s.userScheds.QueryContext(ctx, userID, overrideStart, opts.End)
+	if err != nil {
+		return nil, false, fmt.Errorf("lookup schedules for user '%s': %w", userID, err)
+	}
+	defer rows.Close()
+
+	var schedIDs []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, false, fmt.Errorf("scan schedule ID: %w", err)
+		}
+		schedIDs = append(schedIDs, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	var result []UserShift
+	for _, schedID := range schedIDs {
+		shifts, err := s.HistoryBySchedule(ctx, schedID, opts.Start, opts.End)
+		if err != nil {
+			return nil, false, fmt.Errorf("calculate shifts for schedule '%s': %w", schedID, err)
+		}
+		for _, shift := range shifts {
+			if shift.UserID != userID {
+				continue
+			}
+			result = append(result, UserShift{ScheduleID: schedID, Shift: shift})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Start.Equal(result[j].Start) {
+			return result[i].Start.Before(result[j].Start)
+		}
+		return result[i].ScheduleID < result[j].ScheduleID
+	})
+
+	page, more := pageUserShifts(result, opts.AfterStart, opts.AfterScheduleID, opts.Limit)
+	return page, more, nil
+}
+
+// pageUserShifts returns up to limit shifts following the one starting at afterStart for afterSchedID, and
+// true if more remain. Shifts must be sorted by start time and schedule ID.
+func pageUserShifts(shifts []UserShift, afterStart time.Time, afterSchedID string, limit int) ([]UserShift, bool) {
+	if !afterStart.IsZero() {
+		idx := sort.Search(len(shifts), func(i int) bool {
+			if !shifts[i].Start.Equal(afterStart) {
+				return shifts[i].Start.After(afterStart)
+			}
+			return shifts[i].ScheduleID > afterSchedID
+		})
+		shifts = shifts[idx:]
+	}
+	if len(shifts) > limit {
+		return shifts[:limit], true
+	}
+
+	return shifts, false
+}
+
 // HistoryBySchedule will return the list of shifts that overlap the start and end time for the given schedule.
 func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
@@ -248,11 +561,13 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	defer rows.Close()
 	for rows.Next() {
 		var rotID, userID string
-		err = rows.Scan(&rotID, &userID)
+		var weight int
+		err = rows.Scan(&rotID, &userID, &weight)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation participant info")
 		}
 		rots[rotID].Users = append(rots[rotID].Users, userID)
+		rots[rotID].Weights = append(rots[rotID].Weights, weight)
 	}
 
 	rawRules, err := s.ruleStore.FindAllTx(ctx, tx, scheduleID)
@@ -314,6 +629,10 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	if err != nil {
 		return nil, errors.Wrap(err, "lookup temporary schedules")
 	}
+	fixedShifts, err := s.schedStore.FixedShifts(ctx, tx, id, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup fixed shifts")
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -325,13 +644,112 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 		return nil, errors.Wrap(err, "load time zone info")
 	}
 	st := state{
-		rules:      rules,
-		overrides:  overrides,
-		history:    userHistory,
-		now:        now,
-		loc:        tz,
-		tempScheds: tempScheds,
+		rules:       rules,
+		overrides:   overrides,
+		history:     userHistory,
+		now:         now,
+		loc:         tz,
+		tempScheds:  tempScheds,
+		fixedShifts: fixedShifts,
 	}
 
 	return st.CalculateShifts(start, end), nil
 }
+
+// RotationFairness will return the on-call time and pages of each participant of the rotation,
+// for each bucket between start and end. On-call time comes from the history of the schedules
+// that use the rotation, and pages are alert notifications sent by escalation policies that
+// target the rotation, or one of those schedules.
+//
+// Shifts and pages are only counted when the rotation is the only way the participant could have
+// been on call, so a schedule or policy that also targets them directly, or through another
+// rotation, is ignored for that participant. Shadow and watcher notifications are not pages.
+//
+// The end time is limited to the current time.
+func (s *Store) RotationFairness(ctx context.Context, rotationID string, start, end time.Time, bucket time.Duration) ([]FairnessStat, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer sqlutil.Rollback(ctx, "oncall: fetch rotation fairness", tx)
+
+	rows, err := tx.StmtContext(ctx, s.rotFairUsers).QueryContext(ctx, rotationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup rotation participants")
+	}
+	defer rows.Close()
+	var userIDs []string
+	now := time.Now()
+	for rows.Next() {
+		var userID string
+		err = rows.Scan(&userID, &now)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan rotation participant")
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if end.After(now) {
+		end = now
+	}
+	if len(userIDs) == 0 || !end.After(start) {
+		return []FairnessStat{}, nil
+	}
+
+	rows, err = tx.StmtContext(ctx, s.rotFairShifts).QueryContext(ctx, rotationID, sqlutil.UUIDArray(userIDs), start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup on-call history")
+	}
+	defer rows.Close()
+	var shifts []Shift
+	for rows.Next() {
+		var s Shift
+		var shiftEnd sqlutil.NullTime
+		err = rows.Scan(&s.UserID, &s.Start, &shiftEnd)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan on-call history")
+		}
+		s.End = end
+		if shiftEnd.Valid && shiftEnd.Time.Before(end) {
+			s.End = shiftEnd.Time
+		}
+		shifts = append(shifts, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.StmtContext(ctx, s.rotFairPages).QueryContext(ctx, rotationID, sqlutil.UUIDArray(userIDs), start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup pages")
+	}
+	defer rows.Close()
+	var pages []Page
+	for rows.Next() {
+		var p Page
+		err = rows.Scan(&p.UserID, &p.SentAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan page")
+		}
+		pages = append(pages, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return FairnessStats(userIDs, start, end, bucket, shifts, pages), nil
+}
@@ -0,0 +1,33 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageUserShifts(t *testing.T) {
+	start := time.Date(2023, 10, 30, 8, 0, 0, 0, time.UTC)
+	shift := func(hours int, schedID string) UserShift {
+		return UserShift{ScheduleID: schedID, Shift: Shift{Start: start.Add(time.Duration(hours) * time.Hour)}}
+	}
+	shifts := []UserShift{shift(0, "a"), shift(0, "b"), shift(1, "a"), shift(2, "a")}
+
+	page, more := pageUserShifts(shifts, time.Time{}, "", 2)
+	assert.Equal(t, shifts[:2], page)
+	assert.True(t, more)
+
+	// same start time, later schedule
+	page, more = pageUserShifts(shifts, start, "a", 2)
+	assert.Equal(t, shifts[1:3], page)
+	assert.True(t, more)
+
+	page, more = pageUserShifts(shifts, start, "b", 2)
+	assert.Equal(t, shifts[2:], page)
+	assert.False(t, more)
+
+	page, more = pageUserShifts(shifts, start.Add(2*time.Hour), "a", 2)
+	assert.Empty(t, page)
+	assert.False(t, more)
+}
@@ -0,0 +1,259 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// BasePath is the path OpsGenie-compatible requests are served under.
+//
+// Alerts are created by posting to BasePath (or BasePath + "/v2/alerts"), and
+// can be acknowledged or closed by alias via BasePath + "/v2/alerts/<alias>/acknowledge"
+// or "/close" with `identifierType=alias`.
+const BasePath = "/api/v2/opsgenie/incoming"
+
+/* Example payload
+
+```
+{
+  "message": "An example alert message",
+  "alias": "Life is too short for no alias",
+  "description": "Every alert needs a description",
+  "tags": ["OverwriteQuietHours", "Critical"],
+  "details": {"key1": "value1", "key2": "value2"},
+  "entity": "An example entity",
+  "source": "Monitoring Tool",
+  "priority": "P1"
+}
+```
+*/
+
+type createBody struct {
+	Message     string
+	Alias       string
+	Description string
+	Tags        []string
+	Props       map[string]string `json:"details"`
+	Entity      string
+	Source      string
+	Priority    string
+}
+
+// severity maps an OpsGenie priority onto an alert severity. OpsGenie defaults
+// to P3 when a priority is not provided.
+func severity(priority string) (alert.Severity, error) {
+	switch strings.ToUpper(priority) {
+	case "P1":
+		return alert.SeverityFatal, nil
+	case "P2":
+		return alert.SeverityCritical, nil
+	case "P3", "":
+		return alert.SeverityWarning, nil
+	case "P4", "P5":
+		return alert.SeverityInfo, nil
+	}
+
+	return "", validate.OneOf("Priority", priority, "P1", "P2", "P3", "P4", "P5")
+}
+
+func (b createBody) priority() string {
+	if b.Priority == "" {
+		return "P3"
+	}
+	return strings.ToUpper(b.Priority)
+}
+
+// Summary returns the alert summary for the payload.
+func (b createBody) Summary() string { return b.Message }
+
+// Details returns markdown-formatted alert details for the payload.
+func (b createBody) Details() string {
+	var s strings.Builder
+	if b.Description != "" {
+		s.WriteString(b.Description + "\n\n")
+	}
+
+	sev, _ := severity(b.Priority)
+	fmt.Fprintf(&s, "**Priority:** %s (%s severity)\n\n", b.priority(), sev)
+	if len(b.Tags) > 0 {
+		fmt.Fprintf(&s, "**Tags:** %s\n\n", strings.Join(b.Tags, ", "))
+	}
+	if b.Entity != "" {
+		fmt.Fprintf(&s, "**Entity:** %s\n\n", b.Entity)
+	}
+	if b.Source != "" {
+		fmt.Fprintf(&s, "**Source:** %s\n\n", b.Source)
+	}
+
+	if len(b.Props) > 0 {
+		keys := make([]string, 0, len(b.Props))
+		for k := range b.Props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		s.WriteString("| Detail | Value |\n| ------ | ----- |\n")
+		for _, k := range keys {
+			fmt.Fprintf(&s, "| %s | %s |\n", escapeTableCell(k), escapeTableCell(b.Props[k]))
+		}
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// DedupKey returns the value used to de-duplicate alerts. OpsGenie uses the alias
+// for de-duplication, falling back to the message if one is not provided.
+func (b createBody) DedupKey() string {
+	if b.Alias != "" {
+		return b.Alias
+	}
+
+	return b.Message
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "\n", "<br />")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return s
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// parsePath returns the alias and action for an acknowledge or close request. An empty
+// action indicates a create request.
+func parsePath(p string) (alias, action string, ok bool) {
+	p = strings.TrimPrefix(p, BasePath)
+	p = strings.TrimPrefix(p, "/v2/alerts")
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return "", "", true
+	}
+
+	idx := strings.LastIndexByte(p, '/')
+	if idx < 1 {
+		return "", "", false
+	}
+
+	alias, action = p[:idx], p[idx+1:]
+	switch action {
+	case "acknowledge", "close":
+		return alias, action, true
+	}
+
+	return "", "", false
+}
+
+// OpsGenieToEventsAPI handles requests in the format of the OpsGenie alert API.
+func OpsGenieToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		aliasPath, action, ok := parsePath(r.URL.EscapedPath())
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var msg *alert.Alert
+		switch action {
+		case "":
+			var body createBody
+			err = json.NewDecoder(r.Body).Decode(&body)
+			if clientError(w, http.StatusBadRequest, err) {
+				log.Logf(ctx, "bad request from opsgenie: %v", err)
+				return
+			}
+
+			sev, err := severity(body.Priority)
+			if errutil.HTTPError(ctx, w, err) {
+				return
+			}
+			if body.Message == "" {
+				http.Error(w, "message is required", http.StatusBadRequest)
+				return
+			}
+
+			msg = &alert.Alert{
+				Summary:   validate.SanitizeText(body.Summary(), alert.MaxSummaryLength),
+				Details:   validate.SanitizeText(body.Details(), alert.MaxDetailsLength),
+				Status:    alert.StatusTriggered,
+				ServiceID: serviceID,
+				Severity:  sev,
+				Meta:      alert.Meta(body.Props).Sanitize(),
+				Dedup:     alert.NewUserDedup(body.DedupKey()),
+			}
+		default:
+			if r.FormValue("identifierType") != "alias" {
+				http.Error(w, "only identifierType=alias is supported", http.StatusBadRequest)
+				return
+			}
+
+			msg = &alert.Alert{
+				Summary:   "OpsGenie " + action,
+				Status:    alert.StatusActive,
+				ServiceID: serviceID,
+			}
+			if action == "close" {
+				msg.Status = alert.StatusClosed
+			}
+			alias, err := url.PathUnescape(aliasPath)
+			if clientError(w, http.StatusBadRequest, err) {
+				return
+			}
+			msg.Dedup = alert.NewUserDedup(alias)
+		}
+		msg.Source = alert.SourceOpsGenie
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for opsgenie")) {
+			return
+		}
+
+		// respond the same way OpsGenie does, as some clients expect the body
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(struct {
+			Result    string  `json:"result"`
+			Took      float64 `json:"took"`
+			RequestID string  `json:"requestId"`
+		}{
+			Result:    "Request will be processed",
+			RequestID: uuid.NewString(),
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package opsgenie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/alert"
+)
+
+func TestParsePath(t *testing.T) {
+	check := func(path, expAlias, expAction string, expOK bool) {
+		t.Helper()
+		alias, action, ok := parsePath(path)
+		assert.Equal(t, expOK, ok, "ok")
+		assert.Equal(t, expAlias, alias, "alias")
+		assert.Equal(t, expAction, action, "action")
+	}
+
+	check(BasePath, "", "", true)
+	check(BasePath+"/", "", "", true)
+	check(BasePath+"/v2/alerts", "", "", true)
+	check(BasePath+"/v2/alerts/my-alias/close", "my-alias", "close", true)
+	check(BasePath+"/v2/alerts/foo%2Fbar/acknowledge", "foo%2Fbar", "acknowledge", true)
+	check(BasePath+"/v2/alerts/my-alias/snooze", "", "", false)
+	check(BasePath+"/v2/alerts/close", "", "", false)
+}
+
+func TestCreateBody(t *testing.T) {
+	b := createBody{
+		Message:     "Disk full",
+		Description: "The disk is full.",
+		Tags:        []string{"disk", "prod"},
+		Props:       map[string]string{"host": "web1", "mount": "/var|log"},
+		Priority:    "p1",
+	}
+
+	assert.Equal(t, "Disk full", b.DedupKey())
+	b.Alias = "disk-web1"
+	assert.Equal(t, "disk-web1", b.DedupKey())
+
+	assert.Equal(t, "The disk is full.\n\n"+
+		"**Priority:** P1 (fatal severity)\n\n"+
+		"**Tags:** disk, prod\n\n"+
+		"| Detail | Value |\n"+
+		"| ------ | ----- |\n"+
+		"| host | web1 |\n"+
+		"| mount | /var\\|log |", b.Details())
+
+	_, err := severity("P6")
+	assert.Error(t, err)
+	for prio, exp := range map[string]alert.Severity{
+		"p1": alert.SeverityFatal,
+		"P2": alert.SeverityCritical,
+		"":   alert.SeverityWarning,
+		"P4": alert.SeverityInfo,
+		"P5": alert.SeverityInfo,
+	} {
+		sev, err := severity(prio)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, sev, prio)
+	}
+}
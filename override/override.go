@@ -16,6 +16,9 @@ type UserOverride struct {
 	Start        time.Time `json:"start_time,omitempty"`
 	End          time.Time `json:"end_time,omitempty"`
 	Target       assignment.Target
+
+	// RecurrenceID is set if the override is an instance of a Recurrence.
+	RecurrenceID string `json:"recurrence_id,omitempty"`
 }
 
 const debugTimeFmt = "MonJan2_2006@3:04pm"
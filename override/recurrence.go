@@ -0,0 +1,89 @@
+package override
+
+import (
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxRecurrenceDuration is the longest a single instance of a recurring override may last.
+const MaxRecurrenceDuration = 24 * time.Hour
+
+// MaxRecurrenceRange is the furthest Until may be from the start of a recurring override.
+const MaxRecurrenceRange = 366 * 24 * time.Hour
+
+// A Recurrence is a UserOverride that repeats weekly, on the selected days, until a given time.
+//
+// Start and End define the time of day and duration of each instance, and the first day
+// of the recurrence. Instances keep the same wall-clock time in the location of Start,
+// so they follow DST changes in that time zone.
+type Recurrence struct {
+	ID           string
+	AddUserID    string
+	RemoveUserID string
+	Start        time.Time
+	End          time.Time
+	Weekdays     timeutil.WeekdayFilter
+	Until        time.Time
+	Target       assignment.Target
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (r Recurrence) Normalize() (*Recurrence, error) {
+	_, err := UserOverride{
+		AddUserID:    r.AddUserID,
+		RemoveUserID: r.RemoveUserID,
+		Start:        r.Start,
+		End:          r.End,
+		Target:       r.Target,
+	}.Normalize()
+
+	if r.End.Sub(r.Start) > MaxRecurrenceDuration {
+		err = validate.Many(err, validation.NewFieldError("End", "must be within 24 hours of Start time"))
+	}
+	if r.Weekdays.IsNever() {
+		err = validate.Many(err, validation.NewFieldError("Weekdays", "must include at least one day"))
+	}
+	if !r.Until.After(r.Start) {
+		err = validate.Many(err, validation.NewFieldError("Until", "must occur after Start time"))
+	} else if r.Until.Sub(r.Start) > MaxRecurrenceRange {
+		err = validate.Many(err, validation.NewFieldError("Until", "must be within one year of Start time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Instances returns each occurrence of the recurrence that starts before Until.
+func (r Recurrence) Instances() []UserOverride {
+	dur := r.End.Sub(r.Start)
+	y, m, d := r.Start.Date()
+	hh, mm, ss := r.Start.Clock()
+
+	var result []UserOverride
+	for i := 0; ; i++ {
+		start := time.Date(y, m, d+i, hh, mm, ss, 0, r.Start.Location())
+		if !start.Before(r.Until) {
+			break
+		}
+		if !r.Weekdays.Day(start.Weekday()) {
+			continue
+		}
+
+		result = append(result, UserOverride{
+			AddUserID:    r.AddUserID,
+			RemoveUserID: r.RemoveUserID,
+			Start:        start,
+			End:          start.Add(dur),
+			Target:       r.Target,
+			RecurrenceID: r.ID,
+		})
+	}
+
+	return result
+}
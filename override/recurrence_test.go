@@ -0,0 +1,75 @@
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestRecurrence_Instances(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	var fridays timeutil.WeekdayFilter
+	fridays.SetDay(time.Friday, true)
+
+	r := Recurrence{
+		AddUserID: "bcefacc0-4764-012d-7bfb-002500d5decb",
+		Target:    assignment.ScheduleTarget("e93facc0-4764-012d-7bfb-002500d5d1a6"),
+		Weekdays:  fridays,
+
+		// Friday afternoons, across the November DST change
+		Start: time.Date(2023, 10, 27, 13, 0, 0, 0, loc),
+		End:   time.Date(2023, 10, 27, 17, 0, 0, 0, loc),
+		Until: time.Date(2023, 11, 17, 0, 0, 0, 0, loc),
+	}
+	_, err = r.Normalize()
+	require.NoError(t, err)
+
+	inst := r.Instances()
+	require.Len(t, inst, 3)
+	for _, o := range inst {
+		assert.Equal(t, time.Friday, o.Start.Weekday())
+		assert.Equal(t, 13, o.Start.Hour(), "wall-clock start should be preserved")
+		assert.Equal(t, 4*time.Hour, o.End.Sub(o.Start))
+	}
+	assert.Equal(t, time.Date(2023, 11, 10, 13, 0, 0, 0, loc), inst[2].Start)
+}
+
+func TestRecurrence_Normalize(t *testing.T) {
+	start := time.Date(2023, 10, 27, 13, 0, 0, 0, time.UTC)
+	valid := Recurrence{
+		AddUserID: "bcefacc0-4764-012d-7bfb-002500d5decb",
+		Target:    assignment.ScheduleTarget("e93facc0-4764-012d-7bfb-002500d5d1a6"),
+		Weekdays:  timeutil.EveryDay(),
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Until:     start.Add(90 * 24 * time.Hour),
+	}
+	_, err := valid.Normalize()
+	assert.NoError(t, err)
+
+	noDays := valid
+	noDays.Weekdays = timeutil.WeekdayFilter{}
+	_, err = noDays.Normalize()
+	assert.Error(t, err)
+
+	tooLong := valid
+	tooLong.End = start.Add(25 * time.Hour)
+	_, err = tooLong.Normalize()
+	assert.Error(t, err)
+
+	untilBefore := valid
+	untilBefore.Until = start.Add(-time.Hour)
+	_, err = untilBefore.Normalize()
+	assert.Error(t, err)
+
+	untilTooFar := valid
+	untilTooFar.Until = start.Add(MaxRecurrenceRange + time.Hour)
+	_, err = untilTooFar.Normalize()
+	assert.Error(t, err)
+}
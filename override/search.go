@@ -48,7 +48,7 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 	)
 	{{end}}
 	SELECT
-		o.id, o.start_time, o.end_time, add_user_id, remove_user_id, tgt_schedule_id
+		o.id, o.start_time, o.end_time, add_user_id, remove_user_id, tgt_schedule_id, recurrence_id
 	FROM user_overrides o
 	{{if .After.ID}}
 	JOIN after ON true
@@ -156,14 +156,15 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]UserOverride
 
 	var result []UserOverride
 	var u UserOverride
-	var add, rem, schedID sql.NullString
+	var add, rem, schedID, recurID sql.NullString
 	for rows.Next() {
-		err = rows.Scan(&u.ID, &u.Start, &u.End, &add, &rem, &schedID)
+		err = rows.Scan(&u.ID, &u.Start, &u.End, &add, &rem, &schedID, &recurID)
 		if err != nil {
 			return nil, err
 		}
 		u.AddUserID = add.String
 		u.RemoveUserID = rem.String
+		u.RecurrenceID = recurID.String
 		if schedID.Valid {
 			u.Target = assignment.ScheduleTarget(schedID.String)
 		}
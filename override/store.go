@@ -28,6 +28,12 @@ type Store struct {
 	lock *sql.Stmt
 
 	findUOUpdate *sql.Stmt
+
+	schedTZ           *sql.Stmt
+	createRecurrence  *sql.Stmt
+	findRecurrence    *sql.Stmt
+	deleteRecurrences *sql.Stmt
+	deleteFutureUO    *sql.Stmt
 }
 
 // NewStore initializes a new DB using an existing sql connection.
@@ -46,7 +52,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			remove_user_id,
 			start_time,
 			end_time,
-			tgt_schedule_id
+			tgt_schedule_id,
+			recurrence_id
 		from user_overrides
 		where id = $1
 		for update
@@ -58,7 +65,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				remove_user_id,
 				start_time,
 				end_time,
-				tgt_schedule_id
+				tgt_schedule_id,
+				recurrence_id
 			from user_overrides
 			where id = $1
 		`),
@@ -79,8 +87,9 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				remove_user_id,
 				start_time,
 				end_time,
-				tgt_schedule_id
-			) values ($1, $2, $3, $4, $5, $6)`),
+				tgt_schedule_id,
+				recurrence_id
+			) values ($1, $2, $3, $4, $5, $6, $7)`),
 		deleteUO: p.P(`delete from user_overrides where id = any($1)`),
 		findAllUO: p.P(`
 			select
@@ -94,6 +103,36 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				tgt_schedule_id = $1 and
 				(start_time, end_time) OVERLAPS ($2, $3)
 		`),
+
+		schedTZ: p.P(`select time_zone from schedules where id = $1`),
+		createRecurrence: p.P(`
+			insert into user_override_recurrences (
+				id,
+				add_user_id,
+				remove_user_id,
+				start_time,
+				end_time,
+				weekdays,
+				until,
+				tgt_schedule_id
+			) values ($1, $2, $3, $4, $5, $6, $7, $8)`),
+		findRecurrence: p.P(`
+			select
+				r.id,
+				r.add_user_id,
+				r.remove_user_id,
+				r.start_time,
+				r.end_time,
+				r.weekdays,
+				r.until,
+				r.tgt_schedule_id,
+				s.time_zone
+			from user_override_recurrences r
+			join schedules s on s.id = r.tgt_schedule_id
+			where r.id = $1
+		`),
+		deleteRecurrences: p.P(`delete from user_override_recurrences where id = any($1)`),
+		deleteFutureUO:    p.P(`delete from user_overrides where recurrence_id = any($1) and start_time > now()`),
 	}, p.Err
 }
 
@@ -140,7 +179,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 	}
 
 	var o UserOverride
-	var add, rem, schedTgt sql.NullString
+	var add, rem, schedTgt, recurID sql.NullString
 	err = s.withTx(ctx, tx, func(tx *sql.Tx) error {
 		var row *sql.Row
 		if forUpdate {
@@ -149,7 +188,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 			row = tx.StmtContext(ctx, s.findUO).QueryRowContext(ctx, id)
 		}
 
-		return row.Scan(&o.ID, &add, &rem, &o.Start, &o.End, &schedTgt)
+		return row.Scan(&o.ID, &add, &rem, &o.Start, &o.End, &schedTgt, &recurID)
 	})
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -159,6 +198,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 	}
 	o.AddUserID = add.String
 	o.RemoveUserID = rem.String
+	o.RecurrenceID = recurID.String
 	if schedTgt.Valid {
 		o.Target = assignment.ScheduleTarget(schedTgt.String)
 	}
@@ -233,7 +273,76 @@ func (s *Store) CreateUserOverrideTx(ctx context.Context, tx *sql.Tx, o *UserOve
 		schedTgt.Valid = true
 		schedTgt.String = n.Target.TargetID()
 	}
-	err = s.execContext(ctx, tx, s.createUO, n.ID, add, rem, n.Start, n.End, schedTgt)
+	err = s.execContext(ctx, tx, s.createUO, n.ID, add, rem, n.Start, n.End, schedTgt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// CreateUserOverrideRecurrenceTx adds a Recurrence to the DB with a new ID, along with
+// each of its instances that have not already ended.
+//
+// Instances are evaluated in the time zone of the target schedule.
+func (s *Store) CreateUserOverrideRecurrenceTx(ctx context.Context, tx *sql.Tx, r *Recurrence) (*Recurrence, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	n, err := r.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if !n.Until.After(time.Now()) {
+		return nil, validation.NewFieldError("Until", "must be in the future")
+	}
+	n.ID = uuid.New().String()
+
+	var add, rem sql.NullString
+	if n.AddUserID != "" {
+		add.Valid = true
+		add.String = n.AddUserID
+	}
+	if n.RemoveUserID != "" {
+		rem.Valid = true
+		rem.String = n.RemoveUserID
+	}
+
+	err = s.withTx(ctx, tx, func(tx *sql.Tx) error {
+		var tz string
+		err := tx.StmtContext(ctx, s.schedTZ).QueryRowContext(ctx, n.Target.TargetID()).Scan(&tz)
+		if errors.Is(err, sql.ErrNoRows) {
+			return validation.NewFieldError("ScheduleID", "schedule not found")
+		}
+		if err != nil {
+			return err
+		}
+		loc, err := util.LoadLocation(tz)
+		if err != nil {
+			return err
+		}
+		n.Start = n.Start.In(loc)
+		n.End = n.End.In(loc)
+
+		_, err = tx.StmtContext(ctx, s.createRecurrence).ExecContext(ctx, n.ID, add, rem, n.Start, n.End, n.Weekdays, n.Until, n.Target.TargetID())
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, o := range n.Instances() {
+			if !o.End.After(now) {
+				continue
+			}
+			_, err = tx.StmtContext(ctx, s.createUO).ExecContext(ctx, uuid.New().String(), add, rem, o.Start, o.End, o.Target.TargetID(), n.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +350,66 @@ func (s *Store) CreateUserOverrideTx(ctx context.Context, tx *sql.Tx, o *UserOve
 	return n, nil
 }
 
+// FindOneUserOverrideRecurrence will return the Recurrence with the given ID, or nil if it does not exist.
+func (s *Store) FindOneUserOverrideRecurrence(ctx context.Context, id string) (*Recurrence, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RecurrenceID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Recurrence
+	var add, rem sql.NullString
+	var schedID, tz string
+	err = s.findRecurrence.QueryRowContext(ctx, id).Scan(&r.ID, &add, &rem, &r.Start, &r.End, &r.Weekdays, &r.Until, &schedID, &tz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	loc, err := util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	r.AddUserID = add.String
+	r.RemoveUserID = rem.String
+	r.Start = r.Start.In(loc)
+	r.End = r.End.In(loc)
+	r.Target = assignment.ScheduleTarget(schedID)
+
+	return &r, nil
+}
+
+// DeleteUserOverrideRecurrenceTx removes the Recurrences matching the given IDs, along
+// with their instances that have not yet started. Elapsed and in-progress instances
+// are kept as regular overrides.
+func (s *Store) DeleteUserOverrideRecurrenceTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = validate.ManyUUID("RecurrenceID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, tx, func(tx *sql.Tx) error {
+		_, err := tx.StmtContext(ctx, s.deleteFutureUO).ExecContext(ctx, sqlutil.UUIDArray(ids))
+		if err != nil {
+			return err
+		}
+		_, err = tx.StmtContext(ctx, s.deleteRecurrences).ExecContext(ctx, sqlutil.UUIDArray(ids))
+		return err
+	})
+}
+
 // DeleteUserOverride removes a UserOverride from the DB matching the given ID.
 func (s *Store) DeleteUserOverrideTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
 	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
@@ -29,6 +29,9 @@ const (
 
 	// SourceTypeGQLAPIKey is set when a context is authorized for use of the GraphQL API.
 	SourceTypeGQLAPIKey
+
+	// SourceTypeSlashCommand is set when a context is authorized via a chat slash command from a linked user.
+	SourceTypeSlashCommand
 )
 
 // SourceInfo provides information about the source of a context's authorization.
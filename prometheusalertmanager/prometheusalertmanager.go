@@ -91,17 +91,20 @@ type postBody struct {
 	CommonLabels struct {
 		Instance  string
 		AlertName string `json:"alertname"`
+		Severity  string
 	}
 
 	CommonAnnotations struct {
-		Summary string
-		Details string
+		Summary    string
+		Details    string
+		RunbookURL string `json:"runbook_url"`
 	}
 }
 type postBodyAlert struct {
 	Labels struct {
 		AlertName string
 		Instance  string
+		Severity  string
 	}
 	Annotations struct {
 		Summary string
@@ -153,6 +156,48 @@ func (b postBody) Summary() string {
 	return b.CommonLabels.AlertName + " " + strings.Join(instances, ",")
 }
 
+// Severity returns the highest recognized severity label of the alerts in the group,
+// or the default severity if none is set.
+func (b postBody) Severity() alert.Severity {
+	if sev, err := alert.ParseSeverity(b.CommonLabels.Severity); err == nil && b.CommonLabels.Severity != "" {
+		return sev
+	}
+
+	found := make(map[alert.Severity]bool)
+	for _, a := range b.Alerts {
+		sev, err := alert.ParseSeverity(a.Labels.Severity)
+		if err != nil || a.Labels.Severity == "" {
+			continue
+		}
+		found[sev] = true
+	}
+
+	sevs := alert.Severities()
+	for i := len(sevs) - 1; i >= 0; i-- {
+		if found[sevs[i]] {
+			return sevs[i]
+		}
+	}
+
+	return alert.DefaultSeverity
+}
+
+// Meta returns links from the payload as alert metadata.
+func (b postBody) Meta() alert.Meta {
+	meta := make(alert.Meta)
+	if b.ExternalURL != "" {
+		meta["alertmanager_url"] = b.ExternalURL
+	}
+	if b.CommonAnnotations.RunbookURL != "" {
+		meta["runbook_url"] = b.CommonAnnotations.RunbookURL
+	}
+	if len(b.Alerts) > 0 && b.Alerts[0].GeneratorURL != "" {
+		meta["generator_url"] = b.Alerts[0].GeneratorURL
+	}
+
+	return meta
+}
+
 func (b postBody) Details(payload string) string {
 	var s strings.Builder
 	if b.ExternalURL != "" {
@@ -227,11 +272,14 @@ func PrometheusAlertmanagerEventsAPI(aDB *alert.Store, intDB *integrationkey.Sto
 			Status:    status,
 			Source:    alert.SourcePrometheusAlertmanager,
 			ServiceID: serviceID,
+			Severity:  body.Severity(),
+			Meta:      body.Meta().Sanitize(),
 			Dedup:     alert.NewUserDedup(summary),
 		}
 
+		var result *alert.Alert
 		err = retry.DoTemporaryError(func(int) error {
-			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			result, _, err = aDB.CreateOrUpdate(ctx, msg)
 			return err
 		},
 			retry.Log(ctx),
@@ -241,5 +289,8 @@ func PrometheusAlertmanagerEventsAPI(aDB *alert.Store, intDB *integrationkey.Sto
 		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for prometheus alertmanager")) {
 			return
 		}
+		if status == alert.StatusClosed && result == nil {
+			log.Debugf(ctx, "prometheus alertmanager: resolved event matched no open alert (summary=%s)", summary)
+		}
 	}
 }
@@ -5,8 +5,14 @@ import "time"
 // Data contains configuration for a single schedule.
 type Data struct {
 	V1 struct {
-		TemporarySchedules      []TemporarySchedule
+		TemporarySchedules []TemporarySchedule
+
+		// FixedShifts are planned shifts that are on call in addition to any rule-based
+		// shifts. They are merged per-user and sorted by start time.
+		FixedShifts []FixedShift `json:",omitempty"`
+
 		OnCallNotificationRules []OnCallNotificationRule
+		HandoffNotification     *HandoffNotification `json:",omitempty"`
 	}
 }
 
@@ -35,3 +41,19 @@ func (data *Data) TempOnCall(t time.Time) (isActive bool, users []string) {
 
 	return isActive, users
 }
+
+// FixedOnCall will return the users with a fixed shift active at the given time.
+func (data *Data) FixedOnCall(t time.Time) (users []string) {
+	if data == nil {
+		return nil
+	}
+
+	for _, shift := range data.V1.FixedShifts {
+		if t.Before(shift.Start) || !t.Before(shift.End) {
+			continue
+		}
+		users = append(users, shift.UserID)
+	}
+
+	return users
+}
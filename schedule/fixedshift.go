@@ -86,3 +86,25 @@ func mergeShifts(shifts []FixedShift) []FixedShift {
 
 	return result
 }
+
+// replaceFixedShifts returns shifts with everything between start and end replaced by newShifts.
+// Existing shifts that span start or end are split, and the result is merged per-user and sorted.
+func replaceFixedShifts(shifts []FixedShift, start, end time.Time, newShifts []FixedShift) []FixedShift {
+	result := make([]FixedShift, 0, len(shifts)+len(newShifts))
+	for _, s := range shifts {
+		if !s.End.After(start) || !s.Start.Before(end) {
+			// no overlap
+			result = append(result, s)
+			continue
+		}
+		if s.Start.Before(start) {
+			result = append(result, FixedShift{Start: s.Start, End: start, UserID: s.UserID})
+		}
+		if s.End.After(end) {
+			result = append(result, FixedShift{Start: end, End: s.End, UserID: s.UserID})
+		}
+	}
+	result = append(result, clampShiftTimes(start, end, newShifts)...)
+
+	return mergeShifts(result)
+}
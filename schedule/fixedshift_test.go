@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceFixedShifts(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2000, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	shifts := []FixedShift{
+		{Start: hour(8), End: hour(12), UserID: "foo"},
+		{Start: hour(13), End: hour(14), UserID: "bar"},
+		{Start: hour(18), End: hour(20), UserID: "bar"},
+	}
+
+	res := replaceFixedShifts(shifts, hour(10), hour(16), []FixedShift{
+		{Start: hour(11), End: hour(15), UserID: "baz"},
+		{Start: hour(15), End: hour(16), UserID: "bar"},
+	})
+
+	assert.Equal(t, []FixedShift{
+		{Start: hour(8), End: hour(10), UserID: "foo"},
+		{Start: hour(11), End: hour(15), UserID: "baz"},
+		{Start: hour(15), End: hour(16), UserID: "bar"},
+		{Start: hour(18), End: hour(20), UserID: "bar"},
+	}, res)
+
+	// overlapping shifts for the same user are merged
+	res = replaceFixedShifts(nil, hour(0), hour(12), []FixedShift{
+		{Start: hour(1), End: hour(5), UserID: "foo"},
+		{Start: hour(3), End: hour(8), UserID: "foo"},
+	})
+	assert.Equal(t, []FixedShift{
+		{Start: hour(1), End: hour(8), UserID: "foo"},
+	}, res)
+}
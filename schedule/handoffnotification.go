@@ -0,0 +1,93 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxHandoffLeadTimeMinutes is the maximum lead time for handoff notifications.
+const MaxHandoffLeadTimeMinutes = 24 * 60
+
+// HandoffNotification configures notifications sent to users at on-call shift boundaries.
+//
+// Users about to go on call are notified LeadTimeMinutes before their shift starts, and users
+// going off call are notified when their shift ends.
+type HandoffNotification struct {
+	LeadTimeMinutes int
+}
+
+// LeadTime returns the lead time as a time.Duration.
+func (h HandoffNotification) LeadTime() time.Duration {
+	return time.Duration(h.LeadTimeMinutes) * time.Minute
+}
+
+// HandoffNotice is a single handoff notification sent to a user.
+type HandoffNotice struct {
+	ID         uuid.UUID
+	ScheduleID uuid.UUID
+	UserID     uuid.UUID
+
+	// OnCall is true if the user is about to go on call, and false if they have gone off call.
+	OnCall bool
+
+	// ShiftTime is the expected start of the shift if OnCall is true, otherwise when the shift ended.
+	ShiftTime time.Time
+}
+
+// SetHandoffNotification will set the handoff notification configuration for the given schedule ID. A
+// nil value disables handoff notifications.
+func (store *Store) SetHandoffNotification(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, h *HandoffNotification) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	if h != nil {
+		err = validate.Range("LeadTimeMinutes", h.LeadTimeMinutes, 1, MaxHandoffLeadTimeMinutes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.HandoffNotification = h
+
+		return nil
+	})
+}
+
+// HandoffNotification returns the handoff notification configuration for the provided scheduleID, or nil if disabled.
+func (store *Store) HandoffNotification(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*HandoffNotification, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.V1.HandoffNotification, nil
+}
+
+// FindOneHandoffNotice returns the handoff notice with the given ID.
+func (store *Store) FindOneHandoffNotice(ctx context.Context, id uuid.UUID) (*HandoffNotice, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	var n HandoffNotice
+	err = store.findHandoffNotice.QueryRowContext(ctx, id).Scan(&n.ID, &n.ScheduleID, &n.UserID, &n.OnCall, &n.ShiftTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
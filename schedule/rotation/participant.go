@@ -5,14 +5,24 @@ import (
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxParticipantWeight is the maximum weight of a rotation participant.
+const MaxParticipantWeight = 10
+
 type Participant struct {
 	ID         string `json:"id"`
 	Position   int    `json:"position"`
 	RotationID string `json:"rotation_id"`
 	Target     assignment.Target
+
+	// Weight is the number of consecutive shifts the participant is on call for each turn.
+	Weight int `json:"weight"`
 }
 
 func (p Participant) Normalize() (*Participant, error) {
+	if p.Weight == 0 {
+		p.Weight = 1
+	}
+
 	err := validate.Many(
 		validate.UUID("RotationID", p.RotationID),
 		validate.UUID("TargetID", p.Target.TargetID()),
@@ -20,6 +30,7 @@ func (p Participant) Normalize() (*Participant, error) {
 			assignment.TargetTypeUser,
 		),
 		validate.Range("Position", p.Position, 0, 9000),
+		validate.Range("Weight", p.Weight, 1, MaxParticipantWeight),
 	)
 
 	if err != nil {
@@ -32,7 +32,7 @@ func (r Rotation) shiftClock() timeutil.Clock {
 	case TypeWeekly:
 		return timeutil.NewClock(r.ShiftLength*24*7, 0)
 	default:
-		// monthly is handled separately
+		// monthly and fixed hours are handled separately
 		panic("unexpected rotation type")
 	}
 }
@@ -89,9 +89,21 @@ func (r Rotation) monthEndTime(t time.Time, n int) time.Time {
 	return r.monthEndTime(t, n+1)
 }
 
+// fixedHoursRem returns the amount of elapsed time since the start of the fixed-hours shift active at t.
+func (r Rotation) fixedHoursRem(t time.Time) time.Duration {
+	shiftLen := time.Duration(r.ShiftLength) * time.Hour
+	rem := t.Sub(r.Start) % shiftLen
+	if rem < 0 {
+		rem += shiftLen
+	}
+
+	return rem
+}
+
 // StartTime calculates the start of the "shift" that started at (or was active) at t.
 // For daily, weekly, and monthly rotations, start time will be the previous handoff time (from start).
 // For monthly rotations, the monthStartTime function is used to recursively handle calculations as the length of months vary.
+// For fixed-hours rotations, shifts are measured in elapsed time rather than clock time.
 func (r Rotation) StartTime(t time.Time) time.Time {
 	if r.ShiftLength <= 0 {
 		r.ShiftLength = 1
@@ -102,6 +114,9 @@ func (r Rotation) StartTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthStartTime(t, 1)
 	}
+	if r.Type == TypeFixedHours {
+		return t.Add(-r.fixedHoursRem(t))
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -127,6 +142,9 @@ func (r Rotation) EndTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthEndTime(t, 1)
 	}
+	if r.Type == TypeFixedHours {
+		return t.Add(time.Duration(r.ShiftLength)*time.Hour - r.fixedHoursRem(t))
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -138,6 +156,23 @@ func (r Rotation) EndTime(t time.Time) time.Time {
 	return timeutil.AddClock(t, shiftClockLen-rem)
 }
 
+// TurnEndTime calculates the end of the turn of a participant with the given weight that started at (or was active) at t.
+//
+// A participant remains on call for weight consecutive shifts before handing off to the next participant,
+// so with a daily rotation of shift length 2, a weight-3 participant is on call for 6 days at a time.
+// Over a full cycle, each participant is on call for a share of time proportional to their weight.
+// A weight less than 1 is treated as 1.
+func (r Rotation) TurnEndTime(t time.Time, weight int) time.Time {
+	if weight < 1 {
+		weight = 1
+	}
+	for i := 0; i < weight; i++ {
+		t = r.EndTime(t)
+	}
+
+	return t
+}
+
 func (r Rotation) Normalize() (*Rotation, error) {
 	if r.ShiftLength == 0 {
 		// default to 1
@@ -151,7 +186,7 @@ func (r Rotation) Normalize() (*Rotation, error) {
 	err := validate.Many(
 		validate.IDName("Name", r.Name),
 		validate.Range("ShiftLength", r.ShiftLength, 1, 9000),
-		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly),
+		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly, TypeFixedHours),
 		validate.Text("Description", r.Description, 1, 255),
 	)
 	if err != nil {
@@ -529,6 +529,19 @@ func TestRotation_EndTime(t *testing.T) {
 	})
 }
 
+func TestRotation_TurnEndTime(t *testing.T) {
+	rot := Rotation{
+		Type:        TypeDaily,
+		ShiftLength: 2,
+		Start:       mustParse(t, "Jan 1 2020 9:00 am"),
+	}
+
+	start := mustParse(t, "Jan 1 2020 9:01 am")
+	assert.Equal(t, rot.EndTime(start), rot.TurnEndTime(start, 1))
+	assert.Equal(t, rot.EndTime(start), rot.TurnEndTime(start, 0), "weights less than 1 should be treated as 1")
+	assert.Equal(t, mustParse(t, "Jan 7 2020 9:00 am").String(), rot.TurnEndTime(start, 3).String(), "3 shifts of 2 days")
+}
+
 func TestRotation_Normalize(t *testing.T) {
 
 	test := func(valid bool, r Rotation) {
@@ -683,3 +696,76 @@ func TestRotation_StartTime(t *testing.T) {
 		test(d.s, d.exp, d.l, d.dur, TypeHourly)
 	}
 }
+
+func TestRotation_FixedHours_DST(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	// check walks through consecutive shifts starting at from, asserting each handoff
+	// and that every shift is exactly the configured number of hours long.
+	check := func(rot *Rotation, from time.Time, expectedHandoffs ...string) {
+		t.Helper()
+		ts := rot.StartTime(from)
+		for _, exp := range expectedHandoffs {
+			require.Equal(t, exp, ts.String())
+
+			end := rot.EndTime(ts)
+			assert.Equal(t, time.Duration(rot.ShiftLength)*time.Hour, end.Sub(ts), "shift starting %s", ts.String())
+			assert.Equal(t, ts.String(), rot.StartTime(end.Add(-time.Minute)).String(), "StartTime for last minute of shift")
+			ts = end
+		}
+	}
+
+	// spring forward: 8 elapsed hours after 10PM CST is 7AM CDT
+	check(&Rotation{
+		Type:        TypeFixedHours,
+		ShiftLength: 8,
+		Start:       time.Date(2020, time.March, 6, 6, 0, 0, 0, loc),
+	},
+		time.Date(2020, time.March, 7, 15, 0, 0, 0, loc),
+
+		"2020-03-07 14:00:00 -0600 CST",
+		"2020-03-07 22:00:00 -0600 CST",
+		"2020-03-08 07:00:00 -0500 CDT",
+		"2020-03-08 15:00:00 -0500 CDT",
+	)
+
+	// fall back: 8 elapsed hours after 10PM CDT is 5AM CST
+	check(&Rotation{
+		Type:        TypeFixedHours,
+		ShiftLength: 8,
+		Start:       time.Date(2020, time.October, 30, 6, 0, 0, 0, loc),
+	},
+		time.Date(2020, time.October, 31, 23, 0, 0, 0, loc),
+
+		"2020-10-31 22:00:00 -0500 CDT",
+		"2020-11-01 05:00:00 -0600 CST",
+		"2020-11-01 13:00:00 -0600 CST",
+	)
+
+	// fall back: the repeated 1AM hour is its own shift
+	check(&Rotation{
+		Type:        TypeFixedHours,
+		ShiftLength: 1,
+		Start:       time.Date(2020, time.October, 30, 0, 0, 0, 0, loc),
+	},
+		time.Date(2020, time.November, 1, 0, 30, 0, 0, loc),
+
+		"2020-11-01 00:00:00 -0500 CDT",
+		"2020-11-01 01:00:00 -0500 CDT",
+		"2020-11-01 01:00:00 -0600 CST",
+		"2020-11-01 02:00:00 -0600 CST",
+	)
+
+	// anchor before the requested time and across a DST change
+	check(&Rotation{
+		Type:        TypeFixedHours,
+		ShiftLength: 8,
+		Start:       time.Date(2020, time.December, 1, 6, 0, 0, 0, loc),
+	},
+		time.Date(2020, time.November, 1, 12, 0, 0, 0, loc),
+
+		"2020-11-01 06:00:00 -0600 CST",
+		"2020-11-01 14:00:00 -0600 CST",
+	)
+}
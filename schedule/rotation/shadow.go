@@ -0,0 +1,39 @@
+package rotation
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxShadowDuration is the longest a user can shadow a rotation for.
+const MaxShadowDuration = 90 * 24 * time.Hour
+
+// A Shadow is a user that receives training notifications alongside the active
+// participant of a rotation until ExpiresAt. Shadows never acknowledge or affect
+// escalation of an alert.
+type Shadow struct {
+	RotationID string
+	UserID     string
+	ExpiresAt  time.Time
+}
+
+// Normalize will validate the Shadow, relative to the current time.
+func (s Shadow) Normalize(now time.Time) (*Shadow, error) {
+	err := validate.Many(
+		validate.UUID("RotationID", s.RotationID),
+		validate.UUID("UserID", s.UserID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !s.ExpiresAt.After(now) {
+		return nil, validation.NewFieldError("ExpiresAt", "must be in the future")
+	}
+	if s.ExpiresAt.Sub(now) > MaxShadowDuration {
+		return nil, validation.NewFieldError("ExpiresAt", "must be within 90 days")
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,28 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShadow_Normalize(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := Shadow{
+		RotationID: "a0000000-0000-0000-0000-000000000001",
+		UserID:     "b0000000-0000-0000-0000-000000000001",
+		ExpiresAt:  now.Add(time.Hour),
+	}
+
+	_, err := s.Normalize(now)
+	assert.NoError(t, err)
+
+	s.ExpiresAt = now
+	_, err = s.Normalize(now)
+	assert.Error(t, err, "expired")
+
+	s.ExpiresAt = now.Add(MaxShadowDuration + time.Second)
+	_, err = s.Normalize(now)
+	assert.Error(t, err, "too long")
+}
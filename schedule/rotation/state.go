@@ -23,3 +23,41 @@ func (s State) Normalize() (*State, error) {
 	}
 	return &s, nil
 }
+
+// PreservedPosition returns the position in newUserIDs of the user active at pos in
+// oldUserIDs, so the on-call user is unchanged when the participant list is edited.
+//
+// If a user is in the rotation more than once, the same occurrence is kept when possible.
+// False is returned if the active user is no longer a participant.
+func PreservedPosition(oldUserIDs []string, pos int, newUserIDs []string) (int, bool) {
+	if pos < 0 || pos >= len(oldUserIDs) {
+		return 0, false
+	}
+	activeID := oldUserIDs[pos]
+
+	var n int
+	for _, id := range oldUserIDs[:pos] {
+		if id == activeID {
+			n++
+		}
+	}
+
+	first := -1
+	for i, id := range newUserIDs {
+		if id != activeID {
+			continue
+		}
+		if n == 0 {
+			return i, true
+		}
+		if first == -1 {
+			first = i
+		}
+		n--
+	}
+	if first == -1 {
+		return 0, false
+	}
+
+	return first, true
+}
@@ -0,0 +1,30 @@
+package rotation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreservedPosition(t *testing.T) {
+	check := func(desc string, old []string, pos int, new []string, expPos int, expOK bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			newPos, ok := PreservedPosition(old, pos, new)
+			assert.Equal(t, expOK, ok, "found")
+			assert.Equal(t, expPos, newPos, "position")
+			if ok {
+				assert.Equal(t, old[pos], new[newPos], "on-call user")
+			}
+		})
+	}
+
+	check("add member mid-shift", []string{"a", "b", "c"}, 1, []string{"d", "a", "b", "c"}, 2, true)
+	check("append member", []string{"a", "b", "c"}, 2, []string{"a", "b", "c", "d"}, 2, true)
+	check("reorder", []string{"a", "b", "c"}, 0, []string{"c", "b", "a"}, 2, true)
+	check("remove other", []string{"a", "b", "c"}, 2, []string{"b", "c"}, 1, true)
+	check("remove active", []string{"a", "b", "c"}, 1, []string{"a", "c"}, 0, false)
+	check("duplicate occurrence", []string{"a", "b", "a"}, 2, []string{"d", "a", "b", "a"}, 3, true)
+	check("duplicate removed", []string{"a", "b", "a"}, 2, []string{"b", "a"}, 1, true)
+	check("invalid position", []string{"a"}, 1, []string{"a"}, 0, false)
+}
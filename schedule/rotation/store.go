@@ -3,7 +3,9 @@ package rotation
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -38,9 +40,14 @@ type Store struct {
 
 	deleteParticipants      *sql.Stmt
 	updateParticipantUserID *sql.Stmt
+	setParticipantWeights   *sql.Stmt
 	setActiveIndex          *sql.Stmt
 
 	findPartCount *sql.Stmt
+
+	setShadow      *sql.Stmt
+	deleteShadow   *sql.Stmt
+	findAllShadows *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -105,9 +112,9 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			RETURNING position
 		`),
 
-		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
+		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id, weight FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
 
-		findParticipant: p.P(`SELECT rotation_id, position, user_id FROM rotation_participants WHERE id = $1`),
+		findParticipant: p.P(`SELECT rotation_id, position, user_id, weight FROM rotation_participants WHERE id = $1`),
 
 		state: p.P(`
 			SELECT
@@ -126,7 +133,13 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		updateParticipantUserID: p.P(`
-			UPDATE rotation_participants SET user_id = $2 WHERE id = $1
+			UPDATE rotation_participants SET user_id = $2, weight = 1 WHERE id = $1
+		`),
+		setParticipantWeights: p.P(`
+			UPDATE rotation_participants part
+			SET weight = w.weight
+			FROM unnest($2::int[]) WITH ORDINALITY w(weight, idx)
+			WHERE part.rotation_id = $1 AND part.position = w.idx - 1
 		`),
 
 		setActiveIndex: p.P(`
@@ -135,6 +148,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			WHERE rotation_id = $1
 		`),
 		findPartCount: p.P(`SELECT participant_count FROM rotations WHERE id = $1`),
+
+		setShadow: p.P(`
+			INSERT INTO rotation_shadows (rotation_id, user_id, expires_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (rotation_id, user_id) DO UPDATE
+			SET expires_at = excluded.expires_at
+		`),
+		deleteShadow:   p.P(`DELETE FROM rotation_shadows WHERE rotation_id = $1 AND user_id = $2`),
+		findAllShadows: p.P(`SELECT user_id, expires_at FROM rotation_shadows WHERE rotation_id = $1 AND expires_at > now() ORDER BY expires_at`),
 	}, p.Err
 }
 
@@ -383,7 +405,7 @@ func (s *Store) FindAllParticipantsTx(ctx context.Context, tx *sql.Tx, rotationI
 	var userID sql.NullString
 	var res []Participant
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID)
+		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID, &p.Weight)
 		if err != nil {
 			return nil, err
 		}
@@ -471,7 +493,7 @@ func (s *Store) FindParticipant(ctx context.Context, id string) (*Participant, e
 	var p Participant
 	p.ID = id
 	var userID sql.NullString
-	err = row.Scan(&p.RotationID, &p.Position, &userID)
+	err = row.Scan(&p.RotationID, &p.Position, &userID, &p.Weight)
 	if userID.Valid {
 		p.Target = assignment.UserTarget(userID.String)
 	}
@@ -542,6 +564,40 @@ func (s *Store) UpdateParticipantUserIDTx(ctx context.Context, tx *sql.Tx, partI
 	})
 }
 
+// SetParticipantWeightsTx will set the weight of each participant of a rotation, in position order.
+// The number of weights must match the number of participants.
+func (s *Store) SetParticipantWeightsTx(ctx context.Context, tx *sql.Tx, rotationID string, weights []int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return err
+	}
+	for i, w := range weights {
+		err = validate.Range(fmt.Sprintf("Weights[%d]", i), w, 1, MaxParticipantWeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.withTxLock(ctx, tx, func(tx *sql.Tx) error {
+		var count int
+		err := tx.StmtContext(ctx, s.findPartCount).QueryRowContext(ctx, rotationID).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count != len(weights) {
+			return validation.NewFieldError("Weights", fmt.Sprintf("must have one weight for each of the %d participants", count))
+		}
+
+		_, err = tx.StmtContext(ctx, s.setParticipantWeights).ExecContext(ctx, rotationID, sqlutil.IntArray(weights))
+		return err
+	})
+}
+
 func (s *Store) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string) error {
 	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
 	if err != nil {
@@ -557,3 +613,78 @@ func (s *Store) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string
 		return err
 	})
 }
+
+// SetShadowTx will add a user as a shadow of a rotation, or update the expiration
+// if they are already shadowing it.
+func (s *Store) SetShadowTx(ctx context.Context, tx *sql.Tx, sh Shadow) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := sh.Normalize(time.Now())
+	if err != nil {
+		return err
+	}
+
+	stmt := s.setShadow
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.RotationID, n.UserID, n.ExpiresAt)
+	return err
+}
+
+// DeleteShadowTx will end a user's shadowing of a rotation.
+func (s *Store) DeleteShadowTx(ctx context.Context, tx *sql.Tx, rotationID, userID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("RotationID", rotationID),
+		validate.UUID("UserID", userID),
+	)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.deleteShadow
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, rotationID, userID)
+	return err
+}
+
+// FindAllShadows will return the unexpired shadows of a rotation, soonest to expire first.
+func (s *Store) FindAllShadows(ctx context.Context, rotationID string) ([]Shadow, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllShadows.QueryContext(ctx, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []Shadow
+	for rows.Next() {
+		sh := Shadow{RotationID: rotationID}
+		err = rows.Scan(&sh.UserID, &sh.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sh)
+	}
+
+	return res, rows.Err()
+}
@@ -17,6 +17,10 @@ const (
 	TypeWeekly  Type = "weekly"
 	TypeDaily   Type = "daily"
 	TypeHourly  Type = "hourly"
+
+	// TypeFixedHours rotations hand off every ShiftLength hours of elapsed time from
+	// Start, so shifts keep the same length across DST changes.
+	TypeFixedHours Type = "fixed_hours"
 )
 
 // Scan handles reading a Role from the DB format
@@ -36,7 +40,7 @@ func (r *Type) Scan(value interface{}) error {
 // Value converts the Role to the DB representation
 func (r Type) Value() (driver.Value, error) {
 	switch r {
-	case TypeMonthly, TypeWeekly, TypeDaily, TypeHourly:
+	case TypeMonthly, TypeWeekly, TypeDaily, TypeHourly, TypeFixedHours:
 		return string(r), nil
 	default:
 		return nil, fmt.Errorf("unknown rotation type specified '%s'", r)
@@ -58,6 +62,8 @@ func (t *Type) UnmarshalGQL(v interface{}) error {
 		*t = TypeDaily
 	case "hourly":
 		*t = TypeHourly
+	case "fixed_hours":
+		*t = TypeFixedHours
 	default:
 		return validation.NewFieldError("Type", "unknown rotation type "+str)
 	}
@@ -76,5 +82,7 @@ func (t Type) MarshalGQL(w io.Writer) {
 		graphql.MarshalString("hourly").MarshalGQL(w)
 	case TypeDaily:
 		graphql.MarshalString("daily").MarshalGQL(w)
+	case TypeFixedHours:
+		graphql.MarshalString("fixed_hours").MarshalGQL(w)
 	}
 }
@@ -11,6 +11,9 @@ import (
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxPriority is the highest (least preferred) priority a rule can have.
+const MaxPriority = 9
+
 type Rule struct {
 	ID         string `json:"id"`
 	ScheduleID string `json:"schedule_id"`
@@ -19,6 +22,11 @@ type Rule struct {
 	End       timeutil.Clock `json:"end"`
 	CreatedAt time.Time      `json:"created_at"`
 	Target    assignment.Target
+
+	// Priority orders the users on call for a schedule, with 0 being the highest priority
+	// (e.g., a primary rotation) and larger values used as backups. Escalation only notifies
+	// the highest priority users currently on call.
+	Priority int `json:"priority"`
 }
 
 func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
@@ -30,7 +38,10 @@ func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
 }
 
 func (r Rule) Normalize() (*Rule, error) {
-	err := validate.UUID("ScheduleID", r.ScheduleID)
+	err := validate.Many(
+		validate.UUID("ScheduleID", r.ScheduleID),
+		validate.Range("Priority", r.Priority, 0, MaxPriority),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +65,7 @@ func (r *Rule) scanFrom(s scanner) error {
 		&r.End,
 	}
 	var usr, rot sql.NullString
-	f = append(f, &usr, &rot)
+	f = append(f, &usr, &rot, &r.Priority)
 	err := s.Scan(f...)
 	if err != nil {
 		return err
@@ -87,7 +98,7 @@ func (r Rule) readFields() []interface{} {
 		rot.Valid = true
 		rot.String = r.Target.TargetID()
 	}
-	return append(f, usr, rot)
+	return append(f, usr, rot, r.Priority)
 }
 
 // StartTime will return the next time the rule would be active.
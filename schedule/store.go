@@ -30,6 +30,8 @@ type Store struct {
 
 	findMany *sql.Stmt
 
+	findHandoffNotice *sql.Stmt
+
 	usr *user.Store
 }
 
@@ -76,6 +78,8 @@ func NewStore(ctx context.Context, db *sql.DB, usr *user.Store) (*Store, error)
 		`),
 
 		delete: p.P(`DELETE FROM schedules WHERE id = any($1)`),
+
+		findHandoffNotice: p.P(`SELECT id, schedule_id, user_id, on_call, shift_time FROM schedule_handoff_notices WHERE id = $1`),
 	}, p.Err
 }
 func (store *Store) FindMany(ctx context.Context, ids []string) ([]Schedule, error) {
@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// FixedShiftsPerScheduleLimit is the maximum number of current and future fixed shifts that can be configured for a single schedule.
+const FixedShiftsPerScheduleLimit = 500
+
+// FixedShifts will return the fixed shifts for the provided scheduleID that overlap the start and end times.
+func (store *Store) FixedShifts(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, start, end time.Time) ([]FixedShift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := store.usr.UserExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []FixedShift
+	for _, shift := range data.V1.FixedShifts {
+		if !shift.End.After(start) || !shift.Start.Before(end) {
+			continue
+		}
+		// omit shifts for non-existent users
+		if !check.UserExistsString(shift.UserID) {
+			continue
+		}
+		result = append(result, shift)
+	}
+
+	return mergeShifts(result), nil
+}
+
+// SetFixedShifts will replace any fixed shifts between the start and end times with the provided
+// shifts. Existing shifts that extend past start or end are split, and times before now are left unchanged.
+//
+// Fixed shifts are on call in addition to rule-based shifts; overlapping shifts for the same user are merged.
+func (store *Store) SetFixedShifts(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, start, end time.Time, shifts []FixedShift) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	check, err := store.usr.UserExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	start = start.Truncate(time.Minute)
+	end = end.Truncate(time.Minute)
+	err = validate.Many(
+		validateFuture("End", end),
+		validateTimeRange("", start, end),
+	)
+	if err != nil {
+		return err
+	}
+	if len(shifts) > FixedShiftsPerScheduleLimit {
+		return validation.NewFieldError("Shifts", "too many shifts defined")
+	}
+
+	newShifts := make([]FixedShift, 0, len(shifts))
+	for i, s := range shifts {
+		prefix := fmt.Sprintf("Shifts[%d].", i)
+		s.Start = s.Start.Truncate(time.Minute)
+		s.End = s.End.Truncate(time.Minute)
+
+		err := validate.Many(
+			validate.UUID(prefix+"UserID", s.UserID),
+			validateTimeRange(prefix, s.Start, s.End),
+			validateWithinTimeRange(prefix, "", s.Start, s.End, start, end),
+		)
+		if err != nil {
+			return err
+		}
+		if !check.UserExistsString(s.UserID) {
+			return validation.NewFieldError(prefix+"UserID", "user does not exist")
+		}
+		newShifts = append(newShifts, s)
+	}
+
+	now := time.Now().Truncate(time.Minute)
+	if start.Before(now) {
+		start = now
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.FixedShifts = replaceFixedShifts(data.V1.FixedShifts, start, end, newShifts)
+
+		var count int
+		for _, s := range data.V1.FixedShifts {
+			if s.End.After(now) {
+				count++
+			}
+		}
+		if count > FixedShiftsPerScheduleLimit {
+			return validation.NewFieldError("Shifts", "too many shifts defined for schedule")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+// Misconfiguration describes why alerts of a service would not notify anyone.
+type Misconfiguration string
+
+const (
+	// MisconfigurationNoSteps means the escalation policy of the service has no steps.
+	MisconfigurationNoSteps Misconfiguration = "no_steps"
+
+	// MisconfigurationNoTargets means none of the steps of the escalation policy have targets.
+	MisconfigurationNoTargets Misconfiguration = "no_targets"
+
+	// MisconfigurationNoOnCall means the steps of the escalation policy have targets, but
+	// none currently resolve to an on-call user or notification channel.
+	MisconfigurationNoOnCall Misconfiguration = "no_on_call"
+)
+
+// A MisconfiguredService is a service whose alerts would not notify anyone.
+type MisconfiguredService struct {
+	ServiceID            string
+	ServiceName          string
+	EscalationPolicyID   string
+	EscalationPolicyName string
+	Reason               Misconfiguration
+}
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (m *Misconfiguration) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+
+	switch Misconfiguration(str) {
+	case MisconfigurationNoSteps, MisconfigurationNoTargets, MisconfigurationNoOnCall:
+		*m = Misconfiguration(str)
+		return nil
+	}
+
+	return validation.NewFieldError("Misconfiguration", "unknown misconfiguration "+str)
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (m Misconfiguration) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(m)).MarshalGQL(w)
+}
+
+// FindMisconfigured will return all services whose escalation policy has no steps, or whose
+// steps currently resolve to no one, ordered by service name. Steps with a routing webhook
+// are assumed to resolve to someone.
+func (s *Store) FindMisconfigured(ctx context.Context) ([]MisconfiguredService, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMisconfigured.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find misconfigured services: %w", err)
+	}
+	defer rows.Close()
+
+	var result []MisconfiguredService
+	for rows.Next() {
+		var svc MisconfiguredService
+		err = rows.Scan(&svc.ServiceID, &svc.ServiceName, &svc.EscalationPolicyID, &svc.EscalationPolicyName, &svc.Reason)
+		if err != nil {
+			return nil, fmt.Errorf("scan misconfigured service: %w", err)
+		}
+		result = append(result, svc)
+	}
+
+	return result, rows.Err()
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"time"
 
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -13,12 +15,44 @@ type Service struct {
 	EscalationPolicyID   string
 	MaintenanceExpiresAt time.Time
 
+	// NotificationTemplate, if set, is used to render the summary of alert notifications.
+	NotificationTemplate string
+
+	// RunbookURL, if set, is included with the service's alerts, unless the alert provides its own.
+	RunbookURL string
+
+	// EnrichmentURL, if set, is called when an alert is created for the service, and the
+	// returned key-value pairs are added to the alert's metadata.
+	EnrichmentURL string
+
+	// NotificationDelayMinutes is a grace period after an alert is created before its
+	// escalation policy starts. Alerts closed during this time will not notify anyone.
+	NotificationDelayMinutes int
+
+	// RequireCloseReason, if set, requires users to provide a reason when closing the
+	// service's alerts.
+	RequireCloseReason bool
+
+	// TimeZone, if set, is the IANA time zone used for timestamps in notifications about the
+	// service's alerts to users without a time zone of their own.
+	TimeZone string
+
+	// PausedAt is set while all alerting for the service is paused. New alerts are still
+	// created, but none are escalated or notified until the service is resumed.
+	PausedAt time.Time
+
+	// PausedByUserID is the user that paused the service, if any.
+	PausedByUserID string
+
 	epName         string
 	isUserFavorite bool
 }
 
 const MaxDetailsLength = 6 * 1024 // 6KiB
 
+// MaxNotificationDelayMinutes is the longest grace period allowed before alerts of a service notify.
+const MaxNotificationDelayMinutes = 60
+
 func (s Service) EscalationPolicyName() string {
 	return s.epName
 }
@@ -42,7 +76,20 @@ func (s Service) Normalize() (*Service, error) {
 		validate.Text("Description", s.Description, 1, MaxDetailsLength),
 		validate.UUID("EscalationPolicyID", s.EscalationPolicyID),
 		validate.Duration("MaintenanceExpiresAt", dur, 0, 24*time.Hour+5*time.Minute),
+		validateTemplate("NotificationTemplate", s.NotificationTemplate),
+		validate.Range("NotificationDelayMinutes", s.NotificationDelayMinutes, 0, MaxNotificationDelayMinutes),
 	)
+	if s.RunbookURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("RunbookURL", s.RunbookURL))
+	}
+	if s.EnrichmentURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("EnrichmentURL", s.EnrichmentURL))
+	}
+	if s.TimeZone != "" {
+		if _, tzErr := util.LoadLocation(s.TimeZone); tzErr != nil {
+			err = validate.Many(err, validation.NewFieldError("TimeZone", "unknown time zone"))
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
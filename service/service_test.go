@@ -23,9 +23,12 @@ func TestService_Normalize(t *testing.T) {
 
 	valid := []Service{
 		{Name: "Sample Service", Description: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374"},
+		{Name: "Delayed Service", Description: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", NotificationDelayMinutes: 5},
 	}
 	invalid := []Service{
 		{},
+		{Name: "Negative Delay", Description: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", NotificationDelayMinutes: -1},
+		{Name: "Long Delay", Description: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", NotificationDelayMinutes: MaxNotificationDelayMinutes + 1},
 	}
 	for _, s := range valid {
 		test(true, s)
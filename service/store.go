@@ -7,6 +7,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
@@ -22,6 +23,13 @@ type Store struct {
 	insert      *sql.Stmt
 	update      *sql.Stmt
 	delete      *sql.Stmt
+	setPaused   *sql.Stmt
+
+	setWatcher      *sql.Stmt
+	deleteWatcher   *sql.Stmt
+	findAllWatchers *sql.Stmt
+
+	findMisconfigured *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -37,7 +45,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			fav	is distinct from null,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.notification_template,
+			s.runbook_url,
+			s.enrichment_url,
+			s.notification_delay_minutes,
+			s.require_close_reason,
+			s.time_zone,
+			s.paused_at,
+			s.paused_by_user_id
 		FROM
 			services s
 		JOIN escalation_policies e ON e.id = s.escalation_policy_id
@@ -50,7 +66,13 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.id,
 			s.name,
 			s.description,
-			s.escalation_policy_id
+			s.escalation_policy_id,
+			s.notification_template,
+			s.runbook_url,
+			s.enrichment_url,
+			s.notification_delay_minutes,
+			s.require_close_reason,
+			s.time_zone
 		FROM services s
 		WHERE s.id = $1
 		FOR UPDATE
@@ -63,7 +85,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			fav	is distinct from null,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.notification_template,
+			s.runbook_url,
+			s.enrichment_url,
+			s.notification_delay_minutes,
+			s.require_close_reason,
+			s.time_zone,
+			s.paused_at,
+			s.paused_by_user_id
 		FROM
 			services s
 		JOIN escalation_policies e ON e.id = s.escalation_policy_id
@@ -80,7 +110,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			false,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.notification_template,
+			s.runbook_url,
+			s.enrichment_url,
+			s.notification_delay_minutes,
+			s.require_close_reason,
+			s.time_zone,
+			s.paused_at,
+			s.paused_by_user_id
 		FROM
 			services s,
 			escalation_policies e
@@ -88,9 +126,61 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			e.id = $1 AND
 			e.id = s.escalation_policy_id
 	`)
-	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id) VALUES ($1,$2,$3,$4)`)
-	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4, maintenance_expires_at = $5 WHERE id = $1`)
+	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id,notification_template,runbook_url,notification_delay_minutes,require_close_reason,enrichment_url,time_zone) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`)
+	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4, maintenance_expires_at = $5, notification_template = $6, runbook_url = $7, notification_delay_minutes = $8, require_close_reason = $9, enrichment_url = $10, time_zone = $11 WHERE id = $1`)
 	s.delete = p(`DELETE FROM services WHERE id = any($1)`)
+	s.setPaused = p(`
+		UPDATE services
+		SET
+			paused_at = CASE WHEN $2 THEN now() END,
+			paused_by_user_id = CASE WHEN $2 THEN $3::uuid END
+		WHERE id = $1 AND (paused_at NOTNULL) != $2
+	`)
+
+	s.setWatcher = p(`
+		INSERT INTO service_watchers (service_id, user_id, contact_method_id)
+		SELECT $1, cm.user_id, cm.id
+		FROM user_contact_methods cm
+		WHERE cm.id = $3 AND cm.user_id = $2 AND cm.type != 'VOICE'
+		ON CONFLICT (service_id, user_id) DO UPDATE
+		SET contact_method_id = excluded.contact_method_id
+	`)
+	s.deleteWatcher = p(`DELETE FROM service_watchers WHERE service_id = $1 AND user_id = $2`)
+	s.findAllWatchers = p(`SELECT user_id, contact_method_id, created_at FROM service_watchers WHERE service_id = $1 ORDER BY created_at`)
+
+	s.findMisconfigured = p(`
+		SELECT s.id, s.name, ep.id, ep.name, res.reason
+		FROM services s
+		JOIN escalation_policies ep ON ep.id = s.escalation_policy_id
+		CROSS JOIN LATERAL (
+			SELECT CASE
+				WHEN ep.step_count = 0 THEN 'no_steps'
+				WHEN NOT EXISTS (
+					SELECT NULL
+					FROM escalation_policy_steps step
+					WHERE
+						step.escalation_policy_id = ep.id AND
+						(
+							step.routing_webhook_url NOTNULL OR
+							EXISTS (SELECT NULL FROM escalation_policy_actions act WHERE act.escalation_policy_step_id = step.id)
+						)
+				) THEN 'no_targets'
+				WHEN NOT EXISTS (
+					SELECT NULL
+					FROM escalation_policy_steps step
+					WHERE
+						step.escalation_policy_id = ep.id AND
+						(
+							step.routing_webhook_url NOTNULL OR
+							EXISTS (SELECT NULL FROM escalation_policy_actions act WHERE act.escalation_policy_step_id = step.id AND act.channel_id NOTNULL) OR
+							EXISTS (SELECT NULL FROM ep_step_on_call_users oc WHERE oc.ep_step_id = step.id AND oc.end_time ISNULL)
+						)
+				) THEN 'no_on_call'
+			END reason
+		) res
+		WHERE res.reason NOTNULL
+		ORDER BY lower(s.name), s.id
+	`)
 
 	return s, prep.Err
 }
@@ -105,7 +195,7 @@ func (s *Store) FindOneForUpdate(ctx context.Context, tx *sql.Tx, id string) (*S
 		return nil, err
 	}
 	var svc Service
-	err = tx.StmtContext(ctx, s.findOneUp).QueryRowContext(ctx, id).Scan(&svc.ID, &svc.Name, &svc.Description, &svc.EscalationPolicyID)
+	err = tx.StmtContext(ctx, s.findOneUp).QueryRowContext(ctx, id).Scan(&svc.ID, &svc.Name, &svc.Description, &svc.EscalationPolicyID, &svc.NotificationTemplate, &svc.RunbookURL, &svc.EnrichmentURL, &svc.NotificationDelayMinutes, &svc.RequireCloseReason, &svc.TimeZone)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +240,7 @@ func (s *Store) CreateServiceTx(ctx context.Context, tx *sql.Tx, svc *Service) (
 	if tx != nil {
 		stmt = tx.Stmt(stmt)
 	}
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, n.NotificationTemplate, n.RunbookURL, n.NotificationDelayMinutes, n.RequireCloseReason, n.EnrichmentURL, n.TimeZone)
 	if err != nil {
 		return nil, err
 	}
@@ -203,10 +293,42 @@ func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, svc *Service) error {
 		Valid: !n.MaintenanceExpiresAt.IsZero(),
 	}
 
-	_, err = wrap(tx, s.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, mExp)
+	_, err = wrap(tx, s.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, mExp, n.NotificationTemplate, n.RunbookURL, n.NotificationDelayMinutes, n.RequireCloseReason, n.EnrichmentURL, n.TimeZone)
 	return err
 }
 
+// SetPausedTx will pause or resume all alerting for a service, recording the current user when
+// pausing. It returns false if the service was already in the requested state.
+func (s *Store) SetPausedTx(ctx context.Context, tx *sql.Tx, id string, paused bool) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return false, err
+	}
+
+	err = validate.UUID("ServiceID", id)
+	if err != nil {
+		return false, err
+	}
+
+	var userID sql.NullString
+	if uid := permission.UserID(ctx); uid != "" {
+		userID.Valid = true
+		userID.String = uid
+	}
+
+	res, err := wrap(tx, s.setPaused).ExecContext(ctx, id, paused, userID)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
 func (s *Store) FindOneForUser(ctx context.Context, userID, serviceID string) (*Service, error) {
 	err := validate.UUID("ServiceID", serviceID)
 	if err != nil {
@@ -247,12 +369,15 @@ func (s *Store) FindOne(ctx context.Context, id string) (*Service, error) {
 }
 
 func scanFrom(s *Service, f func(args ...interface{}) error) error {
-	var maintExpiresAt sql.NullTime
-	err := f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.epName, &s.isUserFavorite, &maintExpiresAt)
+	var maintExpiresAt, pausedAt sql.NullTime
+	var pausedBy sql.NullString
+	err := f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.epName, &s.isUserFavorite, &maintExpiresAt, &s.NotificationTemplate, &s.RunbookURL, &s.EnrichmentURL, &s.NotificationDelayMinutes, &s.RequireCloseReason, &s.TimeZone, &pausedAt, &pausedBy)
 	if err != nil {
 		return err
 	}
 	s.MaintenanceExpiresAt = maintExpiresAt.Time
+	s.PausedAt = pausedAt.Time
+	s.PausedByUserID = pausedBy.String
 	return nil
 }
 
@@ -281,3 +406,83 @@ func (s *Store) FindAllByEP(ctx context.Context, epID string) ([]Service, error)
 	defer rows.Close()
 	return scanAllFrom(rows)
 }
+
+// SetWatcherTx will add a user as a watcher of a service, or change the contact method
+// used if they are already watching it. Users can only manage their own watches, unless
+// they are an admin.
+func (s *Store) SetWatcherTx(ctx context.Context, tx *sql.Tx, w Watcher) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(w.UserID))
+	if err != nil {
+		return err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return err
+	}
+
+	res, err := wrap(tx, s.setWatcher).ExecContext(ctx, n.ServiceID, n.UserID, n.ContactMethodID)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		// voice calls are reserved for paging
+		return validation.NewFieldError("ContactMethodID", "must be one of the user's contact methods, and not a voice call")
+	}
+
+	return nil
+}
+
+// DeleteWatcherTx will stop a user from watching a service.
+func (s *Store) DeleteWatcherTx(ctx context.Context, tx *sql.Tx, serviceID, userID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("ServiceID", serviceID),
+		validate.UUID("UserID", userID),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = wrap(tx, s.deleteWatcher).ExecContext(ctx, serviceID, userID)
+	return err
+}
+
+// FindAllWatchers will return the watchers of a service, oldest first.
+func (s *Store) FindAllWatchers(ctx context.Context, serviceID string) ([]Watcher, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllWatchers.QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []Watcher
+	for rows.Next() {
+		w := Watcher{ServiceID: serviceID}
+		err = rows.Scan(&w.UserID, &w.ContactMethodID, &w.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, w)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxTemplateLength is the maximum length of a notification template.
+const MaxTemplateLength = 2048
+
+// DefaultNotificationTemplate is used when a service does not define a notification template.
+const DefaultNotificationTemplate = "{{.Summary}}"
+
+// TemplateData contains the alert fields available to a notification template.
+type TemplateData struct {
+	AlertID     int
+	Summary     string
+	Details     string
+	ServiceID   string
+	ServiceName string
+}
+
+var templateFields = map[string]bool{
+	"AlertID":     true,
+	"Summary":     true,
+	"Details":     true,
+	"ServiceID":   true,
+	"ServiceName": true,
+}
+
+// templateFuncs are the built-in template functions allowed in notification templates.
+var templateFuncs = map[string]bool{
+	"and":     true,
+	"or":      true,
+	"not":     true,
+	"eq":      true,
+	"ne":      true,
+	"len":     true,
+	"print":   true,
+	"printf":  true,
+	"println": true,
+}
+
+// RenderNotificationTemplate renders the summary used for alert notifications. If tmpl is
+// empty, DefaultNotificationTemplate is used.
+func RenderNotificationTemplate(tmpl string, data TemplateData) (string, error) {
+	t, err := parseTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	err = t.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func parseTemplate(tmpl string) (*template.Template, error) {
+	if tmpl == "" {
+		tmpl = DefaultNotificationTemplate
+	}
+
+	t, err := template.New("notification").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkTemplateNode(t.Tree.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// checkTemplateNode restricts templates to simple field references and conditionals, so
+// that a template that validates cannot fail or loop at send time.
+func checkTemplateNode(n parse.Node) error {
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			err := checkTemplateNode(c)
+			if err != nil {
+				return err
+			}
+		}
+	case *parse.TextNode, *parse.DotNode, *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode, *parse.VariableNode:
+	case *parse.ActionNode:
+		return checkTemplateNode(n.Pipe)
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Cmds {
+			err := checkTemplateNode(c)
+			if err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			err := checkTemplateNode(a)
+			if err != nil {
+				return err
+			}
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) != 1 || !templateFields[n.Ident[0]] {
+			return fmt.Errorf("unknown field '%s'", n.String())
+		}
+	case *parse.IdentifierNode:
+		if !templateFuncs[n.Ident] {
+			return fmt.Errorf("unknown function '%s'", n.Ident)
+		}
+	default:
+		return fmt.Errorf("unsupported template syntax '%s'", n.String())
+	}
+
+	return nil
+}
+
+func checkBranch(b *parse.BranchNode) error {
+	err := checkTemplateNode(b.Pipe)
+	if err != nil {
+		return err
+	}
+	err = checkTemplateNode(b.List)
+	if err != nil {
+		return err
+	}
+
+	return checkTemplateNode(b.ElseList)
+}
+
+// validateTemplate ensures the template parses, only references known fields, and
+// executes successfully against sample data.
+func validateTemplate(fname, tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+
+	err := validate.Text(fname, tmpl, 1, MaxTemplateLength)
+	if err != nil {
+		return err
+	}
+
+	_, err = RenderNotificationTemplate(tmpl, TemplateData{
+		AlertID:     1,
+		Summary:     "Example Summary",
+		Details:     "Example Details",
+		ServiceID:   "00000000-0000-0000-0000-000000000000",
+		ServiceName: "Example Service",
+	})
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	return nil
+}
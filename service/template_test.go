@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestRenderNotificationTemplate(t *testing.T) {
+	data := TemplateData{AlertID: 123, Summary: "CPU high", ServiceName: "Web"}
+
+	check := func(tmpl, expected string) {
+		t.Helper()
+		res, err := RenderNotificationTemplate(tmpl, data)
+		if err != nil {
+			t.Fatalf("render %q: %v", tmpl, err)
+		}
+		if res != expected {
+			t.Errorf("render %q: got %q; want %q", tmpl, res, expected)
+		}
+	}
+
+	check("", "CPU high")
+	check("[{{.ServiceName}}] #{{.AlertID}}: {{.Summary}}", "[Web] #123: CPU high")
+	check("{{if .Details}}{{.Details}}{{else}}no details{{end}}", "no details")
+}
+
+func TestValidateTemplate(t *testing.T) {
+	valid := []string{
+		"",
+		"{{.Summary}}",
+		`{{printf "%d" .AlertID}} {{with .Details}}{{.}}{{end}}`,
+	}
+	invalid := []string{
+		"{{.Summary",
+		"{{.Unknown}}",
+		"{{.Summary.Length}}",
+		"{{range .Summary}}{{end}}",
+		`{{define "x"}}{{end}}{{template "x"}}`,
+		"{{call .Summary}}",
+	}
+
+	for _, tmpl := range valid {
+		if err := validateTemplate("Template", tmpl); err != nil {
+			t.Errorf("validate %q: got %v; want nil", tmpl, err)
+		}
+	}
+	for _, tmpl := range invalid {
+		if err := validateTemplate("Template", tmpl); err == nil {
+			t.Errorf("validate %q: got nil; want error", tmpl)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// A Watcher is a user that receives informational notifications for new alerts of a
+// service without being part of its escalation policy. Watchers can't acknowledge or
+// close alerts through these notifications, and never affect escalation.
+type Watcher struct {
+	ServiceID       string
+	UserID          string
+	ContactMethodID string
+	CreatedAt       time.Time
+}
+
+// Normalize will validate the Watcher.
+func (w Watcher) Normalize() (*Watcher, error) {
+	err := validate.Many(
+		validate.UUID("ServiceID", w.ServiceID),
+		validate.UUID("UserID", w.UserID),
+		validate.UUID("ContactMethodID", w.ContactMethodID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/notification/email"
 )
 
 // Config is used to configure the SMTP server.
@@ -18,4 +19,8 @@ type Config struct {
 
 	AuthorizeFunc   func(ctx context.Context, id string) (context.Context, error)
 	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+
+	// ReplyFunc, if set, is called for replies to notification emails sent to the
+	// email.ReplyMailbox.
+	ReplyFunc func(ctx context.Context, r email.Reply) error
 }
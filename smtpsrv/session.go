@@ -11,9 +11,11 @@ import (
 	"github.com/emersion/go-smtp"
 	"github.com/mnako/letters"
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -24,6 +26,9 @@ type Session struct {
 	from    string
 	dedup   string
 	authCtx []context.Context
+
+	reply      bool
+	replyToken string
 }
 
 func (s *Session) isValidDomain(d string) bool {
@@ -89,6 +94,12 @@ func (s *Session) Rcpt(recipient string, opts *smtp.RcptOptions) error {
 			Message:      "Recipient domain not handled here",
 		}
 	}
+	if token, ok := email.ParseReplyMailbox(id); ok && s.cfg.ReplyFunc != nil {
+		s.reply = true
+		s.replyToken = token
+		return nil
+	}
+
 	id, s.dedup, _ = strings.Cut(id, "+")
 	err = validate.UUID("recipient", id)
 	if err != nil {
@@ -131,7 +142,7 @@ func (s *Session) Rcpt(recipient string, opts *smtp.RcptOptions) error {
 
 // Data is called when a new SMTP message is received.
 func (s *Session) Data(r io.Reader) error {
-	if len(s.authCtx) == 0 {
+	if len(s.authCtx) == 0 && !s.reply {
 		return &smtp.SMTPError{
 			Code:         503,
 			EnhancedCode: smtp.EnhancedCode{5, 5, 1},
@@ -139,7 +150,7 @@ func (s *Session) Data(r io.Reader) error {
 		}
 	}
 
-	email, err := letters.ParseEmail(r)
+	msg, err := letters.ParseEmail(r)
 	if err != nil {
 		return &smtp.SMTPError{
 			Code:         554,
@@ -147,9 +158,16 @@ func (s *Session) Data(r io.Reader) error {
 			Message:      "Malformed email message",
 		}
 	}
-	body := email.Text
+	body := msg.Text
+
+	if s.reply {
+		err = s.receiveReply(msg)
+		if err != nil {
+			return err
+		}
+	}
 
-	summary := validate.SanitizeText(email.Headers.Subject, alert.MaxSummaryLength)
+	summary := validate.SanitizeText(msg.Headers.Subject, alert.MaxSummaryLength)
 	details := fmt.Sprintf("From: %s\n\n%s", s.from, body)
 	details = validate.SanitizeText(details, alert.MaxDetailsLength)
 	var dedup *alert.DedupID
@@ -186,11 +204,47 @@ func (s *Session) Data(r io.Reader) error {
 	return nil
 }
 
+func (s *Session) receiveReply(msg letters.Email) error {
+	reply := email.Reply{
+		From:  s.from,
+		Token: s.replyToken,
+		Body:  msg.Text,
+	}
+	for _, id := range msg.Headers.InReplyTo {
+		reply.MessageIDs = append(reply.MessageIDs, string(id))
+	}
+	for _, id := range msg.Headers.References {
+		reply.MessageIDs = append(reply.MessageIDs, string(id))
+	}
+
+	ctx := s.cfg.BackgroundContext()
+	err := s.cfg.ReplyFunc(ctx, reply)
+	if validation.IsClientError(err) {
+		return &smtp.SMTPError{
+			Code:         550,
+			EnhancedCode: smtp.EnhancedCode{5, 7, 1},
+			Message:      err.Error(),
+		}
+	}
+	if err != nil {
+		log.Log(ctx, err)
+		return &smtp.SMTPError{
+			Code:         451,
+			EnhancedCode: smtp.EnhancedCode{4, 3, 0},
+			Message:      "Temporary local error, please try again",
+		}
+	}
+
+	return nil
+}
+
 // Reset resets the session state.
 func (s *Session) Reset() {
 	s.dedup = ""
 	s.from = ""
 	s.authCtx = nil
+	s.reply = false
+	s.replyToken = ""
 }
 
 // Logout is called when the client requests to log out.
@@ -9,8 +9,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
 )
 
 func TestSession_Auth(t *testing.T) {
@@ -98,3 +100,33 @@ func TestSession_Data(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, createdAlert, "CreateAlertFunc not called")
 }
+
+func TestSession_Reply(t *testing.T) {
+	var sess Session
+	sess.cfg.Domain = "localhost"
+	sess.cfg.BackgroundContext = func() context.Context { return log.WithLogger(context.Background(), log.NewLogger()) }
+
+	err := sess.Rcpt("reply+00000000-0000-0000-0000-000000000000@localhost", nil)
+	assert.ErrorContains(t, err, "recipient address", "replies are rejected without ReplyFunc")
+
+	var got email.Reply
+	sess.cfg.ReplyFunc = func(ctx context.Context, r email.Reply) error {
+		got = r
+		return nil
+	}
+	err = sess.Rcpt("reply+00000000-0000-0000-0000-000000000000@localhost", nil)
+	assert.NoError(t, err)
+	sess.from = "user@example.com"
+
+	err = sess.Data(strings.NewReader("Subject: Re: Alert #1\r\nIn-Reply-To: <11111111-1111-1111-1111-111111111111@localhost>\r\n\r\nack\r\n\r\n> Alert #1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", got.From)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", got.Token)
+	assert.Equal(t, []string{"11111111-1111-1111-1111-111111111111@localhost"}, got.MessageIDs)
+
+	sess.cfg.ReplyFunc = func(ctx context.Context, r email.Reply) error {
+		return validation.NewGenericError("bad reply")
+	}
+	err = sess.Data(strings.NewReader("Subject: Re: Alert #1\r\n\r\nok"))
+	assert.ErrorContains(t, err, "bad reply")
+}
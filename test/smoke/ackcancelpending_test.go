@@ -0,0 +1,74 @@
+package smoke
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestAckCancelPending checks that notifications queued before an alert was acknowledged
+// are not sent, for any step of the escalation policy.
+func TestAckCancelPending(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected webhook notification for acknowledged alert")
+	}))
+	defer ts.Close()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'bob@example.com'),
+		({{uuid "u2"}}, 'joe', 'joe@example.com');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "c1"}}, {{uuid "u1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "c2"}}, {{uuid "u2"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into notification_channels (id, type, name, value)
+	values
+		({{uuid "nc"}}, 'WEBHOOK', 'hook', '` + ts.URL + `');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id, step_number, delay)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 0, 30),
+		({{uuid "es2"}}, {{uuid "eid"}}, 1, 30);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id, channel_id)
+	values
+		({{uuid "es1"}}, {{uuid "u1"}}, null),
+		({{uuid "es2"}}, {{uuid "u2"}}, null),
+		({{uuid "es2"}}, null, {{uuid "nc"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary, status)
+	values
+		(1, {{uuid "sid"}}, 'acked', 'active'),
+		(2, {{uuid "sid"}}, 'unacked', 'triggered');
+
+	-- step 2 messages queued for alert 1 just before it was acknowledged
+	insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, channel_id)
+	values
+		('alert_notification', 1, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "u2"}}, {{uuid "c2"}}, null),
+		('alert_notification', 1, {{uuid "sid"}}, {{uuid "eid"}}, null, null, {{uuid "nc"}}),
+		('alert_notification', 2, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "u1"}}, {{uuid "c1"}}, null);
+`
+	h := harness.NewHarness(t, sql, "user-time-zone")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	d1 := tw.Device(h.Phone("1"))
+
+	// messages are processed together, so once the unacked alert is sent the
+	// queued messages for the acked alert have already been canceled
+	d1.ExpectSMS("unacked")
+	h.Trigger()
+}
@@ -0,0 +1,110 @@
+package smoke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestConferenceBridge checks that a step with a conference bridge starts a bridge for the
+// alert and invites the notified user by voice, records participants joining and leaving,
+// and ends the bridge once the alert is closed.
+func TestConferenceBridge(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "sms"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "voice"}}, {{uuid "user"}}, 'personal', 'VOICE', {{phone "1"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "sms"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id, conference_bridge)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, true);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	a := h.CreateAlert(h.UUID("sid"), "major incident")
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+	d.ExpectSMS("major incident")
+	d.ExpectVoice("conference bridge invitation", "major incident")
+
+	events := func() []string {
+		t.Helper()
+		h.Trigger()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{alert(id: %d){recentEvents(input: {}){nodes{message}}}}`, a.ID()))
+		require.Empty(t, resp.Errors)
+
+		var res struct {
+			Alert struct {
+				RecentEvents struct {
+					Nodes []struct{ Message string }
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+		var msgs []string
+		for _, n := range res.Alert.RecentEvents.Nodes {
+			msgs = append(msgs, n.Message)
+		}
+		return msgs
+	}
+	hasEvent := func(msgs []string, prefix string) bool {
+		for _, m := range msgs {
+			if strings.HasPrefix(m, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, hasEvent(events(), "Conference bridge started"), "bridge started")
+
+	permission.SudoContext(context.Background(), func(ctx context.Context) {
+		err := h.App().AlertStore.JoinConferenceBridge(ctx, a.ID(), "CA00000000000000000000000000000001", h.Phone("1"))
+		require.NoError(t, err)
+		err = h.App().AlertStore.JoinConferenceBridge(ctx, a.ID(), "CA00000000000000000000000000000002", h.Phone("2"))
+		assert.Error(t, err, "unknown number")
+		err = h.App().AlertStore.LeaveConferenceBridge(ctx, a.ID(), "CA00000000000000000000000000000001")
+		require.NoError(t, err)
+	})
+
+	msgs := events()
+	assert.True(t, hasEvent(msgs, "Joined conference bridge"), "joined")
+	assert.True(t, hasEvent(msgs, "Left conference bridge"), "left")
+	assert.False(t, hasEvent(msgs, "Conference bridge ended"), "not ended while open")
+
+	a.Close()
+	msgs = events()
+	assert.True(t, hasEvent(msgs, "Conference bridge ended"), "ended on close")
+
+	permission.SudoContext(context.Background(), func(ctx context.Context) {
+		err := h.App().AlertStore.JoinConferenceBridge(ctx, a.ID(), "CA00000000000000000000000000000003", h.Phone("1"))
+		assert.Error(t, err, "bridge ended")
+	})
+}
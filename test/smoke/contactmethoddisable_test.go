@@ -0,0 +1,123 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestContactMethodDisable checks that a contact method is disabled after the configured number
+// of failed messages, that the user is notified using another contact method, and that it is
+// re-enabled once the cooldown has passed.
+func TestContactMethodDisable(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user"}}, 'backup', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.SetConfigValue("General.ContactMethodFailureLimit", "2")
+	h.SetConfigValue("General.ContactMethodDisableMinutes", "10")
+
+	d1 := h.Twilio(t).Device(h.Phone("1"))
+	d2 := h.Twilio(t).Device(h.Phone("2"))
+
+	h.CreateAlert(h.UUID("sid"), "first")
+	d1.RejectSMS("first")
+	h.Trigger()
+
+	h.CreateAlert(h.UUID("sid"), "second")
+	d1.RejectSMS("second")
+	d2.ExpectSMS("personal", "disabled", "2 failed", "10 minutes")
+
+	// no notification while disabled
+	h.CreateAlert(h.UUID("sid"), "third")
+	h.Trigger()
+
+	h.FastForward(11 * time.Minute)
+	h.Trigger()
+
+	h.CreateAlert(h.UUID("sid"), "fourth")
+	d1.ExpectSMS("fourth")
+}
+
+// TestContactMethodDisableSkipped checks that messages failed by the engine itself, without
+// reaching a provider, are not counted against the contact method.
+func TestContactMethodDisableSkipped(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+	insert into user_do_not_disturb (user_id)
+	values
+		({{uuid "user"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into alerts (id, service_id, summary, status, dedup_key)
+	values
+		(1001, {{uuid "sid"}}, 'do not disturb', 'triggered', 'a'),
+		(1002, {{uuid "sid"}}, 'acked before send', 'active', 'b');
+
+	insert into outgoing_messages (id, message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id)
+	values
+		({{uuid "dnd"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "user"}}, {{uuid "cm"}}),
+		({{uuid "acked"}}, 'alert_notification', 1002, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "user"}}, {{uuid "cm"}});
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.SetConfigValue("General.ContactMethodFailureLimit", "1")
+	h.Trigger()
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{
+		userContactMethod(id: "%s"){consecutiveFailures, autoDisabledAt}
+		a: alert(id: 1001){notifications{status}}
+		b: alert(id: 1002){notifications{status}}
+	}`, h.UUID("cm")))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{
+		"userContactMethod": {"consecutiveFailures": 0, "autoDisabledAt": null},
+		"a": {"notifications": [{"status": "failed"}]},
+		"b": {"notifications": [{"status": "failed"}]}
+	}`, string(resp.Data))
+}
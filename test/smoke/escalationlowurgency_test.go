@@ -0,0 +1,56 @@
+package smoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestEscalationLowUrgency checks that a low-urgency step notifies users with their low-urgency
+// rules, even for a critical alert, and that later steps use the alert's usual urgency.
+func TestEscalationLowUrgency(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'high', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user"}}, 'low', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, urgency)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0, 'high'),
+		({{uuid "user"}}, {{uuid "cm2"}}, 0, 'low');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id, delay, low_urgency)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 30, true),
+		({{uuid "es2"}}, {{uuid "eid"}}, 60, false);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "user"}}),
+		({{uuid "es2"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary, severity)
+	values
+		({{uuid "sid"}}, 'testing', 'critical');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("2")).ExpectSMS("testing")
+
+	h.FastForward(30 * time.Minute)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+}
@@ -0,0 +1,85 @@
+package smoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestEscalationOffHoursDelay tests that steps use their off-hours delay outside of their delay
+// business hours, including on days excluded by the weekday filter.
+func TestEscalationOffHoursDelay(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user1"}}, 'bob', 'joe'),
+		({{uuid "user2"}}, 'ben', 'josh'),
+		({{uuid "user3"}}, 'beth', 'jane'),
+		({{uuid "user4"}}, 'bill', 'jill');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user2"}}, 'personal', 'SMS', {{phone "2"}}),
+		({{uuid "cm3"}}, {{uuid "user3"}}, 'personal', 'SMS', {{phone "3"}}),
+		({{uuid "cm4"}}, {{uuid "user4"}}, 'personal', 'SMS', {{phone "4"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user1"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user2"}}, {{uuid "cm2"}}, 0),
+		({{uuid "user3"}}, {{uuid "cm3"}}, 0),
+		({{uuid "user4"}}, {{uuid "cm4"}}, 0);
+
+	insert into business_hours (id, name, time_zone, weekday_filter, start_time, end_time)
+	values
+		({{uuid "bhWeekends"}}, 'weekends', 'UTC', '{t,f,f,f,f,f,t}', '09:00', '17:00'),
+		({{uuid "bhWeekdays"}}, 'weekdays', 'UTC', '{f,t,t,t,t,t,f}', '09:00', '17:00');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'off hours'),
+		({{uuid "ep2"}}, 'business hours');
+
+	insert into escalation_policy_steps (id, escalation_policy_id, step_number, delay, off_hours_delay, delay_business_hours_id)
+	values
+		({{uuid "ep1s1"}}, {{uuid "ep1"}}, 0, 60, 5, {{uuid "bhWeekends"}}),
+		({{uuid "ep1s2"}}, {{uuid "ep1"}}, 1, 60, null, null),
+		({{uuid "ep2s1"}}, {{uuid "ep2"}}, 0, 60, 5, {{uuid "bhWeekdays"}}),
+		({{uuid "ep2s2"}}, {{uuid "ep2"}}, 1, 60, null, null);
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "ep1s1"}}, {{uuid "user1"}}),
+		({{uuid "ep1s2"}}, {{uuid "user2"}}),
+		({{uuid "ep2s1"}}, {{uuid "user3"}}),
+		({{uuid "ep2s2"}}, {{uuid "user4"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid1"}}, {{uuid "ep1"}}, 'service1'),
+		({{uuid "sid2"}}, {{uuid "ep2"}}, 'service2');
+`
+	h := harness.NewStoppedHarness(t, sql, nil, "")
+	defer h.Close()
+
+	// noon on a Monday, so the weekday business hours are in effect
+	now := time.Now().UTC().AddDate(0, 0, 7)
+	now = time.Date(now.Year(), now.Month(), now.Day()-int(now.Weekday())+int(time.Monday), 12, 0, 0, 0, time.UTC)
+	h.SetTime(now)
+	h.Start()
+
+	h.CreateAlert(h.UUID("sid1"), "off-hours")
+	h.CreateAlert(h.UUID("sid2"), "in-hours")
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("off-hours")
+	h.Twilio(t).Device(h.Phone("3")).ExpectSMS("in-hours")
+
+	// only the step outside of its business hours escalates early
+	h.FastForward(5 * time.Minute)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("off-hours")
+	h.Trigger()
+}
@@ -0,0 +1,73 @@
+package smoke
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestEscalationRouting checks that a step with a routing webhook notifies the user returned
+// by the webhook instead of the targets of the step.
+func TestEscalationRouting(t *testing.T) {
+	t.Parallel()
+
+	routedID := uuid.NewString()
+	reqCh := make(chan string, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Type, Summary string }
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+		reqCh <- req.Type + ":" + req.Summary
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"Type": "user", "ID": routedID})
+	}))
+	defer ts.Close()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "static"}}, 'bob', 'joe'),
+		('` + routedID + `', 'ben', 'josh');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "static"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, '` + routedID + `', 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "static"}}, {{uuid "cm1"}}, 0),
+		('` + routedID + `', {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id, routing_webhook_url)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, '` + ts.URL + `');
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "static"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.CreateAlert(h.UUID("sid"), "routed")
+
+	assert.Equal(t, "RoutingRequest:routed", <-reqCh)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("routed")
+
+	h.Trigger()
+	assert.Empty(t, reqCh, "request processed once")
+}
@@ -112,4 +112,34 @@ func TestGenericAPIClose(t *testing.T) {
 
 	h.FastForward(30 * time.Minute)
 	// closed, no SMS
+
+	u = h.URL() + "/v1/api/alerts?key=" + key
+	resp, err = http.Post(u, "application/json", strings.NewReader(`{"summary": "resolved"}`))
+	if err != nil {
+		t.Fatal("post to generic endpoint failed:", err)
+	} else if resp.StatusCode/100 != 2 {
+		t.Error("non-2xx response:", resp.Status)
+	}
+	resp.Body.Close()
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("resolved")
+
+	resp, err = http.Post(u, "application/json", strings.NewReader(`{"summary": "resolved", "status": "resolved"}`))
+	if err != nil {
+		t.Fatal("post to generic endpoint failed:", err)
+	} else if resp.StatusCode/100 != 2 {
+		t.Error("non-2xx response:", resp.Status)
+	}
+	resp.Body.Close()
+
+	resp, err = http.Post(u, "application/json", strings.NewReader(`{"summary": "unknown", "status": "ok"}`))
+	if err != nil {
+		t.Fatal("post to generic endpoint failed:", err)
+	} else if resp.StatusCode != http.StatusBadRequest {
+		t.Error("expected 400 for unknown status:", resp.Status)
+	}
+	resp.Body.Close()
+
+	h.FastForward(30 * time.Minute)
+	// resolved, no SMS
 }
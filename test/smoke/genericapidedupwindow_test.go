@@ -0,0 +1,129 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGenericAPIDedupWindow checks that events for a key with a dedup window update an open
+// alert that occurred within the window, close it (logging why) and create a new alert when it
+// did not, and are de-duplicated against an alert closed within the window.
+func TestGenericAPIDedupWindow(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id, dedup_window_minutes)
+	values
+		({{uuid "key"}}, 'generic', 'my key', {{uuid "sid"}}, 5);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	fire := func(summary string, extra ...string) {
+		t.Helper()
+		v := make(url.Values)
+		v.Set("token", h.UUID("key"))
+		v.Set("summary", summary)
+		v.Set("dedup", "disk-check")
+		for i := 0; i+1 < len(extra); i += 2 {
+			v.Set(extra[i], extra[i+1])
+		}
+		resp, err := http.PostForm(h.URL()+"/api/v2/generic/incoming", v)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 2, resp.StatusCode/100, "status")
+	}
+	d := h.Twilio(t).Device(h.Phone("1"))
+
+	fire("first")
+	d.ExpectSMS("first")
+
+	// open, within the window
+	h.FastForward(time.Minute)
+	fire("first again")
+
+	// open, outside the window
+	h.FastForward(10 * time.Minute)
+	fire("second")
+	d.ExpectSMS("second")
+
+	// closedEvent returns the first event of the closed alert with the given summary that
+	// starts with prefix.
+	closedEvent := func(summary, prefix string) string {
+		t.Helper()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{alerts(input:{filterByStatus: [StatusClosed], filterByServiceID: ["%s"]}){nodes{summary, recentEvents(input: {}){nodes{message}}}}}`, h.UUID("sid")))
+		require.Empty(t, resp.Errors)
+		var res struct {
+			Alerts struct {
+				Nodes []struct {
+					Summary      string
+					RecentEvents struct {
+						Nodes []struct{ Message string }
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+		for _, a := range res.Alerts.Nodes {
+			if a.Summary != summary {
+				continue
+			}
+			for _, n := range a.RecentEvents.Nodes {
+				if strings.HasPrefix(n.Message, prefix) {
+					return n.Message
+				}
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "Closed due to inactivity (no events within the 5 minute dedup window)", closedEvent("first", "Closed"))
+
+	// closed, within the window
+	fire("second", "action", "close")
+	h.FastForward(time.Minute)
+	fire("second again")
+	assert.Contains(t, closedEvent("second", "Suppressed"), "Suppressed duplicate of closed alert (within dedup window)")
+
+	// closed, outside the window
+	h.FastForward(10 * time.Minute)
+	fire("third")
+	d.ExpectSMS("third")
+
+	// the window of the request takes precedence over the key's
+	h.FastForward(2 * time.Minute)
+	fire("fourth", "dedupWindow", "1m")
+	d.ExpectSMS("fourth")
+}
@@ -0,0 +1,51 @@
+package smoke
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGenericAPIDefaultLabels checks that the metadata limits apply to alert metadata merged
+// with the default labels of the integration key.
+func TestGenericAPIDefaultLabels(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id, default_labels)
+	values
+		({{uuid "key"}}, 'generic', 'my key', {{uuid "sid"}}, (select jsonb_object_agg('label' || i, 'value') from generate_series(1, 32) i));
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	fire := func(summary string, meta ...string) int {
+		t.Helper()
+		v := make(url.Values)
+		v.Set("token", h.UUID("key"))
+		v.Set("summary", summary)
+		for i := 0; i+1 < len(meta); i += 2 {
+			v.Set("meta."+meta[i], meta[i+1])
+		}
+		resp, err := http.PostForm(h.URL()+"/api/v2/generic/incoming", v)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, 2, fire("defaults only")/100, "default labels within limits")
+	assert.Equal(t, 2, fire("override", "label1", "custom")/100, "overriding a default label")
+	assert.Equal(t, http.StatusBadRequest, fire("too many", "extra", "1"), "merged labels exceed key limit")
+}
@@ -0,0 +1,118 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAlertNotes checks that notes can be added to an alert without changing its status, and
+// that only the author can edit or delete them within the edit window.
+func TestGraphQLAlertNotes(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "alice"}}, 'alice', 'alice@example.com'),
+		({{uuid "bob"}}, 'bob', 'bob@example.com');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary, dedup_key)
+	values
+		(1001, {{uuid "sid"}}, 'alert', 'a');
+	insert into alert_logs (alert_id, event, message, sub_type, sub_user_id, meta, timestamp)
+	values
+		(1001, 'note', '', 'user', {{uuid "alice"}}, '{"Note": "old note"}', now() - '1 hour'::interval);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	query := func(userID, q string) []string {
+		t.Helper()
+		var msgs []string
+		for _, e := range h.GraphQLQueryUserT(t, h.UUID(userID), q).Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+	type note struct {
+		ID      int
+		Message string
+		Note    *struct {
+			Text     string
+			Editable bool
+		}
+	}
+	notes := func(userID string) (string, []note) {
+		t.Helper()
+		resp := h.GraphQLQueryUserT(t, h.UUID(userID), `{alert(id: 1001){status, recentEvents{nodes{id, message, note{text, editable}}}}}`)
+		require.Empty(t, resp.Errors)
+
+		var res struct {
+			Alert struct {
+				Status       string
+				RecentEvents struct{ Nodes []note }
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+		var result []note
+		for _, n := range res.Alert.RecentEvents.Nodes {
+			if n.Note != nil {
+				result = append(result, n)
+			}
+		}
+		return res.Alert.Status, result
+	}
+
+	assert.Empty(t, query("alice", `mutation{addAlertNote(input:{alertID: 1001, note: "looking into it"})}`))
+	assert.NotEmpty(t, query("alice", `mutation{addAlertNote(input:{alertID: 1001, note: ""})}`), "empty note")
+	assert.NotEmpty(t, query("alice", `mutation{addAlertNote(input:{alertID: 9999, note: "missing"})}`), "missing alert")
+
+	status, n := notes("alice")
+	assert.Equal(t, "StatusUnacknowledged", status, "status unchanged")
+	require.Len(t, n, 2)
+	assert.Equal(t, "looking into it", n[0].Note.Text)
+	assert.Equal(t, "Note added by alice: looking into it", n[0].Message)
+	assert.True(t, n[0].Note.Editable)
+	assert.Equal(t, "old note", n[1].Note.Text)
+	assert.False(t, n[1].Note.Editable, "edit window passed")
+	id, oldID := n[0].ID, n[1].ID
+
+	_, n = notes("bob")
+	require.Len(t, n, 2)
+	assert.False(t, n[0].Note.Editable, "other user")
+
+	update := func(id int, text string) string {
+		return fmt.Sprintf(`mutation{updateAlertNote(input:{id: %d, note: "%s"})}`, id, text)
+	}
+	assert.NotEmpty(t, query("bob", update(id, "changed by bob")), "other user")
+	assert.NotEmpty(t, query("alice", update(oldID, "changed")), "edit window passed")
+	assert.Empty(t, query("alice", update(id, "fixed")))
+
+	_, n = notes("alice")
+	require.Len(t, n, 2)
+	assert.Equal(t, "fixed", n[0].Note.Text)
+	assert.Equal(t, "Note added by alice: fixed (edited)", n[0].Message)
+	assert.Equal(t, "old note", n[1].Note.Text)
+
+	remove := func(id int) string { return fmt.Sprintf(`mutation{deleteAlertNote(id: %d)}`, id) }
+	assert.NotEmpty(t, query("bob", remove(id)), "other user")
+	assert.NotEmpty(t, query("alice", remove(oldID)), "edit window passed")
+	assert.Empty(t, query("alice", remove(id)))
+
+	status, n = notes("alice")
+	assert.Equal(t, "StatusUnacknowledged", status, "status unchanged")
+	require.Len(t, n, 1)
+	assert.Equal(t, oldID, n[0].ID)
+}
@@ -0,0 +1,98 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAlertNotifications checks that Alert.notifications includes the delivery status of every
+// message for the alert, including bundled ones, and only shows contact method values to their owner.
+func TestGraphQLAlertNotifications(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "owner"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "other"}}, 'joe', 'joe@example.com', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value, disabled)
+	values
+		({{uuid "cm"}}, {{uuid "owner"}}, 'personal', 'SMS', {{phone "1"}}, true);
+	insert into notification_channels (id, type, name, value)
+	values
+		({{uuid "chan"}}, 'SLACK', '#test', {{slackChannelID "test"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into alerts (id, service_id, summary, status)
+	values
+		(1001, {{uuid "sid"}}, 'this alert', 'closed'),
+		(1002, {{uuid "sid"}}, 'other alert', 'closed');
+
+	insert into outgoing_messages (id, message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, channel_id, last_status, status_details, sent_at, provider_msg_id)
+	values
+		({{uuid "delivered"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "owner"}}, {{uuid "cm"}}, null, 'delivered', 'delivered', now(), 'Twilio-SMS:SM1'),
+		({{uuid "failed"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "owner"}}, {{uuid "cm"}}, null, 'failed', 'failed: [30003] unreachable', null, null),
+		({{uuid "bundled"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "owner"}}, {{uuid "cm"}}, null, 'bundled', '', null, null),
+		({{uuid "slack"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, null, null, {{uuid "chan"}}, 'sent', '', now(), null),
+		({{uuid "otherAlert"}}, 'alert_notification', 1002, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "owner"}}, {{uuid "cm"}}, null, 'delivered', '', now(), null);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	type notif struct {
+		ID, Status, Channel, Destination, StatusDetails string
+		UserName, ProviderID                            *string
+	}
+	notifications := func(resp *harness.QLResponse) map[string]notif {
+		t.Helper()
+		require.Empty(t, resp.Errors)
+		var res struct {
+			Alert struct{ Notifications []notif }
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+
+		m := make(map[string]notif)
+		for _, n := range res.Alert.Notifications {
+			m[n.ID] = n
+		}
+		return m
+	}
+	const query = `{alert(id: 1001){notifications{id, status, channel, destination, statusDetails, userName, providerID}}}`
+
+	n := notifications(h.GraphQLQueryUserT(t, h.UUID("owner"), query))
+	require.Len(t, n, 4, "messages of other alerts are excluded")
+
+	assert.Equal(t, "delivered", n[h.UUID("delivered")].Status)
+	assert.Equal(t, "SMS", n[h.UUID("delivered")].Channel)
+	assert.Contains(t, n[h.UUID("delivered")].Destination, "(SMS)", "owner sees the contact method value")
+	assert.Equal(t, "bob", *n[h.UUID("delivered")].UserName)
+	require.NotNil(t, n[h.UUID("delivered")].ProviderID)
+	assert.Equal(t, "SM1", *n[h.UUID("delivered")].ProviderID)
+
+	assert.Equal(t, "failed", n[h.UUID("failed")].Status)
+	assert.Equal(t, "failed: [30003] unreachable", n[h.UUID("failed")].StatusDetails)
+	assert.Nil(t, n[h.UUID("failed")].ProviderID)
+
+	assert.Equal(t, "bundled", n[h.UUID("bundled")].Status)
+
+	assert.Equal(t, "sent", n[h.UUID("slack")].Status)
+	assert.Equal(t, "Slack", n[h.UUID("slack")].Channel)
+	assert.Equal(t, "#test (Slack)", n[h.UUID("slack")].Destination)
+	assert.Nil(t, n[h.UUID("slack")].UserName)
+
+	n = notifications(h.GraphQLQueryUserT(t, h.UUID("other"), query))
+	assert.Equal(t, "SMS", n[h.UUID("delivered")].Destination, "other users only see the channel")
+	assert.Equal(t, "#test (Slack)", n[h.UUID("slack")].Destination)
+
+	n = notifications(h.GraphQLQuery2(query))
+	assert.Contains(t, n[h.UUID("delivered")].Destination, "(SMS)", "admins see the contact method value")
+}
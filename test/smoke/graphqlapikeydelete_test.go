@@ -0,0 +1,41 @@
+package smoke
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAPIKeyDelete ensures a deleted GraphQL API key stops authorizing immediately.
+func TestGraphQLAPIKeyDelete(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, "", "")
+	defer h.Close()
+
+	ctx := permission.SystemContext(h.App().Context(context.Background()), "Test")
+	store := h.App().APIKeyStore
+
+	id, tok, err := store.CreateAdminGraphQLKey(ctx, nil, apikey.NewAdminGQLKeyOpts{
+		Name:    "test key",
+		Fields:  []string{"Query.user"},
+		Expires: time.Now().Add(time.Hour),
+		Role:    permission.RoleUser,
+	})
+	require.NoError(t, err)
+
+	_, err = store.AuthorizeGraphQL(ctx, tok, "smoketest", "127.0.0.1:1234")
+	require.NoError(t, err, "new key should authorize")
+
+	err = store.DeleteAdminGraphQLKey(ctx, nil, id)
+	require.NoError(t, err)
+
+	_, err = store.AuthorizeGraphQL(ctx, tok, "smoketest", "127.0.0.1:1234")
+	assert.True(t, permission.IsUnauthorized(err), "deleted key should be unauthorized; got %v", err)
+}
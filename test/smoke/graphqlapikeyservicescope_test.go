@@ -0,0 +1,149 @@
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/expflag"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAPIKeyServiceScope checks that an API key restricted to a service can't act on other services,
+// alerts, or schedules, and that lists only include what the key may access.
+func TestGraphQLAPIKeyServiceScope(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched1"}}, 'allowed', 'UTC'),
+		({{uuid "sched2"}}, 'not allowed', 'UTC');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'ep1'),
+		({{uuid "ep2"}}, 'ep2');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "step1"}}, {{uuid "ep1"}}),
+		({{uuid "step2"}}, {{uuid "ep2"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "step1"}}, {{uuid "sched1"}}),
+		({{uuid "step2"}}, {{uuid "sched2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid1"}}, {{uuid "ep1"}}, 'allowed'),
+		({{uuid "sid2"}}, {{uuid "ep2"}}, 'not allowed');
+
+	insert into alerts (id, service_id, summary, dedup_key)
+	values
+		(1001, {{uuid "sid1"}}, 'allowed', 'a'),
+		(1002, {{uuid "sid2"}}, 'not allowed', 'b');
+`
+	h := harness.NewHarnessWithFlags(t, sql, "", expflag.FlagSet{expflag.GQLAPIKey})
+	defer h.Close()
+
+	createAlert := func(svcID string) string {
+		return fmt.Sprintf(`mutation{createAlert(input:{serviceID: "%s", summary: "new"}){id}}`, svcID)
+	}
+	ackAlert := func(id int) string {
+		return fmt.Sprintf(`mutation{updateAlerts(input:{alertIDs: [%d], newStatus: StatusAcknowledged}){id}}`, id)
+	}
+	updateSchedule := func(id string) string {
+		return fmt.Sprintf(`mutation{updateSchedule(input:{id: "%s", description: "changed"})}`, id)
+	}
+	const (
+		listAlerts    = `{alerts{nodes{id}}}`
+		listServices  = `{services{nodes{id}}}`
+		listSchedules = `{schedules{nodes{id}}}`
+		createService = `mutation{createService(input:{name: "new"}){id}}`
+	)
+	querySchedule := func(id string) string {
+		return fmt.Sprintf(`{schedule(id: "%s"){id}}`, id)
+	}
+
+	var fields []string
+	for _, q := range []string{createAlert(""), ackAlert(0), updateSchedule(""), listAlerts, listServices, listSchedules, createService, querySchedule("")} {
+		f, err := graphql2.QueryFields(q)
+		require.NoError(t, err)
+		fields = append(fields, f...)
+	}
+	slices.Sort(fields)
+	fields = slices.Compact(fields)
+
+	ctx := permission.SystemContext(h.App().Context(context.Background()), "Test")
+	_, tok, err := h.App().APIKeyStore.CreateAdminGraphQLKey(ctx, nil, apikey.NewAdminGQLKeyOpts{
+		Name:       "scoped",
+		Fields:     fields,
+		Expires:    time.Now().Add(time.Hour),
+		Role:       permission.RoleUser,
+		ServiceIDs: []uuid.UUID{uuid.MustParse(h.UUID("sid1"))},
+	})
+	require.NoError(t, err)
+
+	query := func(q string) *harness.QLResponse {
+		t.Helper()
+		data, err := json.Marshal(struct{ Query string }{Query: q})
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", h.URL()+"/api/graphql", bytes.NewReader(data))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tok)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var res harness.QLResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		return &res
+	}
+
+	// mutations are checked before anything is changed
+	assert.Empty(t, query(createAlert(h.UUID("sid1"))).Errors, "alert for allowed service")
+	assert.NotEmpty(t, query(createAlert(h.UUID("sid2"))).Errors, "alert for other service")
+	assert.Empty(t, query(ackAlert(1001)).Errors, "ack alert of allowed service")
+	assert.NotEmpty(t, query(ackAlert(1002)).Errors, "ack alert of other service")
+	assert.Empty(t, query(updateSchedule(h.UUID("sched1"))).Errors, "schedule of allowed service")
+	assert.NotEmpty(t, query(updateSchedule(h.UUID("sched2"))).Errors, "schedule of other service")
+	assert.NotEmpty(t, query(createService).Errors, "mutation without a service")
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{
+		alerts(input:{filterByServiceID: ["%s"]}){nodes{id, status}}
+		schedule(id: "%s"){description}
+	}`, h.UUID("sid2"), h.UUID("sched2")))
+	require.Empty(t, resp.Errors)
+	assert.Contains(t, string(resp.Data), `"nodes":[{"id":"1002","status":"StatusUnacknowledged"}]`, "other service unchanged")
+	assert.Contains(t, string(resp.Data), `"description":""`, "other schedule unchanged")
+
+	// lists are filtered instead of failing
+	resp = query(listAlerts)
+	require.Empty(t, resp.Errors)
+	assert.NotContains(t, string(resp.Data), `"id":"1002"`)
+	assert.Contains(t, string(resp.Data), `"id":"1001"`)
+
+	resp = query(listServices)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"services":{"nodes":[{"id":"%s"}]}}`, h.UUID("sid1")), string(resp.Data))
+
+	resp = query(listSchedules)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"schedules":{"nodes":[{"id":"%s"}]}}`, h.UUID("sched1")), string(resp.Data))
+
+	assert.Empty(t, query(querySchedule(h.UUID("sched1"))).Errors)
+	assert.NotEmpty(t, query(querySchedule(h.UUID("sched2"))).Errors)
+}
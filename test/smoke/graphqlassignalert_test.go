@@ -0,0 +1,79 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAssignAlert checks that assigning an unacknowledged alert notifies the assignee
+// and is reported by the alert, and that acknowledged or closed alerts can't be assigned.
+func TestGraphQLAssignAlert(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "oncall"}}, 'bob', 'joe'),
+		({{uuid "assignee"}}, 'ben', 'josh');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "oncall"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "assignee"}}, 'personal', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "oncall"}}, {{uuid "cm1"}}, 0),
+		({{uuid "assignee"}}, {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "oncall"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	assign := func(id int) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{assignAlert(input:{alertID: %d, userID: "%s"})}`, id, h.UUID("assignee")))
+	}
+
+	a := h.CreateAlert(h.UUID("sid"), "assigned")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("assigned")
+
+	resp := assign(a.ID())
+	require.Empty(t, resp.Errors)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("assigned")
+
+	resp = h.GraphQLQuery2(fmt.Sprintf(`{alert(id: %d){assignee{id}}}`, a.ID()))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"alert":{"assignee":{"id":"%s"}}}`, h.UUID("assignee")), string(resp.Data))
+
+	acked := h.CreateAlert(h.UUID("sid"), "acked")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("acked")
+	acked.Ack()
+	resp = assign(acked.ID())
+	assert.NotEmpty(t, resp.Errors, "acknowledged")
+
+	closed := h.CreateAlert(h.UUID("sid"), "closed")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("closed")
+	closed.Close()
+	resp = assign(closed.ID())
+	assert.NotEmpty(t, resp.Errors, "closed")
+
+	resp = h.GraphQLQuery2(fmt.Sprintf(`{alert(id: %d){assignee{id}}}`, acked.ID()))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"alert":{"assignee":null}}`, string(resp.Data))
+}
@@ -0,0 +1,149 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/expflag"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAuditAPIKeys checks that GraphQL API key mutations are recorded in the audit log,
+// including failed attempts, and that tokens are never part of a snapshot.
+func TestGraphQLAuditAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarnessWithFlags(t, "", "", expflag.FlagSet{expflag.GQLAPIKey})
+	defer h.Close()
+
+	query := func(q string, res interface{}) {
+		t.Helper()
+		resp := h.GraphQLQuery2(q)
+		require.Empty(t, resp.Errors)
+		if res != nil {
+			require.NoError(t, json.Unmarshal(resp.Data, res))
+		}
+	}
+
+	var created struct {
+		CreateGQLAPIKey struct{ ID, Token string }
+	}
+	query(fmt.Sprintf(`mutation{createGQLAPIKey(input:{
+		name: "audited", description: "before", allowedFields: ["Query.user"], expiresAt: "%s", role: user
+	}){id, token}}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339)), &created)
+	id := created.CreateGQLAPIKey.ID
+	require.NotEmpty(t, id)
+
+	var rotated struct {
+		RotateGQLAPIKey struct{ Token string }
+	}
+	query(fmt.Sprintf(`mutation{updateGQLAPIKey(input:{id: "%s", description: "after"})}`, id), nil)
+	query(fmt.Sprintf(`mutation{rotateGQLAPIKey(id: "%s"){token}}`, id), &rotated)
+	query(fmt.Sprintf(`mutation{deleteGQLAPIKey(id: "%s")}`, id), nil)
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`mutation{deleteGQLAPIKey(id: "%s")}`, id))
+	require.NotEmpty(t, resp.Errors, "already deleted")
+
+	var logs struct {
+		AuditLogs struct {
+			Nodes []struct {
+				Action, EntityID, Before, After, Error string
+			}
+		}
+	}
+	query(fmt.Sprintf(`{auditLogs(input:{filterByEntityType: "gqlAPIKey", filterByEntityID: "%s"}){
+		nodes{action, entityID, before, after, error}
+	}}`, id), &logs)
+
+	// newest first
+	nodes := logs.AuditLogs.Nodes
+	require.Len(t, nodes, 5)
+	var actions []string
+	for _, n := range nodes {
+		actions = append(actions, n.Action)
+		assert.Equal(t, id, n.EntityID)
+		assert.NotContains(t, n.Before+n.After, created.CreateGQLAPIKey.Token, "token in snapshot")
+		assert.NotContains(t, n.Before+n.After, rotated.RotateGQLAPIKey.Token, "token in snapshot")
+	}
+	assert.Equal(t, []string{"deleteGQLAPIKey", "deleteGQLAPIKey", "rotateGQLAPIKey", "updateGQLAPIKey", "createGQLAPIKey"}, actions)
+
+	assert.NotEmpty(t, nodes[0].Error, "failed delete")
+	assert.Empty(t, nodes[1].Error)
+	assert.Contains(t, nodes[1].Before, `"after"`)
+	assert.Contains(t, nodes[3].Before, `"before"`)
+	assert.Contains(t, nodes[3].After, `"after"`)
+	assert.Contains(t, nodes[4].After, "Query.user")
+}
+
+// TestGraphQLAuditAdminMutations checks that the other admin-only mutations are recorded in the audit log.
+func TestGraphQLAuditAdminMutations(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'bob@example.com', 'user');
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into alerts (service_id, summary, dedup_key)
+	values
+		({{uuid "sid"}}, 'first', 'a'),
+		({{uuid "sid"}}, 'second', 'b');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	query := func(q string, res interface{}) {
+		t.Helper()
+		resp := h.GraphQLQuery2(q)
+		require.Empty(t, resp.Errors)
+		if res != nil {
+			require.NoError(t, json.Unmarshal(resp.Data, res))
+		}
+	}
+
+	type logs struct {
+		AuditLogs struct {
+			Nodes []struct{ Action, Before, After string }
+		}
+	}
+	auditLogs := func(entityType, id string) logs {
+		t.Helper()
+		var res logs
+		query(fmt.Sprintf(`{auditLogs(input:{filterByEntityType: "%s", filterByEntityID: "%s"}){nodes{action, before, after}}}`, entityType, id), &res)
+		return res
+	}
+
+	query(fmt.Sprintf(`mutation{setUserLabelGrants(input:{userID: "%s", grants: [{key: "example/team", value: "payments"}]})}`, h.UUID("user")), nil)
+	l := auditLogs("user", h.UUID("user"))
+	require.Len(t, l.AuditLogs.Nodes, 1)
+	assert.Equal(t, "setUserLabelGrants", l.AuditLogs.Nodes[0].Action)
+	assert.Contains(t, l.AuditLogs.Nodes[0].After, "payments")
+
+	var rule struct {
+		CreateIncidentCorrelationRule struct{ ID string }
+	}
+	query(`mutation{createIncidentCorrelationRule(input:{name: "by region", key: "region", windowMinutes: 10}){id}}`, &rule)
+	ruleID := rule.CreateIncidentCorrelationRule.ID
+	query(fmt.Sprintf(`mutation{deleteIncidentCorrelationRule(id: "%s")}`, ruleID), nil)
+	l = auditLogs("incidentCorrelationRule", ruleID)
+	require.Len(t, l.AuditLogs.Nodes, 2)
+	assert.Equal(t, "deleteIncidentCorrelationRule", l.AuditLogs.Nodes[0].Action)
+	assert.Contains(t, l.AuditLogs.Nodes[0].Before, "by region")
+	assert.Equal(t, "createIncidentCorrelationRule", l.AuditLogs.Nodes[1].Action)
+	assert.Contains(t, l.AuditLogs.Nodes[1].After, "by region")
+
+	query(fmt.Sprintf(`mutation{closeAllAlertsByService(input:{serviceID: "%s", confirm: "service"})}`, h.UUID("sid")), nil)
+	l = auditLogs("service", h.UUID("sid"))
+	require.Len(t, l.AuditLogs.Nodes, 1)
+	assert.Equal(t, "closeAllAlertsByService", l.AuditLogs.Nodes[0].Action)
+	assert.Contains(t, l.AuditLogs.Nodes[0].After, `"ClosedAlerts":2`)
+}
@@ -0,0 +1,63 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLAuditConfig checks that config and system limit changes are recorded in the
+// audit log, and that the values of password fields are never part of a snapshot.
+func TestGraphQLAuditConfig(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, "", "")
+	defer h.Close()
+
+	query := func(q string, res interface{}) {
+		t.Helper()
+		resp := h.GraphQLQuery2(q)
+		require.Empty(t, resp.Errors)
+		if res != nil {
+			require.NoError(t, json.Unmarshal(resp.Data, res))
+		}
+	}
+	type logs struct {
+		AuditLogs struct {
+			Nodes []struct{ Action, EntityID, Before, After string }
+		}
+	}
+
+	query(`mutation{setConfig(input: [
+		{id: "General.ApplicationName", value: "Audited"},
+		{id: "SMTP.Password", value: "hunter2"}
+	])}`, nil)
+
+	var cfgLogs logs
+	query(`{auditLogs(input:{filterByEntityType: "config"}){nodes{action, entityID, before, after}}}`, &cfgLogs)
+	byID := make(map[string]string)
+	for _, n := range cfgLogs.AuditLogs.Nodes {
+		assert.Equal(t, "setConfig", n.Action)
+		assert.NotContains(t, n.Before+n.After, "hunter2", "password in snapshot")
+		byID[n.EntityID] = n.After
+	}
+	assert.JSONEq(t, `{"value":"Audited"}`, byID["General.ApplicationName"])
+	assert.Contains(t, byID, "SMTP.Password", "password change recorded")
+
+	resp := h.GraphQLQuery2(`{config(all: true){id, value}}`)
+	require.Empty(t, resp.Errors)
+	assert.Contains(t, string(resp.Data), "Audited", "config applied")
+
+	query(`mutation{setSystemLimits(input: [{id: "ContactMethodsPerUser", value: 5}])}`, nil)
+
+	var limitLogs logs
+	query(`{auditLogs(input:{filterByEntityType: "systemLimit"}){nodes{action, entityID, before, after}}}`, &limitLogs)
+	require.Len(t, limitLogs.AuditLogs.Nodes, 1)
+	n := limitLogs.AuditLogs.Nodes[0]
+	assert.Equal(t, "setSystemLimits", n.Action)
+	assert.Equal(t, "contact_methods_per_user", n.EntityID)
+	assert.JSONEq(t, `{"value":5}`, n.After)
+}
@@ -0,0 +1,87 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLCloseAllAlerts checks that closing all alerts of a service requires an admin, a
+// confirmation matching the service name, and a reason if the service requires one, and that
+// each open alert is closed and logged with the reason.
+func TestGraphQLCloseAllAlerts(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name, require_close_reason)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'my service', true),
+		({{uuid "other"}}, {{uuid "eid"}}, 'other service', false);
+
+	insert into alerts (service_id, summary, status)
+	values
+		({{uuid "sid"}}, 'first', 'triggered'),
+		({{uuid "sid"}}, 'second', 'active'),
+		({{uuid "sid"}}, 'already closed', 'closed'),
+		({{uuid "other"}}, 'other', 'triggered');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	closeAll := func(userID, confirm, reason string) *harness.QLResponse {
+		t.Helper()
+		reasonArg := ""
+		if reason != "" {
+			reasonArg = ", closeReason: " + reason
+		}
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{closeAllAlertsByService(input:{serviceID: "%s", confirm: "%s"%s})}`, h.UUID("sid"), confirm, reasonArg))
+	}
+
+	assert.NotEmpty(t, closeAll(h.UUID("user"), "my service", "resolved").Errors, "non-admin")
+	assert.NotEmpty(t, closeAll(harness.DefaultGraphQLAdminUserID, "other service", "resolved").Errors, "confirm mismatch")
+	assert.NotEmpty(t, closeAll(harness.DefaultGraphQLAdminUserID, "my service", "").Errors, "reason required")
+
+	resp := closeAll(harness.DefaultGraphQLAdminUserID, "my service", "resolved")
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"closeAllAlertsByService":2}`, string(resp.Data))
+
+	resp = h.GraphQLQuery2(`{alerts(input:{filterByStatus: [StatusClosed]}){nodes{summary, recentEvents(input: {}){nodes{message}}}}}`)
+	require.Empty(t, resp.Errors)
+	var res struct {
+		Alerts struct {
+			Nodes []struct {
+				Summary      string
+				RecentEvents struct {
+					Nodes []struct{ Message string }
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &res))
+	closed := make(map[string]bool)
+	for _, a := range res.Alerts.Nodes {
+		for _, e := range a.RecentEvents.Nodes {
+			if strings.HasPrefix(e.Message, "Closed as resolved") {
+				closed[a.Summary] = true
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{"first": true, "second": true}, closed, "logged close entries")
+
+	resp = h.GraphQLQuery2(`{alerts(input:{filterByStatus: [StatusUnacknowledged]}){nodes{summary}}}`)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"alerts":{"nodes":[{"summary":"other"}]}}`, string(resp.Data), "other services unaffected")
+}
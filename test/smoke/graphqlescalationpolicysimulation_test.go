@@ -0,0 +1,53 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLEscalationPolicySimulationWeights checks that simulating a policy expands a
+// rotation using participant weights, so a participant with a weight of 2 stays on call for
+// two shifts.
+func TestGraphQLEscalationPolicySimulationWeights(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "alice"}}, 'alice', 'alice@example.com'),
+		({{uuid "bob"}}, 'bob', 'bob@example.com');
+
+	insert into rotations (id, name, type, shift_length, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rotation', 'hourly', 1, now(), 'UTC');
+	insert into rotation_participants (rotation_id, user_id, position, weight)
+	values
+		({{uuid "rot"}}, {{uuid "alice"}}, 0, 2),
+		({{uuid "rot"}}, {{uuid "bob"}}, 1, 1);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, rotation_id)
+	values
+		({{uuid "esid"}}, {{uuid "rot"}});
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.Trigger()
+
+	// second shift of the rotation, still alice's turn
+	at := time.Now().Add(90 * time.Minute).UTC().Format(time.RFC3339)
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{escalationPolicySimulation(input:{policyID: "%s", triggerTime: "%s"}){targets{target{id}}}}`, h.UUID("eid"), at))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"escalationPolicySimulation":[{"targets":[{"target":{"id":"%s"}}]}]}`, h.UUID("alice")), string(resp.Data))
+}
@@ -0,0 +1,77 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLNotificationRuleOrder checks that a user's notification rules can be reordered,
+// and that the new order must list each of the user's rules exactly once.
+func TestGraphQLNotificationRuleOrder(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe'),
+		({{uuid "other"}}, 'ben', 'josh');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "other"}}, 'personal', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (id, user_id, contact_method_id, delay_minutes, position)
+	values
+		({{uuid "r1"}}, {{uuid "user"}}, {{uuid "cm1"}}, 0, 0),
+		({{uuid "r2"}}, {{uuid "user"}}, {{uuid "cm1"}}, 5, 1),
+		({{uuid "r3"}}, {{uuid "user"}}, {{uuid "cm1"}}, 10, 2),
+		({{uuid "o1"}}, {{uuid "other"}}, {{uuid "cm2"}}, 0, 0);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	setOrder := func(names ...string) *harness.QLResponse {
+		t.Helper()
+		ids := make([]string, len(names))
+		for i, n := range names {
+			ids[i] = `"` + h.UUID(n) + `"`
+		}
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{setUserNotificationRuleOrder(input:{userID: "%s", ruleIDs: [%s]})}`, h.UUID("user"), strings.Join(ids, ",")))
+	}
+	positions := func() map[string]int {
+		t.Helper()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{user(id: "%s"){notificationRules{id, position}}}`, h.UUID("user")))
+		require.Empty(t, resp.Errors)
+		var res struct {
+			User struct {
+				NotificationRules []struct {
+					ID       string
+					Position int
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+		pos := make(map[string]int)
+		for _, r := range res.User.NotificationRules {
+			pos[r.ID] = r.Position
+		}
+		return pos
+	}
+
+	resp := setOrder("r3", "r1", "r2")
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]int{h.UUID("r3"): 0, h.UUID("r1"): 1, h.UUID("r2"): 2}, positions())
+
+	assert.NotEmpty(t, setOrder("r1", "r2").Errors, "missing rule")
+	assert.NotEmpty(t, setOrder("r1", "r2", "r2").Errors, "duplicate rule")
+	assert.NotEmpty(t, setOrder("r1", "r2", "r3", "o1").Errors, "another user's rule")
+	assert.NotEmpty(t, setOrder("r1", "r2", "o1").Errors, "another user's rule in place of one")
+
+	// unchanged by the rejected orders
+	assert.Equal(t, map[string]int{h.UUID("r3"): 0, h.UUID("r1"): 1, h.UUID("r2"): 2}, positions())
+}
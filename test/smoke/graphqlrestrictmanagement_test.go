@@ -0,0 +1,85 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLRestrictManagement checks that, with service management restricted, users can't
+// manage maintenance windows or escalation policy steps of services they have no label grant for.
+func TestGraphQLRestrictManagement(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'bob@example.com', 'user');
+	insert into user_label_grants (user_id, key, value)
+	values
+		({{uuid "user"}}, 'example/team', 'payments');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'granted'),
+		({{uuid "ep2"}}, 'not granted');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "step1"}}, {{uuid "ep1"}}),
+		({{uuid "step2"}}, {{uuid "ep2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid1"}}, {{uuid "ep1"}}, 'payments'),
+		({{uuid "sid2"}}, {{uuid "ep2"}}, 'shipping');
+	insert into labels (tgt_service_id, key, value)
+	values
+		({{uuid "sid1"}}, 'example/team', 'payments'),
+		({{uuid "sid2"}}, 'example/team', 'shipping');
+
+	insert into service_maintenance_windows (id, service_id, start_time, end_time)
+	values
+		({{uuid "mw2"}}, {{uuid "sid2"}}, now(), now() + '1 hour'::interval);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.SetConfigValue("General.RestrictServiceManagement", "true")
+
+	query := func(q string) []string {
+		t.Helper()
+		var msgs []string
+		for _, e := range h.GraphQLQueryUserT(t, h.UUID("user"), q).Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+	createWindow := func(svcID string) string {
+		start := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		end := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+		return fmt.Sprintf(`mutation{createMaintenanceWindow(input:{serviceID: "%s", start: "%s", end: "%s"}){id}}`, svcID, start, end)
+	}
+
+	assert.Empty(t, query(createWindow(h.UUID("sid1"))), "granted service")
+	assert.NotEmpty(t, query(createWindow(h.UUID("sid2"))), "service without grant")
+
+	assert.NotEmpty(t, query(fmt.Sprintf(`mutation{deleteMaintenanceWindow(id: "%s")}`, h.UUID("mw2"))), "window of service without grant")
+
+	deleteStep := func(stepID string) string {
+		return fmt.Sprintf(`mutation{deleteAll(input:[{type: escalationPolicyStep, id: "%s"}])}`, stepID)
+	}
+	assert.NotEmpty(t, query(deleteStep(h.UUID("step2"))), "step of service without grant")
+	assert.Empty(t, query(deleteStep(h.UUID("step1"))), "step of granted service")
+
+	// the window and step of the service without a grant are untouched
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{
+		service(id: "%s"){maintenanceWindows{id}}
+		escalationPolicy(id: "%s"){steps{id}}
+	}`, h.UUID("sid2"), h.UUID("ep2")))
+	assert.Empty(t, resp.Errors)
+	assert.Contains(t, string(resp.Data), h.UUID("mw2"))
+	assert.Contains(t, string(resp.Data), h.UUID("step2"))
+}
@@ -0,0 +1,108 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLRotationFairness checks that rotation fairness counts the on-call time and pages of
+// participants through the rotation, and ignores schedules and policies that also target a
+// participant directly.
+func TestGraphQLRotationFairness(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "alice"}}, 'alice', 'alice@example.com'),
+		({{uuid "bob"}}, 'bob', 'bob@example.com');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "alice"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "alice"}}, {{uuid "cm1"}}, 0),
+		({{uuid "bob"}}, {{uuid "cm2"}}, 0);
+
+	insert into rotations (id, name, type, shift_length, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rotation', 'daily', 1, now(), 'UTC');
+	insert into rotation_participants (rotation_id, user_id, position)
+	values
+		({{uuid "rot"}}, {{uuid "alice"}}, 0),
+		({{uuid "rot"}}, {{uuid "bob"}}, 1);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched1"}}, 'rotation only', 'UTC'),
+		({{uuid "sched2"}}, 'rotation and bob', 'UTC');
+	insert into schedule_rules (schedule_id, start_time, end_time, tgt_rotation_id, tgt_user_id)
+	values
+		({{uuid "sched1"}}, '00:00', '00:00', {{uuid "rot"}}, null),
+		({{uuid "sched2"}}, '00:00', '00:00', {{uuid "rot"}}, null),
+		({{uuid "sched2"}}, '00:00', '00:00', null, {{uuid "bob"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'esc policy 1'),
+		({{uuid "ep2"}}, 'esc policy 2');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "step1"}}, {{uuid "ep1"}}),
+		({{uuid "step2"}}, {{uuid "ep2"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "step1"}}, {{uuid "sched1"}}),
+		({{uuid "step2"}}, {{uuid "sched2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "s1"}}, {{uuid "ep1"}}, 'service 1'),
+		({{uuid "s2"}}, {{uuid "ep2"}}, 'service 2');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	start := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	h.Trigger()
+
+	tw := h.Twilio(t)
+	h.CreateAlert(h.UUID("s1"), "one")
+	tw.Device(h.Phone("1")).ExpectSMS("one")
+	h.CreateAlert(h.UUID("s2"), "two")
+	tw.Device(h.Phone("1")).ExpectSMS("two")
+	tw.Device(h.Phone("2")).ExpectSMS("two")
+
+	h.FastForward(time.Hour)
+	h.Trigger()
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{rotation(id: "%s"){fairness(input:{start: "%s"}){userID, onCallHours, pages}}}`, h.UUID("rot"), start))
+	require.Empty(t, resp.Errors)
+	var res struct {
+		Rotation struct {
+			Fairness []struct {
+				UserID      string
+				OnCallHours float64
+				Pages       int
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &res))
+	require.Len(t, res.Rotation.Fairness, 2)
+
+	alice, bob := res.Rotation.Fairness[0], res.Rotation.Fairness[1]
+	assert.Equal(t, h.UUID("alice"), alice.UserID)
+	assert.Equal(t, 2, alice.Pages, "alice pages")
+	assert.Greater(t, alice.OnCallHours, 0.9, "alice on-call hours")
+
+	assert.Equal(t, h.UUID("bob"), bob.UserID)
+	assert.Equal(t, 0, bob.Pages, "bob paged directly, not by the rotation")
+	assert.Zero(t, bob.OnCallHours, "bob on call directly, not by the rotation")
+}
@@ -0,0 +1,95 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLServicesOnCall checks that servicesOnCall returns the users on-call for the first step
+// of each service, whether assigned directly, by rotation, or by schedule. Like the escalation engine,
+// out-of-hours actions and lower priority schedule users are left out, and rotation shadows are flagged.
+func TestGraphQLServicesOnCall(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "direct"}}, 'alice', 'alice@example.com'),
+		({{uuid "rotation"}}, 'bob', 'bob@example.com'),
+		({{uuid "schedule"}}, 'carol', 'carol@example.com'),
+		({{uuid "second"}}, 'dave', 'dave@example.com'),
+		({{uuid "inHours"}}, 'erin', 'erin@example.com'),
+		({{uuid "backup"}}, 'frank', 'frank@example.com'),
+		({{uuid "shadow"}}, 'grace', 'grace@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm"}}, {{uuid "schedule"}}, 'personal', 'SMS', {{phone "1"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "schedule"}}, {{uuid "cm"}}, 0);
+
+	insert into business_hours (id, name, time_zone, weekday_filter, start_time, end_time)
+	values
+		({{uuid "never"}}, 'never', 'UTC', '{f,f,f,f,f,f,f}', '09:00', '17:00');
+
+	insert into rotations (id, name, type, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rotation', 'daily', now(), 'UTC');
+	insert into rotation_participants (rotation_id, user_id, position)
+	values
+		({{uuid "rot"}}, {{uuid "rotation"}}, 0);
+	insert into rotation_shadows (rotation_id, user_id, expires_at)
+	values
+		({{uuid "rot"}}, {{uuid "shadow"}}, now() + '1 day'::interval);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'schedule', 'UTC');
+	insert into schedule_rules (schedule_id, start_time, end_time, tgt_user_id, priority)
+	values
+		({{uuid "sched"}}, '00:00', '00:00', {{uuid "schedule"}}, 0),
+		({{uuid "sched"}}, '00:00', '00:00', {{uuid "backup"}}, 1);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'with steps'),
+		({{uuid "ep2"}}, 'without steps');
+	insert into escalation_policy_steps (id, escalation_policy_id, step_number, routing_business_hours_id)
+	values
+		({{uuid "step1"}}, {{uuid "ep1"}}, 0, {{uuid "never"}}),
+		({{uuid "step2"}}, {{uuid "ep1"}}, 1, null);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id, rotation_id, schedule_id, business_hours_condition)
+	values
+		({{uuid "step1"}}, {{uuid "direct"}}, null, null, null),
+		({{uuid "step1"}}, {{uuid "inHours"}}, null, null, 'in_hours'),
+		({{uuid "step1"}}, null, {{uuid "rot"}}, null, null),
+		({{uuid "step1"}}, null, null, {{uuid "sched"}}, null),
+		({{uuid "step2"}}, {{uuid "second"}}, null, null, null);
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid1"}}, {{uuid "ep1"}}, 'a service'),
+		({{uuid "sid2"}}, {{uuid "ep2"}}, 'b service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.Trigger()
+
+	resp := h.GraphQLQuery2(`{servicesOnCall{serviceID, serviceName, users{userID, userName, shadow}}}`)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"servicesOnCall":[
+		{"serviceID": "%s", "serviceName": "a service", "users": [
+			{"userID": "%s", "userName": "alice", "shadow": false},
+			{"userID": "%s", "userName": "bob", "shadow": false},
+			{"userID": "%s", "userName": "carol", "shadow": false},
+			{"userID": "%s", "userName": "grace", "shadow": true}
+		]},
+		{"serviceID": "%s", "serviceName": "b service", "users": []}
+	]}`, h.UUID("sid1"), h.UUID("direct"), h.UUID("rotation"), h.UUID("schedule"), h.UUID("shadow"), h.UUID("sid2")), string(resp.Data))
+}
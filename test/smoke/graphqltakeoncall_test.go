@@ -0,0 +1,84 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLTakeOnCall checks which schedule takeOnCall overrides: the only schedule of the
+// first step with one, an explicit schedule used by any step of the policy, and an error if the
+// policy has no schedules or the first step with one has several.
+func TestGraphQLTakeOnCall(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "s1"}}, 'schedule 1', 'UTC'),
+		({{uuid "s2"}}, 'schedule 2', 'UTC'),
+		({{uuid "s3"}}, 'schedule 3', 'UTC'),
+		({{uuid "s4"}}, 'schedule 4', 'UTC');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "multi"}}, 'multiple schedules'),
+		({{uuid "single"}}, 'single schedule'),
+		({{uuid "none"}}, 'no schedule');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "multi0"}}, {{uuid "multi"}}),
+		({{uuid "multi1"}}, {{uuid "multi"}}),
+		({{uuid "single0"}}, {{uuid "single"}}),
+		({{uuid "single1"}}, {{uuid "single"}}),
+		({{uuid "none0"}}, {{uuid "none"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id, user_id)
+	values
+		({{uuid "multi0"}}, {{uuid "s1"}}, null),
+		({{uuid "multi0"}}, {{uuid "s2"}}, null),
+		({{uuid "multi1"}}, {{uuid "s3"}}, null),
+		({{uuid "single0"}}, null, {{uuid "user"}}),
+		({{uuid "single1"}}, {{uuid "s4"}}, null),
+		({{uuid "none0"}}, null, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "svcMulti"}}, {{uuid "multi"}}, 'multi'),
+		({{uuid "svcSingle"}}, {{uuid "single"}}, 'single'),
+		({{uuid "svcNone"}}, {{uuid "none"}}, 'none');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	take := func(svc, sched string) *harness.QLResponse {
+		t.Helper()
+		schedArg := ""
+		if sched != "" {
+			schedArg = fmt.Sprintf(`, scheduleID: "%s"`, h.UUID(sched))
+		}
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{takeOnCall(input:{serviceID: "%s", durationMinutes: 60%s}){target{id}}}`, h.UUID(svc), schedArg))
+	}
+	expectSched := func(resp *harness.QLResponse, sched string) {
+		t.Helper()
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{"takeOnCall":{"target":{"id":"%s"}}}`, h.UUID(sched)), string(resp.Data))
+	}
+
+	// first step with a schedule has exactly one, after a step without
+	expectSched(take("svcSingle", ""), "s4")
+
+	// multiple schedules on the first step require an explicit one
+	assert.NotEmpty(t, take("svcMulti", "").Errors, "ambiguous schedule")
+	expectSched(take("svcMulti", "s2"), "s2")
+	expectSched(take("svcMulti", "s3"), "s3")
+	assert.NotEmpty(t, take("svcMulti", "s4").Errors, "schedule not used by the policy")
+
+	assert.NotEmpty(t, take("svcNone", "").Errors, "no schedule")
+}
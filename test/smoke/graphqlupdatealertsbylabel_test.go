@@ -0,0 +1,61 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLUpdateAlertsByLabel checks that closing alerts by label requires a close reason
+// when a matching service requires one, and records the reason on each alert.
+func TestGraphQLUpdateAlertsByLabel(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name, require_close_reason)
+	values
+		({{uuid "sid1"}}, {{uuid "eid"}}, 'service 1', false),
+		({{uuid "sid2"}}, {{uuid "eid"}}, 'service 2', true);
+
+	insert into labels (tgt_service_id, key, value)
+	values
+		({{uuid "sid1"}}, 'team/name', 'a'),
+		({{uuid "sid2"}}, 'team/name', 'b');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	a1 := h.CreateAlert(h.UUID("sid1"), "first")
+	a2 := h.CreateAlert(h.UUID("sid2"), "second")
+
+	closeByLabel := func(value, reason string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{updateAlertsByLabel(input:{labelKey: "team/name", labelValue: %q, newStatus: StatusClosed%s})}`, value, reason))
+	}
+	closeReason := func(id int) string {
+		t.Helper()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{alert(id: %d){status, closeReason}}`, id))
+		require.Empty(t, resp.Errors)
+		return string(resp.Data)
+	}
+
+	resp := closeByLabel("*", "")
+	assert.NotEmpty(t, resp.Errors, "reason required by service 2")
+	assert.JSONEq(t, `{"alert":{"status":"StatusUnacknowledged","closeReason":null}}`, closeReason(a1.ID()), "nothing closed on error")
+
+	resp = closeByLabel("a", "")
+	require.Empty(t, resp.Errors, "reason not required by service 1")
+	assert.JSONEq(t, `{"updateAlertsByLabel":1}`, string(resp.Data))
+
+	resp = closeByLabel("*", ", closeReason: duplicate")
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"updateAlertsByLabel":1}`, string(resp.Data))
+	assert.JSONEq(t, `{"alert":{"status":"StatusClosed","closeReason":"duplicate"}}`, closeReason(a2.ID()))
+}
@@ -0,0 +1,130 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLUserOnCallShifts checks that userOnCallShifts returns a user's shifts across schedules,
+// from rules, overrides, and fixed shifts, ordered by start time, paginated, and with local times in
+// the user's time zone.
+func TestGraphQLUserOnCallShifts(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, time_zone)
+	values
+		({{uuid "alice"}}, 'alice', 'alice@example.com', 'America/Chicago'),
+		({{uuid "bob"}}, 'bob', 'bob@example.com', null);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched1"}}, 'by rule', 'UTC'),
+		({{uuid "sched2"}}, 'by override', 'UTC'),
+		({{uuid "sched3"}}, 'not on call', 'UTC'),
+		({{uuid "sched4"}}, 'by fixed shift', 'UTC');
+	insert into schedule_rules (schedule_id, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched1"}}, '00:00', '00:00', {{uuid "alice"}}),
+		({{uuid "sched2"}}, '00:00', '00:00', {{uuid "bob"}}),
+		({{uuid "sched3"}}, '00:00', '00:00', {{uuid "bob"}});
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, start_time, end_time)
+	values
+		({{uuid "override"}}, {{uuid "sched2"}}, {{uuid "alice"}}, now() + '1 hour'::interval, now() + '2 hours'::interval);
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched4"}}, jsonb_build_object('V1', jsonb_build_object('FixedShifts', jsonb_build_array(
+			jsonb_build_object('Start', date_trunc('minute', now() + '3 hours'::interval), 'End', date_trunc('minute', now() + '4 hours'::interval), 'UserID', {{uuid "alice"}})
+		))));
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "step"}}, {{uuid "ep"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "step"}}, {{uuid "sched1"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "ep"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.Trigger()
+
+	type shifts struct {
+		UserOnCallShifts struct {
+			Nodes []struct {
+				ScheduleID string
+				Start, End time.Time
+				LocalStart string
+				Truncated  bool
+				Services   []struct{ Name string }
+			}
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+			TimeZone string
+		}
+	}
+	start := time.Now().UTC()
+	query := func(first int, after string) shifts {
+		t.Helper()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{userOnCallShifts(input:{userID: "%s", start: "%s", end: "%s", first: %d, after: "%s"}){
+			nodes{scheduleID, start, end, localStart, truncated, services{name}}
+			pageInfo{endCursor, hasNextPage}
+			timeZone
+		}}`, h.UUID("alice"), start.Format(time.RFC3339), start.Add(24*time.Hour).Format(time.RFC3339), first, after))
+		require.Empty(t, resp.Errors)
+
+		var res shifts
+		require.NoError(t, json.Unmarshal(resp.Data, &res))
+		return res
+	}
+
+	page := query(1, "")
+	assert.Equal(t, "America/Chicago", page.UserOnCallShifts.TimeZone)
+	require.Len(t, page.UserOnCallShifts.Nodes, 1)
+	assert.True(t, page.UserOnCallShifts.PageInfo.HasNextPage)
+	rule := page.UserOnCallShifts.Nodes[0]
+	assert.Equal(t, h.UUID("sched1"), rule.ScheduleID)
+	assert.True(t, rule.Truncated, "rule continues past end")
+	assert.Equal(t, []struct{ Name string }{{Name: "service"}}, rule.Services)
+
+	page = query(10, page.UserOnCallShifts.PageInfo.EndCursor)
+	require.Len(t, page.UserOnCallShifts.Nodes, 2)
+	assert.False(t, page.UserOnCallShifts.PageInfo.HasNextPage)
+	override := page.UserOnCallShifts.Nodes[0]
+	assert.Equal(t, h.UUID("sched2"), override.ScheduleID)
+	assert.False(t, override.Truncated)
+	assert.WithinDuration(t, start.Add(time.Hour), override.Start, time.Minute)
+	assert.WithinDuration(t, start.Add(2*time.Hour), override.End, time.Minute)
+	assert.Empty(t, override.Services, "schedule not used by any service")
+
+	chi, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	localStart, err := time.Parse(time.RFC3339, override.LocalStart)
+	require.NoError(t, err)
+	assert.True(t, override.Start.Truncate(time.Second).Equal(localStart), "same instant")
+	_, expOffset := override.Start.In(chi).Zone()
+	_, offset := localStart.Zone()
+	assert.Equal(t, expOffset, offset, "user's time zone")
+
+	fixed := page.UserOnCallShifts.Nodes[1]
+	assert.Equal(t, h.UUID("sched4"), fixed.ScheduleID)
+	assert.WithinDuration(t, start.Add(3*time.Hour), fixed.Start, time.Minute)
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{userOnCallShifts(input:{userID: "%s", start: "%s", end: "%s"}){nodes{scheduleID}}}`,
+		h.UUID("alice"), start.Format(time.RFC3339), start.Add(60*24*time.Hour).Format(time.RFC3339)))
+	assert.NotEmpty(t, resp.Errors, "more than 50 days")
+}
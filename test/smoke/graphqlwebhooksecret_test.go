@@ -0,0 +1,70 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLWebhookSecret checks that a webhook secret can only be set by an admin, or by a
+// target of an escalation policy or schedule that notifies the webhook.
+func TestGraphQLWebhookSecret(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "epUser"}}, 'bob', 'joe', 'user'),
+		({{uuid "schedUser"}}, 'ben', 'josh', 'user'),
+		({{uuid "stranger"}}, 'bill', 'jim', 'user');
+
+	insert into notification_channels (id, type, name, value)
+	values
+		({{uuid "hook1"}}, 'WEBHOOK', 'policy hook', 'https://example.com/policy'),
+		({{uuid "hook2"}}, 'WEBHOOK', 'schedule hook', 'https://example.com/schedule');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}),
+		({{uuid "es2"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, channel_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "hook1"}}, null),
+		({{uuid "es2"}}, null, {{uuid "epUser"}});
+
+	insert into rotations (id, name, type, shift_length, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rotation', 'daily', 1, now(), 'UTC');
+	insert into rotation_participants (rotation_id, user_id)
+	values
+		({{uuid "rot"}}, {{uuid "schedUser"}});
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'schedule', 'UTC');
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "rot"}});
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched"}}, '{"V1":{"OnCallNotificationRules": [{"ChannelID": {{uuidJSON "hook2"}}}]}}');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	setSecret := func(userID, url string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{setWebhookSecret(input:{url: "%s", secret: "0123456789abcdef"})}`, url))
+	}
+
+	assert.Empty(t, setSecret(h.UUID("epUser"), "https://example.com/policy").Errors, "policy target")
+	assert.NotEmpty(t, setSecret(h.UUID("epUser"), "https://example.com/schedule").Errors, "not on the schedule")
+	assert.Empty(t, setSecret(h.UUID("schedUser"), "https://example.com/schedule").Errors, "schedule target via rotation")
+	assert.NotEmpty(t, setSecret(h.UUID("schedUser"), "https://example.com/policy").Errors, "not on the policy")
+	assert.NotEmpty(t, setSecret(h.UUID("stranger"), "https://example.com/policy").Errors, "stranger")
+	assert.Empty(t, setSecret(harness.DefaultGraphQLAdminUserID, "https://example.com/schedule").Errors, "admin")
+}
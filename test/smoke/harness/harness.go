@@ -367,6 +367,17 @@ func (h *Harness) FastForward(d time.Duration) {
 	}
 }
 
+// SetTime will set the current database time (i.e., now()) to t. It must be called before Start, after
+// which time flows normally from t.
+func (h *Harness) SetTime(t time.Time) {
+	h.t.Helper()
+	h.t.Logf("Set time %s", t.String())
+	err := h.pgTime.SetTime(context.Background(), t)
+	if err != nil {
+		h.t.Fatalf("failed to set time: %v", err)
+	}
+}
+
 func (h *Harness) execQuery(sql string, data interface{}) {
 	h.t.Helper()
 	t := template.New("sql")
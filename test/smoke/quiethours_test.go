@@ -0,0 +1,60 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestQuietHours checks that rules in their quiet hours, evaluated in the user's time zone, are
+// skipped, unless they allow high urgency and the alert is high urgency.
+func TestQuietHours(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, time_zone)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'America/Chicago');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'quiet', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user"}}, 'quiet, high urgency', 'SMS', {{phone "2"}}),
+		({{uuid "cm3"}}, {{uuid "user"}}, 'no quiet hours', 'SMS', {{phone "3"}}),
+		({{uuid "cm4"}}, {{uuid "user"}}, 'quiet in UTC', 'SMS', {{phone "4"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, quiet_hours_start, quiet_hours_end, quiet_hours_allow_high_urgency)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0, ((now() at time zone 'America/Chicago') - '1 hour'::interval)::time, ((now() at time zone 'America/Chicago') + '1 hour'::interval)::time, false),
+		({{uuid "user"}}, {{uuid "cm2"}}, 0, ((now() at time zone 'America/Chicago') - '1 hour'::interval)::time, ((now() at time zone 'America/Chicago') + '1 hour'::interval)::time, true),
+		({{uuid "user"}}, {{uuid "cm3"}}, 0, null, null, false),
+		({{uuid "user"}}, {{uuid "cm4"}}, 0, ((now() at time zone 'UTC') - '1 hour'::interval)::time, ((now() at time zone 'UTC') + '1 hour'::interval)::time, false);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "s1"}}, {{uuid "eid"}}, 'service 1'),
+		({{uuid "s2"}}, {{uuid "eid"}}, 'service 2');
+
+	insert into alerts (service_id, summary, severity)
+	values
+		({{uuid "s1"}}, 'critical alert', 'critical'),
+		({{uuid "s2"}}, 'warning alert', 'warning');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("2")).ExpectSMS("critical alert")
+	tw.Device(h.Phone("3")).ExpectSMS("critical alert")
+	tw.Device(h.Phone("3")).ExpectSMS("warning alert")
+	tw.Device(h.Phone("4")).ExpectSMS("critical alert")
+	tw.Device(h.Phone("4")).ExpectSMS("warning alert")
+}
@@ -0,0 +1,48 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestReplayMessage checks that a replayed alert notification is sent to the user even though the
+// alert was closed, and the user has since enabled do-not-disturb.
+func TestReplayMessage(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+	insert into user_do_not_disturb (user_id)
+	values
+		({{uuid "user"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into alerts (id, service_id, summary, status)
+	values
+		(1001, {{uuid "sid"}}, 'blank page', 'closed');
+
+	insert into outgoing_messages (id, message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, last_status, sent_at)
+	values
+		({{uuid "msg"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "user"}}, {{uuid "cm"}}, 'delivered', now() - '1 hour'::interval);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`mutation{replayMessage(input:{id: "%s"})}`, h.UUID("msg")))
+	require.Empty(t, resp.Errors)
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("blank page")
+}
@@ -0,0 +1,80 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestScheduleRulePriorityBackup checks that a step targeting a schedule notifies the backup
+// (lower priority) users when none of the primary users can be reached, and only the primary
+// users otherwise.
+func TestScheduleRulePriorityBackup(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "unreachable"}}, 'bob', 'joe'),
+		({{uuid "backup1"}}, 'ben', 'josh'),
+		({{uuid "primary"}}, 'beth', 'jane'),
+		({{uuid "backup2"}}, 'bill', 'john');
+
+	insert into user_contact_methods (id, user_id, name, type, value, disabled)
+	values
+		({{uuid "cm1"}}, {{uuid "unreachable"}}, 'personal', 'SMS', {{phone "1"}}, true),
+		({{uuid "cm2"}}, {{uuid "backup1"}}, 'personal', 'SMS', {{phone "2"}}, false),
+		({{uuid "cm3"}}, {{uuid "primary"}}, 'personal', 'SMS', {{phone "3"}}, false),
+		({{uuid "cm4"}}, {{uuid "backup2"}}, 'personal', 'SMS', {{phone "4"}}, false);
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "unreachable"}}, {{uuid "cm1"}}, 0),
+		({{uuid "backup1"}}, {{uuid "cm2"}}, 0),
+		({{uuid "primary"}}, {{uuid "cm3"}}, 0),
+		({{uuid "backup2"}}, {{uuid "cm4"}}, 0);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched1"}}, 'unreachable primary', 'UTC'),
+		({{uuid "sched2"}}, 'reachable primary', 'UTC');
+
+	insert into schedule_rules (schedule_id, start_time, end_time, tgt_user_id, priority)
+	values
+		({{uuid "sched1"}}, '00:00', '00:00', {{uuid "unreachable"}}, 0),
+		({{uuid "sched1"}}, '00:00', '00:00', {{uuid "backup1"}}, 1),
+		({{uuid "sched2"}}, '00:00', '00:00', {{uuid "primary"}}, 0),
+		({{uuid "sched2"}}, '00:00', '00:00', {{uuid "backup2"}}, 1);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid1"}}, 'esc policy 1'),
+		({{uuid "eid2"}}, 'esc policy 2');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid1"}}, {{uuid "eid1"}}),
+		({{uuid "esid2"}}, {{uuid "eid2"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid1"}}, {{uuid "sched1"}}),
+		({{uuid "esid2"}}, {{uuid "sched2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid1"}}, {{uuid "eid1"}}, 'service 1'),
+		({{uuid "sid2"}}, {{uuid "eid2"}}, 'service 2');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid1"), h.UUID("backup1"))
+	h.WaitAndAssertOnCallUsers(h.UUID("sid2"), h.UUID("primary"))
+
+	h.CreateAlert(h.UUID("sid1"), "first")
+	h.CreateAlert(h.UUID("sid2"), "second")
+
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("first")
+	h.Twilio(t).Device(h.Phone("3")).ExpectSMS("second")
+}
@@ -0,0 +1,53 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestServicePausePending checks that pausing a service fails alert notifications that were
+// already waiting to be sent or retried, so nothing is sent while the service is paused.
+func TestServicePausePending(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into alerts (id, service_id, summary, status, dedup_key)
+	values
+		(1001, {{uuid "sid"}}, 'retry pending', 'triggered', 'a');
+
+	insert into outgoing_messages (id, message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, last_status, next_retry_at)
+	values
+		({{uuid "msg"}}, 'alert_notification', 1001, {{uuid "sid"}}, {{uuid "eid"}}, {{uuid "user"}}, {{uuid "cm"}}, 'failed', now() + '30 minutes'::interval);
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`mutation{setServicePaused(input:{id: "%s", paused: true})}`, h.UUID("sid")))
+	require.Empty(t, resp.Errors)
+
+	// the harness fails the test if the retry is sent
+	h.FastForward(time.Hour)
+	h.Trigger()
+
+	resp = h.GraphQLQuery2(`{alert(id: 1001){notifications{status, statusDetails}}}`)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"alert":{"notifications":[{"status":"failed","statusDetails":"service paused"}]}}`, string(resp.Data))
+}
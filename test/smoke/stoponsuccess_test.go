@@ -0,0 +1,59 @@
+package smoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestStopOnSuccess checks that once a stop-on-success rule sends a notification, rules later
+// in the user's order are skipped, even if they have the same delay, while earlier rules are
+// unaffected.
+func TestStopOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'before', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user"}}, 'stop', 'SMS', {{phone "2"}}),
+		({{uuid "cm3"}}, {{uuid "user"}}, 'after', 'SMS', {{phone "3"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, position, stop_on_success)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0, 0, false),
+		({{uuid "user"}}, {{uuid "cm2"}}, 0, 1, true),
+		({{uuid "user"}}, {{uuid "cm3"}}, 0, 2, false);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary)
+	values
+		({{uuid "sid"}}, 'testing');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+	tw.Device(h.Phone("2")).ExpectSMS("testing")
+
+	// the rule after the stop rule is held, then skipped
+	h.FastForward(2 * time.Minute)
+	h.Trigger()
+}
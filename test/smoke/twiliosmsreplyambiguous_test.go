@@ -0,0 +1,56 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestTwilioSMSReplyAmbiguous checks that a reply with no code is rejected when multiple alerts were recently sent.
+func TestTwilioSMSReplyAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "ids-to-uuids")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	d1 := tw.Device(h.Phone("1"))
+
+	h.CreateAlert(h.UUID("sid"), "test1")
+	d1.ExpectSMS("test1", "1a")
+
+	h.CreateAlert(h.UUID("sid"), "test2")
+	d1.ExpectSMS("test2", "2a").
+		ThenReply("ack").
+		ThenExpect("Multiple", "1a", "2a").
+		ThenReply("2a").
+		ThenExpect("Acknowledged", "#2").
+		ThenReply("ack"). // only one unacknowledged alert remains
+		ThenExpect("Acknowledged", "#1")
+}
@@ -0,0 +1,61 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestUrgencyWindow checks that urgency windows are evaluated in the user's time zone, so a
+// critical alert outside of the window is downgraded and uses the low-urgency rules.
+func TestUrgencyWindow(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, time_zone)
+	values
+		({{uuid "inside"}}, 'bob', 'joe', 'America/Chicago'),
+		({{uuid "outside"}}, 'ben', 'josh', 'America/Chicago');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "inside"}}, 'high', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "inside"}}, 'low', 'SMS', {{phone "2"}}),
+		({{uuid "cm3"}}, {{uuid "outside"}}, 'high', 'SMS', {{phone "3"}}),
+		({{uuid "cm4"}}, {{uuid "outside"}}, 'low', 'SMS', {{phone "4"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, urgency)
+	values
+		({{uuid "inside"}}, {{uuid "cm1"}}, 0, 'high'),
+		({{uuid "inside"}}, {{uuid "cm2"}}, 0, 'low'),
+		({{uuid "outside"}}, {{uuid "cm3"}}, 0, 'high'),
+		({{uuid "outside"}}, {{uuid "cm4"}}, 0, 'low');
+	insert into user_urgency_windows (user_id, start_time, end_time)
+	values
+		({{uuid "inside"}}, ((now() at time zone 'America/Chicago') - '1 hour'::interval)::time, ((now() at time zone 'America/Chicago') + '1 hour'::interval)::time),
+		({{uuid "outside"}}, ((now() at time zone 'UTC') - '1 hour'::interval)::time, ((now() at time zone 'UTC') + '1 hour'::interval)::time);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "inside"}}),
+		({{uuid "esid"}}, {{uuid "outside"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary, severity)
+	values
+		({{uuid "sid"}}, 'critical alert', 'critical');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).ExpectSMS("critical alert")
+	tw.Device(h.Phone("4")).ExpectSMS("critical alert")
+}
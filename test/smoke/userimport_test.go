@@ -0,0 +1,77 @@
+package smoke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestUserImportUpdateRole checks that updating existing users only changes their role when
+// the row provides one, and that role changes are recorded in the audit log.
+func TestUserImportUpdateRole(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "importer"}}, 'importer', 'importer@example.com', 'admin'),
+		({{uuid "admin"}}, 'alice', 'alice@example.com', 'admin');
+`
+	h := harness.NewHarness(t, sql, "")
+	defer h.Close()
+
+	tok, err := h.App().AuthHandler.CreateSession(context.Background(), "goalert-smoketest", h.UUID("importer"))
+	require.NoError(t, err)
+	sess, err := tok.Encode(h.App().SessionKeyring.Sign)
+	require.NoError(t, err)
+
+	importCSV := func(data string) {
+		t.Helper()
+		req, err := http.NewRequest("POST", h.URL()+"/api/v2/users/import?mode=update", strings.NewReader(data))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "text/csv")
+		req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: sess})
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	user := func() string {
+		t.Helper()
+		resp := h.GraphQLQuery2(fmt.Sprintf(`{user(id: "%s"){name, role}}`, h.UUID("admin")))
+		require.Empty(t, resp.Errors)
+		return string(resp.Data)
+	}
+
+	importCSV("name,email,role\nAlice A,alice@example.com,\n")
+	assert.JSONEq(t, `{"user":{"name":"Alice A","role":"admin"}}`, user(), "blank role")
+
+	importCSV("name,email\nAlice B,alice@example.com\n")
+	assert.JSONEq(t, `{"user":{"name":"Alice B","role":"admin"}}`, user(), "no role column")
+
+	importCSV("name,email,role\nAlice C,alice@example.com,user\n")
+	assert.JSONEq(t, `{"user":{"name":"Alice C","role":"user"}}`, user(), "role provided")
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`{auditLogs(input:{filterByEntityType: "user", filterByEntityID: "%s", first: 1}){nodes{action, actorID, before, after}}}`, h.UUID("admin")))
+	require.Empty(t, resp.Errors)
+	var logs struct {
+		AuditLogs struct {
+			Nodes []struct{ Action, ActorID, Before, After string }
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &logs))
+	require.Len(t, logs.AuditLogs.Nodes, 1)
+	e := logs.AuditLogs.Nodes[0]
+	assert.Equal(t, "importUsers", e.Action)
+	assert.Equal(t, h.UUID("importer"), e.ActorID)
+	assert.Contains(t, e.Before, `"admin"`, "role before")
+	assert.Contains(t, e.After, `"user"`, "role after")
+}
@@ -20,12 +20,20 @@ type ContactMethod struct {
 
 	StatusUpdates bool
 
+	// ConsecutiveFailures is the number of failed deliveries since the last successful one.
+	ConsecutiveFailures int
+
 	lastTestVerifyAt sql.NullTime
+	autoDisabledAt   sql.NullTime
 }
 
 // LastTestVerifyAt will return the timestamp of the last test/verify request.
 func (c ContactMethod) LastTestVerifyAt() time.Time { return c.lastTestVerifyAt.Time }
 
+// AutoDisabledAt will return the time the contact method was disabled due to
+// consecutive failed deliveries, or the zero value if it was not.
+func (c ContactMethod) AutoDisabledAt() time.Time { return c.autoDisabledAt.Time }
+
 // Normalize will validate and 'normalize' the ContactMethod -- such as making email lower-case
 // and setting carrier to "" (for non-phone types).
 func (c ContactMethod) Normalize() (*ContactMethod, error) {
@@ -35,7 +43,7 @@ func (c ContactMethod) Normalize() (*ContactMethod, error) {
 	err := validate.Many(
 		validate.UUID("ID", c.ID),
 		validate.IDName("Name", c.Name),
-		validate.OneOf("Type", c.Type, TypeSMS, TypeVoice, TypeEmail, TypePush, TypeWebhook, TypeSlackDM),
+		validate.OneOf("Type", c.Type, TypeSMS, TypeVoice, TypeEmail, TypePush, TypeWebhook, TypeSlackDM, TypeTelegram),
 	)
 
 	switch c.Type {
@@ -52,6 +60,8 @@ func (c ContactMethod) Normalize() (*ContactMethod, error) {
 		// require the full Slack ID format (which is a bit more complex)
 		// as it may change in the future.
 		err = validate.Many(err, validate.ASCII("Value", c.Value, 3, 128))
+	case TypeTelegram:
+		err = validate.Many(err, validate.TelegramChatID("Value", c.Value))
 	}
 
 	if c.Type.StatusUpdatesAlways() {
@@ -30,6 +30,9 @@ func TestContactMethod_Normalize(t *testing.T) {
 		{Name: "webhookHTTP", Type: TypeWebhook, Value: "http://www.example.com"},
 		{Name: "webhookHTTPS", Type: TypeWebhook, Value: "https://www.example.com"},
 		{Name: "webhookPath", Type: TypeWebhook, Value: "http://www.example.com/example"},
+
+		{Name: "telegramUser", Type: TypeTelegram, Value: "123456789"},
+		{Name: "telegramGroup", Type: TypeTelegram, Value: "-100123456789"},
 	}
 	invalid := []ContactMethod{
 		{Name: "abcd", Type: TypeSMS, Value: "+15555555555"},
@@ -39,6 +42,10 @@ func TestContactMethod_Normalize(t *testing.T) {
 		{Name: "webhookEmpty", Type: TypeWebhook, Value: ""},
 		{Name: "webhookIncomplete", Type: TypeWebhook, Value: "example"},
 		{Name: "webhookMissingProtocol", Type: TypeWebhook, Value: "example.com"},
+
+		{Name: "telegramEmpty", Type: TypeTelegram, Value: ""},
+		{Name: "telegramName", Type: TypeTelegram, Value: "@username"},
+		{Name: "telegramZero", Type: TypeTelegram, Value: "0"},
 	}
 
 	for _, cm := range valid {
@@ -54,7 +54,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		enable: p.P(`
 			UPDATE user_contact_methods
-			SET disabled = false
+			SET disabled = false, consecutive_failures = 0, auto_disabled_at = null
 			WHERE type = $1
 				AND value = $2
 			RETURNING id
@@ -76,29 +76,35 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			VALUES ($1,$2,$3,$4,$5,$6,$7)
 		`),
 		findOne: p.P(`
-			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending
+			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending,consecutive_failures,auto_disabled_at
 			FROM user_contact_methods
 			WHERE id = $1
 		`),
 		findOneUpd: p.P(`
-			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending
+			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending,consecutive_failures,auto_disabled_at
 			FROM user_contact_methods
 			WHERE id = $1
 			FOR UPDATE
 		`),
 		findMany: p.P(`
-			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending
+			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending,consecutive_failures,auto_disabled_at
 			FROM user_contact_methods
 			WHERE id = any($1)
 		`),
 		findAll: p.P(`
-			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending
+			SELECT id,name,type,value,disabled,user_id,last_test_verify_at,enable_status_updates,pending,consecutive_failures,auto_disabled_at
 			FROM user_contact_methods
 			WHERE user_id = $1
 		`),
 		update: p.P(`
 				UPDATE user_contact_methods
-				SET name = $2, disabled = $3, enable_status_updates = $4
+				SET
+					name = $2,
+					disabled = $3,
+					enable_status_updates = $4,
+					-- re-enabling clears the failures that caused it to be disabled
+					consecutive_failures = CASE WHEN disabled AND NOT $3::boolean THEN 0 ELSE consecutive_failures END,
+					auto_disabled_at = CASE WHEN $3::boolean THEN auto_disabled_at ELSE null END
 				WHERE id = $1
 			`),
 		delete: p.P(`
@@ -308,7 +314,7 @@ func (s *Store) FindOneTx(ctx context.Context, tx *sql.Tx, id string) (*ContactM
 
 	var c ContactMethod
 	row := wrapTx(ctx, tx, s.findOneUpd).QueryRowContext(ctx, id)
-	err = row.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending)
+	err = row.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending, &c.ConsecutiveFailures, &c.autoDisabledAt)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +335,7 @@ func (s *Store) FindOne(ctx context.Context, id string) (*ContactMethod, error)
 
 	var c ContactMethod
 	row := s.findOne.QueryRowContext(ctx, id)
-	err = row.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending)
+	err = row.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending, &c.ConsecutiveFailures, &c.autoDisabledAt)
 	if err != nil {
 		return nil, err
 	}
@@ -401,7 +407,7 @@ func scanAll(rows *sql.Rows) ([]ContactMethod, error) {
 	var contactMethods []ContactMethod
 	for rows.Next() {
 		var c ContactMethod
-		err := rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending)
+		err := rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &c.Disabled, &c.UserID, &c.lastTestVerifyAt, &c.StatusUpdates, &c.Pending, &c.ConsecutiveFailures, &c.autoDisabledAt)
 		if err != nil {
 			return nil, err
 		}
@@ -10,13 +10,14 @@ type Type string
 
 // ContactMethod types
 const (
-	TypeUnknown Type = ""
-	TypeVoice   Type = "VOICE"
-	TypeSMS     Type = "SMS"
-	TypeEmail   Type = "EMAIL"
-	TypePush    Type = "PUSH"
-	TypeWebhook Type = "WEBHOOK"
-	TypeSlackDM Type = "SLACK_DM"
+	TypeUnknown  Type = ""
+	TypeVoice    Type = "VOICE"
+	TypeSMS      Type = "SMS"
+	TypeEmail    Type = "EMAIL"
+	TypePush     Type = "PUSH"
+	TypeWebhook  Type = "WEBHOOK"
+	TypeSlackDM  Type = "SLACK_DM"
+	TypeTelegram Type = "TELEGRAM"
 )
 
 func (t Type) StatusUpdatesAlways() bool {
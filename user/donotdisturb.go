@@ -0,0 +1,84 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// DoNotDisturb mutes all notifications to a user's contact methods. Alerts continue to
+// escalate past the user according to their escalation policy.
+type DoNotDisturb struct {
+	// ExpiresAt, if set, is when notifications are automatically unmuted.
+	ExpiresAt time.Time
+}
+
+// Active returns true if do-not-disturb is in effect at t.
+func (d DoNotDisturb) Active(t time.Time) bool {
+	return d.ExpiresAt.IsZero() || d.ExpiresAt.After(t)
+}
+
+// SetDoNotDisturbTx will set do-not-disturb for the given user. If dnd is nil, it is
+// cleared and notifications resume immediately.
+func (s *Store) SetDoNotDisturbTx(ctx context.Context, tx *sql.Tx, userID string, dnd *DoNotDisturb) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	if dnd == nil {
+		_, err = withTx(ctx, tx, s.clearDND).ExecContext(ctx, userID)
+		return err
+	}
+
+	var exp sql.NullTime
+	if !dnd.ExpiresAt.IsZero() {
+		if !dnd.ExpiresAt.After(time.Now()) {
+			return validation.NewFieldError("ExpiresAt", "must be in the future")
+		}
+		exp = sql.NullTime{Time: dnd.ExpiresAt, Valid: true}
+	}
+
+	_, err = withTx(ctx, tx, s.setDND).ExecContext(ctx, userID, exp)
+	return err
+}
+
+// FindDoNotDisturb will return the active do-not-disturb setting for the given user, or
+// nil if notifications are not muted.
+func (s *Store) FindDoNotDisturb(ctx context.Context, userID string) (*DoNotDisturb, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	var exp sql.NullTime
+	err = s.findDND.QueryRowContext(ctx, userID).Scan(&exp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dnd := &DoNotDisturb{ExpiresAt: exp.Time}
+	if !dnd.Active(time.Now()) {
+		return nil, nil
+	}
+
+	return dnd, nil
+}
@@ -0,0 +1,17 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoNotDisturb_Active(t *testing.T) {
+	now := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, DoNotDisturb{}.Active(now), "no expiration")
+	assert.True(t, DoNotDisturb{ExpiresAt: now.Add(time.Minute)}.Active(now), "before expiration")
+	assert.False(t, DoNotDisturb{ExpiresAt: now}.Active(now), "at expiration")
+	assert.False(t, DoNotDisturb{ExpiresAt: now.Add(-time.Minute)}.Active(now), "after expiration")
+}
@@ -9,6 +9,20 @@ type NotificationRule struct {
 	UserID          string `json:"-"`
 	DelayMinutes    int    `json:"delay"`
 	ContactMethodID string `json:"contact_method_id"`
+
+	// QuietHours, if set, suppresses this rule while the window is in effect, in the
+	// time zone of the user.
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+
+	// Urgency, if set, restricts this rule to alerts of the given urgency.
+	Urgency Urgency `json:"urgency,omitempty"`
+
+	// Position is the order of the rule among the user's rules, starting at 0.
+	Position int `json:"position"`
+
+	// StopOnSuccess, if set, skips rules later in the order once a notification sent by
+	// this rule is successfully delivered for an alert.
+	StopOnSuccess bool `json:"stop_on_success,omitempty"`
 }
 
 func validateDelay(d int) error {
@@ -25,6 +39,10 @@ func (n NotificationRule) Normalize(update bool) (*NotificationRule, error) {
 			validate.UUID("UserID", n.UserID),
 		)
 	}
+	if n.QuietHours != nil {
+		err = validate.Many(err, n.QuietHours.validate())
+	}
+	err = validate.Many(err, validate.OneOf("Urgency", n.Urgency, UrgencyAny, UrgencyHigh, UrgencyLow))
 	if err != nil {
 		return nil, err
 	}
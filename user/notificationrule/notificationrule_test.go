@@ -2,6 +2,8 @@ package notificationrule
 
 import (
 	"testing"
+
+	"github.com/target/goalert/util/timeutil"
 )
 
 func TestNotificationRule_Normalize(t *testing.T) {
@@ -23,9 +25,17 @@ func TestNotificationRule_Normalize(t *testing.T) {
 
 	valid := []NotificationRule{
 		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb",
+			QuietHours: &QuietHours{Start: timeutil.NewClock(0, 0), End: timeutil.NewClock(6, 0)}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb",
+			QuietHours: &QuietHours{Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(6, 0), AllowHighUrgency: true}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Urgency: UrgencyLow},
 	}
 	invalid := []NotificationRule{
 		{},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb",
+			QuietHours: &QuietHours{Start: timeutil.NewClock(6, 0), End: timeutil.NewClock(6, 0)}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Urgency: "medium"},
 	}
 	for _, nr := range valid {
 		test(true, nr)
@@ -0,0 +1,25 @@
+package notificationrule
+
+import (
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+)
+
+// QuietHours defines a daily window, evaluated in the user's time zone, during which a
+// notification rule will not send to its contact method.
+type QuietHours struct {
+	Start timeutil.Clock
+	End   timeutil.Clock
+
+	// AllowHighUrgency, if set, still sends notifications for high-urgency alerts during
+	// the window.
+	AllowHighUrgency bool
+}
+
+func (q QuietHours) validate() error {
+	if q.Start == q.End {
+		return validation.NewFieldError("QuietHours.End", "must differ from start")
+	}
+
+	return nil
+}
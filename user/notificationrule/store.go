@@ -3,11 +3,13 @@ package notificationrule
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -19,6 +21,14 @@ type Store struct {
 	delete       *sql.Stmt
 	findAll      *sql.Stmt
 	lookupUserID *sql.Stmt
+
+	setStop     *sql.Stmt
+	setPosition *sql.Stmt
+	findIDs     *sql.Stmt
+
+	setUrgencyWindow   *sql.Stmt
+	clearUrgencyWindow *sql.Stmt
+	findUrgencyWindow  *sql.Stmt
 }
 
 // NewDB will create a DB backend from a sql.DB. An error will be returned if statements fail to prepare.
@@ -27,10 +37,29 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	p := prep.P
 	s := &Store{db: db}
 
-	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id) VALUES ($1,$2,$3,$4)")
-	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id FROM user_notification_rules WHERE user_id = $1")
+	// new rules are added to the end of the order
+	s.insert = p(`
+		INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id,quiet_hours_start,quiet_hours_end,quiet_hours_allow_high_urgency,urgency,stop_on_success,position)
+		SELECT $1,$2,$3,$4,$5,$6,$7,$8,$9,coalesce(max(position)+1, 0)
+		FROM user_notification_rules
+		WHERE user_id = $2
+		RETURNING position
+	`)
+	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id,quiet_hours_start,quiet_hours_end,quiet_hours_allow_high_urgency,urgency,position,stop_on_success FROM user_notification_rules WHERE user_id = $1 ORDER BY position, delay_minutes")
 	s.delete = p("DELETE FROM user_notification_rules WHERE id = any($1)")
 	s.lookupUserID = p("SELECT user_id FROM user_notification_rules WHERE id = any($1)")
+	s.setStop = p("UPDATE user_notification_rules SET stop_on_success = $2 WHERE id = $1")
+	s.setPosition = p("UPDATE user_notification_rules SET position = array_position($2, id) - 1 WHERE user_id = $1 AND id = any($2)")
+	s.findIDs = p("SELECT id FROM user_notification_rules WHERE user_id = $1 FOR UPDATE")
+
+	s.setUrgencyWindow = p(`
+		INSERT INTO user_urgency_windows (user_id, start_time, end_time)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET start_time = $2, end_time = $3
+	`)
+	s.clearUrgencyWindow = p("DELETE FROM user_urgency_windows WHERE user_id = $1")
+	s.findUrgencyWindow = p("SELECT start_time, end_time FROM user_urgency_windows WHERE user_id = $1")
 
 	return s, prep.Err
 }
@@ -56,7 +85,20 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, n *NotificationRule) (
 
 	n.ID = uuid.New().String()
 
-	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID)
+	var start, end sql.NullString
+	var allowHigh bool
+	if n.QuietHours != nil {
+		start = sql.NullString{String: n.QuietHours.Start.String(), Valid: true}
+		end = sql.NullString{String: n.QuietHours.End.String(), Valid: true}
+		allowHigh = n.QuietHours.AllowHighUrgency
+	}
+
+	var urgency sql.NullString
+	if n.Urgency != UrgencyAny {
+		urgency = sql.NullString{String: string(n.Urgency), Valid: true}
+	}
+
+	err = wrapTx(ctx, tx, s.insert).QueryRowContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID, start, end, allowHigh, urgency, n.StopOnSuccess).Scan(&n.Position)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +159,93 @@ func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
 	return err
 }
 
+// ruleUserIDTx returns the ID of the user the rule belongs to.
+func (s *Store) ruleUserIDTx(ctx context.Context, tx *sql.Tx, id string) (string, error) {
+	var userID string
+	err := wrapTx(ctx, tx, s.lookupUserID).QueryRowContext(ctx, sqlutil.UUIDArray{id}).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", validation.NewFieldError("NotificationRuleID", "not found")
+	}
+
+	return userID, err
+}
+
+// SetStopOnSuccessTx will set whether rules later in the order are skipped once a notification
+// sent by the rule is delivered.
+func (s *Store) SetStopOnSuccessTx(ctx context.Context, tx *sql.Tx, id string, stop bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("NotificationRuleID", id)
+	if err != nil {
+		return err
+	}
+
+	userID, err := s.ruleUserIDTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	_, err = wrapTx(ctx, tx, s.setStop).ExecContext(ctx, id, stop)
+	return err
+}
+
+// SetOrderTx will set the order of a user's notification rules. All of the user's rules
+// must be provided.
+func (s *Store) SetOrderTx(ctx context.Context, tx *sql.Tx, userID string, ruleIDs []string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("UserID", userID),
+		validate.ManyUUID("RuleIDs", ruleIDs, 200),
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := wrapTx(ctx, tx, s.findIDs).QueryContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	current := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		current[id] = true
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// each rule must be listed exactly once
+	for _, id := range ruleIDs {
+		if !current[id] {
+			return validation.NewFieldError("RuleIDs", "must contain each of the user's notification rules once")
+		}
+		delete(current, id)
+	}
+	if len(current) > 0 {
+		return validation.NewFieldError("RuleIDs", "must contain each of the user's notification rules once")
+	}
+
+	_, err = wrapTx(ctx, tx, s.setPosition).ExecContext(ctx, userID, sqlutil.UUIDArray(ruleIDs))
+	return err
+}
+
 // FindAll implements the NotificationRuleStore interface.
 func (s *Store) FindAll(ctx context.Context, userID string) ([]NotificationRule, error) {
 	err := validate.UUID("UserID", userID)
@@ -138,12 +267,85 @@ func (s *Store) FindAll(ctx context.Context, userID string) ([]NotificationRule,
 	notificationrules := []NotificationRule{}
 	for rows.Next() {
 		var n NotificationRule
-		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID)
+		var start, end, urgency sql.NullString
+		var allowHigh bool
+		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID, &start, &end, &allowHigh, &urgency, &n.Position, &n.StopOnSuccess)
 		if err != nil {
 			return nil, err
 		}
+		n.Urgency = Urgency(urgency.String)
+		if start.Valid && end.Valid {
+			n.QuietHours = &QuietHours{AllowHighUrgency: allowHigh}
+			err = n.QuietHours.Start.Scan(start.String)
+			if err != nil {
+				return nil, err
+			}
+			err = n.QuietHours.End.Scan(end.String)
+			if err != nil {
+				return nil, err
+			}
+		}
 		notificationrules = append(notificationrules, n)
 	}
 
 	return notificationrules, nil
 }
+
+// SetUrgencyWindowTx will set the urgency window for the given user. If w is nil, the
+// window is removed and high-urgency alerts always use high-urgency rules.
+func (s *Store) SetUrgencyWindowTx(ctx context.Context, tx *sql.Tx, userID string, w *UrgencyWindow) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if w != nil {
+		err = validate.Many(err, w.validate())
+	}
+	if err != nil {
+		return err
+	}
+
+	if w == nil {
+		_, err = wrapTx(ctx, tx, s.clearUrgencyWindow).ExecContext(ctx, userID)
+		return err
+	}
+
+	_, err = wrapTx(ctx, tx, s.setUrgencyWindow).ExecContext(ctx, userID, w.Start.String(), w.End.String())
+	return err
+}
+
+// FindUrgencyWindow will return the urgency window for the given user, or nil if one is not set.
+func (s *Store) FindUrgencyWindow(ctx context.Context, userID string) (*UrgencyWindow, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	var start, end string
+	err = s.findUrgencyWindow.QueryRowContext(ctx, userID).Scan(&start, &end)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var w UrgencyWindow
+	err = w.Start.Scan(start)
+	if err != nil {
+		return nil, err
+	}
+	err = w.End.Scan(end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
@@ -0,0 +1,32 @@
+package notificationrule
+
+import (
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+)
+
+// Urgency restricts a notification rule to alerts of a given urgency.
+type Urgency string
+
+// Urgency levels. UrgencyAny rules are used for all alerts.
+const (
+	UrgencyAny  Urgency = ""
+	UrgencyHigh Urgency = "high"
+	UrgencyLow  Urgency = "low"
+)
+
+// UrgencyWindow defines a daily window, evaluated in the user's time zone, during which
+// high-urgency alerts notify a user through their high-urgency rules. Outside the window,
+// high-urgency alerts are downgraded and use the low-urgency rules instead.
+type UrgencyWindow struct {
+	Start timeutil.Clock
+	End   timeutil.Clock
+}
+
+func (w UrgencyWindow) validate() error {
+	if w.Start == w.End {
+		return validation.NewFieldError("UrgencyWindow.End", "must differ from start")
+	}
+
+	return nil
+}
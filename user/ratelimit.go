@@ -0,0 +1,66 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SetNotificationRateLimitTx will set the maximum number of alert notifications sent to
+// the given user per hour, where zero means no limit. If limit is nil, it is cleared and
+// the configured default is used.
+func (s *Store) SetNotificationRateLimitTx(ctx context.Context, tx *sql.Tx, userID string, limit *int) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	var val sql.NullInt32
+	if limit != nil {
+		err = validate.Range("MaxNotificationsPerHour", *limit, 0, 1000)
+		if err != nil {
+			return err
+		}
+		val = sql.NullInt32{Int32: int32(*limit), Valid: true}
+	}
+
+	_, err = withTx(ctx, tx, s.setRateLimit).ExecContext(ctx, userID, val)
+	return err
+}
+
+// FindNotificationRateLimit will return the maximum number of alert notifications sent to
+// the given user per hour, or nil if the configured default is used.
+func (s *Store) FindNotificationRateLimit(ctx context.Context, userID string) (*int, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	var val sql.NullInt32
+	err = s.findRateLimit.QueryRowContext(ctx, userID).Scan(&val)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !val.Valid {
+		return nil, nil
+	}
+
+	limit := int(val.Int32)
+	return &limit, nil
+}
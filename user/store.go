@@ -42,6 +42,7 @@ type Store struct {
 	findOneForUpdate *sql.Stmt
 
 	findOneBySubject *sql.Stmt
+	findAllByEmail   *sql.Stmt
 
 	insertUserAuthSubject *sql.Stmt
 	deleteUserAuthSubject *sql.Stmt
@@ -51,6 +52,16 @@ type Store struct {
 
 	findAuthSubjects *sql.Stmt
 
+	setDND   *sql.Stmt
+	clearDND *sql.Stmt
+	findDND  *sql.Stmt
+
+	setTimeZone  *sql.Stmt
+	findTimeZone *sql.Stmt
+
+	setRateLimit  *sql.Stmt
+	findRateLimit *sql.Stmt
+
 	grp *groupcache.Group
 
 	userExistHash []byte
@@ -133,6 +144,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			WHERE s.provider_id = $1 AND s.subject_id = $2
 		`),
 
+		findAllByEmail: p.P(`
+			SELECT
+				id, name, email, avatar_url, role, false
+			FROM users
+			WHERE lower(email) = lower($1)
+			ORDER BY id
+			FOR UPDATE
+		`),
+
 		findOne: p.P(`
 			SELECT
 				u.id, u.name, u.email, u.avatar_url, u.role, fav is distinct from null
@@ -165,6 +185,21 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				provider_id = $2 AND 
 				subject_id = $3
 		`),
+
+		setDND: p.P(`
+			INSERT INTO user_do_not_disturb (user_id, expires_at)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE
+			SET expires_at = $2, created_at = now()
+		`),
+		clearDND: p.P(`DELETE FROM user_do_not_disturb WHERE user_id = $1`),
+		findDND:  p.P(`SELECT expires_at FROM user_do_not_disturb WHERE user_id = $1`),
+
+		setTimeZone:  p.P(`UPDATE users SET time_zone = $2 WHERE id = $1`),
+		findTimeZone: p.P(`SELECT time_zone FROM users WHERE id = $1`),
+
+		setRateLimit:  p.P(`UPDATE users SET max_notifications_per_hour = $2 WHERE id = $1`),
+		findRateLimit: p.P(`SELECT max_notifications_per_hour FROM users WHERE id = $1`),
 	}
 	if p.Err != nil {
 		return nil, p.Err
@@ -601,6 +636,38 @@ func (s *Store) FindOneTx(ctx context.Context, tx *sql.Tx, id string, forUpdate
 	return &u, nil
 }
 
+// FindAllByEmailTx will return all users with the given email address (case-insensitive),
+// locking them for update. Favorite information is omitted (always false).
+func (s *Store) FindAllByEmailTx(ctx context.Context, tx *sql.Tx, email string) ([]User, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.Email("Email", email)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := withTx(ctx, tx, s.findAllByEmail).QueryContext(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []User
+	for rows.Next() {
+		var u User
+		err = u.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+
+	return result, rows.Err()
+}
+
 // FindOneBySubject will find a user matching the subjectID for the given providerID.
 func (s *Store) FindOneBySubject(ctx context.Context, providerID, subjectID string) (*User, error) {
 	err := permission.LimitCheckAny(ctx, permission.Admin)
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SetTimeZoneTx will set the time zone used for timestamps in notifications to the given
+// user. If tz is nil, it is cleared and the default time zone is used.
+func (s *Store) SetTimeZoneTx(ctx context.Context, tx *sql.Tx, userID string, tz *time.Location) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	var name sql.NullString
+	if tz != nil {
+		name = sql.NullString{String: tz.String(), Valid: true}
+	}
+
+	_, err = withTx(ctx, tx, s.setTimeZone).ExecContext(ctx, userID, name)
+	return err
+}
+
+// FindTimeZone will return the time zone of the given user, or nil if one is not set.
+func (s *Store) FindTimeZone(ctx context.Context, userID string) (*time.Location, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	var name sql.NullString
+	err = s.findTimeZone.QueryRowContext(ctx, userID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !name.Valid {
+		return nil, nil
+	}
+
+	return util.LoadLocation(name.String)
+}
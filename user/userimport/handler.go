@@ -0,0 +1,271 @@
+package userimport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/target/goalert/auditlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// MaxRows is the maximum number of rows accepted in a single import request.
+const MaxRows = 1000
+
+// maxBodySize limits the size of the request body.
+const maxBodySize = 4 << 20
+
+// Mode controls how rows matching an existing user (by email) are handled.
+type Mode string
+
+const (
+	// ModeSkip will leave existing users unchanged.
+	ModeSkip Mode = "skip"
+
+	// ModeUpdate will update the name and role of existing users and add any missing contact methods. The
+	// role is only changed if one is provided.
+	ModeUpdate Mode = "update"
+)
+
+// Status indicates the outcome of importing a single row.
+type Status string
+
+// Possible row statuses.
+const (
+	StatusCreated Status = "created"
+	StatusUpdated Status = "updated"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the outcome of importing a single row.
+type Result struct {
+	// Row is the 1-based index of the row in the request, not counting any CSV header.
+	Row    int    `json:"row"`
+	Status Status `json:"status"`
+	UserID string `json:"userID,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Config contains the values needed to implement the import handler.
+type Config struct {
+	DB                 *sql.DB
+	UserStore          *user.Store
+	ContactMethodStore *contactmethod.Store
+	AuditLogStore      *auditlog.Store
+}
+
+// Handler serves bulk user import requests.
+type Handler struct {
+	c Config
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(c Config) *Handler {
+	return &Handler{c: c}
+}
+
+// ServeHTTP handles a batch of users as CSV (`text/csv`) or JSON (`application/json`),
+// responding with the result of each row.
+//
+// Each row is imported in its own transaction, so a failed row will not affect others.
+// Contact methods are always created disabled, and must be verified by the user before use.
+// Created and updated users are recorded in the audit log.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if req.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	mode := Mode(req.URL.Query().Get("mode"))
+	switch mode {
+	case "":
+		mode = ModeSkip
+	case ModeSkip, ModeUpdate:
+	default:
+		errutil.HTTPError(ctx, w, validation.NewFieldError("mode", "must be one of 'skip' or 'update'"))
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxBodySize)
+
+	var rows []Row
+	ct, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch ct {
+	case "text/csv":
+		rows, err = ParseCSV(req.Body)
+	case "application/json":
+		rows, err = ParseJSON(req.Body)
+	default:
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	if len(rows) == 0 {
+		errutil.HTTPError(ctx, w, validation.NewGenericError("no users provided"))
+		return
+	}
+	if len(rows) > MaxRows {
+		errutil.HTTPError(ctx, w, validation.NewGenericError(fmt.Sprintf("too many users; at most %d may be imported at once", MaxRows)))
+		return
+	}
+
+	var resp struct {
+		Results []Result `json:"results"`
+	}
+	resp.Results = make([]Result, 0, len(rows))
+	for i, r := range rows {
+		res := Result{Row: i + 1}
+		res.Status, res.UserID, err = h.importRow(ctx, mode, r)
+		if err != nil {
+			res.Status = StatusFailed
+			res.Error = errutil.MapDBError(err).Error()
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	log.Logf(ctx, "UserImport: processed %d row(s) (mode=%s)", len(rows), mode)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		log.Log(ctx, err)
+	}
+}
+
+func (h *Handler) importRow(ctx context.Context, mode Mode, r Row) (Status, string, error) {
+	n, err := r.Normalize()
+	if err != nil {
+		return StatusFailed, "", err
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return StatusFailed, "", err
+	}
+	defer sqlutil.Rollback(ctx, "userimport: import row", tx)
+
+	existing, err := h.c.UserStore.FindAllByEmailTx(ctx, tx, n.Email)
+	if err != nil {
+		return StatusFailed, "", err
+	}
+
+	var status Status
+	var u *user.User
+	e := auditlog.Entry{Action: "importUsers", EntityType: auditlog.EntityTypeUser}
+	switch {
+	case len(existing) > 1:
+		return StatusFailed, "", validation.NewFieldError("Email", "matches multiple existing users")
+	case len(existing) == 1 && mode == ModeSkip:
+		return StatusSkipped, existing[0].ID, nil
+	case len(existing) == 1:
+		status = StatusUpdated
+		u = &existing[0]
+		err = e.SetBefore(u)
+		if err != nil {
+			return StatusFailed, u.ID, err
+		}
+		u.Name = n.Name
+		err = h.c.UserStore.UpdateTx(ctx, tx, u)
+		if err != nil {
+			return StatusFailed, u.ID, err
+		}
+		if n.Role != "" {
+			// only change the role if it was provided, so a blank column doesn't demote admins
+			u.Role = permission.Role(n.Role)
+			err = h.c.UserStore.SetUserRoleTx(ctx, tx, u.ID, u.Role)
+		}
+	default:
+		status = StatusCreated
+		u, err = h.c.UserStore.InsertTx(ctx, tx, &user.User{
+			Name:  n.Name,
+			Email: n.Email,
+			Role:  n.RoleOrDefault(),
+		})
+	}
+	if err != nil {
+		return StatusFailed, "", err
+	}
+
+	err = h.addContactMethods(ctx, tx, status == StatusUpdated, u.ID, n)
+	if err != nil {
+		return StatusFailed, u.ID, err
+	}
+
+	e.EntityID = u.ID
+	err = e.SetAfter(u)
+	if err != nil {
+		return StatusFailed, u.ID, err
+	}
+	err = h.c.AuditLogStore.LogTx(ctx, tx, e)
+	if err != nil {
+		return StatusFailed, u.ID, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return StatusFailed, u.ID, err
+	}
+
+	return status, u.ID, nil
+}
+
+// addContactMethods will create disabled (unverified) contact methods for the row. If checkExisting
+// is set, contact methods the user already has are skipped.
+func (h *Handler) addContactMethods(ctx context.Context, tx *sql.Tx, checkExisting bool, userID string, r *Row) error {
+	cms := []contactmethod.ContactMethod{
+		{Name: "Email", Type: contactmethod.TypeEmail, Value: r.Email},
+	}
+	if r.Phone != "" {
+		cms = append(cms,
+			contactmethod.ContactMethod{Name: "SMS", Type: contactmethod.TypeSMS, Value: r.Phone},
+			contactmethod.ContactMethod{Name: "Voice", Type: contactmethod.TypeVoice, Value: r.Phone},
+		)
+	}
+
+	has := make(map[contactmethod.Type]map[string]bool)
+	if checkExisting {
+		current, err := h.c.ContactMethodStore.FindAll(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, cm := range current {
+			if has[cm.Type] == nil {
+				has[cm.Type] = make(map[string]bool)
+			}
+			has[cm.Type][cm.Value] = true
+		}
+	}
+
+	for _, cm := range cms {
+		if has[cm.Type][cm.Value] {
+			continue
+		}
+
+		cm.UserID = userID
+		cm.Disabled = true
+		_, err := h.c.ContactMethodStore.CreateTx(ctx, tx, &cm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package userimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Row is a single user to be imported.
+type Row struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Normalize will validate the row and return a normalized copy. The role is left
+// empty if not provided, see RoleOrDefault.
+func (r Row) Normalize() (*Row, error) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Phone = strings.TrimSpace(r.Phone)
+	r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+
+	err := validate.Name("Name", r.Name)
+	if r.Role != "" {
+		err = validate.Many(err, validate.OneOf("Role", permission.Role(r.Role), permission.RoleAdmin, permission.RoleUser))
+	}
+	if r.Email == "" {
+		err = validate.Many(err, validation.NewFieldError("Email", "is required"))
+	} else {
+		err = validate.Many(err, validate.Email("Email", r.Email))
+	}
+	if r.Phone != "" {
+		err = validate.Many(err, validate.Phone("Phone", r.Phone))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.Email = validate.SanitizeEmail(r.Email)
+	return &r, nil
+}
+
+// RoleOrDefault returns the role of the row, or `user` if it is empty.
+func (r Row) RoleOrDefault() permission.Role {
+	if r.Role == "" {
+		return permission.RoleUser
+	}
+
+	return permission.Role(r.Role)
+}
+
+// ParseCSV will parse rows from CSV data. The first line must be a header
+// containing `name` and `email` columns, with optional `role` and `phone` columns.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, validation.NewGenericError("missing CSV header")
+	}
+	if err != nil {
+		return nil, validation.NewGenericError("invalid CSV: " + err.Error())
+	}
+
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		switch h {
+		case "name", "email", "role", "phone":
+		default:
+			return nil, validation.NewGenericError(fmt.Sprintf("unknown CSV column '%s'", h))
+		}
+		if _, ok := cols[h]; ok {
+			return nil, validation.NewGenericError(fmt.Sprintf("duplicate CSV column '%s'", h))
+		}
+		cols[h] = i
+	}
+	for _, req := range []string{"name", "email"} {
+		if _, ok := cols[req]; !ok {
+			return nil, validation.NewGenericError(fmt.Sprintf("missing required CSV column '%s'", req))
+		}
+	}
+
+	col := func(rec []string, name string) string {
+		i, ok := cols[name]
+		if !ok {
+			return ""
+		}
+		return rec[i]
+	}
+
+	var rows []Row
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, validation.NewGenericError("invalid CSV: " + err.Error())
+		}
+
+		rows = append(rows, Row{
+			Name:  col(rec, "name"),
+			Email: col(rec, "email"),
+			Role:  col(rec, "role"),
+			Phone: col(rec, "phone"),
+		})
+	}
+
+	return rows, nil
+}
+
+// ParseJSON will parse rows from JSON data. Either an array of rows or an
+// object with a `users` array is accepted.
+func ParseJSON(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &rows)
+	} else {
+		var batch struct {
+			Users []Row `json:"users"`
+		}
+		err = json.Unmarshal(data, &batch)
+		rows = batch.Users
+	}
+	if err != nil {
+		return nil, validation.NewGenericError("invalid JSON: " + err.Error())
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,68 @@
+package userimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/permission"
+)
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("Email,Name,Phone\nfoo@example.com,Foo Bar,+17633453456\nbaz@example.com,Baz,\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Name: "Foo Bar", Email: "foo@example.com", Phone: "+17633453456"},
+		{Name: "Baz", Email: "baz@example.com"},
+	}, rows)
+
+	_, err = ParseCSV(strings.NewReader("name,phone\nFoo,+17633453456\n"))
+	assert.Error(t, err, "missing email column")
+
+	_, err = ParseCSV(strings.NewReader("name,email,foo\n"))
+	assert.Error(t, err, "unknown column")
+
+	_, err = ParseCSV(strings.NewReader("name,email\nFoo\n"))
+	assert.Error(t, err, "wrong number of fields")
+}
+
+func TestParseJSON(t *testing.T) {
+	expected := []Row{{Name: "Foo", Email: "foo@example.com", Role: "admin"}}
+
+	rows, err := ParseJSON(strings.NewReader(`{"users":[{"name":"Foo","email":"foo@example.com","role":"admin"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, rows)
+
+	rows, err = ParseJSON(strings.NewReader(` [{"name":"Foo","email":"foo@example.com","role":"admin"}]`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, rows)
+
+	_, err = ParseJSON(strings.NewReader(`{"users":`))
+	assert.Error(t, err)
+}
+
+func TestRow_Normalize(t *testing.T) {
+	n, err := Row{Name: " Foo ", Email: "Foo@Example.com", Role: "Admin"}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "Foo", n.Name)
+	assert.Equal(t, "admin", n.Role)
+
+	n, err = Row{Name: "Foo", Email: "foo@example.com"}.Normalize()
+	require.NoError(t, err)
+	assert.Empty(t, n.Role, "role not provided")
+	assert.Equal(t, permission.RoleUser, n.RoleOrDefault(), "default role")
+	assert.Equal(t, permission.RoleAdmin, Row{Role: "admin"}.RoleOrDefault())
+
+	_, err = Row{Name: "Foo"}.Normalize()
+	assert.Error(t, err, "missing email")
+
+	_, err = Row{Name: "Foo", Email: "not an email"}.Normalize()
+	assert.Error(t, err, "invalid email")
+
+	_, err = Row{Name: "Foo", Email: "foo@example.com", Phone: "555-1234"}.Normalize()
+	assert.Error(t, err, "invalid phone")
+
+	_, err = Row{Name: "Foo", Email: "foo@example.com", Role: "owner"}.Normalize()
+	assert.Error(t, err, "invalid role")
+}
@@ -65,6 +65,8 @@ func HTTPError(ctx context.Context, w http.ResponseWriter, err error) bool {
 		// possibility to be rate limited, and not sequential requests,
 		// even in the worst case scenario.
 		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+	case errors.Is(err, ErrRateLimited):
+		http.Error(w, unwrapAll(err).Error(), http.StatusTooManyRequests)
 	case isCancel(err):
 		// Client disconnected, send 400 back so logs reflect that this
 		// was a client-side problem.
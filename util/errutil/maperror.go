@@ -89,6 +89,20 @@ func MapDBError(err error) error {
 		if strings.Contains(dbErr.Detail, "is not present") {
 			return validation.NewFieldError("EscalationPolicyID", "does not exist")
 		}
+	case "escalation_policy_steps_routing_business_hours_id_fkey":
+		if strings.Contains(dbErr.Detail, "is still referenced") {
+			return validation.NewFieldError("BusinessHoursID", "is currently in use by an escalation policy step")
+		}
+		if strings.Contains(dbErr.Detail, "is not present") {
+			return validation.NewFieldError("RoutingBusinessHoursID", "does not exist")
+		}
+	case "escalation_policy_steps_delay_business_hours_id_fkey":
+		if strings.Contains(dbErr.Detail, "is still referenced") {
+			return validation.NewFieldError("BusinessHoursID", "is currently in use by an escalation policy step")
+		}
+		if strings.Contains(dbErr.Detail, "is not present") {
+			return validation.NewFieldError("DelayBusinessHoursID", "does not exist")
+		}
 	}
 
 	return err
@@ -0,0 +1,6 @@
+package errutil
+
+import "errors"
+
+// ErrRateLimited is returned (or wrapped) when a request is rejected for exceeding a rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
@@ -0,0 +1,24 @@
+package validate
+
+import (
+	"strconv"
+
+	"github.com/target/goalert/validation"
+)
+
+// TelegramChatID will validate a Telegram chat ID, returning a FieldError
+// if invalid. Chat IDs are non-zero integers, and are negative for group chats.
+func TelegramChatID(fname, value string) error {
+	if value == "" {
+		return validation.NewFieldError(fname, "must not be empty")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return validation.NewFieldError(fname, "must be a numeric chat ID")
+	}
+	if id == 0 {
+		return validation.NewFieldError(fname, "must not be zero")
+	}
+
+	return nil
+}
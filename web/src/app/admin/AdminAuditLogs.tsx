@@ -0,0 +1,137 @@
+import React from 'react'
+import { gql } from '@apollo/client'
+import {
+  Card,
+  FormControl,
+  Grid,
+  InputLabel,
+  MenuItem,
+  Select,
+  Typography,
+} from '@mui/material'
+import QueryList from '../lists/QueryList'
+import { UserSelect } from '../selection'
+import { useURLParams } from '../actions'
+import { Time } from '../util/Time'
+import { AuditLogChange } from '../../schema'
+
+const query = gql`
+  query auditLogs($input: AuditLogSearchOptions) {
+    data: auditLogs(input: $input) {
+      nodes {
+        id
+        timestamp
+        actor {
+          id
+          name
+        }
+        actorSource
+        action
+        entityType
+        entityID
+        changes {
+          field
+        }
+        error
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+`
+
+const entityTypes = [
+  { value: 'integrationKey', label: 'Integration Key' },
+  { value: 'escalationPolicy', label: 'Escalation Policy' },
+  { value: 'escalationPolicyStep', label: 'Escalation Policy Step' },
+  { value: 'user', label: 'User' },
+  { value: 'userOverride', label: 'User Override' },
+  { value: 'outgoingMessage', label: 'Outgoing Message' },
+  { value: 'gqlAPIKey', label: 'GraphQL API Key' },
+  { value: 'incidentCorrelationRule', label: 'Incident Correlation Rule' },
+  { value: 'service', label: 'Service' },
+  { value: 'config', label: 'Config' },
+  { value: 'systemLimit', label: 'System Limit' },
+]
+
+export default function AdminAuditLogs(): JSX.Element {
+  const [params, setParams] = useURLParams({
+    actorID: '',
+    entityType: '',
+  })
+
+  return (
+    <Grid container spacing={2}>
+      <Grid item xs={12}>
+        <Card>
+          <Grid container spacing={1} sx={{ padding: 2 }}>
+            <Grid item sx={{ minWidth: '325px' }}>
+              <UserSelect
+                label='Filter by actor...'
+                value={params.actorID || null}
+                onChange={(actorID: string | null) =>
+                  setParams({ ...params, actorID: actorID || '' })
+                }
+              />
+            </Grid>
+            <Grid item>
+              <FormControl sx={{ minWidth: '250px' }}>
+                <InputLabel id='entity-type-label'>Entity Type</InputLabel>
+                <Select
+                  labelId='entity-type-label'
+                  label='Entity Type'
+                  value={params.entityType}
+                  onChange={(e) =>
+                    setParams({ ...params, entityType: e.target.value })
+                  }
+                >
+                  <MenuItem value=''>All</MenuItem>
+                  {entityTypes.map((t) => (
+                    <MenuItem key={t.value} value={t.value}>
+                      {t.label}
+                    </MenuItem>
+                  ))}
+                </Select>
+              </FormControl>
+            </Grid>
+          </Grid>
+        </Card>
+      </Grid>
+      <Grid item xs={12}>
+        <QueryList
+          query={query}
+          noSearch
+          variables={{
+            input: {
+              filterByActorID: params.actorID || null,
+              filterByEntityType: params.entityType || null,
+            },
+          }}
+          mapDataNode={(n) => ({
+            title: `${n.action} (${n.entityType} ${n.entityID})`,
+            status: n.error ? 'err' : undefined,
+            subText: (
+              <React.Fragment>
+                <Typography variant='body2' component='span'>
+                  <Time time={n.timestamp} /> by{' '}
+                  {n.actor?.name ?? 'unknown'} via {n.actorSource}
+                </Typography>
+                <br />
+                <Typography variant='body2' component='span'>
+                  {n.error
+                    ? 'Failed: ' + n.error
+                    : 'Changed: ' +
+                      (n.changes
+                        .map((c: AuditLogChange) => c.field)
+                        .join(', ') || 'nothing')}
+                </Typography>
+              </React.Fragment>
+            ),
+          })}
+        />
+      </Grid>
+    </Grid>
+  )
+}
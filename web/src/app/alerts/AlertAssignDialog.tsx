@@ -0,0 +1,84 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from '@apollo/client'
+import Grid from '@mui/material/Grid'
+
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import { UserSelect } from '../selection'
+import NumberField from '../util/NumberField'
+import { nonFieldErrors, fieldErrors } from '../util/errutil'
+
+interface Props {
+  alertID: number
+  onClose: () => void
+}
+
+interface Value {
+  userID: string | null
+  pauseEscalationMinutes: number
+}
+
+const mutation = gql`
+  mutation AssignAlertMutation($input: AssignAlertInput!) {
+    assignAlert(input: $input)
+  }
+`
+
+export default function AlertAssignDialog(props: Props): JSX.Element {
+  const [value, setValue] = useState<Value>({
+    userID: null,
+    pauseEscalationMinutes: 0,
+  })
+  const [assignAlert, status] = useMutation(mutation, {
+    refetchQueries: ['AlertDetailsPageQuery'],
+    onCompleted: props.onClose,
+  })
+
+  return (
+    <FormDialog
+      title='Assign Alert'
+      subTitle='The assignee is notified through their notification rules.'
+      loading={status.loading}
+      errors={nonFieldErrors(status.error)}
+      onClose={props.onClose}
+      onSubmit={() =>
+        assignAlert({
+          variables: {
+            input: { alertID: props.alertID, ...value },
+          },
+        })
+      }
+      form={
+        <FormContainer
+          errors={fieldErrors(status.error)}
+          disabled={status.loading}
+          value={value}
+          onChange={(value: Value) => setValue(value)}
+        >
+          <Grid container spacing={2}>
+            <Grid item xs={12}>
+              <FormField
+                component={UserSelect}
+                fullWidth
+                required
+                name='userID'
+                label='Assignee'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                component={NumberField}
+                fullWidth
+                name='pauseEscalationMinutes'
+                label='Pause Escalation (minutes)'
+                hint='Give the assignee time to respond before escalation resumes. Zero keeps normal escalation.'
+                min={0}
+                max={1440}
+              />
+            </Grid>
+          </Grid>
+        </FormContainer>
+      }
+    />
+  )
+}
@@ -0,0 +1,78 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from '@apollo/client'
+import { MenuItem, TextField } from '@mui/material'
+
+import FormDialog from '../dialogs/FormDialog'
+import { nonFieldErrors, fieldErrors } from '../util/errutil'
+import { AlertCloseReason } from '../../schema'
+
+interface Props {
+  alertID: number
+  reasonRequired?: boolean
+  onClose: () => void
+}
+
+const mutation = gql`
+  mutation CloseAlertMutation($input: UpdateAlertsInput!) {
+    updateAlerts(input: $input) {
+      id
+    }
+  }
+`
+
+const reasons: Array<{ value: AlertCloseReason; label: string }> = [
+  { value: 'resolved', label: 'Resolved' },
+  { value: 'falsePositive', label: 'False positive' },
+  { value: 'duplicate', label: 'Duplicate' },
+  { value: 'wontfix', label: "Won't fix" },
+]
+
+export default function AlertCloseDialog(props: Props): JSX.Element {
+  const [reason, setReason] = useState<AlertCloseReason | ''>('')
+  const [closeAlert, status] = useMutation(mutation, {
+    refetchQueries: ['AlertDetailsPageQuery'],
+    onCompleted: props.onClose,
+  })
+  const reasonErr = fieldErrors(status.error).find(
+    (e) => e.field === 'closeReason',
+  )
+
+  return (
+    <FormDialog
+      title='Close Alert'
+      loading={status.loading}
+      errors={nonFieldErrors(status.error)}
+      onClose={props.onClose}
+      onSubmit={() =>
+        closeAlert({
+          variables: {
+            input: {
+              alertIDs: [props.alertID],
+              newStatus: 'StatusClosed',
+              closeReason: reason || null,
+            },
+          },
+        })
+      }
+      form={
+        <TextField
+          select
+          fullWidth
+          label={props.reasonRequired ? 'Reason' : 'Reason (optional)'}
+          required={props.reasonRequired}
+          value={reason}
+          onChange={(e) => setReason(e.target.value as AlertCloseReason)}
+          error={!!reasonErr}
+          helperText={reasonErr?.message}
+        >
+          {!props.reasonRequired && <MenuItem value=''>None</MenuItem>}
+          {reasons.map((r) => (
+            <MenuItem key={r.value} value={r.value}>
+              {r.label}
+            </MenuItem>
+          ))}
+        </TextField>
+      }
+    />
+  )
+}
@@ -1,6 +1,7 @@
 import React, { useState } from 'react'
-import { useQuery, gql } from '@apollo/client'
+import { useQuery, useMutation, gql } from '@apollo/client'
 import Button from '@mui/material/Button'
+import IconButton from '@mui/material/IconButton'
 import List from '@mui/material/List'
 import ListItem from '@mui/material/ListItem'
 import ListItemText from '@mui/material/ListItemText'
@@ -10,6 +11,8 @@ import { POLL_INTERVAL } from '../config'
 import { Time } from '../util/Time'
 import { AlertLogEntry, NotificationStatus } from '../../schema'
 import { AlertColor } from '@mui/material'
+import { Delete, Edit } from '@mui/icons-material'
+import AlertNoteForm from './AlertNoteForm'
 
 const FETCH_LIMIT = 149
 const QUERY_LIMIT = 35
@@ -27,6 +30,10 @@ const query = gql`
             details
             status
           }
+          note {
+            text
+            editable
+          }
         }
         pageInfo {
           hasNextPage
@@ -37,6 +44,12 @@ const query = gql`
   }
 `
 
+const deleteNoteMutation = gql`
+  mutation DeleteAlertNote($id: Int!) {
+    deleteAlertNote(id: $id)
+  }
+`
+
 const useStyles = makeStyles({
   logTimeContainer: {
     width: 'max-content',
@@ -53,11 +66,14 @@ export default function AlertDetailLogs(
 ): JSX.Element {
   const classes = useStyles()
   const [poll, setPoll] = useState(POLL_INTERVAL)
-  const { data, error, loading, fetchMore } = useQuery(query, {
+  const [editNoteID, setEditNoteID] = useState<number | null>(null)
+  const { data, error, loading, fetchMore, refetch } = useQuery(query, {
     pollInterval: poll,
     variables: { id: props.alertID, input: { limit: QUERY_LIMIT } },
   })
 
+  const [deleteNote] = useMutation(deleteNoteMutation)
+
   const events = _.orderBy(
     data?.alert?.recentEvents?.nodes ?? [],
     ['timestamp'],
@@ -98,6 +114,9 @@ export default function AlertDetailLogs(
   ): JSX.Element => {
     return (
       <List data-cy='alert-logs'>
+        <ListItem divider>
+          <AlertNoteForm alertID={props.alertID} onDone={() => refetch()} />
+        </ListItem>
         {items}
         {loadMore && (
           <Button
@@ -136,6 +155,22 @@ export default function AlertDetailLogs(
     const details = _.upperFirst(event?.state?.details ?? '')
     const status = (event?.state?.status ?? '') as NotificationStatus
 
+    if (event.note && editNoteID === event.id) {
+      return (
+        <ListItem key={idx} divider>
+          <AlertNoteForm
+            noteID={event.id}
+            value={event.note.text}
+            onDone={() => {
+              setEditNoteID(null)
+              refetch()
+            }}
+            onCancel={() => setEditNoteID(null)}
+          />
+        </ListItem>
+      )
+    }
+
     return (
       <ListItem key={idx} divider>
         <ListItemText
@@ -145,6 +180,26 @@ export default function AlertDetailLogs(
             color: status && getLogStatusClass(status),
           }}
         />
+        {event.note?.editable && (
+          <div>
+            <IconButton
+              aria-label='Edit Note'
+              onClick={() => setEditNoteID(event.id)}
+            >
+              <Edit />
+            </IconButton>
+            <IconButton
+              aria-label='Delete Note'
+              onClick={() =>
+                deleteNote({ variables: { id: event.id } }).then(() =>
+                  refetch(),
+                )
+              }
+            >
+              <Delete />
+            </IconButton>
+          </div>
+        )}
         <div>
           <ListItemText
             className={classes.logTimeContainer}
@@ -0,0 +1,83 @@
+import React, { useState } from 'react'
+import { useMutation, gql } from '@apollo/client'
+import Button from '@mui/material/Button'
+import Grid from '@mui/material/Grid'
+import TextField from '@mui/material/TextField'
+
+const addMutation = gql`
+  mutation AddAlertNote($input: AddAlertNoteInput!) {
+    addAlertNote(input: $input)
+  }
+`
+
+const updateMutation = gql`
+  mutation UpdateAlertNote($input: UpdateAlertNoteInput!) {
+    updateAlertNote(input: $input)
+  }
+`
+
+interface AlertNoteFormProps {
+  // alertID is required when adding a new note.
+  alertID?: number
+
+  // noteID is the log entry ID of the note being edited, if any.
+  noteID?: number
+  value?: string
+
+  onDone: () => void
+  onCancel?: () => void
+}
+
+export default function AlertNoteForm(props: AlertNoteFormProps): JSX.Element {
+  const [note, setNote] = useState(props.value ?? '')
+  const editing = props.noteID !== undefined
+  const [commit, status] = useMutation(editing ? updateMutation : addMutation)
+
+  const submit = (e: React.FormEvent): void => {
+    e.preventDefault()
+    commit({
+      variables: {
+        input: editing
+          ? { id: props.noteID, note }
+          : { alertID: props.alertID, note },
+      },
+    }).then(() => {
+      if (!editing) setNote('')
+      props.onDone()
+    })
+  }
+
+  return (
+    <form onSubmit={submit} style={{ width: '100%' }}>
+      <Grid container spacing={1} alignItems='center'>
+        <Grid item xs>
+          <TextField
+            fullWidth
+            size='small'
+            placeholder={editing ? '' : 'Add a note...'}
+            value={note}
+            onChange={(e) => setNote(e.target.value)}
+            error={!!status.error}
+            helperText={status.error?.message}
+            inputProps={{ maxLength: 1024 }}
+            data-cy='alert-note-input'
+          />
+        </Grid>
+        <Grid item>
+          <Button
+            type='submit'
+            variant='contained'
+            disabled={!note.trim() || status.loading}
+          >
+            {editing ? 'Save' : 'Add Note'}
+          </Button>
+        </Grid>
+        {props.onCancel && (
+          <Grid item>
+            <Button onClick={props.onCancel}>Cancel</Button>
+          </Grid>
+        )}
+      </Grid>
+    </form>
+  )
+}
@@ -19,6 +19,7 @@ import {
   ArrowUpward as EscalateIcon,
   Check as AcknowledgeIcon,
   Close as CloseIcon,
+  PersonAdd as AssignIcon,
 } from '@mui/icons-material'
 import { gql, useMutation } from '@apollo/client'
 import { DateTime } from 'luxon'
@@ -33,6 +34,8 @@ import {
 import { styles as globalStyles } from '../../styles/materialStyles'
 import Markdown from '../../util/Markdown'
 import AlertDetailLogs from '../AlertDetailLogs'
+import AlertCloseDialog from '../AlertCloseDialog'
+import AlertAssignDialog from '../AlertAssignDialog'
 import AppLink from '../../util/AppLink'
 import CardActions from '../../details/CardActions'
 import {
@@ -113,14 +116,8 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
       },
     },
   })
-  const [close] = useMutation(updateStatusMutation, {
-    variables: {
-      input: {
-        alertIDs: [props.data.id],
-        newStatus: 'StatusClosed',
-      },
-    },
-  })
+  const [showCloseDialog, setShowCloseDialog] = useState(false)
+  const [showAssignDialog, setShowAssignDialog] = useState(false)
   const [escalate] = useMutation(
     gql`
       mutation EscalateAlertMutation($input: [Int!]) {
@@ -339,6 +336,100 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
     )
   }
 
+  function renderAlertMeta(): ReactNode {
+    const meta = props.data.meta ?? []
+    if (!meta.length) return null
+
+    return (
+      <Grid
+        item
+        xs={12}
+        data-cy='alert-meta'
+        className={classes.cardContainer}
+      >
+        <Card sx={{ width: '100%', overflowX: 'auto' }}>
+          <CardContent>
+            <Typography component='h3' variant='h5'>
+              Context
+            </Typography>
+          </CardContent>
+          <CardContent className={classes.tableCardContent}>
+            <Table>
+              <TableBody>
+                {meta.map((m) => (
+                  <TableRow key={m.key}>
+                    <TableCell component='th' scope='row'>
+                      {m.key}
+                    </TableCell>
+                    <TableCell>
+                      {/^https?:\/\//.test(m.value) ? (
+                        <AppLink to={m.value} newTab>
+                          {m.value}
+                        </AppLink>
+                      ) : (
+                        m.value
+                      )}
+                    </TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          </CardContent>
+        </Card>
+      </Grid>
+    )
+  }
+
+  function renderIncident(): ReactNode {
+    const incident = props.data.incident
+    if (!incident) return null
+
+    return (
+      <Grid
+        item
+        xs={12}
+        data-cy='alert-incident'
+        className={classes.cardContainer}
+      >
+        <Card sx={{ width: '100%', overflowX: 'auto' }}>
+          <CardContent>
+            <Typography component='h3' variant='h5'>
+              Incident: {incident.summary}
+            </Typography>
+            {incident.groupedAck && (
+              <Typography variant='body2' color='textSecondary'>
+                Acknowledging any alert in this incident acknowledges all of
+                them.
+              </Typography>
+            )}
+          </CardContent>
+          <CardContent className={classes.tableCardContent}>
+            <Table>
+              <TableBody>
+                {incident.alerts.map((a) => (
+                  <TableRow key={a.id}>
+                    <TableCell component='th' scope='row'>
+                      {a.alertID === props.data.alertID ? (
+                        a.alertID
+                      ) : (
+                        <AppLink to={`/alerts/${a.alertID}`}>
+                          {a.alertID}
+                        </AppLink>
+                      )}
+                    </TableCell>
+                    <TableCell>{a.status.replace('Status', '')}</TableCell>
+                    <TableCell>{a.service?.name}</TableCell>
+                    <TableCell>{a.summary}</TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          </CardContent>
+        </Card>
+      </Grid>
+    )
+  }
+
   /*
    * Options to show for alert details menu
    */
@@ -368,14 +459,41 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
         >
           Escalate
         </Button>
+        {status === 'StatusUnacknowledged' && (
+          <Button
+            startIcon={<AssignIcon />}
+            onClick={() =>
+              alertAction('alert_assigned', () => setShowAssignDialog(true))
+            }
+          >
+            Assign
+          </Button>
+        )}
         <Button
           startIcon={<CloseIcon />}
-          onClick={() => alertAction('alert_closed', close)}
+          onClick={() =>
+            alertAction('alert_closed', () => setShowCloseDialog(true))
+          }
         >
           Close
         </Button>
       </ButtonGroup>,
-    ]
+      showCloseDialog && (
+        <AlertCloseDialog
+          key='close-alert-dialog'
+          alertID={props.data.id}
+          reasonRequired={props.data?.service?.requireCloseReason}
+          onClose={() => setShowCloseDialog(false)}
+        />
+      ),
+      showAssignDialog && (
+        <AlertAssignDialog
+          key='assign-alert-dialog'
+          alertID={props.data.id}
+          onClose={() => setShowAssignDialog(false)}
+        />
+      ),
+    ].filter((e): e is JSX.Element => !!e)
   }
 
   const { data: alert } = props
@@ -451,6 +569,13 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
                   {alert.status.toUpperCase().replace('STATUS', '')}
                 </Typography>
               </Grid>
+              {alert.assignee && (
+                <Grid item xs={12}>
+                  <Typography variant='body2' data-cy='alert-assignee'>
+                    Assigned to {alert.assignee.name}
+                  </Typography>
+                </Grid>
+              )}
             </Grid>
           </CardContent>
           <CardActions primaryActions={getMenuOptions()} />
@@ -462,6 +587,8 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
         </Grid>
       )}
       {renderAlertDetails()}
+      {renderAlertMeta()}
+      {renderIncident()}
 
       {/* Escalation Policy Info */}
       <Grid item xs={12} className={classes.cardContainer}>
@@ -14,10 +14,34 @@ const query = gql`
       details
       createdAt
       noiseReason
+      assignee {
+        id
+        name
+      }
+      meta {
+        key
+        value
+      }
+      incident {
+        id
+        summary
+        groupedAck
+        alerts {
+          id
+          alertID
+          status
+          summary
+          service {
+            id
+            name
+          }
+        }
+      }
       service {
         id
         name
         maintenanceExpiresAt
+        requireCloseReason
         escalationPolicy {
           id
           repeat
@@ -3,6 +3,7 @@ import { gql, useQuery } from 'urql'
 import { Switch, Route, useLocation, RouteProps, useRoute } from 'wouter'
 import AdminMessageLogsLayout from '../admin/admin-message-logs/AdminMessageLogsLayout'
 import AdminAlertCounts from '../admin/admin-alert-counts/AdminAlertCounts'
+import AdminAuditLogs from '../admin/AdminAuditLogs'
 import AdminConfig from '../admin/AdminConfig'
 import AdminLimits from '../admin/AdminLimits'
 import AdminToolbox from '../admin/AdminToolbox'
@@ -116,6 +117,7 @@ export const routes: Record<string, JSXElementConstructor<any>> = {
   '/admin/toolbox': AdminToolbox,
   '/admin/message-logs': AdminMessageLogsLayout,
   '/admin/alert-counts': AdminAlertCounts,
+  '/admin/audit-logs': AdminAuditLogs,
   '/admin/switchover': AdminSwitchover,
   '/admin/switchover/guide': AdminSwitchoverGuide,
 
@@ -81,6 +81,7 @@ export default function NavBar(): JSX.Element {
               <NavBarSubLink to='/admin/toolbox' title='Toolbox' />
               <NavBarSubLink to='/admin/message-logs' title='Message Logs' />
               <NavBarSubLink to='/admin/alert-counts' title='Alert Counts' />
+              <NavBarSubLink to='/admin/audit-logs' title='Audit Logs' />
               <NavBarSubLink to='/admin/switchover' title='Switchover' />
             </NavBarLink>
           </RequireConfig>
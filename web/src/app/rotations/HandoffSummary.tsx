@@ -10,7 +10,8 @@ export interface HandoffSummaryProps {
 }
 
 function dur(p: HandoffSummaryProps): JSX.Element | string {
-  if (p.type === 'hourly') return <Time duration={{ hours: p.shiftLength }} />
+  if (p.type === 'hourly' || p.type === 'fixed_hours')
+    return <Time duration={{ hours: p.shiftLength }} />
   if (p.type === 'daily') return <Time duration={{ days: p.shiftLength }} />
   if (p.type === 'weekly') return <Time duration={{ weeks: p.shiftLength }} />
   if (p.type === 'monthly') return `${p.shiftLength} month(s)` // TODO: update Time to support months
@@ -20,6 +21,15 @@ function dur(p: HandoffSummaryProps): JSX.Element | string {
 function ts(p: HandoffSummaryProps): JSX.Element | string {
   if (p.type === 'hourly')
     return <Time prefix='from ' time={p.start} zone={p.timeZone} />
+  if (p.type === 'fixed_hours')
+    return (
+      <Time
+        prefix='from '
+        suffix=' (elapsed time, unaffected by DST)'
+        time={p.start}
+        zone={p.timeZone}
+      />
+    )
   if (p.type === 'daily')
     return <Time prefix='at ' time={p.start} zone={p.timeZone} format='clock' />
   if (p.type === 'weekly')
@@ -3,10 +3,12 @@ import { gql, useQuery } from 'urql'
 import _ from 'lodash'
 import { Redirect } from 'wouter'
 import { Edit, Delete } from '@mui/icons-material'
+import Grid from '@mui/material/Grid'
 import DetailsPage from '../details/DetailsPage'
 import RotationEditDialog from './RotationEditDialog'
 import RotationDeleteDialog from './RotationDeleteDialog'
 import RotationUserList from './RotationUserList'
+import RotationShadowList from './RotationShadowList'
 import { QuerySetFavoriteButton } from '../util/QuerySetFavoriteButton'
 import Spinner from '../loading/components/Spinner'
 import { ObjectNotFound, GenericError } from '../error-pages'
@@ -76,7 +78,16 @@ export default function RotationDetails(props: {
         title={data.name}
         subheader={<HandoffSummary {...data} />}
         details={data.description}
-        pageContent={<RotationUserList rotationID={props.rotationID} />}
+        pageContent={
+          <Grid container spacing={2}>
+            <Grid item xs={12}>
+              <RotationUserList rotationID={props.rotationID} />
+            </Grid>
+            <Grid item xs={12}>
+              <RotationShadowList rotationID={props.rotationID} />
+            </Grid>
+          </Grid>
+        }
         secondaryActions={[
           {
             label: 'Edit',
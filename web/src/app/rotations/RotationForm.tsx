@@ -27,7 +27,7 @@ const query = gql`
   }
 `
 
-const rotationTypes = ['hourly', 'daily', 'weekly']
+const rotationTypes = ['hourly', 'fixed_hours', 'daily', 'weekly']
 
 const useStyles = makeStyles({
   handoffTimestamp: {
@@ -53,6 +53,7 @@ const useStyles = makeStyles({
 function getHours(count: number, unit: RotationType): number {
   const lookup = {
     hourly: 1,
+    fixed_hours: 1,
     daily: 24,
     weekly: 24 * 7,
     monthly: 24 * DateTime.local().daysInMonth,
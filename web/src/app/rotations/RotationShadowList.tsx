@@ -0,0 +1,183 @@
+import React, { useState } from 'react'
+import { gql, useMutation, useQuery } from '@apollo/client'
+import Button from '@mui/material/Button'
+import Card from '@mui/material/Card'
+import CardHeader from '@mui/material/CardHeader'
+import Grid from '@mui/material/Grid'
+import { Add } from '@mui/icons-material'
+import { DateTime } from 'luxon'
+import FlatList from '../lists/FlatList'
+import OtherActions from '../util/OtherActions'
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import { UserSelect } from '../selection'
+import { ISODateTimePicker } from '../util/ISOPickers'
+import { UserAvatar } from '../util/avatars'
+import { Time } from '../util/Time'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+import { RotationShadow } from '../../schema'
+
+const query = gql`
+  query rotationShadows($id: ID!) {
+    rotation(id: $id) {
+      id
+      shadows {
+        userID
+        user {
+          id
+          name
+        }
+        expiresAt
+      }
+    }
+  }
+`
+
+const setMutation = gql`
+  mutation setRotationShadow($input: SetRotationShadowInput!) {
+    setRotationShadow(input: $input)
+  }
+`
+
+const deleteMutation = gql`
+  mutation deleteRotationShadow($input: DeleteRotationShadowInput!) {
+    deleteRotationShadow(input: $input)
+  }
+`
+
+interface ShadowValue {
+  userID: string | null
+  expiresAt: string
+}
+
+function RotationShadowDialog(props: {
+  rotationID: string
+  onClose: () => void
+}): JSX.Element {
+  const [value, setValue] = useState<ShadowValue>({
+    userID: null,
+    expiresAt: DateTime.local().plus({ weeks: 2 }).startOf('hour').toISO(),
+  })
+  const [setShadow, { loading, error }] = useMutation(setMutation, {
+    refetchQueries: ['rotationShadows'],
+    onCompleted: props.onClose,
+  })
+
+  return (
+    <FormDialog
+      title='Add Shadow'
+      subTitle='Shadows receive training notifications alongside the on-call user. Their responses are ignored.'
+      loading={loading}
+      errors={nonFieldErrors(error)}
+      onClose={props.onClose}
+      onSubmit={() =>
+        setShadow({
+          variables: { input: { rotationID: props.rotationID, ...value } },
+        })
+      }
+      form={
+        <FormContainer
+          errors={fieldErrors(error)}
+          disabled={loading}
+          value={value}
+          onChange={(value: ShadowValue) => setValue(value)}
+        >
+          <Grid container spacing={2}>
+            <Grid item xs={12}>
+              <FormField
+                component={UserSelect}
+                fullWidth
+                required
+                name='userID'
+                label='User'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                component={ISODateTimePicker}
+                fullWidth
+                required
+                name='expiresAt'
+                label='Until'
+              />
+            </Grid>
+          </Grid>
+        </FormContainer>
+      }
+    />
+  )
+}
+
+export default function RotationShadowList(props: {
+  rotationID: string
+}): JSX.Element {
+  const [showAdd, setShowAdd] = useState(false)
+  const { data, loading, error } = useQuery(query, {
+    variables: { id: props.rotationID },
+  })
+  const [deleteShadow] = useMutation(deleteMutation, {
+    refetchQueries: ['rotationShadows'],
+  })
+
+  if (loading && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+
+  const shadows: RotationShadow[] = data?.rotation?.shadows ?? []
+
+  return (
+    <React.Fragment>
+      {showAdd && (
+        <RotationShadowDialog
+          rotationID={props.rotationID}
+          onClose={() => setShowAdd(false)}
+        />
+      )}
+      <Card>
+        <CardHeader
+          component='h3'
+          title='Shadows'
+          action={
+            <Button
+              variant='contained'
+              onClick={() => setShowAdd(true)}
+              startIcon={<Add />}
+            >
+              Add Shadow
+            </Button>
+          }
+        />
+        <FlatList
+          data-cy='shadows'
+          emptyMessage='No users are shadowing this rotation'
+          items={shadows.map((s) => ({
+            title: s.user?.name ?? s.userID,
+            icon: <UserAvatar userID={s.userID} />,
+            subText: (
+              <Time prefix='Until ' time={s.expiresAt} format='relative' />
+            ),
+            secondaryAction: (
+              <OtherActions
+                actions={[
+                  {
+                    label: 'Remove',
+                    onClick: () =>
+                      deleteShadow({
+                        variables: {
+                          input: {
+                            rotationID: props.rotationID,
+                            userID: s.userID,
+                          },
+                        },
+                      }),
+                  },
+                ]}
+              />
+            ),
+          }))}
+        />
+      </Card>
+    </React.Fragment>
+  )
+}
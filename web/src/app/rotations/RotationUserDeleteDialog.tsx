@@ -9,6 +9,7 @@ const query = gql`
     rotation(id: $id) {
       id
       userIDs
+      userWeights
       users {
         id
         name
@@ -39,7 +40,7 @@ const RotationUserDeleteDialog = (props: {
   if (fetching && !data) return <Spinner />
   if (error) return <GenericError error={error.message} />
 
-  const { userIDs, users } = data.rotation
+  const { userIDs, userWeights, users } = data.rotation
 
   return (
     <FormDialog
@@ -60,6 +61,9 @@ const RotationUserDeleteDialog = (props: {
               userIDs: userIDs.filter(
                 (_: string, index: number) => index !== userIndex,
               ),
+              userWeights: userWeights.filter(
+                (_: number, index: number) => index !== userIndex,
+              ),
             },
           },
           { additionalTypenames: ['Rotation'] },
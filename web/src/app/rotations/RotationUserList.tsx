@@ -11,6 +11,7 @@ import { reorderList, calcNewActiveIndex } from './util'
 import OtherActions from '../util/OtherActions'
 import RotationSetActiveDialog from './RotationSetActiveDialog'
 import RotationUserDeleteDialog from './RotationUserDeleteDialog'
+import RotationUserWeightDialog from './RotationUserWeightDialog'
 import { UserAvatar } from '../util/avatars'
 import { styles as globalStyles } from '../styles/materialStyles'
 import Spinner from '../loading/components/Spinner'
@@ -33,6 +34,7 @@ const query = gql`
       activeUserIndex
       nextHandoffTimes
       userIDs
+      userWeights
     }
   }
 `
@@ -65,6 +67,7 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
   const { rotationID } = props
   const [deleteIndex, setDeleteIndex] = useState<number | null>(null)
   const [setActiveIndex, setSetActiveIndex] = useState<number | null>(null)
+  const [weightIndex, setWeightIndex] = useState<number | null>(null)
   const [showAddUser, setShowAddUser] = useState(false)
   const [lastSwap, setLastSwap] = useState<SwapType[]>([])
   const isMobile = useIsWidthDown('md')
@@ -89,7 +92,8 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
   if (qError || mError)
     return <GenericError error={qError?.message || mError?.message} />
 
-  const { users, userIDs, activeUserIndex, nextHandoffTimes } = data.rotation
+  const { users, userIDs, userWeights, activeUserIndex, nextHandoffTimes } =
+    data.rotation
 
   // duplicate first entry
   const _nextHandoffTimes = (nextHandoffTimes || [])
@@ -155,6 +159,13 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
           onClose={() => setSetActiveIndex(null)}
         />
       )}
+      {weightIndex !== null && (
+        <RotationUserWeightDialog
+          rotationID={rotationID}
+          userIndex={weightIndex}
+          onClose={() => setWeightIndex(null)}
+        />
+      )}
       {showAddUser && (
         <RotationAddUserDialog
           rotationID={rotationID}
@@ -184,7 +195,10 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
           data-cy='users'
           emptyMessage='No users currently assigned to this rotation'
           items={users.map((u: User, index: number) => ({
-            title: u.name,
+            title:
+              userWeights?.[index] > 1
+                ? `${u.name} (weight ${userWeights[index]})`
+                : u.name,
             id: String(listIDs[index]),
             highlight: index === activeUserIndex,
             icon: <UserAvatar userID={u.id} />,
@@ -196,6 +210,10 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
                     label: 'Set Active',
                     onClick: () => setSetActiveIndex(index),
                   },
+                  {
+                    label: 'Set Weight',
+                    onClick: () => setWeightIndex(index),
+                  },
                   {
                     label: 'Remove',
                     onClick: () => setDeleteIndex(index),
@@ -220,6 +238,7 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
             const params = {
               id: rotationID,
               userIDs: updatedUsers,
+              userWeights: reorderList(userWeights ?? [], oldIndex, newIndex),
               activeUserIndex,
             }
 
@@ -244,6 +263,11 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
                     oldIndex,
                     newIndex,
                   )
+                  const userWeights = reorderList(
+                    data.rotation.userWeights ?? [],
+                    oldIndex,
+                    newIndex,
+                  )
                   cache.writeQuery({
                     query,
                     variables: { id: rotationID },
@@ -256,6 +280,7 @@ function RotationUserList(props: RotationUserListProps): JSX.Element {
                             ? data?.rotation?.activeUserIndex
                             : newActiveIndex,
                         users,
+                        userWeights,
                       },
                     },
                   })
@@ -0,0 +1,87 @@
+import React, { useState } from 'react'
+import { gql, useQuery, useMutation } from 'urql'
+import FormDialog from '../dialogs/FormDialog'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+import NumberField from '../util/NumberField'
+
+const query = gql`
+  query ($id: ID!) {
+    rotation(id: $id) {
+      id
+      users {
+        id
+        name
+      }
+      userWeights
+    }
+  }
+`
+
+const mutation = gql`
+  mutation ($input: UpdateRotationInput!) {
+    updateRotation(input: $input)
+  }
+`
+
+const maxWeight = 10
+
+const RotationUserWeightDialog = (props: {
+  rotationID: string
+  userIndex: number
+  onClose: () => void
+}): JSX.Element => {
+  const { rotationID, userIndex, onClose } = props
+  const [{ fetching, data, error }] = useQuery({
+    query,
+    variables: {
+      id: rotationID,
+    },
+  })
+  const [weight, setWeight] = useState<string | null>(null)
+  const [status, setWeightMutation] = useMutation(mutation)
+
+  if (fetching && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+  const { users, userWeights } = data.rotation
+  const value = weight ?? String(userWeights[userIndex] ?? 1)
+
+  return (
+    <FormDialog
+      title={`Set Weight for ${users[userIndex]?.name}`}
+      subTitle='A user with weight 2 stays on call for 2 consecutive shifts each turn, and takes twice the on-call time of a weight-1 user over a full rotation.'
+      loading={status.fetching}
+      errors={status.error ? [status.error] : []}
+      onClose={onClose}
+      form={
+        <NumberField
+          fullWidth
+          label='Weight'
+          min={1}
+          max={maxWeight}
+          step={1}
+          value={value}
+          onChange={(e) => setWeight(e.target.value)}
+        />
+      }
+      onSubmit={() =>
+        setWeightMutation(
+          {
+            input: {
+              id: rotationID,
+              userWeights: userWeights.map((w: number, idx: number) =>
+                idx === userIndex ? parseInt(value, 10) : w,
+              ),
+            },
+          },
+          { additionalTypenames: ['Rotation'] },
+        ).then((res) => {
+          if (res.error) return
+          onClose()
+        })
+      }
+    />
+  )
+}
+
+export default RotationUserWeightDialog
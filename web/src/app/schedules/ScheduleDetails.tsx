@@ -20,6 +20,7 @@ import { useIsWidthDown } from '../util/useWidth'
 import { TempSchedValue, defaultTempSchedValue } from './temp-sched/sharedUtils'
 import { Redirect } from 'wouter'
 import { useScheduleTZ } from './useScheduleTZ'
+import useCoverageGapNotices from './useCoverageGapNotices'
 
 const query = gql`
   fragment ScheduleTitleQuery on Schedule {
@@ -96,6 +97,8 @@ export default function ScheduleDetails({
     null,
   )
 
+  const coverageNotices = useCoverageGapNotices(scheduleID)
+
   const {
     data: _data,
     loading,
@@ -147,6 +150,7 @@ export default function ScheduleDetails({
         title={data.name}
         subheader={`Time Zone: ${data.timeZone || 'Loading...'}`}
         details={data.description}
+        notices={coverageNotices}
         pageContent={
           <OverrideDialogContext.Provider
             value={{
@@ -0,0 +1,54 @@
+import { useState } from 'react'
+import { DateTime } from 'luxon'
+import { useQuery, gql } from '@apollo/client'
+import { Notice, ScheduleCoverageGap } from '../../schema'
+
+const query = gql`
+  query scheduleCoverageGaps(
+    $id: ID!
+    $start: ISOTimestamp!
+    $end: ISOTimestamp!
+  ) {
+    schedule(id: $id) {
+      id
+      coverageGaps(start: $start, end: $end) {
+        start
+        end
+      }
+    }
+  }
+`
+
+// useCoverageGapNotices will return a warning if nobody is on call for the
+// schedule at any point within the next `days` days.
+export default function useCoverageGapNotices(
+  scheduleID: string,
+  days = 7,
+): Notice[] {
+  const [now] = useState(() => DateTime.local().startOf('minute'))
+  const { data, loading } = useQuery(query, {
+    variables: {
+      id: scheduleID,
+      start: now.toISO(),
+      end: now.plus({ days }).toISO(),
+    },
+  })
+  const gaps: ScheduleCoverageGap[] = data?.schedule?.coverageGaps ?? []
+  if (loading || !gaps.length) {
+    return []
+  }
+
+  const next = DateTime.fromISO(gaps[0].start)
+  return [
+    {
+      type: 'WARNING',
+      message: `Nobody is on call for ${gaps.length} ${
+        gaps.length === 1 ? 'period' : 'periods'
+      } in the next ${days} days`,
+      details:
+        next <= now
+          ? 'Nobody is currently on call for this schedule'
+          : `The next gap starts ${next.toRelative()}`,
+    },
+  ]
+}
@@ -6,9 +6,10 @@ import Typography from '@mui/material/Typography'
 import HealthyIcon from '@mui/icons-material/Check'
 import UnhealthyIcon from '@mui/icons-material/Clear'
 import InactiveIcon from '@mui/icons-material/Remove'
+import WarningIcon from '@mui/icons-material/Warning'
 import makeStyles from '@mui/styles/makeStyles'
 import useStatusColors from '../theme/useStatusColors'
-import { ISOTimestamp } from '../../schema'
+import { HeartbeatMonitorState, ISOTimestamp } from '../../schema'
 import { Time } from '../util/Time'
 
 const useStyles = makeStyles({
@@ -27,18 +28,20 @@ const useStyles = makeStyles({
 
 const icons = {
   healthy: <HealthyIcon />,
+  warning: <WarningIcon />,
   unhealthy: <UnhealthyIcon />,
   inactive: <InactiveIcon />,
 }
 
 const statusMap = {
   healthy: 'ok',
+  warning: 'warn',
   unhealthy: 'err',
   inactive: '',
 }
 
 export default function HeartbeatMonitorStatus(props: {
-  lastState: 'inactive' | 'healthy' | 'unhealthy'
+  lastState: HeartbeatMonitorState
   lastHeartbeat?: null | ISOTimestamp
 }): JSX.Element {
   const classes = useStyles()
@@ -50,6 +53,7 @@ export default function HeartbeatMonitorStatus(props: {
   const bgColor = (status?: string): string => {
     switch (status) {
       case 'ok':
+      case 'warn':
       case 'err':
         return statusColors[status]
 
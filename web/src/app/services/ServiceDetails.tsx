@@ -16,6 +16,7 @@ import AppLink from '../util/AppLink'
 import { ServiceAvatar } from '../util/avatars'
 import ServiceMaintenanceModeDialog from './ServiceMaintenanceDialog'
 import ServiceNotices from './ServiceNotices'
+import ServicePauseDialog from './ServicePauseDialog'
 import { HeartbeatMonitor } from '../../schema'
 
 interface AlertNode {
@@ -34,6 +35,7 @@ const query = gql`
     service(id: $serviceID) {
       ...ServiceTitleQuery
       maintenanceExpiresAt
+      paused
       ep: escalationPolicy {
         id
         name
@@ -85,6 +87,7 @@ export default function ServiceDetails(props: {
   const [showEdit, setShowEdit] = useState(false)
   const [showDelete, setShowDelete] = useState(false)
   const [showMaintMode, setShowMaintMode] = useState(false)
+  const [showPause, setShowPause] = useState(false)
   const [{ data, fetching, error }] = useQuery({
     query,
     variables: { serviceID },
@@ -127,6 +130,19 @@ export default function ServiceDetails(props: {
           >
             Maintenance Mode
           </Button>,
+          ...(data.service.paused
+            ? []
+            : [
+                <Button
+                  color='primary'
+                  variant='outlined'
+                  key='pause-alerting'
+                  onClick={() => setShowPause(true)}
+                  aria-label='Pause Alerting'
+                >
+                  Pause Alerting
+                </Button>,
+              ]),
         ]}
         secondaryActions={[
           {
@@ -194,6 +210,12 @@ export default function ServiceDetails(props: {
           expiresAt={data.service.maintenanceExpiresAt}
         />
       )}
+      {showPause && (
+        <ServicePauseDialog
+          onClose={() => setShowPause(false)}
+          serviceID={serviceID}
+        />
+      )}
     </React.Fragment>
   )
 }
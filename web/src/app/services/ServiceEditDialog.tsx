@@ -11,6 +11,11 @@ interface Value {
   name: string
   description: string
   escalationPolicyID?: string
+  notificationTemplate?: string
+  runbookURL?: string
+  enrichmentURL?: string
+  notificationDelayMinutes?: number
+  requireCloseReason?: boolean
 }
 
 const query = gql`
@@ -19,6 +24,11 @@ const query = gql`
       id
       name
       description
+      notificationTemplate
+      runbookURL
+      enrichmentURL
+      notificationDelayMinutes
+      requireCloseReason
       ep: escalationPolicy {
         id
         name
@@ -52,6 +62,11 @@ export default function ServiceEditDialog(props: {
     name: data?.service?.name,
     description: data?.service?.description,
     escalationPolicyID: data?.service?.ep?.id,
+    notificationTemplate: data?.service?.notificationTemplate,
+    runbookURL: data?.service?.runbookURL,
+    enrichmentURL: data?.service?.enrichmentURL,
+    notificationDelayMinutes: data?.service?.notificationDelayMinutes,
+    requireCloseReason: data?.service?.requireCloseReason,
   }
 
   const fieldErrs = fieldErrors(saveStatus.error)
@@ -1,6 +1,8 @@
 import React from 'react'
 import Grid from '@mui/material/Grid'
 import TextField from '@mui/material/TextField'
+import Checkbox from '@mui/material/Checkbox'
+import FormControlLabel from '@mui/material/FormControlLabel'
 import { EscalationPolicySelect } from '../selection/EscalationPolicySelect'
 import { FormContainer, FormField } from '../forms'
 import { FieldError } from '../util/errutil'
@@ -11,6 +13,11 @@ export interface Value {
   name: string
   description: string
   escalationPolicyID?: string
+  notificationTemplate?: string
+  runbookURL?: string
+  enrichmentURL?: string
+  notificationDelayMinutes?: number
+  requireCloseReason?: boolean
 }
 
 interface ServiceFormProps {
@@ -61,6 +68,70 @@ export default function ServiceForm(props: ServiceFormProps): JSX.Element {
             component={EscalationPolicySelect}
           />
         </Grid>
+        {props.value.notificationTemplate !== undefined && (
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              label='Notification Template'
+              name='notificationTemplate'
+              multiline
+              component={TextField}
+              charCount={2048}
+              hint='Available fields: {{.AlertID}}, {{.Summary}}, {{.Details}}, {{.ServiceID}}, {{.ServiceName}}. Leave empty to use the alert summary.'
+            />
+          </Grid>
+        )}
+        {props.value.runbookURL !== undefined && (
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              label='Runbook URL'
+              name='runbookURL'
+              component={TextField}
+              hint='Included with alerts from this service, unless the alert provides its own runbook_url.'
+            />
+          </Grid>
+        )}
+        {props.value.enrichmentURL !== undefined && (
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              label='Enrichment URL'
+              name='enrichmentURL'
+              component={TextField}
+              hint='New alerts are POSTed here, and the returned metadata is added to the alert.'
+            />
+          </Grid>
+        )}
+        {props.value.notificationDelayMinutes !== undefined && (
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              label='Notification Delay (minutes)'
+              name='notificationDelayMinutes'
+              type='number'
+              min={0}
+              max={60}
+              component={TextField}
+              hint='Wait this long after an alert is created before notifying anyone. Alerts closed in the meantime are not sent.'
+            />
+          </Grid>
+        )}
+        {props.value.requireCloseReason !== undefined && (
+          <Grid item xs={12}>
+            <FormControlLabel
+              control={
+                <FormField
+                  component={Checkbox}
+                  checkbox
+                  name='requireCloseReason'
+                />
+              }
+              label='Require a reason when closing alerts'
+              labelPlacement='end'
+            />
+          </Grid>
+        )}
       </Grid>
     </FormContainer>
   )
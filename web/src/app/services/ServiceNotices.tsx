@@ -1,14 +1,20 @@
-import React from 'react'
+import React, { useState } from 'react'
 import { gql, useQuery, useMutation } from 'urql'
 import { Button, Grid } from '@mui/material'
 import { DateTime } from 'luxon'
 import Notices, { Notice } from '../details/Notices'
 import { Time } from '../util/Time'
+import ServicePauseDialog from './ServicePauseDialog'
 
 const query = gql`
   query serviceMaintenanceQuery($serviceID: ID!) {
     service(id: $serviceID) {
       maintenanceExpiresAt
+      pausedAt
+      pausedBy {
+        id
+        name
+      }
 
       notices {
         type
@@ -36,6 +42,7 @@ export default function ServiceNotices({
   extraNotices = [],
 }: ServiceMaintenanceNoticeProps): JSX.Element | null {
   const [, updateService] = useMutation(mutation)
+  const [showResume, setShowResume] = useState(false)
   const [{ fetching, data }] = useQuery({
     query,
     variables: { serviceID },
@@ -84,9 +91,42 @@ export default function ServiceNotices({
     ]
   }
 
+  const pausedAt = data?.service?.pausedAt
+  if (pausedAt) {
+    const by = data?.service?.pausedBy?.name
+    notices = [
+      {
+        type: 'WARNING',
+        message: 'Alerting Paused',
+        details: (
+          <React.Fragment>
+            {by ? `Paused by ${by} ` : 'Paused '}
+            <Time format='relative' time={pausedAt} />
+          </React.Fragment>
+        ),
+        action: (
+          <Button
+            aria-label='Resume Alerting'
+            onClick={() => setShowResume(true)}
+          >
+            Resume
+          </Button>
+        ),
+      },
+      ...notices,
+    ]
+  }
+
   return (
     <Grid item sx={{ width: '100%' }}>
       <Notices notices={notices} />
+      {showResume && (
+        <ServicePauseDialog
+          resume
+          onClose={() => setShowResume(false)}
+          serviceID={serviceID}
+        />
+      )}
     </Grid>
   )
 }
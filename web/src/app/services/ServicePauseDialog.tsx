@@ -0,0 +1,65 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from 'urql'
+import { Checkbox, FormControlLabel } from '@mui/material'
+
+import FormDialog from '../dialogs/FormDialog'
+import { nonFieldErrors } from '../util/errutil'
+
+interface Props {
+  serviceID: string
+  resume?: boolean
+  onClose: () => void
+}
+
+const mutation = gql`
+  mutation setServicePaused($input: SetServicePausedInput!) {
+    setServicePaused(input: $input)
+  }
+`
+
+export default function ServicePauseDialog(props: Props): JSX.Element {
+  const [renotify, setRenotify] = useState(false)
+  const [status, setServicePaused] = useMutation(mutation)
+
+  return (
+    <FormDialog
+      title={props.resume ? 'Resume Alerting' : 'Pause Alerting'}
+      subTitle={
+        props.resume
+          ? 'Open alerts will continue escalating, and new alerts will notify as normal.'
+          : 'Stop all notifications and escalations until alerting is resumed. Incoming alerts will still be created.'
+      }
+      confirm
+      loading={status.fetching}
+      errors={nonFieldErrors(status.error)}
+      onClose={props.onClose}
+      onSubmit={() =>
+        setServicePaused(
+          {
+            input: {
+              id: props.serviceID,
+              paused: !props.resume,
+              renotify: props.resume ? renotify : false,
+            },
+          },
+          { additionalTypenames: ['Service'] },
+        ).then((result) => {
+          if (!result.error) props.onClose()
+        })
+      }
+      form={
+        props.resume ? (
+          <FormControlLabel
+            control={
+              <Checkbox
+                checked={renotify}
+                onChange={(e) => setRenotify(e.target.checked)}
+              />
+            }
+            label='Restart escalation of unacknowledged alerts from the first step'
+          />
+        ) : undefined
+      }
+    />
+  )
+}
@@ -40,11 +40,12 @@ export default function UserContactMethodCreateDialog(props: {
   title?: string
   subtitle?: string
 }): JSX.Element {
-  const [allowSV, allowE, allowW, allowS] = useConfigValue(
+  const [allowSV, allowE, allowW, allowS, allowT] = useConfigValue(
     'Twilio.Enable',
     'SMTP.Enable',
     'Webhook.Enable',
     'Slack.Enable',
+    'Telegram.Enable',
   )
 
   let typeVal: ContactMethodType = 'VOICE'
@@ -56,6 +57,8 @@ export default function UserContactMethodCreateDialog(props: {
     typeVal = 'WEBHOOK'
   } else if (allowS) {
     typeVal = 'SLACK_DM'
+  } else if (allowT) {
+    typeVal = 'TELEGRAM'
   }
 
   // values for contact method form
@@ -93,6 +93,22 @@ function renderSlackField(edit: boolean): JSX.Element {
   )
 }
 
+function renderTelegramField(edit: boolean): JSX.Element {
+  return (
+    <FormField
+      fullWidth
+      name='value'
+      required
+      label='Telegram Chat ID'
+      placeholder='chat ID'
+      component={TextField}
+      disabled={edit}
+      // @ts-expect-error TS2322 -- FormField has not been converted to ts, and inferred type is incorrect.
+      helperText='Send /start to the Telegram bot to get your chat ID.'
+    />
+  )
+}
+
 function renderTypeField(type: ContactMethodType, edit: boolean): JSX.Element {
   switch (type) {
     case 'SMS':
@@ -104,6 +120,8 @@ function renderTypeField(type: ContactMethodType, edit: boolean): JSX.Element {
       return renderURLField(edit)
     case 'SLACK_DM':
       return renderSlackField(edit)
+    case 'TELEGRAM':
+      return renderTelegramField(edit)
     default:
   }
 
@@ -133,12 +151,14 @@ export default function UserContactMethodForm(
     emailEnabled,
     webhookEnabled,
     slackEnabled,
+    telegramEnabled,
     disclaimer,
   ] = useConfigValue(
     'Twilio.Enable',
     'SMTP.Enable',
     'Webhook.Enable',
     'Slack.Enable',
+    'Telegram.Enable',
     'General.NotificationDisclaimer',
   )
 
@@ -176,8 +196,19 @@ export default function UserContactMethodForm(
           disabledMessage: 'Slack must be configured by an administrator',
           disabled: !slackEnabled,
         },
+        {
+          value: 'TELEGRAM',
+          disabledMessage: 'Telegram must be configured by an administrator',
+          disabled: !telegramEnabled,
+        },
       ].sort(sortDisableableMenuItems),
-    [smsVoiceEnabled, emailEnabled, webhookEnabled, slackEnabled],
+    [
+      smsVoiceEnabled,
+      emailEnabled,
+      webhookEnabled,
+      slackEnabled,
+      telegramEnabled,
+    ],
   )
 
   return (
@@ -33,6 +33,8 @@ const query = gql`
         formattedValue
         disabled
         pending
+        consecutiveFailures
+        autoDisabledAt
       }
     }
   }
@@ -167,6 +169,9 @@ export default function UserContactMethodList(
     if (cm.pending) {
       cmText = `${cm.formattedValue} - this contact method will be automatically deleted if not verified`
     }
+    if (cm.disabled && cm.autoDisabledAt) {
+      cmText = `${cm.formattedValue} - disabled after ${cm.consecutiveFailures} failed deliveries, reactivate once the problem is fixed`
+    }
     if (cm.type === 'WEBHOOK') {
       return (
         <React.Fragment>
@@ -83,6 +83,7 @@ export default function UserContactMethodVerificationDialog(props) {
       form={
         <UserContactMethodVerificationForm
           contactMethodID={props.contactMethodID}
+          contactMethodType={cm.type}
           errors={fieldErrs}
           setSendError={setSendError}
           disabled={loading}
@@ -40,10 +40,14 @@ export default function UserContactMethodVerificationForm(props) {
     },
   })
 
-  function sendAndCatch() {
+  function sendAndCatch(voice = false) {
     // Clear error on new actions.
     props.setSendError(null)
-    sendCode().catch((err) => props.setSendError(err.message))
+    sendCode({
+      variables: {
+        input: { contactMethodID: props.contactMethodID, voice },
+      },
+    }).catch((err) => props.setSendError(err.message))
   }
 
   // Attempt to send a code on load, but it's ok if it fails.
@@ -65,6 +69,17 @@ export default function UserContactMethodVerificationForm(props) {
             onClick={() => sendAndCatch()}
           />
         </Grid>
+        {props.contactMethodType === 'SMS' && (
+          <Grid item className={classes.sendGridItem}>
+            <LoadingButton
+              loading={sendCodeStatus.loading}
+              disabled={props.disabled}
+              buttonText='Call Me Instead'
+              noSubmit
+              onClick={() => sendAndCatch(true)}
+            />
+          </Grid>
+        )}
         <Grid item className={classes.fieldGridItem}>
           <FormField
             fullWidth
@@ -84,6 +99,7 @@ export default function UserContactMethodVerificationForm(props) {
 
 UserContactMethodVerificationForm.propTypes = {
   contactMethodID: p.string.isRequired,
+  contactMethodType: p.string,
   disabled: p.bool.isRequired,
   errors: p.arrayOf(
     p.shape({
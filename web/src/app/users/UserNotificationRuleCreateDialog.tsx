@@ -17,7 +17,11 @@ export default function UserNotificationRuleCreateDialog(props: {
   onClose: () => void
   userID: string
 }): JSX.Element {
-  const [value, setValue] = useState({ contactMethodID: '', delayMinutes: 0 })
+  const [value, setValue] = useState({
+    contactMethodID: '',
+    delayMinutes: 0,
+    stopOnSuccess: false,
+  })
 
   const [createNotification, { loading, error }] = useMutation(mutation, {
     onCompleted: props.onClose,
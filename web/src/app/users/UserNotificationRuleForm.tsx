@@ -1,6 +1,8 @@
 import React from 'react'
 import Grid from '@mui/material/Grid'
 import TextField from '@mui/material/TextField'
+import Checkbox from '@mui/material/Checkbox'
+import FormControlLabel from '@mui/material/FormControlLabel'
 import { FormContainer, FormField } from '../forms'
 import UserContactMethodSelect from './UserContactMethodSelect'
 import { FieldError } from '../util/errutil'
@@ -8,6 +10,7 @@ import { FieldError } from '../util/errutil'
 interface CreateNotificationRule {
   contactMethodID: string
   delayMinutes: number
+  stopOnSuccess: boolean
 }
 
 interface UserNotificationRuleFormProps {
@@ -50,12 +53,22 @@ export default function UserNotificationRuleForm(
             name='delayMinutes'
             required
             label='Delay (minutes)'
+            helperText='Not sent if the alert is acknowledged or closed first.'
             type='number'
             min={0}
             max={9000}
             component={TextField}
           />
         </Grid>
+        <Grid item xs={12}>
+          <FormControlLabel
+            control={
+              <FormField component={Checkbox} checkbox name='stopOnSuccess' />
+            }
+            label='Skip later rules once this notification is delivered'
+            labelPlacement='end'
+          />
+        </Grid>
       </Grid>
     </FormContainer>
   )
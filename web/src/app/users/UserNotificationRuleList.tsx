@@ -1,5 +1,5 @@
 import React, { useState, ReactNode } from 'react'
-import { gql, QueryResult } from '@apollo/client'
+import { gql, QueryResult, useMutation } from '@apollo/client'
 import {
   Button,
   Card,
@@ -17,6 +17,7 @@ import UserNotificationRuleDeleteDialog from './UserNotificationRuleDeleteDialog
 import { styles as globalStyles } from '../styles/materialStyles'
 import UserNotificationRuleCreateDialog from './UserNotificationRuleCreateDialog'
 import { useIsWidthDown } from '../util/useWidth'
+import { reorderList } from '../rotations/util'
 import { User } from '../../schema'
 
 const query = gql`
@@ -29,6 +30,8 @@ const query = gql`
       notificationRules {
         id
         delayMinutes
+        position
+        stopOnSuccess
         contactMethod {
           id
           type
@@ -41,6 +44,14 @@ const query = gql`
   }
 `
 
+const orderMutation = gql`
+  mutation setUserNotificationRuleOrder(
+    $input: SetUserNotificationRuleOrderInput!
+  ) {
+    setUserNotificationRuleOrder(input: $input)
+  }
+`
+
 const useStyles = makeStyles((theme: Theme) => {
   const { cardHeader } = globalStyles(theme)
   return {
@@ -56,8 +67,27 @@ export default function UserNotificationRuleList(props: {
   const mobile = useIsWidthDown('md')
   const [showAddDialog, setShowAddDialog] = useState(false)
   const [deleteID, setDeleteID] = useState(null)
+  const [setOrder] = useMutation(orderMutation, {
+    refetchQueries: ['nrList'],
+  })
 
   function renderList(user: User): ReactNode {
+    const rules = sortNotificationRules(user.notificationRules)
+    const onReorder = (oldIndex: number, newIndex: number): void => {
+      setOrder({
+        variables: {
+          input: {
+            userID: props.userID,
+            ruleIDs: reorderList(
+              rules.map((nr) => nr.id),
+              oldIndex,
+              newIndex,
+            ),
+          },
+        },
+      })
+    }
+
     return (
       <Grid item xs={12}>
         <Card>
@@ -81,8 +111,12 @@ export default function UserNotificationRuleList(props: {
           />
           <FlatList
             data-cy='notification-rules'
-            items={sortNotificationRules(user.notificationRules).map((nr) => ({
+            items={rules.map((nr) => ({
+              id: nr.id,
               title: formatNotificationRule(nr.delayMinutes, nr.contactMethod),
+              subText: nr.stopOnSuccess
+                ? 'Rules below are skipped once this notification is delivered'
+                : undefined,
               secondaryAction: props.readOnly ? null : (
                 <IconButton
                   aria-label='Delete notification rule'
@@ -94,6 +128,9 @@ export default function UserNotificationRuleList(props: {
               ),
             }))}
             emptyMessage='No notification rules'
+            onReorder={
+              props.readOnly || rules.length < 2 ? undefined : onReorder
+            }
           />
         </Card>
         {showAddDialog && (
@@ -13,6 +13,7 @@ export function formatNotificationRule(
 
 export function sortNotificationRules(nr) {
   return sortBy(nr, [
+    'position',
     'delayMinutes',
     'contactMethod.name',
     'contactMethod.type',
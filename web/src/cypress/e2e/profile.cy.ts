@@ -381,7 +381,7 @@ function testProfile(screen: ScreenFormat): void {
       // delete existing notification rule
       cy.get('ul[data-cy=notification-rules]')
         .contains('li', cm.name)
-        .find('button')
+        .find('button[aria-label="Delete notification rule"]')
         .click()
       cy.dialogTitle('Are you sure?')
       cy.dialogFinish('Confirm')
@@ -4,22 +4,34 @@ export interface Query {
   phoneNumberInfo?: null | PhoneNumberInfo
   experimentalFlags: string[]
   messageLogs: MessageLogConnection
+  stuckMessages: StuckMessageGroup[]
   debugMessages: DebugMessage[]
   user?: null | User
   users: UserConnection
   alert?: null | Alert
   alerts: AlertConnection
+  incident?: null | Incident
+  incidentCorrelationRules: IncidentCorrelationRule[]
+  archivedAlert?: null | ArchivedAlert
+  archivedAlerts: ArchivedAlertConnection
   service?: null | Service
   integrationKey?: null | IntegrationKey
   heartbeatMonitor?: null | HeartbeatMonitor
   services: ServiceConnection
+  servicesOnCall: ServiceOnCall[]
+  misconfiguredServices: MisconfiguredService[]
   rotation?: null | Rotation
   rotations: RotationConnection
   calcRotationHandoffTimes: ISOTimestamp[]
   schedule?: null | Schedule
   userCalendarSubscription?: null | UserCalendarSubscription
   schedules: ScheduleConnection
+  userOnCallShifts: UserOnCallShiftConnection
   escalationPolicy?: null | EscalationPolicy
+  businessHours?: null | BusinessHours
+  allBusinessHours: BusinessHours[]
+  escalationPolicySimulation: EscalationPolicySimulationStep[]
+  alertFeedbackStats: AlertFeedbackStats[]
   escalationPolicies: EscalationPolicyConnection
   authSubjectsForProvider: AuthSubjectConnection
   timeZones: TimeZoneConnection
@@ -31,6 +43,8 @@ export interface Query {
   userOverride?: null | UserOverride
   config: ConfigValue[]
   configHints: ConfigHint[]
+  exportConfig: string
+  auditLogs: AuditLogConnection
   integrationKeyTypes: IntegrationKeyTypeInfo[]
   systemLimits: SystemLimit[]
   debugMessageStatus: DebugMessageStatusInfo
@@ -126,6 +140,15 @@ export interface DebugMessage {
   providerID?: null | string
   sentAt?: null | ISOTimestamp
   retryCount: number
+  deadLetteredAt?: null | ISOTimestamp
+  attempts: DebugMessageAttempt[]
+  replayOfID?: null | string
+}
+
+export interface DebugMessageAttempt {
+  attempt: number
+  status: string
+  createdAt: ISOTimestamp
 }
 
 export interface MessageLogSearchOptions {
@@ -135,6 +158,22 @@ export interface MessageLogSearchOptions {
   createdAfter?: null | ISOTimestamp
   search?: null | string
   omit?: null | string[]
+  deadLettered?: null | boolean
+}
+
+export interface StuckMessagesInput {
+  thresholdMinutes?: null | number
+  serviceIDs?: null | string[]
+  createdAfter?: null | ISOTimestamp
+  createdBefore?: null | ISOTimestamp
+}
+
+export interface StuckMessageGroup {
+  channel: string
+  destination: string
+  count: number
+  oldestCreatedAt: ISOTimestamp
+  messages: DebugMessage[]
 }
 
 export interface MessageLogConnection {
@@ -266,6 +305,19 @@ export interface UserOverride {
   addUser?: null | User
   removeUser?: null | User
   target: Target
+  recurrenceID?: null | string
+  recurrence?: null | UserOverrideRecurrence
+}
+
+export interface UserOverrideRecurrence {
+  id: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  weekdayFilter: WeekdayFilter
+  until: ISOTimestamp
+  addUserID: string
+  removeUserID: string
+  target: Target
 }
 
 export interface LabelSearchOptions {
@@ -328,6 +380,10 @@ export interface DebugCarrierInfoInput {
   number: string
 }
 
+export interface ReplayMessageInput {
+  id: string
+}
+
 export interface DebugSendSMSInput {
   from: string
   to: string
@@ -369,6 +425,13 @@ export interface SetTemporaryScheduleInput {
   shifts: SetScheduleShiftInput[]
 }
 
+export interface SetScheduleFixedShiftsInput {
+  scheduleID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  shifts: SetScheduleShiftInput[]
+}
+
 export interface SetScheduleShiftInput {
   userID: string
   start: ISOTimestamp
@@ -382,39 +445,77 @@ export interface Mutation {
   linkAccount: boolean
   setTemporarySchedule: boolean
   clearTemporarySchedules: boolean
+  setScheduleFixedShifts: boolean
   setScheduleOnCallNotificationRules: boolean
+  setScheduleHandoffNotification: boolean
   debugCarrierInfo: DebugCarrierInfo
   debugSendSMS?: null | DebugSendSMSInfo
+  replayMessage: string
   addAuthSubject: boolean
   deleteAuthSubject: boolean
   endAllAuthSessionsByCurrentUser: boolean
   updateUser: boolean
   testContactMethod: boolean
+  testNotificationRules: string[]
   updateAlerts?: null | Alert[]
   updateRotation: boolean
+  setRotationShadow: boolean
+  deleteRotationShadow: boolean
   escalateAlerts?: null | Alert[]
+  snoozeAlerts?: null | Alert[]
   setFavorite: boolean
   updateService: boolean
+  setServicePaused: boolean
+  setServiceWatcher: boolean
+  deleteServiceWatcher: boolean
   updateEscalationPolicy: boolean
   updateEscalationPolicyStep: boolean
   deleteAll: boolean
   createAlert?: null | Alert
   setAlertNoiseReason: boolean
+  setAlertFeedback: boolean
+  assignAlert: boolean
+  addAlertNote: boolean
+  updateAlertNote: boolean
+  deleteAlertNote: boolean
   createService?: null | Service
   createEscalationPolicy?: null | EscalationPolicy
+  cloneEscalationPolicy: string
   createEscalationPolicyStep?: null | EscalationPolicyStep
+  createBusinessHours?: null | BusinessHours
+  updateBusinessHours: boolean
+  deleteBusinessHours: boolean
   createRotation?: null | Rotation
   createIntegrationKey?: null | IntegrationKey
+  setIntegrationKeyAlertRateLimit: boolean
+  setIntegrationKeyDedupWindow: boolean
+  setIntegrationKeyFieldMapping: boolean
+  setIntegrationKeyDefaultLabels: boolean
   createHeartbeatMonitor?: null | HeartbeatMonitor
+  createMaintenanceWindow?: null | MaintenanceWindow
+  deleteMaintenanceWindow: boolean
+  createIncident?: null | Incident
+  updateIncident: boolean
+  createIncidentCorrelationRule?: null | IncidentCorrelationRule
+  deleteIncidentCorrelationRule: boolean
   setLabel: boolean
+  setWebhookSecret: boolean
   createSchedule?: null | Schedule
   createUser?: null | User
   createUserCalendarSubscription: UserCalendarSubscription
   updateUserCalendarSubscription: boolean
   updateScheduleTarget: boolean
   createUserOverride?: null | UserOverride
+  takeOnCall?: null | UserOverride
+  createUserOverrideRecurrence?: null | UserOverrideRecurrence
+  deleteUserOverrideRecurrence: boolean
   createUserContactMethod?: null | UserContactMethod
   createUserNotificationRule?: null | UserNotificationRule
+  updateUserNotificationRule: boolean
+  setUserNotificationRuleOrder: boolean
+  setUserUrgencyWindow: boolean
+  setUserDoNotDisturb: boolean
+  setUserLabelGrants: boolean
   updateUserContactMethod: boolean
   sendContactMethodVerification: boolean
   verifyContactMethod: boolean
@@ -422,15 +523,31 @@ export interface Mutation {
   updateUserOverride: boolean
   updateHeartbeatMonitor: boolean
   updateAlertsByService: boolean
+  closeAllAlertsByService: number
+  updateAlertsByLabel: number
   setConfig: boolean
   setSystemLimits: boolean
   createGQLAPIKey: CreatedGQLAPIKey
   updateGQLAPIKey: boolean
   deleteGQLAPIKey: boolean
+  deleteGQLAPIKeysByCreator: number
+  rotateGQLAPIKey: CreatedGQLAPIKey
+  promoteGQLAPIKeySigningKey: boolean
   createBasicAuth: boolean
   updateBasicAuth: boolean
 }
 
+export interface Subscription {
+  alertEvents: AlertEvent
+}
+
+export interface AlertEvent {
+  type: AlertEventType
+  alert: Alert
+}
+
+export type AlertEventType = 'created' | 'acknowledged' | 'closed' | 'escalated'
+
 export interface CreatedGQLAPIKey {
   id: string
   token: string
@@ -442,6 +559,11 @@ export interface CreateGQLAPIKeyInput {
   allowedFields: string[]
   expiresAt: ISOTimestamp
   role: UserRole
+  serviceIDs?: null | string[]
+  allowedCIDRs?: null | string[]
+  readOnly?: null | boolean
+  rateLimit?: null | number
+  maxComplexity?: null | number
 }
 
 export interface UpdateGQLAPIKeyInput {
@@ -459,8 +581,16 @@ export interface GQLAPIKey {
   updatedAt: ISOTimestamp
   updatedBy?: null | User
   lastUsed?: null | GQLAPIKeyUsage
+  usageHistory: GQLAPIKeyUsage[]
   expiresAt: ISOTimestamp
+  expiringSoon: boolean
   allowedFields: string[]
+  serviceIDs: string[]
+  allowedCIDRs: string[]
+  readOnly: boolean
+  rateLimit: number
+  maxComplexity: number
+  staleFields: string[]
 }
 
 export interface GQLAPIKeyUsage {
@@ -484,6 +614,25 @@ export interface UpdateBasicAuthInput {
 export interface UpdateAlertsByServiceInput {
   serviceID: string
   newStatus: AlertStatus
+  closeReason?: null | AlertCloseReason
+}
+
+export interface CloseAllAlertsByServiceInput {
+  serviceID: string
+  confirm: string
+  closeReason?: null | AlertCloseReason
+}
+
+export interface UpdateAlertsByLabelInput {
+  labelKey: string
+  labelValue?: null | string
+  newStatus: AlertStatus
+  closeReason?: null | AlertCloseReason
+}
+
+export interface SnoozeAlertsInput {
+  alertIDs: number[]
+  durationMinutes: number
 }
 
 export interface CreateAlertInput {
@@ -491,6 +640,9 @@ export interface CreateAlertInput {
   details?: null | string
   serviceID: string
   sanitize?: null | boolean
+  severity?: null | AlertSeverity
+  meta?: null | AlertMetadataInput[]
+  correlationKey?: null | string
 }
 
 export interface SetAlertNoiseReasonInput {
@@ -498,6 +650,28 @@ export interface SetAlertNoiseReasonInput {
   noiseReason: string
 }
 
+export interface SetAlertFeedbackInput {
+  alertID: number
+  value: AlertFeedbackValue
+  noiseReason?: null | string
+}
+
+export interface AssignAlertInput {
+  alertID: number
+  userID: string
+  pauseEscalationMinutes?: null | number
+}
+
+export interface AddAlertNoteInput {
+  alertID: number
+  note: string
+}
+
+export interface UpdateAlertNoteInput {
+  id: number
+  note: string
+}
+
 export interface CreateUserInput {
   username: string
   password: string
@@ -512,6 +686,8 @@ export interface CreateUserCalendarSubscriptionInput {
   reminderMinutes?: null | number[]
   scheduleID: string
   disabled?: null | boolean
+  fullSchedule?: null | boolean
+  horizonDays?: null | number
 }
 
 export interface UpdateUserCalendarSubscriptionInput {
@@ -519,12 +695,16 @@ export interface UpdateUserCalendarSubscriptionInput {
   name?: null | string
   reminderMinutes?: null | number[]
   disabled?: null | boolean
+  fullSchedule?: null | boolean
+  horizonDays?: null | number
 }
 
 export interface UserCalendarSubscription {
   id: string
   name: string
   reminderMinutes: number[]
+  fullSchedule: boolean
+  horizonDays: number
   scheduleID: string
   schedule?: null | Schedule
   lastAccess: ISOTimestamp
@@ -553,6 +733,22 @@ export interface CreateUserOverrideInput {
   removeUserID?: null | string
 }
 
+export interface TakeOnCallInput {
+  serviceID: string
+  durationMinutes: number
+  scheduleID?: null | string
+}
+
+export interface CreateUserOverrideRecurrenceInput {
+  scheduleID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  weekdayFilter: WeekdayFilter
+  until: ISOTimestamp
+  addUserID?: null | string
+  removeUserID?: null | string
+}
+
 export interface CreateScheduleInput {
   name: string
   description?: null | string
@@ -574,6 +770,7 @@ export interface ScheduleRuleInput {
   start?: null | ClockTime
   end?: null | ClockTime
   weekdayFilter?: null | WeekdayFilter
+  priority?: null | number
 }
 
 export interface SetLabelInput {
@@ -582,6 +779,12 @@ export interface SetLabelInput {
   value: string
 }
 
+export interface SetWebhookSecretInput {
+  url: string
+  secret: string
+  overlapMinutes?: null | number
+}
+
 export interface TimeZoneSearchOptions {
   first?: null | number
   after?: null | string
@@ -607,20 +810,44 @@ export interface CreateServiceInput {
   newIntegrationKeys?: null | CreateIntegrationKeyInput[]
   labels?: null | SetLabelInput[]
   newHeartbeatMonitors?: null | CreateHeartbeatMonitorInput[]
+  notificationTemplate?: null | string
+  runbookURL?: null | string
+  enrichmentURL?: null | string
+  notificationDelayMinutes?: null | number
+  requireCloseReason?: null | boolean
+  timeZone?: null | string
 }
 
 export interface CreateEscalationPolicyInput {
   name: string
   description?: null | string
   repeat?: null | number
+  maxNotifications?: null | number
+  ackTimeoutMinutes?: null | number
   favorite?: null | boolean
   steps?: null | CreateEscalationPolicyStepInput[]
 }
 
+export interface CloneEscalationPolicyInput {
+  id: string
+  name: string
+  favorite?: null | boolean
+}
+
 export interface CreateEscalationPolicyStepInput {
   escalationPolicyID?: null | string
   delayMinutes: number
+  offHoursDelayMinutes?: null | number
+  delayBusinessHoursID?: null | string
+  assignmentStrategy?: null | StepAssignmentStrategy
+  minSeverity?: null | AlertSeverity
+  lowUrgency?: null | boolean
+  conferenceBridge?: null | boolean
+  routingBusinessHoursID?: null | string
+  routingWebhookURL?: null | string
   targets?: null | TargetInput[]
+  inHoursTargets?: null | TargetInput[]
+  afterHoursTargets?: null | TargetInput[]
   newRotation?: null | CreateRotationInput
   newSchedule?: null | CreateScheduleInput
 }
@@ -629,10 +856,69 @@ export interface EscalationPolicyStep {
   id: string
   stepNumber: number
   delayMinutes: number
+  offHoursDelayMinutes: number
+  delayBusinessHours?: null | BusinessHours
+  assignmentStrategy: StepAssignmentStrategy
+  minSeverity: AlertSeverity
+  lowUrgency: boolean
+  conferenceBridge: boolean
+  routingBusinessHours?: null | BusinessHours
+  routingWebhookURL: string
   targets: Target[]
+  inHoursTargets: Target[]
+  afterHoursTargets: Target[]
   escalationPolicy?: null | EscalationPolicy
 }
 
+export interface BusinessHours {
+  id: string
+  name: string
+  description: string
+  timeZone: string
+  weekdayFilter: WeekdayFilter
+  start: ClockTime
+  end: ClockTime
+}
+
+export interface CreateBusinessHoursInput {
+  name: string
+  description?: null | string
+  timeZone: string
+  weekdayFilter: WeekdayFilter
+  start: ClockTime
+  end: ClockTime
+}
+
+export interface UpdateBusinessHoursInput {
+  id: string
+  name?: null | string
+  description?: null | string
+  timeZone?: null | string
+  weekdayFilter?: null | WeekdayFilter
+  start?: null | ClockTime
+  end?: null | ClockTime
+}
+
+export interface EscalationPolicySimulationInput {
+  policyID: string
+  triggerTime?: null | ISOTimestamp
+  severity?: null | AlertSeverity
+}
+
+export interface EscalationPolicySimulationStep {
+  stepNumber: number
+  repeat: number
+  escalateAt: ISOTimestamp
+  targets: EscalationPolicySimulationTarget[]
+}
+
+export interface EscalationPolicySimulationTarget {
+  target: Target
+  via: Target
+}
+
+export type StepAssignmentStrategy = 'sequential' | 'round_robin'
+
 export interface UpdateScheduleInput {
   id: string
   name?: null | string
@@ -646,6 +932,18 @@ export interface UpdateServiceInput {
   description?: null | string
   escalationPolicyID?: null | string
   maintenanceExpiresAt?: null | ISOTimestamp
+  notificationTemplate?: null | string
+  runbookURL?: null | string
+  enrichmentURL?: null | string
+  notificationDelayMinutes?: null | number
+  requireCloseReason?: null | boolean
+  timeZone?: null | string
+}
+
+export interface SetServicePausedInput {
+  id: string
+  paused: boolean
+  renotify?: null | boolean
 }
 
 export interface UpdateEscalationPolicyInput {
@@ -653,13 +951,25 @@ export interface UpdateEscalationPolicyInput {
   name?: null | string
   description?: null | string
   repeat?: null | number
+  maxNotifications?: null | number
+  ackTimeoutMinutes?: null | number
   stepIDs?: null | string[]
 }
 
 export interface UpdateEscalationPolicyStepInput {
   id: string
   delayMinutes?: null | number
+  offHoursDelayMinutes?: null | number
+  delayBusinessHoursID?: null | string
+  assignmentStrategy?: null | StepAssignmentStrategy
+  minSeverity?: null | AlertSeverity
+  lowUrgency?: null | boolean
+  conferenceBridge?: null | boolean
+  routingBusinessHoursID?: null | string
+  routingWebhookURL?: null | string
   targets?: null | TargetInput[]
+  inHoursTargets?: null | TargetInput[]
+  afterHoursTargets?: null | TargetInput[]
 }
 
 export interface SetFavoriteInput {
@@ -689,11 +999,24 @@ export interface Schedule {
   timeZone: string
   assignedTo: Target[]
   shifts: OnCallShift[]
+  coverageGaps: ScheduleCoverageGap[]
+  onCallUsers: ScheduleOnCallUser[]
   targets: ScheduleTarget[]
   target?: null | ScheduleTarget
   isFavorite: boolean
   temporarySchedules: TemporarySchedule[]
+  fixedShifts: OnCallShift[]
   onCallNotificationRules: OnCallNotificationRule[]
+  handoffNotification?: null | ScheduleHandoffNotification
+}
+
+export interface ScheduleHandoffNotification {
+  leadTimeMinutes: number
+}
+
+export interface SetScheduleHandoffNotificationInput {
+  scheduleID: string
+  leadTimeMinutes?: null | number
 }
 
 export interface SetScheduleOnCallNotificationRulesInput {
@@ -715,6 +1038,17 @@ export interface OnCallNotificationRule {
   weekdayFilter?: null | WeekdayFilter
 }
 
+export interface ScheduleOnCallUser {
+  userID: string
+  userName: string
+  priority: number
+}
+
+export interface ScheduleCoverageGap {
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
 export interface OnCallShift {
   userID: string
   user?: null | User
@@ -736,6 +1070,7 @@ export interface ScheduleRule {
   end: ClockTime
   weekdayFilter: WeekdayFilter
   target: Target
+  priority: number
 }
 
 export interface RotationConnection {
@@ -752,6 +1087,7 @@ export interface CreateRotationInput {
   type: RotationType
   shiftLength?: null | number
   userIDs?: null | string[]
+  userWeights?: null | number[]
 }
 
 export interface Rotation {
@@ -766,14 +1102,55 @@ export interface Rotation {
   activeUserIndex: number
   userIDs: string[]
   users: User[]
+  userWeights: number[]
   nextHandoffTimes: ISOTimestamp[]
+  shadows: RotationShadow[]
+  fairness: RotationFairnessStat[]
+}
+
+export interface RotationFairnessInput {
+  start?: null | ISOTimestamp
+  end?: null | ISOTimestamp
+  bucketDuration?: null | ISODuration
+}
+
+export interface RotationFairnessStat {
+  userID: string
+  user?: null | User
+  start: ISOTimestamp
+  end: ISOTimestamp
+  onCallHours: number
+  pages: number
 }
 
-export type RotationType = 'monthly' | 'weekly' | 'daily' | 'hourly'
+export interface RotationShadow {
+  userID: string
+  user?: null | User
+  expiresAt: ISOTimestamp
+}
+
+export interface SetRotationShadowInput {
+  rotationID: string
+  userID: string
+  expiresAt: ISOTimestamp
+}
+
+export interface DeleteRotationShadowInput {
+  rotationID: string
+  userID: string
+}
+
+export type RotationType =
+  | 'monthly'
+  | 'weekly'
+  | 'daily'
+  | 'hourly'
+  | 'fixed_hours'
 
 export interface UpdateAlertsInput {
   alertIDs: number[]
   newStatus: AlertStatus
+  closeReason?: null | AlertCloseReason
 }
 
 export interface UpdateRotationInput {
@@ -786,6 +1163,8 @@ export interface UpdateRotationInput {
   shiftLength?: null | number
   activeUserIndex?: null | number
   userIDs?: null | string[]
+  preserveActiveUser?: null | boolean
+  userWeights?: null | number[]
 }
 
 export interface RotationSearchOptions {
@@ -815,6 +1194,31 @@ export interface EscalationPolicySearchOptions {
   favoritesFirst?: null | boolean
 }
 
+export interface UserOnCallShiftsInput {
+  userID: string
+  start?: null | ISOTimestamp
+  end?: null | ISOTimestamp
+  first?: null | number
+  after?: null | string
+}
+
+export interface UserOnCallShiftConnection {
+  nodes: UserOnCallShift[]
+  pageInfo: PageInfo
+  timeZone: string
+}
+
+export interface UserOnCallShift {
+  scheduleID: string
+  schedule?: null | Schedule
+  start: ISOTimestamp
+  end: ISOTimestamp
+  localStart: string
+  localEnd: string
+  truncated: boolean
+  services: Service[]
+}
+
 export interface ScheduleSearchOptions {
   first?: null | number
   after?: null | string
@@ -846,6 +1250,7 @@ export interface UserSearchOptions {
 
 export interface AlertSearchOptions {
   filterByStatus?: null | AlertStatus[]
+  filterBySeverity?: null | AlertSeverity[]
   filterByServiceID?: null | string[]
   search?: null | string
   first?: null | number
@@ -858,8 +1263,12 @@ export interface AlertSearchOptions {
   notCreatedBefore?: null | ISOTimestamp
   closedBefore?: null | ISOTimestamp
   notClosedBefore?: null | ISOTimestamp
+  escalationExhausted?: null | boolean
+  correlationKey?: null | string
 }
 
+export type AlertSeverity = 'info' | 'warning' | 'critical' | 'fatal'
+
 export type AlertSearchSort = 'statusID' | 'dateID' | 'dateIDReverse'
 
 export type ISODuration = string
@@ -884,16 +1293,108 @@ export interface Alert {
   id: string
   alertID: number
   status: AlertStatus
+  severity: AlertSeverity
   summary: string
   details: string
   createdAt: ISOTimestamp
   serviceID: string
   service?: null | Service
+  occurrences: number
+  lastOccurrence: ISOTimestamp
+  occurrenceSummary: string
+  meta: AlertMetadata[]
+  metaValue: string
+  runbookURL: string
   state?: null | AlertState
   recentEvents: AlertLogEntryConnection
   pendingNotifications: AlertPendingNotification[]
+  notifications: AlertNotification[]
   metrics?: null | AlertMetric
   noiseReason?: null | string
+  feedback?: null | AlertFeedback
+  source: AlertSource
+  integrationKey?: null | IntegrationKey
+  incident?: null | Incident
+  closeReason?: null | AlertCloseReason
+  assignee?: null | User
+  correlationKey: string
+  correlatedAlerts: Alert[]
+}
+
+export interface Incident {
+  id: string
+  summary: string
+  groupedAck: boolean
+  correlationRule?: null | IncidentCorrelationRule
+  createdAt: ISOTimestamp
+  alerts: Alert[]
+}
+
+export interface IncidentCorrelationRule {
+  id: string
+  name: string
+  key: string
+  windowMinutes: number
+  groupedAck: boolean
+}
+
+export interface CreateIncidentInput {
+  summary: string
+  alertIDs: number[]
+  groupedAck?: null | boolean
+}
+
+export interface UpdateIncidentInput {
+  id: string
+  summary?: null | string
+  groupedAck?: null | boolean
+  addAlertIDs?: null | number[]
+  removeAlertIDs?: null | number[]
+}
+
+export interface CreateIncidentCorrelationRuleInput {
+  name: string
+  key: string
+  windowMinutes: number
+  groupedAck?: null | boolean
+}
+
+export type AlertSource = 'email' | 'generic' | 'grafana' | 'manual' | 'opsgenie' | 'prometheusAlertmanager' | 'site24x7'
+
+export type AlertCloseReason = 'resolved' | 'falsePositive' | 'duplicate' | 'wontfix'
+
+export type AlertFeedbackValue = 'actionable' | 'noise'
+
+export interface AlertFeedback {
+  value: AlertFeedbackValue
+  noiseReason: string
+  user?: null | User
+  updatedAt: ISOTimestamp
+}
+
+export interface AlertFeedbackStatsInput {
+  serviceIDs: string[]
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface AlertFeedbackStats {
+  serviceID: string
+  service?: null | Service
+  alertCount: number
+  actionableCount: number
+  noiseCount: number
+  noiseRatio: number
+}
+
+export interface AlertMetadata {
+  key: string
+  value: string
+}
+
+export interface AlertMetadataInput {
+  key: string
+  value: string
 }
 
 export interface AlertMetric {
@@ -907,11 +1408,87 @@ export interface AlertPendingNotification {
   destination: string
 }
 
+export interface AlertNotification {
+  id: string
+  createdAt: ISOTimestamp
+  sentAt?: null | ISOTimestamp
+  type: string
+  userID?: null | string
+  userName?: null | string
+  destination: string
+  channel: string
+  status: AlertNotificationStatus
+  statusDetails: string
+  providerID?: null | string
+}
+
+export type AlertNotificationStatus = 'queued' | 'bundled' | 'sent' | 'delivered' | 'failed'
+
 export interface AlertRecentEventsOptions {
   limit?: null | number
   after?: null | string
 }
 
+export interface AuditLogSearchOptions {
+  filterByActorID?: null | string
+  filterByEntityType?: null | string
+  filterByEntityID?: null | string
+  createdBefore?: null | ISOTimestamp
+  notCreatedBefore?: null | ISOTimestamp
+  first?: null | number
+  after?: null | string
+}
+
+export interface AuditLogConnection {
+  nodes: AuditLogEntry[]
+  pageInfo: PageInfo
+}
+
+export interface AuditLogEntry {
+  id: number
+  timestamp: ISOTimestamp
+  actorID?: null | string
+  actor?: null | User
+  actorSource: string
+  action: string
+  entityType: string
+  entityID: string
+  before: string
+  after: string
+  changes: AuditLogChange[]
+  error: string
+}
+
+export interface AuditLogChange {
+  field: string
+  before: string
+  after: string
+}
+
+export interface ArchivedAlertSearchOptions {
+  filterByServiceID?: null | string[]
+  search?: null | string
+  first?: null | number
+  after?: null | string
+}
+
+export interface ArchivedAlertConnection {
+  nodes: ArchivedAlert[]
+  pageInfo: PageInfo
+}
+
+export interface ArchivedAlert {
+  alertID: number
+  serviceID: string
+  serviceName: string
+  summary: string
+  details: string
+  severity: AlertSeverity
+  createdAt: ISOTimestamp
+  archivedAt: ISOTimestamp
+  logs: AlertLogEntry[]
+}
+
 export interface AlertLogEntryConnection {
   nodes: AlertLogEntry[]
   pageInfo: PageInfo
@@ -922,6 +1499,13 @@ export interface AlertLogEntry {
   timestamp: ISOTimestamp
   message: string
   state?: null | NotificationState
+  note?: null | AlertLogNote
+}
+
+export interface AlertLogNote {
+  text: string
+  editedAt?: null | ISOTimestamp
+  editable: boolean
 }
 
 export interface NotificationState {
@@ -936,6 +1520,8 @@ export interface AlertState {
   lastEscalation: ISOTimestamp
   stepNumber: number
   repeatCount: number
+  escalationExhausted: boolean
+  snoozeUntil?: null | ISOTimestamp
 }
 
 export interface Service {
@@ -946,38 +1532,131 @@ export interface Service {
   escalationPolicy?: null | EscalationPolicy
   isFavorite: boolean
   maintenanceExpiresAt?: null | ISOTimestamp
+  paused: boolean
+  pausedAt?: null | ISOTimestamp
+  pausedBy?: null | User
+  notificationTemplate: string
+  runbookURL: string
+  enrichmentURL: string
+  notificationDelayMinutes: number
+  requireCloseReason: boolean
+  timeZone: string
   onCallUsers: ServiceOnCallUser[]
   integrationKeys: IntegrationKey[]
   labels: Label[]
   heartbeatMonitors: HeartbeatMonitor[]
+  maintenanceWindows: MaintenanceWindow[]
   notices: Notice[]
+  watchers: ServiceWatcher[]
+}
+
+export interface ServiceWatcher {
+  userID: string
+  user?: null | User
+  createdAt: ISOTimestamp
+}
+
+export interface SetServiceWatcherInput {
+  serviceID: string
+  userID: string
+  contactMethodID: string
+}
+
+export interface DeleteServiceWatcherInput {
+  serviceID: string
+  userID: string
+}
+
+export interface MaintenanceWindow {
+  id: string
+  serviceID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  description: string
+}
+
+export interface CreateMaintenanceWindowInput {
+  serviceID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  description?: null | string
 }
 
 export interface CreateIntegrationKeyInput {
   serviceID?: null | string
   type: IntegrationKeyType
   name: string
+  routing?: null | IntegrationKeyRoutingInput
+  alertRateLimit?: null | number
+  dedupWindowMinutes?: null | number
+  fieldMapping?: null | IntegrationKeyFieldMappingInput
+  defaultLabels?: null | IntegrationKeyLabelInput[]
+}
+
+export interface SetIntegrationKeyAlertRateLimitInput {
+  id: string
+  alertRateLimit?: null | number
+}
+
+export interface SetIntegrationKeyDedupWindowInput {
+  id: string
+  dedupWindowMinutes?: null | number
+}
+
+export interface SetIntegrationKeyDefaultLabelsInput {
+  id: string
+  defaultLabels: IntegrationKeyLabelInput[]
+}
+
+export interface IntegrationKeyLabelInput {
+  key: string
+  value: string
+}
+
+export interface SetIntegrationKeyFieldMappingInput {
+  id: string
+  fieldMapping?: null | IntegrationKeyFieldMappingInput
+}
+
+export interface IntegrationKeyFieldMappingInput {
+  summary?: null | string
+  details?: null | string
+  dedup?: null | string
+  severity?: null | string
+  correlationKey?: null | string
+}
+
+export interface IntegrationKeyRoutingInput {
+  labelKey: string
+  field: string
 }
 
 export interface CreateHeartbeatMonitorInput {
   serviceID?: null | string
   name: string
   timeoutMinutes: number
+  warningMinutes?: null | number
 }
 
 export interface UpdateHeartbeatMonitorInput {
   id: string
   name?: null | string
   timeoutMinutes?: null | number
+  warningMinutes?: null | number
 }
 
-export type HeartbeatMonitorState = 'inactive' | 'healthy' | 'unhealthy'
+export type HeartbeatMonitorState =
+  | 'inactive'
+  | 'healthy'
+  | 'warning'
+  | 'unhealthy'
 
 export interface HeartbeatMonitor {
   id: string
   serviceID: string
   name: string
   timeoutMinutes: number
+  warningMinutes: number
   lastState: HeartbeatMonitorState
   lastHeartbeat?: null | ISOTimestamp
   href: string
@@ -994,6 +1673,25 @@ export interface IntegrationKey {
   type: IntegrationKeyType
   name: string
   href: string
+  routing?: null | IntegrationKeyRouting
+  fieldMapping?: null | IntegrationKeyFieldMapping
+  alertRateLimit?: null | number
+  droppedAlertCount: number
+  dedupWindowMinutes?: null | number
+  defaultLabels: Label[]
+}
+
+export interface IntegrationKeyRouting {
+  labelKey: string
+  field: string
+}
+
+export interface IntegrationKeyFieldMapping {
+  summary: string
+  details: string
+  dedup: string
+  severity: string
+  correlationKey: string
 }
 
 export type IntegrationKeyType =
@@ -1002,6 +1700,7 @@ export type IntegrationKeyType =
   | 'site24x7'
   | 'prometheusAlertmanager'
   | 'email'
+  | 'opsgenie'
 
 export interface ServiceOnCallUser {
   userID: string
@@ -1009,11 +1708,35 @@ export interface ServiceOnCallUser {
   stepNumber: number
 }
 
+export interface ServiceOnCall {
+  serviceID: string
+  serviceName: string
+  users: OnCallUser[]
+}
+
+export interface OnCallUser {
+  userID: string
+  userName: string
+  shadow: boolean
+}
+
+export interface MisconfiguredService {
+  serviceID: string
+  serviceName: string
+  escalationPolicyID: string
+  escalationPolicyName: string
+  reason: ServiceMisconfiguration
+}
+
+export type ServiceMisconfiguration = 'no_steps' | 'no_targets' | 'no_on_call'
+
 export interface EscalationPolicy {
   id: string
   name: string
   description: string
   repeat: number
+  maxNotifications: number
+  ackTimeoutMinutes: number
   isFavorite: boolean
   assignedTo: Target[]
   steps: EscalationPolicyStep[]
@@ -1047,6 +1770,8 @@ export type TargetType =
   | 'schedule'
   | 'user'
   | 'chanWebhook'
+  | 'msTeamsChannel'
+  | 'pagerDuty'
   | 'integrationKey'
   | 'userOverride'
   | 'notificationRule'
@@ -1054,6 +1779,7 @@ export type TargetType =
   | 'heartbeatMonitor'
   | 'calendarSubscription'
   | 'userSession'
+  | 'escalationPolicyStep'
 
 export interface ServiceConnection {
   nodes: Service[]
@@ -1080,6 +1806,8 @@ export interface UpdateUserInput {
   name?: null | string
   email?: null | string
   role?: null | UserRole
+  timeZone?: null | string
+  maxNotificationsPerHour?: null | number
   statusUpdateContactMethodID?: null | string
 }
 
@@ -1103,9 +1831,55 @@ export interface User {
   authSubjects: AuthSubject[]
   sessions: UserSession[]
   onCallSteps: EscalationPolicyStep[]
+  urgencyWindow?: null | UserUrgencyWindow
+  doNotDisturb?: null | UserDoNotDisturb
+  timeZone?: null | string
+  maxNotificationsPerHour?: null | number
+  labelGrants: LabelSelector[]
   isFavorite: boolean
 }
 
+export interface LabelSelector {
+  key: string
+  value: string
+}
+
+export interface LabelSelectorInput {
+  key: string
+  value: string
+}
+
+export interface SetUserLabelGrantsInput {
+  userID: string
+  grants: LabelSelectorInput[]
+}
+
+export interface UserDoNotDisturb {
+  expiresAt?: null | ISOTimestamp
+}
+
+export interface SetUserDoNotDisturbInput {
+  userID: string
+  enabled: boolean
+  expiresAt?: null | ISOTimestamp
+}
+
+export interface UserUrgencyWindow {
+  start: ClockTime
+  end: ClockTime
+  allowHighUrgency: boolean
+}
+
+export interface SetUserUrgencyWindowInput {
+  userID: string
+  window?: null | UserUrgencyWindowInput
+}
+
+export interface UserUrgencyWindowInput {
+  start: ClockTime
+  end: ClockTime
+}
+
 export interface UserSession {
   id: string
   current: boolean
@@ -1119,6 +1893,17 @@ export interface UserNotificationRule {
   delayMinutes: number
   contactMethodID: string
   contactMethod?: null | UserContactMethod
+  quietHours?: null | UserNotificationRuleQuietHours
+  urgency?: null | UserNotificationRuleUrgency
+  position: number
+  stopOnSuccess: boolean
+}
+
+export type UserNotificationRuleUrgency = 'high' | 'low'
+
+export interface UserNotificationRuleQuietHours {
+  start: ClockTime
+  end: ClockTime
 }
 
 export type ContactMethodType =
@@ -1127,6 +1912,7 @@ export type ContactMethodType =
   | 'EMAIL'
   | 'WEBHOOK'
   | 'SLACK_DM'
+  | 'TELEGRAM'
 
 export interface UserContactMethod {
   id: string
@@ -1140,6 +1926,8 @@ export interface UserContactMethod {
   lastTestMessageState?: null | NotificationState
   lastVerifyMessageState?: null | NotificationState
   statusUpdates: StatusUpdateState
+  consecutiveFailures: number
+  autoDisabledAt?: null | ISOTimestamp
 }
 
 export type StatusUpdateState =
@@ -1160,6 +1948,25 @@ export interface CreateUserNotificationRuleInput {
   userID?: null | string
   contactMethodID?: null | string
   delayMinutes: number
+  quietHours?: null | UserNotificationRuleQuietHoursInput
+  urgency?: null | UserNotificationRuleUrgency
+  stopOnSuccess?: null | boolean
+}
+
+export interface UpdateUserNotificationRuleInput {
+  id: string
+  stopOnSuccess?: null | boolean
+}
+
+export interface SetUserNotificationRuleOrderInput {
+  userID: string
+  ruleIDs: string[]
+}
+
+export interface UserNotificationRuleQuietHoursInput {
+  start: ClockTime
+  end: ClockTime
+  allowHighUrgency?: null | boolean
 }
 
 export interface UpdateUserContactMethodInput {
@@ -1171,6 +1978,7 @@ export interface UpdateUserContactMethodInput {
 
 export interface SendContactMethodVerificationInput {
   contactMethodID: string
+  voice?: null | boolean
 }
 
 export interface VerifyContactMethodInput {
@@ -1202,10 +2010,19 @@ type ConfigID =
   | 'General.DisableSMSLinks'
   | 'General.DisableLabelCreation'
   | 'General.DisableCalendarSubscriptions'
+  | 'General.RestrictServiceManagement'
+  | 'General.GraphQLMaxComplexity'
+  | 'General.ContactMethodFailureLimit'
+  | 'General.ContactMethodDisableMinutes'
+  | 'General.DefaultTimeZone'
+  | 'General.MaxUserNotificationsPerHour'
   | 'Maintenance.AlertCleanupDays'
+  | 'Maintenance.AlertArchiveDays'
   | 'Maintenance.AlertAutoCloseDays'
   | 'Maintenance.APIKeyExpireDays'
   | 'Maintenance.ScheduleCleanupDays'
+  | 'Maintenance.GQLAPIKeyExpireWarningDays'
+  | 'Maintenance.GQLAPIKeyUsageHistoryRows'
   | 'Auth.RefererURLs'
   | 'Auth.DisableBasic'
   | 'GitHub.Enable'
@@ -1234,6 +2051,7 @@ type ConfigID =
   | 'Slack.AccessToken'
   | 'Slack.SigningSecret'
   | 'Slack.InteractiveMessages'
+  | 'Slack.SlashCommands'
   | 'Twilio.Enable'
   | 'Twilio.VoiceName'
   | 'Twilio.VoiceLanguage'
@@ -1245,6 +2063,9 @@ type ConfigID =
   | 'Twilio.DisableTwoWaySMS'
   | 'Twilio.SMSCarrierLookup'
   | 'Twilio.SMSFromNumberOverride'
+  | 'Twilio.SMSFromNumberRegion'
+  | 'Twilio.SMSMaxLength'
+  | 'Twilio.SMSAllowMultipart'
   | 'SMTP.Enable'
   | 'SMTP.From'
   | 'SMTP.Address'
@@ -1254,5 +2075,15 @@ type ConfigID =
   | 'SMTP.Password'
   | 'Webhook.Enable'
   | 'Webhook.AllowedURLs'
+  | 'Webhook.RetryMaxAttempts'
+  | 'Webhook.RetryBaseDelaySeconds'
+  | 'Webhook.RetryMaxDelaySeconds'
+  | 'MSTeams.Enable'
+  | 'PagerDuty.Enable'
+  | 'PagerDuty.ResolveOnClose'
+  | 'Telegram.Enable'
+  | 'Telegram.BotUsername'
+  | 'Telegram.BotToken'
+  | 'Telegram.WebhookSecret'
   | 'Feedback.Enable'
   | 'Feedback.OverrideURL'